@@ -0,0 +1,167 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// Benchmarks template matching on this machine to pick a maxConcurrentCV setting
+func main() {
+	framePath := flag.String("frame", "", "Screenshot to match templates against")
+	templatesPath := flag.String("templates", "", "Template image, or directory of template images")
+	concurrency := flag.String("concurrency", "", "Comma-separated concurrency levels (default: 1,2,4,... up to one per CPU)")
+	duration := flag.Duration("duration", 5*time.Second, "Time to run each concurrency level")
+	iterations := flag.Int("iterations", 0, "Matches per level instead of a duration (0 = use -duration)")
+	method := flag.String("method", "ssd", "Match method: sad, ssd or ncc")
+	flag.Parse()
+
+	if *framePath == "" || *templatesPath == "" {
+		fmt.Println("Usage:")
+		fmt.Println("  bench_cv -frame <screenshot.png> -templates <file or directory> [-concurrency 1,2,4,8] [-duration 5s]")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  bench_cv -frame ./debug/home.png -templates ./templates/home")
+		fmt.Println("  bench_cv -frame ./debug/home.png -templates ./templates/home/shop.png -iterations 200")
+		os.Exit(1)
+	}
+
+	frame, err := loadImage(*framePath)
+	if err != nil {
+		log.Fatalf("Failed to load frame: %v", err)
+	}
+	templates, err := loadTemplates(*templatesPath)
+	if err != nil {
+		log.Fatalf("Failed to load templates: %v", err)
+	}
+
+	config := cv.DefaultMatchConfig()
+	switch strings.ToLower(*method) {
+	case "sad":
+		config.Method = cv.MatchMethodSAD
+	case "ssd":
+		config.Method = cv.MatchMethodSSD
+	case "ncc":
+		config.Method = cv.MatchMethodNCC
+	default:
+		log.Fatalf("Unknown match method '%s' (expected sad, ssd or ncc)", *method)
+	}
+
+	levels, err := parseLevels(*concurrency)
+	if err != nil {
+		log.Fatalf("Invalid -concurrency: %v", err)
+	}
+
+	bounds := frame.Bounds()
+	fmt.Printf("Frame: %s (%dx%d), %d template(s), method %s\n\n",
+		*framePath, bounds.Dx(), bounds.Dy(), len(templates), strings.ToUpper(*method))
+
+	result, err := cv.Benchmark(cv.BenchmarkOptions{
+		Frame:       frame,
+		Templates:   templates,
+		Config:      config,
+		Concurrency: levels,
+		Duration:    *duration,
+		Iterations:  *iterations,
+	})
+	if err != nil {
+		log.Fatalf("Benchmark failed: %v", err)
+	}
+
+	fmt.Printf("%-12s %10s %12s %10s %10s %10s %10s\n", "Concurrency", "Matches", "Matches/sec", "p50", "p90", "p99", "Max")
+	for _, level := range result.Levels {
+		fmt.Printf("%-12d %10d %12.1f %10s %10s %10s %10s\n",
+			level.Concurrency, level.Matches, level.MatchesPerSec,
+			roundLatency(level.P50), roundLatency(level.P90), roundLatency(level.P99), roundLatency(level.Max))
+	}
+
+	if suggested := result.SuggestedConcurrency(); suggested > 0 {
+		fmt.Printf("\nSuggested maxConcurrentCV: %d (lowest level within 5%% of peak throughput)\n", suggested)
+	}
+}
+
+// loadTemplates loads one template image, or every image in a directory keyed by file name
+func loadTemplates(path string) (map[string]*image.RGBA, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{path}
+	if info.IsDir() {
+		paths = paths[:0]
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() || (ext != ".png" && ext != ".jpg" && ext != ".jpeg") {
+				continue
+			}
+			paths = append(paths, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	templates := make(map[string]*image.RGBA, len(paths))
+	for _, p := range paths {
+		img, err := loadImage(p)
+		if err != nil {
+			return nil, err
+		}
+		templates[filepath.Base(p)] = img
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no template images in %s", path)
+	}
+	return templates, nil
+}
+
+func loadImage(path string) (*image.RGBA, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba, nil
+	}
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba, nil
+}
+
+func parseLevels(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	levels := make([]int, 0)
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("'%s' is not a positive number", part)
+		}
+		levels = append(levels, n)
+	}
+	return levels, nil
+}
+
+func roundLatency(d time.Duration) string {
+	return d.Round(10 * time.Microsecond).String()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/internal/api"
+	"jordanella.com/pocket-tcg-go/internal/bot"
+	"jordanella.com/pocket-tcg-go/internal/coordinator"
+	"jordanella.com/pocket-tcg-go/internal/database"
+	"jordanella.com/pocket-tcg-go/internal/emulator"
+	"jordanella.com/pocket-tcg-go/internal/metrics"
+	"jordanella.com/pocket-tcg-go/internal/notify"
+	"jordanella.com/pocket-tcg-go/pkg/templates"
+)
+
+// runHeadless runs the orchestrator without a window, controlled through the HTTP API.
+// It uses the same working-directory layout as the GUI (bot.db, pools/, routines/, templates/).
+func runHeadless(cfg *bot.Config) error {
+	if cfg.APIKey == "" {
+		return fmt.Errorf("headless mode requires apiKey to be set in Settings.ini")
+	}
+
+	// Registries
+	templatesPath := filepath.Join(".", "templates")
+	templateRegistry := templates.NewTemplateRegistry(templatesPath)
+	if err := templateRegistry.LoadFromDirectory(filepath.Join(templatesPath, "registry")); err != nil {
+		log.Printf("Warning: Failed to load template registry: %v", err)
+	}
+	routineRegistry := actions.NewRoutineRegistry(filepath.Join(".", "routines")).WithTemplateRegistry(templateRegistry)
+
+	// Database
+	db, err := database.Open("bot.db")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	if err := db.RunMigrations(); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+
+	// Account pools
+	poolManager := accountpool.NewPoolManager("pools", db.Conn(), "account_xmls")
+	if err := poolManager.DiscoverPools(); err != nil {
+		log.Printf("Warning: Failed to discover pools: %v", err)
+	}
+	poolManager.StartRefreshScheduler()
+	defer poolManager.StopRefreshScheduler()
+	poolManager.StartStatsSampler(time.Duration(cfg.PoolStatsSampleSeconds) * time.Second)
+	defer poolManager.StopStatsSampler()
+
+	// Orchestrator
+	adbPath := cfg.ADB().Path
+	if adbPath == "" {
+		adbPath = "dummy"
+	}
+	if err := cfg.ValidateADBPaths(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	emulatorManager := emulator.NewManager(cfg.FolderPath, adbPath)
+	emulatorManager.SetInstanceADBPaths(cfg.ResolvedInstanceADBPaths())
+	orchestrator := bot.NewOrchestrator(cfg, templateRegistry, routineRegistry, emulatorManager, poolManager, db.Conn())
+	defer orchestrator.Shutdown()
+	if err := orchestrator.LoadGroupDefinitionsFromDisk(); err != nil {
+		log.Printf("Warning: Failed to load group definitions: %v", err)
+	}
+	if cfg.DefaultPool != "" {
+		if err := orchestrator.SetDefaultPool(cfg.DefaultPool); err != nil {
+			log.Printf("Warning: Failed to set default pool: %v", err)
+		}
+	}
+
+	// Session report (deferred after the database, so it is written while the database is open)
+	defer func() {
+		if !orchestrator.HasSessionActivity() {
+			return
+		}
+		if path, err := orchestrator.WriteSessionReport(bot.DefaultReportsDir); err != nil {
+			log.Printf("Warning: Failed to write session report: %v", err)
+		} else {
+			log.Printf("Session report written to %s", path)
+		}
+	}()
+
+	// Check injected accounts were loaded against their stored identity
+	orchestrator.SetAccountVerifier(coordinator.NewAccountVerifier(db).Verify)
+
+	// God pack preservation (wait for in-flight archives on shutdown)
+	if cfg.GodPackPreserve {
+		preserver := coordinator.NewGodPackPreserver(cfg, poolManager)
+		orchestrator.SetGodPackHook(preserver.Preserve)
+		defer preserver.Wait()
+	}
+
+	// Correct accounts' pack and hourglass counts from the game's save once they are loaded
+	if cfg.SyncSaveCounts {
+		orchestrator.SetAccountLoadedHook(coordinator.NewSaveCountSyncer(cfg, db).Sync)
+	}
+
+	// Notifications
+	if cfg.NotifyWebhookURL != "" {
+		eventTypes, err := notify.ParseEventTypes(cfg.NotifyEvents)
+		if err != nil {
+			log.Printf("Warning: Notifications disabled: %v", err)
+		} else {
+			dispatcher := notify.NewDispatcher(notify.NewDiscordWebhook(cfg.NotifyWebhookURL, cfg.NotifyUserID), cfg.NotifyMaxPerMinute)
+			dispatcher.Subscribe(orchestrator.GetEventBus(), eventTypes)
+			defer dispatcher.Stop()
+		}
+	}
+
+	// Servers
+	apiServer := api.NewServer(cfg.APIPort, cfg.APIKey, orchestrator, poolManager)
+	if err := apiServer.Start(); err != nil {
+		return err
+	}
+
+	var metricsServer *metrics.Server
+	if cfg.MetricsEnabled {
+		metricsServer = metrics.NewServer(cfg.MetricsPort,
+			metrics.NewOrchestratorCollector(orchestrator),
+			metrics.NewPoolCollector(poolManager),
+			metrics.NewDatabaseCollector(db),
+			metrics.NewCVCollector(),
+		)
+		if err := metricsServer.Start(); err != nil {
+			log.Printf("Warning: Failed to start metrics server: %v", err)
+			metricsServer = nil
+		}
+	}
+
+	log.Printf("Running headless; press Ctrl+C to stop")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+
+	log.Printf("Shutting down...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	apiServer.Shutdown(ctx)
+	if metricsServer != nil {
+		metricsServer.Shutdown(ctx)
+	}
+
+	for _, group := range orchestrator.ListActiveGroups() {
+		if !group.IsRunning() {
+			continue
+		}
+		if err := orchestrator.StopGroup(group.Name); err != nil {
+			log.Printf("Warning: Failed to stop group '%s': %v", group.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -1,14 +1,52 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"fyne.io/fyne/v2/app"
 	"jordanella.com/pocket-tcg-go/internal/config"
+	"jordanella.com/pocket-tcg-go/internal/diagnostics"
 	"jordanella.com/pocket-tcg-go/internal/gui"
 )
 
 func main() {
+	headless := flag.Bool("headless", false, "Run without a window, controlled through the HTTP API")
+	check := flag.Bool("check", false, "Run the system checks, print the results and exit (non-zero if any failed)")
+	flag.Parse()
+
+	if *check {
+		cfg, err := config.LoadFromINI("Settings.ini", 1)
+		if err != nil {
+			log.Printf("Warning: Failed to load config: %v", err)
+			cfg = config.NewDefaultConfig()
+		}
+		results := diagnostics.RunAll(diagnostics.DefaultEnvironment(cfg.ADBPath, cfg.FolderPath))
+		for _, result := range results {
+			fmt.Printf("[%s] %s: %s\n", result.Status, result.Name, result.Message)
+			if result.Fix != "" {
+				fmt.Printf("       Fix: %s\n", result.Fix)
+			}
+		}
+		if !diagnostics.AllPassed(results) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *headless {
+		cfg, err := config.LoadFromINI("Settings.ini", 1)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		if err := runHeadless(cfg); err != nil {
+			log.Fatalf("Headless mode failed: %v", err)
+		}
+		return
+	}
+
 	// Create Fyne application
 	myApp := app.NewWithID("com.jordanella.pocket-tcg-go")
 	myApp.Settings().SetTheme(&gui.BotTheme{})
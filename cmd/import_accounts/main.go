@@ -16,14 +16,18 @@ import (
 func main() {
 	// Command line flags
 	importDir := flag.String("dir", "", "Directory containing XML account files to import")
+	importArchive := flag.String("archive", "", "Zip of extracted app data folders (one per account) to import")
 	exportDir := flag.String("export", "", "Directory to export accounts to (exports all if specified)")
 	dbPath := flag.String("db", "accounts.db", "Path to database file")
+	findDuplicates := flag.Bool("duplicates", false, "List accounts sharing a friend code or device account")
 	flag.Parse()
 
-	if *importDir == "" && *exportDir == "" {
+	if *importDir == "" && *importArchive == "" && *exportDir == "" && !*findDuplicates {
 		fmt.Println("Usage:")
-		fmt.Println("  Import: import_accounts -dir <directory> [-db <database>]")
-		fmt.Println("  Export: import_accounts -export <directory> [-db <database>]")
+		fmt.Println("  Import:     import_accounts -dir <directory> [-db <database>]")
+		fmt.Println("  Archive:    import_accounts -archive <file.zip> [-db <database>]")
+		fmt.Println("  Export:     import_accounts -export <directory> [-db <database>]")
+		fmt.Println("  Duplicates: import_accounts -duplicates [-db <database>]")
 		fmt.Println()
 		fmt.Println("Examples:")
 		fmt.Println("  import_accounts -dir ./xml_accounts")
@@ -51,9 +55,39 @@ func main() {
 		performImport(db, *importDir)
 	}
 
+	if *importArchive != "" {
+		performArchiveImport(db, *importArchive)
+	}
+
 	if *exportDir != "" {
 		performExport(db, *exportDir)
 	}
+
+	if *findDuplicates {
+		listDuplicates(db)
+	}
+}
+
+func listDuplicates(db *sql.DB) {
+	fmt.Println("=== Duplicate Accounts ===")
+	fmt.Println()
+
+	groups, err := accounts.FindDuplicates(db)
+	if err != nil {
+		log.Fatalf("Duplicate check failed: %v", err)
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No duplicates found")
+		return
+	}
+
+	for _, group := range groups {
+		fmt.Printf("%s %s:\n", group.Reason, group.Value)
+		for i, id := range group.AccountIDs {
+			fmt.Printf("  - ID %d: %s\n", id, group.DeviceAccounts[i])
+		}
+	}
 }
 
 func performImport(db *sql.DB, directory string) {
@@ -76,6 +110,7 @@ func performImport(db *sql.DB, directory string) {
 	fmt.Printf("  Imported:        %d\n", result.Imported)
 	fmt.Printf("  Skipped:         %d (already in database)\n", result.Skipped)
 	fmt.Printf("  Failed:          %d\n", result.Failed)
+	fmt.Printf("  Suspected dupes: %d (imported, review to merge or delete)\n", len(result.SuspectedDuplicates))
 	fmt.Println()
 
 	if len(result.Errors) > 0 {
@@ -86,12 +121,50 @@ func performImport(db *sql.DB, directory string) {
 		fmt.Println()
 	}
 
+	if len(result.SuspectedDuplicates) > 0 {
+		fmt.Println("Suspected duplicates:")
+		for _, dup := range result.SuspectedDuplicates {
+			fmt.Printf("  - %s (ID %d) matches ID %d (%s) by %s\n",
+				dup.Filename, dup.ImportedID, dup.ExistingID, dup.ExistingAccount, dup.Reason)
+		}
+		fmt.Println()
+	}
+
 	if result.Imported > 0 {
 		fmt.Printf("✓ Successfully imported %d accounts\n", result.Imported)
 		fmt.Println("\nImported account IDs:", result.ImportedIDs)
 	}
 }
 
+func performArchiveImport(db *sql.DB, archivePath string) {
+	fmt.Printf("=== Importing Accounts from %s ===\n\n", archivePath)
+
+	result, err := accounts.ImportFromArchive(db, archivePath)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	for _, folder := range result.Folders {
+		switch folder.Status {
+		case accounts.ImportStatusFailed:
+			fmt.Printf("  ✗ %s: %s\n", folder.Folder, folder.Error)
+		case accounts.ImportStatusSkipped:
+			fmt.Printf("  - %s: %s already in database\n", folder.Folder, folder.DeviceAccount)
+		default:
+			fmt.Printf("  ✓ %s: %s (ID %d, username %q, friend code %q)\n",
+				folder.Folder, folder.DeviceAccount, folder.AccountID, folder.Username, folder.FriendCode)
+		}
+	}
+	fmt.Println()
+
+	fmt.Printf("Import Summary:\n")
+	fmt.Printf("  Folders:         %d\n", result.TotalFiles)
+	fmt.Printf("  Imported:        %d\n", result.Imported)
+	fmt.Printf("  Skipped:         %d (already in database)\n", result.Skipped)
+	fmt.Printf("  Failed:          %d\n", result.Failed)
+	fmt.Printf("  Suspected dupes: %d (imported, review to merge or delete)\n", len(result.SuspectedDuplicates))
+}
+
 func performExport(db *sql.DB, directory string) {
 	fmt.Printf("=== Exporting Accounts to %s ===\n\n", directory)
 
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/pkg/templates"
+)
+
+// Checks every routine without running it and exits non-zero if any routine has problems
+func main() {
+	routinesPath := flag.String("routines", "routines", "Routines folder")
+	templatesPath := flag.String("templates", "templates", "Templates folder (empty = skip template checks)")
+	jsonOutput := flag.Bool("json", false, "Print the report as JSON")
+	verbose := flag.Bool("v", false, "Show registry loading logs")
+	flag.Parse()
+
+	if _, err := os.Stat(*routinesPath); err != nil {
+		fmt.Println("Usage:")
+		fmt.Println("  lint_routines [-routines <folder>] [-templates <folder>] [-json]")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  lint_routines")
+		fmt.Println("  lint_routines -routines ./routines -templates ./templates -json > lint.json")
+		os.Exit(1)
+	}
+
+	// The registries log every load; keep the report readable unless asked
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	var templateRegistry actions.TemplateRegistryInterface
+	if *templatesPath != "" {
+		registry := templates.NewTemplateRegistry(*templatesPath)
+		if err := registry.LoadFromDirectory(filepath.Join(*templatesPath, "registry")); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to load template registry: %v\n", err)
+		}
+		templateRegistry = registry
+	}
+
+	routineRegistry := actions.NewRoutineRegistry(*routinesPath).WithTemplateRegistry(templateRegistry)
+	log.SetOutput(os.Stderr)
+
+	results := routineRegistry.Lint()
+	invalid := 0
+	for _, result := range results {
+		if !result.Valid {
+			invalid++
+		}
+	}
+
+	if *jsonOutput {
+		report := struct {
+			Routines []actions.RoutineLint `json:"routines"`
+			Total    int                   `json:"total"`
+			Invalid  int                   `json:"invalid"`
+		}{results, len(results), invalid}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		for _, result := range results {
+			if result.Valid {
+				fmt.Printf("✓ %s\n", result.Routine)
+				continue
+			}
+			fmt.Printf("✗ %s\n", result.Routine)
+			for _, issue := range result.Issues {
+				fmt.Printf("    %s: %s\n", issue.Kind, issue.Message)
+			}
+		}
+		fmt.Printf("\n%d routine(s) checked, %d invalid\n", len(results), invalid)
+	}
+
+	if invalid > 0 {
+		os.Exit(1)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"jordanella.com/pocket-tcg-go/internal/database"
+	"jordanella.com/pocket-tcg-go/internal/recovery"
 )
 
 func main() {
@@ -84,16 +85,15 @@ func seedAccount(db *database.DB, index int, numActivities, numPacks, numErrors
 		log.Printf("Failed to update resources: %v", err)
 	}
 
-	// Update account level and stats
+	// Update account level and stats (packs_opened is counted by the seeded pack openings)
 	level := rand.Intn(30) + 1
-	packsOpened := rand.Intn(50)
 	wonderPicks := rand.Intn(20)
 
 	_, err = db.Conn().Exec(`
 		UPDATE accounts
-		SET account_level = ?, packs_opened = ?, wonder_picks_done = ?
+		SET account_level = ?, wonder_picks_done = ?
 		WHERE id = ?
-	`, level, packsOpened, wonderPicks, account.ID)
+	`, level, wonderPicks, account.ID)
 	if err != nil {
 		log.Printf("Failed to update account stats: %v", err)
 	}
@@ -243,7 +243,10 @@ func seedPackOpenings(db *database.DB, accountID int, count int) {
 }
 
 func seedErrors(db *database.DB, accountID int, count int) {
-	errorTypes := []string{"popup", "stuck", "no_response", "communication", "timeout"}
+	errorTypes := []recovery.ErrorType{
+		recovery.ErrorPopup, recovery.ErrorStuck, recovery.ErrorNoResponse,
+		recovery.ErrorCommunication, recovery.ErrorTimeout,
+	}
 	severities := []string{"low", "medium", "high", "critical"}
 	templates := []string{"error_popup", "maintenance_screen", "connection_lost", "stuck_loading"}
 	actions := []string{"ClickButton", "SwipeUp", "TapCard", "WaitForScreen"}
@@ -261,7 +264,7 @@ func seedErrors(db *database.DB, accountID int, count int) {
 		errorID, err := db.LogError(
 			&accountID,
 			nil,
-			errorType,
+			string(errorType),
 			severity,
 			message,
 			&stackTrace,
@@ -0,0 +1,186 @@
+package accountpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AffinityPolicy decides what happens to an account pinned to an instance when a different
+// instance asks for an account
+type AffinityPolicy string
+
+const (
+	// AffinityFallback hands a pinned account to another instance when no other account is available (default)
+	AffinityFallback AffinityPolicy = "fallback"
+
+	// AffinityWait keeps a pinned account for its instance; other instances never get it
+	AffinityWait AffinityPolicy = "wait"
+)
+
+// NoInstance is passed to GetNextForInstance by callers that are not tied to an emulator instance
+const NoInstance = -1
+
+// InstanceAccountPool is implemented by pools that honor instance affinity
+type InstanceAccountPool interface {
+	// GetNextForInstance returns the next available account for the instance. Accounts pinned to
+	// the instance come first, then unpinned accounts, then (with AffinityFallback) accounts
+	// pinned to other instances.
+	GetNextForInstance(ctx context.Context, instanceID int) (*Account, error)
+}
+
+// GetNextForInstance gets the next account for instanceID from pool, honoring instance affinity
+// if the pool supports it
+func GetNextForInstance(ctx context.Context, pool AccountPool, instanceID int) (*Account, error) {
+	if instancePool, ok := pool.(InstanceAccountPool); ok {
+		return instancePool.GetNextForInstance(ctx, instanceID)
+	}
+	return pool.GetNext(ctx)
+}
+
+// pickForInstance returns the index of the account instanceID should get from candidates,
+// or -1 if none may be handed to it
+func pickForInstance(candidates []*Account, affinity map[string]int, policy AffinityPolicy, instanceID int) int {
+	unpinned, fallback := -1, -1
+	for i, account := range candidates {
+		pinned, hasAffinity := affinity[account.DeviceAccount]
+		switch {
+		case hasAffinity && pinned == instanceID:
+			return i
+		case !hasAffinity:
+			if unpinned < 0 {
+				unpinned = i
+			}
+		case policy != AffinityWait:
+			if fallback < 0 {
+				fallback = i
+			}
+		}
+	}
+
+	if unpinned >= 0 {
+		return unpinned
+	}
+	return fallback
+}
+
+// effectiveAffinityPolicy returns the pool's affinity policy (empty = AffinityFallback)
+func (p *UnifiedAccountPool) effectiveAffinityPolicy() AffinityPolicy {
+	if p.definition.Config.AffinityPolicy == "" {
+		return AffinityFallback
+	}
+	return AffinityPolicy(p.definition.Config.AffinityPolicy)
+}
+
+// SetAffinity pins an account (by device account) to an emulator instance at runtime.
+// It replaces any affinity from the pool definition until the pool is reopened.
+func (p *UnifiedAccountPool) SetAffinity(accountID string, instanceID int) error {
+	if instanceID < 0 {
+		return fmt.Errorf("invalid instance %d", instanceID)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.affinity[accountID] = instanceID
+	return nil
+}
+
+// ClearAffinity removes an account's instance affinity
+func (p *UnifiedAccountPool) ClearAffinity(accountID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.affinity, accountID)
+}
+
+// Affinity returns the instance an account is pinned to
+func (p *UnifiedAccountPool) Affinity(accountID string) (int, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	instanceID, exists := p.affinity[accountID]
+	return instanceID, exists
+}
+
+// GetNextForInstance implements InstanceAccountPool.GetNextForInstance
+func (p *UnifiedAccountPool) GetNextForInstance(ctx context.Context, instanceID int) (*Account, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+
+	if err := ctx.Err(); err != nil {
+		p.mu.Unlock()
+		return nil, err
+	}
+
+	// Take the queued accounts out to choose one, then put the rest back in order
+	candidates := withoutPackLimited(p.definition, p.drainAvailable())
+	orderForSelection(candidates, selectionStrategyOf(p.definition.Config), p.definition.Config.SelectionWeight, p.selectionCursor, p.rng)
+	prioritize(candidates, p.definition.Priority)
+
+	// Pick again when another process holds the picked account's lease
+	var account *Account
+	contended := make([]*Account, 0)
+	for account == nil {
+		picked := pickForInstance(candidates, p.affinity, p.effectiveAffinityPolicy(), instanceID)
+		if picked < 0 {
+			break
+		}
+		claimed, err := p.claimLease(candidates[picked])
+		if err != nil {
+			for _, candidate := range append(candidates, contended...) {
+				p.requeue(candidate)
+			}
+			p.mu.Unlock()
+			return nil, err
+		}
+		if claimed {
+			account = candidates[picked]
+		} else {
+			contended = append(contended, candidates[picked])
+		}
+		candidates = append(candidates[:picked], candidates[picked+1:]...)
+	}
+	for _, candidate := range append(candidates, contended...) {
+		p.requeue(candidate)
+	}
+
+	if account == nil {
+		p.mu.Unlock()
+		return nil, ErrNoAccountsAvailable
+	}
+
+	account.Status = AccountStatusInUse
+	now := time.Now()
+	account.AssignedAt = &now
+	stampClaimed(account, now)
+	p.selectionCursor = account.DeviceAccount
+	p.updateStats()
+	p.mu.Unlock()
+
+	// Ensure XML exists
+	if err := p.ensureXMLExists(account); err != nil {
+		return nil, fmt.Errorf("failed to ensure XML exists: %w", err)
+	}
+
+	return account, nil
+}
+
+// affinitySnapshot returns a copy of the pool's affinities and its policy
+func (p *UnifiedAccountPool) affinitySnapshot() (map[string]int, AffinityPolicy) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	affinity := make(map[string]int, len(p.affinity))
+	for accountID, instanceID := range p.affinity {
+		affinity[accountID] = instanceID
+	}
+	return affinity, p.effectiveAffinityPolicy()
+}
+
+// hasAffinity reports whether any account is pinned to an instance
+func (p *UnifiedAccountPool) hasAffinity() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.affinity) > 0
+}
@@ -0,0 +1,232 @@
+package accountpool
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HealthWeights controls how much each factor counts towards a pool's health score.
+// Weights are relative to each other; a weight of 0 ignores that factor.
+type HealthWeights struct {
+	Availability float64 // Share of accounts that can be assigned right now
+	Packs        float64 // Average pack count, relative to PackTarget
+	Reliability  float64 // Share of accounts that have not failed
+	Rested       float64 // Share of accounts not used within RestWindow
+
+	PackTarget int           // Average pack count that earns the full pack score
+	RestWindow time.Duration // Accounts used more recently than this count as not rested
+}
+
+// DefaultHealthWeights returns the weights used unless SetHealthWeights is called
+func DefaultHealthWeights() HealthWeights {
+	return HealthWeights{
+		Availability: 0.4,
+		Packs:        0.2,
+		Reliability:  0.25,
+		Rested:       0.15,
+		PackTarget:   20,
+		RestWindow:   12 * time.Hour,
+	}
+}
+
+// PoolHealth summarises how worthwhile a pool is to run.
+//
+// Each factor is a ratio between 0 and 1:
+//
+//	availability = available / total
+//	packs        = min(average pack count / PackTarget, 1)
+//	reliability  = 1 - (failed or banned accounts, or accounts with failures) / total
+//	rested       = accounts never used or last used before RestWindow / total
+//
+// Score is the weighted average of the factors scaled to 0-100. Empty pools score 0.
+type PoolHealth struct {
+	Name  string
+	Score float64 // 0 (unusable) to 100 (every factor at its best)
+	Error string  // Set when the pool could not be resolved; Score is 0
+
+	Total        int
+	Available    int
+	AveragePacks float64
+
+	Availability float64
+	Packs        float64
+	Reliability  float64
+	Rested       float64
+}
+
+// Grade returns a short label for the score, for badges
+func (h PoolHealth) Grade() string {
+	switch {
+	case h.Error != "":
+		return "Error"
+	case h.Score >= 75:
+		return "Healthy"
+	case h.Score >= 50:
+		return "Fair"
+	case h.Score >= 25:
+		return "Poor"
+	default:
+		return "Depleted"
+	}
+}
+
+// SetHealthWeights replaces the weights used by PoolHealth and RankPools
+func (pm *PoolManager) SetHealthWeights(weights HealthWeights) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.healthWeights = weights
+}
+
+// HealthWeights returns the weights used by PoolHealth and RankPools
+func (pm *PoolManager) HealthWeights() HealthWeights {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.healthWeights
+}
+
+// PoolHealth scores a pool from its resolved accounts. Open pools are scored with their runtime
+// statuses; other pools are resolved without creating a persistent instance, as TestPool does,
+// and scored with the pool status and failure count stored in the database.
+func (pm *PoolManager) PoolHealth(name string) (PoolHealth, error) {
+	pm.mu.RLock()
+	open := pm.instances[name] != nil
+	pm.mu.RUnlock()
+
+	accounts, err := pm.PeekAccounts(name)
+	if err != nil {
+		return PoolHealth{Name: name, Error: err.Error()}, err
+	}
+	if !open && pm.db != nil {
+		if err := loadStoredPoolState(pm.db, accounts); err != nil {
+			return PoolHealth{Name: name, Error: err.Error()}, err
+		}
+	}
+
+	health := scorePoolHealth(accounts, pm.HealthWeights(), time.Now())
+	health.Name = name
+	return health, nil
+}
+
+// RankPools scores every discovered pool and returns them best first.
+// Pools that fail to resolve are included last with Error set.
+func (pm *PoolManager) RankPools() []PoolHealth {
+	names := pm.ListPools()
+
+	ranked := make([]PoolHealth, 0, len(names))
+	for _, name := range names {
+		health, _ := pm.PoolHealth(name)
+		ranked = append(ranked, health)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if (ranked[i].Error == "") != (ranked[j].Error == "") {
+			return ranked[i].Error == ""
+		}
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	return ranked
+}
+
+// storedStateBatch is how many accounts loadStoredPoolState looks up per query, below
+// SQLite's default limit on query parameters
+const storedStateBatch = 500
+
+// loadStoredPoolState sets the accounts' status and failure count from their pool_status and
+// failure_count columns. Query pools only select the columns needed to serve accounts, so
+// resolved accounts start out available with no failures.
+func loadStoredPoolState(db *sql.DB, accounts []*Account) error {
+	byDeviceAccount := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		if account.DeviceAccount != "" {
+			byDeviceAccount[account.DeviceAccount] = account
+		}
+	}
+
+	deviceAccounts := make([]interface{}, 0, len(byDeviceAccount))
+	for deviceAccount := range byDeviceAccount {
+		deviceAccounts = append(deviceAccounts, deviceAccount)
+	}
+
+	for start := 0; start < len(deviceAccounts); start += storedStateBatch {
+		batch := deviceAccounts[start:min(start+storedStateBatch, len(deviceAccounts))]
+		query := `
+			SELECT device_account, COALESCE(pool_status, 'available'), COALESCE(failure_count, 0)
+			FROM accounts
+			WHERE device_account IN (?` + strings.Repeat(", ?", len(batch)-1) + `)`
+		rows, err := db.Query(query, batch...)
+		if err != nil {
+			return fmt.Errorf("failed to load pool status: %w", err)
+		}
+		for rows.Next() {
+			var deviceAccount, status string
+			var failures int
+			if err := rows.Scan(&deviceAccount, &status, &failures); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan pool status: %w", err)
+			}
+			if account := byDeviceAccount[deviceAccount]; account != nil {
+				account.Status = AccountStatus(status)
+				account.FailureCount = failures
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("failed to load pool status: %w", err)
+		}
+	}
+	return nil
+}
+
+// scorePoolHealth applies the PoolHealth formula to a set of accounts
+func scorePoolHealth(accounts []*Account, weights HealthWeights, now time.Time) PoolHealth {
+	health := PoolHealth{Total: len(accounts)}
+	if len(accounts) == 0 {
+		return health
+	}
+
+	totalPacks, unreliable, rested := 0, 0, 0
+	for _, account := range accounts {
+		if account.Status == AccountStatusAvailable {
+			health.Available++
+		}
+		totalPacks += account.PackCount
+
+		if account.Status == AccountStatusFailed || account.Status == AccountStatusBanned || account.FailureCount > 0 {
+			unreliable++
+		}
+		if account.LastModified.IsZero() || now.Sub(account.LastModified) >= weights.RestWindow {
+			rested++
+		}
+	}
+
+	total := float64(len(accounts))
+	health.AveragePacks = float64(totalPacks) / total
+	health.Availability = float64(health.Available) / total
+	health.Reliability = 1 - float64(unreliable)/total
+	health.Rested = float64(rested) / total
+	if weights.PackTarget > 0 {
+		health.Packs = health.AveragePacks / float64(weights.PackTarget)
+		if health.Packs > 1 {
+			health.Packs = 1
+		}
+	}
+
+	weightSum := weights.Availability + weights.Packs + weights.Reliability + weights.Rested
+	if weightSum <= 0 {
+		return health
+	}
+	weighted := weights.Availability*health.Availability +
+		weights.Packs*health.Packs +
+		weights.Reliability*health.Reliability +
+		weights.Rested*health.Rested
+	health.Score = 100 * weighted / weightSum
+
+	return health
+}
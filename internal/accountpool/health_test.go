@@ -0,0 +1,137 @@
+package accountpool
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+func TestScorePoolHealth(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	weights := DefaultHealthWeights()
+	recent := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		accounts []*Account
+		want     PoolHealth
+	}{
+		{
+			name: "empty pool",
+			want: PoolHealth{},
+		},
+		{
+			name: "fresh available accounts at the pack target",
+			accounts: []*Account{
+				{Status: AccountStatusAvailable, PackCount: 20},
+				{Status: AccountStatusAvailable, PackCount: 30},
+			},
+			want: PoolHealth{
+				Total: 2, Available: 2, AveragePacks: 25,
+				Availability: 1, Packs: 1, Reliability: 1, Rested: 1, Score: 100,
+			},
+		},
+		{
+			name: "failed, banned and failing accounts",
+			accounts: []*Account{
+				{Status: AccountStatusFailed, PackCount: 10},
+				{Status: AccountStatusBanned, PackCount: 10},
+				{Status: AccountStatusAvailable, PackCount: 10, FailureCount: 2},
+				{Status: AccountStatusAvailable, PackCount: 10},
+			},
+			want: PoolHealth{
+				Total: 4, Available: 2, AveragePacks: 10,
+				Availability: 0.5, Packs: 0.5, Reliability: 0.25, Rested: 1,
+				Score: 100 * (0.4*0.5 + 0.2*0.5 + 0.25*0.25 + 0.15*1),
+			},
+		},
+		{
+			name: "recently used accounts",
+			accounts: []*Account{
+				{Status: AccountStatusInUse, PackCount: 0, LastModified: recent},
+				{Status: AccountStatusAvailable, PackCount: 0, LastModified: now.Add(-weights.RestWindow)},
+			},
+			want: PoolHealth{
+				Total: 2, Available: 1,
+				Availability: 0.5, Packs: 0, Reliability: 1, Rested: 0.5,
+				Score: 100 * (0.4*0.5 + 0.25*1 + 0.15*0.5),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorePoolHealth(tt.accounts, weights, now)
+			if got.Total != tt.want.Total || got.Available != tt.want.Available {
+				t.Errorf("counts = %d total, %d available; want %d, %d", got.Total, got.Available, tt.want.Total, tt.want.Available)
+			}
+			ratios := []struct {
+				name      string
+				got, want float64
+			}{
+				{"AveragePacks", got.AveragePacks, tt.want.AveragePacks},
+				{"Availability", got.Availability, tt.want.Availability},
+				{"Packs", got.Packs, tt.want.Packs},
+				{"Reliability", got.Reliability, tt.want.Reliability},
+				{"Rested", got.Rested, tt.want.Rested},
+				{"Score", got.Score, tt.want.Score},
+			}
+			for _, ratio := range ratios {
+				if math.Abs(ratio.got-ratio.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", ratio.name, ratio.got, ratio.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadStoredPoolState(t *testing.T) {
+	db, err := database.Open(filepath.Join(t.TempDir(), "accounts.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	for _, deviceAccount := range []string{"healthy", "failing", "banned"} {
+		if _, err := db.CreateAccount(deviceAccount, "password", ""); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+	if _, err := db.Conn().Exec(`UPDATE accounts SET failure_count = 2 WHERE device_account = 'failing'`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Conn().Exec(`UPDATE accounts SET pool_status = 'banned' WHERE device_account = 'banned'`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Accounts as a query pool resolves them: available, with no failures
+	accounts := []*Account{
+		{DeviceAccount: "healthy", Status: AccountStatusAvailable},
+		{DeviceAccount: "failing", Status: AccountStatusAvailable},
+		{DeviceAccount: "banned", Status: AccountStatusAvailable},
+	}
+	if err := loadStoredPoolState(db.Conn(), accounts); err != nil {
+		t.Fatalf("loadStoredPoolState failed: %v", err)
+	}
+
+	if accounts[0].Status != AccountStatusAvailable || accounts[0].FailureCount != 0 {
+		t.Errorf("healthy = %s with %d failures", accounts[0].Status, accounts[0].FailureCount)
+	}
+	if accounts[1].FailureCount != 2 {
+		t.Errorf("failing has %d failures, want 2", accounts[1].FailureCount)
+	}
+	if accounts[2].Status != AccountStatusBanned {
+		t.Errorf("banned = %s, want %s", accounts[2].Status, AccountStatusBanned)
+	}
+
+	health := scorePoolHealth(accounts, DefaultHealthWeights(), time.Now())
+	if math.Abs(health.Reliability-1.0/3) > 1e-9 || health.Available != 2 {
+		t.Errorf("health = %+v, want reliability 1/3 and 2 available", health)
+	}
+}
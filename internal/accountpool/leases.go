@@ -0,0 +1,62 @@
+package accountpool
+
+import (
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// A unified pool claims each account's lease in the pool_leases table before handing it out
+// (see database.ClaimPoolLease), so pools in other processes sharing the database skip it.
+// Accounts another process holds stay queued and are tried again on later claims.
+
+// enableLeases turns on cross-process claiming if the database has the pool_leases table
+func (p *UnifiedAccountPool) enableLeases() {
+	if p.db == nil {
+		return
+	}
+	if !database.HasPoolLeases(p.db) {
+		fmt.Printf("Warning: Pool '%s': database has no pool_leases table, accounts are not locked across processes\n", p.definition.PoolName)
+		return
+	}
+	p.leaseHolder = database.NewLeaseHolder(p.definition.PoolName)
+}
+
+// claimLease claims the account's lease for this pool. Returns false if another holder has it.
+// Always succeeds when leasing is off.
+func (p *UnifiedAccountPool) claimLease(account *Account) (bool, error) {
+	if p.leaseHolder == "" {
+		return true, nil
+	}
+	return database.ClaimPoolLease(p.db, account.DeviceAccount, p.definition.PoolName, p.leaseHolder, database.PoolLeaseDuration)
+}
+
+// releaseLease gives up this pool's lease on an account once it is no longer in use
+func (p *UnifiedAccountPool) releaseLease(account *Account) {
+	if p.leaseHolder == "" {
+		return
+	}
+	if err := database.ReleasePoolLease(p.db, account.DeviceAccount, p.leaseHolder); err != nil {
+		fmt.Printf("Warning: Pool '%s': %v\n", p.definition.PoolName, err)
+	}
+}
+
+// renewLeases extends the leases on the accounts this pool has out, so long runs keep them
+func (p *UnifiedAccountPool) renewLeases() {
+	if p.leaseHolder == "" {
+		return
+	}
+	if err := database.RenewPoolLeases(p.db, p.leaseHolder, database.PoolLeaseDuration); err != nil {
+		fmt.Printf("Warning: Pool '%s': %v\n", p.definition.PoolName, err)
+	}
+}
+
+// releaseAllLeases gives up every lease this pool holds
+func (p *UnifiedAccountPool) releaseAllLeases() {
+	if p.leaseHolder == "" {
+		return
+	}
+	if _, err := database.ReleasePoolLeases(p.db, p.leaseHolder); err != nil {
+		fmt.Printf("Warning: Pool '%s': %v\n", p.definition.PoolName, err)
+	}
+}
@@ -0,0 +1,114 @@
+package accountpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// TestPoolLeasesAcrossProcesses runs two pools over separate connections to one database, as
+// the GUI and a CLI tool would, and checks no account is handed out by both
+func TestPoolLeasesAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "accounts.db")
+
+	setup, err := database.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer setup.Close()
+	if err := setup.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	const accountCount = 20
+	include := make([]string, 0, accountCount)
+	for i := 0; i < accountCount; i++ {
+		deviceAccount := fmt.Sprintf("lease_account_%02d", i)
+		if _, err := setup.CreateAccount(deviceAccount, "password", ""); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+		include = append(include, deviceAccount)
+	}
+
+	definitionPath := filepath.Join(dir, "shared.yaml")
+	definition := "pool_name: shared\ninclude:\n  - " + strings.Join(include, "\n  - ") + "\n"
+	if err := os.WriteFile(definitionPath, []byte(definition), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newPool := func() *UnifiedAccountPool {
+		conn, err := database.Open(dbPath)
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		pool, err := NewUnifiedAccountPool(conn.Conn(), definitionPath, filepath.Join(dir, "xml"))
+		if err != nil {
+			t.Fatalf("Failed to create pool: %v", err)
+		}
+		if pool.leaseHolder == "" {
+			t.Fatal("Expected the pool to lease accounts")
+		}
+		return pool
+	}
+	pools := []*UnifiedAccountPool{newPool(), newPool()}
+
+	var mu sync.Mutex
+	claimedBy := make(map[string]int)
+	var wg sync.WaitGroup
+	for i, pool := range pools {
+		wg.Add(1)
+		go func(i int, pool *UnifiedAccountPool) {
+			defer wg.Done()
+			for {
+				account, err := pool.GetNext(context.Background())
+				if errors.Is(err, ErrNoAccountsAvailable) {
+					return
+				}
+				if err != nil {
+					t.Errorf("Pool %d: GetNext failed: %v", i, err)
+					return
+				}
+
+				mu.Lock()
+				if other, taken := claimedBy[account.DeviceAccount]; taken {
+					t.Errorf("Account %s handed out by pool %d and pool %d", account.DeviceAccount, other, i)
+				}
+				claimedBy[account.DeviceAccount] = i
+				mu.Unlock()
+			}
+		}(i, pool)
+	}
+	wg.Wait()
+
+	if len(claimedBy) != accountCount {
+		t.Errorf("Expected all %d accounts to be claimed, got %d", accountCount, len(claimedBy))
+	}
+
+	// Once one pool returns an account, the other can claim it
+	var returned *Account
+	for _, account := range pools[0].ListAccounts() {
+		if account.Status == AccountStatusInUse {
+			returned = account
+			break
+		}
+	}
+	if returned == nil {
+		t.Skip("The first pool claimed no accounts")
+	}
+	if err := pools[0].Return(returned); err != nil {
+		t.Fatalf("Return failed: %v", err)
+	}
+	if claimed, err := pools[1].claimLease(returned); err != nil || !claimed {
+		t.Errorf("Expected the second pool to claim a returned account, got %v, %v", claimed, err)
+	}
+}
@@ -0,0 +1,91 @@
+package accountpool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// legacyPoolTagPrefix prefixes the tag given to a converted legacy file pool's accounts
+const legacyPoolTagPrefix = "legacy-"
+
+// ConvertLegacyFilePool migrates a legacy file pool (a folder of account XMLs) to a unified pool.
+// The folder's accounts are imported into the database and global XML storage like ImportFolder,
+// tagged "legacy-<name>", and a pool named name is created whose query selects that tag,
+// keeping the legacy MinPacks/MaxPacks as filters and its sort, retry and refresh settings.
+// The pool's description records how many accounts were imported and skipped.
+func (pm *PoolManager) ConvertLegacyFilePool(dir string, config PoolConfig, name string) (*PoolDefinition, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("pool name is required")
+	}
+	if _, err := pm.GetPoolDefinition(name); err == nil {
+		return nil, fmt.Errorf("pool '%s' already exists", name)
+	}
+
+	imported, skipped, err := pm.importFolder(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(imported) == 0 {
+		return nil, fmt.Errorf("no accounts could be imported from '%s' (%d skipped)", dir, skipped)
+	}
+
+	tag, err := database.NormalizeTag(legacyPoolTagPrefix + sanitizeFilename(name))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := database.TagAccounts(pm.db, imported, tag); err != nil {
+		return nil, fmt.Errorf("failed to tag imported accounts: %w", err)
+	}
+
+	query := QuerySource{Name: "legacy_accounts", Tags: []string{tag}}
+	if config.MinPacks > 0 {
+		query.Filters = append(query.Filters, QueryFilter{Column: "packs_opened", Comparator: ">=", Value: strconv.Itoa(config.MinPacks)})
+	}
+	if config.MaxPacks > 0 {
+		query.Filters = append(query.Filters, QueryFilter{Column: "packs_opened", Comparator: "<=", Value: strconv.Itoa(config.MaxPacks)})
+	}
+
+	def := &UnifiedPoolDefinition{
+		PoolName: name,
+		Description: fmt.Sprintf("Converted from legacy file pool %s (%d accounts imported, %d skipped)",
+			dir, len(imported), skipped),
+		Queries: []QuerySource{query},
+		Config: UnifiedPoolConfig{
+			SortMethod:      unifiedSortMethod(config.SortMethod),
+			RetryFailed:     config.RetryFailed,
+			MaxFailures:     config.MaxFailures,
+			RefreshInterval: int(config.RefreshInterval / time.Second),
+		},
+	}
+	if result := ValidatePoolDefinition(def); !result.Valid {
+		return nil, fmt.Errorf("converted pool definition is invalid:\n%s", result.FormatErrors())
+	}
+
+	poolDef := &PoolDefinition{Name: name, Config: def}
+	if err := pm.CreatePool(poolDef); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Converted legacy file pool '%s' to pool '%s': %d accounts imported, %d skipped (tagged '%s')\n",
+		dir, name, len(imported), skipped, tag)
+	return poolDef, nil
+}
+
+// unifiedSortMethod returns the unified pool sort_method for a legacy SortMethod
+func unifiedSortMethod(method SortMethod) string {
+	switch method {
+	case SortMethodModifiedDesc:
+		return "modified_desc"
+	case SortMethodPacksAsc:
+		return "packs_asc"
+	case SortMethodPacksDesc:
+		return "packs_desc"
+	default:
+		return "modified_asc"
+	}
+}
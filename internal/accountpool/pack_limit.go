@@ -0,0 +1,52 @@
+package accountpool
+
+import "fmt"
+
+// reachedPackLimit reports whether an account has opened as many packs as the pool's
+// max_packs_per_account allows (never, when the pool has no ceiling)
+func reachedPackLimit(config UnifiedPoolConfig, account *Account) bool {
+	return config.MaxPacksPerAccount > 0 && account.PackCount >= config.MaxPacksPerAccount
+}
+
+// withoutPackLimited drops the accounts that reached the pack ceiling from candidates, so
+// they aren't handed out again. The caller must hold the pool's lock.
+func withoutPackLimited(def *UnifiedPoolDefinition, candidates []*Account) []*Account {
+	if def.Config.MaxPacksPerAccount <= 0 {
+		return candidates
+	}
+
+	kept := candidates[:0]
+	for _, account := range candidates {
+		if reachedPackLimit(def.Config, account) {
+			fmt.Printf("Pool '%s': Skipping account '%s' - opened %d packs (max %d per account)\n",
+				def.PoolName, account.DeviceAccount, account.PackCount, def.Config.MaxPacksPerAccount)
+			continue
+		}
+		kept = append(kept, account)
+	}
+	return kept
+}
+
+// MaxPacksPerAccount returns the pack ceiling of pool's accounts, after which a bot moves on to
+// the next account (0 = no ceiling)
+func MaxPacksPerAccount(pool AccountPool) int {
+	if limited, ok := pool.(interface{ MaxPacksPerAccount() int }); ok {
+		return limited.MaxPacksPerAccount()
+	}
+	return 0
+}
+
+// MaxPacksPerAccount returns the pool's pack ceiling per account (0 = no ceiling)
+func (p *UnifiedAccountPool) MaxPacksPerAccount() int {
+	return p.definition.Config.MaxPacksPerAccount
+}
+
+// MaxPacksPerAccount returns the pool's pack ceiling per account (0 = no ceiling)
+func (p *SandboxPool) MaxPacksPerAccount() int {
+	return p.definition.Config.MaxPacksPerAccount
+}
+
+// MaxPacksPerAccount returns the underlying pool's pack ceiling per account (see MaxPacksPerAccount)
+func (r *ReservedPool) MaxPacksPerAccount() int {
+	return MaxPacksPerAccount(r.AccountPool)
+}
@@ -34,6 +34,17 @@ type AccountPool interface {
 	// MarkFailed marks an account as failed with a reason
 	MarkFailed(account *Account, reason string) error
 
+	// Reserve claims up to n available accounts as in use in one operation, e.g. for a group about
+	// to launch. Fewer accounts are returned if fewer are available.
+	Reserve(n int) ([]*Account, error)
+
+	// ReleaseReservation makes reserved accounts that were never used available again
+	ReleaseReservation(accounts []*Account)
+
+	// ReturnWithStatus releases an account with a final status (e.g. banned) so it is not handed out again.
+	// AccountStatusAvailable behaves like Return.
+	ReturnWithStatus(account *Account, status AccountStatus, reason string) error
+
 	// GetByID retrieves an account by its ID
 	GetByID(id string) (*Account, error)
 
@@ -79,6 +90,8 @@ const (
 	AccountStatusCompleted AccountStatus = "completed" // Successfully processed
 	AccountStatusFailed    AccountStatus = "failed"    // Failed processing
 	AccountStatusSkipped   AccountStatus = "skipped"   // Manually skipped
+	AccountStatusBanned    AccountStatus = "banned"    // Banned in game, never reassigned
+	AccountStatusPreserved AccountStatus = "preserved" // Pulled a god pack; kept aside and never reassigned or overwritten
 )
 
 // AccountResult holds the results of processing an account
@@ -102,6 +115,8 @@ type PoolStats struct {
 	Completed   int       // Successfully processed accounts
 	Failed      int       // Failed accounts
 	Skipped     int       // Manually skipped accounts
+	Banned      int       // Accounts detected as banned
+	Preserved   int       // God pack accounts kept out of rotation
 	LastRefresh time.Time // Last time pool was refreshed
 
 	// Aggregated results
@@ -249,3 +264,8 @@ func (a *Account) IsCompleted() bool {
 func (a *Account) IsFailed() bool {
 	return a.Status == AccountStatusFailed
 }
+
+// IsBanned returns whether the account was detected as banned
+func (a *Account) IsBanned() bool {
+	return a.Status == AccountStatusBanned
+}
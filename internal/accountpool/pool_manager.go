@@ -1,12 +1,15 @@
 package accountpool
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -20,6 +23,15 @@ type PoolManager struct {
 	instances     map[string]AccountPool
 	mu            sync.RWMutex
 	eventBus      interface{} // events.EventBus - interface{} to avoid circular import
+	healthWeights HealthWeights
+
+	// Background refresher (see StartRefreshScheduler)
+	schedulerStop chan struct{}
+	schedulerDone chan struct{}
+
+	// Background statistics sampler (see StartStatsSampler)
+	samplerStop chan struct{}
+	samplerDone chan struct{}
 }
 
 // PoolDefinition describes a pool configuration
@@ -58,6 +70,7 @@ func NewPoolManager(poolsDir string, db *sql.DB, xmlStorageDir string) *PoolMana
 		pools:         make(map[string]*PoolDefinition),
 		instances:     make(map[string]AccountPool),
 		eventBus:      nil,
+		healthWeights: DefaultHealthWeights(),
 	}
 }
 
@@ -184,19 +197,14 @@ func (pm *PoolManager) GetPool(name string) (AccountPool, error) {
 		return nil, fmt.Errorf("pool '%s' not found", name)
 	}
 
-	// Create unified pool instance
-	if pm.db == nil {
-		return nil, fmt.Errorf("database not configured")
-	}
-
-	pool, err := NewUnifiedAccountPool(pm.db, poolDef.FilePath, pm.xmlStorageDir)
+	pool, err := pm.newPoolInstance(poolDef)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pool: %w", err)
 	}
 
 	// Set event bus if available
-	if pm.eventBus != nil {
-		pool.SetEventBus(pm.eventBus)
+	if unifiedPool, ok := pool.(*UnifiedAccountPool); ok && pm.eventBus != nil {
+		unifiedPool.SetEventBus(pm.eventBus)
 	}
 
 	// Cache instance
@@ -204,6 +212,20 @@ func (pm *PoolManager) GetPool(name string) (AccountPool, error) {
 	return pool, nil
 }
 
+// newPoolInstance creates a pool instance for a definition: a SandboxPool for sandbox pools,
+// otherwise a UnifiedAccountPool
+func (pm *PoolManager) newPoolInstance(poolDef *PoolDefinition) (AccountPool, error) {
+	if poolDef.Config != nil && poolDef.Config.Sandbox {
+		// Keep generated XMLs out of the global storage
+		return NewSandboxPool(pm.db, poolDef.Config, filepath.Join(pm.xmlStorageDir, "sandbox"))
+	}
+
+	if pm.db == nil {
+		return nil, fmt.Errorf("database not configured")
+	}
+	return NewUnifiedAccountPool(pm.db, poolDef.FilePath, pm.xmlStorageDir)
+}
+
 // CreatePool saves a new pool definition
 func (pm *PoolManager) CreatePool(poolDef *PoolDefinition) error {
 	pm.mu.Lock()
@@ -308,14 +330,8 @@ func (pm *PoolManager) TestPool(name string) (*TestResult, error) {
 		SampleAccounts: make([]AccountSummary, 0),
 	}
 
-	// Create temporary unified pool instance
-	if pm.db == nil {
-		result.Success = false
-		result.Error = "database not configured"
-		return result, nil
-	}
-
-	pool, err := NewUnifiedAccountPool(pm.db, poolDef.FilePath, pm.xmlStorageDir)
+	// Create temporary pool instance
+	pool, err := pm.newPoolInstance(poolDef)
 	if err != nil {
 		result.Success = false
 		result.Error = err.Error()
@@ -335,6 +351,11 @@ func (pm *PoolManager) TestPool(name string) (*TestResult, error) {
 		sampleLimit = len(accounts)
 	}
 
+	// Report runtime statuses from the open instance, if any
+	pm.mu.RLock()
+	open := pm.instances[name]
+	pm.mu.RUnlock()
+
 	result.SampleAccounts = make([]AccountSummary, 0, sampleLimit)
 	for i := 0; i < sampleLimit; i++ {
 		acc := accounts[i]
@@ -343,12 +364,93 @@ func (pm *PoolManager) TestPool(name string) (*TestResult, error) {
 			PackCount: acc.PackCount,
 			Status:    acc.Status,
 		}
+		if open != nil {
+			if current, err := open.GetByID(acc.ID); err == nil {
+				summary.Status = current.Status
+			}
+		}
 		result.SampleAccounts = append(result.SampleAccounts, summary)
 	}
 
 	return result, nil
 }
 
+// PeekAccounts returns the accounts of an open pool with their runtime statuses. Pools that aren't open
+// are resolved with a temporary instance, as TestPool does, so no pool is left open or modified.
+func (pm *PoolManager) PeekAccounts(name string) ([]*Account, error) {
+	pm.mu.RLock()
+	open := pm.instances[name]
+	pm.mu.RUnlock()
+	if open != nil {
+		return open.ListAccounts(), nil
+	}
+
+	poolDef, err := pm.GetPoolDefinition(name)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := pm.newPoolInstance(poolDef)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	return pool.ListAccounts(), nil
+}
+
+// PreviewQuery runs a query read-only and returns up to limit matching accounts plus the total match count,
+// without creating a pool instance. Malformed queries return an error.
+func (pm *PoolManager) PreviewQuery(query QuerySource, limit int) ([]Account, int, error) {
+	// The name only matters once the query is saved
+	if query.Name == "" {
+		query.Name = "preview"
+	}
+	if validation := ValidateQuery(&query); !validation.Valid {
+		return nil, 0, fmt.Errorf("%s", strings.TrimSpace(validation.FormatErrors()))
+	}
+	if pm.db == nil {
+		return nil, 0, fmt.Errorf("database not configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Roll back unconditionally so the preview can never modify the database
+	tx, err := pm.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin preview: %w", err)
+	}
+	defer tx.Rollback()
+
+	sqlQuery, params := query.GenerateSQL()
+
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+sqlQuery+")", params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("query execution failed: %w", err)
+	}
+
+	if limit <= 0 || total == 0 {
+		return []Account{}, total, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT * FROM (%s) LIMIT %d", sqlQuery, limit), params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	accounts, err := scanQueryRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sample := make([]Account, len(accounts))
+	for i, account := range accounts {
+		sample[i] = *account
+	}
+	return sample, total, nil
+}
+
 // savePoolDefinition saves a pool definition to a YAML file
 func (pm *PoolManager) savePoolDefinition(filePath string, poolDef *PoolDefinition) error {
 	// Marshal the config
@@ -392,6 +494,116 @@ func (pm *PoolManager) RefreshPool(name string) error {
 	return pool.Refresh()
 }
 
+// SetAccountStatus applies a manual status change to every open pool containing the account,
+// publishing a pool refreshed event for each. The database must be updated separately.
+// Returns the number of pools updated.
+func (pm *PoolManager) SetAccountStatus(deviceAccount string, status AccountStatus, resetFailures bool) int {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	updated := 0
+	for _, instance := range pm.instances {
+		if pool, ok := instance.(*UnifiedAccountPool); ok && pool.SetAccountStatus(deviceAccount, status, resetFailures) {
+			updated++
+		}
+	}
+	return updated
+}
+
+// ExcludeAccount adds a device account to the exclude list of every pool definition,
+// saving each changed definition and updating open pools, so no pool picks it again.
+// Returns the number of definitions changed and the first save error, after attempting all pools.
+func (pm *PoolManager) ExcludeAccount(deviceAccount string) (int, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	updated := 0
+	var firstErr error
+	for name, poolDef := range pm.pools {
+		if poolDef.Config == nil || containsString(poolDef.Config.Exclude, deviceAccount) {
+			continue
+		}
+
+		poolDef.Config.Exclude = append(poolDef.Config.Exclude, deviceAccount)
+		if err := pm.savePoolDefinition(poolDef.FilePath, poolDef); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("pool '%s': %w", name, err)
+			}
+			continue
+		}
+		updated++
+	}
+
+	for _, instance := range pm.instances {
+		if pool, ok := instance.(*UnifiedAccountPool); ok {
+			pool.AddExclusion(deviceAccount)
+		}
+	}
+
+	return updated, firstErr
+}
+
+// PoolsUsingAccount returns the names of open pools that currently have the account assigned to a bot
+func (pm *PoolManager) PoolsUsingAccount(deviceAccount string) []string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var names []string
+	for name, instance := range pm.instances {
+		if account, err := instance.GetByID(deviceAccount); err == nil && account.Status == AccountStatusInUse {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshOpenPools re-resolves every open pool instance, e.g. after accounts were deleted.
+// Returns the first refresh error, after attempting all pools.
+func (pm *PoolManager) RefreshOpenPools() error {
+	pm.mu.RLock()
+	pools := make([]AccountPool, 0, len(pm.instances))
+	for _, instance := range pm.instances {
+		pools = append(pools, instance)
+	}
+	pm.mu.RUnlock()
+
+	var firstErr error
+	for _, pool := range pools {
+		if err := pool.Refresh(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// OpenPoolStats returns statistics for every open pool instance, keyed by pool name.
+// Unlike GetPool it never opens a pool.
+func (pm *PoolManager) OpenPoolStats() map[string]PoolStats {
+	pm.mu.RLock()
+	pools := make(map[string]AccountPool, len(pm.instances))
+	for name, instance := range pm.instances {
+		pools[name] = instance
+	}
+	pm.mu.RUnlock()
+
+	stats := make(map[string]PoolStats, len(pools))
+	for name, pool := range pools {
+		stats[name] = pool.GetStats()
+	}
+	return stats
+}
+
 // ClosePool closes a pool instance (removes from cache)
 func (pm *PoolManager) ClosePool(name string) error {
 	pm.mu.Lock()
@@ -410,8 +622,11 @@ func (pm *PoolManager) ClosePool(name string) error {
 	return nil
 }
 
-// CloseAll closes all active pool instances
+// CloseAll stops the background refresher and sampler and closes all active pool instances
 func (pm *PoolManager) CloseAll() error {
+	pm.StopRefreshScheduler()
+	pm.StopStatsSampler()
+
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
@@ -425,15 +640,22 @@ func (pm *PoolManager) CloseAll() error {
 
 // ImportFolder imports account XMLs from an arbitrary folder into the database and global storage
 func (pm *PoolManager) ImportFolder(folderPath string) (imported []string, err error) {
+	imported, _, err = pm.importFolder(folderPath)
+	return imported, err
+}
+
+// importFolder imports a folder's account XMLs, also counting the XMLs that couldn't be
+// parsed or imported
+func (pm *PoolManager) importFolder(folderPath string) (imported []string, skipped int, err error) {
 	// Check if folder exists
 	if _, err := os.Stat(folderPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("folder does not exist: %s", folderPath)
+		return nil, 0, fmt.Errorf("folder does not exist: %s", folderPath)
 	}
 
 	// Read directory
 	files, err := os.ReadDir(folderPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read directory: %w", err)
+		return nil, 0, fmt.Errorf("failed to read directory: %w", err)
 	}
 
 	imported = make([]string, 0)
@@ -454,12 +676,14 @@ func (pm *PoolManager) ImportFolder(folderPath string) (imported []string, err e
 		account, err := parseAccountXMLFile(xmlPath)
 		if err != nil {
 			fmt.Printf("Warning: Failed to parse '%s': %v\n", xmlPath, err)
+			skipped++
 			continue
 		}
 
 		// Import to database
 		if err := importAccountToDB(pm.db, account); err != nil {
 			fmt.Printf("Warning: Failed to import account '%s': %v\n", account.DeviceAccount, err)
+			skipped++
 			continue
 		}
 
@@ -472,7 +696,7 @@ func (pm *PoolManager) ImportFolder(folderPath string) (imported []string, err e
 		imported = append(imported, account.DeviceAccount)
 	}
 
-	return imported, nil
+	return imported, skipped, nil
 }
 
 // ExportPoolXMLs exports all XMLs from a pool to a destination folder
@@ -0,0 +1,93 @@
+package accountpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SnapshotPool freezes the accounts a pool resolves to right now as a file-based pool: each
+// account's XML is exported to outputDir, and a new pool "<name>_snapshot_<timestamp>" is
+// created that only watches outputDir. Runs from the snapshot are reproducible, since later
+// database changes no longer change which accounts it serves. Accounts whose XML can't be
+// found or generated are skipped.
+func (pm *PoolManager) SnapshotPool(name, outputDir string) (*PoolDefinition, error) {
+	poolDef, err := pm.GetPoolDefinition(name)
+	if err != nil {
+		return nil, err
+	}
+	if poolDef.Config == nil {
+		return nil, fmt.Errorf("pool '%s' has no configuration", name)
+	}
+	if poolDef.Config.Sandbox {
+		return nil, fmt.Errorf("pool '%s' is a sandbox pool; only database pools can be snapshotted", name)
+	}
+
+	// Resolve with a temporary instance, so the accounts are current even if the pool is open
+	pool, err := pm.newPoolInstance(poolDef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve pool: %w", err)
+	}
+	accounts := pool.ListAccounts()
+	pool.Close()
+
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("pool '%s' has no accounts to snapshot", name)
+	}
+
+	outputDir, err = filepath.Abs(outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output folder: %w", err)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output folder: %w", err)
+	}
+
+	snapshotted := 0
+	skipped := 0
+	for _, account := range accounts {
+		xmlContent, err := pm.GetAccountXML(account.DeviceAccount)
+		if err != nil {
+			fmt.Printf("Warning: Skipping account '%s' - no XML data: %v\n", account.DeviceAccount, err)
+			skipped++
+			continue
+		}
+
+		destPath := filepath.Join(outputDir, account.DeviceAccount+".xml")
+		if err := os.WriteFile(destPath, xmlContent, 0644); err != nil {
+			fmt.Printf("Warning: Skipping account '%s' - failed to write XML: %v\n", account.DeviceAccount, err)
+			skipped++
+			continue
+		}
+
+		snapshotted++
+	}
+
+	if snapshotted == 0 {
+		return nil, fmt.Errorf("failed to snapshot any accounts (all %d skipped)", skipped)
+	}
+
+	takenAt := time.Now()
+	snapshotName := fmt.Sprintf("%s_snapshot_%s", name, takenAt.Format("20060102_150405"))
+	snapshot := &PoolDefinition{
+		Name: snapshotName,
+		Config: &UnifiedPoolDefinition{
+			PoolName: snapshotName,
+			Description: fmt.Sprintf("Snapshot of pool '%s' taken %s (%d accounts)",
+				name, takenAt.Format("2006-01-02 15:04"), snapshotted),
+			Priority:     poolDef.Config.Priority,
+			Affinity:     poolDef.Config.Affinity,
+			WatchedPaths: []string{outputDir},
+			Config:       poolDef.Config.Config,
+		},
+	}
+	if err := pm.CreatePool(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot pool: %w", err)
+	}
+
+	fmt.Printf("Snapshotted %d accounts (%d skipped) from pool '%s' to pool '%s' in '%s'\n",
+		snapshotted, skipped, name, snapshotName, outputDir)
+
+	return snapshot, nil
+}
@@ -0,0 +1,92 @@
+package accountpool
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// TestSnapshotPool snapshots a database pool and reopens the snapshot from disk, checking it
+// serves the same accounts even after the source pool's accounts change
+func TestSnapshotPool(t *testing.T) {
+	dir := t.TempDir()
+	db, err := database.Open(filepath.Join(dir, "accounts.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	for _, deviceAccount := range []string{"snap_a", "snap_b", "snap_c"} {
+		if _, err := db.CreateAccount(deviceAccount, "password_"+deviceAccount, ""); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+
+	poolsDir := filepath.Join(dir, "pools")
+	xmlDir := filepath.Join(dir, "xml")
+	if err := os.MkdirAll(poolsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	definition := "pool_name: source\ninclude:\n  - snap_a\n  - snap_b\n  - snap_c\n"
+	if err := os.WriteFile(filepath.Join(poolsDir, "source.yaml"), []byte(definition), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pm := NewPoolManager(poolsDir, db.Conn(), xmlDir)
+	if err := pm.DiscoverPools(); err != nil {
+		t.Fatalf("Failed to discover pools: %v", err)
+	}
+
+	snapshotDir := filepath.Join(dir, "snapshot")
+	snapshot, err := pm.SnapshotPool("source", snapshotDir)
+	if err != nil {
+		t.Fatalf("SnapshotPool failed: %v", err)
+	}
+	if !strings.HasPrefix(snapshot.Name, "source_snapshot_") {
+		t.Errorf("snapshot name = %q, want a source_snapshot_ prefix", snapshot.Name)
+	}
+	for _, deviceAccount := range []string{"snap_a", "snap_b", "snap_c"} {
+		if _, err := os.Stat(filepath.Join(snapshotDir, deviceAccount+".xml")); err != nil {
+			t.Errorf("missing snapshot XML for %s: %v", deviceAccount, err)
+		}
+	}
+
+	// A new account in the database doesn't join the snapshot
+	if _, err := db.CreateAccount("snap_d", "password", ""); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	reopened := NewPoolManager(poolsDir, db.Conn(), xmlDir)
+	if err := reopened.DiscoverPools(); err != nil {
+		t.Fatalf("Failed to discover pools: %v", err)
+	}
+	pool, err := reopened.GetPool(snapshot.Name)
+	if err != nil {
+		t.Fatalf("Failed to open snapshot pool: %v", err)
+	}
+	defer pool.Close()
+
+	accounts := pool.ListAccounts()
+	got := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		got = append(got, account.DeviceAccount)
+		if account.DevicePassword != "password_"+account.DeviceAccount {
+			t.Errorf("%s has password %q", account.DeviceAccount, account.DevicePassword)
+		}
+	}
+	sort.Strings(got)
+	if strings.Join(got, ",") != "snap_a,snap_b,snap_c" {
+		t.Errorf("snapshot serves %v, want [snap_a snap_b snap_c]", got)
+	}
+
+	if _, err := pm.SnapshotPool("missing", snapshotDir); err == nil {
+		t.Error("expected an error snapshotting a missing pool")
+	}
+}
@@ -0,0 +1,159 @@
+package accountpool
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+func TestGenerateSQL(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name       string
+		query      QuerySource
+		wantSQL    []string // Fragments the SQL must contain
+		wantParams []interface{}
+	}{
+		{
+			name: "comparison",
+			query: QuerySource{Filters: []QueryFilter{
+				{Column: "packs_opened", Comparator: ">=", Value: "5"},
+			}},
+			wantSQL:    []string{"WHERE packs_opened >= ?"},
+			wantParams: []interface{}{"5"},
+		},
+		{
+			name: "IN and NOT IN",
+			query: QuerySource{Filters: []QueryFilter{
+				{Column: "pool_status", Comparator: "IN", Value: "available, failed,,"},
+				{Column: "device_account", Comparator: "NOT IN", Value: "a,b,c"},
+			}},
+			wantSQL:    []string{"WHERE pool_status IN (?, ?)", "AND device_account NOT IN (?, ?, ?)"},
+			wantParams: []interface{}{"available", "failed", "a", "b", "c"},
+		},
+		{
+			name: "IS NULL takes no value",
+			query: QuerySource{Filters: []QueryFilter{
+				{Column: "friend_code", Comparator: "IS NULL", Value: "ignored"},
+				{Column: "username", Comparator: "IS NOT NULL"},
+			}},
+			wantSQL:    []string{"WHERE friend_code IS NULL", "AND username IS NOT NULL"},
+			wantParams: []interface{}{},
+		},
+		{
+			name: "disabled filters and sorts are skipped",
+			query: QuerySource{
+				Filters: []QueryFilter{
+					{Column: "shinedust", Comparator: ">", Value: "100", Enabled: &disabled},
+					{Column: "is_banned", Comparator: "=", Value: "0"},
+				},
+				Sort: []SortOrder{
+					{Column: "shinedust", Direction: "asc", Enabled: &disabled},
+					{Column: "packs_opened", Direction: "desc"},
+				},
+				Limit: 10,
+			},
+			wantSQL:    []string{"WHERE is_banned = ?", "ORDER BY packs_opened DESC", "LIMIT 10"},
+			wantParams: []interface{}{"0"},
+		},
+		{
+			name: "tags",
+			query: QuerySource{
+				Filters: []QueryFilter{{Column: "is_banned", Comparator: "=", Value: "0"}},
+				Tags:    []string{" Farm ", "", "event"},
+			},
+			wantSQL:    []string{"AND id IN (SELECT account_id FROM account_tags WHERE tag IN (?, ?))"},
+			wantParams: []interface{}{"0", "farm", "event"},
+		},
+		{
+			name: "raw SQL with named parameters",
+			query: QuerySource{
+				RawSQL:     "  " + QueryBaseSQL + "WHERE packs_opened > :min AND shinedust < @max;  ",
+				Parameters: map[string]string{"min": "3", "max": "900"},
+			},
+			wantSQL:    []string{"WHERE packs_opened > :min AND shinedust < @max"},
+			wantParams: []interface{}{sql.Named("max", "900"), sql.Named("min", "3")},
+		},
+	}
+
+	db, err := database.Open(filepath.Join(t.TempDir(), "accounts.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, params := tt.query.GenerateSQL()
+
+			if !strings.HasPrefix(query, "SELECT device_account, device_password") {
+				t.Errorf("SQL does not select the pool columns:\n%s", query)
+			}
+			if strings.HasSuffix(query, ";") {
+				t.Errorf("SQL keeps its trailing semicolon:\n%s", query)
+			}
+			for _, fragment := range tt.wantSQL {
+				if !strings.Contains(query, fragment) {
+					t.Errorf("SQL missing %q:\n%s", fragment, query)
+				}
+			}
+
+			if len(params) != len(tt.wantParams) {
+				t.Fatalf("params = %v, want %v", params, tt.wantParams)
+			}
+			for i := range params {
+				if params[i] != tt.wantParams[i] {
+					t.Errorf("param %d = %v, want %v", i, params[i], tt.wantParams[i])
+				}
+			}
+			if !tt.query.IsAdvanced() {
+				if placeholders := strings.Count(query, "?"); placeholders != len(params) {
+					t.Errorf("%d placeholders for %d params:\n%s", placeholders, len(params), query)
+				}
+			}
+
+			// The query runs against the real schema
+			rows, err := db.Conn().Query(query, params...)
+			if err != nil {
+				t.Fatalf("query failed: %v\n%s", err, query)
+			}
+			rows.Close()
+		})
+	}
+}
+
+func TestValidateRawSQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawSQL     string
+		parameters map[string]string
+		wantValid  bool
+	}{
+		{name: "select", rawSQL: QueryBaseSQL + "WHERE packs_opened > :min", parameters: map[string]string{"min": "3"}, wantValid: true},
+		{name: "trailing semicolon", rawSQL: QueryBaseSQL + ";", wantValid: true},
+		{name: "common table expression", rawSQL: "WITH recent AS (SELECT id FROM accounts) " + QueryBaseSQL, wantValid: true},
+		{name: "lower case", rawSQL: strings.ToLower(QueryBaseSQL), wantValid: true},
+		{name: "second statement", rawSQL: QueryBaseSQL + "; DELETE FROM accounts", wantValid: false},
+		{name: "not a select", rawSQL: "DELETE FROM accounts", wantValid: false},
+		{name: "update", rawSQL: "UPDATE accounts SET is_banned = 1", wantValid: false},
+		{name: "bad parameter name", rawSQL: QueryBaseSQL, parameters: map[string]string{"min value": "3"}, wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &ValidationResult{Valid: true}
+			query := &QuerySource{Name: "raw", RawSQL: tt.rawSQL, Parameters: tt.parameters}
+			validateRawSQL(result, "Queries[0]", query)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package accountpool
+
+import (
+	"fmt"
+	"time"
+)
+
+// refreshSchedulerTick is how often the scheduler checks whether a pool is due for a refresh
+const refreshSchedulerTick = time.Second
+
+// StartRefreshScheduler starts re-resolving open pools in the background according to
+// their refresh_interval config. Pools with an interval of 0 are never refreshed automatically.
+// Does nothing if the scheduler is already running.
+func (pm *PoolManager) StartRefreshScheduler() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.schedulerStop != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	pm.schedulerStop = stop
+	pm.schedulerDone = done
+
+	go pm.runRefreshScheduler(stop, done)
+}
+
+// StopRefreshScheduler stops the background refresher and waits for any running refresh to finish
+func (pm *PoolManager) StopRefreshScheduler() {
+	pm.mu.Lock()
+	stop, done := pm.schedulerStop, pm.schedulerDone
+	pm.schedulerStop = nil
+	pm.schedulerDone = nil
+	pm.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// IsRefreshSchedulerRunning returns true if the background refresher is running
+func (pm *PoolManager) IsRefreshSchedulerRunning() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.schedulerStop != nil
+}
+
+// LastRefreshed returns when an open pool was last re-resolved (zero if the pool isn't open)
+func (pm *PoolManager) LastRefreshed(name string) (time.Time, error) {
+	pm.mu.RLock()
+	instance, exists := pm.instances[name]
+	pm.mu.RUnlock()
+
+	if !exists {
+		if _, err := pm.GetPoolDefinition(name); err != nil {
+			return time.Time{}, err
+		}
+		return time.Time{}, nil
+	}
+
+	return instance.GetStats().LastRefresh, nil
+}
+
+// runRefreshScheduler refreshes due pools until stop is closed
+func (pm *PoolManager) runRefreshScheduler(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(refreshSchedulerTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			for name, pool := range pm.duePools(now) {
+				if err := pool.Refresh(); err != nil && err != ErrPoolClosed {
+					fmt.Printf("Scheduled refresh failed for pool '%s': %v\n", name, err)
+				}
+			}
+		}
+	}
+}
+
+// duePools returns the open pools whose refresh interval has elapsed
+func (pm *PoolManager) duePools(now time.Time) map[string]*UnifiedAccountPool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	due := make(map[string]*UnifiedAccountPool)
+	for name, instance := range pm.instances {
+		pool, ok := instance.(*UnifiedAccountPool)
+		if !ok {
+			continue
+		}
+
+		interval := pool.RefreshInterval()
+		if interval > 0 && now.Sub(pool.LastRefreshed()) >= interval {
+			due[name] = pool
+		}
+	}
+	return due
+}
@@ -0,0 +1,101 @@
+package accountpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ReservedPool hands out a batch of reserved accounts before falling back to the underlying pool.
+// All other operations are passed through to the underlying pool.
+type ReservedPool struct {
+	AccountPool
+
+	mu       sync.Mutex
+	reserved []*Account
+}
+
+// NewReservedPool wraps pool so GetNext returns the reserved accounts first (see AccountPool.Reserve)
+func NewReservedPool(pool AccountPool, reserved []*Account) *ReservedPool {
+	return &ReservedPool{
+		AccountPool: pool,
+		reserved:    reserved,
+	}
+}
+
+// GetNext returns the next reserved account, or the next available account once the reservation is used up
+func (r *ReservedPool) GetNext(ctx context.Context) (*Account, error) {
+	r.mu.Lock()
+	if len(r.reserved) > 0 {
+		account := r.reserved[0]
+		r.reserved = r.reserved[1:]
+		r.mu.Unlock()
+		return account, nil
+	}
+	r.mu.Unlock()
+
+	return r.AccountPool.GetNext(ctx)
+}
+
+// GetNextForInstance implements InstanceAccountPool.GetNextForInstance. Reserved accounts pinned to
+// the instance or unpinned come first, then the underlying pool, then (with AffinityFallback)
+// reserved accounts pinned to other instances.
+func (r *ReservedPool) GetNextForInstance(ctx context.Context, instanceID int) (*Account, error) {
+	var affinity map[string]int
+	policy := AffinityFallback
+	if provider, ok := r.AccountPool.(interface {
+		affinitySnapshot() (map[string]int, AffinityPolicy)
+	}); ok {
+		affinity, policy = provider.affinitySnapshot()
+	}
+
+	if account := r.takeForInstance(affinity, AffinityWait, instanceID); account != nil {
+		return account, nil
+	}
+
+	account, err := GetNextForInstance(ctx, r.AccountPool, instanceID)
+	if errors.Is(err, ErrNoAccountsAvailable) && policy != AffinityWait {
+		if account := r.takeForInstance(affinity, policy, instanceID); account != nil {
+			return account, nil
+		}
+	}
+	return account, err
+}
+
+// takeForInstance removes and returns the reserved account picked for the instance (nil if none fits)
+func (r *ReservedPool) takeForInstance(affinity map[string]int, policy AffinityPolicy, instanceID int) *Account {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	picked := pickForInstance(r.reserved, affinity, policy, instanceID)
+	if picked < 0 {
+		return nil
+	}
+	account := r.reserved[picked]
+	r.reserved = append(r.reserved[:picked:picked], r.reserved[picked+1:]...)
+	return account
+}
+
+// Remaining returns how many reserved accounts have not been handed out yet
+func (r *ReservedPool) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.reserved)
+}
+
+// Release returns the reserved accounts that were never handed out to the underlying pool
+func (r *ReservedPool) Release() {
+	r.mu.Lock()
+	unused := r.reserved
+	r.reserved = nil
+	r.mu.Unlock()
+
+	if len(unused) > 0 {
+		r.AccountPool.ReleaseReservation(unused)
+	}
+}
+
+// Sandboxed reports whether the underlying pool is a sandbox pool (see IsSandbox)
+func (r *ReservedPool) Sandboxed() bool {
+	return IsSandbox(r.AccountPool)
+}
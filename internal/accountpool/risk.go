@@ -0,0 +1,44 @@
+package accountpool
+
+import (
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// accountRisks scores the database's accounts (see database.ComputeAccountRisks) when the pool
+// has a max_risk_score, or returns nil. It queries the error and activity logs, so refresh
+// calls it before taking the pool's lock.
+func (p *UnifiedAccountPool) accountRisks() map[string]database.AccountRisk {
+	if p.definition.Config.MaxRiskScore <= 0 || p.db == nil {
+		return nil
+	}
+
+	risks, err := database.ComputeAccountRisks(p.db, database.DefaultRiskWeights())
+	if err != nil {
+		fmt.Printf("Pool '%s': Warning - failed to score account risk: %v\n", p.definition.PoolName, err)
+		return nil
+	}
+	return risks
+}
+
+// withoutHighRisk drops the accounts whose risk score (see accountRisks) is above the pool's
+// max_risk_score, so accounts likely to get banned are rested. Accounts that weren't scored
+// are kept. The caller must hold the pool's lock.
+func (p *UnifiedAccountPool) withoutHighRisk(accounts map[string]*Account, risks map[string]database.AccountRisk) {
+	maxRisk := p.definition.Config.MaxRiskScore
+	if maxRisk <= 0 || risks == nil {
+		return
+	}
+
+	for deviceAccount := range accounts {
+		risk, scored := risks[deviceAccount]
+		if !scored || risk.Score <= maxRisk {
+			continue
+		}
+
+		fmt.Printf("Pool '%s': Excluding account '%s' - risk score %.0f (max %.0f): %v\n",
+			p.definition.PoolName, deviceAccount, risk.Score, maxRisk, risk.Factors)
+		delete(accounts, deviceAccount)
+	}
+}
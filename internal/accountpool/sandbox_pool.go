@@ -0,0 +1,452 @@
+package accountpool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+)
+
+// SandboxAccount is a test account declared inline in a sandbox pool definition
+type SandboxAccount struct {
+	DeviceAccount  string `yaml:"device_account"`
+	DevicePassword string `yaml:"device_password"`
+	PackCount      int    `yaml:"pack_count,omitempty"`
+}
+
+// SandboxPool serves a fixed, in-memory list of accounts for dry runs. Results, returns and
+// status changes only update the in-memory accounts and statistics; nothing is written to the
+// database, and IsSandbox lets callers skip their own database bookkeeping (checkouts,
+// routine executions, ban flags) for its accounts.
+type SandboxPool struct {
+	mu              sync.Mutex
+	definition      *UnifiedPoolDefinition
+	accounts        map[string]*Account // By device_account
+	queue           []*Account          // Available accounts in the order they are handed out
+	affinity        map[string]int
+	xmlStorageDir   string
+	closed          bool
+	lastRefresh     time.Time
+	stats           PoolStats
+	selectionCursor string     // Device account handed out last (see SelectionRoundRobin)
+	rng             *rand.Rand // Random source for SelectionRandom and SelectionWeightedRandom (guarded by mu)
+}
+
+// NewSandboxPool creates a sandbox pool from a definition. Its inline sandbox accounts come first,
+// followed by the accounts its queries and inclusions match (read once; db may be nil if the
+// definition only has sandbox accounts). Exclusions are applied last.
+func NewSandboxPool(db *sql.DB, def *UnifiedPoolDefinition, xmlStorageDir string) (*SandboxPool, error) {
+	if !def.Sandbox {
+		return nil, fmt.Errorf("pool '%s' is not a sandbox pool", def.PoolName)
+	}
+	validationResult := ValidatePoolDefinition(def)
+	if !validationResult.Valid {
+		return nil, fmt.Errorf("pool definition validation failed:\n%s", validationResult.FormatErrors())
+	}
+
+	if err := os.MkdirAll(xmlStorageDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create XML storage directory: %w", err)
+	}
+
+	accounts := make([]*Account, 0, len(def.SandboxAccounts))
+	for _, sandboxAccount := range def.SandboxAccounts {
+		accounts = append(accounts, &Account{
+			ID:             sandboxAccount.DeviceAccount,
+			DeviceAccount:  sandboxAccount.DeviceAccount,
+			DevicePassword: sandboxAccount.DevicePassword,
+			PackCount:      sandboxAccount.PackCount,
+			Metadata:       map[string]string{"sandbox": "true"},
+			Status:         AccountStatusAvailable,
+		})
+	}
+
+	if len(def.Queries) > 0 || len(def.Include) > 0 {
+		if db == nil {
+			return nil, fmt.Errorf("database not configured")
+		}
+		for _, query := range def.Queries {
+			queried, err := queryAccounts(db, query)
+			if err != nil {
+				return nil, fmt.Errorf("query '%s' failed: %w", query.Name, err)
+			}
+			accounts = append(accounts, queried...)
+		}
+		for _, deviceAccount := range def.Include {
+			account, err := fetchAccount(db, deviceAccount)
+			if err != nil {
+				fmt.Printf("Warning: Failed to fetch included account '%s': %v\n", deviceAccount, err)
+				continue
+			}
+			accounts = append(accounts, account)
+		}
+	}
+
+	pool := &SandboxPool{
+		definition:    def,
+		accounts:      make(map[string]*Account, len(accounts)),
+		queue:         make([]*Account, 0, len(accounts)),
+		affinity:      make(map[string]int, len(def.Affinity)),
+		xmlStorageDir: xmlStorageDir,
+		lastRefresh:   time.Now(),
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, account := range accounts {
+		if containsString(def.Exclude, account.DeviceAccount) {
+			continue
+		}
+		if _, exists := pool.accounts[account.DeviceAccount]; exists {
+			continue
+		}
+		pool.accounts[account.DeviceAccount] = account
+		pool.queue = append(pool.queue, account)
+	}
+	for deviceAccount, instanceID := range def.Affinity {
+		pool.affinity[deviceAccount] = instanceID
+	}
+	warnIneligiblePriority(def, pool.accounts)
+	pool.updateStats()
+
+	return pool, nil
+}
+
+// IsSandbox reports whether pool is a sandbox pool (directly or behind a reservation), whose
+// accounts must not be written to the database
+func IsSandbox(pool AccountPool) bool {
+	sandboxed, ok := pool.(interface{ Sandboxed() bool })
+	return ok && sandboxed.Sandboxed()
+}
+
+// Sandboxed marks SandboxPool for IsSandbox
+func (p *SandboxPool) Sandboxed() bool {
+	return true
+}
+
+// affinityPolicy returns the pool's affinity policy (empty = AffinityFallback)
+func (p *SandboxPool) affinityPolicy() AffinityPolicy {
+	if p.definition.Config.AffinityPolicy == "" {
+		return AffinityFallback
+	}
+	return AffinityPolicy(p.definition.Config.AffinityPolicy)
+}
+
+// affinitySnapshot returns a copy of the pool's affinities and its policy (see ReservedPool)
+func (p *SandboxPool) affinitySnapshot() (map[string]int, AffinityPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	affinity := make(map[string]int, len(p.affinity))
+	for accountID, instanceID := range p.affinity {
+		affinity[accountID] = instanceID
+	}
+	return affinity, p.affinityPolicy()
+}
+
+// updateStats recalculates pool statistics. The caller must hold p.mu.
+func (p *SandboxPool) updateStats() {
+	p.stats = computeStats(p.accounts, p.lastRefresh)
+}
+
+// enqueue makes an account available again. The caller must hold p.mu.
+func (p *SandboxPool) enqueue(account *Account) {
+	account.Status = AccountStatusAvailable
+	account.AssignedAt = nil
+	account.AssignedTo = 0
+	for _, queued := range p.queue {
+		if queued == account {
+			return
+		}
+	}
+	p.queue = append(p.queue, account)
+}
+
+// stored returns the pool's record of an account (callers may hold a clone). The caller must hold p.mu.
+func (p *SandboxPool) stored(account *Account) (*Account, error) {
+	current, exists := p.accounts[account.DeviceAccount]
+	if !exists {
+		return nil, ErrAccountNotFound
+	}
+	return current, nil
+}
+
+// orderQueue orders the queue by the pool's selection strategy, priority accounts first. The caller must hold p.mu.
+func (p *SandboxPool) orderQueue() {
+	orderForSelection(p.queue, selectionStrategyOf(p.definition.Config), p.definition.Config.SelectionWeight, p.selectionCursor, p.rng)
+	prioritize(p.queue, p.definition.Priority)
+}
+
+// take hands out the queued account at index i. The caller must hold p.mu.
+func (p *SandboxPool) take(i int) *Account {
+	account := p.queue[i]
+	p.queue = append(p.queue[:i], p.queue[i+1:]...)
+
+	account.Status = AccountStatusInUse
+	now := time.Now()
+	account.AssignedAt = &now
+	stampClaimed(account, now)
+	p.selectionCursor = account.DeviceAccount
+	return account
+}
+
+// GetNext implements AccountPool.GetNext
+func (p *SandboxPool) GetNext(ctx context.Context) (*Account, error) {
+	return p.GetNextForInstance(ctx, NoInstance)
+}
+
+// GetNextForInstance implements InstanceAccountPool.GetNextForInstance
+func (p *SandboxPool) GetNextForInstance(ctx context.Context, instanceID int) (*Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+
+	p.queue = withoutPackLimited(p.definition, p.queue)
+	p.orderQueue()
+	picked := pickForInstance(p.queue, p.affinity, p.affinityPolicy(), instanceID)
+	if picked < 0 {
+		p.mu.Unlock()
+		return nil, ErrNoAccountsAvailable
+	}
+
+	account := p.take(picked)
+	p.updateStats()
+	p.mu.Unlock()
+
+	if err := ensureAccountXML(p.xmlStorageDir, account); err != nil {
+		return nil, fmt.Errorf("failed to ensure XML exists: %w", err)
+	}
+	return account, nil
+}
+
+// Return implements AccountPool.Return
+func (p *SandboxPool) Return(account *Account) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	current, err := p.stored(account)
+	if err != nil {
+		return err
+	}
+	if current.Status == AccountStatusPreserved {
+		return nil
+	}
+
+	p.enqueue(current)
+	p.updateStats()
+	return nil
+}
+
+// Reserve implements AccountPool.Reserve
+func (p *SandboxPool) Reserve(n int) ([]*Account, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("cannot reserve %d accounts", n)
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+
+	p.orderQueue()
+	reserved := make([]*Account, 0, n)
+	for len(reserved) < n && len(p.queue) > 0 {
+		reserved = append(reserved, p.take(0))
+	}
+	p.updateStats()
+	p.mu.Unlock()
+
+	for _, account := range reserved {
+		if err := ensureAccountXML(p.xmlStorageDir, account); err != nil {
+			p.ReleaseReservation(reserved)
+			return nil, fmt.Errorf("failed to ensure XML exists: %w", err)
+		}
+	}
+	return reserved, nil
+}
+
+// ReleaseReservation implements AccountPool.ReleaseReservation
+func (p *SandboxPool) ReleaseReservation(accounts []*Account) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+	for _, account := range accounts {
+		current, err := p.stored(account)
+		if err != nil || current.Status != AccountStatusInUse {
+			continue
+		}
+		p.enqueue(current)
+	}
+	p.updateStats()
+}
+
+// MarkUsed implements AccountPool.MarkUsed
+func (p *SandboxPool) MarkUsed(account *Account, result AccountResult) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	current, err := p.stored(account)
+	if err != nil {
+		return err
+	}
+
+	current.Result = &result
+	if current.Status == AccountStatusPreserved {
+		p.updateStats()
+		return nil
+	}
+
+	now := time.Now()
+	current.ProcessedAt = &now
+	if result.Success {
+		current.Status = AccountStatusCompleted
+	} else {
+		current.FailureCount++
+		current.LastError = result.Error
+		if p.definition.Config.RetryFailed && current.FailureCount < p.definition.Config.MaxFailures {
+			p.enqueue(current)
+		} else {
+			current.Status = AccountStatusFailed
+		}
+	}
+
+	p.updateStats()
+	return nil
+}
+
+// MarkFailed implements AccountPool.MarkFailed
+func (p *SandboxPool) MarkFailed(account *Account, reason string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	current, err := p.stored(account)
+	if err != nil {
+		return err
+	}
+	if current.Status == AccountStatusPreserved {
+		return nil
+	}
+
+	current.FailureCount++
+	current.LastError = reason
+	current.Status = AccountStatusFailed
+
+	p.updateStats()
+	return nil
+}
+
+// ReturnWithStatus implements AccountPool.ReturnWithStatus
+func (p *SandboxPool) ReturnWithStatus(account *Account, status AccountStatus, reason string) error {
+	if status == AccountStatusAvailable {
+		return p.Return(account)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	current, err := p.stored(account)
+	if err != nil {
+		return err
+	}
+	if status != AccountStatusPreserved && current.Status == AccountStatusPreserved {
+		return nil
+	}
+
+	now := time.Now()
+	current.Status = status
+	current.AssignedAt = nil
+	current.AssignedTo = 0
+	current.ProcessedAt = &now
+	if reason != "" {
+		current.LastError = reason
+	}
+	if status == AccountStatusFailed {
+		current.FailureCount++
+	}
+
+	p.updateStats()
+	return nil
+}
+
+// GetByID implements AccountPool.GetByID
+func (p *SandboxPool) GetByID(id string) (*Account, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	account, exists := p.accounts[id]
+	if !exists {
+		return nil, ErrAccountNotFound
+	}
+	return account.Clone(), nil
+}
+
+// GetStats implements AccountPool.GetStats
+func (p *SandboxPool) GetStats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// Refresh implements AccountPool.Refresh. The account list of a sandbox pool is fixed
+// when it is opened, so refreshing keeps every account and its in-memory state.
+func (p *SandboxPool) Refresh() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	p.lastRefresh = time.Now()
+	p.updateStats()
+	return nil
+}
+
+// ListAccounts implements AccountPool.ListAccounts
+func (p *SandboxPool) ListAccounts() []*Account {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	accounts := make([]*Account, 0, len(p.accounts))
+	for _, account := range p.accounts {
+		accounts = append(accounts, account.Clone())
+	}
+	return accounts
+}
+
+// Close implements AccountPool.Close
+func (p *SandboxPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	p.queue = nil
+	return nil
+}
+
+// GetDefinition returns the pool definition
+func (p *SandboxPool) GetDefinition() *UnifiedPoolDefinition {
+	return p.definition
+}
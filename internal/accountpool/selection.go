@@ -0,0 +1,157 @@
+package accountpool
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// SelectionStrategy decides which available account a pool hands out next. It is separate
+// from the sort method, which orders accounts for display and, for pack-based methods, the
+// queue SelectionSortBased serves from (see orderForRefill).
+type SelectionStrategy string
+
+const (
+	// SelectionSortBased hands accounts out in queue order (default)
+	SelectionSortBased SelectionStrategy = "sort"
+
+	// SelectionRoundRobin cycles through the accounts in device account order
+	SelectionRoundRobin SelectionStrategy = "round_robin"
+
+	// SelectionLeastRecentlyUsed hands out the account used longest ago (by last_used_at, never used first)
+	SelectionLeastRecentlyUsed SelectionStrategy = "lru"
+
+	// SelectionRandom hands out a random available account
+	SelectionRandom SelectionStrategy = "random"
+
+	// SelectionWeightedRandom hands out a random available account with probability
+	// proportional to the pool's selection weight (e.g. pack_count)
+	SelectionWeightedRandom SelectionStrategy = "weighted_random"
+)
+
+// selectionStrategyOf returns the configured strategy (empty = SelectionSortBased)
+func selectionStrategyOf(config UnifiedPoolConfig) SelectionStrategy {
+	if config.SelectionStrategy == "" {
+		return SelectionSortBased
+	}
+	return SelectionStrategy(config.SelectionStrategy)
+}
+
+// orderForSelection reorders candidates in place so the account strategy hands out next comes
+// first. weight is the selection weight expression (for SelectionWeightedRandom); cursor is the
+// device account last handed out (for SelectionRoundRobin); rng is used by SelectionRandom and
+// SelectionWeightedRandom. SelectionSortBased keeps the queue order.
+func orderForSelection(candidates []*Account, strategy SelectionStrategy, weight string, cursor string, rng *rand.Rand) {
+	switch strategy {
+	case SelectionRoundRobin:
+		// Accounts after the cursor come first, then wrap around to the start
+		sort.SliceStable(candidates, func(i, j int) bool {
+			iAfter := candidates[i].DeviceAccount > cursor
+			jAfter := candidates[j].DeviceAccount > cursor
+			if iAfter != jAfter {
+				return iAfter
+			}
+			return candidates[i].DeviceAccount < candidates[j].DeviceAccount
+		})
+
+	case SelectionLeastRecentlyUsed:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if !candidates[i].LastModified.Equal(candidates[j].LastModified) {
+				return candidates[i].LastModified.Before(candidates[j].LastModified)
+			}
+			return candidates[i].DeviceAccount < candidates[j].DeviceAccount
+		})
+
+	case SelectionRandom:
+		rng.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+
+	case SelectionWeightedRandom:
+		// Validation rejects bad expressions; fall back to the default if one slips through
+		expr, err := parseWeightExpression(weight)
+		if err != nil {
+			expr, _ = parseWeightExpression(DefaultSelectionWeight)
+		}
+		orderByWeight(candidates, expr, rng)
+	}
+}
+
+// orderForRefill orders the available accounts a refresh queues up for SelectionSortBased.
+// Pack-based sort methods queue accounts by their current pack count (packs_desc: most
+// first), so packs opened during a run take effect on the next refresh. Accounts already
+// processed and back in rotation (e.g. retried after a failure) go after the ones not yet
+// served, longest ago first, so re-sorting never keeps serving the same accounts and starves
+// the rest in either direction. Other sort methods keep the given order.
+func orderForRefill(accounts []*Account, sortMethod string) {
+	var packsFirst func(a, b *Account) bool
+	switch sortMethod {
+	case "packs_desc":
+		packsFirst = func(a, b *Account) bool { return a.PackCount > b.PackCount }
+	case "packs_asc":
+		packsFirst = func(a, b *Account) bool { return a.PackCount < b.PackCount }
+	default:
+		return
+	}
+
+	sort.SliceStable(accounts, func(i, j int) bool {
+		a, b := accounts[i], accounts[j]
+		if (a.ProcessedAt == nil) != (b.ProcessedAt == nil) {
+			return a.ProcessedAt == nil
+		}
+		if a.ProcessedAt != nil && !a.ProcessedAt.Equal(*b.ProcessedAt) {
+			return a.ProcessedAt.Before(*b.ProcessedAt)
+		}
+		if a.PackCount != b.PackCount {
+			return packsFirst(a, b)
+		}
+		return a.DeviceAccount < b.DeviceAccount
+	})
+}
+
+// prioritize moves the available priority accounts to the front of candidates in listed
+// order, ahead of whatever the selection strategy chose. The rest keep their order.
+func prioritize(candidates []*Account, priority []string) {
+	if len(priority) == 0 || len(candidates) == 0 {
+		return
+	}
+
+	rank := make(map[string]int, len(priority))
+	for i, deviceAccount := range priority {
+		if _, exists := rank[deviceAccount]; !exists {
+			rank[deviceAccount] = i
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iRank, iPriority := rank[candidates[i].DeviceAccount]
+		jRank, jPriority := rank[candidates[j].DeviceAccount]
+		if iPriority != jPriority {
+			return iPriority
+		}
+		return iPriority && iRank < jRank
+	})
+}
+
+// warnIneligiblePriority logs the priority accounts that can't be handed out: not in the
+// pool (unmatched or excluded) or in a status that is never handed out. They are skipped.
+func warnIneligiblePriority(def *UnifiedPoolDefinition, accounts map[string]*Account) {
+	for _, deviceAccount := range def.Priority {
+		account, exists := accounts[deviceAccount]
+		switch {
+		case !exists:
+			fmt.Printf("Warning: Pool '%s' priority account '%s' is not in the pool (not matched, or excluded) - skipping\n",
+				def.PoolName, deviceAccount)
+		case account.Status != AccountStatusAvailable && account.Status != AccountStatusInUse:
+			fmt.Printf("Warning: Pool '%s' priority account '%s' is %s - skipping\n",
+				def.PoolName, deviceAccount, account.Status)
+		}
+	}
+}
+
+// stampClaimed records that an account was just handed out, so least-recently-used
+// selection moves it to the back
+func stampClaimed(account *Account, now time.Time) {
+	account.LastModified = now
+}
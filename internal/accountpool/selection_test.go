@@ -0,0 +1,378 @@
+package accountpool
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// newSelectionPool creates a sandbox pool serving accounts in the given order
+func newSelectionPool(t *testing.T, strategy SelectionStrategy, deviceAccounts ...string) *SandboxPool {
+	t.Helper()
+
+	def := &UnifiedPoolDefinition{
+		PoolName: "selection",
+		Sandbox:  true,
+		Config:   UnifiedPoolConfig{SelectionStrategy: string(strategy)},
+	}
+	for _, deviceAccount := range deviceAccounts {
+		def.SandboxAccounts = append(def.SandboxAccounts, SandboxAccount{DeviceAccount: deviceAccount})
+	}
+
+	pool, err := NewSandboxPool(nil, def, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
+
+// claimAndReturn takes the next account from pool n times, returning each one straight away
+func claimAndReturn(t *testing.T, pool AccountPool, n int) []string {
+	t.Helper()
+
+	claimed := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		account, err := pool.GetNext(context.Background())
+		if err != nil {
+			t.Fatalf("claim %d: %v", i+1, err)
+		}
+		claimed = append(claimed, account.DeviceAccount)
+		if err := pool.Return(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return claimed
+}
+
+func assertOrder(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSelectionSortBasedKeepsQueueOrder(t *testing.T) {
+	pool := newSelectionPool(t, SelectionSortBased, "c", "a", "b")
+
+	// Returned accounts go to the back of the queue
+	assertOrder(t, claimAndReturn(t, pool, 4), "c", "a", "b", "c")
+}
+
+func TestSelectionRoundRobinCyclesByDeviceAccount(t *testing.T) {
+	pool := newSelectionPool(t, SelectionRoundRobin, "c", "a", "b")
+
+	assertOrder(t, claimAndReturn(t, pool, 4), "a", "b", "c", "a")
+
+	// An account held by a bot is skipped without breaking the cycle
+	held, err := pool.GetNext(context.Background())
+	if err != nil || held.DeviceAccount != "b" {
+		t.Fatalf("got %v, %v; want b", held, err)
+	}
+	assertOrder(t, claimAndReturn(t, pool, 3), "c", "a", "c")
+}
+
+func TestSelectionLeastRecentlyUsedOrdersByLastUsed(t *testing.T) {
+	now := time.Now()
+	candidates := []*Account{
+		{DeviceAccount: "recent", LastModified: now.Add(-time.Hour)},
+		{DeviceAccount: "never"},
+		{DeviceAccount: "oldest", LastModified: now.Add(-48 * time.Hour)},
+		{DeviceAccount: "older", LastModified: now.Add(-24 * time.Hour)},
+	}
+	orderForSelection(candidates, SelectionLeastRecentlyUsed, "", "", nil)
+
+	got := make([]string, 0, len(candidates))
+	for _, account := range candidates {
+		got = append(got, account.DeviceAccount)
+	}
+	assertOrder(t, got, "never", "oldest", "older", "recent")
+
+	// Claiming stamps the account, so it moves behind the others
+	pool := newSelectionPool(t, SelectionLeastRecentlyUsed, "c", "a", "b")
+	assertOrder(t, claimAndReturn(t, pool, 4), "a", "b", "c", "a")
+}
+
+func TestSelectionRandomHandsOutEveryAccount(t *testing.T) {
+	candidates := []*Account{{DeviceAccount: "a"}, {DeviceAccount: "b"}, {DeviceAccount: "c"}, {DeviceAccount: "d"}}
+	orderForSelection(candidates, SelectionRandom, "", "", rand.New(rand.NewSource(1)))
+
+	got := make([]string, 0, len(candidates))
+	for _, account := range candidates {
+		got = append(got, account.DeviceAccount)
+	}
+	sort.Strings(got)
+	assertOrder(t, got, "a", "b", "c", "d")
+
+	// Without returns every account is handed out exactly once
+	pool := newSelectionPool(t, SelectionRandom, "a", "b", "c", "d")
+	seen := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		account, err := pool.GetNext(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[account.DeviceAccount] {
+			t.Fatalf("account %s handed out twice", account.DeviceAccount)
+		}
+		seen[account.DeviceAccount] = true
+	}
+	if _, err := pool.GetNext(context.Background()); err != ErrNoAccountsAvailable {
+		t.Fatalf("got %v, want ErrNoAccountsAvailable", err)
+	}
+}
+
+func TestSelectionStrategyValidation(t *testing.T) {
+	def := &UnifiedPoolDefinition{
+		PoolName: "selection",
+		Include:  []string{"a"},
+		Config:   UnifiedPoolConfig{SelectionStrategy: "fastest"},
+	}
+	if ValidatePoolDefinition(def).Valid {
+		t.Error("unknown selection strategy should be invalid")
+	}
+
+	def.Config.SelectionStrategy = string(SelectionLeastRecentlyUsed)
+	if result := ValidatePoolDefinition(def); !result.Valid {
+		t.Errorf("lru should be valid: %s", result.FormatErrors())
+	}
+}
+
+func TestSelectionWeightedRandomMatchesWeights(t *testing.T) {
+	accounts := []*Account{
+		{DeviceAccount: "none", PackCount: 0},
+		{DeviceAccount: "one", PackCount: 1},
+		{DeviceAccount: "two", PackCount: 2},
+		{DeviceAccount: "seven", PackCount: 7},
+	}
+	rng := rand.New(rand.NewSource(42))
+
+	const draws = 20000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		candidates := append([]*Account(nil), accounts...)
+		orderForSelection(candidates, SelectionWeightedRandom, "pack_count", "", rng)
+		counts[candidates[0].DeviceAccount]++
+	}
+
+	// Weights sum to 10, so each account should come first about PackCount/10 of the time
+	for _, account := range accounts {
+		want := float64(account.PackCount) / 10
+		got := float64(counts[account.DeviceAccount]) / draws
+		if got < want-0.02 || got > want+0.02 {
+			t.Errorf("%s picked %.3f of draws, want %.2f", account.DeviceAccount, got, want)
+		}
+	}
+
+	// Zero-weight accounts are still handed out once nothing else is left
+	pool := newSelectionPool(t, SelectionWeightedRandom, "a", "b")
+	for i := 0; i < 2; i++ {
+		if _, err := pool.GetNext(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSelectionWeightExpressions(t *testing.T) {
+	now := time.Now()
+	account := &Account{
+		PackCount:    4,
+		FailureCount: 1,
+		LastModified: now.Add(-2 * time.Hour),
+		Metadata:     map[string]string{"shinedust": "300"},
+	}
+
+	cases := map[string]float64{
+		"":                                 4,
+		"pack_count + 1":                   5,
+		"(pack_count - failure_count) * 2": 6,
+		"shinedust / 100":                  3,
+		"hours_since_used":                 2,
+		"failure_count - pack_count":       0, // Negative weights count as 0
+		"unknown_field + 1":                1,
+	}
+	for expr, want := range cases {
+		weight, err := parseWeightExpression(expr)
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+		if got := weight.Weight(account, now); got < want-0.001 || got > want+0.001 {
+			t.Errorf("%q = %v, want %v", expr, got, want)
+		}
+	}
+
+	def := &UnifiedPoolDefinition{
+		PoolName: "selection",
+		Include:  []string{"a"},
+		Config:   UnifiedPoolConfig{SelectionStrategy: string(SelectionWeightedRandom), SelectionWeight: "pack_count *"},
+	}
+	if ValidatePoolDefinition(def).Valid {
+		t.Error("malformed selection weight should be invalid")
+	}
+}
+
+func TestPriorityAccountsHandedOutFirst(t *testing.T) {
+	def := &UnifiedPoolDefinition{
+		PoolName: "priority",
+		Sandbox:  true,
+		Exclude:  []string{"excluded"},
+		Priority: []string{"d", "missing", "excluded", "b"},
+	}
+	for _, deviceAccount := range []string{"c", "a", "b", "d", "excluded"} {
+		def.SandboxAccounts = append(def.SandboxAccounts, SandboxAccount{DeviceAccount: deviceAccount})
+	}
+
+	// Ineligible priority accounts are skipped; the rest follow the selection strategy
+	pool, err := NewSandboxPool(nil, def, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed := make([]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		account, err := pool.GetNext(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		claimed = append(claimed, account.DeviceAccount)
+	}
+	assertOrder(t, claimed, "d", "b", "c", "a")
+
+	def.Priority = []string{"a", "a"}
+	if ValidatePoolDefinition(def).Valid {
+		t.Error("duplicate priority accounts should be invalid")
+	}
+}
+
+func TestMaxPacksPerAccountSkipsAccountsAtCeiling(t *testing.T) {
+	def := &UnifiedPoolDefinition{
+		PoolName: "ceiling",
+		Sandbox:  true,
+		Config:   UnifiedPoolConfig{MaxPacksPerAccount: 10},
+		SandboxAccounts: []SandboxAccount{
+			{DeviceAccount: "a", PackCount: 10},
+			{DeviceAccount: "b", PackCount: 3},
+			{DeviceAccount: "c", PackCount: 12},
+		},
+	}
+
+	pool, err := NewSandboxPool(nil, def, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := MaxPacksPerAccount(NewReservedPool(pool, nil)); got != 10 {
+		t.Errorf("MaxPacksPerAccount = %d, want 10", got)
+	}
+
+	// Only the account below the ceiling is handed out, and not again once it reaches it
+	account, err := pool.GetNext(context.Background())
+	if err != nil || account.DeviceAccount != "b" {
+		t.Fatalf("got %v (%v), want b", account, err)
+	}
+	account.PackCount = 10
+	if err := pool.Return(account); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.GetNext(context.Background()); err != ErrNoAccountsAvailable {
+		t.Errorf("expected ErrNoAccountsAvailable, got %v", err)
+	}
+
+	def.Config.MaxPacksPerAccount = -1
+	if ValidatePoolDefinition(def).Valid {
+		t.Error("a negative pack ceiling should be invalid")
+	}
+}
+
+func TestReserveSkipsAccountsAtCeiling(t *testing.T) {
+	dir := t.TempDir()
+	db, err := database.Open(filepath.Join(dir, "accounts.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	packs := map[string]int{"a": 10, "b": 3, "c": 12}
+	for deviceAccount, count := range packs {
+		if _, err := db.CreateAccount(deviceAccount, "password", ""); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+		if _, err := db.Conn().Exec(`UPDATE accounts SET packs_opened = ? WHERE device_account = ?`, count, deviceAccount); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	definitionPath := filepath.Join(dir, "ceiling.yaml")
+	definition := "pool_name: ceiling\ninclude:\n  - a\n  - b\n  - c\nconfig:\n  max_packs_per_account: 10\n"
+	if err := os.WriteFile(definitionPath, []byte(definition), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := NewUnifiedAccountPool(db.Conn(), definitionPath, filepath.Join(dir, "xml"))
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+
+	// Only the account below the ceiling is reserved, and not again once it reaches it
+	reserved, err := pool.Reserve(3)
+	if err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if len(reserved) != 1 || reserved[0].DeviceAccount != "b" {
+		t.Fatalf("reserved %d accounts, want only b", len(reserved))
+	}
+	reserved[0].PackCount = 10
+	if err := pool.Return(reserved[0]); err != nil {
+		t.Fatal(err)
+	}
+	if reserved, err := pool.Reserve(3); err != nil || len(reserved) != 0 {
+		t.Errorf("reserved %d accounts (%v), want none", len(reserved), err)
+	}
+}
+
+func TestOrderForRefillSortsByCurrentPacks(t *testing.T) {
+	processed := func(minutesAgo int) *time.Time {
+		at := time.Now().Add(-time.Duration(minutesAgo) * time.Minute)
+		return &at
+	}
+	newAccounts := func() []*Account {
+		return []*Account{
+			{DeviceAccount: "low", PackCount: 1},
+			{DeviceAccount: "high", PackCount: 9},
+			{DeviceAccount: "retried_recent", PackCount: 20, ProcessedAt: processed(1)},
+			{DeviceAccount: "mid", PackCount: 5},
+			{DeviceAccount: "retried_old", PackCount: 0, ProcessedAt: processed(30)},
+		}
+	}
+	names := func(accounts []*Account) []string {
+		result := make([]string, len(accounts))
+		for i, account := range accounts {
+			result[i] = account.DeviceAccount
+		}
+		return result
+	}
+
+	// Accounts not yet served come first by packs, then retried accounts oldest first
+	accounts := newAccounts()
+	orderForRefill(accounts, "packs_desc")
+	assertOrder(t, names(accounts), "high", "mid", "low", "retried_old", "retried_recent")
+
+	accounts = newAccounts()
+	orderForRefill(accounts, "packs_asc")
+	assertOrder(t, names(accounts), "low", "mid", "high", "retried_old", "retried_recent")
+
+	// Other sort methods keep the queue order
+	accounts = newAccounts()
+	orderForRefill(accounts, "modified_asc")
+	assertOrder(t, names(accounts), "low", "high", "retried_recent", "mid", "retried_old")
+}
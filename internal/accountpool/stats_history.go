@@ -0,0 +1,143 @@
+package accountpool
+
+import (
+	"fmt"
+	"time"
+)
+
+// PoolStatsSample is one snapshot of a pool's statistics (see StartStatsSampler)
+type PoolStatsSample struct {
+	PoolName  string
+	Timestamp time.Time
+	Total     int
+	Available int
+	InUse     int
+	Completed int
+	Failed    int
+}
+
+// StartStatsSampler starts recording the statistics of every open pool to the
+// pool_stats_history table every interval. Nothing is written while no pools are open.
+// Does nothing if interval is 0 or the sampler is already running.
+func (pm *PoolManager) StartStatsSampler(interval time.Duration) {
+	if interval <= 0 || pm.db == nil {
+		return
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.samplerStop != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	pm.samplerStop = stop
+	pm.samplerDone = done
+
+	go pm.runStatsSampler(interval, stop, done)
+}
+
+// StopStatsSampler stops the background statistics sampler
+func (pm *PoolManager) StopStatsSampler() {
+	pm.mu.Lock()
+	stop, done := pm.samplerStop, pm.samplerDone
+	pm.samplerStop = nil
+	pm.samplerDone = nil
+	pm.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// runStatsSampler samples open pools until stop is closed
+func (pm *PoolManager) runStatsSampler(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if err := pm.SampleStats(now); err != nil {
+				fmt.Printf("Failed to record pool statistics: %v\n", err)
+			}
+		}
+	}
+}
+
+// SampleStats records the current statistics of every open pool, stamped with now
+func (pm *PoolManager) SampleStats(now time.Time) error {
+	stats := pm.OpenPoolStats()
+	if len(stats) == 0 {
+		return nil
+	}
+
+	tx, err := pm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO pool_stats_history (pool_name, sampled_at, total, available, in_use, completed, failed)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for name, s := range stats {
+		if _, err := stmt.Exec(name, now.UTC(), s.Total, s.Available, s.InUse, s.Completed, s.Failed); err != nil {
+			return fmt.Errorf("failed to record statistics for pool '%s': %w", name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PoolStatsHistory returns the statistics samples recorded for a pool since the given time, oldest first
+func (pm *PoolManager) PoolStatsHistory(name string, since time.Time) ([]PoolStatsSample, error) {
+	if pm.db == nil {
+		return nil, fmt.Errorf("database not configured")
+	}
+
+	rows, err := pm.db.Query(`
+		SELECT pool_name, sampled_at, total, available, in_use, completed, failed
+		FROM pool_stats_history
+		WHERE pool_name = ? AND sampled_at >= ?
+		ORDER BY sampled_at
+	`, name, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pool statistics history: %w", err)
+	}
+	defer rows.Close()
+
+	samples := make([]PoolStatsSample, 0)
+	for rows.Next() {
+		var sample PoolStatsSample
+		if err := rows.Scan(
+			&sample.PoolName,
+			&sample.Timestamp,
+			&sample.Total,
+			&sample.Available,
+			&sample.InUse,
+			&sample.Completed,
+			&sample.Failed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan pool statistics: %w", err)
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -22,11 +24,14 @@ type UnifiedAccountPool struct {
 	available    chan *Account
 	config       PoolConfig
 	closed       bool
-	stopRefresh  chan struct{}
 	lastRefresh  time.Time
 	stats        PoolStats
 	xmlStorageDir string // Global XML storage directory
 	eventBus     interface{} // events.EventBus - interface{} to avoid circular import
+	affinity     map[string]int // Instance each pinned account runs on, by device_account (see SetAffinity)
+	selectionCursor string     // Device account handed out last (see SelectionRoundRobin)
+	rng          *rand.Rand     // Random source for SelectionRandom and SelectionWeightedRandom (guarded by mu)
+	leaseHolder  string         // This pool's pool_leases holder ID ("" = accounts aren't locked across processes)
 }
 
 // UnifiedPoolDefinition defines a unified pool configuration
@@ -36,7 +41,11 @@ type UnifiedPoolDefinition struct {
 	Queries     []QuerySource      `yaml:"queries,omitempty"`      // Query sources (optional)
 	Include     []string           `yaml:"include,omitempty"`      // Manual inclusions (optional)
 	Exclude     []string           `yaml:"exclude,omitempty"`      // Manual exclusions (optional)
+	Priority    []string           `yaml:"priority,omitempty"`     // Accounts handed out before all others, in listed order, by device_account (optional)
 	WatchedPaths []string          `yaml:"watched_paths,omitempty"` // Folders to import from (optional)
+	Affinity    map[string]int     `yaml:"affinity,omitempty"`     // Emulator instance to run each listed account on, by device_account (optional)
+	Sandbox     bool               `yaml:"sandbox,omitempty"`      // Serve accounts from memory without writing account state to the database (see SandboxPool)
+	SandboxAccounts []SandboxAccount `yaml:"sandbox_accounts,omitempty"` // Test accounts a sandbox pool serves besides its queries and inclusions (optional)
 	Config      UnifiedPoolConfig  `yaml:"config"`                 // Pool configuration
 }
 
@@ -47,16 +56,63 @@ type QuerySource struct {
 	Filters []QueryFilter `yaml:"filters,omitempty"` // Filter conditions (combined with AND)
 	Sort    []SortOrder   `yaml:"sort,omitempty"`    // Sort orders (applied in sequence)
 	Limit   int           `yaml:"limit,omitempty"`   // Result limit (0 = no limit)
+
+	// Tags selects accounts carrying any of these tags (see database.TagAccount), ANDed with the
+	// filters. Membership is resolved on every refresh, so tagging an account adds it to the pool.
+	Tags []string `yaml:"tags,omitempty"`
+
+	// Advanced mode: a raw SELECT used instead of the structured filters. It must return
+	// device_account, device_password, shinedust, packs_opened, last_used_at (in that order)
+	RawSQL     string            `yaml:"raw_sql,omitempty"`
+	Parameters map[string]string `yaml:"parameters,omitempty"` // Named parameters for RawSQL (:name, @name or $name)
+}
+
+// normalizedTags returns the query's tags trimmed and lower-cased like database.NormalizeTag,
+// skipping blank ones
+func (q *QuerySource) normalizedTags() []string {
+	tags := make([]string, 0, len(q.Tags))
+	for _, tag := range q.Tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// IsAdvanced returns true if the query uses raw SQL instead of structured filters
+func (q *QuerySource) IsAdvanced() bool {
+	return strings.TrimSpace(q.RawSQL) != ""
 }
 
 // QueryFilter represents a single filter condition
 type QueryFilter struct {
 	Column     string `yaml:"column"`              // Database column name (e.g., "packs_opened")
-	Comparator string `yaml:"comparator"`          // Comparison operator (e.g., ">=", "=", "<", "LIKE")
-	Value      string `yaml:"value"`               // Comparison value
+	Comparator string `yaml:"comparator"`          // Comparison operator (e.g., ">=", "=", "<", "LIKE", "IN", "IS NULL")
+	Value      string `yaml:"value"`               // Comparison value (comma-separated for IN / NOT IN, unused for IS NULL)
 	Enabled    *bool  `yaml:"enabled,omitempty"`   // Whether this filter is active (default: true if omitted)
 }
 
+// IsNullCheck returns true if the comparator takes no value (IS NULL / IS NOT NULL)
+func (f *QueryFilter) IsNullCheck() bool {
+	return f.Comparator == "IS NULL" || f.Comparator == "IS NOT NULL"
+}
+
+// IsSetCheck returns true if the comparator takes a comma-separated set of values (IN / NOT IN)
+func (f *QueryFilter) IsSetCheck() bool {
+	return f.Comparator == "IN" || f.Comparator == "NOT IN"
+}
+
+// SetValues splits the value of an IN / NOT IN filter into its trimmed, non-empty items
+func (f *QueryFilter) SetValues() []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(f.Value, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // IsEnabled returns true if the filter is enabled (default: true)
 func (f *QueryFilter) IsEnabled() bool {
 	if f.Enabled == nil {
@@ -80,14 +136,21 @@ func (s *SortOrder) IsEnabled() bool {
 	return *s.Enabled
 }
 
-// GenerateSQL generates a SQL query from structured filters
+// QueryBaseSQL selects the columns every query must return, in order
+const QueryBaseSQL = "SELECT device_account, device_password, shinedust, packs_opened, last_used_at\nFROM accounts\n"
+
+// GenerateSQL generates a SQL query from structured filters, or returns the raw SQL
+// with its named parameters in advanced mode
 func (q *QuerySource) GenerateSQL() (string, []interface{}) {
+	if q.IsAdvanced() {
+		return q.rawSQL()
+	}
+
 	var sb strings.Builder
 	params := make([]interface{}, 0)
 
 	// Base SELECT statement
-	sb.WriteString("SELECT device_account, device_password, shinedust, packs_opened, last_used_at\n")
-	sb.WriteString("FROM accounts\n")
+	sb.WriteString(QueryBaseSQL)
 
 	// WHERE clause from enabled filters only
 	hasWhere := false
@@ -104,10 +167,42 @@ func (q *QuerySource) GenerateSQL() (string, []interface{}) {
 		sb.WriteString(filter.Column)
 		sb.WriteString(" ")
 		sb.WriteString(filter.Comparator)
-		sb.WriteString(" ?")
 
-		// Add parameter value
-		params = append(params, filter.Value)
+		// Add parameter value(s)
+		switch {
+		case filter.IsNullCheck():
+		case filter.IsSetCheck():
+			values := filter.SetValues()
+			sb.WriteString(" (")
+			for i, value := range values {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString("?")
+				params = append(params, value)
+			}
+			sb.WriteString(")")
+		default:
+			sb.WriteString(" ?")
+			params = append(params, filter.Value)
+		}
+	}
+	if tags := q.normalizedTags(); len(tags) > 0 {
+		if !hasWhere {
+			sb.WriteString("WHERE ")
+			hasWhere = true
+		} else {
+			sb.WriteString("\n  AND ")
+		}
+		sb.WriteString("id IN (SELECT account_id FROM account_tags WHERE tag IN (")
+		for i, tag := range tags {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("?")
+			params = append(params, tag)
+		}
+		sb.WriteString("))")
 	}
 	if hasWhere {
 		sb.WriteString("\n")
@@ -142,12 +237,35 @@ func (q *QuerySource) GenerateSQL() (string, []interface{}) {
 	return sb.String(), params
 }
 
+// rawSQL returns the advanced mode SQL with its parameters as sql.Named values (in name order)
+func (q *QuerySource) rawSQL() (string, []interface{}) {
+	names := make([]string, 0, len(q.Parameters))
+	for name := range q.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		params = append(params, sql.Named(name, q.Parameters[name]))
+	}
+
+	sqlQuery := strings.TrimSpace(q.RawSQL)
+	sqlQuery = strings.TrimSpace(strings.TrimSuffix(sqlQuery, ";"))
+	return sqlQuery, params
+}
+
 // UnifiedPoolConfig holds pool behavior configuration
 type UnifiedPoolConfig struct {
 	SortMethod      string `yaml:"sort_method"`       // "packs_asc", "packs_desc", "modified_asc", "modified_desc"
 	RetryFailed     bool   `yaml:"retry_failed"`      // Whether to retry failed accounts
 	MaxFailures     int    `yaml:"max_failures"`      // Max times to retry
 	RefreshInterval int    `yaml:"refresh_interval"` // Seconds between auto-refresh (0 = disabled)
+	AffinityPolicy  string `yaml:"affinity_policy,omitempty"` // "fallback" (default) or "wait": whether pinned accounts may run on other instances
+	SelectionStrategy string `yaml:"selection_strategy,omitempty"` // "sort" (default), "round_robin", "lru", "random" or "weighted_random": which account is handed out next
+	SelectionWeight   string `yaml:"selection_weight,omitempty"`   // Weight expression for "weighted_random", e.g. "pack_count + 1" (default: pack_count)
+	MaxPacksPerAccount int   `yaml:"max_packs_per_account,omitempty"` // Packs an account may open before it's completed and skipped (0 = no ceiling)
+	MaxRiskScore       float64 `yaml:"max_risk_score,omitempty"`      // Accounts with a higher ban risk score (0-100) are excluded (0 = disabled)
 }
 
 // NewUnifiedAccountPool creates a new unified account pool
@@ -175,7 +293,8 @@ func NewUnifiedAccountPool(db *sql.DB, definitionPath string, xmlStorageDir stri
 		accounts:      make(map[string]*Account),
 		available:     make(chan *Account, 100),
 		xmlStorageDir: xmlStorageDir,
-		stopRefresh:   make(chan struct{}),
+		affinity:      make(map[string]int, len(def.Affinity)),
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
 		config: PoolConfig{
 			RetryFailed: def.Config.RetryFailed,
 			MaxFailures: def.Config.MaxFailures,
@@ -183,16 +302,16 @@ func NewUnifiedAccountPool(db *sql.DB, definitionPath string, xmlStorageDir stri
 		},
 	}
 
+	for deviceAccount, instanceID := range def.Affinity {
+		pool.affinity[deviceAccount] = instanceID
+	}
+	pool.enableLeases()
+
 	// Initial refresh to populate accounts
 	if err := pool.refresh(); err != nil {
 		return nil, fmt.Errorf("initial refresh failed: %w", err)
 	}
 
-	// Start auto-refresh if configured
-	if def.Config.RefreshInterval > 0 {
-		go pool.autoRefresh()
-	}
-
 	return pool, nil
 }
 
@@ -223,9 +342,15 @@ func loadUnifiedPoolDefinition(path string) (*UnifiedPoolDefinition, error) {
 
 // refresh executes account resolution: queries → include → exclude → watched paths
 func (p *UnifiedAccountPool) refresh() error {
+	risks := p.accountRisks()
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.closed {
+		return ErrPoolClosed
+	}
+
 	resolvedAccounts := make(map[string]*Account)
 
 	// Step 1: Execute all queries
@@ -270,6 +395,16 @@ func (p *UnifiedAccountPool) refresh() error {
 		delete(resolvedAccounts, deviceAccount)
 	}
 
+	// Step 5: Exclude accounts likely to get banned
+	p.withoutHighRisk(resolvedAccounts, risks)
+
+	// Keep accounts that are checked out even if they no longer match, so they can still be returned
+	for deviceAccount, oldAccount := range p.accounts {
+		if _, exists := resolvedAccounts[deviceAccount]; !exists && oldAccount.Status == AccountStatusInUse {
+			resolvedAccounts[deviceAccount] = oldAccount
+		}
+	}
+
 	// Preserve runtime state for accounts that still exist
 	oldAccounts := p.accounts
 	p.accounts = resolvedAccounts
@@ -284,20 +419,25 @@ func (p *UnifiedAccountPool) refresh() error {
 			newAccount.Result = oldAccount.Result
 			newAccount.FailureCount = oldAccount.FailureCount
 			newAccount.LastError = oldAccount.LastError
+			if oldAccount.LastModified.After(newAccount.LastModified) {
+				newAccount.LastModified = oldAccount.LastModified
+			}
 		}
 	}
 
 	// Sort accounts
 	p.sortAccounts()
+	warnIneligiblePriority(p.definition, p.accounts)
 
 	// Refill available channel
 	p.refillAvailableChannel()
 
+	p.lastRefresh = time.Now()
+	p.renewLeases()
+
 	// Update stats
 	p.updateStats()
 
-	p.lastRefresh = time.Now()
-
 	// Publish pool refreshed event if event bus is set
 	p.publishPoolRefreshed()
 
@@ -333,18 +473,28 @@ func (p *UnifiedAccountPool) publishPoolRefreshed() {
 
 // executeQuery executes a single query and returns accounts
 func (p *UnifiedAccountPool) executeQuery(query QuerySource) ([]*Account, error) {
+	return queryAccounts(p.db, query)
+}
+
+// queryAccounts runs a query source against the accounts table
+func queryAccounts(db *sql.DB, query QuerySource) ([]*Account, error) {
 	// Generate SQL from structured filters
 	sqlQuery, params := query.GenerateSQL()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	rows, err := p.db.QueryContext(ctx, sqlQuery, params...)
+	rows, err := db.QueryContext(ctx, sqlQuery, params...)
 	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
 	}
 	defer rows.Close()
 
+	return scanQueryRows(rows)
+}
+
+// scanQueryRows reads accounts from the result of a generated query (see QuerySource.GenerateSQL)
+func scanQueryRows(rows *sql.Rows) ([]*Account, error) {
 	accounts := make([]*Account, 0)
 
 	for rows.Next() {
@@ -394,6 +544,11 @@ func (p *UnifiedAccountPool) executeQuery(query QuerySource) ([]*Account, error)
 
 // fetchAccountFromDB retrieves a single account by device_account
 func (p *UnifiedAccountPool) fetchAccountFromDB(deviceAccount string) (*Account, error) {
+	return fetchAccount(p.db, deviceAccount)
+}
+
+// fetchAccount reads a single account by device_account from the accounts table
+func fetchAccount(db *sql.DB, deviceAccount string) (*Account, error) {
 	query := `
 		SELECT device_account, device_password, shinedust, packs_opened, last_used_at
 		FROM accounts
@@ -407,7 +562,7 @@ func (p *UnifiedAccountPool) fetchAccountFromDB(deviceAccount string) (*Account,
 	var lastUsedStr sql.NullString
 	var shinedust, packsOpened int
 
-	err := p.db.QueryRow(query, deviceAccount).Scan(
+	err := db.QueryRow(query, deviceAccount).Scan(
 		&account.DeviceAccount,
 		&account.DevicePassword,
 		&shinedust,
@@ -554,26 +709,41 @@ func (p *UnifiedAccountPool) refillAvailableChannel() {
 		<-p.available
 	}
 
-	// Refill with available accounts
+	// Refill with available accounts; in-use accounts aren't queued, so they keep their place
+	available := make([]*Account, 0, len(p.accounts))
 	for _, account := range p.accounts {
-		if account.Status == AccountStatusAvailable {
-			select {
-			case p.available <- account:
-			default:
-				// Channel full
-				return
-			}
+		if account.Status == AccountStatusAvailable && !reachedPackLimit(p.definition.Config, account) {
+			available = append(available, account)
+		}
+	}
+
+	// Re-sort by the pack counts just read from the database
+	if selectionStrategyOf(p.definition.Config) == SelectionSortBased {
+		orderForRefill(available, p.definition.Config.SortMethod)
+	}
+
+	for _, account := range available {
+		select {
+		case p.available <- account:
+		default:
+			// Channel full
+			return
 		}
 	}
 }
 
 // updateStats recalculates pool statistics
 func (p *UnifiedAccountPool) updateStats() {
+	p.stats = computeStats(p.accounts, p.lastRefresh)
+}
+
+// computeStats tallies statistics for a pool's accounts
+func computeStats(accounts map[string]*Account, lastRefresh time.Time) PoolStats {
 	stats := PoolStats{
-		LastRefresh: p.lastRefresh,
+		LastRefresh: lastRefresh,
 	}
 
-	for _, account := range p.accounts {
+	for _, account := range accounts {
 		stats.Total++
 
 		switch account.Status {
@@ -593,83 +763,107 @@ func (p *UnifiedAccountPool) updateStats() {
 			stats.Failed++
 		case AccountStatusSkipped:
 			stats.Skipped++
+		case AccountStatusBanned:
+			stats.Banned++
+		case AccountStatusPreserved:
+			stats.Preserved++
 		}
 	}
 
-	p.stats = stats
+	return stats
 }
 
-// autoRefresh periodically refreshes the pool
-func (p *UnifiedAccountPool) autoRefresh() {
-	if p.definition.Config.RefreshInterval == 0 {
-		return
-	}
+// RefreshInterval returns how often the pool should be re-resolved (0 = never)
+func (p *UnifiedAccountPool) RefreshInterval() time.Duration {
+	return time.Duration(p.definition.Config.RefreshInterval) * time.Second
+}
+
+// LastRefreshed returns when the pool was last re-resolved
+func (p *UnifiedAccountPool) LastRefreshed() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastRefresh
+}
 
-	interval := time.Duration(p.definition.Config.RefreshInterval) * time.Second
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+// GetNext implements AccountPool.GetNext
+func (p *UnifiedAccountPool) GetNext(ctx context.Context) (*Account, error) {
+	// Callers without an instance must still respect accounts pinned to instances, and
+	// priority accounts and strategies other than the queue order choose among all available accounts
+	if p.hasAffinity() || len(p.definition.Priority) > 0 || selectionStrategyOf(p.definition.Config) != SelectionSortBased {
+		return p.GetNextForInstance(ctx, NoInstance)
+	}
 
+	contended := 0
 	for {
 		select {
-		case <-p.stopRefresh:
-			return
-		case <-ticker.C:
-			if err := p.refresh(); err != nil {
-				fmt.Printf("Auto-refresh failed for pool '%s': %v\n", p.definition.PoolName, err)
+		case account := <-p.available:
+			// Check if pool was closed while waiting
+			p.mu.RLock()
+			if p.closed {
+				p.mu.RUnlock()
+				// Try to return account to pool if possible
+				select {
+				case p.available <- account:
+				default:
+					// Channel was closed or full, account will be lost
+				}
+				return nil, ErrPoolClosed
 			}
-		}
-	}
-}
 
-// GetNext implements AccountPool.GetNext
-func (p *UnifiedAccountPool) GetNext(ctx context.Context) (*Account, error) {
-	select {
-	case account := <-p.available:
-		// Check if pool was closed while waiting
-		p.mu.RLock()
-		if p.closed {
-			p.mu.RUnlock()
-			// Try to return account to pool if possible
-			select {
-			case p.available <- account:
-			default:
-				// Channel was closed or full, account will be lost
+			// Another process may have claimed the account; it stays queued for later
+			claimed, err := p.claimLease(account)
+			if err != nil || !claimed {
+				p.requeue(account)
+				queued := len(p.available)
+				p.mu.RUnlock()
+				if err != nil {
+					return nil, err
+				}
+				if contended++; contended >= queued {
+					return nil, ErrNoAccountsAvailable
+				}
+				continue
 			}
-			return nil, ErrPoolClosed
-		}
 
-		// Mark as in use
-		account.Status = AccountStatusInUse
-		now := time.Now()
-		account.AssignedAt = &now
-		p.mu.RUnlock()
+			// Mark as in use
+			account.Status = AccountStatusInUse
+			now := time.Now()
+			account.AssignedAt = &now
+			stampClaimed(account, now)
+			p.mu.RUnlock()
 
-		// Ensure XML exists
-		if err := p.ensureXMLExists(account); err != nil {
-			return nil, fmt.Errorf("failed to ensure XML exists: %w", err)
-		}
+			// Ensure XML exists
+			if err := p.ensureXMLExists(account); err != nil {
+				return nil, fmt.Errorf("failed to ensure XML exists: %w", err)
+			}
 
-		return account, nil
+			return account, nil
 
-	case <-ctx.Done():
-		return nil, ctx.Err()
+		case <-ctx.Done():
+			return nil, ctx.Err()
 
-	default:
-		// Quick check if pool is closed
-		p.mu.RLock()
-		closed := p.closed
-		p.mu.RUnlock()
+		default:
+			// Quick check if pool is closed
+			p.mu.RLock()
+			closed := p.closed
+			p.mu.RUnlock()
 
-		if closed {
-			return nil, ErrPoolClosed
+			if closed {
+				return nil, ErrPoolClosed
+			}
+			return nil, ErrNoAccountsAvailable
 		}
-		return nil, ErrNoAccountsAvailable
 	}
 }
 
 // ensureXMLExists ensures the account has an XML file in global storage
 func (p *UnifiedAccountPool) ensureXMLExists(account *Account) error {
-	xmlPath := filepath.Join(p.xmlStorageDir, account.DeviceAccount+".xml")
+	return ensureAccountXML(p.xmlStorageDir, account)
+}
+
+// ensureAccountXML sets account.XMLPath to the account's XML in xmlStorageDir, generating it if missing
+func ensureAccountXML(xmlStorageDir string, account *Account) error {
+	xmlPath := filepath.Join(xmlStorageDir, account.DeviceAccount+".xml")
 
 	// Check if file exists
 	if _, err := os.Stat(xmlPath); err == nil {
@@ -690,6 +884,30 @@ func (p *UnifiedAccountPool) ensureXMLExists(account *Account) error {
 	return nil
 }
 
+// drainAvailable takes every available account out of the queue, in queue order. The caller must hold p.mu.
+func (p *UnifiedAccountPool) drainAvailable() []*Account {
+	candidates := make([]*Account, 0, len(p.available))
+	for {
+		select {
+		case account := <-p.available:
+			if account.Status == AccountStatusAvailable {
+				candidates = append(candidates, account)
+			}
+		default:
+			return candidates
+		}
+	}
+}
+
+// requeue puts an account back at the end of the queue. The caller must hold p.mu.
+func (p *UnifiedAccountPool) requeue(account *Account) {
+	select {
+	case p.available <- account:
+	default:
+		// Channel full
+	}
+}
+
 // Return implements AccountPool.Return
 func (p *UnifiedAccountPool) Return(account *Account) error {
 	p.mu.Lock()
@@ -699,9 +917,15 @@ func (p *UnifiedAccountPool) Return(account *Account) error {
 		return ErrPoolClosed
 	}
 
+	// Preserved accounts never go back into rotation
+	if p.isPreserved(account) {
+		return nil
+	}
+
 	account.Status = AccountStatusAvailable
 	account.AssignedAt = nil
 	account.AssignedTo = 0
+	p.releaseLease(account)
 
 	// Add back to channel
 	select {
@@ -713,6 +937,96 @@ func (p *UnifiedAccountPool) Return(account *Account) error {
 	return nil
 }
 
+// Reserve implements AccountPool.Reserve
+func (p *UnifiedAccountPool) Reserve(n int) ([]*Account, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("cannot reserve %d accounts", n)
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+
+	// Claim everything under the lock so concurrent reservations never share an account
+	candidates := withoutPackLimited(p.definition, p.drainAvailable())
+	orderForSelection(candidates, selectionStrategyOf(p.definition.Config), p.definition.Config.SelectionWeight, p.selectionCursor, p.rng)
+	prioritize(candidates, p.definition.Priority)
+
+	reserved := make([]*Account, 0, n)
+	now := time.Now()
+	var claimErr error
+	for _, account := range candidates {
+		if len(reserved) == n {
+			p.requeue(account)
+			continue
+		}
+		claimed, err := p.claimLease(account)
+		if err != nil || !claimed {
+			// Held by another process (or unclaimable right now); it stays queued
+			if claimErr == nil {
+				claimErr = err
+			}
+			p.requeue(account)
+			continue
+		}
+		account.Status = AccountStatusInUse
+		account.AssignedAt = &now
+		stampClaimed(account, now)
+		p.selectionCursor = account.DeviceAccount
+		reserved = append(reserved, account)
+	}
+
+	p.updateStats()
+	p.mu.Unlock()
+
+	if len(reserved) == 0 && claimErr != nil {
+		return nil, claimErr
+	}
+
+	// Ensure XMLs exist (outside the lock, like GetNext)
+	for _, account := range reserved {
+		if err := p.ensureXMLExists(account); err != nil {
+			p.ReleaseReservation(reserved)
+			return nil, fmt.Errorf("failed to ensure XML exists: %w", err)
+		}
+	}
+
+	return reserved, nil
+}
+
+// ReleaseReservation implements AccountPool.ReleaseReservation
+func (p *UnifiedAccountPool) ReleaseReservation(accounts []*Account) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	for _, account := range accounts {
+		// Skip accounts that were used or changed since being reserved
+		current, exists := p.accounts[account.DeviceAccount]
+		if !exists || current.Status != AccountStatusInUse {
+			continue
+		}
+
+		current.Status = AccountStatusAvailable
+		current.AssignedAt = nil
+		current.AssignedTo = 0
+		p.releaseLease(current)
+
+		select {
+		case p.available <- current:
+		default:
+			// Channel full
+		}
+	}
+
+	p.updateStats()
+}
+
 // MarkUsed implements AccountPool.MarkUsed
 func (p *UnifiedAccountPool) MarkUsed(account *Account, result AccountResult) error {
 	p.mu.Lock()
@@ -722,9 +1036,17 @@ func (p *UnifiedAccountPool) MarkUsed(account *Account, result AccountResult) er
 		return ErrPoolClosed
 	}
 
+	// Keep the preserved status; the result is still recorded
+	if p.isPreserved(account) {
+		account.Result = &result
+		p.updateStats()
+		return nil
+	}
+
 	account.Result = &result
 	now := time.Now()
 	account.ProcessedAt = &now
+	p.releaseLease(account)
 
 	if result.Success {
 		account.Status = AccountStatusCompleted
@@ -756,14 +1078,116 @@ func (p *UnifiedAccountPool) MarkFailed(account *Account, reason string) error {
 		return ErrPoolClosed
 	}
 
+	if p.isPreserved(account) {
+		return nil
+	}
+
 	account.FailureCount++
 	account.LastError = reason
 	account.Status = AccountStatusFailed
+	p.releaseLease(account)
+
+	p.updateStats()
+	return nil
+}
+
+// ReturnWithStatus implements AccountPool.ReturnWithStatus
+func (p *UnifiedAccountPool) ReturnWithStatus(account *Account, status AccountStatus, reason string) error {
+	if status == AccountStatusAvailable {
+		return p.Return(account)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	// Only a manual status change (SetAccountStatus) can take an account out of preservation
+	if status != AccountStatusPreserved && p.isPreserved(account) {
+		return nil
+	}
+
+	now := time.Now()
+	apply := func(a *Account) {
+		a.Status = status
+		a.AssignedAt = nil
+		a.AssignedTo = 0
+		a.ProcessedAt = &now
+		if reason != "" {
+			a.LastError = reason
+		}
+		if status == AccountStatusFailed {
+			a.FailureCount++
+		}
+	}
+
+	apply(account)
+	p.releaseLease(account)
+
+	// The caller may hold a clone; update the pool's record so refreshes keep the status
+	if stored, exists := p.accounts[account.DeviceAccount]; exists && stored != account {
+		apply(stored)
+	}
 
 	p.updateStats()
 	return nil
 }
 
+// SetAccountStatus overrides the runtime status of an account after it was changed manually.
+// Returns false if the account is not in this pool.
+func (p *UnifiedAccountPool) SetAccountStatus(deviceAccount string, status AccountStatus, resetFailures bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	account, exists := p.accounts[deviceAccount]
+	if !exists || p.closed {
+		return false
+	}
+
+	wasAvailable := account.Status == AccountStatusAvailable
+	account.Status = status
+	account.AssignedAt = nil
+	account.AssignedTo = 0
+	if resetFailures {
+		account.FailureCount = 0
+		account.LastError = ""
+	}
+
+	// Make newly available accounts assignable (and drop ones that no longer are)
+	if wasAvailable != (status == AccountStatusAvailable) {
+		p.refillAvailableChannel()
+	}
+
+	p.updateStats()
+	p.publishPoolRefreshed()
+	return true
+}
+
+// isPreserved reports whether an account, or the pool's record of it, was preserved.
+// The caller must hold p.mu.
+func (p *UnifiedAccountPool) isPreserved(account *Account) bool {
+	if account.Status == AccountStatusPreserved {
+		return true
+	}
+	stored, exists := p.accounts[account.DeviceAccount]
+	return exists && stored.Status == AccountStatusPreserved
+}
+
+// AddExclusion adds a device account to the pool's exclude list so later refreshes drop it.
+// Returns false if it was already excluded. Only the open pool is changed; see PoolManager.ExcludeAccount.
+func (p *UnifiedAccountPool) AddExclusion(deviceAccount string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if containsString(p.definition.Exclude, deviceAccount) {
+		return false
+	}
+	p.definition.Exclude = append(p.definition.Exclude, deviceAccount)
+	return true
+}
+
 // GetByID implements AccountPool.GetByID
 func (p *UnifiedAccountPool) GetByID(id string) (*Account, error) {
 	p.mu.RLock()
@@ -812,8 +1236,8 @@ func (p *UnifiedAccountPool) Close() error {
 	}
 
 	p.closed = true
-	close(p.stopRefresh)
 	close(p.available)
+	p.releaseAllLeases()
 
 	return nil
 }
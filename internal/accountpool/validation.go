@@ -2,6 +2,7 @@ package accountpool
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ValidationResult contains the results of a validation check
@@ -52,57 +53,29 @@ func ValidatePoolDefinition(def *UnifiedPoolDefinition) *ValidationResult {
 
 	// Validate that at least one source is defined
 	hasSource := len(def.Queries) > 0 || len(def.Include) > 0 || len(def.WatchedPaths) > 0
+	if def.Sandbox {
+		hasSource = hasSource || len(def.SandboxAccounts) > 0
+	}
 	if !hasSource {
 		result.AddError("Sources", "at least one source (queries, include, or watched_paths) must be defined")
 	}
 
-	// Validate queries
-	for i, query := range def.Queries {
-		if query.Name == "" {
-			result.AddError(fmt.Sprintf("Queries[%d].Name", i), "query name is required")
-		}
-
-		if len(query.Filters) == 0 {
-			result.AddError(fmt.Sprintf("Queries[%d].Filters", i), "at least one filter must be defined")
-		}
-
-		// Validate filters
-		for j, filter := range query.Filters {
-			if filter.Column == "" {
-				result.AddError(fmt.Sprintf("Queries[%d].Filters[%d].Column", i, j), "column name is required")
-			}
-
-			if filter.Comparator == "" {
-				result.AddError(fmt.Sprintf("Queries[%d].Filters[%d].Comparator", i, j), "comparator is required")
-			}
-
-			// Validate comparator is a known operator
-			validComparators := map[string]bool{
-				"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
-				"LIKE": true, "NOT LIKE": true, "IN": true, "NOT IN": true,
-			}
-			if !validComparators[filter.Comparator] {
-				result.AddError(fmt.Sprintf("Queries[%d].Filters[%d].Comparator", i, j),
-					fmt.Sprintf("invalid comparator '%s'", filter.Comparator))
-			}
-		}
-
-		// Validate sorts
-		for j, sort := range query.Sort {
-			if sort.Column == "" {
-				result.AddError(fmt.Sprintf("Queries[%d].Sort[%d].Column", i, j), "column name is required")
-			}
-
-			if sort.Direction != "asc" && sort.Direction != "desc" && sort.Direction != "ASC" && sort.Direction != "DESC" {
-				result.AddError(fmt.Sprintf("Queries[%d].Sort[%d].Direction", i, j),
-					fmt.Sprintf("direction must be 'asc' or 'desc', got '%s'", sort.Direction))
-			}
+	// Validate sandbox (watched paths import accounts into the database)
+	if def.Sandbox && len(def.WatchedPaths) > 0 {
+		result.AddError("WatchedPaths", "sandbox pools cannot use watched paths")
+	}
+	if !def.Sandbox && len(def.SandboxAccounts) > 0 {
+		result.AddError("SandboxAccounts", "sandbox accounts require sandbox: true")
+	}
+	for i, account := range def.SandboxAccounts {
+		if account.DeviceAccount == "" {
+			result.AddError(fmt.Sprintf("SandboxAccounts[%d]", i), "device account is required")
 		}
+	}
 
-		// Validate limit
-		if query.Limit < 0 {
-			result.AddError(fmt.Sprintf("Queries[%d].Limit", i), "limit cannot be negative")
-		}
+	// Validate queries
+	for i, query := range def.Queries {
+		validateQuery(result, fmt.Sprintf("Queries[%d]", i), &query)
 	}
 
 	// Validate configuration
@@ -114,6 +87,46 @@ func ValidatePoolDefinition(def *UnifiedPoolDefinition) *ValidationResult {
 		result.AddError("Config.RefreshInterval", "refresh interval cannot be negative")
 	}
 
+	if def.Config.MaxPacksPerAccount < 0 {
+		result.AddError("Config.MaxPacksPerAccount", "max packs per account cannot be negative")
+	}
+
+	if def.Config.MaxRiskScore < 0 || def.Config.MaxRiskScore > 100 {
+		result.AddError("Config.MaxRiskScore", "max risk score must be between 0 and 100")
+	}
+
+	switch AffinityPolicy(def.Config.AffinityPolicy) {
+	case "", AffinityFallback, AffinityWait:
+	default:
+		result.AddError("Config.AffinityPolicy",
+			fmt.Sprintf("invalid affinity policy '%s' (must be 'fallback' or 'wait')", def.Config.AffinityPolicy))
+	}
+	switch SelectionStrategy(def.Config.SelectionStrategy) {
+	case "", SelectionSortBased, SelectionRoundRobin, SelectionLeastRecentlyUsed, SelectionRandom, SelectionWeightedRandom:
+	default:
+		result.AddError("Config.SelectionStrategy",
+			fmt.Sprintf("invalid selection strategy '%s' (must be 'sort', 'round_robin', 'lru', 'random' or 'weighted_random')", def.Config.SelectionStrategy))
+	}
+	if _, err := parseWeightExpression(def.Config.SelectionWeight); err != nil {
+		result.AddError("Config.SelectionWeight", err.Error())
+	}
+	seenPriority := make(map[string]bool, len(def.Priority))
+	for i, deviceAccount := range def.Priority {
+		field := fmt.Sprintf("Priority[%d]", i)
+		switch {
+		case strings.TrimSpace(deviceAccount) == "":
+			result.AddError(field, "device account cannot be empty")
+		case seenPriority[deviceAccount]:
+			result.AddError(field, fmt.Sprintf("account '%s' is listed more than once", deviceAccount))
+		}
+		seenPriority[deviceAccount] = true
+	}
+	for deviceAccount, instanceID := range def.Affinity {
+		if instanceID < 0 {
+			result.AddError("Affinity."+deviceAccount, "instance cannot be negative")
+		}
+	}
+
 	validSortMethods := map[string]bool{
 		"packs_asc": true, "packs_desc": true,
 		"modified_asc": true, "modified_desc": true,
@@ -126,3 +139,119 @@ func ValidatePoolDefinition(def *UnifiedPoolDefinition) *ValidationResult {
 
 	return result
 }
+
+// ValidateQuery validates a single query source, e.g. before previewing it
+func ValidateQuery(query *QuerySource) *ValidationResult {
+	result := &ValidationResult{
+		Valid:  true,
+		Errors: make([]ValidationError, 0),
+	}
+	validateQuery(result, "Query", query)
+	return result
+}
+
+// validateQuery adds errors for a query source, prefixing fields with prefix
+func validateQuery(result *ValidationResult, prefix string, query *QuerySource) {
+	if query.Name == "" {
+		result.AddError(prefix+".Name", "query name is required")
+	}
+
+	// Advanced mode ignores filters, sorts and limit
+	if query.IsAdvanced() {
+		validateRawSQL(result, prefix, query)
+		return
+	}
+
+	if len(query.Filters) == 0 && len(query.Tags) == 0 {
+		result.AddError(prefix+".Filters", "at least one filter or tag must be defined")
+	}
+
+	for j, tag := range query.Tags {
+		if strings.TrimSpace(tag) == "" {
+			result.AddError(fmt.Sprintf("%s.Tags[%d]", prefix, j), "tag cannot be empty")
+		}
+	}
+
+	// Validate filters
+	for j, filter := range query.Filters {
+		field := fmt.Sprintf("%s.Filters[%d]", prefix, j)
+
+		if filter.Column == "" {
+			result.AddError(field+".Column", "column name is required")
+		} else if !isColumnName(filter.Column) {
+			result.AddError(field+".Column", fmt.Sprintf("invalid column name '%s'", filter.Column))
+		}
+
+		if filter.Comparator == "" {
+			result.AddError(field+".Comparator", "comparator is required")
+		}
+
+		// Validate comparator is a known operator
+		validComparators := map[string]bool{
+			"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
+			"LIKE": true, "NOT LIKE": true, "IN": true, "NOT IN": true,
+			"IS NULL": true, "IS NOT NULL": true,
+		}
+		if !validComparators[filter.Comparator] {
+			result.AddError(field+".Comparator", fmt.Sprintf("invalid comparator '%s'", filter.Comparator))
+		}
+
+		if filter.IsSetCheck() && len(filter.SetValues()) == 0 {
+			result.AddError(field+".Value", fmt.Sprintf("%s requires a comma-separated list of values", filter.Comparator))
+		}
+	}
+
+	// Validate sorts
+	for j, sort := range query.Sort {
+		field := fmt.Sprintf("%s.Sort[%d]", prefix, j)
+
+		if sort.Column == "" {
+			result.AddError(field+".Column", "column name is required")
+		} else if !isColumnName(sort.Column) {
+			result.AddError(field+".Column", fmt.Sprintf("invalid column name '%s'", sort.Column))
+		}
+
+		if sort.Direction != "asc" && sort.Direction != "desc" && sort.Direction != "ASC" && sort.Direction != "DESC" {
+			result.AddError(field+".Direction",
+				fmt.Sprintf("direction must be 'asc' or 'desc', got '%s'", sort.Direction))
+		}
+	}
+
+	// Validate limit
+	if query.Limit < 0 {
+		result.AddError(prefix+".Limit", "limit cannot be negative")
+	}
+}
+
+// validateRawSQL adds errors for an advanced mode query
+func validateRawSQL(result *ValidationResult, prefix string, query *QuerySource) {
+	sqlQuery, _ := query.GenerateSQL()
+
+	upper := strings.ToUpper(sqlQuery)
+	if !strings.HasPrefix(upper, "SELECT") && !strings.HasPrefix(upper, "WITH") {
+		result.AddError(prefix+".RawSQL", "raw SQL must be a SELECT statement")
+	}
+
+	// Only a trailing semicolon is allowed, so a query can never run further statements
+	if strings.Contains(sqlQuery, ";") {
+		result.AddError(prefix+".RawSQL", "raw SQL must be a single statement")
+	}
+
+	for name := range query.Parameters {
+		if !isColumnName(name) {
+			result.AddError(prefix+".Parameters", fmt.Sprintf("invalid parameter name '%s'", name))
+		}
+	}
+}
+
+// isColumnName reports whether name is a plain SQL identifier (columns are inserted into generated SQL as-is)
+func isColumnName(name string) bool {
+	for i, r := range name {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return false
+		}
+	}
+	return name != ""
+}
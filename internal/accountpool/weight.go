@@ -0,0 +1,242 @@
+package accountpool
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultSelectionWeight is the weight expression SelectionWeightedRandom uses when none is configured
+const DefaultSelectionWeight = "pack_count"
+
+// weightExpression is a parsed selection weight: arithmetic (+ - * / and parentheses) over
+// numbers and account fields. Fields are pack_count (alias packs_opened), failure_count,
+// hours_since_used, and any account metadata key with a numeric value (0 otherwise).
+type weightExpression struct {
+	root weightNode
+}
+
+type weightNode interface {
+	eval(account *Account, now time.Time) float64
+}
+
+type weightNumber float64
+
+type weightField string
+
+type weightBinary struct {
+	op          byte
+	left, right weightNode
+}
+
+type weightNegate struct {
+	operand weightNode
+}
+
+func (n weightNumber) eval(*Account, time.Time) float64 { return float64(n) }
+
+func (n weightNegate) eval(account *Account, now time.Time) float64 {
+	return -n.operand.eval(account, now)
+}
+
+func (f weightField) eval(account *Account, now time.Time) float64 {
+	switch f {
+	case "pack_count", "packs_opened":
+		return float64(account.PackCount)
+	case "failure_count":
+		return float64(account.FailureCount)
+	case "hours_since_used":
+		if account.LastModified.IsZero() {
+			return 0
+		}
+		return now.Sub(account.LastModified).Hours()
+	}
+	if value, err := strconv.ParseFloat(account.Metadata[string(f)], 64); err == nil {
+		return value
+	}
+	return 0
+}
+
+func (n weightBinary) eval(account *Account, now time.Time) float64 {
+	left, right := n.left.eval(account, now), n.right.eval(account, now)
+	switch n.op {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	default:
+		if right == 0 {
+			return 0
+		}
+		return left / right
+	}
+}
+
+// parseWeightExpression parses a selection weight expression (empty = DefaultSelectionWeight)
+func parseWeightExpression(expr string) (*weightExpression, error) {
+	if strings.TrimSpace(expr) == "" {
+		expr = DefaultSelectionWeight
+	}
+
+	p := &weightParser{input: expr}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, fmt.Errorf("weight '%s': %w", expr, err)
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("weight '%s': unexpected '%c' at position %d", expr, p.input[p.pos], p.pos+1)
+	}
+	return &weightExpression{root: root}, nil
+}
+
+// Weight returns the account's weight; negative and invalid results count as 0
+func (w *weightExpression) Weight(account *Account, now time.Time) float64 {
+	weight := w.root.eval(account, now)
+	if weight <= 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return 0
+	}
+	return weight
+}
+
+// weightParser is a recursive-descent parser for weight expressions
+type weightParser struct {
+	input string
+	pos   int
+}
+
+func (p *weightParser) skipSpace() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// parseSum parses terms joined by + and -
+func (p *weightParser) parseSum() (weightNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '+' && p.input[p.pos] != '-') {
+			return left, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = weightBinary{op: op, left: left, right: right}
+	}
+}
+
+// parseProduct parses factors joined by * and /
+func (p *weightParser) parseProduct() (weightNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '*' && p.input[p.pos] != '/') {
+			return left, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = weightBinary{op: op, left: left, right: right}
+	}
+}
+
+// parseFactor parses a number, field, negation or parenthesized expression
+func (p *weightParser) parseFactor() (weightNode, error) {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	c := p.input[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return inner, nil
+
+	case c == '-':
+		p.pos++
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return weightNegate{operand: operand}, nil
+
+	case c == '.' || unicode.IsDigit(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '.' || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", p.input[start:p.pos])
+		}
+		return weightNumber(value), nil
+
+	case c == '_' || unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '_' || unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		return weightField(p.input[start:p.pos]), nil
+	}
+
+	return nil, fmt.Errorf("unexpected '%c' at position %d", c, p.pos+1)
+}
+
+// orderByWeight reorders candidates so each position is a weighted draw without replacement
+// from the accounts after it: the first account is picked with probability proportional to
+// its weight. Accounts with zero weight go last in random order, so they are only handed out
+// when nothing else is available.
+func orderByWeight(candidates []*Account, weight *weightExpression, rng *rand.Rand) {
+	now := time.Now()
+	keys := make(map[*Account]float64, len(candidates))
+	for _, account := range candidates {
+		// Efraimidis-Spirakis: the largest u^(1/w) wins with probability w / sum(w).
+		// Compared as log(u)/w to stay precise for large weights.
+		u := rng.Float64()
+		for u == 0 {
+			u = rng.Float64()
+		}
+		if w := weight.Weight(account, now); w > 0 {
+			keys[account] = math.Log(u) / w
+		} else {
+			keys[account] = math.Inf(-1)
+		}
+	}
+
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return keys[candidates[i]] > keys[candidates[j]]
+	})
+}
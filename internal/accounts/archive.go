@@ -0,0 +1,309 @@
+package accounts
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// FolderImport is the outcome of importing one account folder from an archive
+type FolderImport struct {
+	Folder        string
+	DeviceAccount string
+	Username      string // Read from the save data ("" if not found)
+	FriendCode    string // Read from the save data ("" if not found)
+	Status        string // ImportStatusImported, ImportStatusSkipped or ImportStatusFailed
+	AccountID     int64  // Set when imported
+	Error         string // Why the folder failed
+}
+
+// Save data keys holding account metadata, compared lowercased without separators
+var (
+	friendCodeKeys = []string{"friendcode", "friendid", "playerfriendid"}
+	usernameKeys   = []string{"username", "playername", "nickname", "displayname"}
+)
+
+// ImportFromArchive imports accounts from a zip of app data folders, as written by
+// ExtractAppData: each top-level folder is one account's extracted data. Credentials come
+// from the device account preferences in shared_prefs; username and friend code are read
+// from the save data (preferences and databases) when present. Each folder's outcome is
+// reported in ImportResult.Folders.
+func ImportFromArchive(db *sql.DB, archivePath string) (*ImportResult, error) {
+	tempDir, err := os.MkdirTemp("", "pokemontcg_import")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := unzip(archivePath, tempDir); err != nil {
+		return nil, fmt.Errorf("failed to unpack archive: %w", err)
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive contents: %w", err)
+	}
+
+	result := &ImportResult{
+		Errors:      make([]string, 0),
+		ImportedIDs: make([]int64, 0),
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		result.TotalFiles++
+
+		folder := FolderImport{Folder: entry.Name()}
+		accountFile, err := loadAppDataFolder(filepath.Join(tempDir, entry.Name()))
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", entry.Name(), err))
+			folder.Status = ImportStatusFailed
+			folder.Error = err.Error()
+			result.Folders = append(result.Folders, folder)
+			continue
+		}
+		accountFile.Filename = entry.Name()
+
+		folder.DeviceAccount = accountFile.DeviceAccount
+		folder.Username = accountFile.Username
+		folder.FriendCode = accountFile.FriendCode
+		folder.Status, folder.AccountID, err = importAccount(db, result, accountFile)
+		if err != nil {
+			folder.Error = err.Error()
+		}
+		result.Folders = append(result.Folders, folder)
+	}
+
+	if result.TotalFiles == 0 {
+		return result, fmt.Errorf("archive has no account folders")
+	}
+	return result, nil
+}
+
+// loadAppDataFolder reads an account's credentials and metadata from its extracted app data
+func loadAppDataFolder(folder string) (*AccountFile, error) {
+	var prefsFiles, databaseFiles []string
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch parent := filepath.Base(filepath.Dir(path)); {
+		case parent == "shared_prefs" && filepath.Ext(path) == ".xml":
+			prefsFiles = append(prefsFiles, path)
+		case isSaveDatabase(path):
+			databaseFiles = append(databaseFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read folder: %w", err)
+	}
+	if len(prefsFiles) == 0 {
+		return nil, fmt.Errorf("no shared_prefs folder found")
+	}
+	sort.Strings(prefsFiles)
+	sort.Strings(databaseFiles)
+
+	accountFile := &AccountFile{FilePath: folder}
+	metadata := make(map[string]string)
+
+	for _, path := range prefsFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+		}
+
+		var xmlMap XMLMap
+		if err := xml.Unmarshal(data, &xmlMap); err != nil {
+			continue // Not every preferences file is a string map
+		}
+		for _, entry := range xmlMap.Strings {
+			switch entry.Name {
+			case "deviceAccount":
+				accountFile.DeviceAccount = strings.TrimSpace(entry.Value)
+			case "devicePassword":
+				accountFile.DevicePassword = strings.TrimSpace(entry.Value)
+			default:
+				addMetadata(metadata, entry.Name, entry.Value)
+			}
+		}
+	}
+
+	if accountFile.DeviceAccount == "" || accountFile.DevicePassword == "" {
+		return nil, fmt.Errorf("no device account credentials in shared_prefs")
+	}
+
+	// Save databases are only searched for metadata the preferences didn't have
+	for _, path := range databaseFiles {
+		if metadataValue(metadata, friendCodeKeys) != "" && metadataValue(metadata, usernameKeys) != "" {
+			break
+		}
+		readDatabaseMetadata(path, metadata)
+	}
+
+	accountFile.Username = metadataValue(metadata, usernameKeys)
+	accountFile.FriendCode = metadataValue(metadata, friendCodeKeys)
+	return accountFile, nil
+}
+
+// isSaveDatabase reports whether an extracted file is one of the game's databases
+// (in a databases folder, and not a journal or WAL file)
+func isSaveDatabase(path string) bool {
+	return filepath.Base(filepath.Dir(path)) == "databases" && !strings.HasSuffix(path, "-journal") &&
+		!strings.HasSuffix(path, "-wal") && !strings.HasSuffix(path, "-shm")
+}
+
+// readDatabaseMetadata adds the columns of each table's first row to metadata.
+// It returns an error, and adds nothing, for files that aren't SQLite databases.
+func readDatabaseMetadata(path string, metadata map[string]string) error {
+	db, err := sql.Open("sqlite3", "file:"+filepath.ToSlash(path)+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table'`)
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if rows.Scan(&name) == nil {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+
+	for _, table := range tables {
+		readTableMetadata(db, table, metadata)
+	}
+	return nil
+}
+
+// readTableMetadata adds the non-NULL columns of a table's first row
+func readTableMetadata(db *sql.DB, table string, metadata map[string]string) {
+	rows, err := db.Query(fmt.Sprintf(`SELECT * FROM "%s" LIMIT 1`, strings.ReplaceAll(table, `"`, `""`)))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil || !rows.Next() {
+		return
+	}
+
+	values := make([]sql.NullString, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if rows.Scan(targets...) != nil {
+		return
+	}
+
+	for i, column := range columns {
+		if values[i].Valid {
+			addMetadata(metadata, column, values[i].String)
+		}
+	}
+}
+
+// addMetadata records a non-empty value under its normalized key, keeping the first one found
+func addMetadata(metadata map[string]string, key, value string) {
+	key = normalizeMetadataKey(key)
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if _, exists := metadata[key]; !exists {
+		metadata[key] = value
+	}
+}
+
+// metadataValue returns the value of the first key found
+func metadataValue(metadata map[string]string, keys []string) string {
+	for _, key := range keys {
+		if value := metadata[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// normalizeMetadataKey lowercases a key and drops separators ("Friend_Code" -> "friendcode")
+func normalizeMetadataKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', '.', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+}
+
+// unzip extracts an archive into dir, rejecting entries that would land outside it.
+// Colons (as in the device's "deviceAccount:.xml") are replaced, since Windows rejects them.
+func unzip(archivePath, dir string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		name := strings.ReplaceAll(file.Name, ":", "_")
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", file.Name)
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := extractZipFile(file, target); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", file.Name, err)
+		}
+	}
+	return nil
+}
+
+// extractZipFile writes one archive entry to target
+func extractZipFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
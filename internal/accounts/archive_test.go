@@ -0,0 +1,118 @@
+package accounts
+
+import (
+	"archive/zip"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSaveDB creates a save database holding a player row
+func writeSaveDB(t *testing.T, path, username, friendCode string) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`CREATE TABLE player (user_name TEXT, friend_code TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO player VALUES (?, ?)`, username, friendCode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeArchive zips every file under dir, keeping paths relative to it
+func writeArchive(t *testing.T, dir, archivePath string) {
+	t.Helper()
+	out, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		w, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportFromArchive(t *testing.T) {
+	db := openTestDB(t)
+	src := t.TempDir()
+
+	// A complete account, the same account again, and a folder without preferences
+	for _, folder := range []string{"alpha", "alpha_copy"} {
+		prefs := filepath.Join(src, folder, "temp_app_data", "shared_prefs")
+		databases := filepath.Join(src, folder, "temp_app_data", "databases")
+		for _, dir := range []string{prefs, databases} {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		writeAccountXML(t, prefs, "deviceAccount_.xml", "account_alpha")
+		writeSaveDB(t, filepath.Join(databases, "save.db"), "Ash", "1111222233334444")
+	}
+	if err := os.MkdirAll(filepath.Join(src, "broken", "files"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "broken", "files", "data.bin"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "accounts.zip")
+	writeArchive(t, src, archivePath)
+
+	result, err := ImportFromArchive(db.Conn(), archivePath)
+	if err != nil {
+		t.Fatalf("ImportFromArchive failed: %v", err)
+	}
+
+	if result.TotalFiles != 3 || result.Imported != 1 || result.Skipped != 1 || result.Failed != 1 {
+		t.Fatalf("Expected 3 folders (1 imported, 1 skipped, 1 failed), got %+v", result)
+	}
+
+	statuses := make(map[string]FolderImport)
+	for _, folder := range result.Folders {
+		statuses[folder.Folder] = folder
+	}
+	if alpha := statuses["alpha"]; alpha.Status != ImportStatusImported || alpha.Username != "Ash" || alpha.FriendCode != "1111222233334444" {
+		t.Errorf("Unexpected result for alpha: %+v", alpha)
+	}
+	if statuses["alpha_copy"].Status != ImportStatusSkipped {
+		t.Errorf("Expected alpha_copy to be skipped, got %+v", statuses["alpha_copy"])
+	}
+	if broken := statuses["broken"]; broken.Status != ImportStatusFailed || broken.Error == "" {
+		t.Errorf("Expected broken to fail with an error, got %+v", broken)
+	}
+
+	var username, friendCode string
+	if err := db.Conn().QueryRow(`SELECT username, friend_code FROM accounts WHERE device_account = 'account_alpha'`).Scan(&username, &friendCode); err != nil {
+		t.Fatalf("Imported account not found: %v", err)
+	}
+	if username != "Ash" || friendCode != "1111222233334444" {
+		t.Errorf("Stored metadata = %q, %q", username, friendCode)
+	}
+}
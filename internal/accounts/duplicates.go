@@ -0,0 +1,122 @@
+package accounts
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Duplicate match reasons
+const (
+	DuplicateByFriendCode    = "friend_code"
+	DuplicateByDeviceAccount = "device_account" // Same device account apart from case or surrounding whitespace
+)
+
+// DuplicateGroup is a set of account rows that appear to be the same account
+type DuplicateGroup struct {
+	Reason         string  // DuplicateByFriendCode or DuplicateByDeviceAccount
+	Value          string  // The shared friend code or normalized device account
+	AccountIDs     []int64 // Ordered by id (oldest first)
+	DeviceAccounts []string
+}
+
+// SuspectedDuplicate is an imported account that matches an existing row by a secondary key
+type SuspectedDuplicate struct {
+	Filename        string
+	DeviceAccount   string
+	ImportedID      int64 // Row created by the import
+	ExistingID      int64 // Row it appears to duplicate
+	ExistingAccount string
+	Reason          string // DuplicateByFriendCode or DuplicateByDeviceAccount
+}
+
+// FindDuplicates returns groups of different account rows sharing a friend code,
+// or a device account that differs only by case or surrounding whitespace
+func FindDuplicates(db *sql.DB) ([]DuplicateGroup, error) {
+	byFriendCode, err := findDuplicateGroups(db, DuplicateByFriendCode, `TRIM(friend_code)`)
+	if err != nil {
+		return nil, err
+	}
+
+	byDeviceAccount, err := findDuplicateGroups(db, DuplicateByDeviceAccount, `LOWER(TRIM(device_account))`)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(byFriendCode, byDeviceAccount...), nil
+}
+
+// findDuplicateGroups groups accounts by keyExpr, returning keys shared by more than one row
+func findDuplicateGroups(db *sql.DB, reason, keyExpr string) ([]DuplicateGroup, error) {
+	query := fmt.Sprintf(`
+		SELECT %[1]s AS dup_key, id, device_account
+		FROM accounts
+		WHERE %[1]s IN (
+			SELECT %[1]s FROM accounts
+			WHERE %[1]s IS NOT NULL AND %[1]s != ''
+			GROUP BY %[1]s
+			HAVING COUNT(*) > 1
+		)
+		ORDER BY dup_key, id
+	`, keyExpr)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("duplicate query by %s failed: %w", reason, err)
+	}
+	defer rows.Close()
+
+	var groups []DuplicateGroup
+	for rows.Next() {
+		var key, deviceAccount string
+		var id int64
+		if err := rows.Scan(&key, &id, &deviceAccount); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate row: %w", err)
+		}
+
+		if len(groups) == 0 || groups[len(groups)-1].Value != key {
+			groups = append(groups, DuplicateGroup{Reason: reason, Value: key})
+		}
+		group := &groups[len(groups)-1]
+		group.AccountIDs = append(group.AccountIDs, id)
+		group.DeviceAccounts = append(group.DeviceAccounts, deviceAccount)
+	}
+
+	return groups, rows.Err()
+}
+
+// findSuspectedDuplicate looks for an existing account (other than the exact device account)
+// that the file appears to duplicate. Returns the existing id and device account and the
+// reason, or a zero id when there is no match.
+func findSuspectedDuplicate(db *sql.DB, file *AccountFile) (int64, string, string, error) {
+	var id int64
+	var deviceAccount string
+
+	if friendCode := strings.TrimSpace(file.FriendCode); friendCode != "" {
+		err := db.QueryRow(`
+			SELECT id, device_account FROM accounts
+			WHERE TRIM(friend_code) = ? AND device_account != ?
+			ORDER BY id LIMIT 1
+		`, friendCode, file.DeviceAccount).Scan(&id, &deviceAccount)
+		if err == nil {
+			return id, deviceAccount, DuplicateByFriendCode, nil
+		}
+		if err != sql.ErrNoRows {
+			return 0, "", "", err
+		}
+	}
+
+	err := db.QueryRow(`
+		SELECT id, device_account FROM accounts
+		WHERE LOWER(TRIM(device_account)) = LOWER(TRIM(?)) AND device_account != ?
+		ORDER BY id LIMIT 1
+	`, file.DeviceAccount, file.DeviceAccount).Scan(&id, &deviceAccount)
+	if err == nil {
+		return id, deviceAccount, DuplicateByDeviceAccount, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, "", "", err
+	}
+
+	return 0, "", "", nil
+}
@@ -0,0 +1,109 @@
+package accounts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+func openTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	return db
+}
+
+func writeAccountXML(t *testing.T, dir, filename, deviceAccount string) {
+	t.Helper()
+	xml := `<?xml version='1.0' encoding='utf-8' standalone='yes' ?>
+<map>
+    <string name="deviceAccount">` + deviceAccount + `</string>
+    <string name="devicePassword">password</string>
+</map>`
+	if err := os.WriteFile(filepath.Join(dir, filename), []byte(xml), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", filename, err)
+	}
+}
+
+func TestImportReportsSuspectedDuplicates(t *testing.T) {
+	db := openTestDB(t)
+
+	first := t.TempDir()
+	writeAccountXML(t, first, "deviceAccount_Ash_1111222233334444_10P.xml", "account_a")
+	if _, err := ImportFromDirectory(db.Conn(), first); err != nil {
+		t.Fatalf("First import failed: %v", err)
+	}
+
+	// Same file again (hard skip), the same friend code under another device account,
+	// and a device account differing only by case
+	second := t.TempDir()
+	writeAccountXML(t, second, "deviceAccount_Ash_1111222233334444_10P.xml", "account_a")
+	writeAccountXML(t, second, "deviceAccount_Ash2_1111222233334444_12P.xml", "account_b")
+	writeAccountXML(t, second, "other.xml", "ACCOUNT_A")
+
+	result, err := ImportFromDirectory(db.Conn(), second)
+	if err != nil {
+		t.Fatalf("Second import failed: %v", err)
+	}
+
+	if result.Skipped != 1 {
+		t.Errorf("Expected 1 skipped, got %d", result.Skipped)
+	}
+	if result.Imported != 2 {
+		t.Errorf("Expected 2 imported, got %d", result.Imported)
+	}
+	if len(result.SuspectedDuplicates) != 2 {
+		t.Fatalf("Expected 2 suspected duplicates, got %+v", result.SuspectedDuplicates)
+	}
+
+	reasons := map[string]string{}
+	for _, dup := range result.SuspectedDuplicates {
+		reasons[dup.DeviceAccount] = dup.Reason
+		if dup.ExistingAccount != "account_a" {
+			t.Errorf("%s: expected match with account_a, got %s", dup.DeviceAccount, dup.ExistingAccount)
+		}
+	}
+	if reasons["account_b"] != DuplicateByFriendCode {
+		t.Errorf("account_b: expected %s, got %q", DuplicateByFriendCode, reasons["account_b"])
+	}
+	if reasons["ACCOUNT_A"] != DuplicateByDeviceAccount {
+		t.Errorf("ACCOUNT_A: expected %s, got %q", DuplicateByDeviceAccount, reasons["ACCOUNT_A"])
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	db := openTestDB(t)
+
+	dir := t.TempDir()
+	writeAccountXML(t, dir, "deviceAccount_Ash_1111222233334444_10P.xml", "account_a")
+	writeAccountXML(t, dir, "deviceAccount_Misty_1111222233334444_5P.xml", "account_b")
+	writeAccountXML(t, dir, "deviceAccount_Brock_9999888877776666_5P.xml", "account_c")
+	if _, err := ImportFromDirectory(db.Conn(), dir); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	groups, err := FindDuplicates(db.Conn())
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 duplicate group, got %+v", groups)
+	}
+	group := groups[0]
+	if group.Reason != DuplicateByFriendCode || group.Value != "1111222233334444" {
+		t.Errorf("Unexpected group %+v", group)
+	}
+	if len(group.AccountIDs) != 2 || group.DeviceAccounts[0] != "account_a" || group.DeviceAccounts[1] != "account_b" {
+		t.Errorf("Unexpected accounts in group %+v", group)
+	}
+}
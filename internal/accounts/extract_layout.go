@@ -0,0 +1,141 @@
+package accounts
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultExtractNaming names extraction folders by instance, as before naming was configurable
+const DefaultExtractNaming = "instance_{instance}"
+
+// Extraction folders, one per kind of data, each holding one folder per extraction
+const (
+	AppDataFolder = "extracted_app_data"
+	OBBFolder     = "extracted_obb"
+)
+
+// accountPlaceholders are the naming placeholders filled from the account's save data
+var accountPlaceholders = []string{"{friend_code}", "{username}", "{device_account}"}
+
+// ExtractLayout decides where extracted account data goes: BaseDir/extracted_app_data/<name>
+// and BaseDir/extracted_obb/<name>, where <name> is Naming with its placeholders filled in:
+//
+//	{instance}        emulator instance number
+//	{friend_code}     friend code read from the save
+//	{username}        username read from the save
+//	{device_account}  device account from the save's credentials
+//	{date}            extraction date (2006-01-02)
+//
+// Naming by identity keeps an account's extractions together however instances are assigned.
+// If the save doesn't have a value the naming uses, the folder falls back to DefaultExtractNaming.
+type ExtractLayout struct {
+	BaseDir     string // Folder the extraction folders are created in (default: ".")
+	Naming      string // Folder name template (default: DefaultExtractNaming)
+	GamePackage string // Game package the data is extracted from (default: AppPackage)
+}
+
+// FolderName returns the sanitized folder name for an extraction from instance of account
+func (l ExtractLayout) FolderName(instance int, account AccountData) string {
+	naming := l.Naming
+	if strings.TrimSpace(naming) == "" {
+		naming = DefaultExtractNaming
+	}
+
+	values := map[string]string{
+		"{friend_code}":    account.FriendCode,
+		"{username}":       account.Username,
+		"{device_account}": account.DeviceAccount,
+	}
+	for _, placeholder := range accountPlaceholders {
+		if strings.Contains(naming, placeholder) && strings.TrimSpace(values[placeholder]) == "" {
+			naming = DefaultExtractNaming
+			break
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		"{instance}", strconv.Itoa(instance),
+		"{friend_code}", account.FriendCode,
+		"{username}", account.Username,
+		"{device_account}", account.DeviceAccount,
+		"{date}", time.Now().Format("2006-01-02"),
+	)
+	return SanitizeFileName(replacer.Replace(naming))
+}
+
+// NeedsAccount reports whether the naming uses values read from the account's save
+func (l ExtractLayout) NeedsAccount() bool {
+	for _, placeholder := range accountPlaceholders {
+		if strings.Contains(l.Naming, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppDataDir returns the folder app data extracted from instance of account goes in
+func (l ExtractLayout) AppDataDir(instance int, account AccountData) string {
+	return filepath.Join(l.baseDir(), AppDataFolder, l.FolderName(instance, account))
+}
+
+// OBBDir returns the folder OBB data extracted from instance of account goes in
+func (l ExtractLayout) OBBDir(instance int, account AccountData) string {
+	return filepath.Join(l.baseDir(), OBBFolder, l.FolderName(instance, account))
+}
+
+// pendingDir is where an extraction lands before the account is known and it can be named
+func (l ExtractLayout) pendingDir(folder string, instance int) string {
+	return filepath.Join(l.baseDir(), folder, fmt.Sprintf(".pending_instance_%d", instance))
+}
+
+func (l ExtractLayout) baseDir() string {
+	if strings.TrimSpace(l.BaseDir) == "" {
+		return "."
+	}
+	return l.BaseDir
+}
+
+// ReadAccountData reads the account's identity from its app data, as extracted by ExtractAppData
+func ReadAccountData(appDataDir string) (AccountData, error) {
+	accountFile, err := loadAppDataFolder(appDataDir)
+	if err != nil {
+		return AccountData{}, err
+	}
+	return AccountData{
+		DeviceAccount: accountFile.DeviceAccount,
+		Username:      accountFile.Username,
+		FriendCode:    accountFile.FriendCode,
+	}, nil
+}
+
+// reservedFileNames are names Windows rejects for files and folders, whatever the extension
+var reservedFileNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// SanitizeFileName makes name a valid file or folder name on Windows and Linux: characters
+// either rejects become "_", trailing dots and spaces are dropped and reserved device names
+// are prefixed with "_". Usernames come from players, so anything may be in them.
+func SanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 32 || r == 127 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(strings.TrimRight(name, ". "))
+
+	if name == "" {
+		return "_"
+	}
+	base := strings.ToUpper(strings.SplitN(name, ".", 2)[0])
+	if reservedFileNames[strings.TrimSpace(base)] {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,88 @@
+package accounts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractLayoutFolderName(t *testing.T) {
+	account := AccountData{DeviceAccount: "account_alpha", Username: "Ash", FriendCode: "1111-2222-3333-4444"}
+
+	tests := []struct {
+		name    string
+		naming  string
+		account AccountData
+		want    string
+	}{
+		{"default", "", account, "instance_3"},
+		{"friend code", "{friend_code}", account, "1111-2222-3333-4444"},
+		{"mixed", "{username}_{instance}", account, "Ash_3"},
+		{"missing value falls back", "{friend_code}", AccountData{DeviceAccount: "account_alpha"}, "instance_3"},
+		{"unsafe username", "{username}", AccountData{Username: `A/sh:"1"?.`}, "A_sh__1__"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := ExtractLayout{Naming: tt.naming}
+			if got := layout.FolderName(3, tt.account); got != tt.want {
+				t.Errorf("FolderName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	layout := ExtractLayout{BaseDir: "out", Naming: "{device_account}"}
+	if got, want := layout.AppDataDir(1, account), filepath.Join("out", AppDataFolder, "account_alpha"); got != want {
+		t.Errorf("AppDataDir() = %q, want %q", got, want)
+	}
+	if got, want := layout.OBBDir(1, account), filepath.Join("out", OBBFolder, "account_alpha"); got != want {
+		t.Errorf("OBBDir() = %q, want %q", got, want)
+	}
+	if !layout.NeedsAccount() || (ExtractLayout{}).NeedsAccount() {
+		t.Error("Expected only namings with account placeholders to need the account")
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := map[string]string{
+		"Ash":          "Ash",
+		"a<b>c|d*e":    "a_b_c_d_e",
+		"..":           "_",
+		"  ":           "_",
+		"trailing. . ": "trailing",
+		"CON":          "_CON",
+		"com1.txt":     "_com1.txt",
+		"tab\there":    "tab_here",
+	}
+	for input, want := range tests {
+		if got := SanitizeFileName(input); got != want {
+			t.Errorf("SanitizeFileName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestReadAccountData(t *testing.T) {
+	dir := t.TempDir()
+	prefs := filepath.Join(dir, "temp_app_data", "shared_prefs")
+	databases := filepath.Join(dir, "temp_app_data", "databases")
+	for _, d := range []string{prefs, databases} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAccountXML(t, prefs, "deviceAccount_.xml", "account_alpha")
+	writeSaveDB(t, filepath.Join(databases, "save.db"), "Ash", "1111222233334444")
+
+	account, err := ReadAccountData(dir)
+	if err != nil {
+		t.Fatalf("ReadAccountData failed: %v", err)
+	}
+	want := AccountData{DeviceAccount: "account_alpha", Username: "Ash", FriendCode: "1111222233334444"}
+	if account != want {
+		t.Errorf("ReadAccountData() = %+v, want %+v", account, want)
+	}
+
+	if _, err := ReadAccountData(t.TempDir()); err == nil {
+		t.Error("Expected an error for a folder without preferences")
+	}
+}
@@ -10,12 +10,26 @@ import (
 type ImportResult struct {
 	TotalFiles    int
 	Imported      int
-	Skipped       int
+	Skipped       int // Device account already in the database
 	Failed        int
 	Errors        []string
 	ImportedIDs   []int64
+
+	// Imported accounts that look like an existing account under another key (e.g. same friend code).
+	// They are imported so they can be merged or deleted; see FindDuplicates.
+	SuspectedDuplicates []SuspectedDuplicate
+
+	// Outcome of each account folder (ImportFromArchive only)
+	Folders []FolderImport
 }
 
+// Outcomes of importing one account
+const (
+	ImportStatusImported = "imported"
+	ImportStatusSkipped  = "skipped" // Device account already in the database
+	ImportStatusFailed   = "failed"
+)
+
 // ImportFromDirectory imports all XML account files from a directory into the database
 // Returns an ImportResult with statistics about the operation
 func ImportFromDirectory(db *sql.DB, directory string) (*ImportResult, error) {
@@ -34,61 +48,71 @@ func ImportFromDirectory(db *sql.DB, directory string) (*ImportResult, error) {
 
 	// Import each account
 	for _, accountFile := range accountFiles {
-		// Validate account has required fields
-		if accountFile.DeviceAccount == "" || accountFile.DevicePassword == "" {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: missing credentials", accountFile.Filename))
-			continue
-		}
+		importAccount(db, result, accountFile)
+	}
 
-		// Check if account already exists
-		var exists bool
-		err := db.QueryRow(`
-			SELECT COUNT(*) > 0
-			FROM accounts
-			WHERE device_account = ?
-		`, accountFile.DeviceAccount).Scan(&exists)
+	return result, nil
+}
 
-		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: database query failed: %v", accountFile.Filename, err))
-			continue
-		}
+// importAccount registers one account file as available for injection and counts the
+// outcome in result. Returns ImportStatusImported, ImportStatusSkipped or ImportStatusFailed,
+// the new account's ID (0 unless imported) and why it failed.
+func importAccount(db *sql.DB, result *ImportResult, accountFile *AccountFile) (string, int64, error) {
+	fail := func(err error) (string, int64, error) {
+		result.Failed++
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", accountFile.Filename, err))
+		return ImportStatusFailed, 0, err
+	}
 
-		if exists {
-			result.Skipped++
-			continue
-		}
+	// Validate account has required fields
+	if accountFile.DeviceAccount == "" || accountFile.DevicePassword == "" {
+		return fail(fmt.Errorf("missing credentials"))
+	}
 
-		// Insert into database
-		res, err := db.Exec(`
-			INSERT INTO accounts (
-				device_account,
-				device_password,
-				pool_status,
-				failure_count,
-				packs_opened,
-				created_at,
-				last_used_at
-			) VALUES (?, ?, 'available', 0, 0, datetime('now'), NULL)
-		`, accountFile.DeviceAccount, accountFile.DevicePassword)
+	// Check if account already exists
+	var exists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM accounts
+		WHERE device_account = ?
+	`, accountFile.DeviceAccount).Scan(&exists)
 
-		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: insert failed: %v", accountFile.Filename, err))
-			continue
-		}
+	if err != nil {
+		return fail(fmt.Errorf("database query failed: %v", err))
+	}
 
-		// Get the inserted ID
-		id, err := res.LastInsertId()
-		if err == nil {
-			result.ImportedIDs = append(result.ImportedIDs, id)
-		}
+	if exists {
+		result.Skipped++
+		return ImportStatusSkipped, 0, nil
+	}
 
-		result.Imported++
+	// Check secondary keys before inserting (the match would otherwise find the new row)
+	existingID, existingAccount, reason, err := findSuspectedDuplicate(db, accountFile)
+	if err != nil {
+		return fail(fmt.Errorf("duplicate check failed: %v", err))
 	}
 
-	return result, nil
+	// Insert into database
+	id, err := insertAccountFile(db, accountFile)
+	if err != nil {
+		return fail(fmt.Errorf("insert failed: %v", err))
+	}
+
+	result.ImportedIDs = append(result.ImportedIDs, id)
+	result.Imported++
+
+	if existingID != 0 {
+		result.SuspectedDuplicates = append(result.SuspectedDuplicates, SuspectedDuplicate{
+			Filename:        accountFile.Filename,
+			DeviceAccount:   accountFile.DeviceAccount,
+			ImportedID:      id,
+			ExistingID:      existingID,
+			ExistingAccount: existingAccount,
+			Reason:          reason,
+		})
+	}
+
+	return ImportStatusImported, id, nil
 }
 
 // ImportSingleFile imports a single XML account file into the database
@@ -137,25 +161,44 @@ func ImportSingleFile(db *sql.DB, filePath string) (int64, error) {
 	}
 
 	// Insert into database
+	id, err := insertAccountFile(db, account)
+	if err != nil {
+		return 0, fmt.Errorf("insert failed: %w", err)
+	}
+
+	return id, nil
+}
+
+// insertAccountFile inserts an account file as a new available account and returns its ID
+func insertAccountFile(db *sql.DB, account *AccountFile) (int64, error) {
 	res, err := db.Exec(`
 		INSERT INTO accounts (
 			device_account,
 			device_password,
+			username,
+			friend_code,
 			pool_status,
 			failure_count,
 			packs_opened,
 			created_at,
 			last_used_at
-		) VALUES (?, ?, 'available', 0, 0, datetime('now'), NULL)
-	`, account.DeviceAccount, account.DevicePassword)
-
+		) VALUES (?, ?, ?, ?, 'available', 0, 0, datetime('now'), NULL)
+	`, account.DeviceAccount, account.DevicePassword, nullIfEmpty(account.Username), nullIfEmpty(account.FriendCode))
 	if err != nil {
-		return 0, fmt.Errorf("insert failed: %w", err)
+		return 0, err
 	}
 
 	return res.LastInsertId()
 }
 
+// nullIfEmpty stores empty strings as NULL
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
 // ExportToDirectory exports accounts from the database to XML files
 // If accountIDs is nil, exports all accounts. Otherwise exports only specified IDs.
 func ExportToDirectory(db *sql.DB, directory string, accountIDs []int64) (*ImportResult, error) {
@@ -8,18 +8,42 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/adb"
 )
 
 const (
-	AppPackage      = "jp.pokemon.pokemontcgp"
-	AppActivity     = "com.unity3d.player.UnityPlayerActivity"
-	SharedPrefsPath = "/data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml"
-	TempPath        = "/sdcard/deviceAccount.xml"
+	AppPackage  = "jp.pokemon.pokemontcgp" // Game package used when none is configured
+	AppActivity = "com.unity3d.player.UnityPlayerActivity"
+	TempPath    = "/sdcard/deviceAccount.xml"
 )
 
-// InjectAccount injects an account XML file into a specific instance
-// Uses the same proven methods as ADBTestTab for reliability
-func InjectAccount(adbPath string, adbPort int, xmlFilePath string) error {
+// appPackage returns gamePackage, or AppPackage if it is empty
+func appPackage(gamePackage string) string {
+	if gamePackage == "" {
+		return AppPackage
+	}
+	return gamePackage
+}
+
+// AppDataPath returns the game's data directory on the device
+func AppDataPath(gamePackage string) string {
+	return "/data/data/" + appPackage(gamePackage)
+}
+
+// SharedPrefsDir returns the game's SharedPreferences directory on the device
+func SharedPrefsDir(gamePackage string) string {
+	return AppDataPath(gamePackage) + "/shared_prefs"
+}
+
+// SharedPrefsPath returns the preferences file the game reads its account from
+func SharedPrefsPath(gamePackage string) string {
+	return SharedPrefsDir(gamePackage) + "/deviceAccount:.xml"
+}
+
+// InjectAccount injects an account XML file into the game package (AppPackage if empty) on a
+// specific instance. Uses the same proven methods as ADBTestTab for reliability
+func InjectAccount(adbPath string, adbPort int, gamePackage, xmlFilePath string) error {
 	// Verify the XML file exists
 	if _, err := os.Stat(xmlFilePath); os.IsNotExist(err) {
 		return fmt.Errorf("account file does not exist: %s", xmlFilePath)
@@ -33,7 +57,7 @@ func InjectAccount(adbPath string, adbPort int, xmlFilePath string) error {
 	}
 
 	// Step 1: Force stop the app
-	if err := forceStopApp(adbPath, adbAddress); err != nil {
+	if err := forceStopApp(adbPath, adbAddress, gamePackage); err != nil {
 		return fmt.Errorf("failed to force stop app: %w", err)
 	}
 
@@ -43,7 +67,7 @@ func InjectAccount(adbPath string, adbPort int, xmlFilePath string) error {
 	}
 
 	// Step 3: Copy to shared preferences location
-	if err := copyToSharedPrefs(adbPath, adbAddress); err != nil {
+	if err := copyToSharedPrefs(adbPath, adbAddress, gamePackage); err != nil {
 		return fmt.Errorf("failed to copy to shared prefs: %w", err)
 	}
 
@@ -53,14 +77,15 @@ func InjectAccount(adbPath string, adbPort int, xmlFilePath string) error {
 	}
 
 	// Step 5: Launch the app
-	if err := launchApp(adbPath, adbAddress); err != nil {
+	if err := launchApp(adbPath, adbAddress, gamePackage); err != nil {
 		return fmt.Errorf("failed to launch app: %w", err)
 	}
 
 	return nil
 }
 
-func ExtractAccount(adbPath string, adbPort int, xmlFilePath string) error {
+// ExtractAccount pulls the account XML of the game package (AppPackage if empty) from an instance
+func ExtractAccount(adbPath string, adbPort int, gamePackage, xmlFilePath string) error {
 	adbAddress := fmt.Sprintf("127.0.0.1:%d", adbPort)
 
 	// Step 0: Connect to device
@@ -69,7 +94,7 @@ func ExtractAccount(adbPath string, adbPort int, xmlFilePath string) error {
 	}
 
 	// Step 1: Copy to shared preferences location
-	if err := copyFromSharedPrefs(adbPath, adbAddress); err != nil {
+	if err := copyFromSharedPrefs(adbPath, adbAddress, gamePackage); err != nil {
 		return fmt.Errorf("failed to copy to shared prefs: %w", err)
 	}
 
@@ -88,6 +113,10 @@ func ExtractAccount(adbPath string, adbPort int, xmlFilePath string) error {
 
 // connectToDevice connects ADB to the device
 func connectToDevice(adbPath, adbAddress string) error {
+	if err := adb.ServerFor(adbPath).EnsureRunning(); err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -107,12 +136,12 @@ func connectToDevice(adbPath, adbAddress string) error {
 	return nil
 }
 
-// forceStopApp stops the Pokemon TCG Pocket app using the same method as ADBTestTab
-func forceStopApp(adbPath, adbAddress string) error {
+// forceStopApp stops the game using the same method as ADBTestTab
+func forceStopApp(adbPath, adbAddress, gamePackage string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", "am", "force-stop", AppPackage)
+	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", "am", "force-stop", appPackage(gamePackage))
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("force-stop failed: %v, output: %s", err, string(output))
@@ -156,12 +185,12 @@ func pullXMLFromDevice(adbPath, adbAddress, xmlFilePath string) error {
 }
 
 // copyToSharedPrefs copies the XML from temp location to shared preferences
-func copyToSharedPrefs(adbPath, adbAddress string) error {
+func copyToSharedPrefs(adbPath, adbAddress, gamePackage string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Command format: adb shell "su -c 'cp /sdcard/deviceAccount.xml /data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml'"
-	suCmd := fmt.Sprintf("su -c 'cp %s %s'", TempPath, SharedPrefsPath)
+	suCmd := fmt.Sprintf("su -c 'cp %s %s'", TempPath, SharedPrefsPath(gamePackage))
 	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", suCmd)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -173,12 +202,12 @@ func copyToSharedPrefs(adbPath, adbAddress string) error {
 	return nil
 }
 
-func copyFromSharedPrefs(adbPath, adbAddress string) error {
+func copyFromSharedPrefs(adbPath, adbAddress, gamePackage string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Command format: adb shell "su -c 'cp /sdcard/deviceAccount.xml /data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml'"
-	suCmd := fmt.Sprintf("su -c 'cp %s %s'", SharedPrefsPath, TempPath)
+	suCmd := fmt.Sprintf("su -c 'cp %s %s'", SharedPrefsPath(gamePackage), TempPath)
 
 	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", suCmd)
 	output, err := cmd.CombinedOutput()
@@ -206,8 +235,8 @@ func cleanupTempFile(adbPath, adbAddress string) error {
 	return nil
 }
 
-// launchApp launches the Pokemon TCG Pocket app using the same method as ADBTestTab
-func launchApp(adbPath, adbAddress string) error {
+// launchApp launches the game using the same method as ADBTestTab
+func launchApp(adbPath, adbAddress, gamePackage string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
@@ -215,7 +244,7 @@ func launchApp(adbPath, adbAddress string) error {
 	// Combined: 0x10018000
 	// Using the same format as ADBTestTab
 	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", "am", "start", "-W",
-		"-n", fmt.Sprintf("%s/%s", AppPackage, AppActivity),
+		"-n", fmt.Sprintf("%s/%s", appPackage(gamePackage), AppActivity),
 		"-f", "0x10018000")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -243,8 +272,9 @@ func parseXMLData(data []byte) ([]*AccountFile, error) {
 	return LoadAccountsFromXML(tempDir)
 }
 
-// ExtractAppData extracts the app data directory from device to local folder
-func ExtractAppData(adbPath string, adbPort int, outputDir string) error {
+// ExtractAppData extracts the data directory of the game package (AppPackage if empty) from
+// device to local folder
+func ExtractAppData(adbPath string, adbPort int, gamePackage, outputDir string) error {
 	adbAddress := fmt.Sprintf("127.0.0.1:%d", adbPort)
 
 	// Step 0: Connect to device
@@ -252,9 +282,9 @@ func ExtractAppData(adbPath string, adbPort int, outputDir string) error {
 		return fmt.Errorf("failed to connect to device: %w", err)
 	}
 
-	// Based on storage crawl: /data/data/jp.pokemon.pokemontcgp is readable
+	// Based on storage crawl: the app data directory is readable
 	// But we'll use copy-to-temp workflow for safety
-	appDataPath := "/data/data/jp.pokemon.pokemontcgp"
+	appDataPath := AppDataPath(gamePackage)
 	tempDataPath := "/sdcard/temp_app_data"
 
 	// Remove any existing temp directory first
@@ -304,8 +334,54 @@ func ExtractAppData(adbPath string, adbPort int, outputDir string) error {
 	return nil
 }
 
-// ExtractOBBData extracts OBB files from device to local folder
-func ExtractOBBData(adbPath string, adbPort int, outputDir string) error {
+// ExtractAppData extracts instance's app data into the layout, in a folder named after the
+// account read from it (replacing an earlier extraction in that folder). Returns the folder.
+func (l ExtractLayout) ExtractAppData(adbPath string, adbPort, instance int) (string, error) {
+	pending := l.pendingDir(AppDataFolder, instance)
+	if err := os.RemoveAll(pending); err != nil {
+		return "", fmt.Errorf("failed to clear %s: %w", pending, err)
+	}
+	if err := ExtractAppData(adbPath, adbPort, l.GamePackage, pending); err != nil {
+		return "", err
+	}
+
+	account, err := ReadAccountData(pending)
+	if err != nil && l.NeedsAccount() {
+		fmt.Printf("Warning: failed to read account from extracted app data, naming it by instance: %v\n", err)
+	}
+
+	outputDir := l.AppDataDir(instance, account)
+	if err := os.RemoveAll(outputDir); err != nil {
+		return "", fmt.Errorf("failed to replace %s: %w", outputDir, err)
+	}
+	if err := os.Rename(pending, outputDir); err != nil {
+		return "", fmt.Errorf("failed to move app data to %s: %w", outputDir, err)
+	}
+	return outputDir, nil
+}
+
+// ExtractOBBData extracts instance's OBB files into the layout. OBB files don't identify the
+// account, so if the naming needs it, it is read from the game's preferences on the device.
+// Returns the folder.
+func (l ExtractLayout) ExtractOBBData(adbPath string, adbPort, instance int) (string, error) {
+	var account AccountData
+	if l.NeedsAccount() {
+		var err error
+		if account, err = ReadDeviceAccountData(adbPath, adbPort, l.GamePackage); err != nil {
+			fmt.Printf("Warning: failed to read account from device, naming OBB data by instance: %v\n", err)
+		}
+	}
+
+	outputDir := l.OBBDir(instance, account)
+	if err := ExtractOBBData(adbPath, adbPort, l.GamePackage, outputDir); err != nil {
+		return "", err
+	}
+	return outputDir, nil
+}
+
+// ExtractOBBData extracts the OBB files of the game package (AppPackage if empty) from device
+// to local folder
+func ExtractOBBData(adbPath string, adbPort int, gamePackage, outputDir string) error {
 	adbAddress := fmt.Sprintf("127.0.0.1:%d", adbPort)
 
 	// Step 0: Connect to device
@@ -315,9 +391,9 @@ func ExtractOBBData(adbPath string, adbPort int, outputDir string) error {
 
 	// Try multiple possible OBB locations (based on storage crawl results)
 	possiblePaths := []string{
-		"/sdcard/Android/data/jp.pokemon.pokemontcgp",
-		"/storage/emulated/0/Android/data/jp.pokemon.pokemontcgp",
-		"/data/media/0/Android/data/jp.pokemon.pokemontcgp",
+		"/sdcard/Android/data/" + appPackage(gamePackage),
+		"/storage/emulated/0/Android/data/" + appPackage(gamePackage),
+		"/data/media/0/Android/data/" + appPackage(gamePackage),
 	}
 
 	var obbPath string
@@ -358,8 +434,9 @@ func ExtractOBBData(adbPath string, adbPort int, outputDir string) error {
 	return nil
 }
 
-// CrawlStorage crawls the device storage and outputs directory structure to a file
-func CrawlStorage(adbPath string, adbPort int, outputFile string) error {
+// CrawlStorage crawls the device storage, including the data of the game package (AppPackage
+// if empty), and outputs directory structure to a file
+func CrawlStorage(adbPath string, adbPort int, gamePackage, outputFile string) error {
 	adbAddress := fmt.Sprintf("127.0.0.1:%d", adbPort)
 
 	// Step 0: Connect to device
@@ -417,7 +494,7 @@ func CrawlStorage(adbPath string, adbPort int, outputFile string) error {
 		"/sdcard/Android/data",
 		"/storage/emulated/0/Android",
 		"/storage/emulated/0/Android/obb",
-		"/data/data/jp.pokemon.pokemontcgp",
+		AppDataPath(gamePackage),
 	}
 
 	for _, path := range specificPaths {
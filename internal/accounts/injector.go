@@ -10,33 +10,35 @@ import (
 
 // Injector handles account XML injection into the game
 type Injector struct {
-	adb      *adb.Controller
-	instance int
+	adb         *adb.Controller
+	instance    int
+	gamePackage string
 }
 
-// NewInjector creates a new account injector
-func NewInjector(adbController *adb.Controller, instance int) *Injector {
+// NewInjector creates a new account injector for the game package (AppPackage if empty)
+func NewInjector(adbController *adb.Controller, instance int, gamePackage string) *Injector {
 	return &Injector{
-		adb:      adbController,
-		instance: instance,
+		adb:         adbController,
+		instance:    instance,
+		gamePackage: appPackage(gamePackage),
 	}
 }
 
 // InjectAccount pushes an account XML to the device and copies it to game data
 func (i *Injector) InjectAccount(xmlPath string) error {
 	// 1. Force stop the game first
-	if err := i.adb.ForceStop("jp.pokemon.pokemontcgp"); err != nil {
+	if err := i.adb.ForceStop(i.gamePackage); err != nil {
 		return fmt.Errorf("failed to force stop game: %w", err)
 	}
 
 	// 2. Push XML to sdcard (temporary location)
-	tempPath := "/sdcard/deviceAccount.xml"
+	tempPath := TempPath
 	if err := i.adb.Push(xmlPath, tempPath); err != nil {
 		return fmt.Errorf("failed to push account XML: %w", err)
 	}
 
 	// 3. Copy to game's shared_prefs directory
-	gamePath := "/data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml"
+	gamePath := SharedPrefsPath(i.gamePackage)
 	if _, err := i.adb.Shell(fmt.Sprintf("cp %s %s", tempPath, gamePath)); err != nil {
 		return fmt.Errorf("failed to copy to game directory: %w", err)
 	}
@@ -53,8 +55,8 @@ func (i *Injector) InjectAccount(xmlPath string) error {
 // ExtractAccount pulls the current account XML from the device
 func (i *Injector) ExtractAccount(destPath string) error {
 	// Path in game data
-	gamePath := "/data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml"
-	tempPath := "/sdcard/deviceAccount.xml"
+	gamePath := SharedPrefsPath(i.gamePackage)
+	tempPath := TempPath
 
 	// 1. Copy from game directory to sdcard
 	if _, err := i.adb.Shell(fmt.Sprintf("cp %s %s", gamePath, tempPath)); err != nil {
@@ -92,10 +94,10 @@ func (i *Injector) BackupCurrentAccount(saveDir string) (string, error) {
 
 // DeleteCurrentAccount removes the account from device (for account reset)
 func (i *Injector) DeleteCurrentAccount() error {
-	gamePath := "/data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml"
+	gamePath := SharedPrefsPath(i.gamePackage)
 
 	// Force stop first
-	if err := i.adb.ForceStop("jp.pokemon.pokemontcgp"); err != nil {
+	if err := i.adb.ForceStop(i.gamePackage); err != nil {
 		return fmt.Errorf("failed to force stop game: %w", err)
 	}
 
@@ -109,7 +111,7 @@ func (i *Injector) DeleteCurrentAccount() error {
 
 // CheckAccountExists checks if an account file exists on device
 func (i *Injector) CheckAccountExists() (bool, error) {
-	gamePath := "/data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml"
+	gamePath := SharedPrefsPath(i.gamePackage)
 
 	output, err := i.adb.Shell(fmt.Sprintf("test -f %s && echo 'exists' || echo 'notfound'", gamePath))
 	if err != nil {
@@ -0,0 +1,128 @@
+package accounts
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Save data keys tried for the pack and hourglass counts when no column is configured,
+// compared lowercased without separators. They are not taken from a documented save schema:
+// check an extracted save (ExtractAppData) and configure the columns (SaveCountKeys) if the
+// counts read from it are wrong.
+var (
+	packCountKeys = []string{"packcount", "packsopened", "openedpackcount", "totalpackcount", "packopencount"}
+	hourglassKeys = []string{"hourglasses", "hourglass", "hourglasscount", "packhourglass", "packhourglasscount"}
+)
+
+// SaveCountKeys name the save data columns holding the counts. Empty fields fall back to the
+// known column names.
+type SaveCountKeys struct {
+	PackCount   string // Column holding the number of packs opened
+	Hourglasses string // Column holding the hourglass count
+}
+
+// saveKeys returns the normalized keys to look a count up by
+func saveKeys(column string, fallback []string) []string {
+	if strings.TrimSpace(column) == "" {
+		return fallback
+	}
+	return []string{normalizeMetadataKey(column)}
+}
+
+// SaveCounts are the counts read from the game's save, which are authoritative over counts
+// tallied from pack-open events
+type SaveCounts struct {
+	PacksOpened    int
+	Hourglasses    int
+	HasHourglasses bool // Whether the save had an hourglass count
+}
+
+// ReadPackCountFromSave reads how many packs the account has opened from its app data,
+// as extracted by ExtractAppData
+func ReadPackCountFromSave(appDataDir string) (int, error) {
+	counts, err := ReadSaveCounts(appDataDir)
+	if err != nil {
+		return 0, err
+	}
+	return counts.PacksOpened, nil
+}
+
+// ReadSaveCounts reads the pack and hourglass counts from the SQLite databases in an
+// account's app data, as extracted by ExtractAppData, trying the known column names
+func ReadSaveCounts(appDataDir string) (*SaveCounts, error) {
+	return ReadSaveCountsWithKeys(appDataDir, SaveCountKeys{})
+}
+
+// ReadSaveCountsWithKeys reads the pack and hourglass counts from the SQLite databases in an
+// account's app data, as extracted by ExtractAppData. The counts are looked up by column
+// name, so saves written by different game versions are read the same way. It fails if no
+// save database could be read or none of them has a pack count.
+func ReadSaveCountsWithKeys(appDataDir string, keys SaveCountKeys) (*SaveCounts, error) {
+	var databaseFiles []string
+	err := filepath.WalkDir(appDataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if isSaveDatabase(path) {
+			databaseFiles = append(databaseFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app data: %w", err)
+	}
+	if len(databaseFiles) == 0 {
+		return nil, fmt.Errorf("no save database found in %s", appDataDir)
+	}
+	sort.Strings(databaseFiles)
+
+	metadata := make(map[string]string)
+	readable := 0
+	var lastErr error
+	for _, path := range databaseFiles {
+		if err := readDatabaseMetadata(path, metadata); err != nil {
+			lastErr = fmt.Errorf("%s: %w", filepath.Base(path), err)
+			continue
+		}
+		readable++
+	}
+	if readable == 0 {
+		return nil, fmt.Errorf("failed to read save database: %w", lastErr)
+	}
+
+	counts := &SaveCounts{}
+	packs, found, err := metadataInt(metadata, saveKeys(keys.PackCount, packCountKeys))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pack count in save: %w", err)
+	}
+	if !found && keys.PackCount != "" {
+		return nil, fmt.Errorf("no '%s' column in save data", keys.PackCount)
+	}
+	if !found {
+		return nil, fmt.Errorf("no pack count in save data (unrecognized save format)")
+	}
+	counts.PacksOpened = packs
+
+	counts.Hourglasses, counts.HasHourglasses, err = metadataInt(metadata, saveKeys(keys.Hourglasses, hourglassKeys))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hourglass count in save: %w", err)
+	}
+	return counts, nil
+}
+
+// metadataInt returns the first key found as a non-negative number
+func metadataInt(metadata map[string]string, keys []string) (int, bool, error) {
+	value := metadataValue(metadata, keys)
+	if value == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0, false, fmt.Errorf("'%s' is not a count", value)
+	}
+	return n, true, nil
+}
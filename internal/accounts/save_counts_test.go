@@ -0,0 +1,111 @@
+package accounts
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeCountsDB creates a save database with one row of the given columns
+func writeCountsDB(t *testing.T, path, createTable, insert string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(createTable); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(insert); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadSaveCounts(t *testing.T) {
+	dir := t.TempDir()
+	writeCountsDB(t, filepath.Join(dir, "temp_app_data", "databases", "save.db"),
+		`CREATE TABLE user_status (pack_count INTEGER, hourglass_count INTEGER)`,
+		`INSERT INTO user_status VALUES (42, 7)`)
+
+	counts, err := ReadSaveCounts(dir)
+	if err != nil {
+		t.Fatalf("ReadSaveCounts failed: %v", err)
+	}
+	if counts.PacksOpened != 42 || counts.Hourglasses != 7 || !counts.HasHourglasses {
+		t.Errorf("got %+v, want 42 packs and 7 hourglasses", counts)
+	}
+
+	packs, err := ReadPackCountFromSave(dir)
+	if err != nil || packs != 42 {
+		t.Errorf("ReadPackCountFromSave = %d, %v; want 42", packs, err)
+	}
+}
+
+func TestReadSaveCountsOlderSchema(t *testing.T) {
+	// Older saves name the column differently and have no hourglass count
+	dir := t.TempDir()
+	writeCountsDB(t, filepath.Join(dir, "databases", "game.db"),
+		`CREATE TABLE stats (PacksOpened INTEGER)`,
+		`INSERT INTO stats VALUES (13)`)
+
+	counts, err := ReadSaveCounts(dir)
+	if err != nil {
+		t.Fatalf("ReadSaveCounts failed: %v", err)
+	}
+	if counts.PacksOpened != 13 || counts.HasHourglasses {
+		t.Errorf("got %+v, want 13 packs and no hourglass count", counts)
+	}
+}
+
+func TestReadSaveCountsErrors(t *testing.T) {
+	empty := t.TempDir()
+	if _, err := ReadSaveCounts(empty); err == nil || !strings.Contains(err.Error(), "no save database") {
+		t.Errorf("expected a missing save database error, got %v", err)
+	}
+
+	corrupt := t.TempDir()
+	path := filepath.Join(corrupt, "databases", "save.db")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSaveCounts(corrupt); err == nil || !strings.Contains(err.Error(), "failed to read save database") {
+		t.Errorf("expected an unreadable save database error, got %v", err)
+	}
+
+	unknown := t.TempDir()
+	writeCountsDB(t, filepath.Join(unknown, "databases", "save.db"),
+		`CREATE TABLE settings (volume INTEGER)`, `INSERT INTO settings VALUES (5)`)
+	if _, err := ReadSaveCounts(unknown); err == nil || !strings.Contains(err.Error(), "no pack count") {
+		t.Errorf("expected a missing pack count error, got %v", err)
+	}
+}
+
+func TestReadSaveCountsWithKeys(t *testing.T) {
+	// A configured column wins over the known names, which may hold a different count
+	dir := t.TempDir()
+	writeCountsDB(t, filepath.Join(dir, "databases", "save.db"),
+		`CREATE TABLE user_status (pack_count INTEGER, opened_total INTEGER, sand_timer INTEGER)`,
+		`INSERT INTO user_status VALUES (2, 58, 9)`)
+
+	counts, err := ReadSaveCountsWithKeys(dir, SaveCountKeys{PackCount: "opened_total", Hourglasses: "Sand_Timer"})
+	if err != nil {
+		t.Fatalf("ReadSaveCountsWithKeys failed: %v", err)
+	}
+	if counts.PacksOpened != 58 || counts.Hourglasses != 9 || !counts.HasHourglasses {
+		t.Errorf("got %+v, want 58 packs and 9 hourglasses", counts)
+	}
+
+	if _, err := ReadSaveCountsWithKeys(dir, SaveCountKeys{PackCount: "missing"}); err == nil || !strings.Contains(err.Error(), "no 'missing' column") {
+		t.Errorf("expected a missing column error, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// AccountData identifies the account a device is expected to have loaded.
+// Empty fields are not checked.
+type AccountData struct {
+	DeviceAccount string
+	Username      string
+	FriendCode    string
+}
+
+// VerifyLoadedAccount checks that the game package (AppPackage if empty) on the device is
+// running with the expected account.
+// The device account is read back from the preferences the game loads on start-up, and the
+// username and friend code are looked up in the game's saved preferences.
+// Returns false on a mismatch, and an error only if the device could not be read.
+func VerifyLoadedAccount(adbPath string, port int, gamePackage string, expected AccountData) (bool, error) {
+	if expected.DeviceAccount == "" && expected.Username == "" && expected.FriendCode == "" {
+		return false, fmt.Errorf("no account data to verify against")
+	}
+
+	adbAddress := fmt.Sprintf("127.0.0.1:%d", port)
+
+	if err := connectToDevice(adbPath, adbAddress); err != nil {
+		return false, fmt.Errorf("failed to connect to device: %w", err)
+	}
+
+	// The game has to be back up, otherwise it hasn't picked up the injected account yet
+	running, err := isAppRunning(adbPath, adbAddress, gamePackage)
+	if err != nil {
+		return false, fmt.Errorf("failed to check app: %w", err)
+	}
+	if !running {
+		return false, nil
+	}
+
+	accountXML, err := readDeviceFile(adbPath, adbAddress, SharedPrefsPath(gamePackage))
+	if err != nil {
+		return false, fmt.Errorf("failed to read device account: %w", err)
+	}
+
+	var prefs []byte
+	if expected.Username != "" || expected.FriendCode != "" {
+		prefs, err = readDeviceFile(adbPath, adbAddress, SharedPrefsDir(gamePackage)+"/*.xml")
+		if err != nil {
+			return false, fmt.Errorf("failed to read game preferences: %w", err)
+		}
+	}
+
+	if reason := accountMismatch(accountXML, prefs, expected); reason != "" {
+		fmt.Printf("Account verification on port %d failed: %s\n", port, reason)
+		return false, nil
+	}
+	return true, nil
+}
+
+// accountMismatch compares the device's account XML and saved preferences against the
+// expected account, returning a description of the first mismatch or "" if everything matches
+func accountMismatch(accountXML, prefs []byte, expected AccountData) string {
+	if expected.DeviceAccount != "" {
+		loaded := deviceAccountFromXML(accountXML)
+		if loaded != expected.DeviceAccount {
+			return fmt.Sprintf("device account is '%s', expected '%s'", loaded, expected.DeviceAccount)
+		}
+	}
+
+	if expected.Username != "" && !containsEscaped(prefs, expected.Username) {
+		return fmt.Sprintf("username '%s' not found in game preferences", expected.Username)
+	}
+
+	if expected.FriendCode != "" && !containsFriendCode(prefs, expected.FriendCode) {
+		return fmt.Sprintf("friend code '%s' not found in game preferences", expected.FriendCode)
+	}
+
+	return ""
+}
+
+// deviceAccountFromXML extracts the deviceAccount entry from a SharedPreferences XML file
+func deviceAccountFromXML(data []byte) string {
+	var xmlMap XMLMap
+	if err := xml.Unmarshal(data, &xmlMap); err != nil {
+		return ""
+	}
+	for _, entry := range xmlMap.Strings {
+		if entry.Name == "deviceAccount" {
+			return strings.TrimSpace(entry.Value)
+		}
+	}
+	return ""
+}
+
+// containsEscaped reports whether prefs contain value, either as-is or XML-escaped
+func containsEscaped(prefs []byte, value string) bool {
+	if bytes.Contains(prefs, []byte(value)) {
+		return true
+	}
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(value)); err != nil {
+		return false
+	}
+	return bytes.Contains(prefs, escaped.Bytes())
+}
+
+// containsFriendCode reports whether prefs contain the friend code, with or without separators
+func containsFriendCode(prefs []byte, friendCode string) bool {
+	if bytes.Contains(prefs, []byte(friendCode)) {
+		return true
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, friendCode)
+	return digits != "" && bytes.Contains(prefs, []byte(digits))
+}
+
+// isAppRunning checks whether the game process is running on the device
+func isAppRunning(adbPath, adbAddress, gamePackage string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", "pidof", appPackage(gamePackage))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, fmt.Errorf("pidof timed out")
+		}
+		return false, nil // pidof exits non-zero when the process is not found
+	}
+	return len(strings.TrimSpace(string(output))) > 0, nil
+}
+
+// readDeviceFile reads a root-owned file (or glob) from the device
+func readDeviceFile(adbPath, adbAddress, path string) ([]byte, error) {
+	return readDeviceCommand(adbPath, adbAddress, "cat "+path)
+}
+
+// readDeviceCommand runs a shell command as root on the device and returns its output
+func readDeviceCommand(adbPath, adbAddress, command string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	suCmd := fmt.Sprintf("su -c '%s'", command)
+	cmd := exec.CommandContext(ctx, adbPath, "-s", adbAddress, "shell", suCmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %v, output: %s", strings.Fields(command)[0], err, string(output))
+	}
+	return output, nil
+}
+
+// ReadDeviceAccountData reads the identity of the account loaded on the device from the
+// preferences of the game package (AppPackage if empty), without pulling the whole app data
+func ReadDeviceAccountData(adbPath string, port int, gamePackage string) (AccountData, error) {
+	adbAddress := fmt.Sprintf("127.0.0.1:%d", port)
+
+	if err := connectToDevice(adbPath, adbAddress); err != nil {
+		return AccountData{}, fmt.Errorf("failed to connect to device: %w", err)
+	}
+
+	prefsDir := SharedPrefsDir(gamePackage)
+	listing, err := readDeviceCommand(adbPath, adbAddress, "ls "+prefsDir)
+	if err != nil {
+		return AccountData{}, fmt.Errorf("failed to list game preferences: %w", err)
+	}
+
+	account := AccountData{}
+	metadata := make(map[string]string)
+	for _, name := range strings.Fields(string(listing)) {
+		if !strings.HasSuffix(name, ".xml") {
+			continue
+		}
+		data, err := readDeviceFile(adbPath, adbAddress, fmt.Sprintf(`"%s/%s"`, prefsDir, name))
+		if err != nil {
+			continue
+		}
+
+		var xmlMap XMLMap
+		if err := xml.Unmarshal(data, &xmlMap); err != nil {
+			continue // Not every preferences file is a string map
+		}
+		for _, entry := range xmlMap.Strings {
+			if entry.Name == "deviceAccount" {
+				account.DeviceAccount = strings.TrimSpace(entry.Value)
+				continue
+			}
+			addMetadata(metadata, entry.Name, entry.Value)
+		}
+	}
+
+	account.Username = metadataValue(metadata, usernameKeys)
+	account.FriendCode = metadataValue(metadata, friendCodeKeys)
+	if account.DeviceAccount == "" && account.Username == "" && account.FriendCode == "" {
+		return AccountData{}, fmt.Errorf("no account data in game preferences")
+	}
+	return account, nil
+}
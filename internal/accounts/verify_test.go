@@ -0,0 +1,59 @@
+package accounts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAccountMismatch(t *testing.T) {
+	accountXML := []byte(`<?xml version='1.0' encoding='utf-8' standalone='yes' ?>
+<map>
+    <string name="deviceAccount">abc123</string>
+    <string name="devicePassword">secret</string>
+</map>`)
+	prefs := []byte(`<map>
+    <string name="playerName">Ash &amp; Pikachu</string>
+    <string name="friendId">1234567890123456</string>
+</map>`)
+
+	tests := []struct {
+		name     string
+		expected AccountData
+		mismatch string // Substring of the expected mismatch, "" for a match
+	}{
+		{"device account matches", AccountData{DeviceAccount: "abc123"}, ""},
+		{"device account differs", AccountData{DeviceAccount: "other"}, "device account is 'abc123'"},
+		{"escaped username", AccountData{DeviceAccount: "abc123", Username: "Ash & Pikachu"}, ""},
+		{"wrong username", AccountData{Username: "Misty"}, "username 'Misty'"},
+		{"friend code with dashes", AccountData{FriendCode: "1234-5678-9012-3456"}, ""},
+		{"wrong friend code", AccountData{FriendCode: "9999-9999-9999-9999"}, "friend code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := accountMismatch(accountXML, prefs, tt.expected)
+			if tt.mismatch == "" && got != "" {
+				t.Errorf("Expected match, got mismatch: %s", got)
+			}
+			if tt.mismatch != "" && !strings.Contains(got, tt.mismatch) {
+				t.Errorf("Expected mismatch containing '%s', got '%s'", tt.mismatch, got)
+			}
+		})
+	}
+}
+
+func TestAccountMismatchUnreadableXML(t *testing.T) {
+	got := accountMismatch([]byte("su: not found"), nil, AccountData{DeviceAccount: "abc123"})
+	if got == "" {
+		t.Error("Expected mismatch for unreadable account XML")
+	}
+}
+
+func TestSharedPrefsPath(t *testing.T) {
+	if got, want := SharedPrefsPath(""), "/data/data/jp.pokemon.pokemontcgp/shared_prefs/deviceAccount:.xml"; got != want {
+		t.Errorf("SharedPrefsPath(\"\") = %s, want %s", got, want)
+	}
+	if got, want := SharedPrefsDir("com.example.game"), "/data/data/com.example.game/shared_prefs"; got != want {
+		t.Errorf("SharedPrefsDir = %s, want %s", got, want)
+	}
+}
@@ -30,6 +30,8 @@ type AccountFile struct {
 	DeviceAccount  string
 	DevicePassword string
 	FilePath       string
+	Username       string // From legacy filename metadata (may be empty)
+	FriendCode     string // From legacy filename metadata (may be empty)
 }
 
 // LoadAccountsFromXML loads all XML account files from a directory
@@ -99,6 +101,12 @@ func LoadAccountsFromXML(directory string) ([]*AccountFile, error) {
 			FilePath:       filePath,
 		}
 
+		// Legacy filenames carry username and friend code (used for duplicate detection)
+		if metadata, err := ExtractMetadata(file.Name()); err == nil {
+			accountFile.Username = metadata.Username
+			accountFile.FriendCode = metadata.FriendCode
+		}
+
 		accounts = append(accounts, accountFile)
 	}
 
@@ -15,6 +15,7 @@ type InjectNextAccount struct {
 	Timeout      int    `yaml:"timeout"`        // Timeout in milliseconds (default: 30000)
 	SaveResult   string `yaml:"save_result"`    // Variable name to store account ID
 	OnNoAccounts string `yaml:"on_no_accounts"` // Action if pool empty: "wait", "stop", "continue" (default: "stop")
+	SkipVerify   bool   `yaml:"skip_verify"`    // Don't restart the game to check it loaded the account
 }
 
 func (a *InjectNextAccount) Validate(ab *ActionBuilder) error {
@@ -55,9 +56,9 @@ func (a *InjectNextAccount) Build(ab *ActionBuilder) *ActionBuilder {
 				return fmt.Errorf("no account pool configured in manager")
 			}
 
-			// Get database for checkout operations
+			// Get database for checkout operations (sandbox pools never touch the database)
 			var db *sql.DB
-			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok {
+			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok && !accountpool.IsSandbox(accountPool) {
 				db = dbProvider.Database()
 			}
 
@@ -76,7 +77,7 @@ func (a *InjectNextAccount) Build(ab *ActionBuilder) *ActionBuilder {
 			maxRetries := 10
 			for retry := 0; retry < maxRetries; retry++ {
 				// Request next account from pool
-				acc, err := accountPool.GetNext(ctx)
+				acc, err := accountpool.GetNextForInstance(ctx, accountPool, botIf.Instance())
 				if err != nil {
 					// Handle no accounts available
 					if err.Error() == "no accounts available" || err.Error() == "account pool is closed" {
@@ -146,6 +147,21 @@ func (a *InjectNextAccount) Build(ab *ActionBuilder) *ActionBuilder {
 				return fmt.Errorf("failed to inject account: %w", err)
 			}
 
+			// Never run the routine against the wrong account: one the game doesn't load is failed
+			if verifier, ok := botIf.(interface{ VerifyInjectedAccount() error }); ok && !a.SkipVerify {
+				if err := verifier.VerifyInjectedAccount(); err != nil {
+					if failErr := accountPool.MarkFailed(account, err.Error()); failErr != nil {
+						fmt.Printf("Bot %d: Warning - failed to mark account '%s' failed: %v\n", botIf.Instance(), account.ID, failErr)
+					}
+					if db != nil {
+						database.ReleaseAccount(db, account.DeviceAccount, orchestrationID)
+					}
+					botIf.ClearCurrentAccount()
+					accountCompleted(botIf)
+					return fmt.Errorf("failed to verify account '%s': %w", account.ID, err)
+				}
+			}
+
 			// Save account ID to variable if requested
 			if a.SaveResult != "" {
 				botIf.Variables().Set(a.SaveResult, account.ID)
@@ -154,15 +170,18 @@ func (a *InjectNextAccount) Build(ab *ActionBuilder) *ActionBuilder {
 
 			// Try to get database account ID if database is available
 			// This enables routine execution tracking
-			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok {
+			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok && !accountpool.IsSandbox(accountPool) {
 				if db := dbProvider.Database(); db != nil && account.DeviceAccount != "" {
 					accountID, err := database.GetAccountIDByDeviceAccount(db, account.DeviceAccount)
 					if err != nil {
 						fmt.Printf("Bot %d: Warning - could not get database account ID: %v\n", botIf.Instance(), err)
 					} else {
-						// Set device_account_id variable for routine execution tracking
-						botIf.Variables().Set("device_account_id", fmt.Sprintf("%d", accountID))
+						// Set device_account_id variable for routine execution tracking (kept across iterations)
+						botIf.Variables().SetPersistent(VarDeviceAccountID, fmt.Sprintf("%d", accountID))
 						fmt.Printf("Bot %d: Set device_account_id variable to %d\n", botIf.Instance(), accountID)
+
+						// Load the routine's account-state variables for this account
+						loadAccountState(botIf)
 					}
 				}
 			}
@@ -270,7 +289,7 @@ func (a *CompleteAccount) Build(ab *ActionBuilder) *ActionBuilder {
 			}
 
 			// Release account checkout in database
-			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok {
+			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok && !accountpool.IsSandbox(accountPool) {
 				if db := dbProvider.Database(); db != nil && account.DeviceAccount != "" {
 					orchestrationID := botIf.OrchestrationID()
 					if err := database.ReleaseAccount(db, account.DeviceAccount, orchestrationID); err != nil {
@@ -285,6 +304,9 @@ func (a *CompleteAccount) Build(ab *ActionBuilder) *ActionBuilder {
 			// Clear current account from bot
 			botIf.ClearCurrentAccount()
 
+			recordProgress(botIf)
+			accountCompleted(botIf)
+
 			fmt.Printf("Bot %d: Account '%s' marked as %s\n", botIf.Instance(), account.ID,
 				map[bool]string{true: "completed", false: "failed"}[a.Success])
 
@@ -364,7 +386,7 @@ func (a *ReturnAccount) Build(ab *ActionBuilder) *ActionBuilder {
 			}
 
 			// Release account checkout in database
-			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok {
+			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok && !accountpool.IsSandbox(accountPool) {
 				if db := dbProvider.Database(); db != nil && account.DeviceAccount != "" {
 					orchestrationID := botIf.OrchestrationID()
 					if err := database.ReleaseAccount(db, account.DeviceAccount, orchestrationID); err != nil {
@@ -379,6 +401,8 @@ func (a *ReturnAccount) Build(ab *ActionBuilder) *ActionBuilder {
 			// Clear current account from bot
 			botIf.ClearCurrentAccount()
 
+			recordProgress(botIf)
+
 			if a.Reason != "" {
 				fmt.Printf("Bot %d: Account '%s' returned to pool (%s)\n", botIf.Instance(), account.ID, a.Reason)
 			} else {
@@ -463,7 +487,7 @@ func (a *MarkAccountFailed) Build(ab *ActionBuilder) *ActionBuilder {
 			}
 
 			// Release account checkout in database
-			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok {
+			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok && !accountpool.IsSandbox(accountPool) {
 				if db := dbProvider.Database(); db != nil && account.DeviceAccount != "" {
 					orchestrationID := botIf.OrchestrationID()
 					if err := database.ReleaseAccount(db, account.DeviceAccount, orchestrationID); err != nil {
@@ -477,6 +501,7 @@ func (a *MarkAccountFailed) Build(ab *ActionBuilder) *ActionBuilder {
 
 			// Clear current account from bot
 			botIf.ClearCurrentAccount()
+			accountCompleted(botIf)
 
 			fmt.Printf("Bot %d: Account '%s' marked as failed: %s\n", botIf.Instance(), account.ID, a.Reason)
 
@@ -487,3 +512,19 @@ func (a *MarkAccountFailed) Build(ab *ActionBuilder) *ActionBuilder {
 	ab.steps = append(ab.steps, step)
 	return ab
 }
+
+// recordProgress resets the bot's idle watchdog after meaningful work (an account
+// processed, packs opened). Bots without a watchdog ignore it.
+func recordProgress(botIf BotInterface) {
+	if recorder, ok := botIf.(interface{ RecordProgress() }); ok {
+		recorder.RecordProgress()
+	}
+}
+
+// accountCompleted tells the bot's manager an account is done (completed or failed), so it
+// can sample the group's progress
+func accountCompleted(botIf BotInterface) {
+	if tracker, ok := botIf.Manager().(interface{ AccountCompleted() }); ok {
+		tracker.AccountCompleted()
+	}
+}
@@ -0,0 +1,165 @@
+package actions
+
+import (
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// accountStateDB returns the database and the injected account's ID for account state. The
+// state lives in the shared accounts database, next to the account the ID refers to.
+func accountStateDB(bot BotInterface) (*database.DB, int, error) {
+	conn := accountsDB(bot)
+	if conn == nil {
+		return nil, 0, fmt.Errorf("no database available for account state")
+	}
+	accountID, hasAccount := bot.Variables().GetInt(VarDeviceAccountID)
+	if !hasAccount {
+		return nil, 0, fmt.Errorf("no account injected")
+	}
+	return database.FromConn(conn), accountID, nil
+}
+
+// loadAccountState sets the account-state variables (config params with persist_account)
+// from the injected account's stored state, or to their defaults where it has none, so one
+// account's values never carry over to the next
+func loadAccountState(bot BotInterface) {
+	vs, ok := bot.Variables().(*VariableStore)
+	if !ok {
+		return
+	}
+	defaults := vs.AccountStateVariables()
+	if len(defaults) == 0 {
+		return
+	}
+
+	db, accountID, err := accountStateDB(bot)
+	if err != nil {
+		return
+	}
+	stored, err := db.GetAllAccountState(accountID)
+	if err != nil {
+		fmt.Printf("Bot %d: Warning - failed to load account state: %v\n", bot.Instance(), err)
+		stored = nil
+	}
+
+	for name, defaultValue := range defaults {
+		value, found := stored[name]
+		if !found {
+			value = defaultValue
+		}
+		vs.SetPersistent(name, value)
+	}
+	fmt.Printf("Bot %d: Loaded %d account state variable(s) for account %d\n", bot.Instance(), len(defaults), accountID)
+}
+
+// FlushAccountState stores the account-state variables to the injected account's state.
+// It is called when the account is released, before its ID is cleared.
+func FlushAccountState(bot BotInterface) {
+	vs, ok := bot.Variables().(*VariableStore)
+	if !ok {
+		return
+	}
+	names := vs.AccountStateVariables()
+	if len(names) == 0 {
+		return
+	}
+
+	db, accountID, err := accountStateDB(bot)
+	if err != nil {
+		return
+	}
+
+	values := make(map[string]string, len(names))
+	for name := range names {
+		if value, exists := vs.Get(name); exists {
+			values[name] = value
+		}
+	}
+	if err := db.SetAccountStates(accountID, values); err != nil {
+		fmt.Printf("Bot %d: Warning - failed to save account state: %v\n", bot.Instance(), err)
+	}
+}
+
+// SetAccountState stores a value in the injected account's state, where it survives across
+// runs of that account (e.g. the last day a mission was claimed)
+type SetAccountState struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"` // Supports ${variable} interpolation
+}
+
+func (a *SetAccountState) Validate(ab *ActionBuilder) error {
+	if a.Key == "" {
+		return fmt.Errorf("SetAccountState: key is required")
+	}
+	return nil
+}
+
+func (a *SetAccountState) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("SetAccountState (%s = %s)", a.Key, a.Value),
+		execute: func(bot BotInterface) error {
+			value, err := InterpolateString(a.Value, bot)
+			if err != nil {
+				return fmt.Errorf("SetAccountState: %w", err)
+			}
+
+			db, accountID, err := accountStateDB(bot)
+			if err != nil {
+				return fmt.Errorf("SetAccountState: %w", err)
+			}
+			if err := db.SetAccountState(accountID, a.Key, value); err != nil {
+				return fmt.Errorf("SetAccountState: %w", err)
+			}
+			return nil
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// GetAccountState reads a value from the injected account's state into a variable (the key
+// by default). An account without the key gets the default value.
+type GetAccountState struct {
+	Key      string `yaml:"key"`
+	Variable string `yaml:"variable,omitempty"` // Variable to store the value in (default: key)
+	Default  string `yaml:"default,omitempty"`  // Value when the account has no value for the key
+}
+
+func (a *GetAccountState) Validate(ab *ActionBuilder) error {
+	if a.Key == "" {
+		return fmt.Errorf("GetAccountState: key is required")
+	}
+	return nil
+}
+
+func (a *GetAccountState) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("GetAccountState (%s)", a.Key),
+		execute: func(bot BotInterface) error {
+			db, accountID, err := accountStateDB(bot)
+			if err != nil {
+				return fmt.Errorf("GetAccountState: %w", err)
+			}
+
+			value, found, err := db.GetAccountState(accountID, a.Key)
+			if err != nil {
+				return fmt.Errorf("GetAccountState: %w", err)
+			}
+			if !found {
+				value = a.Default
+			}
+
+			variable := a.Variable
+			if variable == "" {
+				variable = a.Key
+			}
+			bot.Variables().Set(variable, value)
+			return nil
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
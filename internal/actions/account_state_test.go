@@ -0,0 +1,199 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// queuePool hands out its accounts in order, as account injection needs
+type queuePool struct {
+	accountpool.AccountPool
+	accounts []*accountpool.Account
+	failed   []*accountpool.Account
+}
+
+func (p *queuePool) GetNext(context.Context) (*accountpool.Account, error) {
+	if len(p.accounts) == 0 {
+		return nil, fmt.Errorf("no accounts available")
+	}
+	account := p.accounts[0]
+	p.accounts = p.accounts[1:]
+	return account, nil
+}
+
+func (p *queuePool) Return(account *accountpool.Account) error {
+	p.accounts = append(p.accounts, account)
+	return nil
+}
+
+func (p *queuePool) MarkFailed(account *accountpool.Account, reason string) error {
+	p.failed = append(p.failed, account)
+	return nil
+}
+
+// stateManager provides the pool and the shared accounts database, as the orchestrator's
+// manager adapter does
+type stateManager struct {
+	pool *queuePool
+	db   *sql.DB
+}
+
+func (m *stateManager) AccountPool() accountpool.AccountPool { return m.pool }
+func (m *stateManager) Database() *sql.DB                    { return m.db }
+
+// accountStateBot is checkpointBot with a manager and an injected account, as account
+// state needs
+type accountStateBot struct {
+	checkpointBot
+	manager *stateManager
+	account **accountpool.Account
+}
+
+func (b accountStateBot) Manager() interface{}           { return b.manager }
+func (accountStateBot) OrchestrationID() string          { return "orchestration" }
+func (b accountStateBot) GetCurrentAccount() interface{} { return *b.account }
+
+func (b accountStateBot) InjectAccount(account interface{}) error {
+	*b.account = account.(*accountpool.Account)
+	return nil
+}
+
+// ClearCurrentAccount releases the account as bot.Bot does
+func (b accountStateBot) ClearCurrentAccount() {
+	FlushAccountState(b)
+	*b.account = nil
+	b.vars.Delete(VarDeviceAccountID)
+}
+
+// openAccountStateDB opens a migrated database for account state tests
+func openAccountStateDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	return db
+}
+
+func newAccountStateBot(db *database.DB, accounts ...*accountpool.Account) accountStateBot {
+	var current *accountpool.Account
+	return accountStateBot{
+		checkpointBot: checkpointBot{vars: NewVariableStore()},
+		manager:       &stateManager{pool: &queuePool{accounts: accounts}, db: db.Conn()},
+		account:       &current,
+	}
+}
+
+func TestAccountStateVariablesLoadAndFlush(t *testing.T) {
+	db := openAccountStateDB(t)
+
+	first, err := db.CreateAccount("first_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	second, err := db.CreateAccount("second_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	bot := newAccountStateBot(db)
+	config := []ConfigParam{{Name: "last_mission_day", Type: "text", Default: "never", PersistAccount: true}}
+	if err := InitializeConfigVariables(bot, config, nil); err != nil {
+		t.Fatalf("Failed to initialize config variables: %v", err)
+	}
+
+	inject := func(accountID int) {
+		bot.vars.SetPersistent(VarDeviceAccountID, strconv.Itoa(accountID))
+		loadAccountState(bot)
+	}
+	release := func() {
+		FlushAccountState(bot)
+		bot.vars.Delete(VarDeviceAccountID)
+	}
+
+	// The first account has no state yet, so it starts from the default and stores its change
+	inject(first.ID)
+	if value, _ := bot.vars.Get("last_mission_day"); value != "never" {
+		t.Errorf("Expected default for a new account, got %q", value)
+	}
+	bot.vars.Set("last_mission_day", "2024-01-02")
+	release()
+
+	// The second account must not inherit the first account's value
+	inject(second.ID)
+	if value, _ := bot.vars.Get("last_mission_day"); value != "never" {
+		t.Errorf("Expected default for the second account, got %q", value)
+	}
+	release()
+
+	// Account-state variables survive the clear at the start of each iteration
+	inject(first.ID)
+	bot.vars.ClearNonPersistent()
+	if value, _ := bot.vars.Get("last_mission_day"); value != "2024-01-02" {
+		t.Errorf("Expected the first account's stored value, got %q", value)
+	}
+}
+
+func TestAccountStateAcrossInjections(t *testing.T) {
+	db := openAccountStateDB(t)
+	if _, err := db.CreateAccount("state_account", "password", ""); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	account := &accountpool.Account{ID: "state_account", DeviceAccount: "state_account"}
+	bot := newAccountStateBot(db, account)
+	config := []ConfigParam{{Name: "streak", Type: "text", Default: "0", PersistAccount: true}}
+	if err := InitializeConfigVariables(bot, config, nil); err != nil {
+		t.Fatalf("Failed to initialize config variables: %v", err)
+	}
+
+	run := func(steps ...ActionStep) {
+		t.Helper()
+		ab := NewActionBuilder()
+		for _, step := range steps {
+			step.Build(ab)
+		}
+		if err := ab.executeSteps(context.Background(), bot); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	}
+
+	// Inject, store state through both the action and a persist_account variable, then release
+	run(
+		&InjectNextAccount{},
+		&SetAccountState{Key: "last_claim", Value: "2024-01-02"},
+	)
+	bot.vars.Set("streak", "3")
+	bot.ClearCurrentAccount()
+	if _, exists := bot.vars.Get(VarDeviceAccountID); exists {
+		t.Fatal("Expected the account ID to be cleared")
+	}
+	if err := database.ReleaseAccount(db.Conn(), "state_account", "orchestration"); err != nil {
+		t.Fatalf("Failed to release checkout: %v", err)
+	}
+	bot.manager.pool.Return(account)
+	bot.vars.Set("streak", "0")
+
+	// Injecting the account again brings its state back
+	run(
+		&InjectNextAccount{},
+		&GetAccountState{Key: "last_claim", Default: "never"},
+	)
+	if value, _ := bot.vars.Get("last_claim"); value != "2024-01-02" {
+		t.Errorf("Expected the stored last_claim, got %q", value)
+	}
+	if value, _ := bot.vars.Get("streak"); value != "3" {
+		t.Errorf("Expected the stored streak, got %q", value)
+	}
+}
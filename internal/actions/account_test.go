@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// unloadedAccountBot is accountStateBot whose game never loads the injected account
+type unloadedAccountBot struct {
+	accountStateBot
+}
+
+func (unloadedAccountBot) VerifyInjectedAccount() error {
+	return errors.New("injected account was not loaded")
+}
+
+func TestInjectNextAccountFailsUnloadedAccount(t *testing.T) {
+	db := openAccountStateDB(t)
+	if _, err := db.CreateAccount("unloaded_account", "password", ""); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	account := &accountpool.Account{ID: "unloaded_account", DeviceAccount: "unloaded_account"}
+	bot := unloadedAccountBot{newAccountStateBot(db, account)}
+
+	ab := NewActionBuilder()
+	(&InjectNextAccount{}).Build(ab)
+	if err := ab.executeSteps(context.Background(), bot); err == nil {
+		t.Fatal("Expected injection to fail when the account is not loaded")
+	}
+
+	pool := bot.manager.pool
+	if len(pool.failed) != 1 || pool.failed[0] != account {
+		t.Errorf("Expected the account to be failed through the pool, got %v", pool.failed)
+	}
+	if len(pool.accounts) != 0 {
+		t.Errorf("Expected the account not to be returned to the pool, got %d queued", len(pool.accounts))
+	}
+	if current := bot.GetCurrentAccount(); current != (*accountpool.Account)(nil) {
+		t.Errorf("Expected no current account, got %v", current)
+	}
+	checkedOut, _, _, err := database.IsAccountCheckedOut(db.Conn(), "unloaded_account")
+	if err != nil {
+		t.Fatalf("Failed to check checkout: %v", err)
+	}
+	if checkedOut {
+		t.Error("Expected the account checkout to be released")
+	}
+
+	// The routine can skip verification, e.g. when it restarts the game itself
+	pool.accounts = []*accountpool.Account{account}
+	ab = NewActionBuilder()
+	(&InjectNextAccount{SkipVerify: true}).Build(ab)
+	if err := ab.executeSteps(context.Background(), bot); err != nil {
+		t.Fatalf("Expected injection without verification to succeed: %v", err)
+	}
+}
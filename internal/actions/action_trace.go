@@ -0,0 +1,325 @@
+package actions
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TraceEntry is one executed step in an action trace (one JSON line)
+type TraceEntry struct {
+	Seq        int64                  `json:"seq"`              // Order in which steps started (1-based)
+	Time       time.Time              `json:"time"`             // When the step started
+	Step       string                 `json:"step"`             // Step name as shown in logs
+	Action     string                 `json:"action,omitempty"` // YAML action name ("" for steps built in code)
+	Args       map[string]interface{} `json:"args,omitempty"`   // Action fields by YAML key, with variables resolved
+	Nested     bool                   `json:"nested,omitempty"` // The step runs nested steps, which are traced separately
+	DurationMs int64                  `json:"duration_ms"`      // How long the step took
+	Error      string                 `json:"error,omitempty"`  // Why the step failed ("" on success)
+}
+
+// ActionTrace records every step a bot executes to a JSONL file so a run can be
+// inspected or replayed (see ReplayTrace)
+type ActionTrace struct {
+	mu       sync.Mutex
+	instance int
+	file     *os.File
+	path     string
+	seq      int64
+}
+
+// NewActionTrace creates a disabled action trace for an instance
+func NewActionTrace(instance int) *ActionTrace {
+	return &ActionTrace{instance: instance}
+}
+
+// Start begins a new trace file in dir, closing any trace in progress.
+// Returns the path of the new file.
+func (at *ActionTrace) Start(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create trace folder: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("trace_instance_%d_%s.jsonl", at.instance, time.Now().Format("20060102_150405")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create trace file: %w", err)
+	}
+
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if at.file != nil {
+		at.file.Close()
+	}
+	at.file = file
+	at.path = path
+	at.seq = 0
+	return path, nil
+}
+
+// Stop closes the trace file; later steps are not recorded
+func (at *ActionTrace) Stop() error {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	if at.file == nil {
+		return nil
+	}
+	err := at.file.Close()
+	at.file = nil
+	return err
+}
+
+// Enabled reports whether steps are being recorded
+func (at *ActionTrace) Enabled() bool {
+	if at == nil {
+		return false
+	}
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	return at.file != nil
+}
+
+// Path returns the current (or last) trace file
+func (at *ActionTrace) Path() string {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	return at.path
+}
+
+// begin creates the entry for a step about to run, resolving its arguments against
+// the bot's variables as they are before the step executes
+func (at *ActionTrace) begin(bot BotInterface, step *Step) *TraceEntry {
+	at.mu.Lock()
+	at.seq++
+	seq := at.seq
+	at.mu.Unlock()
+
+	entry := &TraceEntry{
+		Seq:  seq,
+		Time: time.Now(),
+		Step: step.name,
+	}
+	if step.action != nil {
+		action := step.action
+		if wrapped, ok := action.(*ActionWithMetadata); ok {
+			action = wrapped.Action
+		}
+		entry.Action = actionName(action)
+		entry.Args = traceArgs(bot, action)
+		entry.Nested = hasNestedSteps(action)
+	}
+	return entry
+}
+
+// finish records a step's outcome and writes its entry
+func (at *ActionTrace) finish(entry *TraceEntry, err error) {
+	entry.DurationMs = time.Since(entry.Time).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		fmt.Printf("Bot %d: Failed to trace step '%s': %v\n", at.instance, entry.Step, marshalErr)
+		return
+	}
+
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if at.file == nil {
+		return
+	}
+	if _, writeErr := at.file.Write(append(line, '\n')); writeErr != nil {
+		fmt.Printf("Bot %d: Failed to write action trace: %v\n", at.instance, writeErr)
+	}
+}
+
+// traceStep starts tracing a step if the bot records an action trace.
+// The returned function records the step's outcome.
+func traceStep(bot BotInterface, step *Step) func(error) {
+	type actionTraceProvider interface {
+		ActionTrace() *ActionTrace
+	}
+
+	provider, ok := bot.(actionTraceProvider)
+	if !ok {
+		return func(error) {}
+	}
+	trace := provider.ActionTrace()
+	if !trace.Enabled() {
+		return func(error) {}
+	}
+
+	entry := trace.begin(bot, step)
+	return func(err error) {
+		trace.finish(entry, err)
+	}
+}
+
+var (
+	actionNamesOnce sync.Once
+	actionNames     map[reflect.Type]string
+)
+
+// actionName returns the YAML action name of an action (see actionRegistry)
+func actionName(action ActionStep) string {
+	actionNamesOnce.Do(func() {
+		names := make([]string, 0, len(actionRegistry))
+		for name := range actionRegistry {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		actionNames = make(map[reflect.Type]string, len(names))
+		for _, name := range names {
+			if _, exists := actionNames[actionRegistry[name]]; !exists {
+				actionNames[actionRegistry[name]] = name
+			}
+		}
+	})
+
+	t := reflect.TypeOf(action)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return actionNames[t]
+}
+
+// traceArgs returns an action's fields by YAML key, with variable references in
+// top-level strings resolved as they would be at execution time
+func traceArgs(bot BotInterface, action ActionStep) map[string]interface{} {
+	data, err := yaml.Marshal(action)
+	if err != nil {
+		return nil
+	}
+	var args map[string]interface{}
+	if err := yaml.Unmarshal(data, &args); err != nil {
+		return nil
+	}
+
+	for key, value := range args {
+		switch v := value.(type) {
+		case string:
+			if resolved, err := InterpolateString(v, bot); err == nil {
+				args[key] = resolved
+			}
+		case []interface{}:
+			for i, item := range v {
+				if s, ok := item.(string); ok {
+					if resolved, err := InterpolateString(s, bot); err == nil {
+						v[i] = resolved
+					}
+				}
+			}
+		}
+	}
+	return args
+}
+
+var actionStepsType = reflect.TypeOf([]ActionStep(nil))
+
+// hasNestedSteps reports whether an action runs other steps (nested actions or a routine)
+func hasNestedSteps(action ActionStep) bool {
+	if _, ok := action.(*RunRoutine); ok {
+		return true
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(action))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Type() == actionStepsType {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadTrace reads the entries of an action trace file
+func LoadTrace(path string) ([]TraceEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace: %w", err)
+	}
+	defer file.Close()
+
+	entries := make([]TraceEntry, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry TraceEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("trace line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace: %w", err)
+	}
+	return entries, nil
+}
+
+// ReplayTrace prints the actions recorded in a trace and, if bot is not nil, executes
+// them again in order. Steps that ran nested steps are not re-executed themselves; the
+// nested steps they ran are replayed instead, so the bot repeats exactly the actions
+// recorded. Steps built in code (no action name) are printed but skipped.
+// Replay stops at the first action that fails.
+func ReplayTrace(path string, bot BotInterface) error {
+	entries, err := LoadTrace(path)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		status := "ok"
+		if entry.Error != "" {
+			status = "failed: " + entry.Error
+		}
+		fmt.Printf("[%d] %s %v (%dms, %s)\n", entry.Seq, entry.Step, entry.Args, entry.DurationMs, status)
+
+		if bot == nil || entry.Nested || entry.Action == "" {
+			continue
+		}
+		if err := replayEntry(entry, bot); err != nil {
+			return fmt.Errorf("replay of step %d (%s) failed: %w", entry.Seq, entry.Step, err)
+		}
+	}
+	return nil
+}
+
+// replayEntry rebuilds the action of a trace entry and executes it on bot
+func replayEntry(entry TraceEntry, bot BotInterface) error {
+	raw := make(map[string]interface{}, len(entry.Args)+1)
+	for key, value := range entry.Args {
+		raw[key] = value
+	}
+	raw["action"] = entry.Action
+
+	data, err := yaml.Marshal(map[string]interface{}{"steps": []interface{}{raw}})
+	if err != nil {
+		return fmt.Errorf("failed to encode action: %w", err)
+	}
+	var routine Routine
+	if err := yaml.Unmarshal(data, &routine); err != nil {
+		return fmt.Errorf("failed to rebuild action: %w", err)
+	}
+
+	ab := NewActionBuilder()
+	for _, action := range routine.Steps {
+		ab = ab.buildAction(action)
+	}
+	return ab.Execute(bot)
+}
@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestActionTraceRoundTrip(t *testing.T) {
+	at := NewActionTrace(2)
+	path, err := at.Start(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ab := NewActionBuilder()
+	ab = ab.buildAction(&Delay{Count: 2})
+	ab = ab.buildAction(&Repeat{Iterations: 1, Actions: []ActionStep{&Delay{Count: 1}}})
+	ab.steps = append(ab.steps, Step{name: "InCode"})
+
+	// Arguments here have no variable references, so begin never touches the bot
+	outcomes := []error{nil, nil, errors.New("boom")}
+	for i := range ab.steps {
+		at.finish(at.begin(nil, &ab.steps[i]), outcomes[i])
+	}
+	if err := at.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadTrace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	if entries[0].Action != "delay" || entries[0].Args["count"] != float64(2) || entries[0].Nested {
+		t.Errorf("delay entry = %+v", entries[0])
+	}
+	if entries[1].Action != "repeat" || !entries[1].Nested {
+		t.Errorf("repeat entry = %+v", entries[1])
+	}
+	if entries[2].Action != "" || entries[2].Error != "boom" || entries[2].Seq != 3 {
+		t.Errorf("code step entry = %+v", entries[2])
+	}
+
+	// Without a bot the trace is only printed
+	if err := ReplayTrace(path, nil); err != nil {
+		t.Errorf("print-only replay failed: %v", err)
+	}
+}
+
+func TestActionTraceDisabled(t *testing.T) {
+	var at *ActionTrace
+	if at.Enabled() {
+		t.Error("nil trace should be disabled")
+	}
+	if NewActionTrace(1).Enabled() {
+		t.Error("new trace should be disabled until started")
+	}
+}
@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SentryTypeBanDetection is the built-in sentry that watches for the "account banned" screen
+const SentryTypeBanDetection = "ban_detection"
+
+// VarAccountBanned is set to "true" when a ban_detection sentry sees the ban screen.
+// The restart loop checks it after each iteration, releases the account as banned,
+// and stops instead of retrying.
+const VarAccountBanned = "account_banned"
+
+// ErrAccountBanned is returned when the current account was detected as banned
+var ErrAccountBanned = errors.New("account banned")
+
+// MarkAccountBanned flags the bot's current account as banned.
+// The flag is persistent so it survives until the restart loop handles it.
+func MarkAccountBanned(bot BotInterface) {
+	bot.Variables().SetPersistent(VarAccountBanned, "true")
+}
+
+// IsAccountBanned reports whether a ban_detection sentry flagged the current account
+func IsAccountBanned(vars VariableStoreInterface) bool {
+	banned, ok := vars.GetBool(VarAccountBanned)
+	return ok && banned
+}
+
+// ClearAccountBanned removes the ban flag once the banned account has been released
+func ClearAccountBanned(vars VariableStoreInterface) {
+	vars.Delete(VarAccountBanned)
+}
+
+// newBanDetectionBuilder builds the routine run by a ban_detection sentry:
+// if the ban template is on screen, flag the account and fail so the sentry force-stops the routine
+func newBanDetectionBuilder(s *Sentry) *ActionBuilder {
+	condition := &ImageExists{Template: s.Template, Threshold: s.Threshold}
+
+	ab := NewActionBuilder()
+	ab.steps = append(ab.steps, Step{
+		name: fmt.Sprintf("BanDetection (%s)", s.Template),
+		execute: func(bot BotInterface) error {
+			found, err := condition.Evaluate(bot)
+			if err != nil {
+				// Screen check errors are not bans; keep the routine running
+				fmt.Printf("Bot %d: Ban detection check failed: %v\n", bot.Instance(), err)
+				return nil
+			}
+			if !found {
+				return nil
+			}
+
+			MarkAccountBanned(bot)
+			fmt.Printf("Bot %d: Ban screen detected (template '%s') - stopping routine\n", bot.Instance(), s.Template)
+			return ErrAccountBanned
+		},
+	})
+	return ab
+}
@@ -2,13 +2,19 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"jordanella.com/pocket-tcg-go/internal/cv"
+	"jordanella.com/pocket-tcg-go/internal/logging"
 	"jordanella.com/pocket-tcg-go/internal/monitor"
 )
 
+// errRoutineStopped is returned when the routine controller stops execution
+var errRoutineStopped = errors.New("routine stopped by controller")
+
 type ActionStep interface {
 	Validate(ab *ActionBuilder) error
 	Build(ab *ActionBuilder) *ActionBuilder
@@ -25,6 +31,7 @@ type ActionBuilder struct {
 	errorHandler       monitor.ErrorHandlerFunc  // Custom error handler for this action
 	templateRegistry   TemplateRegistryInterface // Optional: for validating template names at build time
 	isSentryExecution  bool                      // If true, ignores pause/stop signals from routine controller
+	loopThreshold      int                       // Routine's loop_threshold (see RoutineExecutor.WithLoopThreshold)
 }
 
 // NewActionBuilder creates a new ActionBuilder for building reusable routines
@@ -54,7 +61,7 @@ func (ab *ActionBuilder) AsSentryExecution() *ActionBuilder {
 func InitializeConfigVariables(bot BotInterface, config []ConfigParam, overrides map[string]string) error {
 	for _, param := range config {
 		// Skip persistent variables that already have a value
-		if param.Persist {
+		if param.Persist || param.PersistAccount {
 			if _, exists := bot.Variables().Get(param.Name); exists {
 				// Variable already exists and is persistent, don't reinitialize
 				continue
@@ -81,6 +88,11 @@ func InitializeConfigVariables(bot BotInterface, config []ConfigParam, overrides
 				vs.MarkPersistent(param.Name)
 			}
 		}
+		if param.PersistAccount {
+			if vs, ok := bot.Variables().(*VariableStore); ok {
+				vs.MarkAccountState(param.Name, value)
+			}
+		}
 	}
 	return nil
 }
@@ -92,6 +104,12 @@ type Step struct {
 	canInterrupt bool
 	issue        error
 	timeout      time.Duration // Timeout for this specific step (0 = no timeout)
+	retry        StepRetry     // In-place retry policy for this step (zero = no retries)
+	allowLoop    bool          // Opted out of loop detection, for steps that poll (steps run inside it aren't counted either)
+	action       ActionStep    // Action the step was built from, for tracing (nil for steps built in code)
+	checkpoint   string        // Checkpoint label, for the first step of a top-level `checkpoint:` action
+	reportsError bool          // Fails on purpose (ReportError): once recovered, the routine moves on instead of running it again
+	sentry       bool          // Built while a sentry routine ran, so loop detection doesn't count it against the main routine
 }
 
 // Builder configuration methods
@@ -178,7 +196,11 @@ var defaultErrorHandler = func(event *monitor.ErrorEvent) monitor.ErrorResponse
 // Execute runs the action sequence on the provided bot
 // This allows the same ActionBuilder to be executed on multiple bots
 func (ab *ActionBuilder) Execute(bot BotInterface) error {
-	ctx := bot.Context()
+	return ab.executeContext(bot.Context(), bot)
+}
+
+// executeContext is Execute with the context the run derives from
+func (ab *ActionBuilder) executeContext(ctx context.Context, bot BotInterface) error {
 	if ab.timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, ab.timeout)
@@ -203,7 +225,17 @@ func (ab *ActionBuilder) ExecuteOnce(bot BotInterface) error {
 // Internal
 
 func (ab *ActionBuilder) executeSteps(ctx context.Context, bot BotInterface) error {
-	for _, step := range ab.steps {
+	recoveredStep := -1 // Step that ran again after recovering from its error; it isn't recovered twice
+
+	// A routine run with checkpoints enabled may resume part way through its steps
+	checkpoints := checkpointRunFrom(ctx)
+	start := 0
+	if checkpoints != nil {
+		start = checkpoints.startStep(ab, bot)
+	}
+
+	for i := start; i < len(ab.steps); i++ {
+		step := ab.steps[i]
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
@@ -213,16 +245,61 @@ func (ab *ActionBuilder) executeSteps(ctx context.Context, bot BotInterface) err
 
 		// Check for pause/stop signals from routine controller
 		if !ab.checkExecutionState(bot) {
-			return fmt.Errorf("routine stopped by controller")
+			return errRoutineStopped
 		}
 
 		if step.issue != nil {
 			return fmt.Errorf("build configuration error for step '%s': %w", step.name, step.issue)
 		}
 
-		// Execute step with timeout
-		if err := ab.executeStepWithTimeout(ctx, bot, &step); err != nil {
+		if checkpoints != nil && step.checkpoint != "" {
+			checkpoints.reach(bot, i, step.checkpoint)
+		}
+
+		// Fail routines that keep cycling through the same steps
+		done, err := ab.visitStep(bot, &step)
+		if err != nil {
+			return err
+		}
+
+		// Execute step with timeout, retrying in place if it has a retry policy
+		err = ab.executeStepWithRetry(ctx, bot, &step)
+		done()
+		if err != nil {
 			if !ab.ignoreErrors {
+				// Known error types run their registered recovery, then the step runs again
+				if i == recoveredStep {
+					err = &recoveryTriedError{err: err}
+				} else if recovered, recoverErr := recoverStepError(bot, &step, err); recovered {
+					if step.reportsError {
+						continue
+					}
+					recoveredStep = i
+					i--
+					continue
+				} else {
+					err = recoverErr
+				}
+
+				// Sentry failures are captured by the sentry engine under the sentry's name
+				if !ab.isSentryExecution {
+					captureFailure(bot, step.name, err)
+
+					// In pause-on-error mode the developer decides what happens next
+					decision, paused := pauseOnStepError(ctx, bot, step.name, err)
+					switch {
+					case decision == StepErrorResume:
+						i--
+						continue
+					case decision == StepErrorSkip:
+						continue
+					case paused:
+						if !ab.checkExecutionState(bot) {
+							return errRoutineStopped
+						}
+						return &abortedStepError{err: err}
+					}
+				}
 				return err
 			}
 		}
@@ -230,11 +307,69 @@ func (ab *ActionBuilder) executeSteps(ctx context.Context, bot BotInterface) err
 	return nil
 }
 
+// executeStepWithRetry runs a step, retrying it per its retry policy. Sentries keep running
+// between attempts, and a pause from a sentry is waited out before the next attempt.
+// The last attempt's error is returned once retries are exhausted.
+func (ab *ActionBuilder) executeStepWithRetry(ctx context.Context, bot BotInterface, step *Step) error {
+	maxAttempts := step.retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		traced := traceStep(bot, step)
+		started := time.Now()
+		err := ab.executeStepWithTimeout(ctx, bot, step)
+		traced(err)
+		logStep(bot, step, time.Since(started), err)
+		ab.recordStepRun(bot, err)
+
+		if err == nil || maxAttempts == 1 || !isRetryable(err) {
+			return err
+		}
+		if attempt >= maxAttempts {
+			logRetry(bot, fmt.Sprintf("Step '%s' failed after %d attempts", step.name, attempt))
+			return fmt.Errorf("step '%s' failed after %d attempts: %w", step.name, attempt, err)
+		}
+
+		logRetry(bot, fmt.Sprintf("Step '%s' failed (attempt %d/%d), retrying in %v: %v",
+			step.name, attempt, maxAttempts, step.retry.Delay, err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(step.retry.Delay):
+		}
+		if !ab.checkExecutionState(bot) {
+			return errRoutineStopped
+		}
+	}
+}
+
+// isRetryable reports whether a failed step may be retried; stops and cancellations may not
+func isRetryable(err error) bool {
+	return !errors.Is(err, errRoutineStopped) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+// logRetry reports a step retry on the console and in the bot's log file, if it has one
+func logRetry(bot BotInterface, message string) {
+	type logWriterProvider interface {
+		LogWriter() io.Writer
+	}
+
+	fmt.Printf("Bot %d: %s\n", bot.Instance(), message)
+	if provider, ok := bot.(logWriterProvider); ok && provider.LogWriter() != nil {
+		logging.WriteLine(provider.LogWriter(), message)
+	}
+}
+
 // executeStepWithTimeout executes a single step with optional timeout
 func (ab *ActionBuilder) executeStepWithTimeout(ctx context.Context, bot BotInterface, step *Step) error {
 	// If no timeout specified, execute directly
 	if step.timeout == 0 {
-		return step.execute(bot)
+		return stepError(step, step.execute(bot))
 	}
 
 	// Create step context with timeout
@@ -252,10 +387,37 @@ func (ab *ActionBuilder) executeStepWithTimeout(ctx context.Context, bot BotInte
 	case <-stepCtx.Done():
 		return fmt.Errorf("step '%s' timed out after %v", step.name, step.timeout)
 	case err := <-done:
-		return err
+		return stepError(step, err)
 	}
 }
 
+// stepError names the step in expression errors so routine authors can find the bad argument
+func stepError(step *Step, err error) error {
+	var exprErr *ExpressionError
+	if err != nil && errors.As(err, &exprErr) {
+		return fmt.Errorf("step '%s': %w", step.name, err)
+	}
+	return err
+}
+
+// logStep records a step's outcome in the bot's log file, if it has one
+func logStep(bot BotInterface, step *Step, elapsed time.Duration, err error) {
+	type logWriterProvider interface {
+		LogWriter() io.Writer
+	}
+
+	provider, ok := bot.(logWriterProvider)
+	if !ok || provider.LogWriter() == nil {
+		return
+	}
+
+	if err != nil {
+		logging.WriteLine(provider.LogWriter(), fmt.Sprintf("Step '%s' failed after %v: %v", step.name, elapsed.Round(time.Millisecond), err))
+		return
+	}
+	logging.WriteLine(provider.LogWriter(), fmt.Sprintf("Step '%s' done in %v", step.name, elapsed.Round(time.Millisecond)))
+}
+
 // checkExecutionState checks if routine should pause or stop
 // Returns true if execution should continue, false if stopped
 func (ab *ActionBuilder) checkExecutionState(bot BotInterface) bool {
@@ -350,14 +512,34 @@ func (ab *ActionBuilder) buildSteps(actions []ActionStep) []Step {
 	// Propagate template registry for nested validation
 	tempBuilder.templateRegistry = ab.templateRegistry
 
+	// Steps nested in a sentry routine run as part of the sentry
+	tempBuilder.isSentryExecution = ab.isSentryExecution
+
 	for _, action := range actions {
-		action.Build(tempBuilder)
+		tempBuilder.buildAction(action)
+	}
+	if ab.isSentryExecution {
+		for i := range tempBuilder.steps {
+			tempBuilder.steps[i].sentry = true
+		}
 	}
 
 	// The steps are now in the temporary builder
 	return tempBuilder.steps
 }
 
+// buildAction builds an action into ab and records it on the steps it added (see ActionTrace)
+func (ab *ActionBuilder) buildAction(action ActionStep) *ActionBuilder {
+	start := len(ab.steps)
+	ab = action.Build(ab)
+	for i := start; i < len(ab.steps); i++ {
+		if ab.steps[i].action == nil {
+			ab.steps[i].action = action
+		}
+	}
+	return ab
+}
+
 func buildTemplateConfiguration(bot BotInterface, templateName string, actionThreshold *float64, actionRegion *cv.Region) (template cv.Template, config *cv.MatchConfig, err error) {
 	template, ok := bot.Templates().Get(templateName)
 	if !ok {
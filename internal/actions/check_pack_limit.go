@@ -0,0 +1,94 @@
+package actions
+
+import (
+	"database/sql"
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+)
+
+// CheckPackLimit moves the bot on to the next account once the current one has opened the
+// pool's max_packs_per_account packs: the account is completed and the next one injected.
+// Does nothing while the account is below the ceiling, or when there is no ceiling.
+type CheckPackLimit struct {
+	MaxPacks     int    `yaml:"max_packs"`      // Ceiling (default: the pool's max_packs_per_account)
+	OnNoAccounts string `yaml:"on_no_accounts"` // Passed to InjectNextAccount (default: "stop")
+}
+
+func (a *CheckPackLimit) Validate(ab *ActionBuilder) error {
+	if a.MaxPacks < 0 {
+		return fmt.Errorf("max_packs cannot be negative, got %d", a.MaxPacks)
+	}
+	return (&InjectNextAccount{OnNoAccounts: a.OnNoAccounts}).Validate(ab)
+}
+
+func (a *CheckPackLimit) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: "CheckPackLimit",
+		execute: func(botIf BotInterface) error {
+			managerIf := botIf.Manager()
+			if managerIf == nil {
+				return fmt.Errorf("bot has no manager - cannot access account pool")
+			}
+
+			pool, ok := managerIf.(interface {
+				AccountPool() accountpool.AccountPool
+			})
+			if !ok {
+				return fmt.Errorf("bot manager does not provide AccountPool method")
+			}
+
+			accountPool := pool.AccountPool()
+			if accountPool == nil {
+				return fmt.Errorf("no account pool configured in manager")
+			}
+
+			maxPacks := a.MaxPacks
+			if maxPacks == 0 {
+				maxPacks = accountpool.MaxPacksPerAccount(accountPool)
+			}
+			if maxPacks == 0 {
+				return nil
+			}
+
+			account, ok := botIf.GetCurrentAccount().(*accountpool.Account)
+			if !ok || account == nil {
+				return fmt.Errorf("no current account assigned to bot")
+			}
+
+			// The database has the latest count; sandbox accounts only have the in-memory one
+			packCount := account.PackCount
+			if dbProvider, ok := managerIf.(interface{ Database() *sql.DB }); ok && !accountpool.IsSandbox(accountPool) {
+				if db := dbProvider.Database(); db != nil && account.DeviceAccount != "" {
+					err := db.QueryRow(`SELECT packs_opened FROM accounts WHERE device_account = ?`, account.DeviceAccount).Scan(&packCount)
+					if err != nil {
+						fmt.Printf("Bot %d: Warning - could not read pack count of account '%s': %v\n",
+							botIf.Instance(), account.DeviceAccount, err)
+						packCount = account.PackCount
+					}
+				}
+			}
+			account.PackCount = packCount
+
+			if packCount < maxPacks {
+				fmt.Printf("Bot %d: Account '%s' has opened %d/%d packs, continuing\n",
+					botIf.Instance(), account.ID, packCount, maxPacks)
+				return nil
+			}
+
+			fmt.Printf("Bot %d: Account '%s' reached the pack ceiling (%d/%d), completing it and moving to the next account\n",
+				botIf.Instance(), account.ID, packCount, maxPacks)
+
+			next := NewActionBuilder()
+			(&CompleteAccount{Success: true, PacksOpened: packCount}).Build(next)
+			(&InjectNextAccount{OnNoAccounts: a.OnNoAccounts}).Build(next)
+			if err := next.executeSteps(botIf.Context(), botIf); err != nil {
+				return fmt.Errorf("CheckPackLimit: %w", err)
+			}
+			return nil
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the last checkpoint a routine run reached: a top-level step marked
+// `checkpoint: <label>` and the variables as they were just before it ran.
+//
+// Resuming re-runs the routine from the checkpoint step with those variables restored, so
+// everything from a checkpoint to the next one must be safe to run twice (idempotent).
+// Routines opt in per step: only mark steps that start such a section.
+type Checkpoint struct {
+	Routine   string
+	Label     string
+	Step      int // Index of the checkpoint step in the routine's built steps
+	Variables map[string]string
+	ReachedAt time.Time
+}
+
+// checkpointState is a RoutineExecutor's last reached checkpoint, kept across the runs of
+// one bot so a restarted run can resume from it
+type checkpointState struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+func (s *checkpointState) get() *Checkpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoint
+}
+
+func (s *checkpointState) set(checkpoint *Checkpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = checkpoint
+}
+
+// checkpointRun records and resumes checkpoints during one run of a routine's top-level
+// steps. It travels in the run's context; nested actions run with the bot's own context,
+// so only top-level steps can be checkpoints.
+type checkpointRun struct {
+	routine string
+	state   *checkpointState
+	resume  *Checkpoint // Checkpoint to resume from (nil = start at the first step)
+}
+
+type checkpointRunKey struct{}
+
+func withCheckpointRun(ctx context.Context, run *checkpointRun) context.Context {
+	return context.WithValue(ctx, checkpointRunKey{}, run)
+}
+
+func checkpointRunFrom(ctx context.Context) *checkpointRun {
+	run, _ := ctx.Value(checkpointRunKey{}).(*checkpointRun)
+	return run
+}
+
+// startStep returns the step to start the run at: the resumed checkpoint's step, or 0 when
+// not resuming or the checkpoint doesn't match the routine (e.g. it was edited and reloaded)
+func (r *checkpointRun) startStep(ab *ActionBuilder, bot BotInterface) int {
+	if r.resume == nil {
+		return 0
+	}
+	cp := r.resume
+	r.resume = nil
+
+	if cp.Step < 0 || cp.Step >= len(ab.steps) || ab.steps[cp.Step].checkpoint != cp.Label {
+		fmt.Printf("Bot %d: Checkpoint '%s' no longer matches routine '%s', starting from the beginning\n",
+			bot.Instance(), cp.Label, r.routine)
+		r.state.set(nil)
+		return 0
+	}
+
+	for name, value := range cp.Variables {
+		if name == VarDeviceAccountID || name == VarExecutionID {
+			continue
+		}
+		bot.Variables().Set(name, value)
+	}
+	fmt.Printf("Bot %d: Resuming routine '%s' from checkpoint '%s' (reached %s)\n",
+		bot.Instance(), r.routine, cp.Label, cp.ReachedAt.Format("15:04:05"))
+	return cp.Step
+}
+
+// reach records that the run reached the checkpoint step i
+func (r *checkpointRun) reach(bot BotInterface, i int, label string) {
+	r.state.set(&Checkpoint{
+		Routine:   r.routine,
+		Label:     label,
+		Step:      i,
+		Variables: bot.Variables().GetAll(),
+		ReachedAt: time.Now(),
+	})
+}
+
+// resumable returns the checkpoint a run of the bot should resume from, if any. A checkpoint
+// taken with another account injected is dropped: its variables belong to that account.
+func (s *checkpointState) resumable(bot BotInterface) *Checkpoint {
+	cp := s.get()
+	if cp == nil {
+		return nil
+	}
+
+	current, _ := bot.Variables().Get(VarDeviceAccountID)
+	if cp.Variables[VarDeviceAccountID] != current {
+		fmt.Printf("Bot %d: Dropping checkpoint '%s': it was taken with another account\n", bot.Instance(), cp.Label)
+		s.set(nil)
+		return nil
+	}
+	return cp
+}
@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// checkpointBot is retryBot with variables and a context, as routine runs need
+type checkpointBot struct {
+	retryBot
+	vars *VariableStore
+}
+
+func (b checkpointBot) Variables() VariableStoreInterface { return b.vars }
+func (checkpointBot) Context() context.Context            { return context.Background() }
+
+func TestRoutineResumesFromCheckpoint(t *testing.T) {
+	setupRuns, checkpointRuns, finalRuns := 0, 0, 0
+	ab := NewActionBuilder()
+	ab.steps = append(ab.steps,
+		Step{name: "Setup", execute: func(bot BotInterface) error {
+			setupRuns++
+			bot.Variables().Set("progress", "set up")
+			return nil
+		}},
+		Step{name: "Farm", checkpoint: "farm", execute: func(BotInterface) error {
+			checkpointRuns++
+			return nil
+		}},
+		Step{name: "Flaky", execute: func(BotInterface) error {
+			finalRuns++
+			if finalRuns == 1 {
+				return errors.New("crashed")
+			}
+			return nil
+		}},
+	)
+
+	bot := checkpointBot{vars: NewVariableStore()}
+	executor := NewRoutineExecutor(ab, nil).WithName("farm_routine").WithCheckpoints()
+
+	if err := executor.Execute(bot); err == nil {
+		t.Fatal("expected the first run to fail")
+	}
+	cp := executor.LastCheckpoint()
+	if cp == nil || cp.Label != "farm" || cp.Step != 1 || cp.Variables["progress"] != "set up" {
+		t.Fatalf("unexpected checkpoint after failed run: %+v", cp)
+	}
+
+	// A restarted run starts at the checkpoint with the variables it had there
+	bot.vars.Clear()
+	if err := executor.Execute(bot); err != nil {
+		t.Fatalf("expected the resumed run to succeed, got %v", err)
+	}
+	if setupRuns != 1 || checkpointRuns != 2 || finalRuns != 2 {
+		t.Errorf("runs = setup %d, checkpoint %d, final %d; want 1, 2, 2", setupRuns, checkpointRuns, finalRuns)
+	}
+	if progress, _ := bot.vars.Get("progress"); progress != "set up" {
+		t.Errorf("progress = %q, want the checkpoint's value restored", progress)
+	}
+	if executor.LastCheckpoint() != nil {
+		t.Error("expected a completed run to clear the checkpoint")
+	}
+
+	// Without checkpoints enabled a failed run restarts from the first step
+	plain := NewRoutineExecutor(ab, nil)
+	finalRuns = 0
+	plain.Execute(bot)
+	plain.Execute(bot)
+	if setupRuns != 3 {
+		t.Errorf("setup runs = %d, want 3 without checkpoints", setupRuns)
+	}
+}
+
+func TestCheckpointParsedFromYAML(t *testing.T) {
+	data := []byte(`
+routine_name: checkpoints
+steps:
+  - action: delay
+    count: 1
+  - action: delay
+    count: 1
+    checkpoint: farm
+`)
+	var routine Routine
+	if err := yaml.Unmarshal(data, &routine); err != nil {
+		t.Fatal(err)
+	}
+
+	ab := NewActionBuilder()
+	for _, step := range routine.Steps {
+		ab.buildAction(step)
+	}
+	if ab.steps[0].checkpoint != "" || ab.steps[1].checkpoint != "farm" {
+		t.Errorf("checkpoints = %q, %q; want only the second step marked", ab.steps[0].checkpoint, ab.steps[1].checkpoint)
+	}
+
+	duplicate := []byte(`
+routine_name: checkpoints
+steps:
+  - action: delay
+    count: 1
+    checkpoint: farm
+  - action: delay
+    count: 1
+    checkpoint: farm
+`)
+	if err := yaml.Unmarshal(duplicate, &routine); err == nil {
+		t.Error("expected an error for a duplicate checkpoint label")
+	}
+}
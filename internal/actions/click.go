@@ -1,16 +1,46 @@
 package actions
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+const (
+	defaultClickVerifyRetries = 2
+	defaultClickVerifyDelay   = 300 // Milliseconds
+)
 
 type Click struct {
-	X int `yaml:"x"`
-	Y int `yaml:"y"`
+	X      int          `yaml:"x"`
+	Y      int          `yaml:"y"`
+	Verify *ClickVerify `yaml:"click_verify,omitempty"` // Optional: confirm the game registered the click
+}
+
+// ClickVerify confirms a click registered by checking that a pixel (or a blob in a region)
+// has the expected color afterwards, e.g. a button's pressed state. When it doesn't, the
+// click is retried; clicks the game ignored ("ghost clicks") would otherwise go unnoticed.
+type ClickVerify struct {
+	X         *int       `yaml:"x,omitempty"`          // Pixel to sample (default: the click point)
+	Y         *int       `yaml:"y,omitempty"`          // Pixel to sample (default: the click point)
+	Region    *cv.Region `yaml:"region,omitempty"`     // Optional: search the region for a blob instead of sampling a pixel
+	Color     string     `yaml:"color"`                // Expected hex color "#RRGGBB"
+	Tolerance *int       `yaml:"tolerance,omitempty"`  // Mean per-channel difference allowed (0-255, default 20)
+	MinPixels int        `yaml:"min_pixels,omitempty"` // Region only: ignore blobs smaller than this
+	Retries   *int       `yaml:"retries,omitempty"`    // Clicks retried when verification fails (default: 2)
+	Delay     int        `yaml:"delay,omitempty"`      // Milliseconds to wait before sampling (default: 300)
 }
 
 func (a *Click) Validate(ab *ActionBuilder) error {
 	if a.X < 0 || a.Y < 0 {
 		return fmt.Errorf("coordinates (x=%d, y=%d) must be non-negative", a.X, a.Y)
 	}
+	if a.Verify != nil {
+		if err := a.Verify.validate(); err != nil {
+			return fmt.Errorf("click_verify: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -18,10 +48,98 @@ func (a *Click) Build(ab *ActionBuilder) *ActionBuilder {
 	step := Step{
 		name: "Click",
 		execute: func(bot BotInterface) error {
-			return bot.ADB().Click(a.X, a.Y)
+			if a.Verify == nil {
+				return bot.ADB().Click(a.X, a.Y)
+			}
+			return a.clickVerified(bot)
 		},
 		issue: a.Validate(ab),
 	}
 	ab.steps = append(ab.steps, step)
 	return ab
 }
+
+// clickVerified clicks until the verification passes, up to 1 + retries clicks
+func (a *Click) clickVerified(bot BotInterface) error {
+	v := a.Verify
+	retries := defaultClickVerifyRetries
+	if v.Retries != nil {
+		retries = *v.Retries
+	}
+	delay := v.Delay
+	if delay == 0 {
+		delay = defaultClickVerifyDelay
+	}
+
+	for attempt := 1; attempt <= retries+1; attempt++ {
+		if err := bot.ADB().Click(a.X, a.Y); err != nil {
+			return err
+		}
+
+		select {
+		case <-bot.Context().Done():
+			return bot.Context().Err()
+		case <-time.After(time.Duration(delay) * time.Millisecond):
+		}
+
+		ok, err := v.check(bot, a.X, a.Y)
+		if err != nil {
+			return fmt.Errorf("click_verify: %w", err)
+		}
+		if ok {
+			return nil
+		}
+
+		fmt.Printf("Bot %d: Click verification failed at (%d, %d), attempt %d/%d: expected %s\n",
+			bot.Instance(), a.X, a.Y, attempt, retries+1, v.Color)
+	}
+
+	return fmt.Errorf("click at (%d, %d) not registered after %d attempts: expected %s", a.X, a.Y, retries+1, v.Color)
+}
+
+func (v *ClickVerify) validate() error {
+	if err := validateColorSearch(v.Color, v.Tolerance, v.MinPixels); err != nil {
+		return err
+	}
+	if (v.X == nil) != (v.Y == nil) {
+		return fmt.Errorf("x and y must be set together")
+	}
+	if v.X != nil && (*v.X < 0 || *v.Y < 0) {
+		return fmt.Errorf("coordinates (x=%d, y=%d) must be non-negative", *v.X, *v.Y)
+	}
+	if v.Retries != nil && *v.Retries < 0 {
+		return fmt.Errorf("retries cannot be negative")
+	}
+	if v.Delay < 0 {
+		return fmt.Errorf("delay cannot be negative")
+	}
+	return nil
+}
+
+// check samples a fresh frame and reports whether it shows the expected color
+func (v *ClickVerify) check(bot BotInterface, clickX, clickY int) (bool, error) {
+	bot.CV().InvalidateCache()
+
+	if v.Region != nil {
+		blob, err := findColorBlob(bot, v.Color, v.Tolerance, v.MinPixels, v.Region)
+		if err != nil {
+			return false, err
+		}
+		return blob != nil, nil
+	}
+
+	expected, err := cv.ParseHexColor(v.Color)
+	if err != nil {
+		return false, err
+	}
+	tolerance := defaultColorTolerance
+	if v.Tolerance != nil {
+		tolerance = *v.Tolerance
+	}
+
+	x, y := clickX, clickY
+	if v.X != nil {
+		x, y = *v.X, *v.Y
+	}
+	return bot.CV().CheckColor(x, y, expected, uint8(tolerance))
+}
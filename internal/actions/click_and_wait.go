@@ -0,0 +1,169 @@
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+const (
+	defaultClickAndWaitMaxWait  = 10  // Seconds
+	defaultClickAndWaitInterval = 500 // Milliseconds
+)
+
+// ClickAndWait clicks a point (or a template) and then waits for the screen the click leads
+// to, replacing a click followed by a wait loop. If retry_after is set and the screen hasn't
+// appeared by then, the click is repeated, for taps the game dropped during a transition.
+type ClickAndWait struct {
+	X            *int       `yaml:"x,omitempty"`             // Point to click
+	Y            *int       `yaml:"y,omitempty"`             // Point to click
+	Target       string     `yaml:"target,omitempty"`        // Template to click instead of a point
+	Screen       string     `yaml:"screen"`                  // Template that shows the click worked (required)
+	Threshold    *float64   `yaml:"threshold,omitempty"`     // Optional: override the screen template's threshold
+	Region       *cv.Region `yaml:"region,omitempty"`        // Optional: override the screen template's region
+	MaxWait      int        `yaml:"max_wait,omitempty"`      // Seconds to wait for the screen (default: 10)
+	PollInterval int        `yaml:"poll_interval,omitempty"` // Milliseconds between screen checks (default: 500)
+	RetryAfter   int        `yaml:"retry_after,omitempty"`   // Seconds without the screen before clicking again (default: click once)
+}
+
+func (a *ClickAndWait) Validate(ab *ActionBuilder) error {
+	hasPoint := a.X != nil || a.Y != nil
+	if (a.X == nil) != (a.Y == nil) {
+		return fmt.Errorf("x and y must be set together")
+	}
+	if hasPoint == (a.Target != "") {
+		return fmt.Errorf("specify either x/y or target")
+	}
+	if hasPoint && (*a.X < 0 || *a.Y < 0) {
+		return fmt.Errorf("coordinates (x=%d, y=%d) must be non-negative", *a.X, *a.Y)
+	}
+	if a.Screen == "" {
+		return fmt.Errorf("screen is required")
+	}
+	if a.MaxWait < 0 || a.PollInterval < 0 || a.RetryAfter < 0 {
+		return fmt.Errorf("max_wait, poll_interval and retry_after must be non-negative")
+	}
+
+	// Validate templates exist in registry (if registry is available)
+	if ab.templateRegistry != nil {
+		for _, name := range []string{a.Target, a.Screen} {
+			if name != "" && !ab.templateRegistry.Has(name) {
+				return fmt.Errorf("template '%s' not found in registry", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *ClickAndWait) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("ClickAndWait (%s -> %s)", a.target(), a.Screen),
+		execute: func(bot BotInterface) error {
+			maxWait := a.MaxWait
+			if maxWait == 0 {
+				maxWait = defaultClickAndWaitMaxWait
+			}
+			interval := a.PollInterval
+			if interval == 0 {
+				interval = defaultClickAndWaitInterval
+			}
+
+			deadline := time.Now().Add(time.Duration(maxWait) * time.Second)
+			clicks := 0
+			var lastClick time.Time
+
+			for {
+				if bot.IsStopped() {
+					return fmt.Errorf("bot stopped")
+				}
+
+				// Click once, then again each time retry_after passes without the screen
+				if clicks == 0 || (a.RetryAfter > 0 && time.Since(lastClick) >= time.Duration(a.RetryAfter)*time.Second) {
+					clicked, err := a.click(bot)
+					if err != nil {
+						return err
+					}
+					if clicked {
+						if clicks > 0 {
+							fmt.Printf("Bot %d: '%s' not shown after %ds, clicked %s again\n", bot.Instance(), a.Screen, a.RetryAfter, a.target())
+						}
+						clicks++
+						lastClick = time.Now()
+					}
+				}
+
+				select {
+				case <-bot.Context().Done():
+					return bot.Context().Err()
+				case <-time.After(time.Duration(interval) * time.Millisecond):
+				}
+
+				if clicks > 0 {
+					shown, err := a.screenShown(bot)
+					if err != nil {
+						return err
+					}
+					if shown {
+						return nil
+					}
+				}
+
+				if time.Now().After(deadline) {
+					if clicks == 0 {
+						return fmt.Errorf("click target '%s' not found within %ds", a.Target, maxWait)
+					}
+					return fmt.Errorf("screen '%s' not shown within %ds after clicking %s (%d click(s))",
+						a.Screen, maxWait, a.target(), clicks)
+				}
+			}
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// click taps the point or the target template. Returns false if the target isn't on screen.
+func (a *ClickAndWait) click(bot BotInterface) (bool, error) {
+	if a.Target == "" {
+		return true, bot.ADB().Click(*a.X, *a.Y)
+	}
+
+	bot.CV().InvalidateCache()
+	match, err := findStartupTemplate(bot, a.Target)
+	if err != nil || match == nil {
+		return false, err
+	}
+	if err := match.click(bot); err != nil {
+		return false, fmt.Errorf("failed to click '%s': %w", a.Target, err)
+	}
+	return true, nil
+}
+
+// screenShown checks a fresh screenshot for the screen template
+func (a *ClickAndWait) screenShown(bot BotInterface) (bool, error) {
+	bot.CV().InvalidateCache()
+
+	template, config, err := buildTemplateConfiguration(bot, a.Screen, a.Threshold, a.Region)
+	if err != nil {
+		return false, fmt.Errorf("failed to build template configuration: %w", err)
+	}
+	result, err := bot.CV().FindTemplate(template.Name, config)
+	if err != nil {
+		return false, fmt.Errorf("error checking template %s: %w", template.Name, err)
+	}
+	return result.Found, nil
+}
+
+// target describes what is clicked, for step names and log lines
+func (a *ClickAndWait) target() string {
+	if a.Target != "" {
+		return a.Target
+	}
+	if a.X == nil || a.Y == nil {
+		return "?"
+	}
+	return fmt.Sprintf("(%d, %d)", *a.X, *a.Y)
+}
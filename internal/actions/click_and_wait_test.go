@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestClickAndWaitUnmarshaling(t *testing.T) {
+	yamlStr := `
+routine_name: "Test Click And Wait"
+steps:
+  - action: click_and_wait
+    x: 270
+    y: 820
+    screen: ShopScreen
+    max_wait: 10
+    poll_interval: 250
+    retry_after: 3
+  - action: click_and_wait
+    target: OkButton
+    screen: HomeScreen
+    max_wait: 5
+`
+
+	var routine Routine
+	if err := yaml.Unmarshal([]byte(yamlStr), &routine); err != nil {
+		t.Fatalf("Failed to unmarshal routine: %v", err)
+	}
+	if len(routine.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(routine.Steps))
+	}
+
+	point, ok := routine.Steps[0].(*ClickAndWait)
+	if !ok {
+		t.Fatalf("Expected first step to be *ClickAndWait, got %T", routine.Steps[0])
+	}
+	if point.X == nil || *point.X != 270 || point.Y == nil || *point.Y != 820 || point.Screen != "ShopScreen" ||
+		point.MaxWait != 10 || point.PollInterval != 250 || point.RetryAfter != 3 {
+		t.Errorf("Unexpected click_and_wait: %+v", point)
+	}
+
+	target := routine.Steps[1].(*ClickAndWait)
+	if target.Target != "OkButton" || target.X != nil || target.MaxWait != 5 || target.RetryAfter != 0 {
+		t.Errorf("Unexpected click_and_wait: %+v", target)
+	}
+
+	ab := &ActionBuilder{}
+	for i, step := range []*ClickAndWait{point, target} {
+		if err := step.Validate(ab); err != nil {
+			t.Errorf("Expected step %d to be valid, got %v", i+1, err)
+		}
+	}
+}
+
+func TestClickAndWaitValidation(t *testing.T) {
+	x, y, negative := 10, 20, -1
+	tests := []struct {
+		name   string
+		action ClickAndWait
+	}{
+		{"no click target", ClickAndWait{Screen: "Home"}},
+		{"point and target", ClickAndWait{X: &x, Y: &y, Target: "Ok", Screen: "Home"}},
+		{"x without y", ClickAndWait{X: &x, Screen: "Home"}},
+		{"negative coordinates", ClickAndWait{X: &negative, Y: &y, Screen: "Home"}},
+		{"missing screen", ClickAndWait{X: &x, Y: &y}},
+		{"negative max_wait", ClickAndWait{X: &x, Y: &y, Screen: "Home", MaxWait: -1}},
+		{"negative retry_after", ClickAndWait{X: &x, Y: &y, Screen: "Home", RetryAfter: -1}},
+	}
+
+	ab := &ActionBuilder{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.action.Validate(ab); err == nil {
+				t.Errorf("Expected validation error for %s", tt.name)
+			}
+		})
+	}
+}
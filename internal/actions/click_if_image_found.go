@@ -63,7 +63,13 @@ func (a *ClickIfImageFound) Build(ab *ActionBuilder) *ActionBuilder {
 				clickY += a.Offset.Y
 			}
 
-			return bot.ADB().Click(clickX, clickY)
+			if a.Point != nil {
+				return bot.ADB().Click(clickX, clickY)
+			}
+
+			// Keep humanized clicks inside the matched template
+			targetRadius := min(template.Region.X2-template.Region.X1, template.Region.Y2-template.Region.Y1) / 2
+			return bot.ADB().ClickTarget(clickX, clickY, targetRadius)
 		},
 		issue: a.Validate(ab),
 	}
@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestClickVerifyUnmarshaling(t *testing.T) {
+	yamlStr := `
+routine_name: "Test Click Verify"
+steps:
+  - action: Click
+    x: 270
+    y: 820
+    click_verify:
+      color: "#3A7BD5"
+      tolerance: 25
+      retries: 1
+      delay: 200
+  - action: Click
+    x: 100
+    y: 200
+`
+
+	var routine Routine
+	if err := yaml.Unmarshal([]byte(yamlStr), &routine); err != nil {
+		t.Fatalf("Failed to unmarshal routine: %v", err)
+	}
+	if len(routine.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(routine.Steps))
+	}
+
+	click, ok := routine.Steps[0].(*Click)
+	if !ok {
+		t.Fatalf("Expected first step to be *Click, got %T", routine.Steps[0])
+	}
+	v := click.Verify
+	if v == nil {
+		t.Fatal("Expected click_verify to be parsed")
+	}
+	if v.Color != "#3A7BD5" || v.Tolerance == nil || *v.Tolerance != 25 ||
+		v.Retries == nil || *v.Retries != 1 || v.Delay != 200 || v.X != nil || v.Region != nil {
+		t.Errorf("Unexpected click_verify: %+v", v)
+	}
+
+	if plain := routine.Steps[1].(*Click); plain.Verify != nil {
+		t.Errorf("Expected no click_verify on a plain click, got %+v", plain.Verify)
+	}
+
+	ab := &ActionBuilder{}
+	if err := click.Validate(ab); err != nil {
+		t.Errorf("Expected valid click, got %v", err)
+	}
+}
+
+func TestClickVerifyValidation(t *testing.T) {
+	x, negative := 10, -1
+	tests := []struct {
+		name   string
+		verify ClickVerify
+	}{
+		{"missing color", ClickVerify{}},
+		{"invalid color", ClickVerify{Color: "blue"}},
+		{"x without y", ClickVerify{Color: "#FFFFFF", X: &x}},
+		{"negative retries", ClickVerify{Color: "#FFFFFF", Retries: &negative}},
+		{"negative delay", ClickVerify{Color: "#FFFFFF", Delay: -5}},
+	}
+
+	ab := &ActionBuilder{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			click := &Click{X: 1, Y: 1, Verify: &tt.verify}
+			if err := click.Validate(ab); err == nil {
+				t.Errorf("Expected validation error for %s", tt.name)
+			}
+		})
+	}
+}
@@ -396,6 +396,68 @@ steps:
 	}
 }
 
+func TestWonderPickUnmarshaling(t *testing.T) {
+	yamlStr := `
+routine_name: "Test Wonder Pick"
+steps:
+  - action: If
+    condition:
+      type: WonderPickAvailable
+      template: "WonderPick"
+      color: "#F2C94C"
+    then:
+      - action: wonder_pick
+        template: "WonderPick"
+        card: "${picked_card}"
+        energy_cost: 1
+        variable: picked
+        actions:
+          - action: Click
+            x: 270
+            y: 600
+`
+
+	var routine Routine
+	if err := yaml.Unmarshal([]byte(yamlStr), &routine); err != nil {
+		t.Fatalf("Failed to unmarshal routine: %v", err)
+	}
+
+	ifAction, ok := routine.Steps[0].(*If)
+	if !ok {
+		t.Fatalf("Expected first step to be *If, got %T", routine.Steps[0])
+	}
+
+	condition, ok := ifAction.Condition.(*WonderPickAvailable)
+	if !ok {
+		t.Fatalf("Expected condition to be *WonderPickAvailable, got %T", ifAction.Condition)
+	}
+	if condition.Template != "WonderPick" || condition.Color != "#F2C94C" {
+		t.Errorf("Unexpected cue: %+v", condition.WonderPickCue)
+	}
+
+	pick, ok := ifAction.ThenActions[0].(*WonderPick)
+	if !ok {
+		t.Fatalf("Expected then action to be *WonderPick, got %T", ifAction.ThenActions[0])
+	}
+	if pick.Template != "WonderPick" || pick.Card != "${picked_card}" || pick.EnergyCost != 1 || pick.Variable != "picked" {
+		t.Errorf("Unexpected wonder pick: %+v", pick)
+	}
+	if len(pick.Actions) != 1 {
+		t.Fatalf("Expected 1 nested action, got %d", len(pick.Actions))
+	}
+
+	ab := &ActionBuilder{}
+	if err := pick.Validate(ab); err != nil {
+		t.Errorf("Expected valid wonder pick, got %v", err)
+	}
+	if err := (&WonderPickAvailable{}).Validate(ab); err == nil {
+		t.Error("Expected error for a cue without template or color")
+	}
+	if err := (&WonderPick{WonderPickCue: WonderPickCue{Color: "#F2C94C"}}).Validate(ab); err == nil {
+		t.Error("Expected error for a wonder pick without actions")
+	}
+}
+
 // Helper to get type string
 func typeString(v interface{}) string {
 	if v == nil {
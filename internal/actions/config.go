@@ -2,21 +2,30 @@ package actions
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // ConfigParam defines a user-configurable parameter for a routine
 type ConfigParam struct {
 	Name        string   `yaml:"name"`                   // Variable name
 	Label       string   `yaml:"label"`                  // Display label for GUI
-	Type        string   `yaml:"type"`                   // Type: text, number, checkbox, dropdown, hidden
+	Type        string   `yaml:"type"`                   // Type: text, number, checkbox, dropdown, duration, filepath, hidden
 	Default     string   `yaml:"default"`                // Default value
 	Description string   `yaml:"description,omitempty"`  // Optional description
 	Options     []string `yaml:"options,omitempty"`      // Options for dropdown type
 	Min         *float64 `yaml:"min,omitempty"`          // Min value for number type
 	Max         *float64 `yaml:"max,omitempty"`          // Max value for number type
+	Extensions  []string `yaml:"extensions,omitempty"`   // Allowed file extensions for filepath type (e.g., [".csv", ".txt"])
 	Required    bool     `yaml:"required,omitempty"`     // Whether parameter is required
 	Persist     bool     `yaml:"persist,omitempty"`      // If true, won't be reset between routine iterations
+
+	// If true, the value is kept per account: loaded from the account's stored state when it
+	// is injected and stored back when it is released (implies persist)
+	PersistAccount bool `yaml:"persist_account,omitempty"`
 }
 
 // Validate validates the config param definition
@@ -35,10 +44,31 @@ func (cp *ConfigParam) Validate() error {
 		"number":   true,
 		"checkbox": true,
 		"dropdown": true,
+		"duration": true,
+		"filepath": true,
 		"hidden":   true,
 	}
 	if !validTypes[cp.Type] {
-		return fmt.Errorf("config param '%s': invalid type '%s' (must be: text, number, checkbox, dropdown, hidden)", cp.Name, cp.Type)
+		return fmt.Errorf("config param '%s': invalid type '%s' (must be: text, number, checkbox, dropdown, duration, filepath, hidden)", cp.Name, cp.Type)
+	}
+
+	// Duration default must parse as a Go duration
+	if cp.Type == "duration" && cp.Default != "" {
+		if _, err := time.ParseDuration(cp.Default); err != nil {
+			return fmt.Errorf("config param '%s': default '%s' is not a valid duration (e.g. 30s, 5m, 1h30m)", cp.Name, cp.Default)
+		}
+	}
+
+	// Extensions only apply to filepath and must look like ".ext"
+	if len(cp.Extensions) > 0 {
+		if cp.Type != "filepath" {
+			return fmt.Errorf("config param '%s': extensions are only supported for filepath type", cp.Name)
+		}
+		for _, ext := range cp.Extensions {
+			if !strings.HasPrefix(ext, ".") || len(ext) < 2 {
+				return fmt.Errorf("config param '%s': invalid extension '%s' (must start with '.')", cp.Name, ext)
+			}
+		}
 	}
 
 	// Dropdown must have options
@@ -96,6 +126,58 @@ func (cp *ConfigParam) Validate() error {
 	return nil
 }
 
+// NormalizeValue validates a user-provided value against the param type and
+// returns it in canonical form (durations as time.Duration.String(), paths cleaned).
+// Empty values are returned unchanged.
+func (cp *ConfigParam) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return value, nil
+	}
+
+	switch cp.Type {
+	case "duration":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return "", fmt.Errorf("%s: '%s' is not a valid duration (e.g. 30s, 5m, 1h30m)", cp.Name, value)
+		}
+		if d < 0 {
+			return "", fmt.Errorf("%s: duration cannot be negative", cp.Name)
+		}
+		return d.String(), nil
+
+	case "filepath":
+		path := filepath.Clean(value)
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", fmt.Errorf("%s: file '%s' does not exist", cp.Name, path)
+		}
+		if info.IsDir() {
+			return "", fmt.Errorf("%s: '%s' is a folder, not a file", cp.Name, path)
+		}
+		if !cp.HasAllowedExtension(path) {
+			return "", fmt.Errorf("%s: '%s' must have one of the extensions %v", cp.Name, path, cp.Extensions)
+		}
+		return path, nil
+	}
+
+	return value, nil
+}
+
+// HasAllowedExtension reports whether path matches the filepath extension filter (no filter allows all)
+func (cp *ConfigParam) HasAllowedExtension(path string) bool {
+	if len(cp.Extensions) == 0 {
+		return true
+	}
+	ext := filepath.Ext(path)
+	for _, allowed := range cp.Extensions {
+		if strings.EqualFold(ext, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetEffectiveValue returns the effective value (default or provided value)
 func (cp *ConfigParam) GetEffectiveValue(providedValue string) string {
 	if providedValue != "" {
@@ -116,7 +198,9 @@ func (cp *ConfigParam) GetTypeDefault() string {
 			return cp.Options[0]
 		}
 		return ""
-	case "text", "hidden":
+	case "duration":
+		return "0s"
+	case "text", "filepath", "hidden":
 		return ""
 	default:
 		return ""
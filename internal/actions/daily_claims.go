@@ -0,0 +1,327 @@
+package actions
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+const (
+	defaultDailyMaxClaims       = 1
+	defaultMissionMaxClaims     = 20
+	defaultDailyClaimedVar      = "daily_claimed"
+	defaultMissionsClaimedVar   = "missions_claimed"
+	defaultMissionType          = "daily"
+	claimSettleDelay            = time.Second
+	activityTypeDailyLogin      = "daily_login"
+	activityTypeMissionComplete = "mission_completion"
+)
+
+// claimFlow is the navigate, detect and claim loop shared by ClaimDaily and CompleteMissions.
+// It is idempotent: when the claimed template is on screen and the claim button isn't, or when
+// there is no claim button at all, nothing is claimed and no activity is logged.
+type claimFlow struct {
+	Navigate  []ActionStep // Optional: reach the screen with the claim button
+	Claim     string       // Template of the claim button (required)
+	Claimed   string       // Optional: template shown when everything is already claimed
+	After     []ActionStep // Optional: run after each claim, e.g. to dismiss the reward popup
+	MaxClaims int          // Claims per run at most
+	Variable  string       // Variable set to the number of claims
+}
+
+// rawClaimFlow is claimFlow as written in YAML, before its nested actions are unmarshaled
+type rawClaimFlow struct {
+	Navigate  interface{} `yaml:"navigate"`
+	Claim     string      `yaml:"claim_template"`
+	Claimed   string      `yaml:"claimed_template"`
+	After     interface{} `yaml:"after"`
+	MaxClaims int         `yaml:"max_claims"`
+	Variable  string      `yaml:"variable"`
+}
+
+// flow converts the raw fields, unmarshaling the nested actions
+func (r *rawClaimFlow) flow(owner string) (claimFlow, error) {
+	f := claimFlow{
+		Claim:     r.Claim,
+		Claimed:   r.Claimed,
+		MaxClaims: r.MaxClaims,
+		Variable:  r.Variable,
+	}
+
+	if r.Navigate != nil {
+		navigate, err := unmarshalNestedActions(r.Navigate)
+		if err != nil {
+			return f, fmt.Errorf("%s: failed to unmarshal navigate actions: %w", owner, err)
+		}
+		f.Navigate = navigate
+	}
+	if r.After != nil {
+		after, err := unmarshalNestedActions(r.After)
+		if err != nil {
+			return f, fmt.Errorf("%s: failed to unmarshal after actions: %w", owner, err)
+		}
+		f.After = after
+	}
+
+	return f, nil
+}
+
+func (f *claimFlow) validate(ab *ActionBuilder, owner string) error {
+	if f.Claim == "" {
+		return fmt.Errorf("%s: claim_template is required", owner)
+	}
+	if f.MaxClaims < 0 {
+		return fmt.Errorf("%s: max_claims cannot be negative", owner)
+	}
+
+	if ab.templateRegistry != nil {
+		for _, name := range []string{f.Claim, f.Claimed} {
+			if name != "" && !ab.templateRegistry.Has(name) {
+				return fmt.Errorf("%s: template '%s' not found in registry", owner, name)
+			}
+		}
+	}
+
+	for i, action := range f.Navigate {
+		if err := action.Validate(ab); err != nil {
+			return fmt.Errorf("%s -> navigate action %d: %w", owner, i+1, err)
+		}
+	}
+	for i, action := range f.After {
+		if err := action.Validate(ab); err != nil {
+			return fmt.Errorf("%s -> after action %d: %w", owner, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// claimSpec is what differs between the claim actions
+type claimSpec struct {
+	owner           string // Action name for errors and the activity's routine name
+	activityType    string // activity_log type of a run that claimed something
+	defaultMax      int
+	defaultVariable string
+	what            string                                                 // What is claimed, for log lines
+	onClaim         func(bot BotInterface, db *database.DB, accountID int) // Optional: record one claim
+}
+
+// run navigates, then claims while the claim button is on screen, logging the run as an
+// activity of the current account. Returns the number of claims.
+func (f *claimFlow) run(ab *ActionBuilder, bot BotInterface, spec claimSpec) (int, error) {
+	variable := f.Variable
+	if variable == "" {
+		variable = spec.defaultVariable
+	}
+	bot.Variables().Set(variable, "0")
+
+	if len(f.Navigate) > 0 {
+		subBuilder := &ActionBuilder{steps: ab.buildSteps(f.Navigate)}
+		if err := subBuilder.executeSteps(bot.Context(), bot); err != nil {
+			return 0, fmt.Errorf("%s -> navigate action failed: %w", spec.owner, err)
+		}
+	}
+
+	bot.CV().InvalidateCache()
+	match, err := findStartupTemplate(bot, f.Claim)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", spec.owner, err)
+	}
+	if match == nil {
+		reason := "nothing to claim"
+		if f.Claimed != "" {
+			if claimed, err := findStartupTemplate(bot, f.Claimed); err == nil && claimed != nil {
+				reason = "already claimed"
+			}
+		}
+		fmt.Printf("Bot %d: No %s to claim (%s)\n", bot.Instance(), spec.what, reason)
+		return 0, nil
+	}
+
+	db, accountID, activityID := f.startActivity(bot, spec)
+
+	maxClaims := f.MaxClaims
+	if maxClaims == 0 {
+		maxClaims = spec.defaultMax
+	}
+
+	claims := 0
+	var claimErr error
+	for match != nil && claims < maxClaims {
+		if err := match.click(bot); err != nil {
+			claimErr = fmt.Errorf("failed to click claim button: %w", err)
+			break
+		}
+		time.Sleep(claimSettleDelay)
+
+		if len(f.After) > 0 {
+			subBuilder := &ActionBuilder{steps: ab.buildSteps(f.After)}
+			if err := subBuilder.executeSteps(bot.Context(), bot); err != nil {
+				claimErr = fmt.Errorf("after action failed: %w", err)
+				break
+			}
+		}
+
+		claims++
+		bot.Variables().Set(variable, strconv.Itoa(claims))
+		if db != nil && spec.onClaim != nil {
+			spec.onClaim(bot, db, accountID)
+		}
+
+		bot.CV().InvalidateCache()
+		if match, err = findStartupTemplate(bot, f.Claim); err != nil {
+			claimErr = err
+			break
+		}
+	}
+
+	if activityID != 0 {
+		if claimErr != nil {
+			err = db.FailActivity(activityID, claimErr.Error())
+		} else {
+			err = db.CompleteActivity(activityID)
+		}
+		if err != nil {
+			fmt.Printf("Bot %d: Warning - failed to update %s activity: %v\n", bot.Instance(), spec.activityType, err)
+		}
+	}
+
+	if claimErr != nil {
+		return claims, fmt.Errorf("%s: %w", spec.owner, claimErr)
+	}
+
+	fmt.Printf("Bot %d: Claimed %d %s\n", bot.Instance(), claims, spec.what)
+	return claims, nil
+}
+
+// startActivity logs the start of a claim run for the current account in the shared accounts
+// database. Bots without one or an injected account claim without logging (db is nil,
+// activityID 0).
+func (f *claimFlow) startActivity(bot BotInterface, spec claimSpec) (*database.DB, int, int64) {
+	conn := accountsDB(bot)
+	if conn == nil {
+		return nil, 0, 0
+	}
+	accountID, hasAccount := bot.Variables().GetInt(VarDeviceAccountID)
+	if !hasAccount {
+		return nil, 0, 0
+	}
+	db := database.FromConn(conn)
+
+	activityID, err := db.StartActivity(accountID, spec.activityType, spec.owner, "")
+	if err != nil {
+		fmt.Printf("Bot %d: Warning - failed to log %s activity: %v\n", bot.Instance(), spec.activityType, err)
+		return db, accountID, 0
+	}
+	return db, accountID, activityID
+}
+
+// ClaimDaily claims the daily login bonus: it runs navigate, then taps claim_template while
+// it is on screen (once by default). When there is nothing to claim it does nothing, so it is
+// safe to run every iteration. A run that claims is logged as a daily_login activity, and the
+// number of claims is stored in variable (default "daily_claimed").
+type ClaimDaily struct {
+	claimFlow
+}
+
+// UnmarshalYAML implements custom unmarshaling for ClaimDaily to handle polymorphic action fields
+func (a *ClaimDaily) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw rawClaimFlow
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	flow, err := raw.flow("ClaimDaily")
+	if err != nil {
+		return err
+	}
+	a.claimFlow = flow
+	return nil
+}
+
+func (a *ClaimDaily) Validate(ab *ActionBuilder) error {
+	return a.validate(ab, "ClaimDaily")
+}
+
+func (a *ClaimDaily) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: "ClaimDaily",
+		execute: func(bot BotInterface) error {
+			_, err := a.run(ab, bot, claimSpec{
+				owner:           "ClaimDaily",
+				activityType:    activityTypeDailyLogin,
+				defaultMax:      defaultDailyMaxClaims,
+				defaultVariable: defaultDailyClaimedVar,
+				what:            "daily bonus(es)",
+			})
+			return err
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// CompleteMissions claims finished missions: it runs navigate, then taps claim_template (e.g.
+// "Claim All" or a single mission's claim button) while it is on screen, up to max_claims
+// (default 20). Each claim is recorded in mission_completion as mission_type (default "daily"),
+// the run is logged as a mission_completion activity, and the number of claims is stored in
+// variable (default "missions_claimed").
+type CompleteMissions struct {
+	claimFlow
+	MissionType string // Recorded for each claimed mission
+}
+
+// UnmarshalYAML implements custom unmarshaling for CompleteMissions to handle polymorphic action fields
+func (a *CompleteMissions) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw struct {
+		rawClaimFlow `yaml:",inline"`
+		MissionType  string `yaml:"mission_type"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	flow, err := raw.flow("CompleteMissions")
+	if err != nil {
+		return err
+	}
+	a.claimFlow = flow
+	a.MissionType = raw.MissionType
+	return nil
+}
+
+func (a *CompleteMissions) Validate(ab *ActionBuilder) error {
+	return a.validate(ab, "CompleteMissions")
+}
+
+func (a *CompleteMissions) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: "CompleteMissions",
+		execute: func(bot BotInterface) error {
+			missionType := a.MissionType
+			if missionType == "" {
+				missionType = defaultMissionType
+			}
+
+			_, err := a.run(ab, bot, claimSpec{
+				owner:           "CompleteMissions",
+				activityType:    activityTypeMissionComplete,
+				defaultMax:      defaultMissionMaxClaims,
+				defaultVariable: defaultMissionsClaimedVar,
+				what:            "mission(s)",
+				onClaim: func(bot BotInterface, db *database.DB, accountID int) {
+					if _, err := db.LogMissionCompletion(accountID, missionType, nil, 0, 0, 0, 0); err != nil {
+						fmt.Printf("Bot %d: Warning - failed to record mission: %v\n", bot.Instance(), err)
+					}
+				},
+			})
+			return err
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
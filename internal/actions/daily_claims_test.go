@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestClaimActionsUnmarshaling(t *testing.T) {
+	yamlStr := `
+routine_name: "Test Daily Claims"
+steps:
+  - action: claim_daily
+    claim_template: "DailyClaim"
+    claimed_template: "DailyClaimed"
+    after:
+      - action: Click
+        x: 270
+        y: 800
+  - action: complete_missions
+    navigate:
+      - action: Click
+        x: 100
+        y: 900
+    claim_template: "MissionClaim"
+    mission_type: "weekly"
+    max_claims: 5
+    variable: claimed
+`
+
+	var routine Routine
+	if err := yaml.Unmarshal([]byte(yamlStr), &routine); err != nil {
+		t.Fatalf("Failed to unmarshal routine: %v", err)
+	}
+	if len(routine.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(routine.Steps))
+	}
+
+	daily, ok := routine.Steps[0].(*ClaimDaily)
+	if !ok {
+		t.Fatalf("Expected first step to be *ClaimDaily, got %T", routine.Steps[0])
+	}
+	if daily.Claim != "DailyClaim" || daily.Claimed != "DailyClaimed" || len(daily.After) != 1 {
+		t.Errorf("Unexpected daily claim: %+v", daily.claimFlow)
+	}
+
+	missions, ok := routine.Steps[1].(*CompleteMissions)
+	if !ok {
+		t.Fatalf("Expected second step to be *CompleteMissions, got %T", routine.Steps[1])
+	}
+	if missions.Claim != "MissionClaim" || missions.MissionType != "weekly" || missions.MaxClaims != 5 ||
+		missions.Variable != "claimed" || len(missions.Navigate) != 1 {
+		t.Errorf("Unexpected mission claim: %+v", missions)
+	}
+
+	ab := &ActionBuilder{}
+	if err := daily.Validate(ab); err != nil {
+		t.Errorf("Expected valid daily claim, got %v", err)
+	}
+	if err := missions.Validate(ab); err != nil {
+		t.Errorf("Expected valid mission claim, got %v", err)
+	}
+	if err := (&ClaimDaily{}).Validate(ab); err == nil {
+		t.Error("Expected error for a daily claim without claim_template")
+	}
+}
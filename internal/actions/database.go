@@ -5,9 +5,29 @@ import (
 	"fmt"
 	"strconv"
 
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
 	"jordanella.com/pocket-tcg-go/internal/database"
 )
 
+// accountsDB returns the shared accounts database from the bot's manager: the database
+// InjectNextAccount resolves VarDeviceAccountID in, so account-scoped rows written to it
+// reference the right account. Returns nil without one, and for sandbox pools, which never
+// touch the database.
+func accountsDB(bot BotInterface) *sql.DB {
+	managerIf := bot.Manager()
+	if managerIf == nil {
+		return nil
+	}
+	dbProvider, ok := managerIf.(interface{ Database() *sql.DB })
+	if !ok {
+		return nil
+	}
+	if pool, ok := managerIf.(interface{ AccountPool() accountpool.AccountPool }); ok && accountpool.IsSandbox(pool.AccountPool()) {
+		return nil
+	}
+	return dbProvider.Database()
+}
+
 // UpdateAccountField updates a specific field in the accounts table
 // Requires device_account_id variable to be set (automatically set by InjectNextAccount)
 type UpdateAccountField struct {
@@ -196,6 +216,14 @@ func (a *IncrementAccountField) Build(ab *ActionBuilder) *ActionBuilder {
 				return fmt.Errorf("no account found with id %d", accountID)
 			}
 
+			if a.Field == "packs_opened" && incrementValue > 0 {
+				recordProgress(botIf)
+				// Keep the in-memory count current for CheckPackLimit
+				if account, ok := botIf.GetCurrentAccount().(*accountpool.Account); ok && account != nil {
+					account.PackCount += int(incrementValue)
+				}
+			}
+
 			fmt.Printf("Bot %d: Incremented account %d field '%s' by %d\n", botIf.Instance(), accountID, a.Field, incrementValue)
 			return nil
 		},
@@ -282,6 +310,10 @@ func (a *UpdateRoutineMetrics) Build(ab *ActionBuilder) *ActionBuilder {
 				return fmt.Errorf("failed to update routine metrics: %w", err)
 			}
 
+			if packsOpened > 0 {
+				recordProgress(botIf)
+			}
+
 			fmt.Printf("Bot %d: Updated routine execution %d metrics (packs: %d, picks: %d)\n",
 				botIf.Instance(), executionID, packsOpened, wonderPicksDone)
 			return nil
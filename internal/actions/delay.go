@@ -22,7 +22,7 @@ func (a *Delay) Build(ab *ActionBuilder) *ActionBuilder {
 		execute: func(bot BotInterface) error {
 			delayMs := bot.Config().Actions().GetDelayBetweenActions()
 			duration := time.Duration(delayMs*a.Count) * time.Millisecond
-			time.Sleep(duration)
+			humanizedSleep(bot, duration)
 			return nil
 		},
 	}
@@ -0,0 +1,192 @@
+package actions
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// defaultColorTolerance is used when a color detection doesn't specify a tolerance
+const defaultColorTolerance = 20
+
+// DetectColor looks for a blob of a solid color (e.g. a red notification badge) and
+// runs actions when it is present, else actions when it is not.
+// If variable is set, "<variable>" is set to "true"/"false" and, when found,
+// "<variable>_x"/"<variable>_y" hold the blob's center and "<variable>_pixels" its size.
+type DetectColor struct {
+	Color     string       `yaml:"color"`                // Hex color "#RRGGBB" (required)
+	Tolerance *int         `yaml:"tolerance,omitempty"`  // Mean per-channel difference allowed (0-255, default 20)
+	MinPixels int          `yaml:"min_pixels,omitempty"` // Ignore blobs smaller than this (default cv.DefaultMinBlobPixels)
+	Region    *cv.Region   `yaml:"region,omitempty"`     // Optional: limit the search area
+	Variable  string       `yaml:"variable,omitempty"`   // Optional: store the result in variables
+	Actions   []ActionStep `yaml:"actions,omitempty"`    // Run when the color is found
+	Else      []ActionStep `yaml:"else,omitempty"`       // Run when the color is not found
+}
+
+// UnmarshalYAML implements custom unmarshaling for DetectColor to handle polymorphic action fields
+func (a *DetectColor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw struct {
+		Color     string      `yaml:"color"`
+		Tolerance *int        `yaml:"tolerance"`
+		MinPixels int         `yaml:"min_pixels"`
+		Region    *cv.Region  `yaml:"region"`
+		Variable  string      `yaml:"variable"`
+		Actions   interface{} `yaml:"actions"`
+		Else      interface{} `yaml:"else"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	a.Color = raw.Color
+	a.Tolerance = raw.Tolerance
+	a.MinPixels = raw.MinPixels
+	a.Region = raw.Region
+	a.Variable = raw.Variable
+
+	if raw.Actions != nil {
+		actions, err := unmarshalNestedActions(raw.Actions)
+		if err != nil {
+			return fmt.Errorf("DetectColor: failed to unmarshal actions: %w", err)
+		}
+		a.Actions = actions
+	}
+	if raw.Else != nil {
+		elseActions, err := unmarshalNestedActions(raw.Else)
+		if err != nil {
+			return fmt.Errorf("DetectColor: failed to unmarshal else actions: %w", err)
+		}
+		a.Else = elseActions
+	}
+
+	return nil
+}
+
+func (a *DetectColor) Validate(ab *ActionBuilder) error {
+	if err := validateColorSearch(a.Color, a.Tolerance, a.MinPixels); err != nil {
+		return fmt.Errorf("DetectColor: %w", err)
+	}
+	if len(a.Actions) == 0 && len(a.Else) == 0 && a.Variable == "" {
+		return fmt.Errorf("DetectColor: at least one of actions, else or variable is required")
+	}
+
+	for i, action := range a.Actions {
+		if err := action.Validate(ab); err != nil {
+			return fmt.Errorf("DetectColor (%s) -> action %d: %w", a.Color, i+1, err)
+		}
+	}
+	for i, action := range a.Else {
+		if err := action.Validate(ab); err != nil {
+			return fmt.Errorf("DetectColor (%s) -> else action %d: %w", a.Color, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *DetectColor) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("DetectColor (%s)", a.Color),
+		execute: func(bot BotInterface) error {
+			blob, err := findColorBlob(bot, a.Color, a.Tolerance, a.MinPixels, a.Region)
+			if err != nil {
+				return fmt.Errorf("DetectColor (%s): %w", a.Color, err)
+			}
+
+			if a.Variable != "" {
+				bot.Variables().Set(a.Variable, strconv.FormatBool(blob != nil))
+				if blob != nil {
+					center := blob.Center()
+					bot.Variables().Set(a.Variable+"_x", strconv.Itoa(center.X))
+					bot.Variables().Set(a.Variable+"_y", strconv.Itoa(center.Y))
+					bot.Variables().Set(a.Variable+"_pixels", strconv.Itoa(blob.PixelCount))
+				}
+			}
+
+			actionsToExecute := a.Else
+			if blob != nil {
+				actionsToExecute = a.Actions
+			}
+			if len(actionsToExecute) == 0 {
+				return nil
+			}
+
+			subBuilder := &ActionBuilder{
+				steps: ab.buildSteps(actionsToExecute),
+			}
+			if err := subBuilder.executeSteps(bot.Context(), bot); err != nil {
+				return fmt.Errorf("DetectColor (%s) -> nested action failed: %w", a.Color, err)
+			}
+
+			return nil
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// ColorDetected is a condition that is true when a blob of the given color is on screen
+type ColorDetected struct {
+	Color     string     `yaml:"color"`                // Hex color "#RRGGBB" (required)
+	Tolerance *int       `yaml:"tolerance,omitempty"`  // Mean per-channel difference allowed (0-255, default 20)
+	MinPixels int        `yaml:"min_pixels,omitempty"` // Ignore blobs smaller than this
+	Region    *cv.Region `yaml:"region,omitempty"`     // Optional: limit the search area
+}
+
+func (c *ColorDetected) Validate(ab *ActionBuilder) error {
+	if err := validateColorSearch(c.Color, c.Tolerance, c.MinPixels); err != nil {
+		return fmt.Errorf("ColorDetected: %w", err)
+	}
+	return nil
+}
+
+func (c *ColorDetected) Evaluate(bot BotInterface) (bool, error) {
+	blob, err := findColorBlob(bot, c.Color, c.Tolerance, c.MinPixels, c.Region)
+	if err != nil {
+		return false, fmt.Errorf("ColorDetected (%s): %w", c.Color, err)
+	}
+	return blob != nil, nil
+}
+
+// validateColorSearch checks the parameters shared by DetectColor and ColorDetected
+func validateColorSearch(hexColor string, tolerance *int, minPixels int) error {
+	if hexColor == "" {
+		return fmt.Errorf("color is required")
+	}
+	if _, err := cv.ParseHexColor(hexColor); err != nil {
+		return err
+	}
+	if tolerance != nil && (*tolerance < 0 || *tolerance > 255) {
+		return fmt.Errorf("tolerance must be between 0 and 255")
+	}
+	if minPixels < 0 {
+		return fmt.Errorf("min_pixels cannot be negative")
+	}
+	return nil
+}
+
+// findColorBlob captures a frame and returns the largest matching blob, or nil if none
+func findColorBlob(bot BotInterface, hexColor string, tolerance *int, minPixels int, region *cv.Region) (*cv.ColorBlob, error) {
+	target, err := cv.ParseHexColor(hexColor)
+	if err != nil {
+		return nil, err
+	}
+
+	tol := defaultColorTolerance
+	if tolerance != nil {
+		tol = *tolerance
+	}
+	if minPixels == 0 {
+		minPixels = cv.DefaultMinBlobPixels
+	}
+
+	var searchRegion *image.Rectangle
+	if region != nil {
+		searchRegion = region.ToImageRectangle()
+	}
+
+	return bot.CV().DetectColorRegion(target, tol, searchRegion, minPixels)
+}
@@ -0,0 +1,240 @@
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// StartupDialog is a launch-time popup DismissStartupDialogs knows how to close
+type StartupDialog struct {
+	Name     string    `yaml:"name,omitempty"`   // Shown in log lines (default: template)
+	Template string    `yaml:"template"`         // Template that identifies the dialog (required)
+	Button   string    `yaml:"button,omitempty"` // Template of the button to tap (default: tap the dialog template)
+	Point    *cv.Point `yaml:"point,omitempty"`  // Tap this point instead of a template
+	Delay    int       `yaml:"delay,omitempty"`  // Milliseconds to wait after dismissing (default: 1000)
+}
+
+// DismissStartupDialogs closes the update/news/connection popups the game shows after launch.
+// It watches for the configured dialogs and taps each one away, finishing once the until
+// template is on screen or no dialog has appeared for settle seconds.
+type DismissStartupDialogs struct {
+	Dialogs []StartupDialog `yaml:"dialogs"`
+	Until   string          `yaml:"until,omitempty"`    // Template that means startup is done (e.g. Home)
+	MaxWait int             `yaml:"max_wait,omitempty"` // Seconds before giving up (default: 60)
+	Settle  int             `yaml:"settle,omitempty"`   // Seconds without a dialog before finishing (default: 5)
+}
+
+const (
+	defaultStartupDialogMaxWait = 60
+	defaultStartupDialogSettle  = 5
+	defaultStartupDialogDelay   = 1000
+	startupDialogPollInterval   = 500 * time.Millisecond
+)
+
+func (a *DismissStartupDialogs) Validate(ab *ActionBuilder) error {
+	if len(a.Dialogs) == 0 {
+		return fmt.Errorf("at least one dialog is required")
+	}
+	if a.MaxWait < 0 || a.Settle < 0 {
+		return fmt.Errorf("max_wait and settle must be non-negative")
+	}
+
+	templateNames := make([]string, 0, len(a.Dialogs)*2+1)
+	for i, dialog := range a.Dialogs {
+		if dialog.Template == "" {
+			return fmt.Errorf("dialog %d: template is required", i+1)
+		}
+		if dialog.Button != "" && dialog.Point != nil {
+			return fmt.Errorf("dialog %d: cannot specify both 'button' and 'point'", i+1)
+		}
+		templateNames = append(templateNames, dialog.Template)
+		if dialog.Button != "" {
+			templateNames = append(templateNames, dialog.Button)
+		}
+	}
+	if a.Until != "" {
+		templateNames = append(templateNames, a.Until)
+	}
+
+	// Validate templates exist in registry (if registry is available)
+	if ab.templateRegistry != nil {
+		for _, name := range templateNames {
+			if !ab.templateRegistry.Has(name) {
+				return fmt.Errorf("template '%s' not found in registry", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *DismissStartupDialogs) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("DismissStartupDialogs (%d dialogs)", len(a.Dialogs)),
+		execute: func(bot BotInterface) error {
+			maxWait := a.MaxWait
+			if maxWait == 0 {
+				maxWait = defaultStartupDialogMaxWait
+			}
+			settle := a.Settle
+			if settle == 0 {
+				settle = defaultStartupDialogSettle
+			}
+
+			deadline := time.Now().Add(time.Duration(maxWait) * time.Second)
+			lastDialog := time.Now()
+			dismissed := 0
+
+			for {
+				if bot.IsStopped() {
+					return fmt.Errorf("bot stopped")
+				}
+
+				// One fresh screenshot per poll
+				bot.CV().InvalidateCache()
+
+				if a.Until != "" {
+					found, err := findStartupTemplate(bot, a.Until)
+					if err != nil {
+						return err
+					}
+					if found != nil {
+						fmt.Printf("Bot %d: Startup finished ('%s' on screen, %d dialog(s) dismissed)\n", bot.Instance(), a.Until, dismissed)
+						return nil
+					}
+				}
+
+				dialog, err := a.dismissNext(bot)
+				if err != nil {
+					return err
+				}
+				if dialog != nil {
+					dismissed++
+					lastDialog = time.Now()
+					fmt.Printf("Bot %d: Dismissed startup dialog '%s'\n", bot.Instance(), dialog.label())
+				} else if a.Until == "" && time.Since(lastDialog) >= time.Duration(settle)*time.Second {
+					fmt.Printf("Bot %d: Startup finished (no dialogs for %ds, %d dismissed)\n", bot.Instance(), settle, dismissed)
+					return nil
+				}
+
+				if time.Now().After(deadline) {
+					if a.Until != "" {
+						return fmt.Errorf("'%s' not found within %ds (%d dialog(s) dismissed)", a.Until, maxWait, dismissed)
+					}
+					return nil
+				}
+
+				select {
+				case <-bot.Context().Done():
+					return bot.Context().Err()
+				case <-time.After(startupDialogPollInterval):
+				}
+			}
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// dismissNext taps away the first configured dialog on screen. Returns nil if none is showing.
+func (a *DismissStartupDialogs) dismissNext(bot BotInterface) (*StartupDialog, error) {
+	for i := range a.Dialogs {
+		dialog := &a.Dialogs[i]
+
+		match, err := findStartupTemplate(bot, dialog.Template)
+		if err != nil {
+			return nil, err
+		}
+		if match == nil {
+			continue
+		}
+
+		tapped, err := dialog.dismiss(bot, match)
+		if err != nil {
+			return nil, err
+		}
+		if !tapped {
+			continue
+		}
+		return dialog, nil
+	}
+	return nil, nil
+}
+
+// dismiss taps the dialog's button (or point, or the dialog itself) and waits its delay.
+// Returns false if the button isn't on screen yet.
+func (d *StartupDialog) dismiss(bot BotInterface, match *startupMatch) (bool, error) {
+	var err error
+	switch {
+	case d.Point != nil:
+		err = bot.ADB().Click(d.Point.X, d.Point.Y)
+	case d.Button != "":
+		button, findErr := findStartupTemplate(bot, d.Button)
+		if findErr != nil {
+			return false, findErr
+		}
+		if button == nil {
+			// The dialog is still animating in; try again on the next poll
+			return false, nil
+		}
+		err = button.click(bot)
+	default:
+		err = match.click(bot)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to dismiss '%s': %w", d.label(), err)
+	}
+
+	delay := d.Delay
+	if delay == 0 {
+		delay = defaultStartupDialogDelay
+	}
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+	return true, nil
+}
+
+// label returns the dialog's name for log lines
+func (d *StartupDialog) label() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.Template
+}
+
+// startupMatch is a template found on screen
+type startupMatch struct {
+	template cv.Template
+	result   *cv.MatchResult
+}
+
+// click taps the middle of the matched template
+func (m *startupMatch) click(bot BotInterface) error {
+	x, y := m.result.Location.X, m.result.Location.Y
+	if m.template.Region == nil {
+		return bot.ADB().Click(x, y)
+	}
+
+	width := m.template.Region.X2 - m.template.Region.X1
+	height := m.template.Region.Y2 - m.template.Region.Y1
+	return bot.ADB().ClickTarget(x+width/2, y+height/2, min(width, height)/2)
+}
+
+// findStartupTemplate looks for a template on the current screenshot. Returns nil if it isn't on screen.
+func findStartupTemplate(bot BotInterface, name string) (*startupMatch, error) {
+	template, config, err := buildTemplateConfiguration(bot, name, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build template configuration: %w", err)
+	}
+
+	result, err := bot.CV().FindTemplate(template.Name, config)
+	if err != nil {
+		return nil, fmt.Errorf("error checking template %s: %w", template.Name, err)
+	}
+	if !result.Found {
+		return nil, nil
+	}
+	return &startupMatch{template: template, result: result}, nil
+}
@@ -0,0 +1,101 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+	"jordanella.com/pocket-tcg-go/internal/recovery"
+)
+
+// recoveryTriedError marks a step error whose recovery already ran, so enclosing steps
+// (e.g. the If or Repeat containing the step) fail with it instead of recovering again
+type recoveryTriedError struct {
+	err error
+}
+
+func (e *recoveryTriedError) Error() string { return e.err.Error() }
+func (e *recoveryTriedError) Unwrap() error { return e.err }
+
+// recoverStepError runs the recovery registered for the type of a failed step's error (see
+// the recovery package). It returns whether the bot recovered, in which case the step can
+// run again (or, for a ReportError step, the routine moves on), and otherwise the error to
+// fail with. Errors without a known type or registered recovery are returned unchanged.
+func recoverStepError(bot BotInterface, step *Step, err error) (bool, error) {
+	var tried *recoveryTriedError
+	if !isRetryable(err) || errors.As(err, &tried) {
+		return false, err
+	}
+
+	errorType, ok := recovery.TypeOf(err)
+	if !ok {
+		return false, err
+	}
+	recoverFn, ok := recovery.Lookup(errorType)
+	if !ok {
+		return false, err
+	}
+
+	stepName := step.name
+	fmt.Printf("Bot %d: Step '%s' hit a %s error (%v), running its recovery\n", bot.Instance(), stepName, errorType, err)
+
+	started := time.Now()
+	recoverErr := recoverFn(bot)
+	elapsed := time.Since(started)
+
+	if recoverErr != nil {
+		fmt.Printf("Bot %d: Recovery from %s error failed after %v: %v\n", bot.Instance(), errorType, elapsed, recoverErr)
+	} else if step.reportsError {
+		fmt.Printf("Bot %d: Recovered from %s error in %v, continuing after step '%s'\n", bot.Instance(), errorType, elapsed, stepName)
+	} else {
+		fmt.Printf("Bot %d: Recovered from %s error in %v, running step '%s' again\n", bot.Instance(), errorType, elapsed, stepName)
+	}
+	logRecovery(bot, errorType, step, err, recoverErr, elapsed)
+
+	if recoverErr != nil {
+		return false, &recoveryTriedError{err: err}
+	}
+	return true, nil
+}
+
+// logRecovery records a recovery attempt in the error log of the shared accounts database:
+// the error, and whether the recovery succeeded (via MarkErrorRecovered). Bots without the
+// database skip it.
+func logRecovery(bot BotInterface, errorType recovery.ErrorType, step *Step, stepErr, recoverErr error, elapsed time.Duration) {
+	conn := accountsDB(bot)
+	if conn == nil {
+		return
+	}
+	db := database.FromConn(conn)
+
+	var accountID *int
+	if id, exists := bot.Variables().GetInt(VarDeviceAccountID); exists {
+		accountID = &id
+	}
+
+	severity := "medium"
+	message := stepErr.Error()
+	if recoverErr != nil {
+		severity = "high"
+		message = fmt.Sprintf("%s (recovery failed: %v)", message, recoverErr)
+	}
+
+	stepName := step.name
+	errorID, err := db.LogError(accountID, nil, string(errorType), severity, message, nil, nil, nil, &stepName)
+	if err != nil {
+		fmt.Printf("Bot %d: Failed to log %s error: %v\n", bot.Instance(), errorType, err)
+		return
+	}
+	if recoverErr != nil {
+		return
+	}
+
+	action := fmt.Sprintf("%s recovery, then retried step '%s'", errorType, stepName)
+	if step.reportsError {
+		action = fmt.Sprintf("%s recovery, then continued after step '%s'", errorType, stepName)
+	}
+	if err := db.MarkErrorRecovered(errorID, action, int(elapsed.Milliseconds())); err != nil {
+		fmt.Printf("Bot %d: Failed to mark %s error recovered: %v\n", bot.Instance(), errorType, err)
+	}
+}
@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/recovery"
+)
+
+func TestKnownErrorRunsRegisteredRecovery(t *testing.T) {
+	recoveries := 0
+	recovery.Register(recovery.ErrorPopup, func(recovery.Bot) error {
+		recoveries++
+		return nil
+	})
+	defer recovery.Register(recovery.ErrorPopup, nil)
+
+	// The step runs again after the recovery
+	calls := 0
+	ab := NewActionBuilder()
+	ab.steps = append(ab.steps, Step{name: "OpenPack", execute: func(BotInterface) error {
+		calls++
+		if calls == 1 {
+			return recovery.New(recovery.ErrorPopup, "level up popup")
+		}
+		return nil
+	}})
+	if err := ab.executeSteps(context.Background(), retryBot{}); err != nil {
+		t.Fatalf("expected the run to recover, got %v", err)
+	}
+	if recoveries != 1 || calls != 2 {
+		t.Errorf("recoveries = %d, calls = %d, want 1 and 2", recoveries, calls)
+	}
+
+	// A step that keeps failing is recovered once, also when nested
+	inner := NewActionBuilder()
+	inner.steps = append(inner.steps, Step{name: "Stuck", execute: func(BotInterface) error {
+		return recovery.New(recovery.ErrorPopup, "popup won't close")
+	}})
+	outer := NewActionBuilder()
+	outer.steps = append(outer.steps, Step{name: "Repeat", execute: func(b BotInterface) error {
+		return inner.executeSteps(context.Background(), b)
+	}})
+
+	recoveries = 0
+	err := outer.executeSteps(context.Background(), retryBot{})
+	if errorType, ok := recovery.TypeOf(err); !ok || errorType != recovery.ErrorPopup {
+		t.Fatalf("expected the popup error, got %v", err)
+	}
+	if recoveries != 1 {
+		t.Errorf("recoveries = %d, want 1", recoveries)
+	}
+
+	// Errors without a registered recovery fail as before
+	plain := errors.New("template not found")
+	failing := NewActionBuilder()
+	failing.steps = append(failing.steps, Step{name: "Find", execute: func(BotInterface) error { return plain }})
+	if err := failing.executeSteps(context.Background(), retryBot{}); !errors.Is(err, plain) {
+		t.Errorf("expected the step error, got %v", err)
+	}
+}
+
+func TestRecoveredReportErrorContinues(t *testing.T) {
+	recoveries := 0
+	recovery.Register(recovery.ErrorStuck, func(recovery.Bot) error {
+		recoveries++
+		return nil
+	})
+	defer recovery.Register(recovery.ErrorStuck, nil)
+
+	bot := checkpointBot{vars: NewVariableStore()}
+
+	// Once its recovery succeeds, the routine moves on to the step after ReportError
+	ranAfter := false
+	ab := NewActionBuilder()
+	(&ReportError{Type: "stuck", Message: "screen unchanged"}).Build(ab)
+	ab.steps = append(ab.steps, Step{name: "After", execute: func(BotInterface) error {
+		ranAfter = true
+		return nil
+	}})
+	if err := ab.executeSteps(context.Background(), bot); err != nil {
+		t.Fatalf("expected the run to recover, got %v", err)
+	}
+	if recoveries != 1 || !ranAfter {
+		t.Errorf("recoveries = %d, ranAfter = %v, want 1 and true", recoveries, ranAfter)
+	}
+
+	// A failed recovery still fails the routine
+	recovery.Register(recovery.ErrorStuck, func(recovery.Bot) error { return errors.New("restart failed") })
+	ranAfter = false
+	err := ab.executeSteps(context.Background(), bot)
+	if errorType, ok := recovery.TypeOf(err); !ok || errorType != recovery.ErrorStuck {
+		t.Fatalf("expected the stuck error, got %v", err)
+	}
+	if ranAfter {
+		t.Error("expected the routine to stop at ReportError")
+	}
+}
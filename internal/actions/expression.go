@@ -0,0 +1,400 @@
+package actions
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression interpolation pattern: {{ expression }}
+var expressionPattern = regexp.MustCompile(`\{\{(.*?)\}\}`)
+
+// ExpressionError is returned when a {{ expression }} cannot be evaluated
+type ExpressionError struct {
+	Expr string
+	Err  error
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("expression '{{ %s }}': %v", e.Expr, e.Err)
+}
+
+func (e *ExpressionError) Unwrap() error {
+	return e.Err
+}
+
+// InterpolateExpressions replaces every {{ expression }} in input with its evaluated value.
+// Expressions support numbers, quoted strings, variable names, + - * / %, parentheses,
+// and the functions randInt(a,b), min(a,b), max(a,b), abs(x), and round(x).
+func InterpolateExpressions(input string, vars VariableStoreInterface) (string, error) {
+	if !strings.Contains(input, "{{") {
+		return input, nil
+	}
+
+	var firstErr error
+	result := expressionPattern.ReplaceAllStringFunc(input, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		expr := strings.TrimSpace(match[2 : len(match)-2])
+		value, err := EvaluateExpression(expr, vars)
+		if err != nil {
+			firstErr = err
+			return match
+		}
+		return value
+	})
+
+	return result, firstErr
+}
+
+// EvaluateExpression evaluates a single expression (without the {{ }} delimiters) against vars.
+// Variables whose values parse as numbers are treated as numbers, everything else as strings.
+// + adds two numbers and concatenates otherwise.
+func EvaluateExpression(expr string, vars VariableStoreInterface) (string, error) {
+	tokens, err := tokenizeExpression(expr)
+	if err != nil {
+		return "", &ExpressionError{Expr: expr, Err: err}
+	}
+
+	p := &exprParser{tokens: tokens, vars: vars}
+	value, err := p.parseAdditive()
+	if err != nil {
+		return "", &ExpressionError{Expr: expr, Err: err}
+	}
+	if p.pos < len(p.tokens) {
+		return "", &ExpressionError{Expr: expr, Err: fmt.Errorf("unexpected '%s'", p.tokens[p.pos].text)}
+	}
+
+	return value.String(), nil
+}
+
+// exprValue is a number or a string produced while evaluating an expression
+type exprValue struct {
+	num   float64
+	str   string
+	isNum bool
+}
+
+func numberValue(n float64) exprValue {
+	return exprValue{num: n, isNum: true}
+}
+
+func stringValue(s string) exprValue {
+	return exprValue{str: s}
+}
+
+// String formats the value (whole numbers without a decimal point)
+func (v exprValue) String() string {
+	if !v.isNum {
+		return v.str
+	}
+	if v.num == math.Trunc(v.num) && math.Abs(v.num) < 1e15 {
+		return strconv.FormatInt(int64(v.num), 10)
+	}
+	return strconv.FormatFloat(v.num, 'f', -1, 64)
+}
+
+type exprTokenKind int
+
+const (
+	exprTokenNumber exprTokenKind = iota
+	exprTokenString
+	exprTokenIdent
+	exprTokenOp
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+}
+
+// tokenizeExpression splits an expression into numbers, strings, identifiers, and operators
+func tokenizeExpression(expr string) ([]exprToken, error) {
+	tokens := make([]exprToken, 0)
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenNumber, text: string(runes[start:i])})
+
+		case r == '"' || r == '\'':
+			quote := r
+			start := i + 1
+			i++
+			for i < len(runes) && runes[i] != quote {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenString, text: string(runes[start:i])})
+			i++
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenIdent, text: string(runes[start:i])})
+
+		case strings.ContainsRune("+-*/%(),", r):
+			tokens = append(tokens, exprToken{kind: exprTokenOp, text: string(r)})
+			i++
+
+		default:
+			return nil, fmt.Errorf("unexpected character '%c'", r)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	return tokens, nil
+}
+
+// exprParser is a recursive descent evaluator over expression tokens
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	vars   VariableStoreInterface
+}
+
+func (p *exprParser) peekOp(ops string) (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	tok := p.tokens[p.pos]
+	if tok.kind != exprTokenOp || !strings.Contains(ops, tok.text) {
+		return "", false
+	}
+	return tok.text, true
+}
+
+func (p *exprParser) expectOp(op string) error {
+	if _, ok := p.peekOp(op); !ok {
+		if p.pos >= len(p.tokens) {
+			return fmt.Errorf("expected '%s' but expression ended", op)
+		}
+		return fmt.Errorf("expected '%s' but found '%s'", op, p.tokens[p.pos].text)
+	}
+	p.pos++
+	return nil
+}
+
+// parseAdditive handles + and -
+func (p *exprParser) parseAdditive() (exprValue, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return exprValue{}, err
+	}
+
+	for {
+		op, ok := p.peekOp("+-")
+		if !ok {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return exprValue{}, err
+		}
+
+		if op == "+" && (!left.isNum || !right.isNum) {
+			left = stringValue(left.String() + right.String())
+			continue
+		}
+
+		if !left.isNum || !right.isNum {
+			return exprValue{}, fmt.Errorf("cannot apply '%s' to '%s' and '%s'", op, left.String(), right.String())
+		}
+		if op == "+" {
+			left = numberValue(left.num + right.num)
+		} else {
+			left = numberValue(left.num - right.num)
+		}
+	}
+}
+
+// parseTerm handles *, /, and %
+func (p *exprParser) parseTerm() (exprValue, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return exprValue{}, err
+	}
+
+	for {
+		op, ok := p.peekOp("*/%")
+		if !ok {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return exprValue{}, err
+		}
+
+		if !left.isNum || !right.isNum {
+			return exprValue{}, fmt.Errorf("cannot apply '%s' to '%s' and '%s'", op, left.String(), right.String())
+		}
+
+		switch op {
+		case "*":
+			left = numberValue(left.num * right.num)
+		case "/":
+			if right.num == 0 {
+				return exprValue{}, fmt.Errorf("division by zero")
+			}
+			left = numberValue(left.num / right.num)
+		case "%":
+			if right.num == 0 {
+				return exprValue{}, fmt.Errorf("modulo by zero")
+			}
+			left = numberValue(math.Mod(left.num, right.num))
+		}
+	}
+}
+
+// parseUnary handles a leading minus sign
+func (p *exprParser) parseUnary() (exprValue, error) {
+	if _, ok := p.peekOp("-"); ok {
+		p.pos++
+		value, err := p.parseUnary()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if !value.isNum {
+			return exprValue{}, fmt.Errorf("cannot negate '%s'", value.String())
+		}
+		return numberValue(-value.num), nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary handles literals, variables, function calls, and parentheses
+func (p *exprParser) parsePrimary() (exprValue, error) {
+	if p.pos >= len(p.tokens) {
+		return exprValue{}, fmt.Errorf("unexpected end of expression")
+	}
+
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	switch tok.kind {
+	case exprTokenNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return exprValue{}, fmt.Errorf("invalid number '%s'", tok.text)
+		}
+		return numberValue(n), nil
+
+	case exprTokenString:
+		return stringValue(tok.text), nil
+
+	case exprTokenIdent:
+		if _, ok := p.peekOp("("); ok {
+			return p.parseCall(tok.text)
+		}
+
+		value, ok := p.vars.Get(tok.text)
+		if !ok {
+			return exprValue{}, fmt.Errorf("undefined variable '%s'", tok.text)
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return numberValue(n), nil
+		}
+		return stringValue(value), nil
+
+	case exprTokenOp:
+		if tok.text == "(" {
+			value, err := p.parseAdditive()
+			if err != nil {
+				return exprValue{}, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return exprValue{}, err
+			}
+			return value, nil
+		}
+	}
+
+	return exprValue{}, fmt.Errorf("unexpected '%s'", tok.text)
+}
+
+// parseCall evaluates a function call; the function name has already been consumed
+func (p *exprParser) parseCall(name string) (exprValue, error) {
+	if err := p.expectOp("("); err != nil {
+		return exprValue{}, err
+	}
+
+	args := make([]exprValue, 0)
+	if _, ok := p.peekOp(")"); !ok {
+		for {
+			arg, err := p.parseAdditive()
+			if err != nil {
+				return exprValue{}, err
+			}
+			args = append(args, arg)
+
+			if _, ok := p.peekOp(","); !ok {
+				break
+			}
+			p.pos++
+		}
+	}
+	if err := p.expectOp(")"); err != nil {
+		return exprValue{}, err
+	}
+
+	return callExpressionFunc(name, args)
+}
+
+// callExpressionFunc runs a built-in expression function
+func callExpressionFunc(name string, args []exprValue) (exprValue, error) {
+	arity := map[string]int{"randInt": 2, "min": 2, "max": 2, "abs": 1, "round": 1}
+	want, ok := arity[name]
+	if !ok {
+		return exprValue{}, fmt.Errorf("unknown function '%s' (available: randInt, min, max, abs, round)", name)
+	}
+	if len(args) != want {
+		return exprValue{}, fmt.Errorf("%s expects %d argument(s), got %d", name, want, len(args))
+	}
+	for i, arg := range args {
+		if !arg.isNum {
+			return exprValue{}, fmt.Errorf("%s argument %d ('%s') is not a number", name, i+1, arg.String())
+		}
+	}
+
+	switch name {
+	case "randInt":
+		lo, hi := int64(args[0].num), int64(args[1].num)
+		if lo > hi {
+			return exprValue{}, fmt.Errorf("randInt min (%d) is greater than max (%d)", lo, hi)
+		}
+		return numberValue(float64(lo + rand.Int63n(hi-lo+1))), nil
+	case "min":
+		return numberValue(math.Min(args[0].num, args[1].num)), nil
+	case "max":
+		return numberValue(math.Max(args[0].num, args[1].num)), nil
+	case "abs":
+		return numberValue(math.Abs(args[0].num)), nil
+	default: // round
+		return numberValue(math.Round(args[0].num)), nil
+	}
+}
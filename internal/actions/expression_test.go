@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestInterpolateExpressions(t *testing.T) {
+	vars := NewVariableStore()
+	vars.Set("count", "4")
+	vars.Set("name", "Bot")
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"arithmetic", "{{ count * 2 + 1 }}", "9", false},
+		{"parentheses", "{{ (count + 2) / 4 }}", "1.5", false},
+		{"unary minus", "{{ -count % 3 }}", "-1", false},
+		{"concat", "{{ name + '_' + count }}", "Bot_4", false},
+		{"embedded", "wait {{ count * 100 }}ms", "wait 400ms", false},
+		{"with ${} variable", "{{ ${count} + 1 }}", "5", false},
+		{"functions", "{{ max(count, 10) - min(1, 2) + abs(-2) + round(1.6) }}", "13", false},
+		{"undefined variable", "{{ missing + 1 }}", "", true},
+		{"division by zero", "{{ count / 0 }}", "", true},
+		{"unknown function", "{{ foo(1) }}", "", true},
+		{"wrong arity", "{{ randInt(1) }}", "", true},
+		{"trailing tokens", "{{ 1 2 }}", "", true},
+		{"no expression", "plain text", "plain text", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateVariables(tt.input, vars)
+			if tt.wantErr {
+				var exprErr *ExpressionError
+				if !errors.As(err, &exprErr) {
+					t.Fatalf("expected ExpressionError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRandIntRange(t *testing.T) {
+	vars := NewVariableStore()
+	for i := 0; i < 100; i++ {
+		got, err := EvaluateExpression("randInt(800, 1200)", vars)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		n, err := strconv.Atoi(got)
+		if err != nil || n < 800 || n > 1200 {
+			t.Fatalf("randInt out of range: %q", got)
+		}
+	}
+}
+
+func TestStepErrorNamesStep(t *testing.T) {
+	step := &Step{name: "Sleep"}
+	_, exprErr := EvaluateExpression("missing", NewVariableStore())
+
+	err := stepError(step, exprErr)
+	if err == nil || !strings.Contains(err.Error(), "step 'Sleep'") || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected error naming step and variable, got %v", err)
+	}
+}
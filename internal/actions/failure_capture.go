@@ -0,0 +1,191 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// FailureCaptureConfig controls automatic screenshots when a routine step fails
+type FailureCaptureConfig struct {
+	Enabled        bool
+	Dir            string         // Root diagnostics folder; screenshots go in <Dir>/instance_<n>
+	MaxPerInstance int            // Oldest screenshots are deleted beyond this count (0 = keep all)
+	Image          cv.SaveOptions // Screenshot format and compression (empty format = PNG)
+}
+
+// duplicateCaptureWindow is how long an error bubbling up through nested builders
+// is recognized as already captured
+const duplicateCaptureWindow = 5 * time.Second
+
+// FailureCapture saves the current frame when a step errors or a sentry fails,
+// keeping a ring buffer of the most recent screenshots per instance
+type FailureCapture struct {
+	mu       sync.Mutex
+	instance int
+	config   FailureCaptureConfig
+	lastErr  error     // Last error captured, so nested builders don't capture it again
+	lastAt   time.Time // When lastErr was captured
+}
+
+// NewFailureCapture creates a failure capture for an instance
+func NewFailureCapture(instance int, config FailureCaptureConfig) *FailureCapture {
+	return &FailureCapture{
+		instance: instance,
+		config:   config,
+	}
+}
+
+// SetConfig replaces the capture settings
+func (fc *FailureCapture) SetConfig(config FailureCaptureConfig) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.config = config
+}
+
+// Config returns the capture settings
+func (fc *FailureCapture) Config() FailureCaptureConfig {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.config
+}
+
+// Dir returns the folder screenshots for this instance are written to
+func (fc *FailureCapture) Dir() string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.instanceDir()
+}
+
+func (fc *FailureCapture) instanceDir() string {
+	return filepath.Join(fc.config.Dir, fmt.Sprintf("instance_%d", fc.instance))
+}
+
+// Capture saves the current frame for a failed step and returns the file path.
+// Returns "" without error when capture is disabled, the error was already
+// captured by an inner step, or the failure was a stop/cancellation.
+func (fc *FailureCapture) Capture(bot BotInterface, stepName string, reason error) (string, error) {
+	if fc == nil || reason == nil || isStopError(reason) {
+		return "", nil
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if !fc.config.Enabled || fc.config.Dir == "" {
+		return "", nil
+	}
+	if fc.lastErr != nil && time.Since(fc.lastAt) < duplicateCaptureWindow && errors.Is(reason, fc.lastErr) {
+		return "", nil
+	}
+	fc.lastErr = reason
+	fc.lastAt = time.Now()
+
+	if bot.CV() == nil {
+		return "", fmt.Errorf("no CV service to capture from")
+	}
+	frame, err := bot.CV().CaptureFrame(false)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture frame: %w", err)
+	}
+
+	dir := fc.instanceDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create diagnostics folder: %w", err)
+	}
+
+	format := fc.config.Image.Format
+	if format == "" {
+		format = cv.ImageFormatPNG
+	}
+	filename := fmt.Sprintf("%s_%s%s", time.Now().Format("20060102_150405.000"), sanitizeStepName(stepName), format.Extension())
+	path := filepath.Join(dir, filename)
+	if err := cv.SaveImage(frame, path, fc.config.Image); err != nil {
+		return "", err
+	}
+
+	fc.prune(dir)
+	return path, nil
+}
+
+// prune deletes the oldest screenshots beyond MaxPerInstance.
+// Filenames start with a timestamp, so name order is capture order.
+func (fc *FailureCapture) prune(dir string) {
+	if fc.config.MaxPerInstance <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isScreenshotFile(entry.Name()) {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+
+	for len(files) > fc.config.MaxPerInstance {
+		os.Remove(filepath.Join(dir, files[0]))
+		files = files[1:]
+	}
+}
+
+var unsafeStepNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// sanitizeStepName makes a step name safe to use in a filename
+func sanitizeStepName(name string) string {
+	name = strings.Trim(unsafeStepNameChars.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return "step"
+	}
+	return name
+}
+
+// isStopError reports whether err comes from stopping the routine rather than a real failure
+func isStopError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, errRoutineStopped)
+}
+
+// captureFailure saves a screenshot for a failed step if the bot supports it and logs the path
+func captureFailure(bot BotInterface, stepName string, reason error) {
+	type failureCaptureProvider interface {
+		FailureCapture() *FailureCapture
+	}
+
+	provider, ok := bot.(failureCaptureProvider)
+	if !ok {
+		return
+	}
+
+	path, err := provider.FailureCapture().Capture(bot, stepName, reason)
+	if err != nil {
+		fmt.Printf("Bot %d: Failed to save failure screenshot for '%s': %v\n", bot.Instance(), stepName, err)
+		return
+	}
+	if path != "" {
+		fmt.Printf("Bot %d: Step '%s' failed (%v), screenshot saved to %s\n", bot.Instance(), stepName, reason, path)
+	}
+}
+
+// isScreenshotFile reports whether a diagnostics file is a screenshot (in any saved format),
+// so switching formats still prunes older captures
+func isScreenshotFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png", ".jpg", ".jpeg":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFailureCapturePrunesOldest(t *testing.T) {
+	fc := NewFailureCapture(3, FailureCaptureConfig{Enabled: true, Dir: t.TempDir(), MaxPerInstance: 2})
+	dir := fc.Dir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"20250101_100000.000_a.png", "20250101_100001.000_b.png", "20250101_100002.000_c.png"}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fc.prune(dir)
+
+	if _, err := os.Stat(filepath.Join(dir, names[0])); !os.IsNotExist(err) {
+		t.Errorf("oldest screenshot should be deleted")
+	}
+	for _, name := range names[1:] {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should be kept: %v", name, err)
+		}
+	}
+}
+
+func TestFailureCaptureSkipsStopsAndDisabled(t *testing.T) {
+	fc := NewFailureCapture(1, FailureCaptureConfig{Enabled: false, Dir: t.TempDir()})
+
+	// Neither call touches the bot, so nil is safe
+	if path, err := fc.Capture(nil, "Click", errors.New("boom")); path != "" || err != nil {
+		t.Errorf("disabled capture returned (%q, %v)", path, err)
+	}
+
+	fc.SetConfig(FailureCaptureConfig{Enabled: true, Dir: t.TempDir()})
+	for _, reason := range []error{context.Canceled, fmt.Errorf("%w during loop", errRoutineStopped)} {
+		if path, err := fc.Capture(nil, "Click", reason); path != "" || err != nil {
+			t.Errorf("stop error %v returned (%q, %v)", reason, path, err)
+		}
+	}
+}
+
+func TestSanitizeStepName(t *testing.T) {
+	cases := map[string]string{
+		"Click":             "Click",
+		"WaitForImage(ok)":  "WaitForImage_ok",
+		"sentry_popup/home": "sentry_popup_home",
+		"???":               "step",
+	}
+	for in, want := range cases {
+		if got := sanitizeStepName(in); got != want {
+			t.Errorf("sanitizeStepName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+)
+
+// ReportGodPack reports that the current account pulled a god pack.
+// Orchestrated bots publish a god pack event (used for notifications); other managers just log it.
+type ReportGodPack struct {
+	PackName string `yaml:"pack_name,omitempty"` // Optional pack name (supports variable interpolation)
+}
+
+func (a *ReportGodPack) Validate(ab *ActionBuilder) error {
+	return nil
+}
+
+func (a *ReportGodPack) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: "ReportGodPack",
+		execute: func(botIf BotInterface) error {
+			account, ok := botIf.GetCurrentAccount().(*accountpool.Account)
+			if !ok || account == nil {
+				return fmt.Errorf("no current account assigned to bot")
+			}
+
+			packName, err := InterpolateString(a.PackName, botIf)
+			if err != nil {
+				return fmt.Errorf("failed to interpolate pack name: %w", err)
+			}
+
+			reporter, ok := botIf.Manager().(interface {
+				ReportGodPack(instanceID int, account *accountpool.Account, packName string)
+			})
+			if !ok {
+				fmt.Printf("Bot %d: God pack found on account '%s' (manager does not publish events)\n", botIf.Instance(), account.ID)
+				return nil
+			}
+
+			reporter.ReportGodPack(botIf.Instance(), account, packName)
+			return nil
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"image"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Variables that override the global humanizer settings for a routine.
+// Declare them as config params to make them editable per bot in the launcher.
+const (
+	HumanizeClickRadiusVar = "humanize_click_radius" // Max click offset in pixels
+	HumanizeDelayJitterVar = "humanize_delay_jitter" // Max delay change in percent
+)
+
+// HumanizerConfig holds global humanization settings
+type HumanizerConfig struct {
+	ClickRadius        int   // Max random click offset in pixels (0 = disabled)
+	DelayJitterPercent int   // Max random delay change in percent, +/- (0 = disabled)
+	Seed               int64 // Random seed (0 = seeded from the clock)
+}
+
+// Humanizer adds random offsets to clicks and random jitter to delays.
+// Per-routine overrides are read from the bot's variable store on every call.
+type Humanizer struct {
+	mu     sync.Mutex
+	rng    *rand.Rand
+	config HumanizerConfig
+	vars   VariableStoreInterface
+	bounds image.Rectangle // Valid click area in source coordinates (empty = unbounded)
+}
+
+// NewHumanizer creates a humanizer; vars may be nil to disable per-routine overrides
+func NewHumanizer(config HumanizerConfig, vars VariableStoreInterface) *Humanizer {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Humanizer{
+		rng:    rand.New(rand.NewSource(seed)),
+		config: config,
+		vars:   vars,
+	}
+}
+
+// SetConfig replaces the global settings (the random sequence is kept unless the seed changes)
+func (h *Humanizer) SetConfig(config HumanizerConfig) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if config.Seed != 0 && config.Seed != h.config.Seed {
+		h.rng = rand.New(rand.NewSource(config.Seed))
+	}
+	h.config = config
+}
+
+// Config returns the global settings
+func (h *Humanizer) Config() HumanizerConfig {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.config
+}
+
+// SetBounds sets the area jittered clicks are clamped to
+func (h *Humanizer) SetBounds(bounds image.Rectangle) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.bounds = bounds
+}
+
+// JitterPoint returns a random point within the click radius of (x, y).
+// maxRadius > 0 caps the radius so the click stays on a small target.
+// The result is clamped to the configured bounds.
+func (h *Humanizer) JitterPoint(x, y, maxRadius int) (int, int) {
+	radius := h.overrideInt(HumanizeClickRadiusVar, h.Config().ClickRadius)
+	if maxRadius > 0 && maxRadius < radius {
+		radius = maxRadius
+	}
+	if radius <= 0 {
+		return x, y
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Uniform point in a disk
+	angle := h.rng.Float64() * 2 * math.Pi
+	distance := float64(radius) * math.Sqrt(h.rng.Float64())
+	jx := x + int(math.Round(distance*math.Cos(angle)))
+	jy := y + int(math.Round(distance*math.Sin(angle)))
+
+	if !h.bounds.Empty() {
+		jx = clampInt(jx, h.bounds.Min.X, h.bounds.Max.X-1)
+		jy = clampInt(jy, h.bounds.Min.Y, h.bounds.Max.Y-1)
+	}
+
+	return jx, jy
+}
+
+// JitterDelay returns d changed by a random amount within +/- the jitter percentage
+func (h *Humanizer) JitterDelay(d time.Duration) time.Duration {
+	percent := h.overrideInt(HumanizeDelayJitterVar, h.Config().DelayJitterPercent)
+	if percent <= 0 || d <= 0 {
+		return d
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	h.mu.Lock()
+	factor := (h.rng.Float64()*2 - 1) * float64(percent) / 100
+	h.mu.Unlock()
+
+	return time.Duration(float64(d) * (1 + factor))
+}
+
+// overrideInt reads a per-routine override from the variable store, falling back to def
+func (h *Humanizer) overrideInt(name string, def int) int {
+	if h.vars == nil {
+		return def
+	}
+	value, ok := h.vars.Get(name)
+	if !ok || value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// humanizedSleep sleeps for d after applying the bot's delay jitter
+func humanizedSleep(bot BotInterface, d time.Duration) {
+	if h := bot.Humanizer(); h != nil {
+		d = h.JitterDelay(d)
+	}
+	time.Sleep(d)
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestHumanizerSeedIsDeterministic(t *testing.T) {
+	config := HumanizerConfig{ClickRadius: 10, DelayJitterPercent: 20, Seed: 42}
+	a := NewHumanizer(config, nil)
+	b := NewHumanizer(config, nil)
+
+	for i := 0; i < 20; i++ {
+		ax, ay := a.JitterPoint(100, 200, 0)
+		bx, by := b.JitterPoint(100, 200, 0)
+		if ax != bx || ay != by {
+			t.Fatalf("iteration %d: points differ (%d,%d) vs (%d,%d)", i, ax, ay, bx, by)
+		}
+		if a.JitterDelay(time.Second) != b.JitterDelay(time.Second) {
+			t.Fatalf("iteration %d: delays differ", i)
+		}
+	}
+}
+
+func TestHumanizerLimits(t *testing.T) {
+	h := NewHumanizer(HumanizerConfig{ClickRadius: 10, DelayJitterPercent: 20, Seed: 7}, nil)
+	h.SetBounds(image.Rect(0, 0, 105, 300))
+
+	for i := 0; i < 200; i++ {
+		x, y := h.JitterPoint(100, 150, 0)
+		dx, dy := x-100, y-150
+		if dx*dx+dy*dy > 11*11 {
+			t.Fatalf("point (%d,%d) outside radius", x, y)
+		}
+		if x > 104 {
+			t.Fatalf("point (%d,%d) not clamped to bounds", x, y)
+		}
+
+		x, y = h.JitterPoint(50, 150, 2)
+		if dx, dy := x-50, y-150; dx*dx+dy*dy > 3*3 {
+			t.Fatalf("point (%d,%d) outside target radius", x, y)
+		}
+
+		d := h.JitterDelay(time.Second)
+		if d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("delay %v outside 20%% jitter", d)
+		}
+	}
+}
+
+func TestHumanizerVariableOverrides(t *testing.T) {
+	vars := NewVariableStore()
+	h := NewHumanizer(HumanizerConfig{ClickRadius: 10, DelayJitterPercent: 20, Seed: 1}, vars)
+
+	vars.Set(HumanizeClickRadiusVar, "0")
+	vars.Set(HumanizeDelayJitterVar, "0")
+
+	if x, y := h.JitterPoint(100, 200, 0); x != 100 || y != 200 {
+		t.Errorf("expected exact click with radius override 0, got (%d,%d)", x, y)
+	}
+	if d := h.JitterDelay(time.Second); d != time.Second {
+		t.Errorf("expected exact delay with jitter override 0, got %v", d)
+	}
+}
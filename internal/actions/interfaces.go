@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	"jordanella.com/pocket-tcg-go/internal/adb"
 	"jordanella.com/pocket-tcg-go/internal/cv"
@@ -33,6 +34,7 @@ type BotInterface interface {
 	RoutineController() RoutineControllerInterface
 	Variables() VariableStoreInterface
 	SentryManager() *SentryManager
+	Humanizer() *Humanizer
 
 	// Context management
 	Context() context.Context
@@ -97,4 +99,13 @@ type VariableStoreInterface interface {
 	Delete(name string)
 	Clear()
 	GetAll() map[string]string
+
+	// Persistent variables survive the clear at the start of each routine iteration
+	SetPersistent(name string, value string)
+
+	// Typed getters return false if the variable is missing or malformed
+	GetInt(name string) (int, bool)
+	GetFloat(name string) (float64, bool)
+	GetBool(name string) (bool, bool)
+	GetDuration(name string) (time.Duration, bool)
 }
@@ -23,6 +23,12 @@ func (a *KillApp) Build(ab *ActionBuilder) *ActionBuilder {
 	step := Step{
 		name: fmt.Sprintf("KillApp (%s)", packageName),
 		execute: func(bot BotInterface) error {
+			// Without an override, stop the bot's configured game package
+			if a.Package == "" {
+				if game, ok := bot.(interface{ StopGame() error }); ok {
+					return game.StopGame()
+				}
+			}
 			return bot.ADB().ForceStop(packageName)
 		},
 		issue: a.Validate(ab),
@@ -17,6 +17,15 @@ const (
 	defaultPocketTCGActivity = "jp.pokemon.pokemontcgp.startup.MainActivity"
 )
 
+// gamePackage returns the bot's configured game package, or Pokemon TCG Pocket's for bots
+// without one
+func gamePackage(bot BotInterface) string {
+	if game, ok := bot.(interface{ GamePackage() string }); ok && game.GamePackage() != "" {
+		return game.GamePackage()
+	}
+	return defaultPocketTCGPackage
+}
+
 func (a *LaunchApp) Validate(ab *ActionBuilder) error {
 	// Validation is optional - defaults will be used if not specified
 	return nil
@@ -37,6 +46,12 @@ func (a *LaunchApp) Build(ab *ActionBuilder) *ActionBuilder {
 	step := Step{
 		name: fmt.Sprintf("LaunchApp (%s)", packageName),
 		execute: func(bot BotInterface) error {
+			// Without overrides, launch the bot's configured game package
+			if a.Package == "" && a.Activity == "" {
+				if game, ok := bot.(interface{ LaunchGame() error }); ok {
+					return game.LaunchGame()
+				}
+			}
 			return bot.ADB().StartApp(packageName, activity)
 		},
 		issue: a.Validate(ab),
@@ -0,0 +1,129 @@
+package actions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// LoopDetectedError reports a routine cycling through the same steps without making progress
+type LoopDetectedError struct {
+	Step   string   // Step that ran more often than the threshold
+	Visits int      // Times it ran since the routine last reached a new step
+	Cycle  []string // Steps run since the routine last reached a new step, in order of first run
+}
+
+func (e *LoopDetectedError) Error() string {
+	return fmt.Sprintf("loop detected: step '%s' ran %d times without the routine reaching a new step (cycle: %s)",
+		e.Step, e.Visits, strings.Join(e.Cycle, " -> "))
+}
+
+// stepKey identifies a step across runs of the builder it's in: nested builders are rebuilt
+// from the same actions each time they run
+type stepKey struct {
+	action uintptr
+	name   string
+}
+
+func stepKeyOf(step *Step) stepKey {
+	key := stepKey{name: step.name}
+	if step.action != nil {
+		if value := reflect.ValueOf(step.action); value.Kind() == reflect.Ptr {
+			key.action = value.Pointer()
+		}
+	}
+	return key
+}
+
+// loopDetector counts step visits during a routine run. Visits reset whenever a step runs
+// for the first time in the run, so only steps the routine keeps cycling through add up.
+type loopDetector struct {
+	mu        sync.Mutex
+	threshold int // 0 = not detecting (outside RoutineExecutor runs)
+	exempt    int // Running allow_loop steps; steps run inside them aren't counted
+	seen      map[stepKey]bool
+	visits    map[stepKey]int
+	cycle     []string
+}
+
+// start begins detection for a routine run
+func (d *loopDetector) start(threshold int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.threshold = threshold
+	d.exempt = 0
+	d.seen = make(map[stepKey]bool)
+	d.visits = make(map[stepKey]int)
+	d.cycle = nil
+}
+
+// stop ends detection when the run finishes
+func (d *loopDetector) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.threshold = 0
+	d.seen = nil
+	d.visits = nil
+	d.cycle = nil
+}
+
+// visit counts a step about to run, returning LoopDetectedError once it ran more often than
+// the threshold since the routine last reached a new step
+func (d *loopDetector) visit(step *Step) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.threshold <= 0 || d.exempt > 0 {
+		return nil
+	}
+
+	key := stepKeyOf(step)
+	if !d.seen[key] {
+		d.seen[key] = true
+		d.visits = make(map[stepKey]int)
+		d.cycle = nil
+	}
+
+	d.visits[key]++
+	if d.visits[key] == 1 {
+		d.cycle = append(d.cycle, step.name)
+	}
+	if d.visits[key] > d.threshold {
+		return &LoopDetectedError{Step: step.name, Visits: d.visits[key], Cycle: append([]string(nil), d.cycle...)}
+	}
+	return nil
+}
+
+// exemptWhile stops counting while an allow_loop step runs; call the returned func when it's done
+func (d *loopDetector) exemptWhile() func() {
+	d.mu.Lock()
+	d.exempt++
+	d.mu.Unlock()
+
+	return func() {
+		d.mu.Lock()
+		d.exempt--
+		d.mu.Unlock()
+	}
+}
+
+// visitStep counts a step for the bot's loop detection (see RoutineExecutor.WithLoopThreshold).
+// Sentry steps, nested ones included, aren't counted, since sentries poll by design. Steps that opted out
+// with allow_loop return a func that ends their exemption once they finish.
+func (ab *ActionBuilder) visitStep(bot BotInterface, step *Step) (func(), error) {
+	stats := runStatsOf(bot)
+	if ab.isSentryExecution || step.sentry || stats == nil {
+		return func() {}, nil
+	}
+
+	if err := stats.loops.visit(step); err != nil {
+		return nil, err
+	}
+	if step.allowLoop {
+		return stats.loops.exemptWhile(), nil
+	}
+	return func() {}, nil
+}
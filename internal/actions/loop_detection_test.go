@@ -0,0 +1,101 @@
+package actions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRoutineExecutorDetectsLoops(t *testing.T) {
+	bot := resultBot{stats: NewRunStats(), variables: NewVariableStore()}
+
+	// Two steps sending each other back and forth forever
+	cycling := func(allowLoop bool) *ActionBuilder {
+		inner := NewActionBuilder()
+		inner.steps = append(inner.steps,
+			Step{name: "OpenMenu", execute: func(BotInterface) error { return nil }},
+			Step{name: "CloseMenu", execute: func(BotInterface) error { return nil }},
+		)
+		runs := 0
+		routine := NewActionBuilder()
+		routine.steps = append(routine.steps,
+			Step{name: "Start", execute: func(BotInterface) error { return nil }},
+			Step{name: "Poll", allowLoop: allowLoop, execute: func(b BotInterface) error {
+				for runs < 20 {
+					runs++
+					if err := inner.executeSteps(b.Context(), b); err != nil {
+						return err
+					}
+				}
+				return nil
+			}},
+		)
+		return routine
+	}
+
+	_, err := NewRoutineExecutor(cycling(false), nil).WithLoopThreshold(5).ExecuteWithResult(bot)
+	var loop *LoopDetectedError
+	if !errors.As(err, &loop) {
+		t.Fatalf("expected LoopDetectedError, got %v", err)
+	}
+	// The cycle starts at the last step the routine reached for the first time
+	if loop.Step != "CloseMenu" || loop.Visits != 6 || !reflect.DeepEqual(loop.Cycle, []string{"CloseMenu", "OpenMenu"}) {
+		t.Errorf("loop = %+v, want CloseMenu run 6 times in CloseMenu -> OpenMenu", loop)
+	}
+
+	// Polling steps can opt out, and detection can be turned off
+	if _, err := NewRoutineExecutor(cycling(true), nil).WithLoopThreshold(5).ExecuteWithResult(bot); err != nil {
+		t.Errorf("allow_loop step: %v", err)
+	}
+	if _, err := NewRoutineExecutor(cycling(false), nil).WithLoopThreshold(0).ExecuteWithResult(bot); err != nil {
+		t.Errorf("detection off: %v", err)
+	}
+	if _, err := NewRoutineExecutor(cycling(false), nil).ExecuteWithResult(bot); err != nil {
+		t.Errorf("detection is off unless the routine sets loop_threshold: %v", err)
+	}
+
+	// The routine's loop_threshold turns detection on
+	routine := cycling(false)
+	routine.loopThreshold = 5
+	if _, err := NewRoutineExecutor(routine, nil).ExecuteWithResult(bot); !errors.As(err, &loop) {
+		t.Errorf("loop_threshold: expected LoopDetectedError, got %v", err)
+	}
+}
+
+func TestLoopDetectionSkipsBoundedLoopsAndSentries(t *testing.T) {
+	bot := resultBot{stats: NewRunStats(), variables: NewVariableStore()}
+
+	// A Repeat runs its steps as often as it was told to
+	routine := NewActionBuilder()
+	(&Repeat{Iterations: 20, Actions: []ActionStep{&SetVariable{Name: "x", Value: "1"}}}).Build(routine)
+	if _, err := NewRoutineExecutor(routine, nil).WithLoopThreshold(5).ExecuteWithResult(bot); err != nil {
+		t.Errorf("Repeat: %v", err)
+	}
+
+	// Steps nested in a sentry routine don't count against the main routine's run
+	sentry := NewActionBuilder().AsSentryExecution()
+	nested := sentry.buildSteps([]ActionStep{&SetVariable{Name: "y", Value: "1"}})
+	bot.stats.loops.start(5)
+	defer bot.stats.loops.stop()
+	for i := 0; i < 20; i++ {
+		if err := (&ActionBuilder{steps: nested}).executeSteps(bot.Context(), bot); err != nil {
+			t.Fatalf("sentry run %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestRoutineLoopThresholdYAML(t *testing.T) {
+	var routine Routine
+	if err := yaml.Unmarshal([]byte("routine_name: test\nloop_threshold: 50\nsteps: []\n"), &routine); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if routine.LoopThreshold != 50 {
+		t.Errorf("LoopThreshold = %d, want 50", routine.LoopThreshold)
+	}
+
+	if err := yaml.Unmarshal([]byte("routine_name: test\nloop_threshold: -1\nsteps: []\n"), &routine); err == nil {
+		t.Error("expected a negative loop_threshold to be rejected")
+	}
+}
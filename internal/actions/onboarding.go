@@ -0,0 +1,226 @@
+package actions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// OnboardingScreen is a fresh-account setup screen CompleteOnboarding knows how to get past
+type OnboardingScreen struct {
+	StartupDialog `yaml:",inline"`
+	Text          string `yaml:"text,omitempty"` // Text to type before tapping, e.g. a player name (supports variables)
+}
+
+// CompleteOnboarding walks a freshly injected account through the game's first-run setup
+// (tutorial, country and birthday, terms, player name). It taps through the configured
+// screens until the until template is on screen; an account that reaches it without showing
+// any screen was already onboarded. The account's friend code is then read from the game's
+// save data into a variable and recorded on the account's database row.
+type CompleteOnboarding struct {
+	Screens       []OnboardingScreen `yaml:"screens"`
+	Until         string             `yaml:"until"`                     // Template that means onboarding is done (e.g. Home)
+	MaxWait       int                `yaml:"max_wait,omitempty"`        // Seconds before giving up (default: 300)
+	FriendCodeVar string             `yaml:"friend_code_var,omitempty"` // Variable receiving the friend code (default: friend_code)
+}
+
+const (
+	defaultOnboardingMaxWait       = 300
+	defaultOnboardingFriendCodeVar = "friend_code"
+)
+
+func (a *CompleteOnboarding) Validate(ab *ActionBuilder) error {
+	if len(a.Screens) == 0 {
+		return fmt.Errorf("at least one screen is required")
+	}
+	if a.Until == "" {
+		return fmt.Errorf("until is required")
+	}
+	if a.MaxWait < 0 {
+		return fmt.Errorf("max_wait must be non-negative")
+	}
+
+	templateNames := []string{a.Until}
+	for i, screen := range a.Screens {
+		if screen.Template == "" {
+			return fmt.Errorf("screen %d: template is required", i+1)
+		}
+		if screen.Button != "" && screen.Point != nil {
+			return fmt.Errorf("screen %d: cannot specify both 'button' and 'point'", i+1)
+		}
+		templateNames = append(templateNames, screen.Template)
+		if screen.Button != "" {
+			templateNames = append(templateNames, screen.Button)
+		}
+	}
+
+	// Validate templates exist in registry (if registry is available)
+	if ab.templateRegistry != nil {
+		for _, name := range templateNames {
+			if !ab.templateRegistry.Has(name) {
+				return fmt.Errorf("template '%s' not found in registry", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *CompleteOnboarding) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("CompleteOnboarding (%d screens)", len(a.Screens)),
+		execute: func(bot BotInterface) error {
+			maxWait := a.MaxWait
+			if maxWait == 0 {
+				maxWait = defaultOnboardingMaxWait
+			}
+			deadline := time.Now().Add(time.Duration(maxWait) * time.Second)
+			completed := 0
+
+			for {
+				if bot.IsStopped() {
+					return fmt.Errorf("bot stopped")
+				}
+
+				// One fresh screenshot per poll
+				bot.CV().InvalidateCache()
+
+				done, err := findStartupTemplate(bot, a.Until)
+				if err != nil {
+					return err
+				}
+				if done != nil {
+					if completed == 0 {
+						fmt.Printf("Bot %d: Account already onboarded ('%s' on screen)\n", bot.Instance(), a.Until)
+					} else {
+						fmt.Printf("Bot %d: Onboarding finished (%d screen(s) completed)\n", bot.Instance(), completed)
+					}
+					a.recordFriendCode(bot)
+					return nil
+				}
+
+				screen, err := a.completeNext(bot)
+				if err != nil {
+					return err
+				}
+				if screen != nil {
+					completed++
+					fmt.Printf("Bot %d: Completed onboarding screen '%s'\n", bot.Instance(), screen.label())
+				}
+
+				if time.Now().After(deadline) {
+					return fmt.Errorf("'%s' not found within %ds (%d onboarding screen(s) completed)", a.Until, maxWait, completed)
+				}
+
+				select {
+				case <-bot.Context().Done():
+					return bot.Context().Err()
+				case <-time.After(startupDialogPollInterval):
+				}
+			}
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// completeNext gets past the first configured screen that is showing. Returns nil if none is.
+func (a *CompleteOnboarding) completeNext(bot BotInterface) (*OnboardingScreen, error) {
+	for i := range a.Screens {
+		screen := &a.Screens[i]
+
+		match, err := findStartupTemplate(bot, screen.Template)
+		if err != nil {
+			return nil, err
+		}
+		if match == nil {
+			continue
+		}
+
+		if screen.Text != "" {
+			text, err := InterpolateString(screen.Text, bot)
+			if err != nil {
+				return nil, fmt.Errorf("screen '%s': failed to interpolate text: %w", screen.label(), err)
+			}
+			if err := bot.ADB().Input(text); err != nil {
+				return nil, fmt.Errorf("screen '%s': failed to type text: %w", screen.label(), err)
+			}
+		}
+
+		tapped, err := screen.dismiss(bot, match)
+		if err != nil {
+			return nil, err
+		}
+		if !tapped {
+			continue
+		}
+		return screen, nil
+	}
+	return nil, nil
+}
+
+// recordFriendCode reads the account's friend code from the game's save data, stores it in
+// the friend code variable and on the injected account's row in the shared accounts database
+// (except for sandbox pools). Failures are logged rather than failing the routine, since
+// onboarding itself succeeded.
+func (a *CompleteOnboarding) recordFriendCode(bot BotInterface) {
+	prefs, err := bot.ADB().Shell(fmt.Sprintf("su -c 'cat /data/data/%s/shared_prefs/*.xml'", gamePackage(bot)))
+	if err != nil {
+		fmt.Printf("Bot %d: Warning - failed to read game preferences for friend code: %v\n", bot.Instance(), err)
+		return
+	}
+
+	friendCode := friendCodeFromPrefs(prefs)
+	if friendCode == "" {
+		fmt.Printf("Bot %d: Warning - no friend code found in game preferences\n", bot.Instance())
+		return
+	}
+
+	variable := a.FriendCodeVar
+	if variable == "" {
+		variable = defaultOnboardingFriendCodeVar
+	}
+	bot.Variables().Set(variable, friendCode)
+	fmt.Printf("Bot %d: Friend code is %s\n", bot.Instance(), friendCode)
+
+	accountID, hasAccount := bot.Variables().GetInt(VarDeviceAccountID)
+	if !hasAccount {
+		return
+	}
+	db := accountsDB(bot)
+	if db == nil {
+		return
+	}
+	if err := database.UpdateAccountFriendCode(db, int64(accountID), friendCode); err != nil {
+		fmt.Printf("Bot %d: Warning - failed to record friend code: %v\n", bot.Instance(), err)
+	}
+}
+
+// Preference keys holding the friend code, compared lowercased without separators
+var (
+	prefsStringPattern    = regexp.MustCompile(`<string name="([^"]+)">([^<]*)</string>`)
+	friendCodePrefsKeys   = map[string]bool{"friendcode": true, "friendid": true, "playerfriendid": true}
+	friendCodeDigitsCount = 16
+)
+
+// friendCodeFromPrefs finds the friend code in the game's preference XML, returning its
+// 16 digits without separators ("" if none is found)
+func friendCodeFromPrefs(prefs string) string {
+	for _, match := range prefsStringPattern.FindAllStringSubmatch(prefs, -1) {
+		key := strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(match[1]))
+		if !friendCodePrefsKeys[key] {
+			continue
+		}
+
+		digits := strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(match[2]))
+		if len(digits) != friendCodeDigitsCount || strings.Trim(digits, "0123456789") != "" {
+			continue
+		}
+		return digits
+	}
+	return ""
+}
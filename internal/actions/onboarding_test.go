@@ -0,0 +1,18 @@
+package actions
+
+import "testing"
+
+func TestFriendCodeFromPrefs(t *testing.T) {
+	cases := map[string]string{
+		`<map><string name="playerName">Ash</string><string name="friend_code">1234-5678-9012-3456</string></map>`: "1234567890123456",
+		`<map><string name="FriendId">6543210987654321</string></map>`:                                             "6543210987654321",
+		`<map><string name="friendCode">12345</string></map>`:                                                      "",
+		`<map><string name="playerName">1234567890123456</string></map>`:                                           "",
+	}
+
+	for prefs, want := range cases {
+		if got := friendCodeFromPrefs(prefs); got != want {
+			t.Errorf("friendCodeFromPrefs(%s) = %q, want %q", prefs, got, want)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import "reflect"
 // 2. Add it to this registry with the name that will be used in YAML files
 var actionRegistry = map[string]reflect.Type{
 	"click":                reflect.TypeOf(Click{}),
+	"click_and_wait":       reflect.TypeOf(ClickAndWait{}),
 	"swipe":                reflect.TypeOf(Swipe{}),
 	"input":                reflect.TypeOf(Input{}),
 	"send_key":             reflect.TypeOf(SendKey{}),
@@ -31,11 +32,14 @@ var actionRegistry = map[string]reflect.Type{
 	"ifallimagesfound":     reflect.TypeOf(IfAllImagesFound{}),
 	"ifnoimagesfound":      reflect.TypeOf(IfNoImagesFound{}),
 	"runroutine":           reflect.TypeOf(RunRoutine{}),
+	"detect_color":         reflect.TypeOf(DetectColor{}),
 	// Generic control flow with conditions
 	"if":    reflect.TypeOf(If{}),
 	"while": reflect.TypeOf(While{}),
 	"until": reflect.TypeOf(Until{}),
 	"break": reflect.TypeOf(Break{}),
+	// Error reporting (runs the recovery registered for the error type)
+	"reporterror": reflect.TypeOf(ReportError{}),
 	// Variable actions
 	"setvariable": reflect.TypeOf(SetVariable{}),
 	"getvariable": reflect.TypeOf(GetVariable{}),
@@ -46,15 +50,24 @@ var actionRegistry = map[string]reflect.Type{
 	"completeaccount":    reflect.TypeOf(CompleteAccount{}),
 	"returnaccount":      reflect.TypeOf(ReturnAccount{}),
 	"markaccountfailed":  reflect.TypeOf(MarkAccountFailed{}),
+	"checkpacklimit":     reflect.TypeOf(CheckPackLimit{}),
+	"reportgodpack":      reflect.TypeOf(ReportGodPack{}),
+	"wonder_pick":        reflect.TypeOf(WonderPick{}),
+	"claim_daily":        reflect.TypeOf(ClaimDaily{}),
+	"complete_missions":  reflect.TypeOf(CompleteMissions{}),
 	// Database actions
 	"updateaccountfield":    reflect.TypeOf(UpdateAccountField{}),
 	"incrementaccountfield": reflect.TypeOf(IncrementAccountField{}),
 	"updateroutinemetrics":  reflect.TypeOf(UpdateRoutineMetrics{}),
 	"getaccountfield":       reflect.TypeOf(GetAccountField{}),
+	"set_account_state":     reflect.TypeOf(SetAccountState{}),
+	"get_account_state":     reflect.TypeOf(GetAccountState{}),
 	// Sentry control actions
 	"sentryhalt":   reflect.TypeOf(SentryHalt{}),
 	"sentryresume": reflect.TypeOf(SentryResume{}),
 	// App management actions
-	"launchapp": reflect.TypeOf(LaunchApp{}),
-	"killapp":   reflect.TypeOf(KillApp{}),
+	"launchapp":             reflect.TypeOf(LaunchApp{}),
+	"killapp":               reflect.TypeOf(KillApp{}),
+	"dismissstartupdialogs": reflect.TypeOf(DismissStartupDialogs{}),
+	"completeonboarding":    reflect.TypeOf(CompleteOnboarding{}),
 }
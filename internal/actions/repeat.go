@@ -75,12 +75,13 @@ func (a *Repeat) Build(ab *ActionBuilder) *ActionBuilder {
 				}
 
 				if !ab.checkExecutionState(bot) {
-					return fmt.Errorf("routine stopped by controller during loop")
+					return fmt.Errorf("%w during loop", errRoutineStopped)
 				}
 			}
 			return nil
 		},
-		issue: a.Validate(ab),
+		issue:     a.Validate(ab),
+		allowLoop: true, // Bounded by its iterations, so never a runaway loop
 	}
 	ab.steps = append(ab.steps, step)
 	return ab
@@ -0,0 +1,42 @@
+package actions
+
+import (
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/recovery"
+)
+
+// ReportError fails with an error of a known type (see the recovery package), so the
+// recovery registered for that type runs before the routine fails. Routines and sentries
+// use it after detecting e.g. a popup or a stuck screen. Once the recovery succeeds the
+// routine carries on with the next step.
+type ReportError struct {
+	Type    string `yaml:"type"`    // Error type: popup, stuck, no_response, communication, timeout, ...
+	Message string `yaml:"message"` // Error message (supports variable interpolation)
+}
+
+func (a *ReportError) Validate(ab *ActionBuilder) error {
+	if _, err := recovery.Parse(a.Type); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *ReportError) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: fmt.Sprintf("ReportError (%s)", a.Type),
+		execute: func(bot BotInterface) error {
+			message := a.Message
+			if message == "" {
+				message = fmt.Sprintf("%s detected", a.Type)
+			} else if interpolated, err := InterpolateString(message, bot); err == nil {
+				message = interpolated
+			}
+			return recovery.New(recovery.ErrorType(a.Type), message)
+		},
+		issue:        a.Validate(ab),
+		reportsError: true,
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
@@ -13,22 +13,59 @@ import (
 
 // Routine holds the entire routine definition from the YAML file
 type Routine struct {
-	RoutineName string        `yaml:"routine_name"`
-	Description string        `yaml:"description,omitempty"` // Optional description of the routine's purpose
-	Tags        []string      `yaml:"tags,omitempty"`        // Optional tags for organization (e.g., "sentry", "navigation", "combat")
-	Config      []ConfigParam `yaml:"config,omitempty"`      // Optional user-configurable parameters
-	Steps       []ActionStep  `yaml:"steps"`                 // ActionStep is the interface you already defined
-	Sentries    []Sentry      `yaml:"sentries,omitempty"`    // Sentry definitions for error handling
+	RoutineName   string        `yaml:"routine_name"`
+	Description   string        `yaml:"description,omitempty"`    // Optional description of the routine's purpose
+	Tags          []string      `yaml:"tags,omitempty"`           // Optional tags for organization (e.g., "sentry", "navigation", "combat")
+	Config        []ConfigParam `yaml:"config,omitempty"`         // Optional user-configurable parameters
+	Steps         []ActionStep  `yaml:"steps"`                    // ActionStep is the interface you already defined
+	Sentries      []Sentry      `yaml:"sentries,omitempty"`       // Sentry definitions for error handling
+	TemplateDir   string        `yaml:"template_dir,omitempty"`   // Optional folder of the routine's own templates (relative to the routine file), checked before global templates
+	LoopThreshold int           `yaml:"loop_threshold,omitempty"` // Step runs without reaching a new step before the run fails with LoopDetectedError (0 = no loop detection)
 }
 
-// StepMetadata holds timeout configuration for a step
+// StepMetadata holds timeout, retry, loop detection and checkpoint configuration for a step
 type StepMetadata struct {
-	Timeout time.Duration // Timeout for the step (0 = no timeout)
+	Timeout    time.Duration // Timeout for the step (0 = no timeout)
+	Retry      StepRetry     // Retry policy for the step (zero = no retries)
+	AllowLoop  bool          // Opt out of loop detection, for steps that legitimately poll (allow_loop: true)
+	Checkpoint string        // Label of a checkpoint restarted runs may resume from (top-level steps only, see Checkpoint)
+}
+
+// StepRetry retries a failed step in place before the routine fails.
+// In YAML: retry: {max_attempts: 3, delay: 500} (delay in milliseconds).
+type StepRetry struct {
+	MaxAttempts int           // Attempts including the first (<= 1 = no retries)
+	Delay       time.Duration // Wait between attempts
 }
 
 // HasMetadata returns true if any metadata is set
 func (sm StepMetadata) HasMetadata() bool {
-	return sm.Timeout > 0
+	return sm.Timeout > 0 || sm.Retry.MaxAttempts > 1 || sm.AllowLoop || sm.Checkpoint != ""
+}
+
+// parseStepRetry reads a step's retry policy from its raw YAML map
+func parseStepRetry(raw interface{}) (StepRetry, error) {
+	retryMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return StepRetry{}, fmt.Errorf("'retry' must be a map with max_attempts and delay")
+	}
+
+	var retry StepRetry
+	if val, exists := retryMap["max_attempts"]; exists {
+		attempts, ok := val.(int)
+		if !ok || attempts < 1 {
+			return StepRetry{}, fmt.Errorf("retry max_attempts must be a positive integer")
+		}
+		retry.MaxAttempts = attempts
+	}
+	if val, exists := retryMap["delay"]; exists {
+		delayMs, ok := val.(int)
+		if !ok || delayMs < 0 {
+			return StepRetry{}, fmt.Errorf("retry delay must be a non-negative number of milliseconds")
+		}
+		retry.Delay = time.Duration(delayMs) * time.Millisecond
+	}
+	return retry, nil
 }
 
 // ActionWithMetadata wraps an ActionStep with execution metadata
@@ -45,14 +82,26 @@ func (a *ActionWithMetadata) Validate(ab *ActionBuilder) error {
 // Build delegates to the wrapped action and applies metadata to the built step
 func (a *ActionWithMetadata) Build(ab *ActionBuilder) *ActionBuilder {
 	// Build the action normally
+	start := len(ab.steps)
 	ab = a.Action.Build(ab)
 
+	// A checkpoint resumes at the first step the action built
+	if a.Metadata.Checkpoint != "" && len(ab.steps) > start {
+		ab.steps[start].checkpoint = a.Metadata.Checkpoint
+	}
+
 	// Apply metadata to the last added step
 	if len(ab.steps) > 0 {
 		lastStep := &ab.steps[len(ab.steps)-1]
 		if a.Metadata.Timeout > 0 {
 			lastStep.timeout = a.Metadata.Timeout
 		}
+		if a.Metadata.Retry.MaxAttempts > 1 {
+			lastStep.retry = a.Metadata.Retry
+		}
+		if a.Metadata.AllowLoop {
+			lastStep.allowLoop = true
+		}
 	}
 
 	return ab
@@ -88,6 +137,20 @@ func (r *Routine) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 	}
 
+	// Extract the template directory
+	if dir, ok := raw["template_dir"].(string); ok {
+		r.TemplateDir = dir
+	}
+
+	// Extract the loop detection threshold
+	if thresholdRaw, ok := raw["loop_threshold"]; ok {
+		threshold, ok := thresholdRaw.(int)
+		if !ok || threshold < 0 {
+			return fmt.Errorf("'loop_threshold' must be a non-negative integer")
+		}
+		r.LoopThreshold = threshold
+	}
+
 	// Extract sentries (will be unmarshaled separately)
 	if sentriesRaw, ok := raw["sentries"].([]interface{}); ok {
 		r.Sentries = make([]Sentry, len(sentriesRaw))
@@ -127,17 +190,39 @@ func (r *Routine) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	// Iterate through the raw steps and map them to concrete ActionStep types
 	r.Steps = make([]ActionStep, len(rawSteps))
+	checkpoints := make(map[string]int) // Checkpoint label -> step number, to reject duplicates
 	for i, rawStep := range rawSteps {
 		actionType, ok := rawStep["action"].(string)
 		if !ok || actionType == "" {
 			return fmt.Errorf("step %d: missing or invalid 'action' field", i+1)
 		}
 
-		// Extract step metadata (timeout) before unmarshaling
+		// Extract step metadata (timeout, retry) before unmarshaling
 		var stepMetadata StepMetadata
 		if timeoutMs, ok := rawStep["timeout"].(int); ok {
 			stepMetadata.Timeout = time.Duration(timeoutMs) * time.Millisecond
 		}
+		if retryRaw, ok := rawStep["retry"]; ok {
+			retry, err := parseStepRetry(retryRaw)
+			if err != nil {
+				return fmt.Errorf("step %d (%s): %w", i+1, actionType, err)
+			}
+			stepMetadata.Retry = retry
+		}
+		if allowLoop, ok := rawStep["allow_loop"].(bool); ok {
+			stepMetadata.AllowLoop = allowLoop
+		}
+		if checkpointRaw, ok := rawStep["checkpoint"]; ok {
+			label, ok := checkpointRaw.(string)
+			if !ok || strings.TrimSpace(label) == "" {
+				return fmt.Errorf("step %d (%s): checkpoint must be a non-empty label", i+1, actionType)
+			}
+			if previous, exists := checkpoints[label]; exists {
+				return fmt.Errorf("step %d (%s): checkpoint '%s' is already used by step %d", i+1, actionType, label, previous)
+			}
+			checkpoints[label] = i + 1
+			stepMetadata.Checkpoint = label
+		}
 
 		// Look up the concrete struct type in the registry
 		stepType, found := actionRegistry[strings.ToLower(actionType)]
@@ -314,7 +399,7 @@ func NewActionBuilderFromRoutine(filepath string) (*ActionBuilder, error) {
 	// 3. Build the steps: this recursively appends the executable Step structs
 	//    to ab.steps by calling the Build method on each ActionStep.
 	for _, action := range routine.Steps {
-		ab = action.Build(ab)
+		ab = ab.buildAction(action)
 	}
 
 	// The ab.steps slice now holds the entire executable routine
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"fmt"
+	"time"
 )
 
 // RoutineExecutor handles execution of routines with sentry support
@@ -10,13 +11,17 @@ type RoutineExecutor struct {
 	sentries      []Sentry
 	sentryEngine  *SentryEngine
 	routineLoader *RoutineLoader
+	name          string           // Routine name reported in results
+	loopThreshold int              // Step visits without progress before LoopDetectedError (0 = no detection)
+	checkpoints   *checkpointState // Last reached checkpoint, when resuming from checkpoints is enabled
 }
 
 // NewRoutineExecutor creates a new routine executor
 func NewRoutineExecutor(routine *ActionBuilder, sentries []Sentry) *RoutineExecutor {
 	return &RoutineExecutor{
-		routine:  routine,
-		sentries: sentries,
+		routine:       routine,
+		sentries:      sentries,
+		loopThreshold: routine.loopThreshold,
 	}
 }
 
@@ -26,6 +31,38 @@ func (re *RoutineExecutor) WithRoutineLoader(loader *RoutineLoader) *RoutineExec
 	return re
 }
 
+// WithName sets the routine name reported in RoutineResult
+func (re *RoutineExecutor) WithName(name string) *RoutineExecutor {
+	re.name = name
+	return re
+}
+
+// WithLoopThreshold sets how many times a step may run without the routine reaching a step it
+// hasn't run before; past it the run fails with LoopDetectedError. 0 disables loop detection.
+// Defaults to the routine's loop_threshold, so detection is off unless the routine sets it.
+// Steps that poll can opt out with allow_loop. Detection needs the bot to keep RunStats.
+func (re *RoutineExecutor) WithLoopThreshold(threshold int) *RoutineExecutor {
+	re.loopThreshold = threshold
+	return re
+}
+
+// WithCheckpoints makes the executor remember the last checkpoint step each run reaches and
+// start the next run from it (with the variables it had) instead of from the first step.
+// A run that completes clears the checkpoint. Reuse the executor across restarts of a
+// bot's routine so a restarted run resumes where the failed one got to.
+func (re *RoutineExecutor) WithCheckpoints() *RoutineExecutor {
+	re.checkpoints = &checkpointState{}
+	return re
+}
+
+// LastCheckpoint returns the checkpoint the next run resumes from (nil if none or checkpoints are disabled)
+func (re *RoutineExecutor) LastCheckpoint() *Checkpoint {
+	if re.checkpoints == nil {
+		return nil
+	}
+	return re.checkpoints.get()
+}
+
 // LoadSentryRoutines loads and validates all sentry routine builders
 func (re *RoutineExecutor) LoadSentryRoutines(bot BotInterface) error {
 	if len(re.sentries) == 0 {
@@ -33,22 +70,12 @@ func (re *RoutineExecutor) LoadSentryRoutines(bot BotInterface) error {
 	}
 
 	routineRegistry := bot.Routines()
-	if routineRegistry == nil {
-		return fmt.Errorf("routine registry not available on bot")
-	}
 
-	// Load each sentry routine
+	// Load and cache each sentry routine (built-in sentries need no registry)
 	for i := range re.sentries {
-		sentry := &re.sentries[i]
-
-		// Get the routine builder from the registry
-		builder, err := routineRegistry.Get(sentry.Routine)
-		if err != nil {
-			return fmt.Errorf("sentry routine '%s' not found in registry: %w", sentry.Routine, err)
+		if err := re.sentries[i].LoadRoutineBuilder(routineRegistry); err != nil {
+			return err
 		}
-
-		// Cache the builder
-		sentry.SetRoutineBuilder(builder)
 	}
 
 	return nil
@@ -56,6 +83,46 @@ func (re *RoutineExecutor) LoadSentryRoutines(bot BotInterface) error {
 
 // Execute runs the main routine with sentry monitoring
 func (re *RoutineExecutor) Execute(bot BotInterface) error {
+	_, err := re.ExecuteWithResult(bot)
+	return err
+}
+
+// ExecuteWithResult runs the main routine with sentry monitoring and reports how the run
+// went. A result is returned even when the routine fails. Step and sentry counts need the
+// bot to keep RunStats; without them they stay zero.
+func (re *RoutineExecutor) ExecuteWithResult(bot BotInterface) (*RoutineResult, error) {
+	result := &RoutineResult{Routine: re.name, StartedAt: time.Now()}
+	stats := runStatsOf(bot)
+	var before runStatsSnapshot
+	if stats != nil {
+		before = stats.snapshot()
+	}
+
+	err := re.execute(bot)
+
+	result.Duration = time.Since(result.StartedAt)
+	result.Success = err == nil
+	result.Reason = resultReason(err)
+	result.SentriesFired = make(map[string]int)
+	if stats != nil {
+		after := stats.snapshot()
+		result.StepsExecuted = int(after.steps - before.steps)
+		result.StepsFailed = int(after.failedSteps - before.failedSteps)
+		for name, count := range after.sentryFires {
+			if fired := count - before.sentryFires[name]; fired > 0 {
+				result.SentriesFired[name] = fired
+			}
+		}
+	}
+	if variables := bot.Variables(); variables != nil {
+		result.Variables = variables.GetAll()
+	}
+
+	return result, err
+}
+
+// execute runs the main routine with sentry monitoring
+func (re *RoutineExecutor) execute(bot BotInterface) error {
 	// Initialize routine controller state
 	controller := bot.RoutineController()
 	if controller != nil {
@@ -84,8 +151,25 @@ func (re *RoutineExecutor) Execute(bot BotInterface) error {
 		defer sentryMgr.Unregister(re.sentries)
 	}
 
-	// Execute the main routine
-	err := re.routine.Execute(bot)
+	// Detect the routine cycling through the same steps
+	if stats := runStatsOf(bot); stats != nil && re.loopThreshold > 0 {
+		stats.loops.start(re.loopThreshold)
+		defer stats.loops.stop()
+	}
+
+	// Execute the main routine, resuming from the last checkpoint if enabled
+	ctx := bot.Context()
+	if re.checkpoints != nil {
+		ctx = withCheckpointRun(ctx, &checkpointRun{
+			routine: re.name,
+			state:   re.checkpoints,
+			resume:  re.checkpoints.resumable(bot),
+		})
+	}
+	err := re.routine.executeContext(ctx, bot)
+	if err == nil && re.checkpoints != nil {
+		re.checkpoints.set(nil)
+	}
 
 	// Sentries will be unregistered by defer
 	return err
@@ -106,6 +190,6 @@ func ExecuteRoutineWithSentries(bot BotInterface, routineName string) error {
 	}
 
 	// Create executor and run
-	executor := NewRoutineExecutor(builder, sentries)
+	executor := NewRoutineExecutor(builder, sentries).WithName(routineName)
 	return executor.Execute(bot)
 }
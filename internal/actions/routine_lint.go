@@ -0,0 +1,229 @@
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Lint issue kinds
+const (
+	LintInvalid           = "invalid"            // The routine failed to load or validate (e.g. a missing template)
+	LintMissingRoutine    = "missing_routine"    // A RunRoutine step or sentry names a routine that doesn't exist
+	LintCircularInclude   = "circular_include"   // The routine ends up running itself
+	LintUndefinedVariable = "undefined_variable" // ${name} is used but nothing that shares the bot's variables sets it
+	LintUnreachableStep   = "unreachable_step"   // A step follows a Break or ReportError in the same list
+)
+
+// LintIssue is one problem found in a routine
+type LintIssue struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// RoutineLint is the lint result for one routine
+type RoutineLint struct {
+	Routine string      `json:"routine"`
+	Valid   bool        `json:"valid"`
+	Issues  []LintIssue `json:"issues"`
+}
+
+// assignKeys are the YAML keys that name a variable an action or config param sets
+var assignKeys = map[string]bool{
+	"name":            true, // Config params, SetVariable, Increment, Decrement
+	"target":          true, // GetVariable
+	"variable":        true, // DetectColor, WonderPick, claims, GetAccountState
+	"key":             true, // GetAccountState without a variable stores under its key
+	"save_result":     true, // InjectNextAccount
+	"save_to":         true, // GetAccountField
+	"friend_code_var": true, // CompleteOnboarding
+}
+
+// builtinVariables are set by the bot or by actions under a default name, so routines may use them without setting them
+var builtinVariables = []string{
+	VarDeviceAccountID,
+	VarExecutionID,
+	VarAccountBanned,
+	defaultOnboardingFriendCodeVar,
+	defaultDailyClaimedVar,
+	defaultMissionsClaimedVar,
+}
+
+// terminalActions end the step list they are in, so later steps in it never run
+var terminalActions = map[string]bool{
+	"break":       true,
+	"reporterror": true,
+}
+
+// Lint checks every discovered routine without running it and returns one result per routine,
+// sorted by name. Beyond the load and validation errors (missing templates, bad parameters)
+// it reports RunRoutine/sentry references to unknown routines, circular includes, ${variables}
+// nothing sets and unreachable steps.
+//
+// A variable counts as set if the routine, any routine it runs or any routine that runs it
+// (directly or not) may set it, since they all share the bot's variables.
+func (rr *RoutineRegistry) Lint() []RoutineLint {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	names := make([]string, 0, len(rr.routines)+len(rr.validationErrors))
+	for name := range rr.routines {
+		names = append(names, name)
+	}
+	for name := range rr.validationErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]RoutineLint, 0, len(names))
+	for _, name := range names {
+		issues := make([]LintIssue, 0)
+		if err, ok := rr.validationErrors[name]; ok {
+			issues = append(issues, LintIssue{Kind: LintInvalid, Message: err.Error()})
+		}
+
+		if refs, ok := rr.references[name]; ok {
+			for _, routine := range refs.Routines {
+				if !rr.knownLocked(routine) {
+					issues = append(issues, LintIssue{Kind: LintMissingRoutine, Message: fmt.Sprintf("routine '%s' not found", routine)})
+				}
+			}
+
+			if cycle := rr.includeCycleLocked(name); cycle != nil {
+				issues = append(issues, LintIssue{Kind: LintCircularInclude, Message: "circular include: " + strings.Join(cycle, " -> ")})
+			}
+
+			assigned := rr.assignedVariablesLocked(name)
+			for _, variable := range refs.Variables {
+				if !assigned[variable] {
+					issues = append(issues, LintIssue{Kind: LintUndefinedVariable, Message: fmt.Sprintf("variable '%s' is used but never set", variable)})
+				}
+			}
+
+			for _, step := range refs.Unreachable {
+				issues = append(issues, LintIssue{Kind: LintUnreachableStep, Message: step})
+			}
+		}
+
+		results = append(results, RoutineLint{Routine: name, Valid: len(issues) == 0, Issues: issues})
+	}
+	return results
+}
+
+// knownLocked reports whether a routine was discovered, valid or not (caller must hold mu)
+func (rr *RoutineRegistry) knownLocked(filename string) bool {
+	_, valid := rr.routines[filename]
+	_, invalid := rr.validationErrors[filename]
+	return valid || invalid
+}
+
+// includeCycleLocked returns a chain of routines that leads from start back to itself,
+// or nil if start never ends up running itself (caller must hold mu)
+func (rr *RoutineRegistry) includeCycleLocked(start string) []string {
+	visited := make(map[string]bool)
+
+	var search func(name string, path []string) []string
+	search = func(name string, path []string) []string {
+		refs, ok := rr.references[name]
+		if !ok {
+			return nil
+		}
+		for _, next := range refs.Routines {
+			if next == start {
+				return append(append([]string{}, path...), start)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if cycle := search(next, append(path, next)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return search(start, []string{start})
+}
+
+// assignedVariablesLocked returns the variables that may be set while filename runs: the
+// built-in ones plus those set by every routine connected to it through includes (caller must hold mu)
+func (rr *RoutineRegistry) assignedVariablesLocked(filename string) map[string]bool {
+	// Routines are connected if either runs the other
+	neighbours := make(map[string][]string)
+	for name, refs := range rr.references {
+		for _, routine := range refs.Routines {
+			neighbours[name] = append(neighbours[name], routine)
+			neighbours[routine] = append(neighbours[routine], name)
+		}
+	}
+
+	assigned := make(map[string]bool)
+	for _, name := range builtinVariables {
+		assigned[name] = true
+	}
+
+	seen := map[string]bool{filename: true}
+	queue := []string{filename}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if refs, ok := rr.references[name]; ok {
+			for _, variable := range refs.Assigns {
+				assigned[variable] = true
+			}
+		}
+		for _, next := range neighbours[name] {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return assigned
+}
+
+// findUnreachableSteps walks a raw routine document and describes every step list with steps
+// after a Break or ReportError, e.g. "steps.2.then: steps 4-5 never run after Break at step 3".
+// Lists are located by key path with 1-based step numbers.
+func findUnreachableSteps(raw interface{}) []string {
+	found := make([]string, 0)
+
+	var walk func(node interface{}, path string)
+	walk = func(node interface{}, path string) {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				child := key
+				if path != "" {
+					child = path + "." + key
+				}
+				walk(v[key], child)
+			}
+		case []interface{}:
+			terminal := -1
+			for i, item := range v {
+				step, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				action, _ := step["action"].(string)
+				if terminal < 0 && terminalActions[strings.ToLower(action)] && i < len(v)-1 {
+					terminal = i
+					after := fmt.Sprintf("step %d never runs", i+2)
+					if len(v)-i > 2 {
+						after = fmt.Sprintf("steps %d-%d never run", i+2, len(v))
+					}
+					found = append(found, fmt.Sprintf("%s: %s after %s at step %d", path, after, action, i+1))
+				}
+				walk(item, fmt.Sprintf("%s.%d", path, i+1))
+			}
+		}
+	}
+	walk(raw, "")
+	return found
+}
@@ -0,0 +1,126 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRoutineRegistryLint(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"clean.yaml": `
+routine_name: Clean
+config:
+  - name: target_count
+    type: int
+    default: 3
+steps:
+  - action: SetVariable
+    name: label
+    value: "run ${target_count}"
+  - action: RunRoutine
+    routine: "common/child"
+`,
+		// Uses a variable its parent sets
+		"common/child.yaml": `
+routine_name: Child
+steps:
+  - action: SetVariable
+    name: copy
+    value: "${label} on ${device_account_id}"
+`,
+		"broken.yaml": `
+routine_name: Broken
+steps:
+  - action: SetVariable
+    name: message
+    value: "${never_set}"
+  - action: Repeat
+    iterations: 2
+    actions:
+      - action: Break
+      - action: Sleep
+        duration: 1
+      - action: Sleep
+        duration: 1
+  - action: RunRoutine
+    routine: "missing"
+  - action: RunRoutine
+    routine: "loop_b"
+`,
+		"loop_b.yaml": `
+routine_name: Loop B
+steps:
+  - action: RunRoutine
+    routine: "broken"
+`,
+		"unparseable.yaml": `
+routine_name: Unparseable
+steps:
+  - action: NoSuchAction
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	rr := NewRoutineRegistry(dir)
+	rr.mu.Lock()
+	rr.loadAllRoutines()
+	rr.mu.Unlock()
+
+	results := make(map[string]RoutineLint)
+	for _, result := range rr.Lint() {
+		results[result.Routine] = result
+	}
+	if len(results) != len(files) {
+		t.Fatalf("Expected %d lint results, got %d", len(files), len(results))
+	}
+
+	for _, name := range []string{"clean", "common/child"} {
+		if !results[name].Valid {
+			t.Errorf("Expected %s to be valid, got issues %+v", name, results[name].Issues)
+		}
+	}
+
+	tests := []struct {
+		routine string
+		kind    string
+		message string
+	}{
+		{"broken", LintUndefinedVariable, "never_set"},
+		{"broken", LintUnreachableStep, "steps.2.actions: steps 2-3 never run after Break at step 1"},
+		{"broken", LintMissingRoutine, "routine 'missing' not found"},
+		{"broken", LintCircularInclude, "broken -> loop_b -> broken"},
+		{"loop_b", LintCircularInclude, "loop_b -> broken -> loop_b"},
+		{"unparseable", LintInvalid, "NoSuchAction"},
+	}
+
+	for _, tt := range tests {
+		result := results[tt.routine]
+		if result.Valid {
+			t.Errorf("Expected %s to be invalid", tt.routine)
+		}
+
+		found := false
+		for _, issue := range result.Issues {
+			if issue.Kind == tt.kind && strings.Contains(issue.Message, tt.message) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected a %s issue containing %q, got %+v", tt.routine, tt.kind, tt.message, result.Issues)
+		}
+	}
+}
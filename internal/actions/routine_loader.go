@@ -3,13 +3,24 @@ package actions
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
+	"jordanella.com/pocket-tcg-go/internal/cv"
 	// ... other necessary imports
 )
 
 type RoutineLoader struct {
 	templateRegistry TemplateRegistryInterface // Optional: for build-time validation
+	routineName      string                    // Namespace for the routine's own templates (default: file name)
+}
+
+// routineTemplateRegistry is implemented by template registries that can hold a routine's own
+// templates (see templates.TemplateRegistry.LoadRoutineTemplates)
+type routineTemplateRegistry interface {
+	LoadRoutineTemplates(routineName, dirPath string) error
+	ResolveForRoutine(routineName, templateName string) (cv.Template, bool)
 }
 
 func NewRoutineLoader() *RoutineLoader {
@@ -22,6 +33,12 @@ func (rl *RoutineLoader) WithTemplateRegistry(registry TemplateRegistryInterface
 	return rl
 }
 
+// WithRoutineName sets the name the routine's own templates are registered under (see Routine.TemplateDir)
+func (rl *RoutineLoader) WithRoutineName(name string) *RoutineLoader {
+	rl.routineName = name
+	return rl
+}
+
 // LoadFromFile reads a YAML file, unmarshals the Routine, validates all actions,
 // and builds the final executable ActionBuilder that can be executed on any bot.
 // Returns the ActionBuilder and the associated sentries (if any)
@@ -38,6 +55,13 @@ func (rl *RoutineLoader) LoadFromFile(filepath string) (*ActionBuilder, []Sentry
 		return nil, nil, fmt.Errorf("failed to unmarshal routine YAML: %w", err)
 	}
 
+	// 2b. Load the routine's own templates and resolve its template names against them
+	if routine.TemplateDir != "" {
+		if err := rl.loadRoutineTemplates(&routine, data, filepath); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// 3. Validate config parameters (if any)
 	for i, param := range routine.Config {
 		if err := param.Validate(); err != nil {
@@ -50,6 +74,7 @@ func (rl *RoutineLoader) LoadFromFile(filepath string) (*ActionBuilder, []Sentry
 	if rl.templateRegistry != nil {
 		ab.WithTemplateRegistry(rl.templateRegistry)
 	}
+	ab.loopThreshold = routine.LoopThreshold
 
 	// 5. Validate and Build all steps
 	// Note: We use the *same* ActionBuilder (ab) for both validation and building
@@ -62,7 +87,7 @@ func (rl *RoutineLoader) LoadFromFile(filepath string) (*ActionBuilder, []Sentry
 
 		// Build the step (appends the executable Step to ab.steps and captures
 		// the 'issue' error if validation passed but was captured in 'issue')
-		ab = action.Build(ab)
+		ab = ab.buildAction(action)
 	}
 
 	// 6. Validate sentries (if any)
@@ -75,3 +100,79 @@ func (rl *RoutineLoader) LoadFromFile(filepath string) (*ActionBuilder, []Sentry
 	// The ab.steps slice now holds the entire executable routine
 	return ab, routine.Sentries, nil
 }
+
+// loadRoutineTemplates checks that the routine's template_dir exists, loads the templates in it for
+// the routine and re-reads the routine with every template the routine ships pointing at its own
+// variant. Templates the routine does not ship keep resolving to the global templates.
+func (rl *RoutineLoader) loadRoutineTemplates(routine *Routine, data []byte, routinePath string) error {
+	dir := routine.TemplateDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(filepath.Dir(routinePath), dir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("routine '%s' template_dir '%s' does not exist", routine.RoutineName, routine.TemplateDir)
+	}
+
+	registry, ok := rl.templateRegistry.(routineTemplateRegistry)
+	if !ok {
+		return nil // No registry to load into; the routine uses global templates
+	}
+
+	name := rl.routineName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(routinePath), filepath.Ext(routinePath))
+	}
+	if err := registry.LoadRoutineTemplates(name, dir); err != nil {
+		return fmt.Errorf("routine '%s' template_dir: %w", routine.RoutineName, err)
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal routine YAML: %w", err)
+	}
+	resolveTemplateNames(raw, func(templateName string) string {
+		if template, ok := registry.ResolveForRoutine(name, templateName); ok {
+			return template.Name
+		}
+		return templateName
+	})
+
+	resolved, err := yaml.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to marshal routine YAML: %w", err)
+	}
+	*routine = Routine{}
+	if err := yaml.Unmarshal(resolved, routine); err != nil {
+		return fmt.Errorf("failed to unmarshal routine YAML: %w", err)
+	}
+	return nil
+}
+
+// resolveTemplateNames replaces the template names in raw routine YAML ("template" and
+// "templates" keys, as in extractReferences) with resolve(name)
+func resolveTemplateNames(node interface{}, resolve func(string) string) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch key {
+			case "template":
+				if name, ok := value.(string); ok && name != "" {
+					v[key] = resolve(name)
+				}
+			case "templates":
+				if list, ok := value.([]interface{}); ok {
+					for i, item := range list {
+						if name, ok := item.(string); ok && name != "" {
+							list[i] = resolve(name)
+						}
+					}
+				}
+			}
+			resolveTemplateNames(value, resolve)
+		}
+	case []interface{}:
+		for _, item := range v {
+			resolveTemplateNames(item, resolve)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -52,6 +53,18 @@ type RoutineRegistry struct {
 
 	// Validation errors (filename -> error)
 	validationErrors map[string]error
+
+	// Routines and templates referenced by each routine (filename -> references)
+	references map[string]*routineReferences
+}
+
+// routineReferences lists what a routine file refers to, extracted from its raw YAML
+type routineReferences struct {
+	Routines    []string // Routines run via RunRoutine steps or sentries
+	Templates   []string // Templates used by image actions and conditions
+	Variables   []string // Variables interpolated with ${name}
+	Assigns     []string // Variables the routine may set (config params, variable actions, RunRoutine config)
+	Unreachable []string // Steps that can never run, described for lint reports (see findUnreachableSteps)
 }
 
 // NewRoutineRegistry creates a new routine registry
@@ -64,6 +77,7 @@ func NewRoutineRegistry(routinesPath string) *RoutineRegistry {
 		configs:          make(map[string][]ConfigParam),
 		metadata:         make(map[string]*RoutineMetadata),
 		validationErrors: make(map[string]error),
+		references:       make(map[string]*routineReferences),
 	}
 
 	return rr
@@ -152,6 +166,16 @@ func (rr *RoutineRegistry) loadRoutine(filename string, path string) {
 		return
 	}
 
+	// Record references from the raw YAML so dependencies are known even for invalid routines
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err == nil {
+		// Template files of a routine's template_dir can sit next to the routines; they are not routines
+		if isTemplateFile(raw) {
+			return
+		}
+		rr.references[filename] = extractReferences(raw)
+	}
+
 	var routine Routine
 	if err := yaml.Unmarshal(data, &routine); err != nil {
 		rr.validationErrors[filename] = fmt.Errorf("failed to parse YAML: %w", err)
@@ -171,7 +195,7 @@ func (rr *RoutineRegistry) loadRoutine(filename string, path string) {
 	}
 
 	// Now load and validate with the loader
-	loader := NewRoutineLoader()
+	loader := NewRoutineLoader().WithRoutineName(filename)
 	if rr.templateRegistry != nil {
 		loader.WithTemplateRegistry(rr.templateRegistry)
 	}
@@ -420,6 +444,7 @@ func (rr *RoutineRegistry) Reload() error {
 	rr.configs = make(map[string][]ConfigParam)
 	rr.metadata = make(map[string]*RoutineMetadata)
 	rr.validationErrors = make(map[string]error)
+	rr.references = make(map[string]*routineReferences)
 
 	// Reload all routines
 	log.Printf("[RoutineRegistry] Reloading routines from: %s", rr.routinesPath)
@@ -480,3 +505,147 @@ func (rr *RoutineRegistry) GetNamespace(filename string) string {
 func (rr *RoutineRegistry) GetBaseName(filename string) string {
 	return filepath.Base(filepath.ToSlash(filename))
 }
+
+// Dependents returns the routines that directly run the given routine
+// (via RunRoutine steps or as a sentry)
+func (rr *RoutineRegistry) Dependents(filename string) []string {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	dependents := make([]string, 0)
+	for name, refs := range rr.references {
+		if name == filename {
+			continue
+		}
+		for _, routine := range refs.Routines {
+			if routine == filename {
+				dependents = append(dependents, name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(dependents)
+	return dependents
+}
+
+// Dependencies returns the routines that the given routine runs directly
+func (rr *RoutineRegistry) Dependencies(filename string) []string {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	refs, ok := rr.references[filename]
+	if !ok {
+		return []string{}
+	}
+	return append([]string{}, refs.Routines...)
+}
+
+// TemplatesUsed returns every template the given routine references directly
+func (rr *RoutineRegistry) TemplatesUsed(filename string) []string {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	refs, ok := rr.references[filename]
+	if !ok {
+		return []string{}
+	}
+	return append([]string{}, refs.Templates...)
+}
+
+// isTemplateFile reports whether raw YAML is a template file (a "templates" list without steps)
+func isTemplateFile(raw interface{}) bool {
+	root, ok := raw.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, hasTemplates := root["templates"]
+	_, hasSteps := root["steps"]
+	return hasTemplates && !hasSteps
+}
+
+// extractReferences walks a raw YAML document and collects routine and template names.
+// Any "routine" key (RunRoutine steps, sentries) is a routine reference and any
+// "template"/"templates" key is a template reference, at any nesting depth.
+// Variables are collected the same way: ${name} in any string is a use, and any
+// key in assignKeys names a variable the routine may set.
+func extractReferences(raw interface{}) *routineReferences {
+	routines := make(map[string]bool)
+	templates := make(map[string]bool)
+	variables := make(map[string]bool)
+	assigns := make(map[string]bool)
+
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch v := node.(type) {
+		case string:
+			for _, name := range ExtractVariableNames(v) {
+				variables[name] = true
+			}
+		case map[string]interface{}:
+			action, _ := v["action"].(string)
+			for key, value := range v {
+				if assignKeys[key] {
+					if name, ok := value.(string); ok && name != "" {
+						assigns[name] = true
+						// DetectColor also stores the blob's position and size
+						if key == "variable" && strings.EqualFold(action, "detect_color") {
+							assigns[name+"_x"] = true
+							assigns[name+"_y"] = true
+							assigns[name+"_pixels"] = true
+						}
+					}
+				}
+				switch key {
+				case "routine":
+					if name, ok := value.(string); ok && name != "" {
+						routines[name] = true
+					}
+				case "template":
+					if name, ok := value.(string); ok && name != "" {
+						templates[name] = true
+					}
+				case "templates":
+					if list, ok := value.([]interface{}); ok {
+						for _, item := range list {
+							if name, ok := item.(string); ok && name != "" {
+								templates[name] = true
+							}
+						}
+					}
+				case "config":
+					// RunRoutine config overrides set the sub-routine's variables
+					if overrides, ok := value.(map[string]interface{}); ok {
+						for name := range overrides {
+							assigns[name] = true
+						}
+					}
+				}
+				walk(value)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(raw)
+
+	return &routineReferences{
+		Routines:    sortedKeys(routines),
+		Templates:   sortedKeys(templates),
+		Variables:   sortedKeys(variables),
+		Assigns:     sortedKeys(assigns),
+		Unreachable: findUnreachableSteps(raw),
+	}
+}
+
+// sortedKeys returns a set's members in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
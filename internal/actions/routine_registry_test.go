@@ -0,0 +1,161 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/pkg/templates"
+)
+
+func TestRoutineRegistryReferences(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.yaml": `
+routine_name: Main
+steps:
+  - action: ClickIfImageFound
+    template: "OK"
+  - action: If
+    condition:
+      type: Any
+      conditions:
+        - type: ImageExists
+          template: "Error"
+    then:
+      - action: RunRoutine
+        routine: "common/recover"
+sentries:
+  - routine: "popup_watch"
+`,
+		"common/recover.yaml": `
+routine_name: Recover
+steps:
+  - action: WhileAnyImagesFound
+    templates: ["Close", "Back"]
+    actions:
+      - action: Sleep
+        duration: 1
+`,
+		"popup_watch.yaml": `
+routine_name: Popup Watch
+steps:
+  - action: RunRoutine
+    routine: "common/recover"
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	rr := NewRoutineRegistry(dir)
+	rr.mu.Lock()
+	rr.loadAllRoutines()
+	rr.mu.Unlock()
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"main dependencies", rr.Dependencies("main"), []string{"common/recover", "popup_watch"}},
+		{"recover dependents", rr.Dependents("common/recover"), []string{"main", "popup_watch"}},
+		{"popup_watch dependents", rr.Dependents("popup_watch"), []string{"main"}},
+		{"main dependents", rr.Dependents("main"), []string{}},
+		{"main templates", rr.TemplatesUsed("main"), []string{"Error", "OK"}},
+		{"recover templates", rr.TemplatesUsed("common/recover"), []string{"Back", "Close"}},
+		{"unknown routine templates", rr.TemplatesUsed("missing"), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutineTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"templates/global.yaml": `
+templates:
+  - name: OK
+    path: ok.png
+  - name: Close
+    path: close.png
+`,
+		"combat/battle.yaml": `
+routine_name: Battle
+template_dir: battle_templates
+steps:
+  - action: ClickIfImageFound
+    template: "OK"
+  - action: ClickIfImageFound
+    template: "Close"
+`,
+		"combat/battle_templates/overlay.yaml": `
+templates:
+  - name: OK
+    path: ok_alt.png
+`,
+		"broken.yaml": `
+routine_name: Broken
+template_dir: missing
+steps:
+  - action: Sleep
+    duration: 1
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	registry := templates.NewTemplateRegistry(dir).WithoutImageCache()
+	if err := registry.LoadFromDirectory(filepath.Join(dir, "templates")); err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+
+	rr := NewRoutineRegistry(dir)
+	rr.mu.Lock()
+	rr.templateRegistry = registry
+	rr.loadAllRoutines()
+	rr.mu.Unlock()
+
+	builder, err := rr.Get("combat/battle")
+	if err != nil {
+		t.Fatalf("expected battle routine to load: %v", err)
+	}
+	want := []string{"ClickIfImageFound (combat/battle/OK)", "ClickIfImageFound (Close)"}
+	for i, step := range builder.steps {
+		if step.name != want[i] {
+			t.Errorf("step %d: expected %q, got %q", i+1, want[i], step.name)
+		}
+	}
+
+	if err := rr.GetValidationError("broken"); err == nil || !strings.Contains(err.Error(), "template_dir") {
+		t.Errorf("expected missing template_dir error, got %v", err)
+	}
+	if rr.Has("combat/battle_templates/overlay") {
+		t.Error("expected template files not to be loaded as routines")
+	}
+}
@@ -0,0 +1,154 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RoutineResult describes how a routine run went, beyond whether it returned an error
+type RoutineResult struct {
+	Routine       string
+	StartedAt     time.Time
+	Duration      time.Duration
+	Success       bool
+	Reason        string            // "completed", "stopped", "cancelled", "timed out", "interrupted: ..." or "failed: ..."
+	StepsExecuted int               // Steps run, nested steps and retries included
+	StepsFailed   int               // Step attempts that returned an error (some may have been retried or ignored)
+	SentriesFired map[string]int    // Sentry routine -> times it halted the routine or failed during the run
+	Variables     map[string]string // Bot variables when the run ended
+}
+
+// TotalSentriesFired returns how many times any sentry fired during the run
+func (r *RoutineResult) TotalSentriesFired() int {
+	total := 0
+	for _, count := range r.SentriesFired {
+		total += count
+	}
+	return total
+}
+
+// Summary describes the result in one line, e.g.
+// "completed after 47 steps, 2 sentry recoveries (popup_handler x2)"
+func (r *RoutineResult) Summary() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s after %d steps", r.Reason, r.StepsExecuted)
+
+	if total := r.TotalSentriesFired(); total > 0 {
+		names := make([]string, 0, len(r.SentriesFired))
+		for name := range r.SentriesFired {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fired := make([]string, 0, len(names))
+		for _, name := range names {
+			fired = append(fired, fmt.Sprintf("%s x%d", name, r.SentriesFired[name]))
+		}
+
+		noun := "recoveries"
+		if total == 1 {
+			noun = "recovery"
+		}
+		fmt.Fprintf(&sb, ", %d sentry %s (%s)", total, noun, strings.Join(fired, ", "))
+	}
+	return sb.String()
+}
+
+// resultReason describes why a run ended
+func resultReason(err error) string {
+	var interrupt *ErrorInterrupt
+	switch {
+	case err == nil:
+		return "completed"
+	case errors.Is(err, errRoutineStopped):
+		return "stopped"
+	case errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timed out"
+	case errors.As(err, &interrupt):
+		return "interrupted: " + interrupt.Message
+	}
+	return "failed: " + err.Error()
+}
+
+// RunStats counts the steps a bot executes and the sentries that fire on it. It counts for
+// the bot's whole life; RoutineExecutor.ExecuteWithResult reports the difference over a run.
+type RunStats struct {
+	steps       atomic.Int64
+	failedSteps atomic.Int64
+	halts       atomic.Int64 // SentryHalt calls, to tell which sentry runs halted the routine
+
+	mu          sync.Mutex
+	sentryFires map[string]int
+
+	loops loopDetector // Loop detection during RoutineExecutor runs
+}
+
+// NewRunStats creates empty run statistics
+func NewRunStats() *RunStats {
+	return &RunStats{sentryFires: make(map[string]int)}
+}
+
+// runStatsSnapshot is RunStats at a point in time
+type runStatsSnapshot struct {
+	steps       int64
+	failedSteps int64
+	sentryFires map[string]int
+}
+
+func (s *RunStats) snapshot() runStatsSnapshot {
+	s.mu.Lock()
+	fires := make(map[string]int, len(s.sentryFires))
+	for name, count := range s.sentryFires {
+		fires[name] = count
+	}
+	s.mu.Unlock()
+
+	return runStatsSnapshot{
+		steps:       s.steps.Load(),
+		failedSteps: s.failedSteps.Load(),
+		sentryFires: fires,
+	}
+}
+
+// recordSentryFire counts a sentry run that handled something
+func (s *RunStats) recordSentryFire(routine string) {
+	s.mu.Lock()
+	s.sentryFires[routine]++
+	s.mu.Unlock()
+}
+
+// runStatsOf returns the bot's run statistics (nil if it doesn't keep any)
+func runStatsOf(bot BotInterface) *RunStats {
+	type runStatsProvider interface {
+		RunStats() *RunStats
+	}
+
+	if provider, ok := bot.(runStatsProvider); ok {
+		return provider.RunStats()
+	}
+	return nil
+}
+
+// recordStepRun counts an executed step attempt for the bot's run statistics.
+// Top-level sentry steps aren't counted, so idle sentry polling doesn't inflate a run.
+func (ab *ActionBuilder) recordStepRun(bot BotInterface, err error) {
+	if ab.isSentryExecution {
+		return
+	}
+	stats := runStatsOf(bot)
+	if stats == nil {
+		return
+	}
+	stats.steps.Add(1)
+	if err != nil {
+		stats.failedSteps.Add(1)
+	}
+}
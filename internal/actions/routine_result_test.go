@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// resultBot keeps run statistics and variables, as a real bot does
+type resultBot struct {
+	retryBot
+	stats     *RunStats
+	variables *VariableStore
+}
+
+func (b resultBot) RunStats() *RunStats               { return b.stats }
+func (b resultBot) Variables() VariableStoreInterface { return b.variables }
+func (b resultBot) Context() context.Context          { return context.Background() }
+
+func TestExecuteWithResultReportsRun(t *testing.T) {
+	bot := resultBot{stats: NewRunStats(), variables: NewVariableStore()}
+
+	calls := 0
+	routine := NewActionBuilder()
+	routine.steps = append(routine.steps,
+		Step{name: "Flaky", retry: StepRetry{MaxAttempts: 2}, execute: func(BotInterface) error {
+			calls++
+			if calls == 1 {
+				return errors.New("missed")
+			}
+			return nil
+		}},
+		Step{name: "SetPacks", execute: func(b BotInterface) error {
+			b.Variables().Set("packs", "3")
+			return nil
+		}},
+	)
+
+	// A sentry that fired before the run isn't counted in it
+	bot.stats.recordSentryFire("popup_handler")
+
+	executor := NewRoutineExecutor(routine, nil).WithName("farm")
+	result, err := executor.ExecuteWithResult(bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.Routine != "farm" || result.StepsExecuted != 3 || result.StepsFailed != 1 {
+		t.Errorf("result = %+v, want a successful farm run with 3 steps, 1 failed", result)
+	}
+	if result.Variables["packs"] != "3" {
+		t.Errorf("variables = %v, want packs=3", result.Variables)
+	}
+	if got := result.Summary(); got != "completed after 3 steps" {
+		t.Errorf("summary = %q", got)
+	}
+
+	result.SentriesFired = map[string]int{"popup_handler": 2}
+	if got := result.Summary(); got != "completed after 3 steps, 2 sentry recoveries (popup_handler x2)" {
+		t.Errorf("summary = %q", got)
+	}
+
+	// Execute keeps returning only the error
+	failing := NewActionBuilder()
+	failing.steps = append(failing.steps, Step{name: "Broken", execute: func(BotInterface) error { return errors.New("boom") }})
+	executor = NewRoutineExecutor(failing, nil)
+	if err := executor.Execute(bot); err == nil {
+		t.Fatal("expected the step error")
+	}
+	result, _ = executor.ExecuteWithResult(bot)
+	if result.Success || result.Reason != "failed: boom" {
+		t.Errorf("result = %+v, want failed: boom", result)
+	}
+}
@@ -0,0 +1,202 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// routineReloadDebounce coalesces the burst of write events editors produce on save
+const routineReloadDebounce = 250 * time.Millisecond
+
+// RoutineReloadEvent describes a single routine file change applied by WatchDirectory
+type RoutineReloadEvent struct {
+	Filename string // Routine name relative to the routines folder (e.g., "combat/battle_loop")
+	Removed  bool   // File was deleted or renamed away and the routine was unregistered
+	Valid    bool   // Routine loaded and passed validation
+	Error    error  // Validation error when the routine is registered but invalid
+}
+
+// WatchDirectory watches the routines folder and reloads only the routine file that changed.
+// Each reload is reported on the returned channel, which is closed when ctx is cancelled.
+// Invalid routines stay registered (flagged via GetValidationError), deleted files are unregistered.
+func (rr *RoutineRegistry) WatchDirectory(ctx context.Context) (<-chan RoutineReloadEvent, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	// fsnotify is not recursive, so watch every namespace folder
+	if err := addWatchRecursive(watcher, rr.routinesPath); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch routines folder: %w", err)
+	}
+
+	events := make(chan RoutineReloadEvent, 16)
+
+	go func() {
+		defer close(events)
+		defer watcher.Close()
+
+		var timersMu sync.Mutex
+		timers := make(map[string]*time.Timer)
+		var pending sync.WaitGroup
+
+		defer func() {
+			// Stop outstanding reloads before closing the channel
+			timersMu.Lock()
+			for path, timer := range timers {
+				if timer.Stop() {
+					pending.Done()
+				}
+				delete(timers, path)
+			}
+			timersMu.Unlock()
+			pending.Wait()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("[RoutineRegistry] Watch error: %v", err)
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				// New namespace folders need their own watch
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						if err := addWatchRecursive(watcher, event.Name); err != nil {
+							log.Printf("[RoutineRegistry] Failed to watch new folder %s: %v", event.Name, err)
+						}
+						continue
+					}
+				}
+
+				if !isRoutineFile(event.Name) {
+					continue
+				}
+
+				// Debounce per file, then reload just that file
+				path := event.Name
+				timersMu.Lock()
+				if timer, exists := timers[path]; exists && timer.Stop() {
+					pending.Done()
+				}
+				pending.Add(1)
+				timers[path] = time.AfterFunc(routineReloadDebounce, func() {
+					defer pending.Done()
+
+					timersMu.Lock()
+					delete(timers, path)
+					timersMu.Unlock()
+
+					reloadEvent, ok := rr.ReloadFile(path)
+					if !ok {
+						return
+					}
+
+					select {
+					case events <- reloadEvent:
+					case <-ctx.Done():
+					}
+				})
+				timersMu.Unlock()
+			}
+		}
+	}()
+
+	log.Printf("[RoutineRegistry] Watching %s for routine changes", rr.routinesPath)
+	return events, nil
+}
+
+// ReloadFile reloads (or unregisters, if deleted) a single routine file.
+// Returns false if the path is not a routine inside the routines folder.
+func (rr *RoutineRegistry) ReloadFile(path string) (RoutineReloadEvent, bool) {
+	filename, ok := rr.routineNameForPath(path)
+	if !ok {
+		return RoutineReloadEvent{}, false
+	}
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.unregisterLocked(filename)
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		log.Printf("[RoutineRegistry] Unregistered removed routine: %s", filename)
+		return RoutineReloadEvent{Filename: filename, Removed: true}, true
+	}
+
+	rr.loadRoutine(filename, path)
+
+	validationErr := rr.validationErrors[filename]
+	if validationErr != nil {
+		log.Printf("[RoutineRegistry] ⚠️  Reloaded invalid routine '%s': %v", filename, validationErr)
+	}
+
+	return RoutineReloadEvent{
+		Filename: filename,
+		Valid:    validationErr == nil,
+		Error:    validationErr,
+	}, true
+}
+
+// unregisterLocked removes every trace of a routine (caller must hold mu)
+func (rr *RoutineRegistry) unregisterLocked(filename string) {
+	delete(rr.routines, filename)
+	delete(rr.sentries, filename)
+	delete(rr.configs, filename)
+	delete(rr.metadata, filename)
+	delete(rr.validationErrors, filename)
+	delete(rr.references, filename)
+}
+
+// routineNameForPath converts a file path to its registry name (relative, no extension, forward slashes)
+func (rr *RoutineRegistry) routineNameForPath(path string) (string, bool) {
+	if !isRoutineFile(path) {
+		return "", false
+	}
+
+	relPath, err := filepath.Rel(rr.routinesPath, path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return "", false
+	}
+
+	ext := filepath.Ext(relPath)
+	return filepath.ToSlash(relPath[:len(relPath)-len(ext)]), true
+}
+
+// isRoutineFile reports whether a path has a routine (.yaml/.yml) extension
+func isRoutineFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// addWatchRecursive adds a watch for root and every folder below it
+func addWatchRecursive(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
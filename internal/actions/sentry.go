@@ -29,20 +29,46 @@ const (
 
 // Sentry defines a monitoring routine that runs in parallel to check for errors
 type Sentry struct {
+	Type       string         `yaml:"type,omitempty"`       // Built-in sentry type (e.g., "ban_detection"); empty runs Routine
 	Routine    string         `yaml:"routine"`              // Name of the routine to execute
 	Frequency  int            `yaml:"frequency,omitempty"`  // How often to poll in seconds (default: 5)
 	Severity   SentrySeverity `yaml:"severity,omitempty"`   // Logging severity (default: medium)
 	OnSuccess  SentryAction   `yaml:"on_success,omitempty"` // Action on success (nil error) (default: resume)
 	OnFailure  SentryAction   `yaml:"on_failure,omitempty"` // Action on failure (non-nil error) (default: force_stop)
 
+	// Built-in sentry settings
+	Template  string   `yaml:"template,omitempty"`  // Template to watch for (ban_detection)
+	Threshold *float64 `yaml:"threshold,omitempty"` // Optional: override template's threshold
+
 	// Internal fields set during validation
 	routineBuilder *ActionBuilder // Cached routine builder
 }
 
 // Validate checks if the sentry configuration is valid
 func (s *Sentry) Validate(ab *ActionBuilder) error {
-	if s.Routine == "" {
-		return fmt.Errorf("sentry routine name is required")
+	switch s.Type {
+	case "":
+		if s.Routine == "" {
+			return fmt.Errorf("sentry routine name is required")
+		}
+	case SentryTypeBanDetection:
+		if s.Template == "" {
+			return fmt.Errorf("ban_detection sentry: template is required")
+		}
+		if ab.templateRegistry != nil && !ab.templateRegistry.Has(s.Template) {
+			return fmt.Errorf("ban_detection sentry: template '%s' not found in registry", s.Template)
+		}
+		// The built-in routine has no file; name it so metrics and dedup keys are stable
+		if s.Routine == "" {
+			s.Routine = SentryTypeBanDetection + ":" + s.Template
+		}
+		// A ban always ends the routine and is logged as critical
+		if s.Severity == "" {
+			s.Severity = SentrySeverityCritical
+		}
+		s.OnFailure = SentryActionForceStop
+	default:
+		return fmt.Errorf("unknown sentry type '%s' (available types: %s)", s.Type, SentryTypeBanDetection)
 	}
 
 	// Set defaults
@@ -109,6 +135,26 @@ func (s *Sentry) SetRoutineBuilder(builder *ActionBuilder) {
 	s.routineBuilder = builder
 }
 
+// LoadRoutineBuilder resolves and caches the builder this sentry runs.
+// Built-in sentry types build their own routine; others are looked up in the registry.
+func (s *Sentry) LoadRoutineBuilder(registry RoutineRegistryInterface) error {
+	if s.Type == SentryTypeBanDetection {
+		s.routineBuilder = newBanDetectionBuilder(s)
+		return nil
+	}
+
+	if registry == nil {
+		return fmt.Errorf("routine registry not available")
+	}
+
+	builder, err := registry.Get(s.Routine)
+	if err != nil {
+		return fmt.Errorf("sentry routine '%s' not found: %w", s.Routine, err)
+	}
+	s.routineBuilder = builder
+	return nil
+}
+
 // GetRoutineBuilder returns the cached routine builder
 func (s *Sentry) GetRoutineBuilder() *ActionBuilder {
 	return s.routineBuilder
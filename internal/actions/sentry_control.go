@@ -33,6 +33,9 @@ func (a *SentryHalt) Build(ab *ActionBuilder) *ActionBuilder {
 			}
 
 			// Pause the main routine
+			if stats := runStatsOf(bot); stats != nil {
+				stats.halts.Add(1)
+			}
 			if !controller.Pause() {
 				// Routine wasn't running, this is non-fatal
 				fmt.Printf("Bot %d: SentryHalt called but routine is not running\n", bot.Instance())
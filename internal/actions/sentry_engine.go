@@ -128,6 +128,13 @@ func (se *SentryEngine) executeSentry(sentry *Sentry) {
 	// Get controller for result handling (but don't pause yet)
 	controller := se.getRoutineController()
 
+	// A run that halts the main routine or fails has fired (see RoutineResult)
+	stats := runStatsOf(se.bot)
+	var haltsBefore int64
+	if stats != nil {
+		haltsBefore = stats.halts.Load()
+	}
+
 	// Execute the sentry routine (runs in parallel with main routine)
 	err := builder.Execute(se.bot)
 
@@ -136,6 +143,9 @@ func (se *SentryEngine) executeSentry(sentry *Sentry) {
 	if metrics := se.metrics[sentry.Routine]; metrics != nil {
 		metrics.RecordExecution(duration, err)
 	}
+	if stats != nil && (err != nil || stats.halts.Load() > haltsBefore) {
+		stats.recordSentryFire(sentry.Routine)
+	}
 
 	// Handle result based on success/failure
 	if controller != nil {
@@ -154,6 +164,7 @@ func (se *SentryEngine) handleSentryResult(sentry *Sentry, controller RoutineCon
 		// Failure: routine returned error
 		action = sentry.OnFailure
 		se.logSentry(sentry, fmt.Sprintf("Sentry failed (%v), action: %s", err, action))
+		captureFailure(se.bot, "sentry_"+sentry.Routine, err)
 	}
 
 	// Record action metrics
@@ -76,17 +76,10 @@ func (sm *SentryManager) Register(sentries []Sentry) error {
 				sm.bot.Instance(), key, sentry.Frequency)
 
 			// Load the sentry routine
-			routineRegistry := sm.bot.Routines()
-			if routineRegistry == nil {
-				return fmt.Errorf("routine registry not available")
+			if err := sentry.LoadRoutineBuilder(sm.bot.Routines()); err != nil {
+				return err
 			}
 
-			builder, err := routineRegistry.Get(sentry.Routine)
-			if err != nil {
-				return fmt.Errorf("sentry routine '%s' not found: %w", sentry.Routine, err)
-			}
-			sentry.SetRoutineBuilder(builder)
-
 			// Create and start sentry engine
 			engine := NewSentryEngine(sm.bot, []Sentry{*sentry})
 			if err := engine.Start(); err != nil {
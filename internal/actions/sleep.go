@@ -2,16 +2,23 @@ package actions
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Sleep struct {
-	Duration int `yaml:"duration"`
+	Duration DurationExpr `yaml:"duration"`
 }
 
 func (a *Sleep) Validate(ab *ActionBuilder) error {
-	if a.Duration <= 0 {
-		return fmt.Errorf("duration (%d) must be greater than 0", a.Duration)
+	if a.Duration.Raw == "" {
+		return fmt.Errorf("duration is required")
+	}
+	if d, ok := a.Duration.Static(); ok && d <= 0 {
+		return fmt.Errorf("duration (%s) must be greater than 0", a.Duration.Raw)
 	}
 	return nil
 }
@@ -20,10 +27,60 @@ func (a *Sleep) Build(ab *ActionBuilder) *ActionBuilder {
 	step := Step{
 		name: "Sleep",
 		execute: func(bot BotInterface) error {
-			time.Sleep(time.Duration(a.Duration) * time.Millisecond)
+			duration, err := a.Duration.Resolve(bot.Variables())
+			if err != nil {
+				return fmt.Errorf("Sleep: %w", err)
+			}
+			humanizedSleep(bot, duration)
 			return nil
 		},
 	}
 	ab.steps = append(ab.steps, step)
 	return ab
 }
+
+// DurationExpr is a duration argument that may be a plain millisecond count (500),
+// a Go duration ("2s"), or contain ${variables} and {{ expressions }} ("{{ randInt(800,1200) }}ms")
+type DurationExpr struct {
+	Raw string
+}
+
+// UnmarshalYAML accepts both numbers and strings
+func (d *DurationExpr) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("duration must be a number or string")
+	}
+	d.Raw = strings.TrimSpace(node.Value)
+	return nil
+}
+
+// Static returns the duration if it contains no interpolation and parses
+func (d DurationExpr) Static() (time.Duration, bool) {
+	if HasInterpolation(d.Raw) {
+		return 0, false
+	}
+	duration, err := parseDurationValue(d.Raw)
+	return duration, err == nil
+}
+
+// Resolve interpolates variables and expressions, then parses the result
+func (d DurationExpr) Resolve(vars VariableStoreInterface) (time.Duration, error) {
+	value, err := InterpolateVariables(d.Raw, vars)
+	if err != nil {
+		return 0, err
+	}
+	return parseDurationValue(value)
+}
+
+// parseDurationValue parses a bare integer as milliseconds, otherwise as a Go duration
+func parseDurationValue(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if ms, err := strconv.Atoi(value); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s' (use milliseconds or e.g. 500ms, 2s)", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,57 @@
+package actions
+
+import (
+	"context"
+	"errors"
+)
+
+// StepErrorDecision is how a developer resolves a step error a bot paused on (pause-on-error mode)
+type StepErrorDecision int
+
+const (
+	StepErrorAbort  StepErrorDecision = iota // Fail the routine with the error, as without pause-on-error
+	StepErrorResume                          // Run the failed step again
+	StepErrorSkip                            // Continue with the step after the failed one
+)
+
+// String returns the decision's name
+func (d StepErrorDecision) String() string {
+	switch d {
+	case StepErrorResume:
+		return "resume"
+	case StepErrorSkip:
+		return "skip"
+	}
+	return "abort"
+}
+
+// abortedStepError marks a step error the developer chose to abort on, so enclosing
+// steps (e.g. the If or Repeat containing the step) fail with it instead of pausing again
+type abortedStepError struct {
+	err error
+}
+
+func (e *abortedStepError) Error() string { return e.err.Error() }
+func (e *abortedStepError) Unwrap() error { return e.err }
+
+// pauseOnStepError lets a bot in pause-on-error mode hold a failed step for inspection,
+// returning the developer's decision and whether the bot paused. The bot doesn't pause when
+// it isn't in that mode, the error is a stop or cancellation, or the error was already
+// aborted on in a nested step.
+func pauseOnStepError(ctx context.Context, bot BotInterface, stepName string, err error) (StepErrorDecision, bool) {
+	type stepErrorPauser interface {
+		PauseOnError() bool
+		PauseForStepError(ctx context.Context, step string, err error) StepErrorDecision
+	}
+
+	var aborted *abortedStepError
+	if !isRetryable(err) || errors.As(err, &aborted) {
+		return StepErrorAbort, false
+	}
+
+	pauser, ok := bot.(stepErrorPauser)
+	if !ok || !pauser.PauseOnError() {
+		return StepErrorAbort, false
+	}
+	return pauser.PauseForStepError(ctx, stepName, err), true
+}
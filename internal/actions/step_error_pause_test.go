@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func assertSteps(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// pausingBot is in pause-on-error mode and answers each pause with the next scripted decision
+type pausingBot struct {
+	retryBot
+	decisions []StepErrorDecision
+	paused    []string
+}
+
+func (b *pausingBot) PauseOnError() bool { return true }
+
+func (b *pausingBot) PauseForStepError(ctx context.Context, step string, err error) StepErrorDecision {
+	b.paused = append(b.paused, step)
+	decision := b.decisions[0]
+	b.decisions = b.decisions[1:]
+	return decision
+}
+
+func TestPauseOnErrorResumeSkipAbort(t *testing.T) {
+	failure := errors.New("template not found")
+	var ran []string
+	flakyCalls := 0
+
+	ab := NewActionBuilder()
+	ab.steps = append(ab.steps,
+		Step{name: "Flaky", execute: func(BotInterface) error {
+			flakyCalls++
+			ran = append(ran, "Flaky")
+			if flakyCalls == 1 {
+				return failure
+			}
+			return nil
+		}},
+		Step{name: "Broken", execute: func(BotInterface) error { ran = append(ran, "Broken"); return failure }},
+		Step{name: "After", execute: func(BotInterface) error { ran = append(ran, "After"); return nil }},
+	)
+
+	// Resume runs the failed step again; skip moves on to the next one
+	bot := &pausingBot{decisions: []StepErrorDecision{StepErrorResume, StepErrorSkip}}
+	if err := ab.executeSteps(context.Background(), bot); err != nil {
+		t.Fatalf("expected the run to finish, got %v", err)
+	}
+	assertSteps(t, ran, "Flaky", "Flaky", "Broken", "After")
+	assertSteps(t, bot.paused, "Flaky", "Broken")
+
+	// An aborted nested step fails the enclosing step without pausing again
+	inner := NewActionBuilder()
+	inner.steps = append(inner.steps, Step{name: "Broken", execute: func(BotInterface) error { return failure }})
+	outer := NewActionBuilder()
+	outer.steps = append(outer.steps, Step{name: "Repeat", execute: func(b BotInterface) error {
+		return inner.executeSteps(context.Background(), b)
+	}})
+
+	bot = &pausingBot{decisions: []StepErrorDecision{StepErrorAbort}}
+	if err := outer.executeSteps(context.Background(), bot); !errors.Is(err, failure) {
+		t.Fatalf("expected the step error after abort, got %v", err)
+	}
+	assertSteps(t, bot.paused, "Broken")
+}
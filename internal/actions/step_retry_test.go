@@ -0,0 +1,87 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// retryBot is the minimum bot executeSteps needs; other methods are never called
+type retryBot struct {
+	BotInterface
+}
+
+func (retryBot) Instance() int                                 { return 1 }
+func (retryBot) RoutineController() RoutineControllerInterface { return nil }
+func (retryBot) Manager() interface{}                          { return nil }
+
+func TestStepRetryRecoversFlakyStep(t *testing.T) {
+	calls := 0
+	ab := NewActionBuilder()
+	ab.steps = append(ab.steps, Step{
+		name:  "FlakyClick",
+		retry: StepRetry{MaxAttempts: 3},
+		execute: func(BotInterface) error {
+			calls++
+			if calls < 3 {
+				return errors.New("missed")
+			}
+			return nil
+		},
+	})
+
+	if err := ab.executeSteps(context.Background(), retryBot{}); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestStepRetryExhaustedPropagates(t *testing.T) {
+	calls := 0
+	failure := errors.New("missed")
+	ab := NewActionBuilder()
+	ab.steps = append(ab.steps, Step{
+		name:    "FlakyClick",
+		retry:   StepRetry{MaxAttempts: 2},
+		execute: func(BotInterface) error { calls++; return failure },
+	})
+
+	err := ab.executeSteps(context.Background(), retryBot{})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the step error to propagate, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestStepRetryParsedFromYAML(t *testing.T) {
+	data := []byte(`
+routine_name: retry
+steps:
+  - action: delay
+    count: 1
+    retry:
+      max_attempts: 4
+      delay: 250
+`)
+	var routine Routine
+	if err := yaml.Unmarshal(data, &routine); err != nil {
+		t.Fatal(err)
+	}
+
+	ab := NewActionBuilder().buildAction(routine.Steps[0])
+	retry := ab.steps[len(ab.steps)-1].retry
+	if retry.MaxAttempts != 4 || retry.Delay.Milliseconds() != 250 {
+		t.Errorf("retry = %+v, want 4 attempts with 250ms delay", retry)
+	}
+
+	bad := []byte("routine_name: retry\nsteps:\n  - action: delay\n    count: 1\n    retry: {max_attempts: 0}\n")
+	if err := yaml.Unmarshal(bad, &routine); err == nil {
+		t.Error("expected max_attempts 0 to be rejected")
+	}
+}
@@ -12,6 +12,8 @@ import (
 var conditionRegistry = map[string]reflect.Type{
 	"imageexists":                reflect.TypeOf(ImageExists{}),
 	"imagenotexists":             reflect.TypeOf(ImageNotExists{}),
+	"colordetected":              reflect.TypeOf(ColorDetected{}),
+	"wonderpickavailable":        reflect.TypeOf(WonderPickAvailable{}),
 	"not":                        reflect.TypeOf(Not{}),
 	"all":                        reflect.TypeOf(All{}),
 	"any":                        reflect.TypeOf(Any{}),
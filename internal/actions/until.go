@@ -91,7 +91,7 @@ func (a *Until) Build(ab *ActionBuilder) *ActionBuilder {
 
 				// Check pause/stop state
 				if !ab.checkExecutionState(bot) {
-					return fmt.Errorf("Until: routine stopped by controller")
+					return fmt.Errorf("Until: %w", errRoutineStopped)
 				}
 
 				// Evaluate condition
@@ -122,7 +122,8 @@ func (a *Until) Build(ab *ActionBuilder) *ActionBuilder {
 				time.Sleep(100 * time.Millisecond)
 			}
 		},
-		issue: a.Validate(ab),
+		issue:     a.Validate(ab),
+		allowLoop: a.MaxAttempts > 0, // Bounded by max_attempts, so never a runaway loop
 	}
 
 	ab.steps = append(ab.steps, step)
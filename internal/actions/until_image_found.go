@@ -98,7 +98,7 @@ func (a *UntilImageFound) Build(ab *ActionBuilder) *ActionBuilder {
 				time.Sleep(100 * time.Millisecond)
 
 				if !ab.checkExecutionState(bot) {
-					return fmt.Errorf("routine stopped by controller during loop")
+					return fmt.Errorf("%w during loop", errRoutineStopped)
 				}
 			}
 		},
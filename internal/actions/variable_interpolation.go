@@ -9,10 +9,11 @@ import (
 // Variable interpolation pattern: ${variable_name}
 var interpolationPattern = regexp.MustCompile(`\$\{([a-zA-Z0-9_]+)\}`)
 
-// InterpolateVariables replaces ${variable_name} patterns with their values
+// InterpolateVariables replaces ${variable_name} patterns with their values,
+// then evaluates any {{ expression }} blocks (see InterpolateExpressions)
 // Returns the interpolated string and any error if variables are not found
 func InterpolateVariables(input string, vars VariableStoreInterface) (string, error) {
-	if !strings.Contains(input, "${") {
+	if !HasInterpolation(input) {
 		// Fast path: no interpolation needed
 		return input, nil
 	}
@@ -34,7 +35,7 @@ func InterpolateVariables(input string, vars VariableStoreInterface) (string, er
 		return result, fmt.Errorf("undefined variables: %v", missingVars)
 	}
 
-	return result, nil
+	return InterpolateExpressions(result, vars)
 }
 
 // InterpolateVariablesWithDefault is like InterpolateVariables but returns a default value for missing variables
@@ -53,9 +54,9 @@ func InterpolateVariablesWithDefault(input string, vars VariableStoreInterface,
 	})
 }
 
-// HasInterpolation checks if a string contains variable interpolation syntax
+// HasInterpolation checks if a string contains variable or expression interpolation syntax
 func HasInterpolation(input string) bool {
-	return strings.Contains(input, "${")
+	return strings.Contains(input, "${") || strings.Contains(input, "{{")
 }
 
 // ExtractVariableNames returns all variable names referenced in the string
@@ -4,27 +4,138 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 )
 
-// VariableStore is a thread-safe implementation of VariableStoreInterface
+// Well-known variables set by the bot runtime.
+// Both are persistent: they must survive the ClearNonPersistent call that starts
+// every routine iteration, so they are written with SetPersistent.
+const (
+	// VarDeviceAccountID is the database ID of the injected account (set by InjectNextAccount,
+	// removed when the account is released)
+	VarDeviceAccountID = "device_account_id"
+
+	// VarExecutionID is the routine execution tracking ID (set before each iteration starts)
+	VarExecutionID = "execution_id"
+)
+
+// variableHistorySize is how many recent values are kept per variable for History
+const variableHistorySize = 20
+
+// VariableChange is a recorded change to a single variable
+type VariableChange struct {
+	Time    time.Time
+	Value   string
+	Deleted bool // True if the variable was deleted or cleared
+}
+
+// VariableStore is a thread-safe implementation of VariableStoreInterface.
+// Variables are non-persistent by default and are cleared between routine iterations
+// by ClearNonPersistent. Use SetPersistent (or `persist: true` on a config param) for
+// values that must carry over, such as account state shared across iterations.
 type VariableStore struct {
 	mu         sync.RWMutex
 	vars       map[string]string
 	persistent map[string]bool // Tracks which variables should persist between routine iterations
+
+	// Account-state variables (see MarkAccountState) and the value each takes for an
+	// account with none stored
+	accountState map[string]string
+
+	// Change tracking
+	history     map[string][]VariableChange // Recent changes per variable, oldest first
+	watchers    map[int]chan struct{}       // Signalled (coalesced) whenever any variable changes
+	nextWatchID int
 }
 
 // NewVariableStore creates a new variable store
 func NewVariableStore() *VariableStore {
 	return &VariableStore{
-		vars:       make(map[string]string),
-		persistent: make(map[string]bool),
+		vars:         make(map[string]string),
+		persistent:   make(map[string]bool),
+		accountState: make(map[string]string),
+		history:      make(map[string][]VariableChange),
+		watchers:     make(map[int]chan struct{}),
 	}
 }
 
 func (vs *VariableStore) Set(name string, value string) {
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
+	vs.setLocked(name, value)
+}
+
+// setLocked sets a variable and records the change if the value differs (caller must hold mu)
+func (vs *VariableStore) setLocked(name string, value string) {
+	if old, ok := vs.vars[name]; ok && old == value {
+		return
+	}
 	vs.vars[name] = value
+	vs.recordLocked(name, VariableChange{Time: time.Now(), Value: value})
+	vs.notifyLocked()
+}
+
+// deleteLocked removes a variable and records the deletion if it existed (caller must hold mu)
+func (vs *VariableStore) deleteLocked(name string) {
+	if _, ok := vs.vars[name]; !ok {
+		return
+	}
+	delete(vs.vars, name)
+	vs.recordLocked(name, VariableChange{Time: time.Now(), Deleted: true})
+	vs.notifyLocked()
+}
+
+// recordLocked appends a change to the variable's history (caller must hold mu)
+func (vs *VariableStore) recordLocked(name string, change VariableChange) {
+	history := append(vs.history[name], change)
+	if len(history) > variableHistorySize {
+		history = history[len(history)-variableHistorySize:]
+	}
+	vs.history[name] = history
+}
+
+// notifyLocked signals every watcher without blocking (caller must hold mu)
+func (vs *VariableStore) notifyLocked() {
+	for _, ch := range vs.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// Already signalled; the watcher will read the latest values
+		}
+	}
+}
+
+// Watch returns a channel that is signalled whenever a variable changes.
+// Signals are coalesced: one signal may stand for several changes.
+// Call the returned function to stop watching (the channel is then closed).
+func (vs *VariableStore) Watch() (<-chan struct{}, func()) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	id := vs.nextWatchID
+	vs.nextWatchID++
+	ch := make(chan struct{}, 1)
+	vs.watchers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			vs.mu.Lock()
+			defer vs.mu.Unlock()
+			delete(vs.watchers, id)
+			close(ch)
+		})
+	}
+}
+
+// History returns the recent changes to a variable, oldest first
+func (vs *VariableStore) History(name string) []VariableChange {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	history := make([]VariableChange, len(vs.history[name]))
+	copy(history, vs.history[name])
+	return history
 }
 
 func (vs *VariableStore) Get(name string) (string, bool) {
@@ -44,14 +155,79 @@ func (vs *VariableStore) Has(name string) bool {
 func (vs *VariableStore) Delete(name string) {
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
-	delete(vs.vars, name)
+	vs.deleteLocked(name)
+	delete(vs.persistent, name)
+}
+
+// SetPersistent sets a variable and marks it persistent so it survives ClearNonPersistent
+func (vs *VariableStore) SetPersistent(name string, value string) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	vs.setLocked(name, value)
+	vs.persistent[name] = true
+}
+
+// GetInt returns a variable parsed as an int (false if missing or malformed)
+func (vs *VariableStore) GetInt(name string) (int, bool) {
+	value, ok := vs.Get(name)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// GetFloat returns a variable parsed as a float64 (false if missing or malformed)
+func (vs *VariableStore) GetFloat(name string) (float64, bool) {
+	value, ok := vs.Get(name)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// GetBool returns a variable parsed as a bool ("true", "false", "1", "0", ...) (false if missing or malformed)
+func (vs *VariableStore) GetBool(name string) (bool, bool) {
+	value, ok := vs.Get(name)
+	if !ok {
+		return false, false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false
+	}
+	return b, true
+}
+
+// GetDuration returns a variable parsed as a duration; bare integers are milliseconds,
+// otherwise Go duration syntax is used (e.g. "5m") (false if missing or malformed)
+func (vs *VariableStore) GetDuration(name string) (time.Duration, bool) {
+	value, ok := vs.Get(name)
+	if !ok {
+		return 0, false
+	}
+	d, err := parseDurationValue(value)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
 }
 
 func (vs *VariableStore) Clear() {
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
-	vs.vars = make(map[string]string)
+	for name := range vs.vars {
+		vs.deleteLocked(name)
+	}
 	vs.persistent = make(map[string]bool)
+	vs.accountState = make(map[string]string)
 }
 
 // ClearNonPersistent clears all variables except those marked as persistent
@@ -63,7 +239,7 @@ func (vs *VariableStore) ClearNonPersistent() {
 	// Keep only persistent variables
 	for name := range vs.vars {
 		if !vs.persistent[name] {
-			delete(vs.vars, name)
+			vs.deleteLocked(name)
 		}
 	}
 }
@@ -75,6 +251,27 @@ func (vs *VariableStore) MarkPersistent(name string) {
 	vs.persistent[name] = true
 }
 
+// MarkAccountState marks a variable as account state: it is persistent, loaded from the
+// injected account's stored state on injection (defaultValue when the account has none) and
+// stored back when the account is released (see FlushAccountState)
+func (vs *VariableStore) MarkAccountState(name string, defaultValue string) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	vs.persistent[name] = true
+	vs.accountState[name] = defaultValue
+}
+
+// AccountStateVariables returns the account-state variables with their default values
+func (vs *VariableStore) AccountStateVariables() map[string]string {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	defaults := make(map[string]string, len(vs.accountState))
+	for name, value := range vs.accountState {
+		defaults[name] = value
+	}
+	return defaults
+}
+
 // IsPersistent checks if a variable is marked as persistent
 func (vs *VariableStore) IsPersistent(name string) bool {
 	vs.mu.RLock()
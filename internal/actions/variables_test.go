@@ -0,0 +1,182 @@
+package actions
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVariableStoreTypedGetters(t *testing.T) {
+	vs := NewVariableStore()
+	vs.Set("int", "42")
+	vs.Set("negative", "-7")
+	vs.Set("float", "2.5")
+	vs.Set("bool", "true")
+	vs.Set("bool_numeric", "0")
+	vs.Set("ms", "1500")
+	vs.Set("duration", "5m")
+	vs.Set("empty", "")
+	vs.Set("garbage", "abc")
+	vs.Set("int_with_suffix", "12px")
+
+	t.Run("GetInt", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			want   int
+			wantOK bool
+		}{
+			{"int", 42, true},
+			{"negative", -7, true},
+			{"float", 0, false},
+			{"empty", 0, false},
+			{"garbage", 0, false},
+			{"int_with_suffix", 0, false},
+			{"missing", 0, false},
+		}
+		for _, tt := range tests {
+			got, ok := vs.GetInt(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetInt(%q) = %d, %v; want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		}
+	})
+
+	t.Run("GetFloat", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			want   float64
+			wantOK bool
+		}{
+			{"float", 2.5, true},
+			{"int", 42, true},
+			{"empty", 0, false},
+			{"garbage", 0, false},
+			{"missing", 0, false},
+		}
+		for _, tt := range tests {
+			got, ok := vs.GetFloat(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetFloat(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		}
+	})
+
+	t.Run("GetBool", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			want   bool
+			wantOK bool
+		}{
+			{"bool", true, true},
+			{"bool_numeric", false, true},
+			{"int", false, false},
+			{"empty", false, false},
+			{"garbage", false, false},
+			{"missing", false, false},
+		}
+		for _, tt := range tests {
+			got, ok := vs.GetBool(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetBool(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		}
+	})
+
+	t.Run("GetDuration", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			want   time.Duration
+			wantOK bool
+		}{
+			{"ms", 1500 * time.Millisecond, true},
+			{"duration", 5 * time.Minute, true},
+			{"float", 0, false},
+			{"empty", 0, false},
+			{"garbage", 0, false},
+			{"missing", 0, false},
+		}
+		for _, tt := range tests {
+			got, ok := vs.GetDuration(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetDuration(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		}
+	})
+}
+
+func TestVariableStoreSetPersistent(t *testing.T) {
+	vs := NewVariableStore()
+	vs.Set("iteration_counter", "3")
+	vs.SetPersistent(VarDeviceAccountID, "17")
+
+	vs.ClearNonPersistent()
+
+	if vs.Has("iteration_counter") {
+		t.Error("non-persistent variable survived ClearNonPersistent")
+	}
+	if id, ok := vs.GetInt(VarDeviceAccountID); !ok || id != 17 {
+		t.Errorf("persistent variable lost: got %d, %v", id, ok)
+	}
+
+	// Deleting drops the persistent flag so a later Set is cleared again
+	vs.Delete(VarDeviceAccountID)
+	vs.Set(VarDeviceAccountID, "18")
+	vs.ClearNonPersistent()
+	if vs.Has(VarDeviceAccountID) {
+		t.Error("deleted variable kept its persistent flag")
+	}
+}
+
+func TestVariableStoreWatch(t *testing.T) {
+	vs := NewVariableStore()
+	changes, stop := vs.Watch()
+
+	vs.Set("counter", "1")
+	select {
+	case <-changes:
+	default:
+		t.Fatal("no signal after Set")
+	}
+
+	// Setting the same value is not a change
+	vs.Set("counter", "1")
+	select {
+	case <-changes:
+		t.Fatal("signal for unchanged value")
+	default:
+	}
+
+	stop()
+	if _, ok := <-changes; ok {
+		t.Error("channel not closed after stop")
+	}
+	stop() // Safe to call twice
+	vs.Set("counter", "2")
+}
+
+func TestVariableStoreHistory(t *testing.T) {
+	vs := NewVariableStore()
+	vs.Set("state", "a")
+	vs.Set("state", "a")
+	vs.Set("state", "b")
+	vs.ClearNonPersistent()
+
+	history := vs.History("state")
+	if len(history) != 3 {
+		t.Fatalf("got %d changes, want 3: %+v", len(history), history)
+	}
+	if history[0].Value != "a" || history[1].Value != "b" || !history[2].Deleted {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+	for i := 0; i < variableHistorySize+5; i++ {
+		vs.Set("state", strconv.Itoa(i))
+	}
+	history = vs.History("state")
+	if len(history) != variableHistorySize {
+		t.Fatalf("history not capped: got %d", len(history))
+	}
+	if last := history[len(history)-1].Value; last != strconv.Itoa(variableHistorySize+4) {
+		t.Errorf("last value = %s", last)
+	}
+}
@@ -0,0 +1,252 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/cv"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// WonderPickCue is how a routine recognizes that a (time-gated) wonder pick is available:
+// a template such as the wonder pick button in its active state and/or a color such as its
+// notification badge. When both are set, both must be on screen.
+type WonderPickCue struct {
+	Template  string     `yaml:"template,omitempty"`   // Template lookup by name
+	Threshold *float64   `yaml:"threshold,omitempty"`  // Optional: override template's threshold
+	Color     string     `yaml:"color,omitempty"`      // Hex color "#RRGGBB"
+	Tolerance *int       `yaml:"tolerance,omitempty"`  // Mean per-channel difference allowed (0-255, default 20)
+	MinPixels int        `yaml:"min_pixels,omitempty"` // Ignore blobs smaller than this
+	Region    *cv.Region `yaml:"region,omitempty"`     // Optional: limit the search area of both cues
+}
+
+// validate checks the cue, prefixing errors with the owning condition or action's name
+func (c *WonderPickCue) validate(ab *ActionBuilder, owner string) error {
+	if c.Template == "" && c.Color == "" {
+		return fmt.Errorf("%s: template or color is required", owner)
+	}
+	if c.Template != "" && ab.templateRegistry != nil && !ab.templateRegistry.Has(c.Template) {
+		return fmt.Errorf("%s: template '%s' not found in registry", owner, c.Template)
+	}
+	if c.Color != "" {
+		if err := validateColorSearch(c.Color, c.Tolerance, c.MinPixels); err != nil {
+			return fmt.Errorf("%s: %w", owner, err)
+		}
+	}
+	return nil
+}
+
+// available reports whether the cue is on screen
+func (c *WonderPickCue) available(bot BotInterface) (bool, error) {
+	if c.Template != "" {
+		templateName, err := InterpolateString(c.Template, bot)
+		if err != nil {
+			return false, err
+		}
+
+		template, config, err := buildTemplateConfiguration(bot, templateName, c.Threshold, c.Region)
+		if err != nil {
+			return false, fmt.Errorf("failed to build template configuration: %w", err)
+		}
+
+		bot.CV().InvalidateCache()
+		result, err := bot.CV().FindTemplate(template.Name, config)
+		if err != nil {
+			return false, fmt.Errorf("error checking template %s: %w", template.Name, err)
+		}
+		if !result.Found {
+			return false, nil
+		}
+	}
+
+	if c.Color != "" {
+		blob, err := findColorBlob(bot, c.Color, c.Tolerance, c.MinPixels, c.Region)
+		if err != nil {
+			return false, fmt.Errorf("color %s: %w", c.Color, err)
+		}
+		if blob == nil {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// WonderPickAvailable is a condition that is true when a wonder pick is available
+type WonderPickAvailable struct {
+	WonderPickCue `yaml:",inline"`
+}
+
+func (c *WonderPickAvailable) Validate(ab *ActionBuilder) error {
+	return c.validate(ab, "WonderPickAvailable")
+}
+
+func (c *WonderPickAvailable) Evaluate(bot BotInterface) (bool, error) {
+	available, err := c.available(bot)
+	if err != nil {
+		return false, fmt.Errorf("WonderPickAvailable: %w", err)
+	}
+	return available, nil
+}
+
+// WonderPick performs one wonder pick when the cue shows one is available; otherwise it does
+// nothing. The pick itself is done by actions. A successful pick is counted in the account's
+// and routine execution's wonder_picks_done, and every attempt is recorded in wonder_pick_results
+// with card and rarity (interpolated after actions run, so they can read variables the actions set).
+// If variable is set, "<variable>" is set to "true" when a pick was done and "false" otherwise.
+type WonderPick struct {
+	WonderPickCue `yaml:",inline"`
+	Actions       []ActionStep `yaml:"actions"`               // Perform the pick (required)
+	Card          string       `yaml:"card,omitempty"`        // Optional: card picked (supports variable interpolation)
+	Rarity        string       `yaml:"rarity,omitempty"`      // Optional: its rarity (supports variable interpolation)
+	EnergyCost    int          `yaml:"energy_cost,omitempty"` // Wonder energy spent
+	Free          bool         `yaml:"free,omitempty"`        // The pick was a free one
+	Variable      string       `yaml:"variable,omitempty"`    // Optional: store whether a pick was done
+}
+
+// UnmarshalYAML implements custom unmarshaling for WonderPick to handle polymorphic action fields
+func (a *WonderPick) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw struct {
+		WonderPickCue `yaml:",inline"`
+		Actions       interface{} `yaml:"actions"`
+		Card          string      `yaml:"card"`
+		Rarity        string      `yaml:"rarity"`
+		EnergyCost    int         `yaml:"energy_cost"`
+		Free          bool        `yaml:"free"`
+		Variable      string      `yaml:"variable"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	a.WonderPickCue = raw.WonderPickCue
+	a.Card = raw.Card
+	a.Rarity = raw.Rarity
+	a.EnergyCost = raw.EnergyCost
+	a.Free = raw.Free
+	a.Variable = raw.Variable
+
+	if raw.Actions != nil {
+		actions, err := unmarshalNestedActions(raw.Actions)
+		if err != nil {
+			return fmt.Errorf("WonderPick: failed to unmarshal actions: %w", err)
+		}
+		a.Actions = actions
+	}
+
+	return nil
+}
+
+func (a *WonderPick) Validate(ab *ActionBuilder) error {
+	if err := a.validate(ab, "WonderPick"); err != nil {
+		return err
+	}
+	if len(a.Actions) == 0 {
+		return fmt.Errorf("WonderPick: actions are required")
+	}
+	if a.EnergyCost < 0 {
+		return fmt.Errorf("WonderPick: energy_cost cannot be negative")
+	}
+
+	for i, action := range a.Actions {
+		if err := action.Validate(ab); err != nil {
+			return fmt.Errorf("WonderPick -> action %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *WonderPick) Build(ab *ActionBuilder) *ActionBuilder {
+	step := Step{
+		name: "WonderPick",
+		execute: func(bot BotInterface) error {
+			available, err := a.available(bot)
+			if err != nil {
+				return fmt.Errorf("WonderPick: %w", err)
+			}
+			if a.Variable != "" {
+				bot.Variables().Set(a.Variable, "false")
+			}
+			if !available {
+				fmt.Printf("Bot %d: No wonder pick available\n", bot.Instance())
+				return nil
+			}
+
+			subBuilder := &ActionBuilder{
+				steps: ab.buildSteps(a.Actions),
+			}
+			pickErr := subBuilder.executeSteps(bot.Context(), bot)
+
+			a.recordResult(bot, pickErr == nil)
+			if pickErr != nil {
+				return fmt.Errorf("WonderPick -> nested action failed: %w", pickErr)
+			}
+
+			if a.Variable != "" {
+				bot.Variables().Set(a.Variable, "true")
+			}
+			return nil
+		},
+		issue: a.Validate(ab),
+	}
+	ab.steps = append(ab.steps, step)
+	return ab
+}
+
+// recordResult records the pick in the database. Recording is best effort: the pick has already
+// happened, so a bot without a database or an injected account only logs a warning. Picks on
+// sandbox pool accounts are never recorded.
+func (a *WonderPick) recordResult(bot BotInterface, success bool) {
+	if pool, ok := bot.Manager().(interface {
+		AccountPool() accountpool.AccountPool
+	}); ok && accountpool.IsSandbox(pool.AccountPool()) {
+		return
+	}
+	db := accountsDB(bot)
+	if db == nil {
+		fmt.Printf("Bot %d: Warning - no database configured, wonder pick not recorded\n", bot.Instance())
+		return
+	}
+
+	accountID, exists := bot.Variables().GetInt(VarDeviceAccountID)
+	if !exists {
+		fmt.Printf("Bot %d: Warning - %s not set, wonder pick not recorded\n", bot.Instance(), VarDeviceAccountID)
+		return
+	}
+
+	card := a.interpolateOptional(bot, a.Card)
+	rarity := a.interpolateOptional(bot, a.Rarity)
+	if _, err := database.RecordWonderPick(db, int64(accountID), card, rarity, success, a.EnergyCost, a.Free); err != nil {
+		fmt.Printf("Bot %d: Warning - failed to record wonder pick: %v\n", bot.Instance(), err)
+		return
+	}
+
+	if success {
+		if executionID, exists := bot.Variables().GetInt(VarExecutionID); exists {
+			if err := database.IncrementRoutineExecutionWonderPicks(db, int64(executionID)); err != nil {
+				fmt.Printf("Bot %d: Warning - %v\n", bot.Instance(), err)
+			}
+		}
+	}
+
+	cardName := "unknown card"
+	if card != nil {
+		cardName = *card
+	}
+	fmt.Printf("Bot %d: Recorded wonder pick on account %d (%s, success: %v)\n", bot.Instance(), accountID, cardName, success)
+}
+
+// interpolateOptional interpolates an optional field, returning nil when it is empty or refers to
+// a variable that was never set (e.g. no nested action read the card)
+func (a *WonderPick) interpolateOptional(bot BotInterface, value string) *string {
+	if value == "" {
+		return nil
+	}
+	interpolated, err := InterpolateString(value, bot)
+	if err != nil || interpolated == "" || strings.Contains(interpolated, "${") {
+		return nil
+	}
+	return &interpolated
+}
@@ -40,10 +40,23 @@ func (c *Controller) translateY(y int) int {
 
 // Click performs a tap at the specified coordinates
 func (c *Controller) Click(x, y int) error {
+	return c.ClickTarget(x, y, 0)
+}
+
+// ClickTarget performs a tap near the specified coordinates.
+// maxRadius > 0 limits click jitter so the tap stays on a target of that size.
+func (c *Controller) ClickTarget(x, y, maxRadius int) error {
+	c.mu.Lock()
+	jitter := c.jitter
+	c.mu.Unlock()
+	if jitter != nil {
+		x, y = jitter.JitterPoint(x, y, maxRadius)
+	}
 	translatedX := c.translateX(x)
 	translatedY := c.translateY(y)
 	cmd := fmt.Sprintf("input tap %d %d", translatedX, translatedY)
 	fmt.Printf("Click: (%d, %d) -> (%d, %d)\n", x, y, translatedX, translatedY)
+	c.waitForInputSlot()
 	_, err := c.Shell(cmd)
 	return err
 }
@@ -60,6 +73,7 @@ func (c *Controller) Swipe(X1, Y1, X2, Y2 int, duration int) error {
 		translatedX1, translatedY1, translatedX2, translatedY2, duration)
 	fmt.Printf("Swipe: (%d,%d)->(%d,%d) translated to (%d,%d)->(%d,%d) over %dms\n",
 		X1, Y1, X2, Y2, translatedX1, translatedY1, translatedX2, translatedY2, duration)
+	c.waitForInputSlot()
 	_, err := c.Shell(cmd)
 	return err
 }
@@ -67,6 +81,7 @@ func (c *Controller) Swipe(X1, Y1, X2, Y2 int, duration int) error {
 // SendKey sends a key event (e.g., "KEYCODE_BACK", "KEYCODE_HOME")
 func (c *Controller) SendKey(key string) error {
 	cmd := fmt.Sprintf("input keyevent %s", key)
+	c.waitForInputSlot()
 	_, err := c.Shell(cmd)
 	return err
 }
@@ -76,6 +91,7 @@ func (c *Controller) Input(text string) error {
 	// Escape spaces and special characters
 	escapedText := strings.ReplaceAll(text, " ", "%s")
 	cmd := fmt.Sprintf("input text %s", escapedText)
+	c.waitForInputSlot()
 	_, err := c.Shell(cmd)
 	return err
 }
@@ -120,6 +136,14 @@ func (c *Controller) StartApp(packageName, activity string) error {
 	return err
 }
 
+// LaunchApp starts an application through its launcher activity, so the activity name
+// doesn't need to be known
+func (c *Controller) LaunchApp(packageName string) error {
+	cmd := fmt.Sprintf("monkey -p %s -c android.intent.category.LAUNCHER 1", packageName)
+	_, err := c.Shell(cmd)
+	return err
+}
+
 // ClearAppData clears application data
 func (c *Controller) ClearAppData(packageName string) error {
 	cmd := fmt.Sprintf("pm clear %s", packageName)
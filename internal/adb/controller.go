@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 )
 
 // CoordinateTranslator interface for dependency injection
@@ -15,6 +16,11 @@ type CoordinateTranslator interface {
 	TranslatePoint(x, y int) (int, int)
 }
 
+// ClickJitter randomizes click coordinates (in source coordinates, before translation)
+type ClickJitter interface {
+	JitterPoint(x, y, maxRadius int) (int, int)
+}
+
 // ADB controller type and lifecycle
 type Controller struct {
 	path       string
@@ -27,6 +33,8 @@ type Controller struct {
 	device     string // Device ID: "127.0.0.1:port"
 	connected  bool
 	translator CoordinateTranslator // Coordinate translation (optional, uses defaults if nil)
+	jitter     ClickJitter          // Click randomization (optional, exact clicks if nil)
+	throttle   *Throttle            // Spacing between input commands (optional, unthrottled if nil)
 }
 
 // NewController creates a new ADB controller
@@ -107,6 +115,12 @@ func (c *Controller) Disconnect() error {
 	return nil
 }
 
+// Reconnect drops the shell session and connects again, e.g. after the ADB server restarted
+func (c *Controller) Reconnect() error {
+	c.Disconnect()
+	return c.Connect()
+}
+
 // IsConnected returns whether the controller is connected
 func (c *Controller) IsConnected() bool {
 	c.mu.Lock()
@@ -120,3 +134,40 @@ func (c *Controller) SetCoordinateTranslator(translator CoordinateTranslator) {
 	defer c.mu.Unlock()
 	c.translator = translator
 }
+
+// SetClickJitter sets the click randomizer for this controller (nil disables it)
+func (c *Controller) SetClickJitter(jitter ClickJitter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jitter = jitter
+}
+
+// SetInputThrottle spaces input commands (tap, swipe, key, text) at least interval apart.
+// The controller is shared by everything driving the instance, so the spacing applies
+// across all callers. An interval <= 0 disables throttling.
+func (c *Controller) SetInputThrottle(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if interval <= 0 {
+		c.throttle = nil
+		return
+	}
+	c.throttle = NewThrottle(interval, 1)
+}
+
+// InputThrottleStats returns how often input commands waited on the throttle
+func (c *Controller) InputThrottleStats() ThrottleStats {
+	c.mu.Lock()
+	throttle := c.throttle
+	c.mu.Unlock()
+	return throttle.Stats()
+}
+
+// waitForInputSlot blocks until the throttle allows another input command.
+// The controller lock is not held while waiting.
+func (c *Controller) waitForInputSlot() {
+	c.mu.Lock()
+	throttle := c.throttle
+	c.mu.Unlock()
+	throttle.Wait()
+}
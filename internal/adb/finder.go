@@ -1,6 +1,7 @@
 package adb
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,16 +10,39 @@ import (
 	"strings"
 )
 
-// FindADB attempts to locate the ADB executable
-func FindADB(preferredPath string) (string, error) {
-	// Try preferred path first
-	if preferredPath != "" {
-		adbPath := filepath.Join(preferredPath, "adb", "adb.exe")
-		if runtime.GOOS != "windows" {
-			adbPath = filepath.Join(preferredPath, "adb", "adb")
+// installationADBPaths are where an emulator installation keeps its ADB executable,
+// relative to the installation folder
+func installationADBPaths() []string {
+	if runtime.GOOS != "windows" {
+		return []string{filepath.Join("adb", "adb")}
+	}
+	return []string{
+		filepath.Join("adb", "adb.exe"),
+		filepath.Join("shell", "adb.exe"),
+		filepath.Join("vmonitor", "bin", "adb_server.exe"), // MuMu 12
+	}
+}
+
+// FindADBInFolder looks for the ADB executable of one emulator installation
+func FindADBInFolder(folderPath string) (string, error) {
+	for _, relPath := range installationADBPaths() {
+		adbPath := filepath.Join(folderPath, relPath)
+		if info, err := os.Stat(adbPath); err == nil && !info.IsDir() {
+			return adbPath, nil
 		}
+	}
+	return "", fmt.Errorf("adb not found in %s", folderPath)
+}
 
-		if _, err := os.Stat(adbPath); err == nil {
+// FindADB attempts to locate the ADB executable. The preferred installation folders are
+// searched in order (e.g. one per MuMu installation) before the common locations.
+func FindADB(preferredPaths ...string) (string, error) {
+	// Try preferred paths first
+	for _, preferredPath := range preferredPaths {
+		if preferredPath == "" {
+			continue
+		}
+		if adbPath, err := FindADBInFolder(preferredPath); err == nil {
 			return adbPath, nil
 		}
 	}
@@ -65,6 +89,19 @@ func FindADB(preferredPath string) (string, error) {
 	return "", fmt.Errorf("adb not found, please specify path in config")
 }
 
+// Version runs `adb version` and returns its first line, checking that the executable responds
+func Version(adbPath string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), serverCommandTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, adbPath, "version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("adb version failed: %w, output: %s", err, output)
+	}
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(firstLine), nil
+}
+
 // DetectMuMuPort attempts to detect the MuMu emulator port
 func DetectMuMuPort() (string, error) {
 	// Common MuMu ports
@@ -0,0 +1,32 @@
+package adb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindADBSearchesEachInstallation(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+
+	// Only the second installation has an ADB executable
+	relPath := installationADBPaths()[len(installationADBPaths())-1]
+	adbPath := filepath.Join(second, relPath)
+	if err := os.MkdirAll(filepath.Dir(adbPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(adbPath, nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FindADBInFolder(first); err == nil {
+		t.Error("expected no ADB in the first installation")
+	}
+	if found, err := FindADBInFolder(second); err != nil || found != adbPath {
+		t.Errorf("FindADBInFolder(second) = %q, %v; want %q", found, err, adbPath)
+	}
+	if found, err := FindADB("", first, second); err != nil || found != adbPath {
+		t.Errorf("FindADB = %q, %v; want %q", found, err, adbPath)
+	}
+}
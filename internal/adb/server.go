@@ -0,0 +1,283 @@
+package adb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const (
+	// serverAddress is where the ADB server listens (ADB's default port)
+	serverAddress = "127.0.0.1:5037"
+
+	// serverHealthInterval is how often a server with connections is checked
+	serverHealthInterval = 15 * time.Second
+
+	// serverCommandTimeout bounds start-server and kill-server
+	serverCommandTimeout = 15 * time.Second
+)
+
+// ServerStatus describes the shared ADB server (see Server.Status)
+type ServerStatus struct {
+	Path        string    // ADB executable
+	Running     bool      // Server answered the last health check
+	Monitoring  bool      // Health monitor is running (while connections are held)
+	Connections int       // Controllers connected through the server
+	Restarts    int       // Restarts since startup (manual and after crashes)
+	LastCheck   time.Time // Last health check
+	LastError   string    // Last start/restart failure ("" if none)
+}
+
+// Server manages the ADB server process shared by every instance. It starts the server when it
+// isn't running, hands out device connections, and while connections are held it checks the
+// server's health and restarts it (reconnecting every controller) if it died.
+type Server struct {
+	path string
+
+	mu          sync.Mutex
+	controllers map[string]*Controller // By device
+	running     bool
+	restarts    int
+	lastCheck   time.Time
+	lastError   string
+	monitorStop chan struct{}
+	monitorDone chan struct{}
+
+	// restartMu serializes restarts so a crash seen by several callers restarts the server once
+	restartMu sync.Mutex
+}
+
+var (
+	serversMu sync.Mutex
+	servers   = make(map[string]*Server)
+)
+
+// ServerFor returns the shared server for an ADB executable
+func ServerFor(adbPath string) *Server {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	server, exists := servers[adbPath]
+	if !exists {
+		server = &Server{
+			path:        adbPath,
+			controllers: make(map[string]*Controller),
+		}
+		servers[adbPath] = server
+	}
+	return server
+}
+
+// isAlive reports whether the ADB server accepts connections
+func isAlive() bool {
+	conn, err := net.DialTimeout("tcp", serverAddress, time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// run runs an ADB server command
+func (s *Server) run(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), serverCommandTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, s.path, args...).CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("adb %v failed: %w, output: %s", args, err, output)
+	}
+	return string(output), nil
+}
+
+// recordCheck stores the outcome of a health check or start
+func (s *Server) recordCheck(running bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running = running
+	s.lastCheck = time.Now()
+	if err != nil {
+		s.lastError = err.Error()
+	} else if running {
+		s.lastError = ""
+	}
+}
+
+// EnsureRunning starts the ADB server if it isn't running
+func (s *Server) EnsureRunning() error {
+	if isAlive() {
+		s.recordCheck(true, nil)
+		return nil
+	}
+
+	if _, err := s.run("start-server"); err != nil {
+		s.recordCheck(false, err)
+		return fmt.Errorf("failed to start ADB server: %w", err)
+	}
+
+	s.recordCheck(true, nil)
+	return nil
+}
+
+// Connect ensures the server is running and returns a controller connected to the device on
+// 127.0.0.1:port. The server tracks the controller until Release, reconnecting it after restarts.
+func (s *Server) Connect(port string) (*Controller, error) {
+	if err := s.EnsureRunning(); err != nil {
+		return nil, err
+	}
+
+	ctrl := NewController(s.path, port)
+	if err := ctrl.Connect(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.controllers[ctrl.device] = ctrl
+	s.mu.Unlock()
+
+	s.startMonitor()
+	return ctrl, nil
+}
+
+// Release disconnects a controller and stops tracking it. The health monitor stops once no
+// controllers are left.
+func (s *Server) Release(ctrl *Controller) {
+	if ctrl == nil {
+		return
+	}
+	ctrl.Disconnect()
+
+	s.mu.Lock()
+	if s.controllers[ctrl.device] == ctrl {
+		delete(s.controllers, ctrl.device)
+	}
+	idle := len(s.controllers) == 0
+	s.mu.Unlock()
+
+	if idle {
+		s.stopMonitor()
+	}
+}
+
+// Restart kills and starts the ADB server, then reconnects every tracked controller.
+// Returns the first error; controllers that fail to reconnect stay tracked and are
+// retried on the next restart.
+func (s *Server) Restart() error {
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+
+	s.mu.Lock()
+	s.restarts++
+	s.mu.Unlock()
+
+	// kill-server fails when no server is running, which is fine
+	s.run("kill-server")
+
+	if _, err := s.run("start-server"); err != nil {
+		s.recordCheck(false, err)
+		return fmt.Errorf("failed to start ADB server: %w", err)
+	}
+	s.recordCheck(true, nil)
+
+	s.mu.Lock()
+	controllers := make([]*Controller, 0, len(s.controllers))
+	for _, ctrl := range s.controllers {
+		controllers = append(controllers, ctrl)
+	}
+	s.mu.Unlock()
+
+	var firstErr error
+	for _, ctrl := range controllers {
+		if err := ctrl.Reconnect(); err != nil {
+			fmt.Printf("ADB: Failed to reconnect %s after server restart: %v\n", ctrl.device, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		s.recordCheck(true, firstErr)
+	}
+	return firstErr
+}
+
+// Status returns the server's current status
+func (s *Server) Status() ServerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return ServerStatus{
+		Path:        s.path,
+		Running:     s.running,
+		Monitoring:  s.monitorStop != nil,
+		Connections: len(s.controllers),
+		Restarts:    s.restarts,
+		LastCheck:   s.lastCheck,
+		LastError:   s.lastError,
+	}
+}
+
+// startMonitor starts the health monitor if it isn't running
+func (s *Server) startMonitor() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.monitorStop != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	s.monitorStop = stop
+	s.monitorDone = done
+
+	go s.runMonitor(stop, done)
+}
+
+// stopMonitor stops the health monitor and waits for it to exit
+func (s *Server) stopMonitor() {
+	s.mu.Lock()
+	stop, done := s.monitorStop, s.monitorDone
+	s.monitorStop = nil
+	s.monitorDone = nil
+	s.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// runMonitor restarts the server whenever it stops answering, until stop is closed
+func (s *Server) runMonitor(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(serverHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if isAlive() {
+				s.recordCheck(true, nil)
+				continue
+			}
+
+			s.recordCheck(false, nil)
+			fmt.Println("ADB: Server is not responding, restarting...")
+			if err := s.Restart(); err != nil {
+				fmt.Printf("ADB: Server restart failed: %v\n", err)
+			} else {
+				fmt.Println("ADB: Server restarted and devices reconnected")
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package adb
+
+import "testing"
+
+func TestServerForIsShared(t *testing.T) {
+	first := ServerFor("/opt/adb-test/adb")
+	if ServerFor("/opt/adb-test/adb") != first {
+		t.Fatal("same path should share one server")
+	}
+	if ServerFor("/opt/other/adb") == first {
+		t.Fatal("different paths should get different servers")
+	}
+
+	status := first.Status()
+	if status.Path != "/opt/adb-test/adb" || status.Connections != 0 || status.Monitoring {
+		t.Errorf("unexpected idle status %+v", status)
+	}
+
+	// Releasing nothing must not start or stop anything
+	first.Release(nil)
+	if first.Status().Monitoring {
+		t.Error("monitor should not run without connections")
+	}
+}
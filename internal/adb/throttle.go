@@ -0,0 +1,100 @@
+package adb
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottleStats reports how often input commands had to wait for the throttle
+type ThrottleStats struct {
+	Commands  int64         // Commands that passed through the throttle
+	Waits     int64         // Commands that had to wait
+	TotalWait time.Duration // Total time spent waiting
+}
+
+// Throttle is a token bucket that spaces out input commands sent to one emulator.
+// Each command takes a token; tokens refill at one per interval up to burst.
+// With a burst of 1, consecutive commands are at least interval apart.
+type Throttle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	tokens   float64
+	last     time.Time
+	stats    ThrottleStats
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewThrottle creates a throttle allowing one command per interval with up to burst
+// commands back to back. An interval <= 0 disables throttling.
+func NewThrottle(interval time.Duration, burst int) *Throttle {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Throttle{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+		now:      time.Now,
+		sleep:    time.Sleep,
+	}
+}
+
+// Wait blocks until the next command may be sent. The token is reserved before
+// sleeping, so concurrent callers are queued one interval apart.
+func (t *Throttle) Wait() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.stats.Commands++
+	if t.interval <= 0 {
+		t.mu.Unlock()
+		return
+	}
+
+	now := t.now()
+	if !t.last.IsZero() {
+		t.tokens += float64(now.Sub(t.last)) / float64(t.interval)
+		if t.tokens > float64(t.burst) {
+			t.tokens = float64(t.burst)
+		}
+	}
+	t.last = now
+	t.tokens--
+
+	var delay time.Duration
+	if t.tokens < 0 {
+		delay = time.Duration(-t.tokens * float64(t.interval))
+		t.stats.Waits++
+		t.stats.TotalWait += delay
+	}
+	t.mu.Unlock()
+
+	if delay > 0 {
+		t.sleep(delay)
+	}
+}
+
+// Interval returns the minimum spacing between commands
+func (t *Throttle) Interval() time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.interval
+}
+
+// Stats returns a snapshot of the throttle counters
+func (t *Throttle) Stats() ThrottleStats {
+	if t == nil {
+		return ThrottleStats{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
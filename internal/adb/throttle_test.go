@@ -0,0 +1,106 @@
+package adb
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock lets throttle tests run without real sleeps
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestThrottle(interval time.Duration, burst int) (*Throttle, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	throttle := NewThrottle(interval, burst)
+	throttle.now = clock.Now
+	throttle.sleep = clock.Advance
+	return throttle, clock
+}
+
+func TestThrottleSpacesCommands(t *testing.T) {
+	interval := 100 * time.Millisecond
+	throttle, clock := newTestThrottle(interval, 1)
+
+	var sent []time.Time
+	for i := 0; i < 5; i++ {
+		throttle.Wait()
+		sent = append(sent, clock.Now())
+	}
+
+	for i := 1; i < len(sent); i++ {
+		if gap := sent[i].Sub(sent[i-1]); gap < interval {
+			t.Errorf("commands %d and %d only %v apart, want at least %v", i, i+1, gap, interval)
+		}
+	}
+
+	stats := throttle.Stats()
+	if stats.Commands != 5 || stats.Waits != 4 {
+		t.Errorf("expected 5 commands and 4 waits, got %+v", stats)
+	}
+	if stats.TotalWait != 4*interval {
+		t.Errorf("expected %v total wait, got %v", 4*interval, stats.TotalWait)
+	}
+}
+
+func TestThrottleDoesNotDelaySpacedCommands(t *testing.T) {
+	throttle, clock := newTestThrottle(100*time.Millisecond, 1)
+
+	for i := 0; i < 3; i++ {
+		throttle.Wait()
+		clock.Advance(250 * time.Millisecond) // Actions already slower than the limit
+	}
+
+	if stats := throttle.Stats(); stats.Waits != 0 {
+		t.Errorf("commands spaced beyond the interval should not wait, got %d waits", stats.Waits)
+	}
+}
+
+func TestThrottleRealTimeSpacing(t *testing.T) {
+	interval := 20 * time.Millisecond
+	throttle := NewThrottle(interval, 1)
+
+	// Concurrent callers are queued one interval apart
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			throttle.Wait()
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 3*interval {
+		t.Errorf("4 commands finished in %v, want at least %v", elapsed, 3*interval)
+	}
+}
+
+func TestThrottleDisabled(t *testing.T) {
+	throttle, clock := newTestThrottle(0, 1)
+	before := clock.Now()
+	for i := 0; i < 3; i++ {
+		throttle.Wait()
+	}
+	if !clock.Now().Equal(before) {
+		t.Error("a zero interval should never wait")
+	}
+
+	var nilThrottle *Throttle
+	nilThrottle.Wait() // Must not panic
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/bot"
+)
+
+// GroupSummary is one entry of GET /api/groups
+type GroupSummary struct {
+	Name          string
+	RoutineName   string
+	Running       bool
+	RequestedBots int
+	ActiveBots    int
+}
+
+// GroupProgress is a group's account progress and completion estimate
+type GroupProgress struct {
+	Processed   int
+	Total       int
+	RatePerHour float64
+	ETASeconds  int64
+	Estimating  bool // Too few accounts have completed to estimate yet
+}
+
+// GroupStatus is the response of GET /api/groups/{name}
+type GroupStatus struct {
+	Name            string
+	OrchestrationID string
+	Running         bool
+	Bots            []*bot.BotInfo
+	QueuedInstances []int
+	Progress        *GroupProgress `json:",omitempty"`
+}
+
+// PoolSummary is one entry of GET /api/pools
+type PoolSummary struct {
+	Name  string
+	Open  bool                   // Whether a pool instance is loaded (stats are only reported for open pools)
+	Stats *accountpool.PoolStats `json:",omitempty"`
+}
+
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	definitions := s.orchestrator.ListGroupDefinitions()
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+
+	summaries := make([]GroupSummary, 0, len(definitions))
+	for _, def := range definitions {
+		summary := GroupSummary{
+			Name:          def.Name,
+			RoutineName:   def.RoutineName,
+			RequestedBots: def.RequestedBotCount,
+		}
+		if group, exists := s.orchestrator.GetGroup(def.Name); exists {
+			summary.Running = group.IsRunning()
+			summary.ActiveBots = len(group.GetAllBotInfo())
+		}
+		summaries = append(summaries, summary)
+	}
+
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (s *Server) handleGroupStatus(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	group, exists := s.orchestrator.GetGroup(name)
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("group '%s' not found", name))
+		return
+	}
+
+	status := GroupStatus{
+		Name:            group.Name,
+		OrchestrationID: group.OrchestrationID,
+		Running:         group.IsRunning(),
+		Bots:            make([]*bot.BotInfo, 0),
+		QueuedInstances: s.orchestrator.GetQueuedInstances(name),
+	}
+
+	infos := group.GetAllBotInfo()
+	for _, info := range infos {
+		status.Bots = append(status.Bots, info)
+	}
+	sort.Slice(status.Bots, func(i, j int) bool { return status.Bots[i].InstanceID < status.Bots[j].InstanceID })
+
+	if group.AccountPool != nil {
+		processed, total, rate, eta, err := s.orchestrator.GroupETA(name)
+		if err == nil || errors.Is(err, bot.ErrETAUnavailable) {
+			status.Progress = &GroupProgress{
+				Processed:   processed,
+				Total:       total,
+				RatePerHour: rate,
+				ETASeconds:  int64(eta.Seconds()),
+				Estimating:  err != nil,
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleStartGroup(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	def, err := s.orchestrator.LoadGroupDefinition(name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if _, err := s.orchestrator.EnsureRuntimeGroup(name); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Refresh instance state before launching, as the GUI does
+	if emulatorManager := s.orchestrator.GetEmulatorManager(); emulatorManager != nil {
+		if err := emulatorManager.DiscoverInstances(); err != nil {
+			fmt.Printf("Warning: Failed to discover instances before launch: %v\n", err)
+		}
+	}
+
+	result, err := s.orchestrator.LaunchGroup(name, def.LaunchOptions)
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleStopGroup(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if err := s.orchestrator.StopGroup(name); err != nil {
+		writeError(w, groupErrorStatus(s.orchestrator, name), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "stopped"})
+}
+
+func (s *Server) handlePauseGroup(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	paused, err := s.orchestrator.PauseGroup(name)
+	if err != nil {
+		writeError(w, groupErrorStatus(s.orchestrator, name), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"paused": paused})
+}
+
+func (s *Server) handleResumeGroup(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	resumed, err := s.orchestrator.ResumeGroup(name)
+	if err != nil {
+		writeError(w, groupErrorStatus(s.orchestrator, name), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"resumed": resumed})
+}
+
+func (s *Server) handleListPools(w http.ResponseWriter, r *http.Request) {
+	if s.poolManager == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("pool manager not available"))
+		return
+	}
+
+	openStats := s.poolManager.OpenPoolStats()
+	names := s.poolManager.ListPools()
+	sort.Strings(names)
+
+	pools := make([]PoolSummary, 0, len(names))
+	for _, name := range names {
+		summary := PoolSummary{Name: name}
+		if stats, open := openStats[name]; open {
+			summary.Open = true
+			summary.Stats = &stats
+		}
+		pools = append(pools, summary)
+	}
+
+	writeJSON(w, http.StatusOK, pools)
+}
+
+func (s *Server) handlePoolStats(w http.ResponseWriter, r *http.Request) {
+	if s.poolManager == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("pool manager not available"))
+		return
+	}
+
+	pool, err := s.poolManager.GetPool(r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pool.GetStats())
+}
+
+// groupErrorStatus distinguishes unknown groups (404) from invalid state changes (409)
+func groupErrorStatus(orchestrator *bot.Orchestrator, name string) int {
+	if _, exists := orchestrator.GetGroup(name); !exists {
+		return http.StatusNotFound
+	}
+	return http.StatusConflict
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/bot"
+)
+
+// DefaultPort is the port the control API listens on when none is configured
+const DefaultPort = 8765
+
+// APIKeyHeader is the header clients send the API key in (Authorization: Bearer <key> also works)
+const APIKeyHeader = "X-API-Key"
+
+// Server is an HTTP control API for running bot groups without the GUI
+type Server struct {
+	addr         string
+	apiKey       string
+	orchestrator *bot.Orchestrator
+	poolManager  *accountpool.PoolManager
+
+	mu         sync.Mutex
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// NewServer creates a control API server listening on port (all interfaces).
+// Every request must present apiKey; an empty key is rejected by Start.
+func NewServer(port int, apiKey string, orchestrator *bot.Orchestrator, poolManager *accountpool.PoolManager) *Server {
+	if port <= 0 {
+		port = DefaultPort
+	}
+	return &Server{
+		addr:         fmt.Sprintf(":%d", port),
+		apiKey:       apiKey,
+		orchestrator: orchestrator,
+		poolManager:  poolManager,
+	}
+}
+
+// Start begins listening; the port is bound before returning so conflicts are reported
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer != nil {
+		return fmt.Errorf("API server already running")
+	}
+	if s.apiKey == "" {
+		return fmt.Errorf("an API key is required to start the API server")
+	}
+	if s.orchestrator == nil {
+		return fmt.Errorf("orchestrator not available")
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	s.listener = listener
+	s.httpServer = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("API server stopped: %v\n", err)
+		}
+	}(s.httpServer)
+
+	fmt.Printf("API server listening on %s\n", listener.Addr())
+	return nil
+}
+
+// Addr returns the address the server is listening on (empty when stopped)
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Shutdown stops the server, waiting for in-flight requests until ctx expires
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.httpServer
+	s.httpServer = nil
+	s.listener = nil
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Handler returns the API routes wrapped in API key authentication
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/groups", s.handleListGroups)
+	mux.HandleFunc("GET /api/groups/{name}", s.handleGroupStatus)
+	mux.HandleFunc("POST /api/groups/{name}/start", s.handleStartGroup)
+	mux.HandleFunc("POST /api/groups/{name}/stop", s.handleStopGroup)
+	mux.HandleFunc("POST /api/groups/{name}/pause", s.handlePauseGroup)
+	mux.HandleFunc("POST /api/groups/{name}/resume", s.handleResumeGroup)
+	mux.HandleFunc("GET /api/pools", s.handleListPools)
+	mux.HandleFunc("GET /api/pools/{name}", s.handlePoolStats)
+
+	return s.requireAPIKey(mux)
+}
+
+// requireAPIKey rejects requests without the configured API key
+func (s *Server) requireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(APIKeyHeader)
+		if key == "" {
+			key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+		if s.apiKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(s.apiKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("missing or invalid API key"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// writeError writes {"error": "..."} with the given status
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/adb"
+)
+
+const (
+	// maxInjectionAttempts is how often an account is injected before it is failed
+	maxInjectionAttempts = 3
+
+	// accountLoadDelay gives the game time to restart with the injected account before it is verified
+	accountLoadDelay = 15 * time.Second
+)
+
+// ErrAccountNotLoaded is returned when the game is still running a different account after injection
+var ErrAccountNotLoaded = errors.New("injected account was not loaded")
+
+// VerifyInjectedAccount restarts the game and checks it loaded the current account,
+// re-injecting it up to maxInjectionAttempts times. This is called by the InjectNextAccount
+// action, which fails the account through its pool on an error. The check itself is the
+// orchestrator's account verifier (see SetAccountVerifier). Once the account is loaded,
+// the orchestrator's account loaded hook runs (see SetAccountLoadedHook).
+func (b *Bot) VerifyInjectedAccount() error {
+	account := b.currentAccount
+	if account == nil {
+		return fmt.Errorf("no account injected")
+	}
+	if account.DeviceAccount == "" {
+		b.Logf("Account '%s' has no device account, skipping verification\n", account.ID)
+		return nil
+	}
+
+	adapter, _ := b.manager.(*BotGroupManagerAdapter)
+	var verify AccountVerifier
+	if adapter != nil {
+		verify = adapter.accountVerifier()
+	}
+	if verify == nil {
+		b.Logf("No account verifier set, skipping verification of '%s'\n", account.ID)
+		return nil
+	}
+
+	for attempt := 1; attempt <= maxInjectionAttempts; attempt++ {
+		if attempt > 1 {
+			if err := pushAccountXML(b.adb, b.GamePackage(), account); err != nil {
+				return err
+			}
+		}
+		if err := b.restartApp(); err != nil {
+			return err
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		case <-time.After(accountLoadDelay):
+		}
+
+		loaded, err := verify(b, account)
+		if err != nil {
+			b.Logf("Could not verify account '%s' (attempt %d/%d): %v\n", account.ID, attempt, maxInjectionAttempts, err)
+			continue
+		}
+		if loaded {
+			b.Logf("Account '%s' loaded\n", account.ID)
+			adapter.accountLoaded(b, account)
+			return nil
+		}
+
+		b.Logf("Account '%s' not loaded (attempt %d/%d)\n", account.ID, attempt, maxInjectionAttempts)
+	}
+
+	return fmt.Errorf("%w: %s after %d attempts", ErrAccountNotLoaded, account.ID, maxInjectionAttempts)
+}
+
+// deviceAccountPath is the preferences file the game reads its account from
+func deviceAccountPath(packageName string) string {
+	return fmt.Sprintf("/data/data/%s/shared_prefs/deviceAccount:.xml", packageName)
+}
+
+// ADBTarget returns the ADB path and port of the bot's emulator instance
+func (b *Bot) ADBTarget() (string, int, error) {
+	if b.emulatorManager == nil {
+		return "", 0, fmt.Errorf("bot %d is not initialized", b.instance)
+	}
+
+	inst, err := b.emulatorManager.GetInstance(b.instance)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get instance %d: %w", b.instance, err)
+	}
+
+	adbPath := b.config.ADBPath
+	if b.config.InstanceADBPaths[b.instance] != "" {
+		adbPath = b.config.ADBPathFor(b.instance)
+	}
+	if adbPath == "" {
+		adbPath, err = adb.FindADB(b.config.FolderPath)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to find ADB: %w", err)
+		}
+	}
+
+	return adbPath, inst.MuMu.ADBPort, nil
+}
@@ -3,7 +3,12 @@ package bot
 import (
 	"context"
 	"fmt"
+	"image"
+	"io"
 	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"jordanella.com/pocket-tcg-go/internal/accountpool"
@@ -12,6 +17,7 @@ import (
 	"jordanella.com/pocket-tcg-go/internal/cv"
 	"jordanella.com/pocket-tcg-go/internal/database"
 	"jordanella.com/pocket-tcg-go/internal/emulator"
+	"jordanella.com/pocket-tcg-go/internal/logging"
 	"jordanella.com/pocket-tcg-go/internal/monitor"
 	"jordanella.com/pocket-tcg-go/pkg/templates"
 )
@@ -44,6 +50,7 @@ type RecoveryConfig struct {
 	InstanceWindowMissing RecoveryAction
 	DeviceUnresponsive    RecoveryAction
 	ScreenFrozen          RecoveryAction
+	ScreenStalled         RecoveryAction // Frame unchanged while a routine is running
 	BotStuck              RecoveryAction
 	MaxRecoveryAttempts   int
 }
@@ -55,11 +62,15 @@ func DefaultRecoveryConfig() RecoveryConfig {
 		InstanceWindowMissing: RecoveryActionStop,
 		DeviceUnresponsive:    RecoveryActionRestartApp,
 		ScreenFrozen:          RecoveryActionRestartApp,
+		ScreenStalled:         RecoveryActionRestartApp,
 		BotStuck:              RecoveryActionRestart,
 		MaxRecoveryAttempts:   3,
 	}
 }
 
+// TemplatesConfigDir is where template YAML definitions are loaded from (and reloaded by ReloadTemplates)
+var TemplatesConfigDir = filepath.Join("config", "templates")
+
 // Core Bot struct definition
 type Bot struct {
 	instance          int
@@ -70,14 +81,22 @@ type Bot struct {
 	actions           ActionLibrary
 	emulatorManager   *emulator.Manager
 	screenHistory     *ScreenHistory
+	screenTracker     *cv.ScreenTracker // Last screen template recognized (diagnostics)
 	errorMonitor      *monitor.ErrorMonitor
 	healthCheck       *monitor.HealthChecker
+	stallDetector     *monitor.StallDetector
 	db                *database.DB
 	templateRegistry  actions.TemplateRegistryInterface
 	routineRegistry   actions.RoutineRegistryInterface
 	routineController *RoutineController
 	variableStore     actions.VariableStoreInterface
-	sentryManager     *actions.SentryManager // Global sentry lifecycle manager
+	sentryManager     *actions.SentryManager  // Global sentry lifecycle manager
+	humanizer         *actions.Humanizer      // Click/delay randomization
+	failureCapture    *actions.FailureCapture // Screenshots on step failure
+	actionTrace       *actions.ActionTrace    // Per-run log of executed actions (disabled until EnableTrace)
+	runStats          *actions.RunStats       // Steps executed and sentries fired (see RoutineResult)
+	lastResult        atomic.Pointer[actions.RoutineResult]
+	logWriter         io.Writer // Per-instance log file (nil unless the bot's group logs to files)
 	orchestrationID   string
 	lastRoutineName   string // Track last executed routine for restart
 	restartPolicy     *RestartPolicy
@@ -86,6 +105,10 @@ type Bot struct {
 	onUnhealthyAction func()               // Callback when unhealthy event occurs
 	manager           interface{}          // Reference to parent manager or manager adapter (optional)
 	currentAccount    *accountpool.Account // Currently assigned account (nil if none)
+	lastProgress      atomic.Int64         // Unix nanoseconds of the last completed account or opened pack (see RecordProgress)
+	pauseOnError      atomic.Bool          // Pause on a failed step instead of failing the routine (see SetPauseOnError)
+	pendingStepError  *PendingStepError    // Step error the bot is paused on (nil if none)
+	stepErrorMu       sync.Mutex
 	ctx               context.Context
 	cancel            context.CancelFunc
 }
@@ -93,14 +116,20 @@ type Bot struct {
 // Lifecycle methods
 func New(instance int, config *Config) (*Bot, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	variableStore := actions.NewVariableStore()
 
 	return &Bot{
 		instance:          instance,
 		config:            config,
 		state:             &State{},
 		screenHistory:     NewScreenHistory(50), // Track last 50 screen states
+		screenTracker:     cv.NewScreenTracker(instance),
 		routineController: NewRoutineController(),
-		variableStore:     actions.NewVariableStore(),
+		variableStore:     variableStore,
+		humanizer:         actions.NewHumanizer(config.GetHumanizerConfig(), variableStore),
+		failureCapture:    actions.NewFailureCapture(instance, config.GetFailureCaptureConfig()),
+		actionTrace:       actions.NewActionTrace(instance),
+		runStats:          actions.NewRunStats(),
 		recoveryConfig:    DefaultRecoveryConfig(),
 		recoveryAttempts:  make(map[string]int),
 		ctx:               ctx,
@@ -128,13 +157,18 @@ func (b *Bot) initializeInternal(sharedRegistries bool) error {
 	} else {
 		// Search for ADB in MuMu folder
 		adbPath, err = adb.FindADB(b.config.FolderPath)
-		if err != nil {
+		if err != nil && b.config.InstanceADBPaths[b.instance] == "" {
 			return fmt.Errorf("failed to find ADB (set ADBPath in config or ensure MuMu is installed): %w", err)
 		}
 	}
 
-	// Create emulator manager
+	if err := b.config.ValidateInstanceADBPath(b.instance); err != nil {
+		return fmt.Errorf("invalid ADB configuration: %w", err)
+	}
+
+	// Create emulator manager (instances of other MuMu installations use their own ADB)
 	b.emulatorManager = emulator.NewManager(b.config.FolderPath, adbPath)
+	b.emulatorManager.SetInstanceADBPaths(b.config.ResolvedInstanceADBPaths())
 
 	// Discover instances
 	if err := b.emulatorManager.DiscoverInstances(); err != nil {
@@ -180,6 +214,14 @@ func (b *Bot) initializeInternal(sharedRegistries bool) error {
 		fmt.Printf("Bot %d: %s\n", b.instance, translator.String())
 	}
 
+	// Randomize clicks within the game board (source coordinates)
+	b.humanizer.SetConfig(b.config.GetHumanizerConfig())
+	b.failureCapture.SetConfig(b.config.GetFailureCaptureConfig())
+	b.humanizer.SetBounds(image.Rect(0, coordConfig.TitleBarHeight,
+		coordConfig.SourceWidth, coordConfig.TitleBarHeight+coordConfig.SourceHeight))
+	b.adb.SetClickJitter(b.humanizer)
+	b.adb.SetInputThrottle(b.config.GetADBInputInterval(b.instance))
+
 	// Initialize CV service with window capture
 	windowCapture, err := cv.NewWindowCapture(inst.MuMu.WindowHandle)
 	if err != nil {
@@ -189,7 +231,11 @@ func (b *Bot) initializeInternal(sharedRegistries bool) error {
 	// Use title bar height from config
 	titleBarHeight := b.config.TitleBarHeight
 
-	b.cv = cv.NewServiceWithTitleBar(windowCapture, titleBarHeight)
+	b.cv = cv.NewServiceWithTitleBar(windowCapture, titleBarHeight).
+		WithScreenTracker(b.screenTracker)
+
+	// The limit is shared by every bot; all bots apply the same setting
+	cv.SetConcurrencyLimit(b.config.MaxConcurrentCV)
 
 	// Initialize database
 	dbPath := filepath.Join(b.config.FolderPath, "bot.db")
@@ -213,7 +259,7 @@ func (b *Bot) initializeInternal(sharedRegistries bool) error {
 	b.healthCheck = monitor.NewHealthChecker(b).
 		WithCheckInterval(10 * time.Second).
 		WithUnhealthyCallback(func(reason string, err error) {
-			fmt.Printf("Bot %d: Health check failed - %s: %v\n", b.instance, reason, err)
+			b.Logf("Health check failed - %s: %v\n", reason, err)
 
 			// Execute recovery action based on reason
 			b.executeRecoveryAction(reason, err)
@@ -225,14 +271,25 @@ func (b *Bot) initializeInternal(sharedRegistries bool) error {
 		})
 	b.healthCheck.Start()
 
+	// Initialize stall detector (only watches while a routine is running)
+	b.stallDetector = monitor.NewStallDetector(b.cv, b.config.GetStallConfig()).
+		WithActiveCheck(b.routineController.IsRunning).
+		WithStallCallback(func(reason string, err error) {
+			b.Logf("Stall detected - %v\n", err)
+			b.executeRecoveryAction(reason, err)
+			if b.onUnhealthyAction != nil {
+				b.onUnhealthyAction()
+			}
+		})
+	b.stallDetector.Start()
+
 	// Initialize registries only if not using shared ones
 	if !sharedRegistries {
 		// Initialize template registry (from current directory)
 		templatesPath := "templates"
 		b.templateRegistry = templates.NewTemplateRegistry(templatesPath)
 		// Load templates from YAML files if directory exists
-		templatesConfigPath := filepath.Join("config", "templates")
-		if err := b.templateRegistry.(*templates.TemplateRegistry).LoadFromDirectory(templatesConfigPath); err != nil {
+		if err := b.templateRegistry.(*templates.TemplateRegistry).LoadFromDirectory(TemplatesConfigDir); err != nil {
 			// Non-fatal: templates directory might not exist or be empty
 			fmt.Printf("Info: Template directory not loaded: %v\n", err)
 		}
@@ -243,12 +300,24 @@ func (b *Bot) initializeInternal(sharedRegistries bool) error {
 		b.routineRegistry.(*actions.RoutineRegistry).WithTemplateRegistry(b.templateRegistry)
 	}
 
+	// Share decoded template images with every bot using the same registry
+	if registry, ok := b.templateRegistry.(*templates.TemplateRegistry); ok {
+		if b.config.TemplateCacheMaxMB > 0 {
+			registry.SetMaxCacheBytes(int64(b.config.TemplateCacheMaxMB) << 20)
+		}
+		b.cv.WithTemplateRegistry(registry.CVRegistry())
+
+		if language := b.config.TemplateLanguage; language != "" && !slices.Contains(registry.Languages(), language) {
+			b.Logf("Warning - no '%s' template set loaded, using default templates\n", language)
+		}
+	}
+
 	// Initialize global sentry manager (always initialized, regardless of registry source)
 	// Note: This must be done after all other initialization since SentryManager needs access to bot services
 	// Create a temporary interface-compatible wrapper if needed
 	var botInterface actions.BotInterface = b
 	b.sentryManager = actions.NewSentryManager(botInterface)
-	fmt.Printf("Bot %d: Sentry manager initialized\n", b.instance)
+	b.Logf("Sentry manager initialized\n")
 
 	return nil
 }
@@ -293,6 +362,11 @@ func (b *Bot) shutdownInternal(sharedRegistries bool) {
 		b.healthCheck.Stop()
 	}
 
+	// Stop stall detector
+	if b.stallDetector != nil {
+		b.stallDetector.Stop()
+	}
+
 	// Only clean up registries if not using shared ones
 	if !sharedRegistries {
 		// Unload all cached template images
@@ -304,6 +378,11 @@ func (b *Bot) shutdownInternal(sharedRegistries bool) {
 		// Note: Routines are eagerly loaded and don't need per-bot cleanup
 	}
 
+	// Close action trace
+	if err := b.actionTrace.Stop(); err != nil {
+		fmt.Printf("Bot %d: Failed to close action trace: %v\n", b.instance, err)
+	}
+
 	// Close database connection
 	if b.db != nil {
 		b.db.Close()
@@ -392,6 +471,12 @@ func (b *Bot) ScreenHistory() *ScreenHistory {
 	return b.screenHistory
 }
 
+// LastScreen returns the last screen template this bot recognized and when.
+// Returns "" and the zero time if nothing has matched yet.
+func (b *Bot) LastScreen() (string, time.Time) {
+	return b.screenTracker.Last()
+}
+
 // DB returns the database connection
 func (b *Bot) DB() *database.DB {
 	return b.db
@@ -416,6 +501,10 @@ func (b *Bot) Config() actions.ConfigInterface {
 
 // Templates returns the template registry (implements actions.BotInterface)
 func (b *Bot) Templates() actions.TemplateRegistryInterface {
+	// Prefer the configured language's template variants
+	if registry, ok := b.templateRegistry.(*templates.TemplateRegistry); ok && b.config != nil && b.config.TemplateLanguage != "" {
+		return registry.ForLanguage(b.config.TemplateLanguage)
+	}
 	return b.templateRegistry
 }
 
@@ -439,6 +528,83 @@ func (b *Bot) SentryManager() *actions.SentryManager {
 	return b.sentryManager
 }
 
+// Humanizer returns the click/delay randomizer (implements actions.BotInterface)
+func (b *Bot) Humanizer() *actions.Humanizer {
+	return b.humanizer
+}
+
+// SaveCapturedTemplate saves an image as a new named template in this bot's template
+// registry, making it usable in routines immediately and persisting it for reloads
+func (b *Bot) SaveCapturedTemplate(img image.Image, name string, threshold float64) (cv.Template, error) {
+	registry, ok := b.templateRegistry.(*templates.TemplateRegistry)
+	if !ok {
+		return cv.Template{}, fmt.Errorf("template registry not available")
+	}
+	return registry.SaveCapturedTemplate(img, name, threshold, TemplatesConfigDir)
+}
+
+// FailureCapture returns the failure screenshot recorder
+func (b *Bot) FailureCapture() *actions.FailureCapture {
+	return b.failureCapture
+}
+
+// ActionTrace returns the executed action recorder
+func (b *Bot) ActionTrace() *actions.ActionTrace {
+	return b.actionTrace
+}
+
+// RunStats returns the bot's step and sentry counters
+func (b *Bot) RunStats() *actions.RunStats {
+	return b.runStats
+}
+
+// SetLastRoutineResult records the outcome of the bot's latest routine run
+func (b *Bot) SetLastRoutineResult(result *actions.RoutineResult) {
+	b.lastResult.Store(result)
+}
+
+// LastRoutineResult returns the outcome of the bot's latest routine run (nil before the first)
+func (b *Bot) LastRoutineResult() *actions.RoutineResult {
+	return b.lastResult.Load()
+}
+
+// EnableTrace starts recording every executed action, with its resolved arguments, result
+// and duration, to a new JSONL file in dir. Replay a trace with actions.ReplayTrace.
+func (b *Bot) EnableTrace(dir string) error {
+	path, err := b.actionTrace.Start(dir)
+	if err != nil {
+		return err
+	}
+	b.Logf("Recording action trace to %s\n", path)
+	return nil
+}
+
+// DisableTrace stops recording executed actions
+func (b *Bot) DisableTrace() error {
+	return b.actionTrace.Stop()
+}
+
+// SetLogWriter sets where the bot's log lines are also written, besides the console.
+// Set it before the bot starts running; nil stops file logging.
+func (b *Bot) SetLogWriter(w io.Writer) {
+	b.logWriter = w
+}
+
+// LogWriter returns the bot's log file writer (nil if the bot doesn't log to a file)
+func (b *Bot) LogWriter() io.Writer {
+	return b.logWriter
+}
+
+// Logf prints a "Bot <n>: " prefixed message to the console and, if set, to the bot's log file
+func (b *Bot) Logf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	fmt.Printf("Bot %d: %s", b.instance, message)
+
+	if b.logWriter != nil {
+		logging.WriteLine(b.logWriter, message)
+	}
+}
+
 // SetLastRoutine sets the name of the last executed routine
 func (b *Bot) SetLastRoutine(routineName string) {
 	b.lastRoutineName = routineName
@@ -511,35 +677,119 @@ func (b *Bot) InjectAccount(accountIf interface{}) error {
 		return fmt.Errorf("ADB not initialized")
 	}
 
-	// Get package data directory
-	packageName := "jp.pokemon.pokemontcgp"
-	dataPath := fmt.Sprintf("/data/data/%s/shared_prefs", packageName)
+	// An account the group's warm pool pre-injected onto this instance is already in place
+	if adapter, ok := b.manager.(*BotGroupManagerAdapter); ok && adapter.group.consumePreInjected(b.instance, account) {
+		b.currentAccount = account
+		b.Logf("Account '%s' was pre-injected, skipping injection\n", account.ID)
+		return nil
+	}
 
-	// Target file path on device
-	targetFile := fmt.Sprintf("%s/account.xml", dataPath)
+	b.Logf("Injecting account '%s' from %s\n", account.ID, account.XMLPath)
+	if err := pushAccountXML(b.adb, b.GamePackage(), account); err != nil {
+		return err
+	}
+
+	// Store current account reference
+	b.currentAccount = account
+
+	b.Logf("Account '%s' injected successfully\n", account.ID)
+	return nil
+}
 
-	fmt.Printf("Bot %d: Injecting account '%s' from %s\n", b.instance, account.ID, account.XMLPath)
+// pushAccountXML pushes an account's XML into the game's shared preferences on a device
+func pushAccountXML(controller *adb.Controller, packageName string, account *accountpool.Account) error {
+	// Target file path on device
+	targetFile := deviceAccountPath(packageName)
 
 	// Push XML file to device
-	if err := b.adb.Push(account.XMLPath, targetFile); err != nil {
+	if err := controller.Push(account.XMLPath, targetFile); err != nil {
 		return fmt.Errorf("failed to push account XML: %w", err)
 	}
 
 	// Set permissions (readable by app)
-	if _, err := b.adb.Shell(fmt.Sprintf("chmod 660 %s", targetFile)); err != nil {
+	if _, err := controller.Shell(fmt.Sprintf("chmod 660 %s", targetFile)); err != nil {
 		fmt.Printf("Warning: Failed to set permissions on %s: %v\n", targetFile, err)
 	}
-
-	// Store current account reference
-	b.currentAccount = account
-
-	fmt.Printf("Bot %d: Account '%s' injected successfully\n", b.instance, account.ID)
 	return nil
 }
 
-// ClearCurrentAccount clears the current account assignment
+// ClearCurrentAccount clears the current account assignment, first storing its
+// account-state variables (see actions.FlushAccountState)
 func (b *Bot) ClearCurrentAccount() {
+	actions.FlushAccountState(b)
 	b.currentAccount = nil
+	b.variableStore.Delete(actions.VarDeviceAccountID)
+}
+
+// GamePackage returns the configured game package (see Config.GamePackage)
+func (b *Bot) GamePackage() string {
+	if b.config == nil {
+		return DefaultGamePackage
+	}
+	return b.config.GamePackageName()
+}
+
+// LaunchGame starts the game (see Config.GamePackage)
+func (b *Bot) LaunchGame() error {
+	if b.adb == nil {
+		return fmt.Errorf("ADB not initialized")
+	}
+	if err := b.adb.LaunchApp(b.GamePackage()); err != nil {
+		return fmt.Errorf("failed to launch %s: %w", b.GamePackage(), err)
+	}
+	return nil
+}
+
+// StopGame force-stops the game
+func (b *Bot) StopGame() error {
+	if b.adb == nil {
+		return fmt.Errorf("ADB not initialized")
+	}
+	if err := b.adb.ForceStop(b.GamePackage()); err != nil {
+		return fmt.Errorf("failed to stop %s: %w", b.GamePackage(), err)
+	}
+	return nil
+}
+
+// IsGameRunning reports whether the game has a running process
+func (b *Bot) IsGameRunning() (bool, error) {
+	if b.adb == nil {
+		return false, fmt.Errorf("ADB not initialized")
+	}
+	return b.adb.IsAppRunning(b.GamePackage())
+}
+
+// EnsureGameRunning launches the game unless it is already running
+func (b *Bot) EnsureGameRunning() error {
+	running, err := b.IsGameRunning()
+	if err != nil {
+		return err
+	}
+	if running {
+		return nil
+	}
+
+	b.Logf("Launching %s\n", b.GamePackage())
+	return b.LaunchGame()
+}
+
+// RunStartupRoutine runs a routine that gets the game from launch to a usable state
+// (typically one that dismisses launch-time dialogs) before the main routine starts
+func (b *Bot) RunStartupRoutine(routineName string) error {
+	if b.routineRegistry == nil {
+		return fmt.Errorf("routine registry not initialized")
+	}
+
+	routine, err := b.routineRegistry.Get(routineName)
+	if err != nil {
+		return fmt.Errorf("failed to get startup routine '%s': %w", routineName, err)
+	}
+
+	b.Logf("Running startup routine '%s'\n", routineName)
+	if err := routine.Execute(b); err != nil {
+		return fmt.Errorf("startup routine '%s' failed: %w", routineName, err)
+	}
+	return nil
 }
 
 // configAdapter wraps *Config to implement actions.ConfigInterface
@@ -568,10 +818,12 @@ func (b *Bot) executeRecoveryAction(reason string, _ error) {
 		action = b.recoveryConfig.DeviceUnresponsive
 	case "screen_frozen":
 		action = b.recoveryConfig.ScreenFrozen
+	case "screen_stalled":
+		action = b.recoveryConfig.ScreenStalled
 	case "bot_stuck":
 		action = b.recoveryConfig.BotStuck
 	default:
-		fmt.Printf("Bot %d: Unknown health issue '%s', defaulting to log\n", b.instance, reason)
+		b.Logf("Unknown health issue '%s', defaulting to log\n", reason)
 		action = RecoveryActionLog
 	}
 
@@ -581,14 +833,14 @@ func (b *Bot) executeRecoveryAction(reason string, _ error) {
 
 	// Check if max attempts exceeded
 	if attemptCount > b.recoveryConfig.MaxRecoveryAttempts {
-		fmt.Printf("Bot %d: Max recovery attempts (%d) exceeded for '%s', stopping bot\n",
-			b.instance, b.recoveryConfig.MaxRecoveryAttempts, reason)
+		b.Logf("Max recovery attempts (%d) exceeded for '%s', stopping bot\n",
+			b.recoveryConfig.MaxRecoveryAttempts, reason)
 		b.Stop()
 		return
 	}
 
-	fmt.Printf("Bot %d: Executing recovery action '%s' for '%s' (attempt %d/%d)\n",
-		b.instance, action, reason, attemptCount, b.recoveryConfig.MaxRecoveryAttempts)
+	b.Logf("Executing recovery action '%s' for '%s' (attempt %d/%d)\n",
+		action, reason, attemptCount, b.recoveryConfig.MaxRecoveryAttempts)
 
 	// Execute the recovery action
 	switch action {
@@ -606,25 +858,20 @@ func (b *Bot) executeRecoveryAction(reason string, _ error) {
 	case RecoveryActionRestart:
 		// Restart the last executed routine
 		if b.lastRoutineName != "" {
-			fmt.Printf("Bot %d: Restarting routine '%s'\n", b.instance, b.lastRoutineName)
+			b.Logf("Restarting routine '%s'\n", b.lastRoutineName)
 			// Stop current routine
 			b.Stop()
 			// The manager should handle restart via RestartBot()
 		} else {
-			fmt.Printf("Bot %d: Cannot restart - no last routine recorded\n", b.instance)
+			b.Logf("Cannot restart - no last routine recorded\n")
 		}
 
 	case RecoveryActionReconnectADB:
 		// Attempt to reconnect ADB
 		if b.emulatorManager != nil {
-			fmt.Printf("Bot %d: Attempting to reconnect ADB\n", b.instance)
-			// Disconnect and reconnect
-			b.emulatorManager.DisconnectInstance(b.instance)
-			if err := b.emulatorManager.ConnectInstance(b.instance); err != nil {
-				fmt.Printf("Bot %d: Failed to reconnect ADB: %v\n", b.instance, err)
+			if err := b.reconnectADB(); err != nil {
 				b.Stop()
 			} else {
-				fmt.Printf("Bot %d: ADB reconnected successfully\n", b.instance)
 				// Reset recovery attempts on success
 				b.recoveryAttempts[reason] = 0
 			}
@@ -633,35 +880,62 @@ func (b *Bot) executeRecoveryAction(reason string, _ error) {
 	case RecoveryActionRestartApp:
 		// Restart the target app (Pokemon TCG Pocket)
 		if b.adb != nil {
-			packageName := "jp.pokemon.pokemontcgp" // Pokemon TCG Pocket package name
-			fmt.Printf("Bot %d: Restarting app '%s'\n", b.instance, packageName)
-
-			// Force stop the app
-			if _, err := b.adb.Shell(fmt.Sprintf("am force-stop %s", packageName)); err != nil {
-				fmt.Printf("Bot %d: Failed to stop app: %v\n", b.instance, err)
-			}
-
-			// Wait a moment
-			time.Sleep(2 * time.Second)
-
-			// Restart the app
-			if _, err := b.adb.Shell(fmt.Sprintf("monkey -p %s -c android.intent.category.LAUNCHER 1", packageName)); err != nil {
-				fmt.Printf("Bot %d: Failed to restart app: %v\n", b.instance, err)
+			if err := b.restartApp(); err != nil {
 				b.Stop()
 			} else {
-				fmt.Printf("Bot %d: App restarted successfully\n", b.instance)
 				// Reset recovery attempts on success
 				b.recoveryAttempts[reason] = 0
 			}
 		}
 
 	case RecoveryActionStop:
-		fmt.Printf("Bot %d: Stopping bot due to '%s'\n", b.instance, reason)
+		b.Logf("Stopping bot due to '%s'\n", reason)
 		b.Stop()
 
 	default:
-		fmt.Printf("Bot %d: Unknown recovery action '%s'\n", b.instance, action)
+		b.Logf("Unknown recovery action '%s'\n", action)
+	}
+}
+
+// reconnectADB disconnects and reconnects the instance's ADB connection
+func (b *Bot) reconnectADB() error {
+	if b.emulatorManager == nil {
+		return fmt.Errorf("no emulator manager")
 	}
+
+	b.Logf("Attempting to reconnect ADB\n")
+	b.emulatorManager.DisconnectInstance(b.instance)
+	if err := b.emulatorManager.ConnectInstance(b.instance); err != nil {
+		b.Logf("Failed to reconnect ADB: %v\n", err)
+		return err
+	}
+	b.Logf("ADB reconnected successfully\n")
+	return nil
+}
+
+// restartApp force-stops and relaunches the game
+func (b *Bot) restartApp() error {
+	if b.adb == nil {
+		return fmt.Errorf("ADB not connected")
+	}
+
+	b.Logf("Restarting app '%s'\n", b.GamePackage())
+
+	// Force stop the app
+	if err := b.StopGame(); err != nil {
+		b.Logf("Failed to stop app: %v\n", err)
+	}
+
+	// Wait a moment
+	time.Sleep(2 * time.Second)
+
+	// Restart the app
+	if err := b.LaunchGame(); err != nil {
+		b.Logf("Failed to restart app: %v\n", err)
+		return err
+	}
+	b.Logf("App restarted successfully\n")
+	return nil
 }
 
 func (b *Bot) OrchestrationID() string {
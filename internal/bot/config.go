@@ -1,7 +1,17 @@
 package bot
 
 import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
 	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/internal/adb"
+	"jordanella.com/pocket-tcg-go/internal/cv"
+	"jordanella.com/pocket-tcg-go/internal/emulator"
+	"jordanella.com/pocket-tcg-go/internal/monitor"
 )
 
 // Configuration type - comprehensive settings from AHK bot
@@ -19,6 +29,9 @@ type Config struct {
 	InjectSortMethod SortMethod
 	InjectMinPacks   int
 	InjectMaxPacks   int
+	SyncSaveCounts   bool   // Read pack and hourglass counts from the game's save after injection
+	SavePackCountKey string // Save column holding the packs opened (default: known column names)
+	SaveHourglassKey string // Save column holding the hourglass count (default: known column names)
 
 	// Account waiting logic
 	WaitForEligibleAccounts bool
@@ -106,6 +119,8 @@ type Config struct {
 
 	// Extended configuration for GUI and advanced features
 	ADBPath          string // Path to ADB executable
+	InstanceADBPaths map[int]string // Per-instance ADB executables from [instance:N] adb_path (see ADBPathFor)
+	InstanceProxies  map[int]string // Per-instance HTTP proxies from [instance:N] proxy (see ProxyFor)
 	MuMuWindowWidth  int    // MuMu window width
 	MuMuWindowHeight int    // MuMu window height
 	TitleBarHeight   int    // Height of window title bar to exclude from searches (pixels)
@@ -133,6 +148,84 @@ type Config struct {
 	MonitorScaleFactor float64 // DPI scaling factor for monitor (default: 1.0 for 100%, 1.25 for 125%)
 	MonitorOffsetX     int     // X offset for selected monitor (pixels)
 	MonitorOffsetY     int     // Y offset for selected monitor (pixels)
+
+	// Humanization (per-routine overrides via humanize_click_radius / humanize_delay_jitter variables)
+	HumanizeClickRadius int   // Max random click offset in pixels (0 = disabled)
+	HumanizeDelayJitter int   // Max random delay change in percent (0 = disabled)
+	HumanizeSeed        int64 // Random seed for reproducible runs (0 = random)
+
+	// Maintenance detection (pauses groups whose bots see the maintenance screen)
+	MaintenanceTemplate      string // Template shown on the maintenance screen (empty = disabled)
+	MaintenanceCheckInterval int    // Seconds between maintenance screen checks (default: 30)
+	MaintenanceRecheckDelay  int    // Initial seconds to wait before re-checking during maintenance (default: 120)
+
+	// Template image cache (shared by all bots using the same template registry)
+	TemplateCacheMaxMB int // Memory limit for decoded template images in MB (default: 256)
+
+	// Heavy CV operations (frame captures and template matches) run at once across all bots
+	MaxConcurrentCV int // Bots wait for a slot beyond this (default: 0 = one per CPU)
+
+	// Failure screenshots (saved when a routine step errors or a sentry fails)
+	FailureScreenshots   bool   // Capture a screenshot on step failure (default: true)
+	FailureScreenshotDir string // Root folder; one subfolder per instance (default: "diagnostics")
+	FailureScreenshotMax int    // Screenshots kept per instance, oldest deleted first (default: 20, 0 = unlimited)
+
+	// Saved screenshots (failure captures and default for Control tab snapshots)
+	ScreenshotFormat         string // "png" (default) or "jpeg"
+	ScreenshotJPEGQuality    int    // JPEG quality 1-100 (default: 85)
+	ScreenshotPNGCompression string // "default", "fast", "best" or "none"
+
+	// Stall detection (screen unchanged while a routine is running)
+	StallWindowSeconds    int     // Seconds without a screen change before a stall is raised (default: 180, 0 = disabled)
+	StallThresholdPercent float64 // Mean luminance change in percent that counts as the screen changing (default: 1.0)
+
+	// ADB input throttling (spaces out taps/swipes so the emulator doesn't drop them)
+	ADBInputIntervalMs         int         // Minimum milliseconds between input commands (default: 50, 0 = unthrottled)
+	InstanceADBInputIntervalMs map[int]int // Per-instance overrides from [InstanceN] or [instance:N] (see GetADBInputInterval)
+
+	// Metrics endpoint (Prometheus text format at /metrics)
+	MetricsEnabled bool // Serve metrics over HTTP (default: false)
+	MetricsPort    int  // Port for the metrics server (default: 9464)
+
+	// Control API (JSON over HTTP for headless operation)
+	APIEnabled bool   // Serve the control API (default: false)
+	APIPort    int    // Port for the control API (default: 8765)
+	APIKey     string // Key clients must send in X-API-Key; the API won't start without one
+
+	// Notifications (Discord webhook)
+	NotifyWebhookURL   string // Discord webhook URL; notifications are off when empty (default: "")
+	NotifyUserID       string // Discord user ID to mention in notifications (default: "")
+	NotifyEvents       string // Comma-separated events to notify on (default: all supported events)
+	NotifyMaxPerMinute int    // Notifications sent per minute before the rest are suppressed (default: 10)
+
+	// God pack preservation (reportgodpack action)
+	GodPackPreserve   bool   // Keep god pack accounts out of every pool and archive them (default: true)
+	GodPackArchiveDir string // Folder for archived god pack accounts (default: "god_packs")
+
+	// Extracted account data (see accounts.ExtractLayout)
+	ExtractDir    string // Folder the extracted_app_data and extracted_obb folders go in (default: ".")
+	ExtractNaming string // Folder name per extraction; {instance}, {friend_code}, {username}, {device_account}, {date} (default: "instance_{instance}")
+
+	// Pool health score (relative weights; see accountpool.PoolHealth)
+	PoolHealthWeightAvailability float64 // Weight of the share of available accounts (default: 0.4)
+	PoolHealthWeightPacks        float64 // Weight of the average pack count (default: 0.2)
+	PoolHealthWeightReliability  float64 // Weight of the share of accounts without failures (default: 0.25)
+	PoolHealthWeightRested       float64 // Weight of the share of accounts not used recently (default: 0.15)
+	PoolHealthPackTarget         int     // Average pack count that earns the full pack score (default: 20)
+	PoolHealthRestHours          int     // Hours since last use before an account counts as rested (default: 12)
+
+	// Default pool
+	DefaultPool string // Account pool for groups launched without one of their own ("" = none, see Orchestrator.SetDefaultPool)
+
+	// Pool exhaustion
+	PoolExhaustedWaitMinutes int // Minutes a bot idles waiting for accounts before stopping cleanly (0 = stop at once, default: 5)
+
+	// Pool statistics history (see accountpool.PoolManager.StartStatsSampler)
+	PoolStatsSampleSeconds int // Seconds between pool statistics samples (0 = disabled, default: 60)
+
+	// Game
+	GamePackage      string // Android package of the game, for region variants (default: jp.pokemon.pokemontcgp)
+	TemplateLanguage string // Game language whose template set is preferred, e.g. "ja" ("" = default templates only; DefaultLanguage holds the UI scale)
 }
 
 type DeleteMethod int
@@ -211,7 +304,51 @@ func LoadConfig(path string) (*Config, error) {
 }
 
 func (c *Config) Validate() error {
-	// TODO: Implement validation
+	if err := c.ValidateADBPaths(); err != nil {
+		return err
+	}
+	return c.ValidateProxies()
+}
+
+// ValidateADBPaths checks that the configured ADB executables exist: the global ADBPath
+// (if set) and every per-instance override. A folder override must contain an ADB executable.
+func (c *Config) ValidateADBPaths() error {
+	if c.ADBPath != "" {
+		if _, err := os.Stat(c.ADBPath); err != nil {
+			return fmt.Errorf("adbPath %s does not exist", c.ADBPath)
+		}
+	}
+
+	instances := make([]int, 0, len(c.InstanceADBPaths))
+	for instance := range c.InstanceADBPaths {
+		instances = append(instances, instance)
+	}
+	sort.Ints(instances)
+
+	for _, instance := range instances {
+		if err := c.ValidateInstanceADBPath(instance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateInstanceADBPath checks an instance's ADB override, if it has one
+func (c *Config) ValidateInstanceADBPath(instance int) error {
+	path := c.InstanceADBPaths[instance]
+	if path == "" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("[instance:%d] adb_path %s does not exist", instance, path)
+	}
+	if info.IsDir() {
+		if _, err := adb.FindADBInFolder(path); err != nil {
+			return fmt.Errorf("[instance:%d] adb_path: %w", instance, err)
+		}
+	}
 	return nil
 }
 
@@ -260,6 +397,74 @@ func (c *Config) ADB() ADBConfig {
 	return ADBConfig{Path: path}
 }
 
+// DefaultGamePackage is the Android package of Pokemon TCG Pocket
+const DefaultGamePackage = "jp.pokemon.pokemontcgp"
+
+// GamePackageName returns the game's Android package (GamePackage, or DefaultGamePackage if unset)
+func (c *Config) GamePackageName() string {
+	if c.GamePackage == "" {
+		return DefaultGamePackage
+	}
+	return c.GamePackage
+}
+
+// ADBPathFor returns the ADB executable for an instance: its [instance:N] override if set,
+// otherwise the global path (see ADB). An override may name the executable or the folder of
+// the instance's MuMu installation, which is searched with adb.FindADBInFolder.
+func (c *Config) ADBPathFor(instance int) string {
+	path := c.InstanceADBPaths[instance]
+	if path == "" {
+		return c.ADB().Path
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if adbPath, err := adb.FindADBInFolder(path); err == nil {
+			return adbPath
+		}
+	}
+	return path
+}
+
+// ResolvedInstanceADBPaths returns the per-instance ADB overrides with installation
+// folders resolved to their executables (see ADBPathFor)
+func (c *Config) ResolvedInstanceADBPaths() map[int]string {
+	paths := make(map[int]string, len(c.InstanceADBPaths))
+	for instance := range c.InstanceADBPaths {
+		paths[instance] = c.ADBPathFor(instance)
+	}
+	return paths
+}
+
+// ValidateProxies checks that every per-instance proxy is "host:port" or "none"
+func (c *Config) ValidateProxies() error {
+	instances := make([]int, 0, len(c.InstanceProxies))
+	for instance := range c.InstanceProxies {
+		instances = append(instances, instance)
+	}
+	sort.Ints(instances)
+
+	for _, instance := range instances {
+		if _, _, err := c.ProxyFor(instance); err != nil {
+			return fmt.Errorf("[instance:%d] proxy: %w", instance, err)
+		}
+	}
+	return nil
+}
+
+// ProxyFor returns an instance's [instance:N] proxy. configured is false when the instance
+// has none, and its network settings are left alone; "none" is configured with a nil proxy,
+// which clears a proxy set before.
+func (c *Config) ProxyFor(instance int) (proxy *emulator.Proxy, configured bool, err error) {
+	value := c.InstanceProxies[instance]
+	if value == "" {
+		return nil, false, nil
+	}
+	proxy, err = emulator.ParseProxy(value)
+	if err != nil {
+		return nil, false, err
+	}
+	return proxy, true, nil
+}
+
 // MuMu returns MuMu emulator configuration
 func (c *Config) MuMu() MuMuConfig {
 	width := c.MuMuWindowWidth
@@ -427,6 +632,78 @@ func (c *Config) GetCoordinateTranslationConfig() CoordinateConfig {
 	}
 }
 
+// GetHumanizerConfig returns the global humanization settings
+func (c *Config) GetHumanizerConfig() actions.HumanizerConfig {
+	if c == nil {
+		return actions.HumanizerConfig{}
+	}
+	return actions.HumanizerConfig{
+		ClickRadius:        c.HumanizeClickRadius,
+		DelayJitterPercent: c.HumanizeDelayJitter,
+		Seed:               c.HumanizeSeed,
+	}
+}
+
+// GetFailureCaptureConfig returns the failure screenshot settings
+func (c *Config) GetFailureCaptureConfig() actions.FailureCaptureConfig {
+	if c == nil {
+		return actions.FailureCaptureConfig{}
+	}
+	return actions.FailureCaptureConfig{
+		Enabled:        c.FailureScreenshots,
+		Dir:            c.FailureScreenshotDir,
+		MaxPerInstance: c.FailureScreenshotMax,
+		Image:          c.GetScreenshotOptions(),
+	}
+}
+
+// GetScreenshotOptions returns how screenshots are saved; unknown format or compression
+// names fall back to PNG with default compression
+func (c *Config) GetScreenshotOptions() cv.SaveOptions {
+	if c == nil {
+		return cv.SaveOptions{Format: cv.ImageFormatPNG}
+	}
+	format, err := cv.ParseImageFormat(c.ScreenshotFormat)
+	if err != nil {
+		format = cv.ImageFormatPNG
+	}
+	compression, _ := cv.ParsePNGCompression(c.ScreenshotPNGCompression)
+	return cv.SaveOptions{
+		Format:         format,
+		JPEGQuality:    c.ScreenshotJPEGQuality,
+		PNGCompression: compression,
+	}
+}
+
+// GetStallConfig returns the stall detector settings; a zero Window disables detection
+func (c *Config) GetStallConfig() monitor.StallConfig {
+	config := monitor.DefaultStallConfig()
+	if c == nil {
+		return config
+	}
+	config.Window = time.Duration(c.StallWindowSeconds) * time.Second
+	if c.StallThresholdPercent > 0 {
+		config.Threshold = c.StallThresholdPercent
+	}
+	return config
+}
+
+// GetADBInputInterval returns the minimum spacing between ADB input commands for an instance,
+// falling back to ADBInputIntervalMs when the instance has no override
+func (c *Config) GetADBInputInterval(instance int) time.Duration {
+	if c == nil {
+		return 0
+	}
+	ms := c.ADBInputIntervalMs
+	if override := c.InstanceADBInputIntervalMs[instance]; override > 0 {
+		ms = override
+	}
+	if ms <= 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
 // CoordinateConfig holds coordinate translation parameters
 type CoordinateConfig struct {
 	SourceWidth     int     // Source coordinate system width (templates)
@@ -438,14 +715,68 @@ type CoordinateConfig struct {
 	ScaleFactor     float64 // Monitor DPI scale factor
 }
 
+// BackoffStrategy selects how the restart delay grows with each retry
+type BackoffStrategy string
+
+const (
+	BackoffExponential BackoffStrategy = "exponential" // InitialDelay * BackoffFactor^(retry-1)
+	BackoffFixed       BackoffStrategy = "fixed"       // InitialDelay every time
+	BackoffLinear      BackoffStrategy = "linear"      // InitialDelay * retry
+	BackoffFibonacci   BackoffStrategy = "fibonacci"   // InitialDelay * 1, 1, 2, 3, 5, 8, ...
+)
+
+// BackoffStrategies lists the supported strategies, in the order shown in the GUI
+var BackoffStrategies = []BackoffStrategy{BackoffFixed, BackoffLinear, BackoffExponential, BackoffFibonacci}
+
 // RestartPolicy defines how bots should restart on failure
 type RestartPolicy struct {
-	Enabled        bool          `yaml:"enabled" json:"enabled"`               // Whether auto-restart is enabled
-	MaxRetries     int           `yaml:"max_retries" json:"max_retries"`       // Maximum number of restart attempts (0 = unlimited)
-	InitialDelay   time.Duration `yaml:"initial_delay" json:"initial_delay"`   // Initial backoff delay
-	MaxDelay       time.Duration `yaml:"max_delay" json:"max_delay"`           // Maximum backoff delay
-	BackoffFactor  float64       `yaml:"backoff_factor" json:"backoff_factor"` // Exponential backoff multiplier
-	ResetOnSuccess bool          `yaml:"reset_on_success" json:"reset_on_success"` // Reset retry counter on successful execution
+	Enabled        bool            `yaml:"enabled" json:"enabled"`                       // Whether auto-restart is enabled
+	MaxRetries     int             `yaml:"max_retries" json:"max_retries"`               // Maximum number of restart attempts (0 = unlimited)
+	InitialDelay   time.Duration   `yaml:"initial_delay" json:"initial_delay"`           // Initial backoff delay
+	MaxDelay       time.Duration   `yaml:"max_delay" json:"max_delay"`                   // Maximum backoff delay
+	Strategy       BackoffStrategy `yaml:"strategy,omitempty" json:"strategy,omitempty"` // How the delay grows (empty = exponential)
+	BackoffFactor  float64         `yaml:"backoff_factor" json:"backoff_factor"`         // Exponential backoff multiplier
+	ResetOnSuccess bool            `yaml:"reset_on_success" json:"reset_on_success"`     // Reset retry counter on successful execution
+	MaxIterations  int             `yaml:"max_iterations,omitempty" json:"max_iterations,omitempty"` // Successful runs before the bot completes instead of restarting (0 = unlimited)
+	ResumeFromCheckpoint bool `yaml:"resume_from_checkpoint,omitempty" json:"resume_from_checkpoint,omitempty"` // Restart a failed run from the last checkpoint step it reached, with its variables (see actions.Checkpoint)
+}
+
+// EffectiveStrategy returns the backoff strategy, treating an empty one as exponential
+func (p RestartPolicy) EffectiveStrategy() BackoffStrategy {
+	if p.Strategy == "" {
+		return BackoffExponential
+	}
+	return p.Strategy
+}
+
+// Delay returns how long to wait before the given retry (1 for the first retry), capped at MaxDelay
+func (p RestartPolicy) Delay(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+
+	// Computed in float64 so large retry counts saturate at MaxDelay instead of overflowing
+	var multiplier float64
+	switch p.EffectiveStrategy() {
+	case BackoffFixed:
+		multiplier = 1
+	case BackoffLinear:
+		multiplier = float64(retry)
+	case BackoffFibonacci:
+		previous, current := 0.0, 1.0
+		for i := 1; i < retry; i++ {
+			previous, current = current, previous+current
+		}
+		multiplier = current
+	default:
+		multiplier = math.Pow(p.BackoffFactor, float64(retry-1))
+	}
+
+	delay := float64(p.InitialDelay) * multiplier
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(delay)
 }
 
 // DefaultRestartPolicy returns sensible defaults
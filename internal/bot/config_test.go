@@ -0,0 +1,52 @@
+package bot
+
+import "testing"
+
+func TestProxyFor(t *testing.T) {
+	config := &Config{InstanceProxies: map[int]string{
+		1: "10.0.0.5:8080",
+		2: "http://10.0.0.6:3128",
+		3: "none",
+		4: "10.0.0.7:99999",
+	}}
+
+	tests := []struct {
+		instance       int
+		wantProxy      string
+		wantConfigured bool
+		wantErr        bool
+	}{
+		{instance: 1, wantProxy: "10.0.0.5:8080", wantConfigured: true},
+		{instance: 2, wantProxy: "10.0.0.6:3128", wantConfigured: true},
+		{instance: 3, wantConfigured: true},
+		{instance: 4, wantErr: true},
+		{instance: 5},
+	}
+
+	for _, tt := range tests {
+		proxy, configured, err := config.ProxyFor(tt.instance)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("instance %d: err = %v, want error %v", tt.instance, err, tt.wantErr)
+			continue
+		}
+		if configured != tt.wantConfigured {
+			t.Errorf("instance %d: configured = %v, want %v", tt.instance, configured, tt.wantConfigured)
+		}
+		got := ""
+		if proxy != nil {
+			got = proxy.String()
+		}
+		if got != tt.wantProxy {
+			t.Errorf("instance %d: proxy = %q, want %q", tt.instance, got, tt.wantProxy)
+		}
+	}
+
+	// The bad port is reported against its instance
+	if err := config.ValidateProxies(); err == nil {
+		t.Error("expected ValidateProxies to reject instance 4's port")
+	}
+	delete(config.InstanceProxies, 4)
+	if err := config.ValidateProxies(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
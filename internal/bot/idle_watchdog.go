@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+)
+
+// IdleAction is what the idle watchdog does with a bot that stopped making progress
+type IdleAction string
+
+const (
+	IdleActionRestart IdleAction = "restart" // Abort the current routine iteration; the restart policy runs it again
+	IdleActionStop    IdleAction = "stop"    // Stop the bot and return its account, like scaling the group down
+)
+
+// idleCheckInterval is how often the idle watchdog compares a bot's last progress to the timeout
+const idleCheckInterval = 30 * time.Second
+
+// RecordProgress marks that the bot did meaningful work (completed an account, opened packs),
+// resetting its idle watchdog. Actions call it through an optional interface.
+func (b *Bot) RecordProgress() {
+	b.lastProgress.Store(time.Now().UnixNano())
+}
+
+// LastProgress returns when the bot last recorded progress (zero if it never did)
+func (b *Bot) LastProgress() time.Time {
+	nanos := b.lastProgress.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// idleTimer tracks how long a bot's routine has run without recording progress
+type idleTimer struct {
+	timeout   time.Duration
+	action    IdleAction
+	idleSince time.Time
+}
+
+// newIdleTimer starts an idle timer at now (an empty action restarts)
+func newIdleTimer(timeout time.Duration, action IdleAction, now time.Time) *idleTimer {
+	if action == "" {
+		action = IdleActionRestart
+	}
+	return &idleTimer{timeout: timeout, action: action, idleSince: now}
+}
+
+// check updates the timer at now, given whether the routine is running (and not paused) and
+// the bot's last progress. Once the bot has been idle for the timeout it returns the action to
+// take and how long the bot was idle; a restart starts the timer over. Returns "" otherwise.
+func (t *idleTimer) check(now time.Time, running bool, lastProgress time.Time) (IdleAction, time.Duration) {
+	if !running {
+		t.idleSince = now
+		return "", 0
+	}
+	if lastProgress.After(t.idleSince) {
+		t.idleSince = lastProgress
+	}
+
+	idle := now.Sub(t.idleSince)
+	if idle < t.timeout {
+		return "", 0
+	}
+	if t.action == IdleActionRestart {
+		t.idleSince = now
+	}
+	return t.action, idle
+}
+
+// watchIdle restarts or stops a bot whose routine runs for timeout without recording progress.
+// Time spent paused or between routine iterations (e.g. waiting for accounts) doesn't count.
+// Restarting relies on the group's restart policy running the routine again, so launch
+// validation requires it to be enabled for IdleActionRestart.
+// Runs until the bot's routine context ends.
+func (o *Orchestrator) watchIdle(group *BotGroup, botInfo *BotInfo, timeout time.Duration, action IdleAction) {
+	bot := botInfo.Bot
+
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+
+	timer := newIdleTimer(timeout, action, time.Now())
+	for {
+		select {
+		case <-botInfo.routineCtx.Done():
+			return
+		case <-bot.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		running := bot.routineController.IsRunning() && !bot.IsPaused()
+		action, idle := timer.check(time.Now(), running, bot.LastProgress())
+		if action == "" {
+			continue
+		}
+
+		reason := fmt.Sprintf("no progress for %v", idle.Round(time.Second))
+		fmt.Printf("[BotGroup '%s'] Instance %d idle watchdog: %s - %s bot\n", group.Name, botInfo.InstanceID, reason, action)
+
+		switch action {
+		case IdleActionStop:
+			group.activeBotsMu.Lock()
+			botInfo.stopReason = reason
+			botInfo.Status = BotStatusStopping
+			group.activeBotsMu.Unlock()
+			bot.Logf("Stopping (%s)\n", reason)
+			bot.Stop()
+			return
+
+		default:
+			bot.Logf("Restarting routine (%s)\n", reason)
+			bot.Stop()
+		}
+	}
+}
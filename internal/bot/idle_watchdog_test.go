@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleTimerRestartsAfterTimeout(t *testing.T) {
+	start := time.Now()
+	timer := newIdleTimer(10*time.Minute, "", start)
+
+	if action, _ := timer.check(start.Add(9*time.Minute), true, time.Time{}); action != "" {
+		t.Fatalf("idle action %q before the timeout", action)
+	}
+	action, idle := timer.check(start.Add(10*time.Minute), true, time.Time{})
+	if action != IdleActionRestart || idle != 10*time.Minute {
+		t.Fatalf("got %q after %v, want restart after 10m", action, idle)
+	}
+
+	// A restart starts the timer over
+	if action, _ := timer.check(start.Add(15*time.Minute), true, time.Time{}); action != "" {
+		t.Errorf("idle action %q right after a restart", action)
+	}
+	if action, _ := timer.check(start.Add(20*time.Minute), true, time.Time{}); action != IdleActionRestart {
+		t.Errorf("got %q a timeout after the restart, want restart", action)
+	}
+}
+
+func TestIdleTimerStopsAfterTimeout(t *testing.T) {
+	start := time.Now()
+	timer := newIdleTimer(10*time.Minute, IdleActionStop, start)
+
+	action, idle := timer.check(start.Add(12*time.Minute), true, time.Time{})
+	if action != IdleActionStop || idle != 12*time.Minute {
+		t.Errorf("got %q after %v, want stop after 12m", action, idle)
+	}
+}
+
+func TestIdleTimerResetsOnProgress(t *testing.T) {
+	start := time.Now()
+	timer := newIdleTimer(10*time.Minute, IdleActionRestart, start)
+
+	// Progress 8 minutes in pushes the timeout back to 18 minutes
+	progress := start.Add(8 * time.Minute)
+	if action, _ := timer.check(start.Add(9*time.Minute), true, progress); action != "" {
+		t.Fatalf("idle action %q after progress", action)
+	}
+	if action, _ := timer.check(start.Add(17*time.Minute), true, progress); action != "" {
+		t.Fatalf("idle action %q before the timeout counted from progress", action)
+	}
+	if action, idle := timer.check(start.Add(18*time.Minute), true, progress); action != IdleActionRestart || idle != 10*time.Minute {
+		t.Errorf("got %q after %v, want restart after 10m", action, idle)
+	}
+}
+
+func TestIdleTimerIgnoresTimeNotRunning(t *testing.T) {
+	start := time.Now()
+	timer := newIdleTimer(10*time.Minute, IdleActionStop, start)
+
+	// Paused or between iterations for 20 minutes, then running for 5
+	if action, _ := timer.check(start.Add(20*time.Minute), false, time.Time{}); action != "" {
+		t.Fatalf("idle action %q while not running", action)
+	}
+	if action, _ := timer.check(start.Add(25*time.Minute), true, time.Time{}); action != "" {
+		t.Errorf("idle action %q counting time not running", action)
+	}
+}
+
+func TestValidateIdleRestartNeedsRestartPolicy(t *testing.T) {
+	options := LaunchOptions{EmulatorTimeout: time.Minute, IdleTimeout: 10 * time.Minute}
+	if ValidateLaunchOptions(&options).Valid {
+		t.Error("idle restart without a restart policy should be invalid")
+	}
+
+	options.IdleAction = IdleActionStop
+	if result := ValidateLaunchOptions(&options); !result.Valid {
+		t.Errorf("idle stop without a restart policy should be valid: %v", result.Errors)
+	}
+}
@@ -3,7 +3,6 @@ package bot
 import (
 	"database/sql"
 	"fmt"
-	"math"
 	"path/filepath"
 	"sync"
 	"time"
@@ -25,8 +24,27 @@ type Manager struct {
 	accountPool      accountpool.AccountPool // Shared account pool (optional)
 	db               *sql.DB                 // Database connection for routine tracking (optional)
 	orchestrationID  string                  // UUID of the bot group this manager belongs to
+
+	// Active WatchBotVariables streams per instance (stopped when the bot shuts down)
+	variableWatchers map[int][]func()
+
+	// Starts routines on existing bots (the launcher sets this to the bot coordinator)
+	routineStarter RoutineStarter
+}
+
+// RoutineStarter runs routines on bots owned by a Manager.
+// It is implemented by the bot coordinator, which the bot package cannot import.
+type RoutineStarter interface {
+	// WaitForBotStopped waits until the instance has no routine execution in progress
+	WaitForBotStopped(instance int, timeout time.Duration) error
+
+	// StartRoutine starts a routine on the bot
+	StartRoutine(b *Bot, routineName string) error
 }
 
+// restartStopTimeout is how long RestartBotWithRoutine waits for the current routine to stop
+const restartStopTimeout = 30 * time.Second
+
 // NewManagerWithRegistries creates a new bot manager with externally provided registries
 // This allows multiple managers to share the same template and routine registries
 func NewManagerWithRegistries(
@@ -36,6 +54,7 @@ func NewManagerWithRegistries(
 ) *Manager {
 	return &Manager{
 		bots:             make(map[int]*Bot),
+		variableWatchers: make(map[int][]func()),
 		config:           config,
 		templateRegistry: templateRegistry,
 		routineRegistry:  routineRegistry,
@@ -98,6 +117,7 @@ func (m *Manager) ShutdownBot(instance int) error {
 
 	// Shutdown the bot (this will NOT unload shared registries)
 	bot.ShutdownWithSharedRegistries()
+	m.stopVariableWatchersLocked(instance)
 
 	delete(m.bots, instance)
 	return nil
@@ -128,6 +148,72 @@ func (m *Manager) RestartBot(instance int) (string, error) {
 	return lastRoutine, nil
 }
 
+// SetRoutineStarter sets how the manager starts routines on existing bots
+func (m *Manager) SetRoutineStarter(starter RoutineStarter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routineStarter = starter
+}
+
+// RestartBotWithRoutine stops the bot's current routine (if any), applies config overrides for
+// the new routine, and starts it through the routine starter. The new routine becomes the
+// bot's last routine, so a later RestartBot repeats it.
+func (m *Manager) RestartBotWithRoutine(instance int, routineName string, overrides map[string]string) error {
+	m.mu.RLock()
+	bot, exists := m.bots[instance]
+	starter := m.routineStarter
+	m.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("bot instance %d not found", instance)
+	}
+	if routineName == "" {
+		return fmt.Errorf("bot instance %d: routine name is required", instance)
+	}
+	if !bot.Routines().Has(routineName) {
+		return fmt.Errorf("bot instance %d: routine '%s' not found", instance, routineName)
+	}
+	if starter == nil {
+		return fmt.Errorf("bot instance %d: no routine starter configured", instance)
+	}
+
+	// Validate overrides before touching the running routine
+	configParams := routineConfigParams(bot, routineName)
+	for name := range overrides {
+		if !hasConfigParam(configParams, name) {
+			return fmt.Errorf("bot instance %d: routine '%s' has no config parameter '%s'", instance, routineName, name)
+		}
+	}
+
+	// Stop the current routine and wait for its execution to finish
+	controller := bot.RoutineController()
+	if controller.IsRunning() || controller.IsPaused() {
+		fmt.Printf("Bot %d: Stopping routine '%s' to switch to '%s'\n", instance, bot.GetLastRoutine(), routineName)
+		controller.ForceStop()
+	}
+	if err := starter.WaitForBotStopped(instance, restartStopTimeout); err != nil {
+		return fmt.Errorf("bot instance %d: current routine did not stop: %w", instance, err)
+	}
+
+	// Reset the routine controller to prepare for new execution
+	controller.Reset()
+
+	if len(overrides) > 0 {
+		if err := actions.InitializeConfigVariables(bot, configParams, overrides); err != nil {
+			return fmt.Errorf("bot instance %d: failed to apply config overrides: %w", instance, err)
+		}
+	}
+
+	bot.SetLastRoutine(routineName)
+
+	if err := starter.StartRoutine(bot, routineName); err != nil {
+		return fmt.Errorf("bot instance %d: failed to start routine '%s': %w", instance, routineName, err)
+	}
+
+	fmt.Printf("Bot %d: Restarted with routine '%s'\n", instance, routineName)
+	return nil
+}
+
 // ExecuteWithRestart executes a routine with auto-restart on failure
 // Uses the provided RestartPolicy to determine retry behavior
 // NOTE: Account injection should occur via routine-defined action steps (InjectAccount action),
@@ -152,24 +238,15 @@ func (m *Manager) ExecuteWithRestart(instance int, routineName string, policy Re
 	}
 
 	// Get routine metadata for config parameters
-	routineMetadata := bot.Routines().GetMetadata(routineName + ".yaml")
-	var configParams []actions.ConfigParam
-	if routineMetadata != nil {
-		if metadata, ok := routineMetadata.(map[string]interface{}); ok {
-			if config, ok := metadata["config"].([]actions.ConfigParam); ok {
-				configParams = config
-			}
-		}
-	}
+	configParams := routineConfigParams(bot, routineName)
 
 	// Start routine execution tracking if database is available and account is injected
 	var executionID int64
 	var accountID int64
 	if db != nil {
 		// Check if bot has device_account_id variable set (indicates account was injected)
-		if deviceAccountStr, exists := bot.Variables().Get("device_account_id"); exists && deviceAccountStr != "" {
-			// Parse account ID from variable
-			fmt.Sscanf(deviceAccountStr, "%d", &accountID)
+		if id, exists := bot.Variables().GetInt(actions.VarDeviceAccountID); exists {
+			accountID = int64(id)
 
 			// Record routine start
 			executionID, err = database.StartRoutineExecution(db, accountID, routineName, bot.OrchestrationID(), instance)
@@ -177,14 +254,17 @@ func (m *Manager) ExecuteWithRestart(instance int, routineName string, policy Re
 				fmt.Printf("Bot %d: Warning - failed to start routine tracking: %v\n", instance, err)
 			} else {
 				// Store execution_id in bot variables for UpdateRoutineMetrics action
-				bot.Variables().Set("execution_id", fmt.Sprintf("%d", executionID))
+				bot.Variables().SetPersistent(actions.VarExecutionID, fmt.Sprintf("%d", executionID))
 				fmt.Printf("Bot %d: Started routine execution tracking (ID: %d)\n", instance, executionID)
 			}
 		}
 	}
 
 	// Create routine executor with sentries
-	executor := actions.NewRoutineExecutor(routineBuilder, sentries)
+	executor := actions.NewRoutineExecutor(routineBuilder, sentries).WithName(routineName)
+	if policy.Enabled && policy.ResumeFromCheckpoint {
+		executor.WithCheckpoints()
+	}
 
 	// Helper function to execute one iteration with proper initialization
 	executeIteration := func() error {
@@ -200,14 +280,27 @@ func (m *Manager) ExecuteWithRestart(instance int, routineName string, policy Re
 			}
 		}
 
-		// Execute the routine with sentries
-		return executor.Execute(bot)
+		// Execute the routine with sentries, keeping the outcome for the GUI and tracking
+		result, err := executor.ExecuteWithResult(bot)
+		bot.SetLastRoutineResult(result)
+		fmt.Printf("Bot %d: Routine '%s' %s\n", instance, routineName, result.Summary())
+		if db != nil && executionID > 0 {
+			if recordErr := database.RecordRoutineOutcome(db, executionID, result.StepsExecuted, result.TotalSentriesFired(), result.Summary()); recordErr != nil {
+				fmt.Printf("Bot %d: Warning - %v\n", bot.Instance(), recordErr)
+			}
+		}
+		return err
 	}
 
 	// If restart is not enabled, execute once and return
 	if !policy.Enabled {
 		err := executeIteration()
 
+		// A banned account ends the routine; tracking is closed by the ban handler
+		if banErr := bot.HandleBannedAccount(db, executionID); banErr != nil {
+			return banErr
+		}
+
 		// Update routine execution tracking
 		if db != nil && executionID > 0 {
 			if err == nil {
@@ -226,12 +319,17 @@ func (m *Manager) ExecuteWithRestart(instance int, routineName string, policy Re
 
 	// Execute with retry logic
 	retryCount := 0
-	currentDelay := policy.InitialDelay
+	iterations := 0 // Successful runs, for MaxIterations
 
 	for {
 		// Execute the routine (with variable reinitialization)
 		err := executeIteration()
 
+		// A banned account must not be retried or looped, regardless of restart policy
+		if banErr := bot.HandleBannedAccount(db, executionID); banErr != nil {
+			return banErr
+		}
+
 		// Success - reset retry counter and restart routine
 		if err == nil {
 			// Update routine execution tracking
@@ -249,24 +347,32 @@ func (m *Manager) ExecuteWithRestart(instance int, routineName string, policy Re
 
 			// Reset retry counter for next iteration
 			retryCount = 0
-			currentDelay = policy.InitialDelay
+
+			iterations++
+			if policy.MaxIterations > 0 {
+				fmt.Printf("Bot %d: Routine '%s' iteration %d/%d succeeded\n", instance, routineName, iterations, policy.MaxIterations)
+				if iterations >= policy.MaxIterations {
+					fmt.Printf("Bot %d: Reached %d iterations, completing instead of restarting\n", instance, policy.MaxIterations)
+					return nil
+				}
+			}
 
 			// Start new execution tracking for next iteration
 			if db != nil {
-				if deviceAccountStr, exists := bot.Variables().Get("device_account_id"); exists && deviceAccountStr != "" {
-					fmt.Sscanf(deviceAccountStr, "%d", &accountID)
+				if id, exists := bot.Variables().GetInt(actions.VarDeviceAccountID); exists {
+					accountID = int64(id)
 					executionID, err = database.StartRoutineExecution(db, accountID, routineName, bot.OrchestrationID(), instance)
 					if err != nil {
 						fmt.Printf("Bot %d: Warning - failed to start routine tracking: %v\n", instance, err)
 						executionID = 0
 					} else {
-						bot.Variables().Set("execution_id", fmt.Sprintf("%d", executionID))
+						bot.Variables().SetPersistent(actions.VarExecutionID, fmt.Sprintf("%d", executionID))
 						fmt.Printf("Bot %d: Restarting routine from beginning (new execution ID: %d)\n", instance, executionID)
 					}
 				}
 			}
 
-			// Continue to next iteration (infinite loop until stopped)
+			// Continue to next iteration (until stopped or MaxIterations is reached)
 			continue
 		}
 
@@ -284,19 +390,13 @@ func (m *Manager) ExecuteWithRestart(instance int, routineName string, policy Re
 
 		// Log retry attempt
 		retryCount++
+		currentDelay := policy.Delay(retryCount)
 		fmt.Printf("Bot %d: Routine '%s' failed (attempt %d/%d): %v. Retrying in %v...\n",
 			instance, routineName, retryCount, policy.MaxRetries, err, currentDelay)
 
 		// Wait before retry
 		time.Sleep(currentDelay)
 
-		// Calculate next backoff delay using exponential backoff
-		nextDelay := time.Duration(float64(policy.InitialDelay) * math.Pow(policy.BackoffFactor, float64(retryCount)))
-		if nextDelay > policy.MaxDelay {
-			nextDelay = policy.MaxDelay
-		}
-		currentDelay = nextDelay
-
 		// Reset the routine controller for next attempt
 		bot.RoutineController().Reset()
 	}
@@ -310,6 +410,7 @@ func (m *Manager) ShutdownAll() {
 	// Shutdown all bots
 	for instance, bot := range m.bots {
 		bot.ShutdownWithSharedRegistries()
+		m.stopVariableWatchersLocked(instance)
 		delete(m.bots, instance)
 	}
 
@@ -405,7 +506,7 @@ func (m *Manager) ReloadTemplates() error {
 
 	if registry, ok := m.templateRegistry.(*templates.TemplateRegistry); ok {
 		registry.Clear()
-		templatesConfigPath := filepath.Join(m.basePath, "config", "templates")
+		templatesConfigPath := filepath.Join(m.basePath, TemplatesConfigDir)
 		return registry.LoadFromDirectory(templatesConfigPath)
 	}
 
@@ -424,3 +525,113 @@ func (m *Manager) GetBotVariables(instance int) (map[string]string, error) {
 
 	return bot.GetAllVariables(), nil
 }
+
+// WatchBotVariables streams a bot's variables: a snapshot is sent immediately and again
+// whenever a variable changes. Only the latest snapshot is buffered, so a slow reader skips
+// intermediate states. The channel is closed when stop is called or the bot shuts down
+// (or immediately if the bot does not exist).
+func (m *Manager) WatchBotVariables(instance int) (<-chan map[string]string, func()) {
+	out := make(chan map[string]string, 1)
+
+	m.mu.Lock()
+	bot, exists := m.bots[instance]
+	var store *actions.VariableStore
+	if exists {
+		store, _ = bot.variableStore.(*actions.VariableStore)
+	}
+	if store == nil {
+		m.mu.Unlock()
+		close(out)
+		return out, func() {}
+	}
+
+	changes, unwatch := store.Watch()
+	done := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			unwatch()
+		})
+	}
+	m.variableWatchers[instance] = append(m.variableWatchers[instance], stop)
+	m.mu.Unlock()
+
+	go func() {
+		defer close(out)
+
+		send := func() {
+			snapshot := store.GetAll()
+			// Replace an unread snapshot with the newer one
+			select {
+			case <-out:
+			default:
+			}
+			out <- snapshot
+		}
+
+		send()
+		for {
+			select {
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+				send()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out, stop
+}
+
+// BotVariableHistory returns the recent changes to one of a bot's variables, oldest first
+func (m *Manager) BotVariableHistory(instance int, key string) ([]actions.VariableChange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bot, exists := m.bots[instance]
+	if !exists {
+		return nil, fmt.Errorf("bot instance %d not found", instance)
+	}
+
+	store, ok := bot.variableStore.(*actions.VariableStore)
+	if !ok {
+		return nil, fmt.Errorf("bot instance %d does not record variable history", instance)
+	}
+
+	return store.History(key), nil
+}
+
+// stopVariableWatchersLocked ends every variable stream for an instance (caller must hold mu)
+func (m *Manager) stopVariableWatchersLocked(instance int) {
+	for _, stop := range m.variableWatchers[instance] {
+		stop()
+	}
+	delete(m.variableWatchers, instance)
+}
+
+// routineConfigParams returns the config parameters declared by a routine (nil if none)
+func routineConfigParams(bot *Bot, routineName string) []actions.ConfigParam {
+	registry, ok := bot.Routines().(*actions.RoutineRegistry)
+	if !ok {
+		return nil
+	}
+	configParams, err := registry.GetConfig(routineName)
+	if err != nil {
+		return nil
+	}
+	return configParams
+}
+
+// hasConfigParam reports whether a config parameter with the given name exists
+func hasConfigParam(params []actions.ConfigParam, name string) bool {
+	for _, param := range params {
+		if param.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -3,7 +3,10 @@ package bot
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"jordanella.com/pocket-tcg-go/internal/database"
 	"jordanella.com/pocket-tcg-go/internal/emulator"
 	"jordanella.com/pocket-tcg-go/internal/events"
+	"jordanella.com/pocket-tcg-go/internal/logging"
 	"jordanella.com/pocket-tcg-go/pkg/templates"
 )
 
@@ -26,6 +30,10 @@ type Orchestrator struct {
 	// Global configuration
 	config *Config
 
+	// Pool used by groups that don't set their own ("" = none)
+	defaultPoolName string
+	defaultPoolMu   sync.RWMutex
+
 	// Database connection for routine tracking (optional)
 	db *sql.DB
 
@@ -38,6 +46,21 @@ type Orchestrator struct {
 	// Event bus for pub/sub notifications
 	eventBus events.EventBus
 
+	// Pauses groups whose bots see the game's maintenance screen
+	maintenanceMonitor *MaintenanceMonitor
+
+	// Called when a bot reports a god pack (see SetGodPackHook)
+	godPackHook   GodPackHook
+	godPackHookMu sync.RWMutex
+
+	// Called when a bot's game loaded an injected account (see SetAccountLoadedHook)
+	accountLoadedHook   AccountLoadedHook
+	accountLoadedHookMu sync.RWMutex
+
+	// Checks a bot's game loaded an injected account (see SetAccountVerifier)
+	accountVerifier   AccountVerifier
+	accountVerifierMu sync.RWMutex
+
 	// Group management
 	groupDefinitions map[string]*BotGroupDefinition // Saved configurations
 	activeGroups     map[string]*BotGroup           // Running instances
@@ -50,8 +73,22 @@ type Orchestrator struct {
 	// Stagger delay for bot launches
 	staggerDelay time.Duration
 
+	// Global bot budget shared by all groups (0 = unlimited)
+	maxConcurrentBots int
+	botSlots          map[string]map[int]bool // group name -> instance IDs holding a slot
+	launchQueue       []*pendingLaunch        // Launches waiting for a free slot (FIFO)
+	budgetMu          sync.Mutex
+
+	// Launches also wait while free system memory is below this (0 = no limit)
+	minFreeMemoryMB int
+	memoryLow       bool          // Last check found memory below the limit (logged once)
+	memoryWatchStop chan struct{} // Stops the queue re-check loop (nil when not running)
+
 	// Configuration directory for saving group definitions
 	groupConfigDir string
+
+	// Groups launched since the orchestrator started (see SessionSummary)
+	session *sessionLog
 }
 
 // BotGroup represents a coordinated set of bots with shared configuration
@@ -68,8 +105,9 @@ type BotGroup struct {
 	orchestrator *Orchestrator
 
 	// Routine configuration
-	RoutineName   string
-	RoutineConfig map[string]string // Variable overrides
+	RoutineName      string
+	RoutineConfig    map[string]string // Variable overrides
+	InstanceRoutines map[int]string    // Per-instance routine overrides (see BotGroupDefinition.InstanceRoutines)
 
 	// Emulator instance pool
 	AvailableInstances []int            // Pool of instances this group can use
@@ -81,10 +119,27 @@ type BotGroup struct {
 	AccountPoolName     string                  // Name of pool definition (resolved via PoolManager)
 	AccountPool         accountpool.AccountPool // Execution-specific pool instance for this orchestration
 	InitialAccountCount int                     // Total accounts when pool first populated (for progress monitoring)
+	reservation         *accountpool.ReservedPool // Accounts reserved for the current launch (nil when not running)
+	reservationMu       sync.Mutex
+	progress            progressTracker // Completion samples for GroupETA
+	warmInstances       map[int]bool                 // Instances accounts are pre-injected onto (see EnableWarmPool)
+	warmed              map[int]*warmAccount         // Warm instance -> account reserved and injected there
+	preInjected         map[int]*accountpool.Account // Instance -> warmed account its bot took, not injected again
+	warmMu              sync.Mutex
+	launchGame          bool            // Start the game before each bot's routine (LaunchOptions.LaunchGame)
+	startupRoutine      string          // Routine run before each bot's routine (LaunchOptions.StartupRoutine)
+	logSink             *logging.FileSink // Per-instance log files (LaunchOptions.LogToFiles; nil when off)
+	launchOptions       LaunchOptions     // Options of the current launch, reused when ScaleGroup adds bots
+	staggerDelay        time.Duration     // Delay between bot launches, adjustable while running (see SetStaggerDelay)
+	staggerMu           sync.RWMutex
+	timeLimit           *runTimeLimit     // MaxRunDuration timer of the current run (nil when it has none)
+	timeLimitMu         sync.Mutex
 
 	// Runtime state
-	running   bool
-	runningMu sync.RWMutex
+	running       bool
+	launchAborted bool // Set by EmergencyStop so a launch in progress stops starting bots
+	poolClosed    bool // Set by EmergencyStop: AccountPool was closed, so the next launch resolves it again
+	runningMu     sync.RWMutex
 
 	// Context for cancellation
 	ctx        context.Context
@@ -98,22 +153,28 @@ type BotInfo struct {
 	StartedAt  time.Time
 	Status     BotStatus
 	Error      error
+	stopReason string // Why the bot was asked to stop after its current routine iteration ("" while it keeps running)
 
 	// Routine execution context
 	routineCtx    context.Context
 	routineCancel context.CancelFunc
+	routineDone   chan struct{} // Closed once runBotRoutine has finished, cleanup included
 }
 
 // BotStatus represents the current state of a bot
 type BotStatus string
 
 const (
+	BotStatusQueued    BotStatus = "queued"
 	BotStatusStarting  BotStatus = "starting"
 	BotStatusRunning   BotStatus = "running"
 	BotStatusStopping  BotStatus = "stopping"
 	BotStatusStopped   BotStatus = "stopped"
 	BotStatusFailed    BotStatus = "failed"
 	BotStatusCompleted BotStatus = "completed"
+
+	// Idle because the group's pool ran out of accounts (see PoolExhaustedWaitMinutes)
+	BotStatusWaitingForAccounts BotStatus = "waiting_for_accounts"
 )
 
 // InstanceAssignment tracks which group/bot is using an emulator instance
@@ -142,6 +203,7 @@ type LaunchOptions struct {
 	ValidateRoutine   bool `yaml:"validate_routine" json:"validate_routine"`
 	ValidateTemplates bool `yaml:"validate_templates" json:"validate_templates"`
 	ValidateEmulators bool `yaml:"validate_emulators" json:"validate_emulators"`
+	ValidateProxies   bool `yaml:"validate_proxies,omitempty" json:"validate_proxies,omitempty"` // Check each instance's proxy accepts connections before starting its bot
 
 	// Conflict handling
 	OnConflict ConflictResolution `yaml:"on_conflict" json:"on_conflict"`
@@ -149,9 +211,27 @@ type LaunchOptions struct {
 	// Launch behavior
 	StaggerDelay    time.Duration `yaml:"stagger_delay" json:"stagger_delay"`
 	EmulatorTimeout time.Duration `yaml:"emulator_timeout" json:"emulator_timeout"`
+	LaunchGame      bool          `yaml:"launch_game" json:"launch_game"` // Start the game on each instance (if not running) before its routine
+	StartupRoutine  string        `yaml:"startup_routine,omitempty" json:"startup_routine,omitempty"` // Routine run before the group's routine, e.g. to dismiss launch-time dialogs
+
+	// Per-instance log files (logs/<group>/instance-<n>.log), written besides the console and log tab
+	LogToFiles   bool `yaml:"log_to_files" json:"log_to_files"`
+	LogMaxSizeMB int  `yaml:"log_max_size_mb,omitempty" json:"log_max_size_mb,omitempty"` // Size a log file rotates at (0 = 10 MB)
+	LogMaxFiles  int  `yaml:"log_max_files,omitempty" json:"log_max_files,omitempty"`     // Rotated files kept per instance (0 = 5)
 
 	// Restart policy for bots
 	RestartPolicy RestartPolicy `yaml:"restart_policy" json:"restart_policy"`
+
+	// Idle watchdog: acts on a bot that completes no account and opens no pack for this long (0 = disabled)
+	IdleTimeout time.Duration `yaml:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`
+	IdleAction  IdleAction    `yaml:"idle_action,omitempty" json:"idle_action,omitempty"` // What to do with an idle bot (empty = restart)
+
+	// Pause a bot on a failed step (instead of failing and restarting) until it is resumed,
+	// the step skipped or the routine aborted. For debugging routines, not production runs.
+	PauseOnError bool `yaml:"pause_on_error,omitempty" json:"pause_on_error,omitempty"`
+
+	// Stop the group gracefully (bots finish their current iteration) this long after launch (0 = no limit)
+	MaxRunDuration time.Duration `yaml:"max_run_duration,omitempty" json:"max_run_duration,omitempty"`
 }
 
 // NewOrchestrator creates a new bot orchestrator
@@ -182,7 +262,7 @@ func NewOrchestrator(
 		poolManager.SetEventBus(eventBus)
 	}
 
-	return &Orchestrator{
+	o := &Orchestrator{
 		config:           config,
 		templateRegistry: templateRegistry,
 		routineRegistry:  routineRegistry,
@@ -194,9 +274,19 @@ func NewOrchestrator(
 		groupDefinitions: make(map[string]*BotGroupDefinition),
 		activeGroups:     make(map[string]*BotGroup),
 		instanceRegistry: make(map[int]*InstanceAssignment),
+		botSlots:         make(map[string]map[int]bool),
+		launchQueue:      make([]*pendingLaunch, 0),
 		staggerDelay:     5 * time.Second, // Default 5 second stagger
 		groupConfigDir:   groupConfigDir,
+		session:          newSessionLog(time.Now()),
 	}
+
+	// Create and start maintenance monitor (idle until a maintenance template is configured)
+	o.maintenanceMonitor = NewMaintenanceMonitor(o, config)
+	o.maintenanceMonitor.SetEventBus(eventBus)
+	o.maintenanceMonitor.Start()
+
+	return o
 }
 
 // SetStaggerDelay sets the delay between bot launches
@@ -204,6 +294,25 @@ func (o *Orchestrator) SetStaggerDelay(delay time.Duration) {
 	o.staggerDelay = delay
 }
 
+// GetMaintenanceMonitor returns the orchestrator's maintenance monitor
+func (o *Orchestrator) GetMaintenanceMonitor() *MaintenanceMonitor {
+	return o.maintenanceMonitor
+}
+
+// Shutdown stops the orchestrator's background monitoring: the maintenance and health
+// monitors and the free memory watch. Running groups are left alone; stop them first.
+func (o *Orchestrator) Shutdown() {
+	o.maintenanceMonitor.Stop()
+	o.healthMonitor.Stop()
+
+	o.budgetMu.Lock()
+	if o.memoryWatchStop != nil {
+		close(o.memoryWatchStop)
+		o.memoryWatchStop = nil
+	}
+	o.budgetMu.Unlock()
+}
+
 // GetEventBus returns the orchestrator's event bus for subscription
 func (o *Orchestrator) GetEventBus() events.EventBus {
 	return o.eventBus
@@ -281,7 +390,7 @@ func (o *Orchestrator) CreateGroupFromDefinition(def *BotGroupDefinition) (*BotG
 	}
 
 	// Use the existing CreateGroup method
-	return o.CreateGroup(
+	group, err := o.CreateGroup(
 		def.Name,
 		def.RoutineName,
 		def.AvailableInstances,
@@ -289,6 +398,12 @@ func (o *Orchestrator) CreateGroupFromDefinition(def *BotGroupDefinition) (*BotG
 		def.RoutineConfig,
 		def.AccountPoolName,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	group.InstanceRoutines = copyInstanceRoutines(def.InstanceRoutines)
+	return group, nil
 }
 
 // DeleteGroup removes a group (must be stopped first)
@@ -343,6 +458,16 @@ func (o *Orchestrator) ListGroups() []string {
 	return names
 }
 
+// RoutineForInstance returns the routine an instance runs: its override, or the group routine
+func (g *BotGroup) RoutineForInstance(instanceID int) string {
+	return routineForInstance(g.RoutineName, g.InstanceRoutines, instanceID)
+}
+
+// RoutineNames returns every routine the group runs, without duplicates
+func (g *BotGroup) RoutineNames() []string {
+	return distinctRoutines(g.RoutineName, g.InstanceRoutines)
+}
+
 // IsRunning returns whether the group is currently running
 func (g *BotGroup) IsRunning() bool {
 	g.runningMu.RLock()
@@ -412,6 +537,7 @@ func (o *Orchestrator) SetGroupAccountPool(groupName, poolName string) error {
 	group.AccountPoolName = poolName
 	group.AccountPool = pool
 	group.InitialAccountCount = initialCount
+	group.progress.reset(0, time.Now())
 	// Account pool is already set on the group
 
 	fmt.Printf("Bot Group '%s' (orchestration %s): Populated pool '%s' with %d accounts\n",
@@ -438,6 +564,42 @@ func (o *Orchestrator) resolveAccountPool(poolName string) (accountpool.AccountP
 	return pool, nil
 }
 
+// SetDefaultPool sets the account pool used by groups launched without one of their own,
+// and records it in the config (defaultPool) so it is saved with it.
+// The pool must exist; an empty name clears the default.
+func (o *Orchestrator) SetDefaultPool(name string) error {
+	name = strings.TrimSpace(name)
+	if name != "" {
+		if o.poolManager == nil {
+			return fmt.Errorf("pool manager not configured")
+		}
+		if _, err := o.poolManager.GetPoolDefinition(name); err != nil {
+			return fmt.Errorf("default pool '%s' does not exist: %w", name, err)
+		}
+	}
+
+	o.defaultPoolMu.Lock()
+	o.defaultPoolName = name
+	if o.config != nil {
+		o.config.DefaultPool = name
+	}
+	o.defaultPoolMu.Unlock()
+
+	if name == "" {
+		fmt.Println("Orchestrator: Default pool cleared")
+	} else {
+		fmt.Printf("Orchestrator: Default pool set to '%s'\n", name)
+	}
+	return nil
+}
+
+// GetDefaultPool returns the account pool used by groups without one ("" = none)
+func (o *Orchestrator) GetDefaultPool() string {
+	o.defaultPoolMu.RLock()
+	defer o.defaultPoolMu.RUnlock()
+	return o.defaultPoolName
+}
+
 // RefreshGroupAccountPool manually refreshes a group's account pool
 func (o *Orchestrator) RefreshGroupAccountPool(groupName string) error {
 	group, exists := o.GetGroup(groupName)
@@ -475,7 +637,7 @@ func (o *Orchestrator) GetGroupAccountProgress(groupName string) (processed int,
 
 // SaveGroupDefinition saves a group definition to memory and disk
 func (o *Orchestrator) SaveGroupDefinition(def *BotGroupDefinition) error {
-	if err := def.Validate(); err != nil {
+	if err := o.ValidateDefinition(def).Err(); err != nil {
 		return fmt.Errorf("invalid definition: %w", err)
 	}
 
@@ -587,6 +749,44 @@ func (o *Orchestrator) DeleteGroupDefinition(name string) error {
 	return nil
 }
 
+// GroupsUsing returns the names of group definitions whose routine is the given
+// routine or runs it (directly or through nested RunRoutine steps and sentries)
+func (o *Orchestrator) GroupsUsing(filename string) []string {
+	filename = routineNameWithoutExt(filename)
+
+	// Collect the routine and everything that depends on it
+	affected := map[string]bool{filename: true}
+	if o.routineRegistry != nil {
+		pending := []string{filename}
+		for len(pending) > 0 {
+			current := pending[0]
+			pending = pending[1:]
+			for _, dependent := range o.routineRegistry.Dependents(current) {
+				if !affected[dependent] {
+					affected[dependent] = true
+					pending = append(pending, dependent)
+				}
+			}
+		}
+	}
+
+	o.groupsMu.RLock()
+	defer o.groupsMu.RUnlock()
+
+	names := make([]string, 0)
+	for name, def := range o.groupDefinitions {
+		for _, routineName := range def.RoutineNames() {
+			if affected[routineNameWithoutExt(routineName)] {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 // GetActiveGroup retrieves a running group by name
 func (o *Orchestrator) GetActiveGroup(name string) (*BotGroup, bool) {
 	o.groupsMu.RLock()
@@ -634,6 +834,16 @@ func (g *BotGroup) createBot(instanceID int) (*Bot, error) {
 	bot.templateRegistry = g.orchestrator.templateRegistry
 	bot.routineRegistry = g.orchestrator.routineRegistry
 	bot.SetOrchestrationID(g.OrchestrationID)
+	bot.SetPauseOnError(g.launchOptions.PauseOnError)
+
+	// Mirror the bot's log lines to its log file when the launch asked for one
+	if g.logSink != nil {
+		if w, err := g.logSink.Writer(g.Name, instanceID); err != nil {
+			fmt.Printf("[BotGroup '%s'] Warning: failed to open log file for instance %d: %v\n", g.Name, instanceID, err)
+		} else {
+			bot.SetLogWriter(w)
+		}
+	}
 
 	// Inject manager adapter so bot can access account pool
 	if g.AccountPool != nil {
@@ -711,43 +921,40 @@ func (g *BotGroup) executeWithRestart(instanceID int, routineName string, policy
 		return fmt.Errorf("failed to get routine '%s': %w", routineName, err)
 	}
 
-	// Get routine metadata for config parameters
-	routineMetadata := bot.Routines().GetMetadata(routineName + ".yaml")
-	var configParams []actions.ConfigParam
-	if routineMetadata != nil {
-		if metadata, ok := routineMetadata.(map[string]interface{}); ok {
-			if config, ok := metadata["config"].([]actions.ConfigParam); ok {
-				configParams = config
-			}
-		}
-	}
+	// Get the routine's config parameters
+	configParams := routineConfigParams(bot, routineName)
 
 	// Start routine execution tracking if database is available and account is injected
 	var executionID int64
 	var accountID int64
 	if db != nil {
 		// Check if bot has device_account_id variable set (indicates account was injected)
-		if deviceAccountStr, exists := bot.Variables().Get("device_account_id"); exists && deviceAccountStr != "" {
-			// Parse account ID from variable
-			fmt.Sscanf(deviceAccountStr, "%d", &accountID)
+		if id, exists := bot.Variables().GetInt(actions.VarDeviceAccountID); exists {
+			accountID = int64(id)
 
 			// Record routine start
 			executionID, err = database.StartRoutineExecution(db, accountID, routineName, bot.OrchestrationID(), instanceID)
 			if err != nil {
-				fmt.Printf("Bot %d: Warning - failed to start routine tracking: %v\n", instanceID, err)
+				bot.Logf("Warning - failed to start routine tracking: %v\n", err)
 			} else {
 				// Store execution_id in bot variables for UpdateRoutineMetrics action
-				bot.Variables().Set("execution_id", fmt.Sprintf("%d", executionID))
-				fmt.Printf("Bot %d: Started routine execution tracking (ID: %d)\n", instanceID, executionID)
+				bot.Variables().SetPersistent(actions.VarExecutionID, fmt.Sprintf("%d", executionID))
+				bot.Logf("Started routine execution tracking (ID: %d)\n", executionID)
 			}
 		}
 	}
 
 	// Create routine executor with sentries
-	executor := actions.NewRoutineExecutor(routineBuilder, sentries)
+	executor := actions.NewRoutineExecutor(routineBuilder, sentries).WithName(routineName)
+	if policy.Enabled && policy.ResumeFromCheckpoint {
+		executor.WithCheckpoints()
+	}
 
 	// Helper function to execute one iteration with proper initialization
 	executeIteration := func() error {
+		// Get the next account ready on an idle warm instance while this one runs
+		go g.warmNext()
+
 		// Clear non-persistent variables before each iteration
 		if vs, ok := bot.Variables().(*actions.VariableStore); ok {
 			vs.ClearNonPersistent()
@@ -760,68 +967,111 @@ func (g *BotGroup) executeWithRestart(instanceID int, routineName string, policy
 			}
 		}
 
-		// Execute the routine with sentries
-		return executor.Execute(bot)
+		// Execute the routine with sentries, keeping the outcome for the GUI and tracking
+		result, err := executor.ExecuteWithResult(bot)
+		bot.SetLastRoutineResult(result)
+		bot.Logf("Routine '%s' %s\n", routineName, result.Summary())
+		if db != nil && executionID > 0 {
+			if recordErr := database.RecordRoutineOutcome(db, executionID, result.StepsExecuted, result.TotalSentriesFired(), result.Summary()); recordErr != nil {
+				fmt.Printf("Bot %d: Warning - %v\n", bot.Instance(), recordErr)
+			}
+		}
+		return err
 	}
 
 	// If restart is not enabled, execute once and return
 	if !policy.Enabled {
 		err := executeIteration()
 
+		// Idle for accounts if the pool ran out, then run once they are available
+		for g.AccountPool != nil && errors.Is(err, accountpool.ErrNoAccountsAvailable) && g.waitForAccounts(bot, instanceID) {
+			err = executeIteration()
+		}
+
+		// A banned account ends the routine; tracking is closed by the ban handler
+		if banErr := g.handleBannedAccount(bot, db, executionID); banErr != nil {
+			return banErr
+		}
+
 		// Update routine execution tracking
 		if db != nil && executionID > 0 {
 			if err == nil {
 				if completeErr := database.CompleteRoutineExecution(db, executionID, 0, 0); completeErr != nil {
-					fmt.Printf("Bot %d: Warning - failed to mark routine as completed: %v\n", instanceID, completeErr)
+					bot.Logf("Warning - failed to mark routine as completed: %v\n", completeErr)
 				}
 			} else {
 				if failErr := database.FailRoutineExecution(db, executionID, err.Error()); failErr != nil {
-					fmt.Printf("Bot %d: Warning - failed to mark routine as failed: %v\n", instanceID, failErr)
+					bot.Logf("Warning - failed to mark routine as failed: %v\n", failErr)
 				}
 			}
 		}
 
+		// Running out of accounts stops the bot cleanly rather than failing it
+		if g.AccountPool != nil && errors.Is(err, accountpool.ErrNoAccountsAvailable) {
+			return nil
+		}
 		return err
 	}
 
 	// Execute with retry logic
 	retryCount := 0
-	currentDelay := policy.InitialDelay
+	iterations := 0 // Successful runs, for MaxIterations
 
 	for {
 		// Execute the routine (with variable reinitialization)
 		err := executeIteration()
 
+		// A banned account must not be retried or looped, regardless of restart policy
+		if banErr := g.handleBannedAccount(bot, db, executionID); banErr != nil {
+			return banErr
+		}
+
 		// Success - reset retry counter and restart routine
 		if err == nil {
 			// Update routine execution tracking
 			if db != nil && executionID > 0 {
 				if completeErr := database.CompleteRoutineExecution(db, executionID, 0, 0); completeErr != nil {
-					fmt.Printf("Bot %d: Warning - failed to mark routine as completed: %v\n", instanceID, completeErr)
+					bot.Logf("Warning - failed to mark routine as completed: %v\n", completeErr)
 				} else {
-					fmt.Printf("Bot %d: Routine execution completed and tracked (ID: %d)\n", instanceID, executionID)
+					bot.Logf("Routine execution completed and tracked (ID: %d)\n", executionID)
 				}
 			}
 
 			if policy.ResetOnSuccess && retryCount > 0 {
-				fmt.Printf("Bot %d: Routine '%s' succeeded after %d retries\n", instanceID, routineName, retryCount)
+				bot.Logf("Routine '%s' succeeded after %d retries\n", routineName, retryCount)
 			}
 
 			// Reset retry counter for next iteration
 			retryCount = 0
-			currentDelay = policy.InitialDelay
+
+			iterations++
+			if policy.MaxIterations > 0 {
+				bot.Logf("Routine '%s' iteration %d/%d succeeded\n", routineName, iterations, policy.MaxIterations)
+				if iterations >= policy.MaxIterations {
+					bot.Logf("Reached %d iterations, completing instead of restarting\n", policy.MaxIterations)
+					return nil
+				}
+			} else {
+				bot.Logf("Routine '%s' iteration %d succeeded\n", routineName, iterations)
+			}
+
+			// A bot scaled out of the group stops between iterations
+			if reason := g.stopReason(instanceID); reason != "" {
+				bot.Logf("Stopping after routine iteration (%s)\n", reason)
+				return nil
+			}
 
 			// Start new execution tracking for next iteration
 			if db != nil {
-				if deviceAccountStr, exists := bot.Variables().Get("device_account_id"); exists && deviceAccountStr != "" {
-					fmt.Sscanf(deviceAccountStr, "%d", &accountID)
+				if id, exists := bot.Variables().GetInt(actions.VarDeviceAccountID); exists {
+					accountID = int64(id)
 					executionID, err = database.StartRoutineExecution(db, accountID, routineName, bot.OrchestrationID(), instanceID)
 					if err != nil {
-						fmt.Printf("Bot %d: Warning - failed to start routine tracking: %v\n", instanceID, err)
+						bot.Logf("Warning - failed to start routine tracking: %v\n", err)
 						executionID = 0
 					} else {
-						bot.Variables().Set("execution_id", fmt.Sprintf("%d", executionID))
-						fmt.Printf("Bot %d: Restarting routine from beginning (new execution ID: %d)\n", instanceID, executionID)
+						bot.Variables().SetPersistent(actions.VarExecutionID, fmt.Sprintf("%d", executionID))
+						bot.Logf("Restarting routine from beginning (new execution ID: %d)\n", executionID)
 					}
 				}
 			}
@@ -829,39 +1079,9 @@ func (g *BotGroup) executeWithRestart(instanceID int, routineName string, policy
 			// Check if accounts are available before continuing
 			// This prevents infinite loops when account pool is exhausted
 			// The routine itself will call InjectNextAccount when it needs an account
-			if g.AccountPool != nil {
-				stats := g.AccountPool.GetStats()
-				if stats.Available == 0 {
-					fmt.Printf("Bot %d: No accounts available in pool. Waiting for accounts to become available...\n", instanceID)
-
-					// Wait for accounts to become available (with timeout)
-					accountAvailable := false
-					maxWait := 5 * time.Minute
-					checkInterval := 10 * time.Second
-					elapsed := time.Duration(0)
-
-					for elapsed < maxWait {
-						time.Sleep(checkInterval)
-						elapsed += checkInterval
-
-						stats = g.AccountPool.GetStats()
-						if stats.Available > 0 {
-							accountAvailable = true
-							fmt.Printf("Bot %d: Accounts now available (%d accounts). Continuing...\n", instanceID, stats.Available)
-							break
-						}
-
-						// Check if bot should stop
-						if bot.routineController.IsStopped() {
-							fmt.Printf("Bot %d: Stopped while waiting for accounts\n", instanceID)
-							return nil
-						}
-					}
-
-					if !accountAvailable {
-						fmt.Printf("Bot %d: Timeout waiting for accounts after %v. Stopping bot.\n", instanceID, maxWait)
-						return fmt.Errorf("no accounts available after waiting %v", maxWait)
-					}
+			if g.AccountPool != nil && g.AccountPool.GetStats().Available == 0 {
+				if !g.waitForAccounts(bot, instanceID) {
+					return nil
 				}
 			}
 
@@ -869,15 +1089,34 @@ func (g *BotGroup) executeWithRestart(instanceID int, routineName string, policy
 			continue
 		}
 
+		// An exhausted pool is not a routine failure: idle for accounts instead of retrying
+		if g.AccountPool != nil && errors.Is(err, accountpool.ErrNoAccountsAvailable) {
+			if db != nil && executionID > 0 {
+				if failErr := database.FailRoutineExecution(db, executionID, err.Error()); failErr != nil {
+					bot.Logf("Warning - failed to mark routine as failed: %v\n", failErr)
+				}
+				executionID = 0
+			}
+			if !g.waitForAccounts(bot, instanceID) {
+				return nil
+			}
+			retryCount = 0
+			continue
+		}
+
 		// Check if we've exceeded max retries
 		if policy.MaxRetries > 0 && retryCount >= policy.MaxRetries {
 			// Update routine execution tracking on final failure
 			if db != nil && executionID > 0 {
 				if failErr := database.FailRoutineExecution(db, executionID, err.Error()); failErr != nil {
-					fmt.Printf("Bot %d: Warning - failed to mark routine as failed: %v\n", instanceID, failErr)
+					bot.Logf("Warning - failed to mark routine as failed: %v\n", failErr)
 				}
 			}
 
+			if g.orchestrator.eventBus != nil {
+				g.orchestrator.eventBus.PublishAsync(events.NewCircuitBreakerTrippedEvent(g.Name, instanceID, routineName, retryCount+1, err))
+			}
+
 			return fmt.Errorf("bot %d routine '%s' failed after %d retries: %w", instanceID, routineName, retryCount, err)
 		}
 
@@ -888,33 +1127,33 @@ func (g *BotGroup) executeWithRestart(instanceID int, routineName string, policy
 		// Update routine execution tracking on failure (but continuing retries)
 		if db != nil && executionID > 0 {
 			if failErr := database.FailRoutineExecution(db, executionID, err.Error()); failErr != nil {
-				fmt.Printf("Bot %d: Warning - failed to mark routine as failed: %v\n", instanceID, failErr)
+				bot.Logf("Warning - failed to mark routine as failed: %v\n", failErr)
 			}
 		}
 
-		// Calculate delay with backoff
-		retryCount++
-		if retryCount > 1 {
-			currentDelay = time.Duration(float64(currentDelay) * policy.BackoffFactor)
-			if currentDelay > policy.MaxDelay {
-				currentDelay = policy.MaxDelay
-			}
+		if reason := g.stopReason(instanceID); reason != "" {
+			bot.Logf("Stopping instead of retrying (%s)\n", reason)
+			return nil
 		}
 
+		// Calculate delay with the policy's backoff strategy
+		retryCount++
+		currentDelay := policy.Delay(retryCount)
+
 		// Wait before retrying
-		fmt.Printf("Bot %d: Waiting %v before retry %d...\n", instanceID, currentDelay, retryCount+1)
+		bot.Logf("Waiting %v before retry %d...\n", currentDelay, retryCount+1)
 		time.Sleep(currentDelay)
 
 		// Start new execution tracking for retry
 		if db != nil {
-			if deviceAccountStr, exists := bot.Variables().Get("device_account_id"); exists && deviceAccountStr != "" {
-				fmt.Sscanf(deviceAccountStr, "%d", &accountID)
+			if id, exists := bot.Variables().GetInt(actions.VarDeviceAccountID); exists {
+				accountID = int64(id)
 				executionID, err = database.StartRoutineExecution(db, accountID, routineName, bot.OrchestrationID(), instanceID)
 				if err != nil {
-					fmt.Printf("Bot %d: Warning - failed to start routine tracking: %v\n", instanceID, err)
+					bot.Logf("Warning - failed to start routine tracking: %v\n", err)
 					executionID = 0
 				} else {
-					bot.Variables().Set("execution_id", fmt.Sprintf("%d", executionID))
+					bot.Variables().SetPersistent(actions.VarExecutionID, fmt.Sprintf("%d", executionID))
 				}
 			}
 		}
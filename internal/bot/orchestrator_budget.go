@@ -0,0 +1,320 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/emulator"
+)
+
+// memoryRecheckInterval is how often queued launches are retried while they wait for memory
+const memoryRecheckInterval = 15 * time.Second
+
+// pendingLaunch is a bot launch waiting for a free slot in the global bot budget
+type pendingLaunch struct {
+	group      *BotGroup
+	instanceID int
+	policy     RestartPolicy
+	queuedAt   time.Time
+}
+
+// SetMaxConcurrentBots sets the maximum number of bots allowed to run across all groups.
+// A value of 0 (or less) removes the limit. Raising the limit immediately starts queued bots.
+func (o *Orchestrator) SetMaxConcurrentBots(n int) {
+	if n < 0 {
+		n = 0
+	}
+
+	o.budgetMu.Lock()
+	o.maxConcurrentBots = n
+	o.budgetMu.Unlock()
+
+	fmt.Printf("[Budget] Max concurrent bots set to %d (0 = unlimited)\n", n)
+
+	// A larger budget may free room for queued launches
+	o.processLaunchQueue()
+}
+
+// GetMaxConcurrentBots returns the global bot budget (0 = unlimited)
+func (o *Orchestrator) GetMaxConcurrentBots() int {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+	return o.maxConcurrentBots
+}
+
+// SetMinFreeMemoryMB sets how much free system memory (MB) must remain for another bot to
+// start. Launches below it are queued until memory frees up. 0 (or less) removes the limit.
+func (o *Orchestrator) SetMinFreeMemoryMB(mb int) {
+	if mb < 0 {
+		mb = 0
+	}
+
+	o.budgetMu.Lock()
+	o.minFreeMemoryMB = mb
+	o.memoryLow = false
+	if mb > 0 && o.memoryWatchStop == nil {
+		o.memoryWatchStop = make(chan struct{})
+		go o.watchMemory(o.memoryWatchStop)
+	} else if mb == 0 && o.memoryWatchStop != nil {
+		close(o.memoryWatchStop)
+		o.memoryWatchStop = nil
+	}
+	o.budgetMu.Unlock()
+
+	fmt.Printf("[Budget] Min free memory set to %d MB (0 = no limit)\n", mb)
+
+	// A lower limit may free room for queued launches
+	o.processLaunchQueue()
+}
+
+// GetMinFreeMemoryMB returns the free memory launches must leave (0 = no limit)
+func (o *Orchestrator) GetMinFreeMemoryMB() int {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+	return o.minFreeMemoryMB
+}
+
+// IsMemoryLow reports whether launches are currently held back by low system memory
+func (o *Orchestrator) IsMemoryLow() bool {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+	return o.memoryLow
+}
+
+// watchMemory retries queued launches periodically, since nothing else wakes the queue
+// when memory frees up outside the bot budget
+func (o *Orchestrator) watchMemory(stop chan struct{}) {
+	ticker := time.NewTicker(memoryRecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			o.processLaunchQueue()
+		}
+	}
+}
+
+// ActiveBotCount returns the number of bots holding a budget slot across all groups
+func (o *Orchestrator) ActiveBotCount() int {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+	return o.activeSlotCountLocked()
+}
+
+// QueuedBotCount returns the number of bot launches waiting for budget across all groups
+func (o *Orchestrator) QueuedBotCount() int {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+	return len(o.launchQueue)
+}
+
+// GetGroupBudgetStatus returns how many bots a group has launched and how many are queued
+func (o *Orchestrator) GetGroupBudgetStatus(groupName string) (launched int, queued int) {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+
+	launched = len(o.botSlots[groupName])
+	for _, pending := range o.launchQueue {
+		if pending.group.Name == groupName {
+			queued++
+		}
+	}
+	return launched, queued
+}
+
+// GetQueuedInstances returns the instance IDs a group has waiting for budget, in queue order
+func (o *Orchestrator) GetQueuedInstances(groupName string) []int {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+
+	instances := make([]int, 0)
+	for _, pending := range o.launchQueue {
+		if pending.group.Name == groupName {
+			instances = append(instances, pending.instanceID)
+		}
+	}
+	return instances
+}
+
+// activeSlotCountLocked counts held slots (caller must hold budgetMu)
+func (o *Orchestrator) activeSlotCountLocked() int {
+	count := 0
+	for _, slots := range o.botSlots {
+		count += len(slots)
+	}
+	return count
+}
+
+// hasBudgetLocked reports whether another bot may start (caller must hold budgetMu)
+func (o *Orchestrator) hasBudgetLocked() bool {
+	if o.maxConcurrentBots > 0 && o.activeSlotCountLocked() >= o.maxConcurrentBots {
+		return false
+	}
+	return o.hasMemoryLocked()
+}
+
+// hasMemoryLocked reports whether free system memory is above the configured minimum,
+// logging when launches start and stop being held back (caller must hold budgetMu).
+// If memory can't be read the launch is allowed.
+func (o *Orchestrator) hasMemoryLocked() bool {
+	if o.minFreeMemoryMB <= 0 {
+		return true
+	}
+
+	availableMB, _, err := emulator.SystemMemory()
+	if err != nil {
+		return true
+	}
+
+	low := availableMB < o.minFreeMemoryMB
+	if low != o.memoryLow {
+		if low {
+			fmt.Printf("[Budget] Free memory %d MB is below %d MB - holding new bot launches\n",
+				availableMB, o.minFreeMemoryMB)
+		} else {
+			fmt.Printf("[Budget] Free memory recovered to %d MB - resuming bot launches\n", availableMB)
+		}
+		o.memoryLow = low
+	}
+	return !low
+}
+
+// tryAcquireBotSlot claims a budget slot for a bot if one is free.
+// Queued launches are served first so a new group cannot jump the queue.
+func (o *Orchestrator) tryAcquireBotSlot(groupName string, instanceID int) bool {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+
+	if len(o.launchQueue) > 0 || !o.hasBudgetLocked() {
+		return false
+	}
+
+	o.claimSlotLocked(groupName, instanceID)
+	return true
+}
+
+// claimSlotLocked records a slot as held (caller must hold budgetMu)
+func (o *Orchestrator) claimSlotLocked(groupName string, instanceID int) {
+	if o.botSlots[groupName] == nil {
+		o.botSlots[groupName] = make(map[int]bool)
+	}
+	o.botSlots[groupName][instanceID] = true
+}
+
+// releaseBotSlot frees a bot's budget slot and starts the next queued launch.
+// Releasing a slot that is not held is a no-op.
+func (o *Orchestrator) releaseBotSlot(groupName string, instanceID int) {
+	o.dropBotSlot(groupName, instanceID)
+	o.processLaunchQueue()
+}
+
+// dropBotSlot frees a bot's budget slot without starting queued launches
+func (o *Orchestrator) dropBotSlot(groupName string, instanceID int) {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+
+	if slots, exists := o.botSlots[groupName]; exists {
+		delete(slots, instanceID)
+		if len(slots) == 0 {
+			delete(o.botSlots, groupName)
+		}
+	}
+}
+
+// enqueueLaunch queues a bot launch until budget becomes available
+func (o *Orchestrator) enqueueLaunch(group *BotGroup, instanceID int, policy RestartPolicy) {
+	o.budgetMu.Lock()
+	o.launchQueue = append(o.launchQueue, &pendingLaunch{
+		group:      group,
+		instanceID: instanceID,
+		policy:     policy,
+		queuedAt:   time.Now(),
+	})
+	queueLen := len(o.launchQueue)
+	o.budgetMu.Unlock()
+
+	fmt.Printf("[Budget] Group '%s': queued bot on instance %d (queue length: %d)\n",
+		group.Name, instanceID, queueLen)
+}
+
+// dropQueuedLaunches removes a group's queued launches.
+// Returns the instance IDs whose queued launches were dropped.
+func (o *Orchestrator) dropQueuedLaunches(groupName string) []int {
+	o.budgetMu.Lock()
+	dropped := make([]int, 0)
+	remaining := make([]*pendingLaunch, 0, len(o.launchQueue))
+	for _, pending := range o.launchQueue {
+		if pending.group.Name == groupName {
+			dropped = append(dropped, pending.instanceID)
+			continue
+		}
+		remaining = append(remaining, pending)
+	}
+	o.launchQueue = remaining
+	o.budgetMu.Unlock()
+
+	if len(dropped) > 0 {
+		fmt.Printf("[Budget] Group '%s': dropped %d queued launch(es)\n", groupName, len(dropped))
+	}
+
+	return dropped
+}
+
+// dropLastQueuedLaunches removes up to n of a group's queued launches, newest first.
+// Returns the instance IDs whose queued launches were dropped.
+func (o *Orchestrator) dropLastQueuedLaunches(groupName string, n int) []int {
+	o.budgetMu.Lock()
+	dropped := make([]int, 0, n)
+	for i := len(o.launchQueue) - 1; i >= 0 && len(dropped) < n; i-- {
+		if o.launchQueue[i].group.Name == groupName {
+			dropped = append(dropped, o.launchQueue[i].instanceID)
+			o.launchQueue = append(o.launchQueue[:i], o.launchQueue[i+1:]...)
+		}
+	}
+	o.budgetMu.Unlock()
+
+	if len(dropped) > 0 {
+		fmt.Printf("[Budget] Group '%s': dropped %d queued launch(es)\n", groupName, len(dropped))
+	}
+
+	return dropped
+}
+
+// releaseGroupBudget drops a group's queued launches and frees all of its slots
+// so queued bots from other groups can start
+func (o *Orchestrator) releaseGroupBudget(groupName string) {
+	o.dropQueuedLaunches(groupName)
+
+	o.budgetMu.Lock()
+	delete(o.botSlots, groupName)
+	o.budgetMu.Unlock()
+
+	o.processLaunchQueue()
+}
+
+// processLaunchQueue starts queued launches while budget is available
+func (o *Orchestrator) processLaunchQueue() {
+	for {
+		o.budgetMu.Lock()
+		if len(o.launchQueue) == 0 || !o.hasBudgetLocked() {
+			o.budgetMu.Unlock()
+			return
+		}
+
+		pending := o.launchQueue[0]
+		o.launchQueue = o.launchQueue[1:]
+		o.claimSlotLocked(pending.group.Name, pending.instanceID)
+		o.budgetMu.Unlock()
+
+		fmt.Printf("[Budget] Group '%s': starting queued bot on instance %d (waited %v)\n",
+			pending.group.Name, pending.instanceID, time.Since(pending.queuedAt).Round(time.Second))
+
+		if err := o.startBot(pending.group, pending.instanceID, pending.policy); err != nil {
+			fmt.Printf("[Budget] Group '%s': failed to start queued bot on instance %d: %v\n",
+				pending.group.Name, pending.instanceID, err)
+		}
+	}
+}
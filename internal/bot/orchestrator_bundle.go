@@ -0,0 +1,305 @@
+package bot
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+)
+
+// groupBundleVersion is the current bundle format version
+const groupBundleVersion = 1
+
+// GroupBundle is a portable export of group definitions and everything they reference.
+// Pool definitions are embedded; routines are referenced by name only and must exist on the importing machine.
+type GroupBundle struct {
+	Version    int                                  `yaml:"version"`
+	ExportedAt time.Time                            `yaml:"exported_at"`
+	Groups     []*BotGroupDefinition                `yaml:"groups"`
+	Pools      []*accountpool.UnifiedPoolDefinition `yaml:"pools,omitempty"`
+	Routines   []string                             `yaml:"routines,omitempty"`
+}
+
+// ImportConflictMode defines how to handle a group or pool name that already exists
+type ImportConflictMode int
+
+const (
+	ImportConflictError     ImportConflictMode = iota // Abort the import
+	ImportConflictRename                              // Import under a new, unused name
+	ImportConflictOverwrite                           // Replace the existing definition
+)
+
+// ImportOptions configures how a group bundle is imported
+type ImportOptions struct {
+	// OnConflict controls what happens when a group or pool name already exists
+	OnConflict ImportConflictMode
+
+	// InstanceMap remaps emulator instance IDs from the exporting machine to this one.
+	// Instances without an entry keep their original ID.
+	InstanceMap map[int]int
+
+	// SkipPools imports only the groups; referenced pools must already exist locally
+	SkipPools bool
+}
+
+// ExportGroups writes the named group definitions, their account pool definitions,
+// and the routines they reference to w as a single YAML bundle
+func (o *Orchestrator) ExportGroups(names []string, w io.Writer) error {
+	if len(names) == 0 {
+		return fmt.Errorf("no groups specified for export")
+	}
+
+	bundle := &GroupBundle{
+		Version:    groupBundleVersion,
+		ExportedAt: time.Now(),
+		Groups:     make([]*BotGroupDefinition, 0, len(names)),
+	}
+
+	poolNames := make(map[string]bool)
+	routineNames := make(map[string]bool)
+
+	for _, name := range names {
+		def, err := o.LoadGroupDefinition(name)
+		if err != nil {
+			return err
+		}
+
+		bundle.Groups = append(bundle.Groups, def)
+		for _, routineName := range def.RoutineNames() {
+			routineNames[routineName] = true
+		}
+		for _, poolName := range definitionPoolNames(def) {
+			poolNames[poolName] = true
+		}
+	}
+
+	// Embed referenced pool definitions
+	if len(poolNames) > 0 {
+		if o.poolManager == nil {
+			return fmt.Errorf("pool manager not configured, cannot export referenced pools")
+		}
+
+		for _, poolName := range sortedKeys(poolNames) {
+			poolDef, err := o.poolManager.GetPoolDefinition(poolName)
+			if err != nil {
+				return fmt.Errorf("failed to export pool '%s': %w", poolName, err)
+			}
+			if poolDef.Config == nil {
+				return fmt.Errorf("pool '%s' has no configuration to export", poolName)
+			}
+			bundle.Pools = append(bundle.Pools, poolDef.Config)
+		}
+	}
+
+	bundle.Routines = sortedKeys(routineNames)
+
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+
+	if err := encoder.Encode(bundle); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Printf("Exported %d group(s), %d pool(s), %d routine reference(s)\n",
+		len(bundle.Groups), len(bundle.Pools), len(bundle.Routines))
+	return nil
+}
+
+// ImportGroups reads a bundle written by ExportGroups and saves its pools and groups.
+// All checks run before anything is written, so a failed import leaves existing definitions untouched.
+func (o *Orchestrator) ImportGroups(r io.Reader, opts ImportOptions) error {
+	var bundle GroupBundle
+	if err := yaml.NewDecoder(r).Decode(&bundle); err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	if bundle.Version > groupBundleVersion {
+		return fmt.Errorf("bundle version %d is newer than supported version %d", bundle.Version, groupBundleVersion)
+	}
+
+	if len(bundle.Groups) == 0 {
+		return fmt.Errorf("bundle contains no groups")
+	}
+
+	// Routines are not embedded, so every referenced routine must already exist here
+	if o.routineRegistry != nil {
+		missing := make([]string, 0)
+		for _, routineName := range bundle.Routines {
+			if !o.routineRegistry.Has(routineNameWithoutExt(routineName)) {
+				missing = append(missing, routineName)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("bundle references routines not found locally: %v", missing)
+		}
+	}
+
+	// Plan pool imports (original name -> local name)
+	poolRenames := make(map[string]string)
+	poolsToWrite := make([]*accountpool.UnifiedPoolDefinition, 0)
+	if !opts.SkipPools && len(bundle.Pools) > 0 {
+		if o.poolManager == nil {
+			return fmt.Errorf("pool manager not configured, cannot import pools")
+		}
+
+		for _, poolConfig := range bundle.Pools {
+			localName := poolConfig.PoolName
+			if _, err := o.poolManager.GetPoolDefinition(localName); err == nil {
+				switch opts.OnConflict {
+				case ImportConflictError:
+					return fmt.Errorf("pool '%s' already exists", localName)
+				case ImportConflictRename:
+					localName = o.uniquePoolName(localName)
+				}
+			}
+
+			poolCopy := *poolConfig
+			poolCopy.PoolName = localName
+			poolRenames[poolConfig.PoolName] = localName
+			poolsToWrite = append(poolsToWrite, &poolCopy)
+		}
+	}
+
+	// Plan group imports with remapped instances, renamed pools, and resolved name conflicts
+	groupsToWrite := make([]*BotGroupDefinition, 0, len(bundle.Groups))
+	plannedNames := make(map[string]bool)
+	for _, imported := range bundle.Groups {
+		def := imported.Clone()
+
+		for i, instanceID := range def.AvailableInstances {
+			if mapped, ok := opts.InstanceMap[instanceID]; ok {
+				def.AvailableInstances[i] = mapped
+			}
+		}
+
+		if renamed, ok := poolRenames[def.AccountPoolName]; ok {
+			def.AccountPoolName = renamed
+		}
+		for i, poolName := range def.AccountPoolNames {
+			if renamed, ok := poolRenames[poolName]; ok {
+				def.AccountPoolNames[i] = renamed
+			}
+		}
+
+		if o.groupNameTaken(def.Name) || plannedNames[def.Name] {
+			switch opts.OnConflict {
+			case ImportConflictError:
+				return fmt.Errorf("group '%s' already exists", def.Name)
+			case ImportConflictRename:
+				def.Name = o.uniqueGroupName(def.Name, plannedNames)
+			case ImportConflictOverwrite:
+				if group, exists := o.GetGroup(def.Name); exists && group.IsRunning() {
+					return fmt.Errorf("cannot overwrite group '%s' while it is running", def.Name)
+				}
+			}
+		}
+		plannedNames[def.Name] = true
+
+		// Structural validation now; bundled pools are only checked once written
+		if err := def.Validate(); err != nil {
+			return fmt.Errorf("imported group '%s' is invalid: %w", imported.Name, err)
+		}
+
+		// Without bundled pools, referenced pools must already exist locally
+		if opts.SkipPools && o.poolManager != nil {
+			for _, poolName := range definitionPoolNames(def) {
+				if _, err := o.poolManager.GetPoolDefinition(poolName); err != nil {
+					return fmt.Errorf("group '%s' references pool '%s' which does not exist locally", def.Name, poolName)
+				}
+			}
+		}
+
+		def.UpdatedAt = time.Now()
+		groupsToWrite = append(groupsToWrite, def)
+	}
+
+	// Write pools first so group pool references resolve
+	for _, poolConfig := range poolsToWrite {
+		poolDef := &accountpool.PoolDefinition{Name: poolConfig.PoolName, Config: poolConfig}
+		if _, err := o.poolManager.GetPoolDefinition(poolConfig.PoolName); err == nil {
+			if err := o.poolManager.UpdatePool(poolConfig.PoolName, poolDef); err != nil {
+				return fmt.Errorf("failed to overwrite pool '%s': %w", poolConfig.PoolName, err)
+			}
+		} else if err := o.poolManager.CreatePool(poolDef); err != nil {
+			return fmt.Errorf("failed to import pool '%s': %w", poolConfig.PoolName, err)
+		}
+		fmt.Printf("Imported pool definition '%s'\n", poolConfig.PoolName)
+	}
+
+	// Save groups (replacing any stale runtime group on overwrite)
+	for _, def := range groupsToWrite {
+		if group, exists := o.GetGroup(def.Name); exists && !group.IsRunning() {
+			if err := o.DeleteGroup(def.Name); err != nil {
+				fmt.Printf("Warning: failed to remove runtime group '%s' before import: %v\n", def.Name, err)
+			}
+		}
+
+		if err := o.SaveGroupDefinition(def); err != nil {
+			return fmt.Errorf("failed to import group '%s': %w", def.Name, err)
+		}
+	}
+
+	fmt.Printf("Imported %d group(s) and %d pool(s)\n", len(groupsToWrite), len(poolsToWrite))
+	return nil
+}
+
+// groupNameTaken reports whether a group definition with this name exists
+func (o *Orchestrator) groupNameTaken(name string) bool {
+	o.groupsMu.RLock()
+	defer o.groupsMu.RUnlock()
+	_, exists := o.groupDefinitions[name]
+	return exists
+}
+
+// uniqueGroupName returns name with an "(imported N)" suffix that is not yet in use
+func (o *Orchestrator) uniqueGroupName(name string, planned map[string]bool) string {
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (imported)", name)
+		if i > 1 {
+			candidate = fmt.Sprintf("%s (imported %d)", name, i)
+		}
+		if !o.groupNameTaken(candidate) && !planned[candidate] {
+			return candidate
+		}
+	}
+}
+
+// uniquePoolName returns name with an "(imported N)" suffix that is not yet in use
+func (o *Orchestrator) uniquePoolName(name string) string {
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (imported)", name)
+		if i > 1 {
+			candidate = fmt.Sprintf("%s (imported %d)", name, i)
+		}
+		if _, err := o.poolManager.GetPoolDefinition(candidate); err != nil {
+			return candidate
+		}
+	}
+}
+
+// definitionPoolNames returns the pools a definition references (legacy single field included)
+func definitionPoolNames(def *BotGroupDefinition) []string {
+	names := make([]string, 0, len(def.AccountPoolNames)+1)
+	seen := make(map[string]bool)
+	for _, name := range append([]string{def.AccountPoolName}, def.AccountPoolNames...) {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/actions"
+)
+
+// PauseGroup pauses every running bot in a group and returns how many were paused
+func (o *Orchestrator) PauseGroup(groupName string) (int, error) {
+	group, exists := o.GetGroup(groupName)
+	if !exists {
+		return 0, fmt.Errorf("group '%s' not found", groupName)
+	}
+	if !group.IsRunning() {
+		return 0, fmt.Errorf("group '%s' is not running", groupName)
+	}
+
+	paused := 0
+	for _, b := range group.runningBots() {
+		if b.IsPaused() {
+			continue
+		}
+		b.Pause()
+		paused++
+	}
+	return paused, nil
+}
+
+// ResumeGroup resumes every paused bot in a group and returns how many were resumed
+func (o *Orchestrator) ResumeGroup(groupName string) (int, error) {
+	group, exists := o.GetGroup(groupName)
+	if !exists {
+		return 0, fmt.Errorf("group '%s' not found", groupName)
+	}
+	if !group.IsRunning() {
+		return 0, fmt.Errorf("group '%s' is not running", groupName)
+	}
+
+	resumed := 0
+	for _, b := range group.runningBots() {
+		if !b.IsPaused() {
+			continue
+		}
+		b.Resume()
+		resumed++
+	}
+	return resumed, nil
+}
+
+// ResolveStepError resumes a bot paused on a step error (pause-on-error mode) with the
+// developer's decision: run the step again, skip it, or abort the routine
+func (o *Orchestrator) ResolveStepError(groupName string, instanceID int, decision actions.StepErrorDecision) error {
+	group, exists := o.GetGroup(groupName)
+	if !exists {
+		return fmt.Errorf("group '%s' not found", groupName)
+	}
+
+	botInfo, exists := group.GetBotInfo(instanceID)
+	if !exists || botInfo.Bot == nil {
+		return fmt.Errorf("no bot running on instance %d in group '%s'", instanceID, groupName)
+	}
+	return botInfo.Bot.ResolveStepError(decision)
+}
+
+// EnsureRuntimeGroup returns the runtime group for a saved definition, creating it if needed
+func (o *Orchestrator) EnsureRuntimeGroup(groupName string) (*BotGroup, error) {
+	if group, exists := o.GetGroup(groupName); exists {
+		return group, nil
+	}
+
+	def, err := o.LoadGroupDefinition(groupName)
+	if err != nil {
+		return nil, err
+	}
+	return o.CreateGroupFromDefinition(def)
+}
+
+// MarshalJSON encodes a BotInfo for the control API. The bot itself is summarized
+// (paused state and last screen) and the error is encoded as a string.
+func (bi BotInfo) MarshalJSON() ([]byte, error) {
+	info := struct {
+		InstanceID int
+		StartedAt  time.Time
+		Status     BotStatus
+		Error      string `json:",omitempty"`
+		Paused     bool
+		StepError  string `json:",omitempty"` // Step error a pause-on-error bot is paused on
+		LastScreen string `json:",omitempty"`
+	}{
+		InstanceID: bi.InstanceID,
+		StartedAt:  bi.StartedAt,
+		Status:     bi.Status,
+	}
+
+	if bi.Error != nil {
+		info.Error = bi.Error.Error()
+	}
+	if bi.Bot != nil {
+		info.Paused = bi.Bot.IsPaused()
+		if pending := bi.Bot.PendingStepError(); pending != nil {
+			info.StepError = fmt.Sprintf("step '%s': %v", pending.Step, pending.Err)
+		}
+		info.LastScreen, _ = bi.Bot.LastScreen()
+	}
+
+	return json.Marshal(info)
+}
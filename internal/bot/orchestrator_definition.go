@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -21,6 +22,9 @@ type BotGroupDefinition struct {
 	RoutineName   string            `yaml:"routine_name" json:"routine_name"`
 	RoutineConfig map[string]string `yaml:"routine_config,omitempty" json:"routine_config,omitempty"` // Variable overrides
 
+	// Per-instance routine overrides (key = instance ID); instances not listed run RoutineName
+	InstanceRoutines map[int]string `yaml:"instance_routines,omitempty" json:"instance_routines,omitempty"`
+
 	// Emulator configuration
 	AvailableInstances []int `yaml:"available_instances" json:"available_instances"`
 	RequestedBotCount  int   `yaml:"requested_bot_count" json:"requested_bot_count"`
@@ -55,42 +59,66 @@ func (d *BotGroupDefinition) Clone() *BotGroupDefinition {
 		clone.RoutineConfig[k] = v
 	}
 
+	clone.InstanceRoutines = copyInstanceRoutines(d.InstanceRoutines)
+
 	return &clone
 }
 
-// Validate checks if the definition is valid and returns an error if not
-func (d *BotGroupDefinition) Validate() error {
-	if d.Name == "" {
-		return fmt.Errorf("name is required")
-	}
+// RoutineForInstance returns the routine an instance runs: its override, or the group routine
+func (d *BotGroupDefinition) RoutineForInstance(instanceID int) string {
+	return routineForInstance(d.RoutineName, d.InstanceRoutines, instanceID)
+}
 
-	if d.RoutineName == "" {
-		return fmt.Errorf("routine name is required")
-	}
+// RoutineNames returns every routine the group runs (the group routine and per-instance
+// overrides), without duplicates
+func (d *BotGroupDefinition) RoutineNames() []string {
+	return distinctRoutines(d.RoutineName, d.InstanceRoutines)
+}
 
-	if len(d.AvailableInstances) == 0 {
-		return fmt.Errorf("at least one emulator instance is required")
+// routineForInstance returns an instance's override routine, falling back to the group routine
+func routineForInstance(groupRoutine string, overrides map[int]string, instanceID int) string {
+	if routine := overrides[instanceID]; routine != "" {
+		return routine
 	}
+	return groupRoutine
+}
 
-	if d.RequestedBotCount <= 0 {
-		return fmt.Errorf("requested bot count must be positive")
+// distinctRoutines lists the group routine followed by the other override routines, sorted
+func distinctRoutines(groupRoutine string, overrides map[int]string) []string {
+	seen := map[string]bool{groupRoutine: true}
+	others := make([]string, 0)
+	for _, routine := range overrides {
+		if routine != "" && !seen[routine] {
+			seen[routine] = true
+			others = append(others, routine)
+		}
 	}
+	sort.Strings(others)
 
-	if d.RequestedBotCount > len(d.AvailableInstances) {
-		return fmt.Errorf("requested bot count (%d) exceeds available instances (%d)",
-			d.RequestedBotCount, len(d.AvailableInstances))
+	names := make([]string, 0, len(others)+1)
+	if groupRoutine != "" {
+		names = append(names, groupRoutine)
 	}
+	return append(names, others...)
+}
 
-	// Validate that instance IDs are unique
-	instanceSet := make(map[int]bool)
-	for _, id := range d.AvailableInstances {
-		if instanceSet[id] {
-			return fmt.Errorf("duplicate instance ID: %d", id)
-		}
-		instanceSet[id] = true
+// copyInstanceRoutines copies a per-instance routine map (nil stays nil)
+func copyInstanceRoutines(overrides map[int]string) map[int]string {
+	if overrides == nil {
+		return nil
+	}
+	copied := make(map[int]string, len(overrides))
+	for instanceID, routine := range overrides {
+		copied[instanceID] = routine
 	}
+	return copied
+}
 
-	return nil
+// Validate checks if the definition is valid.
+// Every problem is reported at once via a *ValidationFailure error.
+// Registry-backed checks (routine and pool existence) are done by Orchestrator.ValidateDefinition.
+func (d *BotGroupDefinition) Validate() error {
+	return ValidateGroupDefinition(d).Err()
 }
 
 // Update updates the definition with new values and sets UpdatedAt timestamp
@@ -118,6 +146,9 @@ func (d *BotGroupDefinition) Update(updates *BotGroupDefinition) error {
 	if len(updates.RoutineConfig) > 0 {
 		d.RoutineConfig = updates.RoutineConfig
 	}
+	if updates.InstanceRoutines != nil {
+		d.InstanceRoutines = updates.InstanceRoutines
+	}
 	if len(updates.Tags) > 0 {
 		d.Tags = updates.Tags
 	}
@@ -0,0 +1,42 @@
+package bot
+
+import "testing"
+
+func TestInstanceRoutines(t *testing.T) {
+	def := NewBotGroupDefinition("mixed", "farm.yaml", []int{1, 2, 3, 4}, 4)
+	def.InstanceRoutines = map[int]string{4: "wonder_pick.yaml"}
+
+	if err := def.Validate(); err != nil {
+		t.Fatalf("expected valid definition, got %v", err)
+	}
+	if got := def.RoutineForInstance(1); got != "farm.yaml" {
+		t.Errorf("instance 1: expected group routine, got %q", got)
+	}
+	if got := def.RoutineForInstance(4); got != "wonder_pick.yaml" {
+		t.Errorf("instance 4: expected override, got %q", got)
+	}
+	if got := def.RoutineNames(); len(got) != 2 || got[0] != "farm.yaml" || got[1] != "wonder_pick.yaml" {
+		t.Errorf("expected [farm.yaml wonder_pick.yaml], got %v", got)
+	}
+
+	clone := def.Clone()
+	clone.InstanceRoutines[4] = "other.yaml"
+	if def.InstanceRoutines[4] != "wonder_pick.yaml" {
+		t.Error("expected Clone to copy InstanceRoutines")
+	}
+
+	def.InstanceRoutines = map[int]string{5: "wonder_pick.yaml", 2: "", 3: "notes.txt"}
+	result := ValidateGroupDefinition(def)
+	if result.Valid {
+		t.Fatal("expected invalid overrides to fail validation")
+	}
+	contexts := make(map[string]int)
+	for _, validationErr := range result.Errors {
+		contexts[validationErr.Context]++
+	}
+	for _, context := range []string{"InstanceRoutines[2]", "InstanceRoutines[3]", "InstanceRoutines[5]"} {
+		if contexts[context] != 1 {
+			t.Errorf("expected one error for %s, got %d (%v)", context, contexts[context], result.Errors)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// emergencyStopWait is how long EmergencyStop waits for cancelled routines to exit before
+// it gives up on returning their accounts
+const emergencyStopWait = 30 * time.Second
+
+// EmergencyStopReport describes what EmergencyStop stopped and released
+type EmergencyStopReport struct {
+	GroupsStopped    []string // Groups that were running or launching
+	BotsStopped      int
+	AccountsReturned int   // Accounts bots held mid-routine, returned to their pools
+	PoolsClosed      int   // Open pool instances closed
+	EmulatorsStopped []int // Instances shut down (only when killEmulators is set)
+	Errors           []string
+	Duration         time.Duration
+}
+
+// EmergencyStop stops everything at once: every group's bots (including groups still
+// launching), then returns the accounts the bots held, closes all open account pools and,
+// if killEmulators is set, shuts down every running emulator instance.
+//
+// It never holds the groups lock while stopping, so it is safe to call at any time. Groups
+// can be relaunched afterwards; they resolve their pools again. The pool refresh scheduler
+// and stats sampler are stopped along with the pools.
+func (o *Orchestrator) EmergencyStop(killEmulators bool) (*EmergencyStopReport, error) {
+	start := time.Now()
+	report := &EmergencyStopReport{
+		GroupsStopped:    make([]string, 0),
+		EmulatorsStopped: make([]int, 0),
+		Errors:           make([]string, 0),
+	}
+	fmt.Printf("[EmergencyStop] Stopping all groups (kill emulators: %v)\n", killEmulators)
+
+	groups := o.ListActiveGroups()
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	// 1. Stop bots: abort launches in progress and cancel every routine first, so all
+	// bots wind down together instead of one group after another
+	stopping := make([]*BotGroup, 0, len(groups))
+	routines := make(map[*BotGroup][]*BotInfo, len(groups))
+	for _, group := range groups {
+		group.runningMu.Lock()
+		group.launchAborted = true
+		busy := group.running
+		group.runningMu.Unlock()
+
+		group.cancelRunTimeLimit()
+		o.dropQueuedLaunches(group.Name)
+
+		group.activeBotsMu.Lock()
+		for _, botInfo := range group.ActiveBots {
+			botInfo.Status = BotStatusStopping
+			botInfo.routineCancel()
+			routines[group] = append(routines[group], botInfo)
+		}
+		busy = busy || len(group.ActiveBots) > 0
+		report.BotsStopped += len(group.ActiveBots)
+		group.activeBotsMu.Unlock()
+
+		if busy || group.GetBotCount() > 0 {
+			stopping = append(stopping, group)
+		}
+	}
+
+	// 2. Shut each group down, then return the accounts its bots were working on once their
+	// routines have exited, so no routine still uses the account
+	for _, group := range stopping {
+		o.haltGroup(group)
+		report.GroupsStopped = append(report.GroupsStopped, group.Name)
+		if o.eventBus != nil {
+			o.eventBus.PublishAsync(events.NewGroupStoppedEvent(group.Name))
+		}
+	}
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), emergencyStopWait)
+	defer cancelWait()
+	for _, group := range stopping {
+		for _, botInfo := range routines[group] {
+			select {
+			case <-botInfo.routineDone:
+			case <-waitCtx.Done():
+				report.Errors = append(report.Errors, fmt.Sprintf("bot %d of group '%s' did not stop within %v, its account was not returned",
+					botInfo.InstanceID, group.Name, emergencyStopWait))
+				continue
+			}
+			if botInfo.Bot.currentAccount == nil {
+				continue
+			}
+			group.returnInFlightAccount(botInfo.Bot)
+			report.AccountsReturned++
+		}
+	}
+
+	// 3. Close pools; groups resolve their closed instances again on the next launch
+	if o.poolManager != nil {
+		report.PoolsClosed = len(o.poolManager.OpenPoolStats())
+		if err := o.poolManager.CloseAll(); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to close pools: %v", err))
+		}
+		for _, group := range groups {
+			group.runningMu.Lock()
+			group.poolClosed = true
+			group.runningMu.Unlock()
+		}
+	}
+
+	// 4. Shut down the emulators last, once nothing uses them
+	if killEmulators {
+		if o.emulatorManager == nil {
+			report.Errors = append(report.Errors, "cannot stop emulators: emulator manager not configured")
+		} else {
+			stopped, err := o.emulatorManager.StopAllInstances()
+			report.EmulatorsStopped = append(report.EmulatorsStopped, stopped...)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to stop emulators: %v", err))
+			}
+		}
+	}
+
+	report.Duration = time.Since(start)
+	fmt.Printf("[EmergencyStop] Stopped %d group(s) and %d bot(s), returned %d account(s), closed %d pool(s), stopped %d emulator(s) in %v\n",
+		len(report.GroupsStopped), report.BotsStopped, report.AccountsReturned, report.PoolsClosed,
+		len(report.EmulatorsStopped), report.Duration.Round(time.Millisecond))
+
+	if len(report.Errors) > 0 {
+		errs := make([]error, 0, len(report.Errors))
+		for _, msg := range report.Errors {
+			fmt.Printf("[EmergencyStop] Warning: %s\n", msg)
+			errs = append(errs, errors.New(msg))
+		}
+		return report, errors.Join(errs...)
+	}
+	return report, nil
+}
+
+// takePoolClosed reports whether EmergencyStop closed the group's pool since the last
+// launch, clearing the flag
+func (g *BotGroup) takePoolClosed() bool {
+	g.runningMu.Lock()
+	defer g.runningMu.Unlock()
+	closed := g.poolClosed
+	g.poolClosed = false
+	return closed
+}
+
+// launchWasAborted reports whether an emergency stop came in during the group's launch
+func (g *BotGroup) launchWasAborted() bool {
+	g.runningMu.RLock()
+	defer g.runningMu.RUnlock()
+	return g.launchAborted
+}
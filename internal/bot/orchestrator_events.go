@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"database/sql"
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// GodPackInfo describes a god pack reported by a bot
+type GodPackInfo struct {
+	GroupName string
+	Bot       *Bot
+	Account   *accountpool.Account
+	Pool      accountpool.AccountPool // The pool the account was taken from (may be nil)
+	PackName  string
+}
+
+// GodPackHook is called when a bot reports a god pack. It runs on the bot's routine
+// goroutine, which waits for it: work that needs the account still loaded on the device
+// (e.g. extracting app data) belongs here, anything else should be started asynchronously.
+type GodPackHook func(info GodPackInfo)
+
+// SetGodPackHook sets the hook called for every reported god pack (nil disables it)
+func (o *Orchestrator) SetGodPackHook(hook GodPackHook) {
+	o.godPackHookMu.Lock()
+	defer o.godPackHookMu.Unlock()
+	o.godPackHook = hook
+}
+
+// AccountLoadedInfo describes an injected account a bot's game was verified to have loaded
+type AccountLoadedInfo struct {
+	GroupName string
+	Bot       *Bot
+	Account   *accountpool.Account
+	Pool      accountpool.AccountPool // The pool the account was taken from
+}
+
+// AccountLoadedHook is called once a bot's game has loaded an injected account (see
+// Bot.VerifyInjectedAccount). It runs on the bot's routine goroutine before the routine continues.
+type AccountLoadedHook func(info AccountLoadedInfo)
+
+// SetAccountLoadedHook sets the hook called for every loaded account (nil disables it)
+func (o *Orchestrator) SetAccountLoadedHook(hook AccountLoadedHook) {
+	o.accountLoadedHookMu.Lock()
+	defer o.accountLoadedHookMu.Unlock()
+	o.accountLoadedHook = hook
+}
+
+// AccountVerifier checks that the game on a bot's instance is running the injected account
+// (see Bot.VerifyInjectedAccount). It returns false on a mismatch, and an error only if the
+// device could not be read.
+type AccountVerifier func(bot *Bot, account *accountpool.Account) (bool, error)
+
+// SetAccountVerifier sets the check run after every account injection (nil skips verification)
+func (o *Orchestrator) SetAccountVerifier(verifier AccountVerifier) {
+	o.accountVerifierMu.Lock()
+	defer o.accountVerifierMu.Unlock()
+	o.accountVerifier = verifier
+}
+
+// accountVerifier returns the orchestrator's account verifier (nil if none is set)
+func (a *BotGroupManagerAdapter) accountVerifier() AccountVerifier {
+	o := a.group.orchestrator
+	o.accountVerifierMu.RLock()
+	defer o.accountVerifierMu.RUnlock()
+	return o.accountVerifier
+}
+
+// accountLoaded runs the account loaded hook for an account the bot's game loaded
+func (a *BotGroupManagerAdapter) accountLoaded(bot *Bot, account *accountpool.Account) {
+	o := a.group.orchestrator
+	o.accountLoadedHookMu.RLock()
+	hook := o.accountLoadedHook
+	o.accountLoadedHookMu.RUnlock()
+
+	if hook != nil {
+		hook(AccountLoadedInfo{
+			GroupName: a.group.Name,
+			Bot:       bot,
+			Account:   account,
+			Pool:      a.AccountPool(),
+		})
+	}
+}
+
+// handleBannedAccount runs the bot's ban handling and publishes an account banned event
+// when the bot's account was taken out of rotation
+func (g *BotGroup) handleBannedAccount(bot *Bot, db *sql.DB, executionID int64) error {
+	account := bot.currentAccount
+
+	err := bot.HandleBannedAccount(db, executionID)
+	if err != nil && account != nil && g.orchestrator.eventBus != nil {
+		g.orchestrator.eventBus.PublishAsync(events.NewAccountBannedEvent(g.Name, bot.Instance(), account.ID, account.DeviceAccount))
+	}
+	return err
+}
+
+// ReportGodPack runs the god pack hook and publishes a god pack found event for an account
+// (called by the reportgodpack action)
+func (a *BotGroupManagerAdapter) ReportGodPack(instanceID int, account *accountpool.Account, packName string) {
+	group := a.group
+	fmt.Printf("[BotGroup '%s'] God pack found on instance %d (account '%s')\n", group.Name, instanceID, account.ID)
+	group.orchestrator.session.godPackFound(group)
+
+	group.orchestrator.godPackHookMu.RLock()
+	hook := group.orchestrator.godPackHook
+	group.orchestrator.godPackHookMu.RUnlock()
+
+	if hook != nil {
+		bot, _ := group.GetBot(instanceID)
+		hook(GodPackInfo{
+			GroupName: group.Name,
+			Bot:       bot,
+			Account:   account,
+			Pool:      a.AccountPool(),
+			PackName:  packName,
+		})
+	}
+
+	if bus := group.orchestrator.eventBus; bus != nil {
+		bus.PublishAsync(events.NewGodPackFoundEvent(group.Name, instanceID, account.ID, account.DeviceAccount, packName))
+	}
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// poolExhaustedCheckInterval is how often an idle bot checks its pool for accounts
+const poolExhaustedCheckInterval = 10 * time.Second
+
+// poolMayRefill reports whether accounts can still become available in the group's pool
+// without user action: the pool re-resolves on a schedule, or other bots hold accounts
+// that may be returned.
+func (g *BotGroup) poolMayRefill() bool {
+	if refresher, ok := g.AccountPool.(interface{ RefreshInterval() time.Duration }); ok && refresher.RefreshInterval() > 0 {
+		return true
+	}
+	return g.AccountPool.GetStats().InUse > 0
+}
+
+// poolExhaustedWait returns how long a bot idles for accounts before stopping
+func (g *BotGroup) poolExhaustedWait() time.Duration {
+	if g.orchestrator.config == nil {
+		return 5 * time.Minute
+	}
+	return time.Duration(g.orchestrator.config.PoolExhaustedWaitMinutes) * time.Minute
+}
+
+// setBotStatus updates the status of an active bot
+func (g *BotGroup) setBotStatus(instanceID int, status BotStatus) {
+	g.activeBotsMu.Lock()
+	defer g.activeBotsMu.Unlock()
+	if info, exists := g.ActiveBots[instanceID]; exists {
+		info.Status = status
+	}
+}
+
+// waitForAccounts handles a bot finding its group's pool exhausted. The bot is marked as
+// waiting for accounts and a pool exhausted event is published. If the pool may refill, the
+// bot waits up to PoolExhaustedWaitMinutes for an account to become available. Returns true
+// if the bot should continue, false if it should stop cleanly (not as a failure).
+func (g *BotGroup) waitForAccounts(bot *Bot, instanceID int) bool {
+	maxWait := g.poolExhaustedWait()
+	if !g.poolMayRefill() {
+		maxWait = 0
+	}
+
+	g.setBotStatus(instanceID, BotStatusWaitingForAccounts)
+	if g.orchestrator.eventBus != nil {
+		g.orchestrator.eventBus.PublishAsync(events.NewPoolExhaustedEvent(g.Name, instanceID, g.AccountPoolName, maxWait))
+	}
+
+	if maxWait <= 0 {
+		bot.Logf("No accounts available in pool and it is not refilling. Stopping bot.\n")
+		return false
+	}
+	bot.Logf("No accounts available in pool. Waiting up to %v for accounts...\n", maxWait)
+
+	deadline := time.Now().Add(maxWait)
+	for time.Now().Before(deadline) {
+		select {
+		case <-bot.Context().Done():
+			bot.Logf("Stopped while waiting for accounts\n")
+			return false
+		case <-time.After(poolExhaustedCheckInterval):
+		}
+
+		if bot.IsStopped() || g.stopRequested(instanceID) {
+			bot.Logf("Stopped while waiting for accounts\n")
+			return false
+		}
+
+		if stats := g.AccountPool.GetStats(); stats.Available > 0 {
+			bot.Logf("Accounts now available (%d accounts). Continuing...\n", stats.Available)
+			g.setBotStatus(instanceID, BotStatusRunning)
+			return true
+		}
+	}
+
+	bot.Logf("No accounts became available after %v. Stopping bot.\n", maxWait)
+	return false
+}
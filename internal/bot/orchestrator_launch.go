@@ -2,19 +2,25 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
 	"jordanella.com/pocket-tcg-go/internal/database"
+	"jordanella.com/pocket-tcg-go/internal/emulator"
 	"jordanella.com/pocket-tcg-go/internal/events"
+	"jordanella.com/pocket-tcg-go/internal/logging"
 )
 
 // LaunchResult contains the results of a group launch
 type LaunchResult struct {
 	Success        bool
 	LaunchedBots   int
+	QueuedBots     int // Bots waiting for the global bot budget
 	RequestedBots  int
+	ReservedAccounts int // Accounts reserved up front from the group's pool
 	Errors         []string
 	Conflicts      []InstanceConflict
 	SkippedInstances []int
@@ -134,6 +140,20 @@ func (o *Orchestrator) launchGroupInternal(group *BotGroup, options LaunchOption
 		return nil, fmt.Errorf("launch options validation failed:\n%s", validationResult.FormatValidationErrors())
 	}
 
+	group.runningMu.Lock()
+	group.launchAborted = false
+	group.runningMu.Unlock()
+
+	group.launchGame = options.LaunchGame
+	group.startupRoutine = options.StartupRoutine
+	group.launchOptions = options
+	if options.StaggerDelay > 0 {
+		group.SetStaggerDelay(options.StaggerDelay)
+	} else {
+		group.SetStaggerDelay(o.staggerDelay)
+	}
+	group.openLogs(options)
+
 	result := &LaunchResult{
 		Success:       true,
 		RequestedBots: group.RequestedBotCount,
@@ -142,25 +162,64 @@ func (o *Orchestrator) launchGroupInternal(group *BotGroup, options LaunchOption
 		SkippedInstances: make([]int, 0),
 	}
 
-	// Phase 0: Resolve and setup account pool if needed
-	if group.AccountPoolName != "" && group.AccountPool == nil {
-		pool, err := o.resolveAccountPool(group.AccountPoolName)
-		if err != nil {
-			result.Success = false
-			result.Errors = append(result.Errors, fmt.Sprintf("failed to resolve account pool: %v", err))
-			return result, fmt.Errorf("failed to resolve account pool '%s': %w", group.AccountPoolName, err)
+	// Phase 0: Resolve and setup account pool if needed (groups without one use the default pool)
+	if group.takePoolClosed() || group.AccountPool == nil {
+		poolName := group.AccountPoolName
+		if poolName == "" {
+			poolName = o.GetDefaultPool()
+			if poolName != "" {
+				fmt.Printf("Bot Group '%s': No account pool set, using default pool '%s'\n", group.Name, poolName)
+			}
+		}
+
+		if poolName != "" {
+			pool, err := o.resolveAccountPool(poolName)
+			if err != nil {
+				result.Success = false
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to resolve account pool: %v", err))
+				return result, fmt.Errorf("failed to resolve account pool '%s': %w", poolName, err)
+			}
+			group.AccountPool = pool
 		}
-		group.AccountPool = pool
 	}
 
 	// Phase 1: Routine Validation
 	if options.ValidateRoutine {
-		validationResult := o.ValidateRoutine(group.RoutineName, group.RoutineConfig)
-		if !validationResult.Valid {
+		for _, routineName := range group.RoutineNames() {
+			validationResult := o.ValidateRoutine(routineName, group.RoutineConfig)
+			if !validationResult.Valid {
+				result.Success = false
+				result.Errors = append(result.Errors, validationResult.FormatValidationErrors())
+				return result, fmt.Errorf("routine validation failed")
+			}
+		}
+
+		if options.StartupRoutine != "" {
+			validationResult := o.ValidateRoutine(options.StartupRoutine, nil)
+			if !validationResult.Valid {
+				result.Success = false
+				result.Errors = append(result.Errors, validationResult.FormatValidationErrors())
+				return result, fmt.Errorf("startup routine validation failed")
+			}
+		}
+	}
+
+	// Phase 1b: Reserve accounts up front so concurrent launches can't race for them
+	if group.AccountPool != nil {
+		reserved, err := group.AccountPool.Reserve(group.RequestedBotCount)
+		if err != nil {
 			result.Success = false
-			result.Errors = append(result.Errors, validationResult.FormatValidationErrors())
-			return result, fmt.Errorf("routine validation failed")
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to reserve accounts: %v", err))
+			return result, fmt.Errorf("failed to reserve accounts: %w", err)
+		}
+
+		result.ReservedAccounts = len(reserved)
+		if len(reserved) < group.RequestedBotCount {
+			// Warn but continue - bots will wait on the pool for the rest
+			result.Errors = append(result.Errors,
+				fmt.Sprintf("only reserved %d of %d requested accounts", len(reserved), group.RequestedBotCount))
 		}
+		group.setReservation(accountpool.NewReservedPool(group.AccountPool, reserved))
 	}
 
 	// Phase 2: Acquire Emulator Instances
@@ -171,6 +230,7 @@ func (o *Orchestrator) launchGroupInternal(group *BotGroup, options LaunchOption
 	if len(acquiredInstances) == 0 {
 		result.Success = false
 		result.Errors = append(result.Errors, "no emulator instances available")
+		group.releaseReservation()
 		return result, fmt.Errorf("failed to acquire any emulator instances")
 	}
 
@@ -181,22 +241,35 @@ func (o *Orchestrator) launchGroupInternal(group *BotGroup, options LaunchOption
 				len(acquiredInstances), group.RequestedBotCount))
 	}
 
-	// Phase 3: Launch Bots with Stagger
-	launchedCount, launchErrors := o.launchBotsStaggered(group, acquiredInstances, options)
+	// Phase 3: Launch Bots with Stagger (queuing any that exceed the global budget)
+	launchedCount, queuedCount, launchErrors := o.launchBotsStaggered(group, acquiredInstances, options)
 	result.LaunchedBots = launchedCount
+	result.QueuedBots = queuedCount
 	result.Errors = append(result.Errors, launchErrors...)
 
-	if launchedCount == 0 {
+	// An emergency stop during the launch already stopped the bots; release what's left
+	if group.launchWasAborted() {
+		return o.abortLaunch(group, result)
+	}
+
+	if launchedCount == 0 && queuedCount == 0 {
 		result.Success = false
-		// Release all acquired instances since no bots launched
+		// Release all acquired instances and accounts since no bots launched
 		o.releaseAllInstances(group.Name)
+		group.releaseReservation()
 		return result, fmt.Errorf("failed to launch any bots")
 	}
 
-	// Mark group as running
+	// Mark group as running, unless an emergency stop came in since the check above
 	group.runningMu.Lock()
-	group.running = true
+	aborted := group.launchAborted
+	group.running = !aborted
 	group.runningMu.Unlock()
+	if aborted {
+		return o.abortLaunch(group, result)
+	}
+	o.session.groupLaunched(group, time.Now())
+	o.startRunTimeLimit(group, options.MaxRunDuration)
 
 	// Publish group launched event
 	if o.eventBus != nil {
@@ -211,6 +284,14 @@ func (o *Orchestrator) launchGroupInternal(group *BotGroup, options LaunchOption
 	return result, nil
 }
 
+// abortLaunch ends a launch an emergency stop came in during, releasing what it acquired
+func (o *Orchestrator) abortLaunch(group *BotGroup, result *LaunchResult) (*LaunchResult, error) {
+	o.haltGroup(group)
+	result.Success = false
+	result.Errors = append(result.Errors, "launch aborted by emergency stop")
+	return result, fmt.Errorf("launch of group '%s' aborted by emergency stop", group.Name)
+}
+
 // InstanceAcquisitionResult contains results of instance acquisition
 type InstanceAcquisitionResult struct {
 	AcquiredInstances []int
@@ -221,15 +302,20 @@ type InstanceAcquisitionResult struct {
 
 // acquireInstances attempts to acquire emulator instances for a group
 func (o *Orchestrator) acquireInstances(group *BotGroup, options LaunchOptions) ([]int, *InstanceAcquisitionResult) {
+	return o.acquireInstancesFrom(group, group.AvailableInstances, group.RequestedBotCount, options)
+}
+
+// acquireInstancesFrom acquires up to count instances for a group from candidates, in order
+func (o *Orchestrator) acquireInstancesFrom(group *BotGroup, candidates []int, count int, options LaunchOptions) ([]int, *InstanceAcquisitionResult) {
 	result := &InstanceAcquisitionResult{
-		AcquiredInstances: make([]int, 0, group.RequestedBotCount),
+		AcquiredInstances: make([]int, 0, count),
 		Conflicts:         make([]InstanceConflict, 0),
 		SkippedInstances:  make([]int, 0),
 		LaunchErrors:      make([]string, 0),
 	}
 
 	fmt.Printf("[AcquireInstances] Group '%s': Requested=%d, Available instances=%v\n",
-		group.Name, group.RequestedBotCount, group.AvailableInstances)
+		group.Name, count, candidates)
 
 	// Discover running instances before checking availability
 	if err := o.emulatorManager.DiscoverInstances(); err != nil {
@@ -243,23 +329,23 @@ func (o *Orchestrator) acquireInstances(group *BotGroup, options LaunchOptions)
 		instanceID int
 		isRunning  bool
 	}
-	instancesPlanned := make([]instancePlan, 0, group.RequestedBotCount)
+	instancesPlanned := make([]instancePlan, 0, count)
 
 	// Refresh instance discovery before planning to get current state
 	if err := o.emulatorManager.DiscoverInstances(); err != nil {
 		fmt.Printf("[AcquireInstances] Warning: Failed to refresh instance discovery: %v\n", err)
 	}
 
-	for _, instanceID := range group.AvailableInstances {
+	for _, instanceID := range candidates {
 		// Stop if we have enough planned
-		if len(instancesPlanned) >= group.RequestedBotCount {
+		if len(instancesPlanned) >= count {
 			fmt.Printf("[AcquireInstances] Planned enough instances (%d/%d)\n",
-				len(instancesPlanned), group.RequestedBotCount)
+				len(instancesPlanned), count)
 			break
 		}
 
 		fmt.Printf("[AcquireInstances] Evaluating instance %d (planned=%d, needed=%d)\n",
-			instanceID, len(instancesPlanned), group.RequestedBotCount)
+			instanceID, len(instancesPlanned), count)
 
 		// Check availability
 		available, conflictingGroup, err := o.checkInstanceAvailability(instanceID, group.Name)
@@ -305,6 +391,17 @@ func (o *Orchestrator) acquireInstances(group *BotGroup, options LaunchOptions)
 			}
 		}
 
+		// Make sure the instance's computed ADB port really reaches this instance
+		if err := o.emulatorManager.VerifyInstancePort(instanceID); err != nil {
+			if !errors.Is(err, emulator.ErrADBPortUnknown) {
+				result.LaunchErrors = append(result.LaunchErrors,
+					fmt.Sprintf("skipping instance %d: %v", instanceID, err))
+				result.SkippedInstances = append(result.SkippedInstances, instanceID)
+				continue
+			}
+			fmt.Printf("[AcquireInstances] Warning: could not verify ADB port of instance %d: %v\n", instanceID, err)
+		}
+
 		// Check if emulator is running
 		running, err := o.isEmulatorRunning(instanceID)
 		if err != nil {
@@ -364,71 +461,102 @@ func (o *Orchestrator) acquireInstances(group *BotGroup, options LaunchOptions)
 		// Successfully acquired
 		result.AcquiredInstances = append(result.AcquiredInstances, instanceID)
 		fmt.Printf("[AcquireInstances] Successfully acquired instance %d (total: %d/%d)\n",
-			instanceID, len(result.AcquiredInstances), group.RequestedBotCount)
+			instanceID, len(result.AcquiredInstances), count)
 	}
 
 	return result.AcquiredInstances, result
 }
 
-// launchBotsStaggered launches bots with a staggered delay
-func (o *Orchestrator) launchBotsStaggered(group *BotGroup, instances []int, options LaunchOptions) (int, []string) {
+// launchBotsStaggered launches bots with a staggered delay.
+// Bots that would exceed the global bot budget are queued and started as slots free up.
+// Returns (launched, queued, errors).
+func (o *Orchestrator) launchBotsStaggered(group *BotGroup, instances []int, options LaunchOptions) (int, int, []string) {
 	launchedCount := 0
+	queuedCount := 0
 	errors := make([]string, 0)
 
-	staggerDelay := options.StaggerDelay
-	if staggerDelay == 0 {
-		staggerDelay = o.staggerDelay
-	}
-
 	for i, instanceID := range instances {
-		// Create bot for this instance
-		bot, err := group.createBot(instanceID)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("failed to create bot for instance %d: %v", instanceID, err))
-			// Release this instance
-			o.releaseInstance(instanceID, group.Name)
-			continue
+		// An emergency stop ends the launch; instances not reached are released with the group
+		if group.launchWasAborted() {
+			break
 		}
 
-		// Create bot info
-		botCtx, botCancel := context.WithCancel(group.ctx)
-		botInfo := &BotInfo{
-			Bot:           bot,
-			InstanceID:    instanceID,
-			StartedAt:     time.Now(),
-			Status:        BotStatusStarting,
-			routineCtx:    botCtx,
-			routineCancel: botCancel,
+		// Queue the launch if the global budget is exhausted
+		if !o.tryAcquireBotSlot(group.Name, instanceID) {
+			o.enqueueLaunch(group, instanceID, options.RestartPolicy)
+			queuedCount++
+			continue
 		}
 
-		// Add to active bots
-		group.activeBotsMu.Lock()
-		group.ActiveBots[instanceID] = botInfo
-		group.activeBotsMu.Unlock()
-
-		// Launch bot routine in background
-		go o.runBotRoutine(group, botInfo, options.RestartPolicy)
+		if err := o.startBot(group, instanceID, options.RestartPolicy); err != nil {
+			errors = append(errors, err.Error())
+			continue
+		}
 
 		launchedCount++
 
-		// Stagger next launch (except for last bot)
+		// Stagger next launch (except for last bot); the delay can change while launching
 		if i < len(instances)-1 {
-			time.Sleep(staggerDelay)
+			time.Sleep(group.StaggerDelay())
 		}
 	}
 
-	return launchedCount, errors
+	return launchedCount, queuedCount, errors
+}
+
+// startBot creates a bot on an acquired instance and runs its routine in the background.
+// The caller must already hold a budget slot for the instance; it is freed on failure.
+func (o *Orchestrator) startBot(group *BotGroup, instanceID int, policy RestartPolicy) error {
+	// Create bot for this instance
+	bot, err := group.createBot(instanceID)
+	if err != nil {
+		// Release this instance and its budget slot
+		o.dropBotSlot(group.Name, instanceID)
+		o.releaseInstance(instanceID, group.Name)
+		return fmt.Errorf("failed to create bot for instance %d: %v", instanceID, err)
+	}
+
+	// Route the instance's traffic through its proxy before anything runs on it
+	if err := o.applyInstanceProxy(group, bot, instanceID); err != nil {
+		group.shutdownBot(instanceID)
+		o.dropBotSlot(group.Name, instanceID)
+		o.releaseInstance(instanceID, group.Name)
+		return fmt.Errorf("instance %d: %w", instanceID, err)
+	}
+
+	// Create bot info
+	botCtx, botCancel := context.WithCancel(group.ctx)
+	botInfo := &BotInfo{
+		Bot:           bot,
+		InstanceID:    instanceID,
+		StartedAt:     time.Now(),
+		Status:        BotStatusStarting,
+		routineCtx:    botCtx,
+		routineCancel: botCancel,
+		routineDone:   make(chan struct{}),
+	}
+
+	// Add to active bots
+	group.activeBotsMu.Lock()
+	group.ActiveBots[instanceID] = botInfo
+	group.activeBotsMu.Unlock()
+
+	// Launch bot routine in background
+	go o.runBotRoutine(group, botInfo, policy)
+
+	return nil
 }
 
 // runBotRoutine executes a bot's routine with restart policy
 func (o *Orchestrator) runBotRoutine(group *BotGroup, botInfo *BotInfo, policy RestartPolicy) {
 	instanceID := botInfo.InstanceID
+	defer close(botInfo.routineDone)
 
 	// Guarantee cleanup runs regardless of panic or early return
 	defer func() {
 		// Recover from panics to ensure cleanup always runs
 		if r := recover(); r != nil {
-			fmt.Printf("[RunBotRoutine] PANIC in bot routine for instance %d: %v\n", instanceID, r)
+			botInfo.Bot.Logf("PANIC in bot routine: %v\n", r)
 			botInfo.Status = BotStatusFailed
 			botInfo.Error = fmt.Errorf("panic: %v", r)
 		}
@@ -437,7 +565,15 @@ func (o *Orchestrator) runBotRoutine(group *BotGroup, botInfo *BotInfo, policy R
 		o.healthMonitor.UntrackInstance(instanceID)
 		fmt.Printf("[RunBotRoutine] Stopped health monitoring for instance %d\n", instanceID)
 
+		// A bot stopped by ScaleGroup or the idle watchdog hands back its account and leaves the group
+		stopRequested := group.stopRequested(instanceID)
+		if stopRequested {
+			group.returnInFlightAccount(botInfo.Bot)
+			group.shutdownBot(instanceID)
+		}
+
 		// Remove from active bots
+		o.session.botExited(group, botInfo.Status)
 		group.activeBotsMu.Lock()
 		delete(group.ActiveBots, instanceID)
 		group.activeBotsMu.Unlock()
@@ -445,11 +581,33 @@ func (o *Orchestrator) runBotRoutine(group *BotGroup, botInfo *BotInfo, policy R
 		// Release instance
 		o.releaseInstance(instanceID, group.Name)
 
-		// If all bots have finished, mark group as not running
-		if group.GetActiveBotCount() == 0 {
+		if stopRequested && o.eventBus != nil {
+			o.eventBus.PublishAsync(events.NewBotStoppedEvent(group.Name, instanceID))
+		}
+
+		// Free the budget slot so queued bots (from any group) can start
+		o.releaseBotSlot(group.Name, instanceID)
+
+		// If all bots have finished and none are queued, mark group as not running
+		_, queued := o.GetGroupBudgetStatus(group.Name)
+		if group.GetActiveBotCount() == 0 && queued == 0 {
 			group.runningMu.Lock()
+			finished := group.running // False when StopGroup already ended the run
 			group.running = false
 			group.runningMu.Unlock()
+
+			group.releaseReservation()
+			group.releaseWarmed()
+			group.closeLogs()
+			group.cancelRunTimeLimit()
+
+			if finished {
+				o.session.groupFinished(group, time.Now())
+			}
+			if finished && o.eventBus != nil {
+				processed, _, _, _, _ := o.GroupETA(group.Name)
+				o.eventBus.PublishAsync(events.NewGroupCompletedEvent(group.Name, processed))
+			}
 		}
 	}()
 
@@ -465,21 +623,41 @@ func (o *Orchestrator) runBotRoutine(group *BotGroup, botInfo *BotInfo, policy R
 		}
 	})
 
+	// Watch for a routine that loops without making progress
+	if options := group.launchOptions; options.IdleTimeout > 0 {
+		go o.watchIdle(group, botInfo, options.IdleTimeout, options.IdleAction)
+	}
+
 	// Update status
 	botInfo.Status = BotStatusRunning
+	routineName := group.RoutineForInstance(instanceID)
+	botInfo.Bot.Logf("Starting routine '%s' in group '%s'\n", routineName, group.Name)
 
 	// Publish bot started event
 	if o.eventBus != nil {
 		o.eventBus.PublishAsync(events.NewBotStartedEvent(group.Name, instanceID))
 	}
 
+	// Start the game first if the launch asked for it, then let the startup routine
+	// clear launch-time dialogs
+	var err error
+	if group.launchGame {
+		err = botInfo.Bot.EnsureGameRunning()
+	}
+	if err == nil && group.startupRoutine != "" {
+		err = botInfo.Bot.RunStartupRoutine(group.startupRoutine)
+	}
+
 	// Execute with restart policy
-	err := group.executeWithRestart(instanceID, group.RoutineName, policy)
+	if err == nil {
+		err = group.executeWithRestart(instanceID, routineName, policy)
+	}
 
 	// Update status based on result and publish appropriate event
 	if err != nil {
 		botInfo.Status = BotStatusFailed
 		botInfo.Error = err
+		botInfo.Bot.Logf("Routine '%s' failed: %v\n", routineName, err)
 
 		// Publish bot failed event
 		if o.eventBus != nil {
@@ -487,6 +665,7 @@ func (o *Orchestrator) runBotRoutine(group *BotGroup, botInfo *BotInfo, policy R
 		}
 	} else {
 		botInfo.Status = BotStatusCompleted
+		botInfo.Bot.Logf("Routine '%s' completed\n", routineName)
 
 		// Publish bot completed event
 		if o.eventBus != nil {
@@ -506,6 +685,31 @@ func (o *Orchestrator) StopGroup(groupName string) error {
 		return fmt.Errorf("group '%s' is not running", groupName)
 	}
 
+	o.haltGroup(group)
+
+	// Publish group stopped event
+	if o.eventBus != nil {
+		o.eventBus.PublishAsync(events.NewGroupStoppedEvent(groupName))
+	}
+
+	return nil
+}
+
+// haltGroup cancels and shuts down a group's bots and releases everything the run holds:
+// queued launches, reserved accounts, account checkouts, instances and budget slots.
+// Unlike StopGroup it also works on a group whose launch hasn't finished yet.
+func (o *Orchestrator) haltGroup(group *BotGroup) {
+	groupName := group.Name
+
+	// A manual stop cancels the time limit, so it can't fire against a later run
+	group.cancelRunTimeLimit()
+
+	// Drop queued launches first so none start while the group is stopping
+	o.dropQueuedLaunches(groupName)
+
+	// Remember how the bots ended for the session report
+	o.session.groupStopped(group, countBotsByStatus(group), time.Now())
+
 	// Cancel all bot routines
 	group.activeBotsMu.Lock()
 	for _, botInfo := range group.ActiveBots {
@@ -516,6 +720,11 @@ func (o *Orchestrator) StopGroup(groupName string) error {
 
 	// Shutdown all bots
 	group.shutdownAllBots()
+	group.closeLogs()
+
+	// Return reserved and warmed accounts no bot got to use
+	group.releaseReservation()
+	group.releaseWarmed()
 
 	// Release all account checkouts for this orchestration
 	if o.db != nil && group.OrchestrationID != "" {
@@ -530,6 +739,9 @@ func (o *Orchestrator) StopGroup(groupName string) error {
 	// Release all instances
 	o.releaseAllInstances(groupName)
 
+	// Free the group's budget slots for queued bots in other groups
+	o.releaseGroupBudget(groupName)
+
 	// Clear active bots
 	group.activeBotsMu.Lock()
 	group.ActiveBots = make(map[int]*BotInfo)
@@ -539,13 +751,50 @@ func (o *Orchestrator) StopGroup(groupName string) error {
 	group.runningMu.Lock()
 	group.running = false
 	group.runningMu.Unlock()
+}
 
-	// Publish group stopped event
-	if o.eventBus != nil {
-		o.eventBus.PublishAsync(events.NewGroupStoppedEvent(groupName))
+// openLogs starts per-instance log files for the launch if its options ask for them,
+// replacing any from a previous launch
+func (g *BotGroup) openLogs(options LaunchOptions) {
+	g.closeLogs()
+	g.logSink = nil
+	if options.LogToFiles {
+		g.logSink = logging.NewFileSink(logging.DefaultLogsDir, options.LogMaxSizeMB, options.LogMaxFiles)
 	}
+}
 
-	return nil
+// closeLogs closes the group's log files; lines logged by bots still shutting down are dropped
+func (g *BotGroup) closeLogs() {
+	if g.logSink == nil {
+		return
+	}
+	if err := g.logSink.Close(); err != nil {
+		fmt.Printf("[BotGroup '%s'] Warning: failed to close log files: %v\n", g.Name, err)
+	}
+}
+
+// setReservation replaces the group's account reservation, releasing any previous one
+func (g *BotGroup) setReservation(reservation *accountpool.ReservedPool) {
+	g.reservationMu.Lock()
+	previous := g.reservation
+	g.reservation = reservation
+	g.reservationMu.Unlock()
+
+	if previous != nil {
+		previous.Release()
+	}
+}
+
+// getReservation returns the group's current account reservation (nil if none)
+func (g *BotGroup) getReservation() *accountpool.ReservedPool {
+	g.reservationMu.Lock()
+	defer g.reservationMu.Unlock()
+	return g.reservation
+}
+
+// releaseReservation returns unused reserved accounts to the pool and clears the reservation
+func (g *BotGroup) releaseReservation() {
+	g.setReservation(nil)
 }
 
 // stopBotOnInstance stops a specific bot instance from another group
@@ -572,6 +821,9 @@ func (o *Orchestrator) stopBotOnInstance(groupName string, instanceID int) error
 	// Release instance
 	o.releaseInstance(instanceID, groupName)
 
+	// Free the budget slot
+	o.releaseBotSlot(groupName, instanceID)
+
 	// Remove from active bots
 	group.activeBotsMu.Lock()
 	delete(group.ActiveBots, instanceID)
@@ -608,6 +860,7 @@ func (o *Orchestrator) createTempRuntimeGroup(runtimeName string, def *BotGroupD
 		bots:               make(map[int]*Bot),
 		RoutineName:        def.RoutineName,
 		RoutineConfig:      def.RoutineConfig,
+		InstanceRoutines:   copyInstanceRoutines(def.InstanceRoutines),
 		AvailableInstances: def.AvailableInstances,
 		RequestedBotCount:  def.RequestedBotCount,
 		ActiveBots:         make(map[int]*BotInfo),
@@ -0,0 +1,353 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// maxMaintenanceRecheckDelay caps the backoff between checks while maintenance is active
+const maxMaintenanceRecheckDelay = 30 * time.Minute
+
+// MaintenanceStatus is a snapshot of the maintenance monitor state
+type MaintenanceStatus struct {
+	Active     bool
+	Template   string
+	DetectedAt time.Time
+	DetectedBy string   // "group/instance" of the bot that saw the maintenance screen
+	Groups     []string // Groups paused for maintenance
+	PausedBots int
+	NextCheck  time.Time
+}
+
+// MaintenanceMonitor watches running bots for the game's maintenance screen.
+// When a bot sees it, every running bot in that bot's group is paused and the screen is
+// re-checked with exponential backoff; once it is gone the paused bots are resumed. Other
+// groups keep running until one of their own bots sees the screen.
+type MaintenanceMonitor struct {
+	orchestrator *Orchestrator
+	eventBus     events.EventBus
+
+	// Configuration
+	template      string
+	checkInterval time.Duration
+	recheckDelay  time.Duration
+
+	// Maintenance state
+	active     bool
+	detectedAt time.Time
+	detectedBy string
+	checkBot   *Bot            // Bot used to re-check the screen while maintenance is active
+	groups     map[string]bool // Groups whose bots saw the maintenance screen
+	pausedBots map[*Bot]bool   // Bots paused by the monitor (user-paused bots are left alone)
+	backoff    time.Duration
+	nextCheck  time.Time
+	mu         sync.Mutex
+
+	// Background monitoring
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewMaintenanceMonitor creates a maintenance monitor from the global config.
+// The monitor is disabled until a maintenance template is configured.
+func NewMaintenanceMonitor(orchestrator *Orchestrator, config *Config) *MaintenanceMonitor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &MaintenanceMonitor{
+		orchestrator:  orchestrator,
+		checkInterval: 30 * time.Second,
+		recheckDelay:  2 * time.Minute,
+		groups:        make(map[string]bool),
+		pausedBots:    make(map[*Bot]bool),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+
+	if config != nil {
+		m.template = config.MaintenanceTemplate
+		if config.MaintenanceCheckInterval > 0 {
+			m.checkInterval = time.Duration(config.MaintenanceCheckInterval) * time.Second
+		}
+		if config.MaintenanceRecheckDelay > 0 {
+			m.recheckDelay = time.Duration(config.MaintenanceRecheckDelay) * time.Second
+		}
+	}
+
+	return m
+}
+
+// SetEventBus sets the event bus for publishing maintenance events
+func (m *MaintenanceMonitor) SetEventBus(eventBus events.EventBus) {
+	m.eventBus = eventBus
+}
+
+// SetTemplate changes the maintenance screen template (empty disables detection)
+func (m *MaintenanceMonitor) SetTemplate(template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.template = template
+}
+
+// Start begins background maintenance monitoring
+func (m *MaintenanceMonitor) Start() {
+	m.wg.Add(1)
+	go m.monitor()
+}
+
+// Stop stops background monitoring. Bots paused for maintenance stay paused.
+func (m *MaintenanceMonitor) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// IsActive reports whether bots are currently paused for maintenance
+func (m *MaintenanceMonitor) IsActive() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.active
+}
+
+// Status returns a snapshot of the maintenance state
+func (m *MaintenanceMonitor) Status() MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	groups := make([]string, 0, len(m.groups))
+	for name := range m.groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	return MaintenanceStatus{
+		Active:     m.active,
+		Template:   m.template,
+		DetectedAt: m.detectedAt,
+		DetectedBy: m.detectedBy,
+		Groups:     groups,
+		PausedBots: len(m.pausedBots),
+		NextCheck:  m.nextCheck,
+	}
+}
+
+// monitor runs in a goroutine, scanning for maintenance or re-checking while it is active
+func (m *MaintenanceMonitor) monitor() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	lastScan := time.Now()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			template := m.template
+			active := m.active
+			due := m.nextCheck
+			m.mu.Unlock()
+
+			if template == "" {
+				continue
+			}
+
+			if active && now.After(due) {
+				m.recheck(template)
+			}
+
+			// Groups not paused for maintenance are still scanned while it is active
+			if now.Sub(lastScan) >= m.checkInterval {
+				lastScan = now
+				m.scan(template)
+			}
+		}
+	}
+}
+
+// scan checks the unpaused bots of groups not yet paused for maintenance for the maintenance
+// screen, and pauses a group on the first hit among its bots
+func (m *MaintenanceMonitor) scan(template string) {
+	for _, group := range m.orchestrator.ListActiveGroups() {
+		if m.isAffected(group.Name) {
+			continue
+		}
+		for _, b := range group.runningBots() {
+			if b.IsPaused() {
+				continue
+			}
+
+			found, err := m.screenShows(b, template)
+			if err != nil {
+				fmt.Printf("[Maintenance] Bot %d: check failed: %v\n", b.Instance(), err)
+				continue
+			}
+			if found {
+				m.enterMaintenance(group, b)
+				break
+			}
+		}
+	}
+}
+
+// enterMaintenance pauses the running bots of the group whose bot saw the maintenance screen.
+// The first group to see it schedules the first re-check; later ones share its schedule.
+func (m *MaintenanceMonitor) enterMaintenance(group *BotGroup, detectedBy *Bot) {
+	m.mu.Lock()
+	if !m.active {
+		m.active = true
+		m.detectedAt = time.Now()
+		m.detectedBy = fmt.Sprintf("%s/%d", group.Name, detectedBy.Instance())
+		m.checkBot = detectedBy
+		m.backoff = m.recheckDelay
+		m.nextCheck = m.detectedAt.Add(m.backoff)
+	}
+	m.groups[group.Name] = true
+	nextCheck := m.nextCheck
+	m.mu.Unlock()
+
+	paused := m.pauseGroupBots(group)
+
+	fmt.Printf("[Maintenance] Detected by bot %d in group '%s' - paused %d bot(s), re-checking in %v\n",
+		detectedBy.Instance(), group.Name, paused, time.Until(nextCheck).Round(time.Second))
+
+	if m.eventBus != nil {
+		m.eventBus.PublishAsync(events.NewMaintenanceDetectedEvent(group.Name, detectedBy.Instance(), paused, nextCheck))
+	}
+}
+
+// recheck looks at the screen again and resumes bots if maintenance is over,
+// otherwise doubles the backoff (up to maxMaintenanceRecheckDelay)
+func (m *MaintenanceMonitor) recheck(template string) {
+	// Bots launched into paused groups during maintenance are paused too
+	for _, group := range m.orchestrator.ListActiveGroups() {
+		if m.isAffected(group.Name) {
+			m.pauseGroupBots(group)
+		}
+	}
+
+	b := m.recheckBot()
+	if b == nil {
+		// Nothing left to check with - nothing left to pause either
+		m.exitMaintenance()
+		return
+	}
+
+	found, err := m.screenShows(b, template)
+	if err == nil && !found {
+		m.exitMaintenance()
+		return
+	}
+	if err != nil {
+		fmt.Printf("[Maintenance] Bot %d: re-check failed: %v\n", b.Instance(), err)
+	}
+
+	m.mu.Lock()
+	m.backoff *= 2
+	if m.backoff > maxMaintenanceRecheckDelay {
+		m.backoff = maxMaintenanceRecheckDelay
+	}
+	m.nextCheck = time.Now().Add(m.backoff)
+	backoff := m.backoff
+	m.mu.Unlock()
+
+	fmt.Printf("[Maintenance] Still in maintenance - re-checking in %v\n", backoff)
+}
+
+// exitMaintenance resumes the bots the monitor paused
+func (m *MaintenanceMonitor) exitMaintenance() {
+	m.mu.Lock()
+	duration := time.Since(m.detectedAt)
+	bots := make([]*Bot, 0, len(m.pausedBots))
+	for b := range m.pausedBots {
+		bots = append(bots, b)
+	}
+	m.active = false
+	m.checkBot = nil
+	m.groups = make(map[string]bool)
+	m.pausedBots = make(map[*Bot]bool)
+	m.nextCheck = time.Time{}
+	m.mu.Unlock()
+
+	for _, b := range bots {
+		b.Resume()
+	}
+
+	fmt.Printf("[Maintenance] Maintenance over after %v - resumed %d bot(s)\n", duration.Round(time.Second), len(bots))
+
+	if m.eventBus != nil {
+		m.eventBus.PublishAsync(events.NewMaintenanceEndedEvent(len(bots), duration))
+	}
+}
+
+// pauseGroupBots pauses every running bot of the group that is not already paused and returns the total paused by the monitor
+func (m *MaintenanceMonitor) pauseGroupBots(group *BotGroup) int {
+	for _, b := range group.runningBots() {
+		if b.IsPaused() {
+			continue
+		}
+		b.Pause()
+
+		m.mu.Lock()
+		m.pausedBots[b] = true
+		m.mu.Unlock()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.pausedBots)
+}
+
+// isAffected reports whether the group is paused for maintenance
+func (m *MaintenanceMonitor) isAffected(groupName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.groups[groupName]
+}
+
+// recheckBot returns the bot that detected maintenance, or another paused bot if it has stopped
+func (m *MaintenanceMonitor) recheckBot() *Bot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.checkBot != nil && !m.checkBot.IsStopped() {
+		return m.checkBot
+	}
+	for b := range m.pausedBots {
+		if b.IsStopped() {
+			delete(m.pausedBots, b)
+			continue
+		}
+		m.checkBot = b
+		return b
+	}
+	return nil
+}
+
+// screenShows reports whether the bot's screen currently shows the template
+func (m *MaintenanceMonitor) screenShows(b *Bot, template string) (bool, error) {
+	if b.cv == nil || b.templateRegistry == nil {
+		return false, fmt.Errorf("bot not initialized")
+	}
+	condition := &actions.ImageExists{Template: template}
+	return condition.Evaluate(b)
+}
+
+// runningBots returns a snapshot of the group's bots
+func (g *BotGroup) runningBots() []*Bot {
+	g.botsMu.RLock()
+	defer g.botsMu.RUnlock()
+
+	bots := make([]*Bot, 0, len(g.bots))
+	for _, b := range g.bots {
+		bots = append(bots, b)
+	}
+	return bots
+}
@@ -1,6 +1,10 @@
 package bot
 
-import "jordanella.com/pocket-tcg-go/internal/accountpool"
+import (
+	"database/sql"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+)
 
 // BotGroupManagerAdapter adapts a BotGroup to provide Manager-like functionality
 // This allows orchestrator-created bots to access the group's account pool
@@ -15,7 +19,23 @@ func NewBotGroupManagerAdapter(group *BotGroup) *BotGroupManagerAdapter {
 	}
 }
 
-// AccountPool returns the bot group's account pool
+// AccountPool returns the bot group's account pool, serving accounts pre-injected onto warm
+// instances and then its reserved accounts first
 func (a *BotGroupManagerAdapter) AccountPool() accountpool.AccountPool {
-	return a.group.AccountPool
+	var pool accountpool.AccountPool = a.group.AccountPool
+	if reservation := a.group.getReservation(); reservation != nil {
+		pool = reservation
+	}
+	if pool != nil && a.group.hasWarmPool() {
+		return &warmedPool{AccountPool: pool, group: a.group}
+	}
+	return pool
+}
+
+// Database returns the orchestrator's shared accounts database (nil if it has none)
+func (a *BotGroupManagerAdapter) Database() *sql.DB {
+	if a.group.orchestrator == nil {
+		return nil
+	}
+	return a.group.orchestrator.db
 }
@@ -0,0 +1,34 @@
+package bot
+
+import (
+	"jordanella.com/pocket-tcg-go/internal/metrics"
+)
+
+// GroupStats reports each running group's bots and account progress for the metrics endpoint
+func (o *Orchestrator) GroupStats() []metrics.GroupStats {
+	groups := o.ListActiveGroups()
+	stats := make([]metrics.GroupStats, 0, len(groups))
+
+	for _, group := range groups {
+		groupStats := metrics.GroupStats{
+			Name:         group.Name,
+			BotsByStatus: make(map[string]int),
+		}
+
+		for _, info := range group.GetAllBotInfo() {
+			groupStats.BotsByStatus[string(info.Status)]++
+		}
+		if queued := len(o.GetQueuedInstances(group.Name)); queued > 0 {
+			groupStats.BotsByStatus[string(BotStatusQueued)] += queued
+		}
+
+		if processed, total, err := o.GetGroupAccountProgress(group.Name); err == nil {
+			groupStats.AccountsProcessed = processed
+			groupStats.AccountsTotal = total
+		}
+
+		stats = append(stats, groupStats)
+	}
+
+	return stats
+}
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// etaWindow is how far back completions count toward the rolling throughput
+	etaWindow = time.Hour
+
+	// etaMinCompletions is how many completions are needed before an ETA is estimated
+	etaMinCompletions = 3
+)
+
+// ErrETAUnavailable is returned by GroupETA (with a zero ETA) while too few accounts
+// have completed to estimate throughput. Processed and total are still valid.
+var ErrETAUnavailable = errors.New("not enough completed accounts to estimate")
+
+// progressSample records how many accounts had been processed at a point in time
+type progressSample struct {
+	at        time.Time
+	processed int
+}
+
+// progressTracker keeps recent completion samples for a group to compute throughput
+type progressTracker struct {
+	mu      sync.Mutex
+	samples []progressSample
+}
+
+// reset starts tracking over, e.g. when a new account pool is assigned
+func (p *progressTracker) reset(processed int, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.samples = []progressSample{{at: now, processed: processed}}
+}
+
+// record adds a sample when the processed count has changed since the last one
+func (p *progressTracker) record(processed int, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := len(p.samples); n > 0 {
+		last := p.samples[n-1]
+		if processed == last.processed {
+			return
+		}
+		if processed < last.processed {
+			// The pool was refreshed or refilled; earlier samples no longer apply
+			p.samples = p.samples[:0]
+		}
+	}
+	p.samples = append(p.samples, progressSample{at: now, processed: processed})
+
+	// Drop samples outside the window, keeping the newest older one as the baseline
+	cutoff := now.Add(-etaWindow)
+	drop := 0
+	for drop+1 < len(p.samples) && p.samples[drop+1].at.Before(cutoff) {
+		drop++
+	}
+	p.samples = p.samples[drop:]
+}
+
+// rate returns throughput in accounts per hour from the first sample in the window until now.
+// Measuring to now (rather than the last completion) lets the rate decay when bots stall.
+func (p *progressTracker) rate(now time.Time) (float64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.samples) < 2 {
+		return 0, false
+	}
+	first, last := p.samples[0], p.samples[len(p.samples)-1]
+	completed := last.processed - first.processed
+	elapsed := now.Sub(first.at)
+	if completed < etaMinCompletions || elapsed <= 0 {
+		return 0, false
+	}
+	return float64(completed) / elapsed.Hours(), true
+}
+
+// AccountCompleted samples the group's progress when a bot has finished an account, so the
+// throughput is tracked whether or not GroupETA is polled
+func (a *BotGroupManagerAdapter) AccountCompleted() {
+	a.group.orchestrator.recordProgress(a.group, time.Now())
+}
+
+// recordProgress samples a group's processed account count
+func (o *Orchestrator) recordProgress(group *BotGroup, now time.Time) {
+	processed, _, err := o.GetGroupAccountProgress(group.Name)
+	if err != nil {
+		return
+	}
+	group.progress.record(processed, now)
+}
+
+// GroupETA returns a group's account progress, its rolling throughput in accounts per hour,
+// and the projected time to finish the remaining accounts. While too few completions exist,
+// it returns a zero rate and ETA along with ErrETAUnavailable.
+func (o *Orchestrator) GroupETA(name string) (processed, total int, rate float64, eta time.Duration, err error) {
+	processed, total, err = o.GetGroupAccountProgress(name)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	group, exists := o.GetGroup(name)
+	if !exists {
+		return 0, 0, 0, 0, fmt.Errorf("group '%s' not found", name)
+	}
+
+	now := time.Now()
+	group.progress.record(processed, now)
+
+	rate, ok := group.progress.rate(now)
+	if !ok {
+		return processed, total, 0, 0, ErrETAUnavailable
+	}
+
+	remaining := total - processed
+	if remaining <= 0 {
+		return processed, total, rate, 0, nil
+	}
+	eta = time.Duration(float64(remaining) / rate * float64(time.Hour))
+	return processed, total, rate, eta, nil
+}
+
+// FormatGroupETA formats GroupETA results for display, e.g. "42/100, ~3h remaining"
+func FormatGroupETA(processed, total int, eta time.Duration, err error) string {
+	progress := fmt.Sprintf("%d/%d", processed, total)
+	switch {
+	case errors.Is(err, ErrETAUnavailable):
+		return progress + ", estimating..."
+	case err != nil:
+		return ""
+	case processed >= total:
+		return progress + ", complete"
+	}
+	return fmt.Sprintf("%s, ~%s remaining", progress, formatETA(eta))
+}
+
+// formatETA rounds a duration to the most useful unit
+func formatETA(d time.Duration) string {
+	switch {
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Round(time.Hour).Hours()))
+	case d >= time.Hour:
+		d = d.Round(time.Minute)
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	}
+	return "<1m"
+}
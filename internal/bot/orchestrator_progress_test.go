@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProgressTrackerRate(t *testing.T) {
+	start := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	var p progressTracker
+	p.reset(0, start)
+	if _, ok := p.rate(start.Add(time.Minute)); ok {
+		t.Error("rate available without completions")
+	}
+
+	// Two completions are too few to estimate
+	p.record(1, start.Add(10*time.Minute))
+	p.record(2, start.Add(20*time.Minute))
+	if _, ok := p.rate(start.Add(20 * time.Minute)); ok {
+		t.Error("rate available after 2 completions")
+	}
+
+	// An unchanged count adds no sample
+	p.record(2, start.Add(25*time.Minute))
+	if got := len(p.samples); got != 3 {
+		t.Errorf("%d samples after an unchanged count, want 3", got)
+	}
+
+	p.record(3, start.Add(30*time.Minute))
+	rate, ok := p.rate(start.Add(30 * time.Minute))
+	if !ok || rate != 6 {
+		t.Errorf("rate = %v (ok %v), want 6 accounts/hour", rate, ok)
+	}
+
+	// Measured to now, the rate decays while bots stall
+	if rate, _ := p.rate(start.Add(time.Hour)); rate != 3 {
+		t.Errorf("stalled rate = %v, want 3 accounts/hour", rate)
+	}
+}
+
+func TestProgressTrackerWindow(t *testing.T) {
+	start := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	var p progressTracker
+	p.reset(0, start)
+	for i := 1; i <= 10; i++ {
+		p.record(i, start.Add(time.Duration(i)*15*time.Minute))
+	}
+
+	// Samples older than the window are dropped, keeping the newest older one as the baseline
+	now := start.Add(150 * time.Minute)
+	if first := p.samples[0]; first.processed != 5 {
+		t.Errorf("baseline sample has %d processed, want 5", first.processed)
+	}
+	if rate, ok := p.rate(now); !ok || rate != 4 {
+		t.Errorf("rate = %v (ok %v), want 4 accounts/hour", rate, ok)
+	}
+}
+
+func TestProgressTrackerCountDrop(t *testing.T) {
+	start := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	var p progressTracker
+	p.reset(0, start)
+	for i := 1; i <= 5; i++ {
+		p.record(i, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	// A refilled pool lowers the processed count; earlier samples no longer apply
+	p.record(1, start.Add(10*time.Minute))
+	if len(p.samples) != 1 || p.samples[0].processed != 1 {
+		t.Errorf("samples = %+v, want only the new count", p.samples)
+	}
+	if _, ok := p.rate(start.Add(20 * time.Minute)); ok {
+		t.Error("rate available right after the count dropped")
+	}
+}
+
+func TestFormatGroupETA(t *testing.T) {
+	tests := []struct {
+		processed, total int
+		eta              time.Duration
+		err              error
+		want             string
+	}{
+		{3, 10, 0, ErrETAUnavailable, "3/10, estimating..."},
+		{0, 0, 0, errors.New("no pool"), ""},
+		{10, 10, 0, nil, "10/10, complete"},
+		{4, 10, 150 * time.Minute, nil, "4/10, ~3h remaining"},
+		{4, 10, 75 * time.Minute, nil, "4/10, ~1h15m remaining"},
+		{9, 10, 20 * time.Second, nil, "9/10, ~<1m remaining"},
+	}
+	for _, tt := range tests {
+		if got := FormatGroupETA(tt.processed, tt.total, tt.eta, tt.err); got != tt.want {
+			t.Errorf("FormatGroupETA(%d, %d, %v, %v) = %q, want %q", tt.processed, tt.total, tt.eta, tt.err, got, tt.want)
+		}
+	}
+}
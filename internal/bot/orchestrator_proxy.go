@@ -0,0 +1,39 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+)
+
+// proxyCheckTimeout bounds the reachability check of LaunchOptions.ValidateProxies
+const proxyCheckTimeout = 5 * time.Second
+
+// applyInstanceProxy sets the instance's configured proxy (see Config.ProxyFor) through the
+// bot's ADB connection. With ValidateProxies it first checks the proxy accepts connections,
+// so a bot never starts on an instance whose proxy is down.
+func (o *Orchestrator) applyInstanceProxy(group *BotGroup, b *Bot, instanceID int) error {
+	proxy, configured, err := o.config.ProxyFor(instanceID)
+	if err != nil {
+		return fmt.Errorf("invalid proxy: %w", err)
+	}
+	if !configured {
+		return nil
+	}
+
+	if proxy != nil && group.launchOptions.ValidateProxies {
+		if err := proxy.CheckReachable(proxyCheckTimeout); err != nil {
+			return err
+		}
+	}
+
+	if err := b.emulatorManager.ApplyProxy(instanceID, proxy); err != nil {
+		return err
+	}
+
+	if proxy == nil {
+		b.Logf("Cleared proxy\n")
+	} else {
+		b.Logf("Using proxy %s\n", proxy)
+	}
+	return nil
+}
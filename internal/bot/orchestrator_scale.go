@@ -0,0 +1,207 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// ScaleGroup changes how many bots a running group has. Scaling up acquires free instances
+// from the group's AvailableInstances and launches bots on them (staggered, and queued if the
+// global budget is full). Scaling down first drops queued launches, then asks the most
+// recently started bots to stop after their current routine iteration; their in-flight
+// accounts are returned to the pool.
+func (o *Orchestrator) ScaleGroup(groupName string, newBotCount int) error {
+	group, exists := o.GetGroup(groupName)
+	if !exists {
+		return fmt.Errorf("group '%s' not found", groupName)
+	}
+	if !group.IsRunning() {
+		return fmt.Errorf("group '%s' is not running", groupName)
+	}
+	if newBotCount < 1 {
+		return fmt.Errorf("bot count must be at least 1 (stop the group to run no bots)")
+	}
+	if newBotCount > len(group.AvailableInstances) {
+		return fmt.Errorf("bot count %d exceeds the group's %d available instances",
+			newBotCount, len(group.AvailableInstances))
+	}
+
+	current := group.scaledBotCount()
+	group.RequestedBotCount = newBotCount
+
+	switch {
+	case newBotCount > current:
+		return o.scaleUp(group, newBotCount-current)
+	case newBotCount < current:
+		o.scaleDown(group, current-newBotCount)
+	}
+	return nil
+}
+
+// scaleUp launches count more bots on instances the group isn't using
+func (o *Orchestrator) scaleUp(group *BotGroup, count int) error {
+	candidates := group.idleInstances(o.GetQueuedInstances(group.Name))
+	options := group.launchOptions
+
+	acquired, acquireResult := o.acquireInstancesFrom(group, candidates, count, options)
+	if len(acquired) == 0 {
+		return fmt.Errorf("no instances available to scale group '%s' up: %s",
+			group.Name, strings.Join(acquireResult.LaunchErrors, "; "))
+	}
+
+	launched, queued, launchErrors := o.launchBotsStaggered(group, acquired, options)
+	fmt.Printf("[ScaleGroup] Group '%s': launched %d, queued %d of %d added bot(s)\n",
+		group.Name, launched, queued, count)
+
+	if launched == 0 && queued == 0 {
+		return fmt.Errorf("failed to add bots to group '%s': %s", group.Name, strings.Join(launchErrors, "; "))
+	}
+	return nil
+}
+
+// scaleDown removes count bots, queued launches first, then the most recently started bots
+func (o *Orchestrator) scaleDown(group *BotGroup, count int) {
+	dropped := o.dropLastQueuedLaunches(group.Name, count)
+	for _, instanceID := range dropped {
+		o.releaseInstance(instanceID, group.Name)
+	}
+	count -= len(dropped)
+	if count <= 0 {
+		return
+	}
+
+	group.activeBotsMu.Lock()
+	running := make([]*BotInfo, 0, len(group.ActiveBots))
+	for _, info := range group.ActiveBots {
+		if info.stopReason == "" {
+			running = append(running, info)
+		}
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].StartedAt.After(running[j].StartedAt)
+	})
+	if count > len(running) {
+		count = len(running)
+	}
+	reason := fmt.Sprintf("group '%s' scaled down", group.Name)
+	for _, info := range running[:count] {
+		info.stopReason = reason
+		info.Status = BotStatusStopping
+	}
+	group.activeBotsMu.Unlock()
+
+	for _, info := range running[:count] {
+		info.Bot.Logf("Stopping after the current routine iteration (%s)\n", reason)
+	}
+}
+
+// scaledBotCount counts the group's bots that aren't being scaled down, including queued launches
+func (g *BotGroup) scaledBotCount() int {
+	_, queued := g.orchestrator.GetGroupBudgetStatus(g.Name)
+
+	g.activeBotsMu.RLock()
+	defer g.activeBotsMu.RUnlock()
+
+	count := queued
+	for _, info := range g.ActiveBots {
+		if info.stopReason == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// idleInstances returns the group's available instances that have no bot and no queued launch
+func (g *BotGroup) idleInstances(queued []int) []int {
+	busy := make(map[int]bool, len(queued))
+	for _, instanceID := range queued {
+		busy[instanceID] = true
+	}
+
+	g.activeBotsMu.RLock()
+	for instanceID := range g.ActiveBots {
+		busy[instanceID] = true
+	}
+	g.activeBotsMu.RUnlock()
+
+	g.botsMu.RLock()
+	for instanceID := range g.bots {
+		busy[instanceID] = true
+	}
+	g.botsMu.RUnlock()
+
+	idle := make([]int, 0, len(g.AvailableInstances))
+	for _, instanceID := range g.AvailableInstances {
+		if !busy[instanceID] {
+			idle = append(idle, instanceID)
+		}
+	}
+	return idle
+}
+
+// stopRequested reports whether the bot on an instance was asked to stop and leave the group
+// (by ScaleGroup or the idle watchdog)
+func (g *BotGroup) stopRequested(instanceID int) bool {
+	return g.stopReason(instanceID) != ""
+}
+
+// stopReason returns why the bot on an instance was asked to stop ("" if it wasn't)
+func (g *BotGroup) stopReason(instanceID int) string {
+	g.activeBotsMu.RLock()
+	defer g.activeBotsMu.RUnlock()
+	if info, exists := g.ActiveBots[instanceID]; exists {
+		return info.stopReason
+	}
+	return ""
+}
+
+// returnInFlightAccount returns the account a bot still holds to the pool and releases
+// its checkout, so another bot can pick it up
+func (g *BotGroup) returnInFlightAccount(bot *Bot) {
+	account := bot.currentAccount
+	if account == nil {
+		return
+	}
+
+	pool := g.AccountPool
+	if reservation := g.getReservation(); reservation != nil {
+		pool = reservation
+	}
+	if pool != nil {
+		if err := pool.Return(account); err != nil {
+			bot.Logf("Warning - failed to return account '%s' to pool: %v\n", account.ID, err)
+		}
+	}
+
+	if db := g.orchestrator.db; db != nil && account.DeviceAccount != "" && !accountpool.IsSandbox(g.AccountPool) {
+		if err := database.ReleaseAccount(db, account.DeviceAccount, g.OrchestrationID); err != nil {
+			bot.Logf("Warning - failed to release account checkout: %v\n", err)
+		}
+	}
+
+	bot.ClearCurrentAccount()
+	bot.Logf("Returned account '%s' to the pool\n", account.ID)
+}
+
+// StaggerDelay returns the delay between the group's bot launches
+func (g *BotGroup) StaggerDelay() time.Duration {
+	g.staggerMu.RLock()
+	defer g.staggerMu.RUnlock()
+	return g.staggerDelay
+}
+
+// SetStaggerDelay changes the delay between the group's bot launches. It applies to the
+// rest of a launch in progress and to bots added by ScaleGroup.
+func (g *BotGroup) SetStaggerDelay(delay time.Duration) {
+	if delay < 0 {
+		delay = 0
+	}
+	g.staggerMu.Lock()
+	g.staggerDelay = delay
+	g.staggerMu.Unlock()
+}
@@ -0,0 +1,348 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// DefaultReportsDir is where session reports are written (see WriteSessionReport)
+const DefaultReportsDir = "reports"
+
+// sessionReportTimeFormat names report files so they sort by time
+const sessionReportTimeFormat = "20060102_150405"
+
+// SessionReport summarizes what the orchestrator did since it started: the totals recorded
+// in the database plus each group launched during the session
+type SessionReport struct {
+	StartedAt   time.Time     `json:"started_at"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Runtime     time.Duration `json:"runtime"`
+
+	AccountsProcessed int            `json:"accounts_processed"`
+	PacksOpened       int            `json:"packs_opened"`
+	GodPacks          int            `json:"god_packs"`
+	Errors            int            `json:"errors"`
+	ErrorsByType      map[string]int `json:"errors_by_type,omitempty"`
+	Activities        map[string]int `json:"activities,omitempty"` // activity_log entries by status
+
+	Groups []GroupSessionReport `json:"groups"`
+}
+
+// GroupSessionReport is one group's outcome in a session
+type GroupSessionReport struct {
+	Name            string        `json:"name"`
+	OrchestrationID string        `json:"orchestration_id"`
+	Routine         string        `json:"routine"`
+	Launches        int           `json:"launches"`
+	StartedAt       time.Time     `json:"started_at"`
+	StoppedAt       time.Time     `json:"stopped_at,omitempty"` // Zero while running
+	Runtime         time.Duration `json:"runtime"`
+	Running         bool          `json:"running"`
+
+	AccountsProcessed int            `json:"accounts_processed"`
+	RoutinesCompleted int            `json:"routines_completed"`
+	RoutinesFailed    int            `json:"routines_failed"`
+	PacksOpened       int            `json:"packs_opened"`
+	GodPacks          int            `json:"god_packs"`
+	BotsByStatus      map[string]int `json:"bots_by_status,omitempty"` // When the group stopped, or now if running
+}
+
+// sessionGroup is what the orchestrator remembers about a group launched this session
+type sessionGroup struct {
+	name            string
+	orchestrationID string
+	routine         string
+	launches        int
+	startedAt       time.Time
+	stoppedAt       time.Time
+	botsByStatus    map[string]int
+	exitedBots      map[string]int // Statuses bots of the current run exited with
+	godPacks        int
+}
+
+// sessionLog tracks the groups launched since the orchestrator started
+type sessionLog struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	groups    map[string]*sessionGroup // Keyed by orchestration ID
+	order     []string                 // Orchestration IDs in launch order
+}
+
+func newSessionLog(now time.Time) *sessionLog {
+	return &sessionLog{
+		startedAt: now,
+		groups:    make(map[string]*sessionGroup),
+	}
+}
+
+// groupLaunched records a group launch (relaunches of a group extend its entry)
+func (s *sessionLog) groupLaunched(group *BotGroup, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.groups[group.OrchestrationID]
+	if !exists {
+		entry = &sessionGroup{
+			name:            group.Name,
+			orchestrationID: group.OrchestrationID,
+			startedAt:       now,
+		}
+		s.groups[group.OrchestrationID] = entry
+		s.order = append(s.order, group.OrchestrationID)
+	}
+	entry.routine = group.RoutineName
+	entry.launches++
+	entry.stoppedAt = time.Time{}
+	entry.botsByStatus = nil
+	entry.exitedBots = make(map[string]int)
+}
+
+// groupStopped records the bot statuses a group stopped with, along with those of the bots
+// that already exited during the run
+func (s *sessionLog) groupStopped(group *BotGroup, botsByStatus map[string]int, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, exists := s.groups[group.OrchestrationID]; exists {
+		for status, count := range entry.exitedBots {
+			botsByStatus[status] += count
+		}
+		entry.stoppedAt = now
+		entry.botsByStatus = botsByStatus
+		entry.exitedBots = nil
+	}
+}
+
+// botExited records the status a bot of the group's current run exited with
+func (s *sessionLog) botExited(group *BotGroup, status BotStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, exists := s.groups[group.OrchestrationID]; exists && entry.exitedBots != nil {
+		entry.exitedBots[string(status)]++
+	}
+}
+
+// groupFinished records a group whose bots all exited on their own with the statuses they
+// exited with
+func (s *sessionLog) groupFinished(group *BotGroup, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, exists := s.groups[group.OrchestrationID]; exists {
+		entry.stoppedAt = now
+		entry.botsByStatus = entry.exitedBots
+		entry.exitedBots = nil
+	}
+}
+
+// godPackFound counts a god pack reported by a group's bot
+func (s *sessionLog) godPackFound(group *BotGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, exists := s.groups[group.OrchestrationID]; exists {
+		entry.godPacks++
+	}
+}
+
+// countBotsByStatus counts a group's bots by status
+func countBotsByStatus(group *BotGroup) map[string]int {
+	counts := make(map[string]int)
+	for _, info := range group.GetAllBotInfo() {
+		counts[string(info.Status)]++
+	}
+	return counts
+}
+
+// SessionSummary reports the current session: runtime state for the groups launched since
+// the orchestrator started, combined with the activity, pack, error and routine execution
+// logs recorded since then
+func (o *Orchestrator) SessionSummary() (*SessionReport, error) {
+	now := time.Now()
+
+	o.session.mu.Lock()
+	report := &SessionReport{
+		StartedAt:   o.session.startedAt,
+		GeneratedAt: now,
+		Runtime:     now.Sub(o.session.startedAt),
+		Groups:      make([]GroupSessionReport, 0, len(o.session.order)),
+	}
+	for _, id := range o.session.order {
+		entry := o.session.groups[id]
+		group := GroupSessionReport{
+			Name:            entry.name,
+			OrchestrationID: entry.orchestrationID,
+			Routine:         entry.routine,
+			Launches:        entry.launches,
+			StartedAt:       entry.startedAt,
+			StoppedAt:       entry.stoppedAt,
+			GodPacks:        entry.godPacks,
+			BotsByStatus:    entry.botsByStatus,
+		}
+		report.Groups = append(report.Groups, group)
+	}
+	o.session.mu.Unlock()
+
+	for i := range report.Groups {
+		group := &report.Groups[i]
+		if group.StoppedAt.IsZero() {
+			group.Running = true
+			group.Runtime = now.Sub(group.StartedAt)
+			if active, exists := o.GetActiveGroup(group.Name); exists && active.OrchestrationID == group.OrchestrationID {
+				group.BotsByStatus = countBotsByStatus(active)
+			}
+		} else {
+			group.Runtime = group.StoppedAt.Sub(group.StartedAt)
+		}
+		report.GodPacks += group.GodPacks
+	}
+
+	if o.db == nil {
+		return report, nil
+	}
+
+	totals, err := database.GetSessionTotals(o.db, report.StartedAt)
+	if err != nil {
+		return report, fmt.Errorf("failed to read session totals: %w", err)
+	}
+
+	report.AccountsProcessed = totals.AccountsProcessed
+	report.Errors = totals.Errors()
+	report.ErrorsByType = totals.ErrorsByType
+	report.Activities = totals.ActivitiesByState
+
+	// Bots record packs on their routine executions; pack_results only holds packs whose
+	// contents were read, so take whichever saw more
+	report.PacksOpened = max(totals.PacksOpened, totals.PackResults)
+	report.GodPacks = max(report.GodPacks, totals.GodPacks)
+
+	for i := range report.Groups {
+		group := &report.Groups[i]
+		if execution, ok := totals.Orchestrations[group.OrchestrationID]; ok {
+			group.AccountsProcessed = execution.AccountsProcessed
+			group.RoutinesCompleted = execution.RoutinesCompleted
+			group.RoutinesFailed = execution.RoutinesFailed
+			group.PacksOpened = execution.PacksOpened
+		}
+	}
+
+	return report, nil
+}
+
+// HasSessionActivity reports whether any group was launched this session
+func (o *Orchestrator) HasSessionActivity() bool {
+	o.session.mu.Lock()
+	defer o.session.mu.Unlock()
+	return len(o.session.order) > 0
+}
+
+// WriteSessionReport writes the session summary to dir as session_<start time>.json and
+// returns the file's path. Writing again during the same session replaces the file.
+func (o *Orchestrator) WriteSessionReport(dir string) (string, error) {
+	report, err := o.SessionSummary()
+	if report == nil {
+		return "", err
+	}
+	if err != nil {
+		// Still write what the runtime state knows
+		fmt.Printf("Warning: Session report is missing database totals: %v\n", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode session report: %w", err)
+	}
+
+	path := filepath.Join(dir, "session_"+report.StartedAt.Format(sessionReportTimeFormat)+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write session report: %w", err)
+	}
+	return path, nil
+}
+
+// LoadLastSessionReport reads the most recent session report written to dir
+func LoadLastSessionReport(dir string) (*SessionReport, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "session_*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no session reports in %s", dir)
+	}
+	sort.Strings(matches)
+
+	data, err := os.ReadFile(matches[len(matches)-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session report: %w", err)
+	}
+
+	var report SessionReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse session report: %w", err)
+	}
+	return &report, nil
+}
+
+// Format renders the report as plain text for dialogs and logs
+func (r *SessionReport) Format() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Session started %s, ran %s\n", r.StartedAt.Format("2006-01-02 15:04:05"), r.Runtime.Round(time.Second))
+	fmt.Fprintf(&b, "Accounts processed: %d\n", r.AccountsProcessed)
+	fmt.Fprintf(&b, "Packs opened: %d\n", r.PacksOpened)
+	fmt.Fprintf(&b, "God packs: %d\n", r.GodPacks)
+	fmt.Fprintf(&b, "Errors: %d%s\n", r.Errors, formatCounts(r.ErrorsByType))
+
+	if len(r.Groups) == 0 {
+		b.WriteString("\nNo groups were launched.\n")
+		return b.String()
+	}
+
+	for _, group := range r.Groups {
+		state := "stopped"
+		if group.Running {
+			state = "running"
+		}
+		fmt.Fprintf(&b, "\n%s (%s, %s, ran %s)\n", group.Name, group.Routine, state, group.Runtime.Round(time.Second))
+		fmt.Fprintf(&b, "  Accounts: %d, routines: %d completed / %d failed\n",
+			group.AccountsProcessed, group.RoutinesCompleted, group.RoutinesFailed)
+		fmt.Fprintf(&b, "  Packs: %d, god packs: %d\n", group.PacksOpened, group.GodPacks)
+		if len(group.BotsByStatus) > 0 {
+			fmt.Fprintf(&b, "  Bots%s\n", formatCounts(group.BotsByStatus))
+		}
+	}
+
+	return b.String()
+}
+
+// formatCounts renders counts as " (key: n, ...)" sorted by key, or "" if empty
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", key, counts[key])
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
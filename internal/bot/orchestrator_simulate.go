@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+)
+
+// PlannedBot is one bot a launch would start
+type PlannedBot struct {
+	InstanceID      int
+	EmulatorRunning bool   // False if the emulator would be started first
+	Account         string // Device account expected to be reserved ("" if the group has no pool or it ran out)
+	Queued          bool   // Would wait for a slot in the global bot budget
+	Preempts        string // Group whose bot on this instance would be stopped (ConflictResolutionCancel)
+}
+
+// LaunchPlan is the outcome of SimulateLaunch: what LaunchGroup would do right now
+type LaunchPlan struct {
+	GroupName         string
+	RequestedBots     int
+	Bots              []PlannedBot
+	Conflicts         []InstanceConflict
+	SkippedInstances  []int
+	PoolName          string
+	AvailableAccounts int      // Available accounts in the group's pool
+	Errors            []string // Problems that would make the launch fail
+	Warnings          []string // Problems the launch would continue past
+}
+
+// WouldLaunch reports whether the launch is expected to start (or queue) at least one bot
+func (p *LaunchPlan) WouldLaunch() bool {
+	return len(p.Errors) == 0 && len(p.Bots) > 0
+}
+
+// SimulateLaunch plans a launch of the named group with opts without side effects: no bots are
+// created, no emulators started or stopped, and no accounts or instances reserved. It applies the
+// same validation, conflict resolution, account reservation and bot budget rules as LaunchGroup.
+// The accounts listed are candidates; the pool may hand out different available accounts.
+func (o *Orchestrator) SimulateLaunch(name string, opts LaunchOptions) (*LaunchPlan, error) {
+	def, err := o.LoadGroupDefinition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &LaunchPlan{
+		GroupName:        name,
+		RequestedBots:    def.RequestedBotCount,
+		Bots:             make([]PlannedBot, 0),
+		Conflicts:        make([]InstanceConflict, 0),
+		SkippedInstances: make([]int, 0),
+		PoolName:         def.AccountPoolName,
+		Errors:           make([]string, 0),
+		Warnings:         make([]string, 0),
+	}
+
+	group, exists := o.GetGroup(name)
+	if exists && group.IsRunning() {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("group '%s' is already running", name))
+	}
+
+	// Validation (same checks as saving the definition and launching it)
+	if result := o.ValidateDefinition(def); !result.Valid {
+		plan.Errors = append(plan.Errors, result.FormatValidationErrors())
+	}
+	if result := ValidateLaunchOptions(&opts); !result.Valid {
+		plan.Errors = append(plan.Errors, result.FormatValidationErrors())
+	}
+	if opts.ValidateRoutine {
+		for _, routineName := range def.RoutineNames() {
+			if result := o.ValidateRoutine(routineName, def.RoutineConfig); !result.Valid {
+				plan.Errors = append(plan.Errors, result.FormatValidationErrors())
+			}
+		}
+	}
+
+	// Accounts that would be reserved
+	candidates := make([]string, 0)
+	if def.AccountPoolName != "" {
+		accounts, err := o.peekGroupAccounts(group, def.AccountPoolName)
+		if err != nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("failed to resolve account pool: %v", err))
+		} else {
+			for _, account := range accounts {
+				if account.Status == accountpool.AccountStatusAvailable {
+					candidates = append(candidates, account.DeviceAccount)
+				}
+			}
+			sort.Strings(candidates)
+			plan.AvailableAccounts = len(candidates)
+			if len(candidates) < def.RequestedBotCount {
+				plan.Warnings = append(plan.Warnings,
+					fmt.Sprintf("only %d of %d requested accounts available", len(candidates), def.RequestedBotCount))
+			}
+		}
+	}
+
+	// Instances that would be acquired
+	o.planInstances(plan, def, opts)
+	if len(plan.Bots) == 0 {
+		plan.Errors = append(plan.Errors, "no emulator instances available")
+	} else if len(plan.Bots) < def.RequestedBotCount {
+		plan.Warnings = append(plan.Warnings,
+			fmt.Sprintf("only %d of %d requested instances available", len(plan.Bots), def.RequestedBotCount))
+	}
+
+	// Pair bots with accounts and the global bot budget
+	freeSlots := o.freeBotSlots()
+	for i := range plan.Bots {
+		if i < len(candidates) && i < def.RequestedBotCount {
+			plan.Bots[i].Account = candidates[i]
+		}
+		if freeSlots >= 0 && i >= freeSlots {
+			plan.Bots[i].Queued = true
+		}
+	}
+
+	return plan, nil
+}
+
+// peekGroupAccounts lists a group's pool accounts without opening the pool if it isn't open already
+func (o *Orchestrator) peekGroupAccounts(group *BotGroup, poolName string) ([]*accountpool.Account, error) {
+	if group != nil && group.AccountPool != nil {
+		return group.AccountPool.ListAccounts(), nil
+	}
+	if o.poolManager == nil {
+		return nil, fmt.Errorf("pool manager not configured")
+	}
+	return o.poolManager.PeekAccounts(poolName)
+}
+
+// planInstances mirrors the planning phase of acquireInstances without reserving, starting or stopping anything
+func (o *Orchestrator) planInstances(plan *LaunchPlan, def *BotGroupDefinition, opts LaunchOptions) {
+	if o.emulatorManager != nil {
+		if err := o.emulatorManager.DiscoverInstances(); err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("failed to discover emulator instances: %v", err))
+		}
+	}
+
+	for _, instanceID := range def.AvailableInstances {
+		if len(plan.Bots) >= def.RequestedBotCount {
+			break
+		}
+
+		available, conflictingGroup, err := o.checkInstanceAvailability(instanceID, def.Name)
+		if err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("error checking instance %d: %v", instanceID, err))
+			continue
+		}
+
+		preempts := ""
+		if !available {
+			plan.Conflicts = append(plan.Conflicts, InstanceConflict{
+				InstanceID:       instanceID,
+				CurrentGroupName: conflictingGroup,
+				RequestedBy:      def.Name,
+			})
+
+			switch opts.OnConflict {
+			case ConflictResolutionCancel:
+				preempts = conflictingGroup
+			case ConflictResolutionAbort:
+				plan.Errors = append(plan.Errors,
+					fmt.Sprintf("instance %d is in use by group '%s' and conflicts abort the launch", instanceID, conflictingGroup))
+				plan.Bots = plan.Bots[:0]
+				return
+			default:
+				plan.SkippedInstances = append(plan.SkippedInstances, instanceID)
+				continue
+			}
+		}
+
+		running, err := o.isEmulatorRunning(instanceID)
+		if err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("error checking if instance %d is running: %v", instanceID, err))
+			continue
+		}
+
+		plan.Bots = append(plan.Bots, PlannedBot{
+			InstanceID:      instanceID,
+			EmulatorRunning: running,
+			Preempts:        preempts,
+		})
+	}
+}
+
+// freeBotSlots returns how many more bots may start before launches queue (-1 = unlimited)
+func (o *Orchestrator) freeBotSlots() int {
+	o.budgetMu.Lock()
+	defer o.budgetMu.Unlock()
+
+	// Queued launches are served first, so new bots would queue behind them
+	if len(o.launchQueue) > 0 {
+		return 0
+	}
+	if o.maxConcurrentBots <= 0 {
+		return -1
+	}
+	free := o.maxConcurrentBots - o.activeSlotCountLocked()
+	if free < 0 {
+		return 0
+	}
+	return free
+}
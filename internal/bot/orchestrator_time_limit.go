@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// runTimeLimit is a running group's LaunchOptions.MaxRunDuration timer
+type runTimeLimit struct {
+	timer     *time.Timer
+	startedAt time.Time
+	duration  time.Duration
+}
+
+// StopGroupGraceful asks every bot in a running group to stop after its current routine
+// iteration and drops its queued launches. Stopped bots hand back their in-flight accounts,
+// and the group completes once the last one exits. Use StopGroup to stop immediately.
+func (o *Orchestrator) StopGroupGraceful(groupName string) error {
+	group, exists := o.GetGroup(groupName)
+	if !exists {
+		return fmt.Errorf("group '%s' not found", groupName)
+	}
+	if !group.IsRunning() {
+		return fmt.Errorf("group '%s' is not running", groupName)
+	}
+
+	o.stopGroupGraceful(group, fmt.Sprintf("group '%s' stopping gracefully", groupName))
+	return nil
+}
+
+// stopGroupGraceful asks the group's bots to stop after their current iteration for a reason
+func (o *Orchestrator) stopGroupGraceful(group *BotGroup, reason string) {
+	group.cancelRunTimeLimit()
+
+	dropped := o.dropQueuedLaunches(group.Name)
+	for _, instanceID := range dropped {
+		o.releaseInstance(instanceID, group.Name)
+	}
+
+	group.activeBotsMu.Lock()
+	stopping := make([]*BotInfo, 0, len(group.ActiveBots))
+	for _, info := range group.ActiveBots {
+		if info.stopReason == "" {
+			info.stopReason = reason
+			info.Status = BotStatusStopping
+			stopping = append(stopping, info)
+		}
+	}
+	group.activeBotsMu.Unlock()
+
+	fmt.Printf("[BotGroup '%s'] Stopping %d bot(s) after their current routine iteration (%s), dropped %d queued launch(es)\n",
+		group.Name, len(stopping), reason, len(dropped))
+	for _, info := range stopping {
+		info.Bot.Logf("Stopping after the current routine iteration (%s)\n", reason)
+	}
+
+	// With no bot left to finish, end the run now (only queued launches were waiting)
+	if group.GetActiveBotCount() == 0 && group.IsRunning() {
+		if err := o.StopGroup(group.Name); err != nil {
+			fmt.Printf("[BotGroup '%s'] Warning: failed to stop group: %v\n", group.Name, err)
+		}
+	}
+}
+
+// startRunTimeLimit starts the group's MaxRunDuration timer for a launch (none when duration
+// is 0), replacing any timer of a previous launch
+func (o *Orchestrator) startRunTimeLimit(group *BotGroup, duration time.Duration) {
+	group.cancelRunTimeLimit()
+	if duration <= 0 {
+		return
+	}
+
+	limit := &runTimeLimit{startedAt: time.Now(), duration: duration}
+	group.timeLimitMu.Lock()
+	group.timeLimit = limit
+	limit.timer = time.AfterFunc(duration, func() { o.runTimeLimitElapsed(group, limit) })
+	group.timeLimitMu.Unlock()
+
+	fmt.Printf("[BotGroup '%s'] Running for at most %v\n", group.Name, duration)
+}
+
+// runTimeLimitElapsed gracefully stops the group its time limit belongs to. A limit that was
+// cancelled or replaced (the group was stopped or relaunched) in the meantime does nothing.
+func (o *Orchestrator) runTimeLimitElapsed(group *BotGroup, limit *runTimeLimit) {
+	group.timeLimitMu.Lock()
+	current := group.timeLimit == limit
+	if current {
+		group.timeLimit = nil
+	}
+	group.timeLimitMu.Unlock()
+
+	if !current || !group.IsRunning() {
+		return
+	}
+
+	fmt.Printf("[BotGroup '%s'] Time limit of %v reached\n", group.Name, limit.duration)
+	if o.eventBus != nil {
+		o.eventBus.PublishAsync(events.NewGroupTimeLimitReachedEvent(group.Name, limit.duration))
+	}
+
+	o.stopGroupGraceful(group, fmt.Sprintf("time limit of %v reached", limit.duration))
+}
+
+// cancelRunTimeLimit stops the group's time limit timer, if any
+func (g *BotGroup) cancelRunTimeLimit() {
+	g.timeLimitMu.Lock()
+	defer g.timeLimitMu.Unlock()
+
+	if g.timeLimit != nil {
+		g.timeLimit.timer.Stop()
+		g.timeLimit = nil
+	}
+}
+
+// RunTimeLimit returns how long the group has run and how long it has left under its
+// LaunchOptions.MaxRunDuration. ok is false when the current run has no time limit.
+func (g *BotGroup) RunTimeLimit() (elapsed, remaining time.Duration, ok bool) {
+	g.timeLimitMu.Lock()
+	defer g.timeLimitMu.Unlock()
+
+	if g.timeLimit == nil {
+		return 0, 0, false
+	}
+
+	elapsed = time.Since(g.timeLimit.startedAt)
+	remaining = g.timeLimit.duration - elapsed
+	if remaining < 0 {
+		remaining = 0
+	}
+	return elapsed, remaining, true
+}
@@ -2,6 +2,8 @@ package bot
 
 import (
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -94,25 +96,17 @@ func (o *Orchestrator) ValidateRoutine(routineName string, config map[string]str
 func (o *Orchestrator) validateTemplates(routineName string) []ValidationError {
 	errors := make([]ValidationError, 0)
 
-	// Get routine metadata to find template references
-	metadata := o.routineRegistry.GetMetadata(routineName)
+	if o.templateRegistry == nil {
+		return errors
+	}
 
-	// Extract template references from metadata
-	// The metadata structure depends on how routines store template info
-	if metadataMap, ok := metadata.(map[string]interface{}); ok {
-		if templates, ok := metadataMap["templates"].([]interface{}); ok {
-			for _, tmpl := range templates {
-				if templateName, ok := tmpl.(string); ok {
-					// Check if template exists
-					if !o.templateRegistry.Has(templateName) {
-						errors = append(errors, ValidationError{
-							Type:    ValidationErrorTemplateNotFound,
-							Message: fmt.Sprintf("Template '%s' not found in registry", templateName),
-							Context: templateName,
-						})
-					}
-				}
-			}
+	for _, templateName := range o.routineRegistry.TemplatesUsed(routineName) {
+		if !o.templateRegistry.Has(templateName) {
+			errors = append(errors, ValidationError{
+				Type:    ValidationErrorTemplateNotFound,
+				Message: fmt.Sprintf("Template '%s' not found in registry", templateName),
+				Context: templateName,
+			})
 		}
 	}
 
@@ -188,6 +182,35 @@ func (vr *ValidationResult) FormatValidationErrors() string {
 	return sb.String()
 }
 
+// ValidationFailure is an error that carries every problem found during validation,
+// so callers (e.g. the GUI) can show all issues at once instead of only the first
+type ValidationFailure struct {
+	Errors []ValidationError
+}
+
+// Error implements the error interface, listing every validation problem
+func (vf *ValidationFailure) Error() string {
+	result := &ValidationResult{Valid: false, Errors: vf.Errors}
+	return strings.TrimSuffix(result.FormatValidationErrors(), "\n")
+}
+
+// Messages returns one human-readable line per validation problem
+func (vf *ValidationFailure) Messages() []string {
+	messages := make([]string, 0, len(vf.Errors))
+	for _, err := range vf.Errors {
+		messages = append(messages, err.Message)
+	}
+	return messages
+}
+
+// Err returns nil if validation passed, otherwise a *ValidationFailure listing every error
+func (vr *ValidationResult) Err() error {
+	if vr.Valid {
+		return nil
+	}
+	return &ValidationFailure{Errors: vr.Errors}
+}
+
 // GetErrorsByType returns all errors of a specific type
 func (vr *ValidationResult) GetErrorsByType(errorType ValidationErrorType) []ValidationError {
 	errors := make([]ValidationError, 0)
@@ -234,6 +257,45 @@ func ValidateGroupDefinition(def *BotGroupDefinition) *ValidationResult {
 			Message: "Routine name is required",
 			Context: "RoutineName",
 		})
+	} else if ext := filepath.Ext(def.RoutineName); ext != "" && ext != ".yaml" && ext != ".yml" {
+		// Routines are YAML files; names are stored without extension but a .yaml/.yml suffix is tolerated
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: fmt.Sprintf("Routine '%s' must be a .yaml routine file (got extension '%s')", def.RoutineName, ext),
+			Context: "RoutineName",
+		})
+	}
+
+	// Validate per-instance routine overrides
+	for _, instanceID := range overrideInstanceIDs(def.InstanceRoutines) {
+		routineName := def.InstanceRoutines[instanceID]
+		context := fmt.Sprintf("InstanceRoutines[%d]", instanceID)
+
+		if strings.TrimSpace(routineName) == "" {
+			result.Valid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Type:    ValidationErrorMissingField,
+				Message: fmt.Sprintf("Routine override for instance %d is empty", instanceID),
+				Context: context,
+			})
+		} else if ext := filepath.Ext(routineName); ext != "" && ext != ".yaml" && ext != ".yml" {
+			result.Valid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Type:    ValidationErrorInvalidField,
+				Message: fmt.Sprintf("Routine '%s' must be a .yaml routine file (got extension '%s')", routineName, ext),
+				Context: context,
+			})
+		}
+
+		if !slices.Contains(def.AvailableInstances, instanceID) {
+			result.Valid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Type:    ValidationErrorInvalidField,
+				Message: fmt.Sprintf("Routine override for instance %d, which is not one of the group's instances", instanceID),
+				Context: context,
+			})
+		}
 	}
 
 	// Validate available instances
@@ -293,9 +355,179 @@ func ValidateGroupDefinition(def *BotGroupDefinition) *ValidationResult {
 		instanceSet[instanceID] = true
 	}
 
+	// Validate account pool names are not blank
+	for i, poolName := range def.AccountPoolNames {
+		if strings.TrimSpace(poolName) == "" {
+			result.Valid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Type:    ValidationErrorInvalidField,
+				Message: "Account pool name cannot be blank",
+				Context: fmt.Sprintf("AccountPoolNames[%d]", i),
+			})
+		}
+	}
+
+	// Validate restart policies (both the group-level and launch option copies are persisted)
+	policyErrors := validateRestartPolicy(def.RestartPolicy, "RestartPolicy")
+	policyErrors = append(policyErrors, validateRestartPolicy(def.LaunchOptions.RestartPolicy, "LaunchOptions.RestartPolicy")...)
+	if len(policyErrors) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, policyErrors...)
+	}
+
 	return result
 }
 
+// overrideInstanceIDs returns the instances with a routine override, in order so validation
+// messages are stable
+func overrideInstanceIDs(overrides map[int]string) []int {
+	instanceIDs := make([]int, 0, len(overrides))
+	for instanceID := range overrides {
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	slices.Sort(instanceIDs)
+	return instanceIDs
+}
+
+// ValidateDefinition validates a group definition, including references that can
+// only be resolved by the orchestrator (routine registry and account pools)
+func (o *Orchestrator) ValidateDefinition(def *BotGroupDefinition) *ValidationResult {
+	result := ValidateGroupDefinition(def)
+
+	// Check routine exists in the registry
+	if def.RoutineName != "" && o.routineRegistry != nil {
+		routineName := routineNameWithoutExt(def.RoutineName)
+		if !o.routineRegistry.Has(routineName) {
+			result.Valid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Type:    ValidationErrorRoutineNotFound,
+				Message: fmt.Sprintf("Routine '%s' not found in registry", def.RoutineName),
+				Context: "RoutineName",
+			})
+		}
+	}
+
+	// Check per-instance routine overrides exist in the registry
+	if o.routineRegistry != nil {
+		for _, instanceID := range overrideInstanceIDs(def.InstanceRoutines) {
+			routineName := def.InstanceRoutines[instanceID]
+			if strings.TrimSpace(routineName) == "" {
+				continue // Reported by ValidateGroupDefinition
+			}
+			if !o.routineRegistry.Has(routineNameWithoutExt(routineName)) {
+				result.Valid = false
+				result.Errors = append(result.Errors, ValidationError{
+					Type:    ValidationErrorRoutineNotFound,
+					Message: fmt.Sprintf("Routine '%s' (instance %d) not found in registry", routineName, instanceID),
+					Context: fmt.Sprintf("InstanceRoutines[%d]", instanceID),
+				})
+			}
+		}
+	}
+
+	// Check referenced account pools exist
+	if o.poolManager != nil {
+		poolNames := def.AccountPoolNames
+		if len(poolNames) == 0 && def.AccountPoolName != "" {
+			poolNames = []string{def.AccountPoolName}
+		}
+
+		for _, poolName := range poolNames {
+			if strings.TrimSpace(poolName) == "" {
+				continue // Reported by ValidateGroupDefinition
+			}
+			if _, err := o.poolManager.GetPoolDefinition(poolName); err != nil {
+				result.Valid = false
+				result.Errors = append(result.Errors, ValidationError{
+					Type:    ValidationErrorInvalidField,
+					Message: fmt.Sprintf("Account pool '%s' does not exist", poolName),
+					Context: "AccountPoolName",
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// routineNameWithoutExt strips a .yaml/.yml extension to get the registry key
+func routineNameWithoutExt(routineName string) string {
+	ext := filepath.Ext(routineName)
+	if ext == ".yaml" || ext == ".yml" {
+		return strings.TrimSuffix(routineName, ext)
+	}
+	return routineName
+}
+
+// validateRestartPolicy checks an enabled restart policy has usable delays and backoff
+func validateRestartPolicy(policy RestartPolicy, field string) []ValidationError {
+	errors := make([]ValidationError, 0)
+	if !policy.Enabled {
+		return errors
+	}
+
+	if policy.MaxRetries < -1 {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Max retries must be >= -1 (-1 for infinite)",
+			Context: field + ".MaxRetries",
+		})
+	}
+
+	if policy.MaxIterations < 0 {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Max iterations must be >= 0 (0 for unlimited)",
+			Context: field + ".MaxIterations",
+		})
+	}
+
+	if policy.InitialDelay <= 0 {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: fmt.Sprintf("Initial delay must be positive (got %v)", policy.InitialDelay),
+			Context: field + ".InitialDelay",
+		})
+	}
+
+	if policy.MaxDelay <= 0 {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: fmt.Sprintf("Max delay must be positive (got %v)", policy.MaxDelay),
+			Context: field + ".MaxDelay",
+		})
+	}
+
+	if policy.InitialDelay > 0 && policy.MaxDelay > 0 && policy.InitialDelay > policy.MaxDelay {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: fmt.Sprintf("Initial delay (%v) cannot exceed max delay (%v)", policy.InitialDelay, policy.MaxDelay),
+			Context: field,
+		})
+	}
+
+	switch policy.EffectiveStrategy() {
+	case BackoffExponential:
+		// A factor of 1 or less never grows the delay; use the fixed strategy for that
+		if policy.BackoffFactor <= 1.0 {
+			errors = append(errors, ValidationError{
+				Type:    ValidationErrorInvalidField,
+				Message: fmt.Sprintf("Exponential backoff requires a backoff factor greater than 1.0 (got %.2f)", policy.BackoffFactor),
+				Context: field + ".BackoffFactor",
+			})
+		}
+	case BackoffFixed, BackoffLinear, BackoffFibonacci:
+	default:
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: fmt.Sprintf("Unknown backoff strategy '%s' (must be fixed, linear, exponential or fibonacci)", policy.Strategy),
+			Context: field + ".Strategy",
+		})
+	}
+
+	return errors
+}
+
 // ValidateLaunchOptions validates launch options
 func ValidateLaunchOptions(options *LaunchOptions) *ValidationResult {
 	result := &ValidationResult{
@@ -323,52 +555,68 @@ func ValidateLaunchOptions(options *LaunchOptions) *ValidationResult {
 		})
 	}
 
-	// Validate restart policy
-	if options.RestartPolicy.Enabled {
-		if options.RestartPolicy.MaxRetries < -1 {
-			result.Valid = false
-			result.Errors = append(result.Errors, ValidationError{
-				Type:    ValidationErrorInvalidField,
-				Message: "Max retries must be >= -1 (-1 for infinite)",
-				Context: "RestartPolicy.MaxRetries",
-			})
-		}
-
-		if options.RestartPolicy.InitialDelay <= 0 {
-			result.Valid = false
-			result.Errors = append(result.Errors, ValidationError{
-				Type:    ValidationErrorInvalidField,
-				Message: "Initial delay must be positive",
-				Context: "RestartPolicy.InitialDelay",
-			})
-		}
+	// Validate log rotation (0 uses the defaults)
+	if options.LogMaxSizeMB < 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Log max size cannot be negative",
+			Context: "LogMaxSizeMB",
+		})
+	}
+	if options.LogMaxFiles < 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Log max files cannot be negative",
+			Context: "LogMaxFiles",
+		})
+	}
 
-		if options.RestartPolicy.MaxDelay <= 0 {
-			result.Valid = false
-			result.Errors = append(result.Errors, ValidationError{
-				Type:    ValidationErrorInvalidField,
-				Message: "Max delay must be positive",
-				Context: "RestartPolicy.MaxDelay",
-			})
-		}
+	// Validate time limit (0 disables it)
+	if options.MaxRunDuration < 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Max run duration cannot be negative",
+			Context: "MaxRunDuration",
+		})
+	}
 
-		if options.RestartPolicy.InitialDelay > options.RestartPolicy.MaxDelay {
-			result.Valid = false
-			result.Errors = append(result.Errors, ValidationError{
-				Type:    ValidationErrorInvalidField,
-				Message: "Initial delay cannot exceed max delay",
-				Context: "RestartPolicy",
-			})
-		}
+	// Validate idle watchdog (0 disables it)
+	if options.IdleTimeout < 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Idle timeout cannot be negative",
+			Context: "IdleTimeout",
+		})
+	}
+	switch options.IdleAction {
+	case "", IdleActionRestart, IdleActionStop:
+	default:
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: fmt.Sprintf("Unknown idle action '%s' (use 'restart' or 'stop')", options.IdleAction),
+			Context: "IdleAction",
+		})
+	}
+	// Restarting an idle bot only stops its routine; the restart policy is what runs it again
+	restartsIdle := options.IdleAction == "" || options.IdleAction == IdleActionRestart
+	if options.IdleTimeout > 0 && restartsIdle && !options.RestartPolicy.Enabled {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    ValidationErrorInvalidField,
+			Message: "Idle action 'restart' needs the restart policy enabled (or use 'stop')",
+			Context: "IdleAction",
+		})
+	}
 
-		if options.RestartPolicy.BackoffFactor <= 0 {
-			result.Valid = false
-			result.Errors = append(result.Errors, ValidationError{
-				Type:    ValidationErrorInvalidField,
-				Message: "Backoff factor must be positive",
-				Context: "RestartPolicy.BackoffFactor",
-			})
-		}
+	// Validate restart policy
+	if policyErrors := validateRestartPolicy(options.RestartPolicy, "RestartPolicy"); len(policyErrors) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, policyErrors...)
 	}
 
 	return result
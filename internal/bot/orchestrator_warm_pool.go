@@ -0,0 +1,297 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+)
+
+// Warm pool: while a group's bots run their accounts, the next account is reserved from the
+// group's pool and pre-injected onto an idle warm instance, so a bot started there (e.g. by
+// ScaleGroup or a queued launch) has its account ready instead of waiting for injection.
+// Warmed accounts stay reserved until that bot takes them, and are released when the run
+// stops or the instance leaves the warm pool.
+
+// warmAccount is an account pre-injected onto a warm instance
+type warmAccount struct {
+	account *accountpool.Account
+	ready   chan struct{} // Closed once the injection finished
+	err     error         // Injection error (set before ready is closed)
+	taken   bool          // Claimed by the bot starting on the instance (see takeWarmed)
+}
+
+// EnableWarmPool designates instances of a group as warm: idle instances among them get the
+// next account pre-injected while the group runs. The instances must be among the group's
+// AvailableInstances. An empty list disables the warm pool and releases warmed accounts.
+func (o *Orchestrator) EnableWarmPool(groupName string, instanceIDs []int) error {
+	group, exists := o.GetGroup(groupName)
+	if !exists {
+		return fmt.Errorf("group '%s' not found", groupName)
+	}
+	for _, instanceID := range instanceIDs {
+		if !slices.Contains(group.AvailableInstances, instanceID) {
+			return fmt.Errorf("instance %d is not one of group '%s' available instances", instanceID, groupName)
+		}
+	}
+
+	group.warmMu.Lock()
+	if group.warmed == nil {
+		group.warmed = make(map[int]*warmAccount)
+		group.preInjected = make(map[int]*accountpool.Account)
+	}
+	group.warmInstances = make(map[int]bool, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		group.warmInstances[instanceID] = true
+	}
+	var released []*warmAccount
+	for instanceID, warm := range group.warmed {
+		if !group.warmInstances[instanceID] && !warm.taken {
+			delete(group.warmed, instanceID)
+			released = append(released, warm)
+		}
+	}
+	group.warmMu.Unlock()
+
+	group.releaseWarmAccounts(released)
+
+	if len(instanceIDs) == 0 {
+		fmt.Printf("[BotGroup '%s'] Warm pool disabled\n", groupName)
+	} else {
+		fmt.Printf("[BotGroup '%s'] Warm pool enabled on instances %v\n", groupName, instanceIDs)
+	}
+	return nil
+}
+
+// WarmedAccounts returns the account pre-injected (or being injected) on each warm instance
+func (g *BotGroup) WarmedAccounts() map[int]string {
+	g.warmMu.Lock()
+	defer g.warmMu.Unlock()
+
+	warmed := make(map[int]string, len(g.warmed))
+	for instanceID, warm := range g.warmed {
+		warmed[instanceID] = warm.account.ID
+	}
+	return warmed
+}
+
+// warmNext reserves the next account from the group's pool and pre-injects it onto an idle
+// warm instance, if the group has one. Bots call it as they start an iteration.
+func (g *BotGroup) warmNext() {
+	pool := g.AccountPool
+	if pool == nil {
+		return
+	}
+
+	instanceID, ok := g.idleWarmInstance()
+	if !ok {
+		return
+	}
+	reserved, err := pool.Reserve(1)
+	if err != nil || len(reserved) == 0 {
+		if err != nil {
+			fmt.Printf("[BotGroup '%s'] Warning: could not reserve an account to warm instance %d: %v\n", g.Name, instanceID, err)
+		}
+		return
+	}
+
+	// Another bot may have warmed the instance (or it left the warm pool) while reserving
+	warm := &warmAccount{account: reserved[0], ready: make(chan struct{})}
+	g.warmMu.Lock()
+	_, warmed := g.warmed[instanceID]
+	claimed := g.warmInstances[instanceID] && !warmed
+	if claimed {
+		g.warmed[instanceID] = warm
+	}
+	g.warmMu.Unlock()
+	if !claimed {
+		pool.ReleaseReservation(reserved)
+		return
+	}
+
+	fmt.Printf("[BotGroup '%s'] Pre-injecting account '%s' onto warm instance %d\n", g.Name, warm.account.ID, instanceID)
+	warm.err = g.orchestrator.injectOnInstance(instanceID, warm.account)
+	close(warm.ready)
+
+	if warm.err == nil {
+		fmt.Printf("[BotGroup '%s'] Account '%s' is ready on warm instance %d\n", g.Name, warm.account.ID, instanceID)
+		return
+	}
+
+	fmt.Printf("[BotGroup '%s'] Warning: failed to pre-inject account '%s' onto warm instance %d: %v\n",
+		g.Name, warm.account.ID, instanceID, warm.err)
+	g.warmMu.Lock()
+	if g.warmed[instanceID] == warm && !warm.taken {
+		delete(g.warmed, instanceID)
+	} else {
+		warm = nil
+	}
+	g.warmMu.Unlock()
+	if warm != nil {
+		g.releaseWarmAccounts([]*warmAccount{warm})
+	}
+}
+
+// idleWarmInstance returns the lowest warm instance with no bot, no queued launch, no warmed
+// account and no other group using it. g.warmMu is only held to read the warm pool, not while
+// checking the emulators.
+func (g *BotGroup) idleWarmInstance() (int, bool) {
+	g.warmMu.Lock()
+	candidates := make(map[int]bool, len(g.warmInstances))
+	for instanceID := range g.warmInstances {
+		if _, warmed := g.warmed[instanceID]; !warmed {
+			candidates[instanceID] = true
+		}
+	}
+	g.warmMu.Unlock()
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	o := g.orchestrator
+	idle := make([]int, 0, len(candidates))
+	for _, instanceID := range g.idleInstances(o.GetQueuedInstances(g.Name)) {
+		if !candidates[instanceID] {
+			continue
+		}
+		if assignment, assigned := o.GetInstanceAssignment(instanceID); assigned && assignment.GroupName != g.Name {
+			continue
+		}
+		if running, err := o.isEmulatorRunning(instanceID); err != nil || !running {
+			continue
+		}
+		idle = append(idle, instanceID)
+	}
+	if len(idle) == 0 {
+		return 0, false
+	}
+	sort.Ints(idle)
+	return idle[0], true
+}
+
+// injectOnInstance pushes an account onto an instance no bot is running on
+func (o *Orchestrator) injectOnInstance(instanceID int, account *accountpool.Account) error {
+	if o.emulatorManager == nil {
+		return fmt.Errorf("emulator manager not configured")
+	}
+	if err := o.emulatorManager.ConnectInstance(instanceID); err != nil {
+		return err
+	}
+	defer o.emulatorManager.DisconnectInstance(instanceID)
+
+	instance, err := o.emulatorManager.GetInstance(instanceID)
+	if err != nil {
+		return err
+	}
+	packageName := DefaultGamePackage
+	if o.config != nil {
+		packageName = o.config.GamePackageName()
+	}
+	return pushAccountXML(instance.ADB, packageName, account)
+}
+
+// takeWarmed hands the account warmed on an instance to the bot starting there, waiting for an
+// injection still in progress. Returns nil if there is none or its injection failed.
+func (g *BotGroup) takeWarmed(instanceID int) *accountpool.Account {
+	g.warmMu.Lock()
+	warm, exists := g.warmed[instanceID]
+	if !exists || warm.taken {
+		g.warmMu.Unlock()
+		return nil
+	}
+	warm.taken = true
+	g.warmMu.Unlock()
+
+	<-warm.ready
+
+	g.warmMu.Lock()
+	if g.warmed[instanceID] == warm {
+		delete(g.warmed, instanceID)
+	}
+	if warm.err == nil {
+		g.preInjected[instanceID] = warm.account
+	}
+	g.warmMu.Unlock()
+
+	if warm.err != nil {
+		g.releaseWarmAccounts([]*warmAccount{warm})
+		return nil
+	}
+	fmt.Printf("[BotGroup '%s'] Instance %d: using pre-injected account '%s'\n", g.Name, instanceID, warm.account.ID)
+	return warm.account
+}
+
+// consumePreInjected reports whether account is the one takeWarmed handed to the bot on the
+// instance, so the bot can skip injecting it again
+func (g *BotGroup) consumePreInjected(instanceID int, account *accountpool.Account) bool {
+	g.warmMu.Lock()
+	defer g.warmMu.Unlock()
+
+	preInjected, exists := g.preInjected[instanceID]
+	delete(g.preInjected, instanceID)
+	return exists && preInjected == account
+}
+
+// releaseWarmed releases every warmed account no bot took, e.g. when the run stops
+func (g *BotGroup) releaseWarmed() {
+	g.warmMu.Lock()
+	released := make([]*warmAccount, 0, len(g.warmed))
+	for instanceID, warm := range g.warmed {
+		delete(g.warmed, instanceID)
+		if !warm.taken {
+			released = append(released, warm)
+		}
+	}
+	g.warmMu.Unlock()
+
+	g.releaseWarmAccounts(released)
+}
+
+// releaseWarmAccounts returns warmed accounts to the group's pool once their injection is done
+func (g *BotGroup) releaseWarmAccounts(warmed []*warmAccount) {
+	if len(warmed) == 0 || g.AccountPool == nil {
+		return
+	}
+
+	accounts := make([]*accountpool.Account, 0, len(warmed))
+	for _, warm := range warmed {
+		<-warm.ready
+		accounts = append(accounts, warm.account)
+		fmt.Printf("[BotGroup '%s'] Released warmed account '%s'\n", g.Name, warm.account.ID)
+	}
+	g.AccountPool.ReleaseReservation(accounts)
+}
+
+// warmedPool serves a bot on a warm instance the account pre-injected there before asking the
+// group's pool. All other operations are passed through.
+type warmedPool struct {
+	accountpool.AccountPool
+	group *BotGroup
+}
+
+// GetNextForInstance implements accountpool.InstanceAccountPool.GetNextForInstance
+func (p *warmedPool) GetNextForInstance(ctx context.Context, instanceID int) (*accountpool.Account, error) {
+	if account := p.group.takeWarmed(instanceID); account != nil {
+		return account, nil
+	}
+	return accountpool.GetNextForInstance(ctx, p.AccountPool, instanceID)
+}
+
+// Sandboxed reports whether the underlying pool is a sandbox pool (see accountpool.IsSandbox)
+func (p *warmedPool) Sandboxed() bool {
+	return accountpool.IsSandbox(p.AccountPool)
+}
+
+// MaxPacksPerAccount returns the underlying pool's pack ceiling per account
+func (p *warmedPool) MaxPacksPerAccount() int {
+	return accountpool.MaxPacksPerAccount(p.AccountPool)
+}
+
+// hasWarmPool reports whether the group has warm instances
+func (g *BotGroup) hasWarmPool() bool {
+	g.warmMu.Lock()
+	defer g.warmMu.Unlock()
+	return len(g.warmInstances) > 0
+}
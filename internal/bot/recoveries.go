@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/recovery"
+)
+
+// popupDismissDelay is how long a popup dismissal waits for the popup to close
+const popupDismissDelay = time.Second
+
+// Bots register the recoveries for the error types they know how to handle, so a ReportError
+// (or any step failing with a typed error) runs the same recovery in every routine:
+//   - communication: reconnect ADB
+//   - popup: press back to dismiss it
+//   - stuck, no_response, timeout, title_screen: restart the game
+//
+// Maintenance and update errors have no recovery and fail the routine.
+func init() {
+	recovery.Register(recovery.ErrorCommunication, botRecovery((*Bot).reconnectADB))
+	recovery.Register(recovery.ErrorPopup, botRecovery((*Bot).dismissPopup))
+	recovery.Register(recovery.ErrorStuck, botRecovery((*Bot).restartApp))
+	recovery.Register(recovery.ErrorNoResponse, botRecovery((*Bot).restartApp))
+	recovery.Register(recovery.ErrorTimeout, botRecovery((*Bot).restartApp))
+	recovery.Register(recovery.ErrorTitleScreen, botRecovery((*Bot).restartApp))
+}
+
+// botRecovery adapts a Bot method to a recovery.Func
+func botRecovery(fn func(*Bot) error) recovery.Func {
+	return func(rb recovery.Bot) error {
+		b, ok := rb.(*Bot)
+		if !ok {
+			return fmt.Errorf("recovery needs a *bot.Bot, got %T", rb)
+		}
+		return fn(b)
+	}
+}
+
+// dismissPopup presses back to close a popup covering the screen
+func (b *Bot) dismissPopup() error {
+	if b.adb == nil {
+		return fmt.Errorf("ADB not connected")
+	}
+	if err := b.adb.SendKey("KEYCODE_BACK"); err != nil {
+		return fmt.Errorf("failed to dismiss popup: %w", err)
+	}
+
+	select {
+	case <-b.ctx.Done():
+		return b.ctx.Err()
+	case <-time.After(popupDismissDelay):
+	}
+	return nil
+}
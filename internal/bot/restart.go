@@ -1,6 +1,13 @@
 package bot
 
-import "fmt"
+import (
+	"database/sql"
+	"fmt"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
 
 // Game restart logic
 
@@ -13,7 +20,7 @@ func (b *Bot) restartGameInstance(reason string) error {
 	// 3. Clearing app data if needed
 	// 4. Relaunching the app
 	// 5. Waiting for startup
-	fmt.Printf("Bot %d: Would restart game instance - Reason: %s\n", b.instance, reason)
+	b.Logf("Would restart game instance - Reason: %s\n", reason)
 	return nil
 }
 
@@ -37,3 +44,53 @@ func (b *Bot) handleNoAccounts(err error) error {
 	// 3. Waiting for new accounts
 	return fmt.Errorf("bot %d: no accounts available: %w", b.instance, err)
 }
+
+// HandleBannedAccount releases the current account as banned if a ban_detection sentry flagged it.
+// Returns nil when no ban was flagged; otherwise an error wrapping actions.ErrAccountBanned
+// that callers must return without restarting, so the banned account cannot loop.
+func (b *Bot) HandleBannedAccount(db *sql.DB, executionID int64) error {
+	if !actions.IsAccountBanned(b.variableStore) {
+		return nil
+	}
+	defer actions.ClearAccountBanned(b.variableStore)
+
+	account := b.currentAccount
+	if account == nil {
+		b.Logf("Ban detected but no account is assigned\n")
+		return fmt.Errorf("bot %d: %w", b.instance, actions.ErrAccountBanned)
+	}
+
+	// Take the account out of the pool for good
+	if provider, ok := b.manager.(interface{ AccountPool() accountpool.AccountPool }); ok {
+		if pool := provider.AccountPool(); pool != nil {
+			if err := pool.ReturnWithStatus(account, accountpool.AccountStatusBanned, "ban screen detected"); err != nil {
+				b.Logf("Warning - failed to mark account '%s' banned in pool: %v\n", account.ID, err)
+			}
+
+			// Sandbox accounts are never written to the database
+			if accountpool.IsSandbox(pool) {
+				db = nil
+			}
+		}
+	}
+
+	// Flag the account in the database and release its checkout
+	if db != nil && account.DeviceAccount != "" {
+		if err := database.MarkDeviceAccountBanned(db, account.DeviceAccount); err != nil {
+			b.Logf("Warning - failed to mark account banned in database: %v\n", err)
+		}
+		if err := database.ReleaseAccount(db, account.DeviceAccount, b.orchestrationID); err != nil {
+			b.Logf("Warning - failed to release account checkout: %v\n", err)
+		}
+		if executionID > 0 {
+			if err := database.FailRoutineExecution(db, executionID, actions.ErrAccountBanned.Error()); err != nil {
+				b.Logf("Warning - failed to mark routine as failed: %v\n", err)
+			}
+		}
+	}
+
+	b.ClearCurrentAccount()
+
+	b.Logf("Account '%s' marked banned - not restarting\n", account.ID)
+	return fmt.Errorf("bot %d account '%s': %w", b.instance, account.ID, actions.ErrAccountBanned)
+}
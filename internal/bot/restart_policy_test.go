@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRestartPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy BackoffStrategy
+		factor   float64
+		want     []time.Duration
+	}{
+		{"fixed", BackoffFixed, 0, []time.Duration{10, 10, 10, 10, 10, 10}},
+		{"linear", BackoffLinear, 0, []time.Duration{10, 20, 30, 40, 50, 60}},
+		{"exponential", BackoffExponential, 2, []time.Duration{10, 20, 40, 60, 60, 60}},
+		{"default is exponential", "", 2, []time.Duration{10, 20, 40, 60, 60, 60}},
+		{"fibonacci", BackoffFibonacci, 0, []time.Duration{10, 10, 20, 30, 50, 60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := RestartPolicy{
+				InitialDelay:  10 * time.Second,
+				MaxDelay:      60 * time.Second,
+				Strategy:      tt.strategy,
+				BackoffFactor: tt.factor,
+			}
+			for i, want := range tt.want {
+				if got := policy.Delay(i + 1); got != want*time.Second {
+					t.Errorf("retry %d: expected %v, got %v", i+1, want*time.Second, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRestartPolicyDelayCapsLargeRetries(t *testing.T) {
+	for _, strategy := range BackoffStrategies {
+		policy := RestartPolicy{
+			InitialDelay:  time.Second,
+			MaxDelay:      time.Minute,
+			Strategy:      strategy,
+			BackoffFactor: 2,
+		}
+		if got := policy.Delay(5000); got > time.Minute || got <= 0 {
+			t.Errorf("%s: expected delay capped at %v, got %v", strategy, time.Minute, got)
+		}
+	}
+}
+
+func TestValidateRestartPolicyStrategy(t *testing.T) {
+	policy := RestartPolicy{
+		Enabled:      true,
+		MaxRetries:   3,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+	}
+
+	// Exponential needs a factor above 1
+	policy.Strategy = BackoffExponential
+	policy.BackoffFactor = 1
+	if errs := validateRestartPolicy(policy, "RestartPolicy"); len(errs) != 1 {
+		t.Errorf("Expected 1 error for exponential with factor 1, got %d", len(errs))
+	}
+	policy.BackoffFactor = 1.5
+	if errs := validateRestartPolicy(policy, "RestartPolicy"); len(errs) != 0 {
+		t.Errorf("Expected no errors for exponential with factor 1.5, got %v", errs)
+	}
+
+	// Other strategies ignore the factor
+	policy.BackoffFactor = 0
+	for _, strategy := range []BackoffStrategy{BackoffFixed, BackoffLinear, BackoffFibonacci} {
+		policy.Strategy = strategy
+		if errs := validateRestartPolicy(policy, "RestartPolicy"); len(errs) != 0 {
+			t.Errorf("Expected no errors for %s, got %v", strategy, errs)
+		}
+	}
+
+	policy.Strategy = "random"
+	if errs := validateRestartPolicy(policy, "RestartPolicy"); len(errs) != 1 {
+		t.Errorf("Expected 1 error for unknown strategy, got %d", len(errs))
+	}
+}
+
+func TestValidateRestartPolicyMaxIterations(t *testing.T) {
+	policy := RestartPolicy{
+		Enabled:      true,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Strategy:     BackoffFixed,
+	}
+
+	for _, iterations := range []int{0, 1, 10} {
+		policy.MaxIterations = iterations
+		if errs := validateRestartPolicy(policy, "RestartPolicy"); len(errs) != 0 {
+			t.Errorf("Expected no errors for %d max iterations, got %v", iterations, errs)
+		}
+	}
+
+	policy.MaxIterations = -1
+	if errs := validateRestartPolicy(policy, "RestartPolicy"); len(errs) != 1 {
+		t.Errorf("Expected 1 error for negative max iterations, got %d", len(errs))
+	}
+}
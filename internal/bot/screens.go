@@ -145,6 +145,10 @@ func (b *Bot) DetectCurrentScreenWithConfidence() *ScreenDetectionResult {
 		}
 	}
 
+	if bestScreen != ScreenUnknown {
+		b.screenTracker.Record(bestScreen.String())
+	}
+
 	return &ScreenDetectionResult{
 		Screen:     bestScreen,
 		Confidence: bestConfidence,
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/actions"
+)
+
+// PendingStepError is the step error a bot in pause-on-error mode is paused on
+type PendingStepError struct {
+	Step     string
+	Err      error
+	PausedAt time.Time
+
+	decision actions.StepErrorDecision // Set by ResolveStepError before the bot is resumed
+}
+
+// SetPauseOnError turns pause-on-error mode on or off. In this mode a failed step pauses the
+// bot instead of failing the routine, so the emulator can be inspected before resuming,
+// skipping the step or aborting. Meant for developing routines, not for production runs.
+func (b *Bot) SetPauseOnError(enabled bool) {
+	b.pauseOnError.Store(enabled)
+}
+
+// PauseOnError reports whether the bot is in pause-on-error mode
+func (b *Bot) PauseOnError() bool {
+	return b.pauseOnError.Load()
+}
+
+// PendingStepError returns the step error the bot is paused on (nil if none)
+func (b *Bot) PendingStepError() *PendingStepError {
+	b.stepErrorMu.Lock()
+	defer b.stepErrorMu.Unlock()
+
+	if b.pendingStepError == nil {
+		return nil
+	}
+	pending := *b.pendingStepError
+	return &pending
+}
+
+// PauseForStepError pauses the bot on a failed step and blocks until ResolveStepError (or a
+// plain Resume, which runs the step again), a stop, or ctx ends. Called by the step executor.
+func (b *Bot) PauseForStepError(ctx context.Context, step string, err error) actions.StepErrorDecision {
+	if b.routineController == nil || !b.routineController.Pause() {
+		return actions.StepErrorAbort
+	}
+
+	pending := &PendingStepError{Step: step, Err: err, PausedAt: time.Now(), decision: actions.StepErrorResume}
+	b.stepErrorMu.Lock()
+	b.pendingStepError = pending
+	b.stepErrorMu.Unlock()
+
+	defer func() {
+		b.stepErrorMu.Lock()
+		b.pendingStepError = nil
+		b.stepErrorMu.Unlock()
+	}()
+
+	b.Logf("Paused on error in step '%s': %v (resume, skip or abort)\n", step, err)
+
+	select {
+	case <-b.routineController.ResumeChan():
+	case <-b.routineController.StopChan():
+		return actions.StepErrorAbort
+	case <-ctx.Done():
+		return actions.StepErrorAbort
+	}
+
+	b.stepErrorMu.Lock()
+	decision := pending.decision
+	b.stepErrorMu.Unlock()
+
+	b.Logf("Step '%s' error resolved: %s\n", step, decision)
+	return decision
+}
+
+// ResolveStepError resumes a bot paused on a step error: StepErrorResume runs the step again,
+// StepErrorSkip continues after it and StepErrorAbort fails the routine with the error
+func (b *Bot) ResolveStepError(decision actions.StepErrorDecision) error {
+	b.stepErrorMu.Lock()
+	pending := b.pendingStepError
+	if pending != nil {
+		pending.decision = decision
+	}
+	b.stepErrorMu.Unlock()
+
+	if pending == nil {
+		return fmt.Errorf("bot %d is not paused on a step error", b.instance)
+	}
+
+	b.routineController.Resume()
+	return nil
+}
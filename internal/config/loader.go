@@ -2,12 +2,17 @@ package config
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/ini.v1"
 	"jordanella.com/pocket-tcg-go/internal/bot"
 )
 
+// defaultNotifyEvents are the events notified on when notifyEvents is not set
+const defaultNotifyEvents = "pack.god_pack,group.completed,bot.circuit_breaker_tripped,account.banned"
+
 // LoadFromINI loads configuration from Settings.ini file
 func LoadFromINI(path string, instance int) (*bot.Config, error) {
 	cfg, err := ini.Load(path)
@@ -39,6 +44,9 @@ func LoadFromINI(path string, instance int) (*bot.Config, error) {
 	config.InjectSortMethod = parseSortMethod(section.Key("injectSortMethod").MustString("ModifiedAsc"))
 	config.InjectMinPacks = section.Key("injectMinPacks").MustInt(0)
 	config.InjectMaxPacks = section.Key("injectMaxPacks").MustInt(39)
+	config.SyncSaveCounts = section.Key("syncSaveCounts").MustBool(false)
+	config.SavePackCountKey = section.Key("savePackCountKey").String()
+	config.SaveHourglassKey = section.Key("saveHourglassKey").String()
 
 	// Account waiting
 	config.WaitForEligibleAccounts = section.Key("waitForEligibleAccounts").MustBool(true)
@@ -164,6 +172,83 @@ func LoadFromINI(path string, instance int) (*bot.Config, error) {
 	config.SlowMotion = section.Key("slowMotion").MustBool(false)
 	config.WaitTime = section.Key("waitTime").MustInt(5)
 
+	// Humanization
+	config.HumanizeClickRadius = section.Key("humanizeClickRadius").MustInt(0)
+	config.HumanizeDelayJitter = section.Key("humanizeDelayJitter").MustInt(0)
+	config.HumanizeSeed = section.Key("humanizeSeed").MustInt64(0)
+
+	// Maintenance detection
+	config.MaintenanceTemplate = section.Key("maintenanceTemplate").MustString("")
+	config.MaintenanceCheckInterval = section.Key("maintenanceCheckInterval").MustInt(30)
+	config.MaintenanceRecheckDelay = section.Key("maintenanceRecheckDelay").MustInt(120)
+
+	// Template image cache
+	config.TemplateCacheMaxMB = section.Key("templateCacheMaxMB").MustInt(256)
+
+	// Heavy CV operation limit
+	config.MaxConcurrentCV = section.Key("maxConcurrentCV").MustInt(0)
+
+	// Failure screenshots
+	config.FailureScreenshots = section.Key("failureScreenshots").MustBool(true)
+	config.FailureScreenshotDir = section.Key("failureScreenshotDir").MustString("diagnostics")
+	config.FailureScreenshotMax = section.Key("failureScreenshotMax").MustInt(20)
+
+	// Saved screenshot format
+	config.ScreenshotFormat = section.Key("screenshotFormat").MustString("png")
+	config.ScreenshotJPEGQuality = section.Key("screenshotJPEGQuality").MustInt(85)
+	config.ScreenshotPNGCompression = section.Key("screenshotPNGCompression").MustString("default")
+
+	// Stall detection
+	config.StallWindowSeconds = section.Key("stallWindowSeconds").MustInt(180)
+	config.StallThresholdPercent = section.Key("stallThresholdPercent").MustFloat64(1.0)
+
+	// ADB input throttling
+	config.ADBInputIntervalMs = section.Key("adbInputIntervalMs").MustInt(50)
+
+	// Metrics endpoint
+	config.MetricsEnabled = section.Key("metricsEnabled").MustBool(false)
+	config.MetricsPort = section.Key("metricsPort").MustInt(9464)
+
+	// Control API
+	config.APIEnabled = section.Key("apiEnabled").MustBool(false)
+	config.APIPort = section.Key("apiPort").MustInt(8765)
+	config.APIKey = section.Key("apiKey").String()
+
+	// Notifications
+	config.NotifyWebhookURL = section.Key("notifyWebhookURL").MustString("")
+	config.NotifyUserID = section.Key("notifyUserId").MustString("")
+	config.NotifyEvents = section.Key("notifyEvents").MustString(defaultNotifyEvents)
+	config.NotifyMaxPerMinute = section.Key("notifyMaxPerMinute").MustInt(10)
+
+	// God pack preservation
+	config.GodPackPreserve = section.Key("godPackPreserve").MustBool(true)
+	config.GodPackArchiveDir = section.Key("godPackArchiveDir").MustString("god_packs")
+
+	// Extracted account data
+	config.ExtractDir = section.Key("extractDir").MustString(".")
+	config.ExtractNaming = section.Key("extractNaming").MustString("instance_{instance}")
+
+	// Pool health score
+	config.PoolHealthWeightAvailability = section.Key("poolHealthWeightAvailability").MustFloat64(0.4)
+	config.PoolHealthWeightPacks = section.Key("poolHealthWeightPacks").MustFloat64(0.2)
+	config.PoolHealthWeightReliability = section.Key("poolHealthWeightReliability").MustFloat64(0.25)
+	config.PoolHealthWeightRested = section.Key("poolHealthWeightRested").MustFloat64(0.15)
+	config.PoolHealthPackTarget = section.Key("poolHealthPackTarget").MustInt(20)
+	config.PoolHealthRestHours = section.Key("poolHealthRestHours").MustInt(12)
+
+	// Default pool
+	config.DefaultPool = section.Key("defaultPool").MustString("")
+
+	// Pool exhaustion
+	config.PoolExhaustedWaitMinutes = section.Key("poolExhaustedWaitMinutes").MustInt(5)
+
+	// Pool statistics history
+	config.PoolStatsSampleSeconds = section.Key("poolStatsSampleSeconds").MustInt(60)
+
+	// Game
+	config.GamePackage = section.Key("gamePackage").MustString(bot.DefaultGamePackage)
+	config.TemplateLanguage = section.Key("templateLanguage").MustString("")
+
 	// Display
 	config.ShowStatus = section.Key("showStatus").MustBool(true)
 
@@ -183,9 +268,80 @@ func LoadFromINI(path string, instance int) (*bot.Config, error) {
 		config.DeadCheck = instanceSection.Key("DeadCheck").MustBool(false)
 	}
 
+	// Per-instance ADB input intervals, so each bot throttles by its own instance's setting
+	config.InstanceADBInputIntervalMs = loadInstanceIntervals(cfg)
+
+	// Per-instance ADB paths ([instance:N] adb_path=...) for setups with several MuMu installations
+	config.InstanceADBPaths = loadInstanceSettings(cfg, "adb_path")
+
+	// Per-instance proxies ([instance:N] proxy=host:port) so instances don't share one IP
+	config.InstanceProxies = loadInstanceSettings(cfg, "proxy")
+
 	return config, nil
 }
 
+// instanceSectionPrefix prefixes the per-instance override sections ([instance:N])
+const instanceSectionPrefix = "instance:"
+
+// loadInstanceSettings reads a key of every [instance:N] section, by instance
+func loadInstanceSettings(cfg *ini.File, key string) map[int]string {
+	values := make(map[int]string)
+	for _, section := range cfg.Sections() {
+		name := section.Name()
+		if !strings.HasPrefix(name, instanceSectionPrefix) {
+			continue
+		}
+		instance, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(name, instanceSectionPrefix)))
+		if err != nil {
+			continue
+		}
+		if value := section.Key(key).MustString(""); value != "" {
+			values[instance] = value
+		}
+	}
+	return values
+}
+
+// legacyInstanceSectionPrefix prefixes the older per-instance sections ([InstanceN])
+const legacyInstanceSectionPrefix = "Instance"
+
+// loadInstanceIntervals reads the ADB input interval of every [InstanceN] (ADBInputIntervalMs)
+// and [instance:N] (adb_input_interval_ms) section, by instance. [instance:N] wins if both are set.
+func loadInstanceIntervals(cfg *ini.File) map[int]int {
+	intervals := make(map[int]int)
+	for _, section := range cfg.Sections() {
+		name := section.Name()
+		if !strings.HasPrefix(name, legacyInstanceSectionPrefix) {
+			continue
+		}
+		instance, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(name, legacyInstanceSectionPrefix)))
+		if err != nil {
+			continue
+		}
+		if ms := section.Key("ADBInputIntervalMs").MustInt(0); ms > 0 {
+			intervals[instance] = ms
+		}
+	}
+	for instance, value := range loadInstanceSettings(cfg, "adb_input_interval_ms") {
+		if ms, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && ms > 0 {
+			intervals[instance] = ms
+		}
+	}
+	return intervals
+}
+
+// saveInstanceSettings writes a key to the [instance:N] section of every instance in values
+func saveInstanceSettings(cfg *ini.File, key string, values map[int]string) {
+	instances := make([]int, 0, len(values))
+	for instance := range values {
+		instances = append(instances, instance)
+	}
+	sort.Ints(instances)
+	for _, instance := range instances {
+		cfg.Section(fmt.Sprintf("%s%d", instanceSectionPrefix, instance)).Key(key).SetValue(values[instance])
+	}
+}
+
 func parseDeleteMethod(s string) bot.DeleteMethod {
 	switch s {
 	case "Create Bots (13P)":
@@ -275,11 +431,57 @@ func NewDefaultConfig() *bot.Config {
 		FolderPath:       "C:\\Program Files\\Netease\\MuMuPlayer-12.0",
 		DefaultLanguage:  "Scale125",
 		ADBPath:          "",
+		InstanceADBPaths: make(map[int]string),
+		InstanceProxies:  make(map[int]string),
+
+		InstanceADBInputIntervalMs: make(map[int]int),
+
 		MuMuWindowWidth:  540,
 		MuMuWindowHeight: 960,
 		LogLevel:         "INFO",
 		LoggingEnabled:   true,
 		VerboseLogging:   false,
+
+		FailureScreenshots:   true,
+		FailureScreenshotDir: "diagnostics",
+		FailureScreenshotMax: 20,
+
+		ScreenshotFormat:         "png",
+		ScreenshotJPEGQuality:    85,
+		ScreenshotPNGCompression: "default",
+
+		StallWindowSeconds:    180,
+		StallThresholdPercent: 1.0,
+
+		ADBInputIntervalMs: 50,
+
+		MetricsEnabled: false,
+		MetricsPort:    9464,
+
+		APIEnabled: false,
+		APIPort:    8765,
+
+		NotifyEvents:       defaultNotifyEvents,
+		NotifyMaxPerMinute: 10,
+
+		GodPackPreserve:   true,
+		GodPackArchiveDir: "god_packs",
+
+		ExtractDir:    ".",
+		ExtractNaming: "instance_{instance}",
+
+		PoolHealthWeightAvailability: 0.4,
+		PoolHealthWeightPacks:        0.2,
+		PoolHealthWeightReliability:  0.25,
+		PoolHealthWeightRested:       0.15,
+		PoolHealthPackTarget:         20,
+		PoolHealthRestHours:          12,
+
+		PoolExhaustedWaitMinutes: 5,
+
+		PoolStatsSampleSeconds: 60,
+
+		GamePackage: bot.DefaultGamePackage,
 	}
 }
 
@@ -302,6 +504,9 @@ func SaveToINI(config *bot.Config, path string) error {
 	section.Key("injectSortMethod").SetValue(config.InjectSortMethod.String())
 	section.Key("injectMinPacks").SetValue(fmt.Sprintf("%d", config.InjectMinPacks))
 	section.Key("injectMaxPacks").SetValue(fmt.Sprintf("%d", config.InjectMaxPacks))
+	section.Key("syncSaveCounts").SetValue(fmt.Sprintf("%t", config.SyncSaveCounts))
+	section.Key("savePackCountKey").SetValue(config.SavePackCountKey)
+	section.Key("saveHourglassKey").SetValue(config.SaveHourglassKey)
 
 	// Account waiting
 	section.Key("waitForEligibleAccounts").SetValue(fmt.Sprintf("%t", config.WaitForEligibleAccounts))
@@ -412,6 +617,83 @@ func SaveToINI(config *bot.Config, path string) error {
 	section.Key("slowMotion").SetValue(fmt.Sprintf("%t", config.SlowMotion))
 	section.Key("waitTime").SetValue(fmt.Sprintf("%d", config.WaitTime))
 
+	// Humanization
+	section.Key("humanizeClickRadius").SetValue(fmt.Sprintf("%d", config.HumanizeClickRadius))
+	section.Key("humanizeDelayJitter").SetValue(fmt.Sprintf("%d", config.HumanizeDelayJitter))
+	section.Key("humanizeSeed").SetValue(fmt.Sprintf("%d", config.HumanizeSeed))
+
+	// Maintenance detection
+	section.Key("maintenanceTemplate").SetValue(config.MaintenanceTemplate)
+	section.Key("maintenanceCheckInterval").SetValue(fmt.Sprintf("%d", config.MaintenanceCheckInterval))
+	section.Key("maintenanceRecheckDelay").SetValue(fmt.Sprintf("%d", config.MaintenanceRecheckDelay))
+
+	// Template image cache
+	section.Key("templateCacheMaxMB").SetValue(fmt.Sprintf("%d", config.TemplateCacheMaxMB))
+
+	// Heavy CV operation limit
+	section.Key("maxConcurrentCV").SetValue(fmt.Sprintf("%d", config.MaxConcurrentCV))
+
+	// Failure screenshots
+	section.Key("failureScreenshots").SetValue(fmt.Sprintf("%t", config.FailureScreenshots))
+	section.Key("failureScreenshotDir").SetValue(config.FailureScreenshotDir)
+	section.Key("failureScreenshotMax").SetValue(fmt.Sprintf("%d", config.FailureScreenshotMax))
+
+	// Saved screenshot format
+	section.Key("screenshotFormat").SetValue(config.ScreenshotFormat)
+	section.Key("screenshotJPEGQuality").SetValue(fmt.Sprintf("%d", config.ScreenshotJPEGQuality))
+	section.Key("screenshotPNGCompression").SetValue(config.ScreenshotPNGCompression)
+
+	// Stall detection
+	section.Key("stallWindowSeconds").SetValue(fmt.Sprintf("%d", config.StallWindowSeconds))
+	section.Key("stallThresholdPercent").SetValue(fmt.Sprintf("%g", config.StallThresholdPercent))
+
+	// ADB input throttling
+	section.Key("adbInputIntervalMs").SetValue(fmt.Sprintf("%d", config.ADBInputIntervalMs))
+
+	// Metrics endpoint
+	section.Key("metricsEnabled").SetValue(fmt.Sprintf("%t", config.MetricsEnabled))
+	section.Key("metricsPort").SetValue(fmt.Sprintf("%d", config.MetricsPort))
+
+	// Control API
+	section.Key("apiEnabled").SetValue(fmt.Sprintf("%t", config.APIEnabled))
+	section.Key("apiPort").SetValue(fmt.Sprintf("%d", config.APIPort))
+	section.Key("apiKey").SetValue(config.APIKey)
+
+	// Notifications
+	section.Key("notifyWebhookURL").SetValue(config.NotifyWebhookURL)
+	section.Key("notifyUserId").SetValue(config.NotifyUserID)
+	section.Key("notifyEvents").SetValue(config.NotifyEvents)
+	section.Key("notifyMaxPerMinute").SetValue(fmt.Sprintf("%d", config.NotifyMaxPerMinute))
+
+	// God pack preservation
+	section.Key("godPackPreserve").SetValue(fmt.Sprintf("%t", config.GodPackPreserve))
+	section.Key("godPackArchiveDir").SetValue(config.GodPackArchiveDir)
+
+	// Extracted account data
+	section.Key("extractDir").SetValue(config.ExtractDir)
+	section.Key("extractNaming").SetValue(config.ExtractNaming)
+
+	// Pool health score
+	section.Key("poolHealthWeightAvailability").SetValue(fmt.Sprintf("%g", config.PoolHealthWeightAvailability))
+	section.Key("poolHealthWeightPacks").SetValue(fmt.Sprintf("%g", config.PoolHealthWeightPacks))
+	section.Key("poolHealthWeightReliability").SetValue(fmt.Sprintf("%g", config.PoolHealthWeightReliability))
+	section.Key("poolHealthWeightRested").SetValue(fmt.Sprintf("%g", config.PoolHealthWeightRested))
+	section.Key("poolHealthPackTarget").SetValue(fmt.Sprintf("%d", config.PoolHealthPackTarget))
+	section.Key("poolHealthRestHours").SetValue(fmt.Sprintf("%d", config.PoolHealthRestHours))
+
+	// Default pool
+	section.Key("defaultPool").SetValue(config.DefaultPool)
+
+	// Pool exhaustion
+	section.Key("poolExhaustedWaitMinutes").SetValue(fmt.Sprintf("%d", config.PoolExhaustedWaitMinutes))
+
+	// Pool statistics history
+	section.Key("poolStatsSampleSeconds").SetValue(fmt.Sprintf("%d", config.PoolStatsSampleSeconds))
+
+	// Game
+	section.Key("gamePackage").SetValue(config.GamePackage)
+	section.Key("templateLanguage").SetValue(config.TemplateLanguage)
+
 	// Display
 	section.Key("showStatus").SetValue(fmt.Sprintf("%t", config.ShowStatus))
 
@@ -428,6 +710,15 @@ func SaveToINI(config *bot.Config, path string) error {
 	// Save instance-specific settings
 	instanceSection := cfg.Section(fmt.Sprintf("Instance%d", config.Instance))
 	instanceSection.Key("DeadCheck").SetValue(fmt.Sprintf("%t", config.DeadCheck))
+	for instance, ms := range config.InstanceADBInputIntervalMs {
+		if ms > 0 {
+			cfg.Section(fmt.Sprintf("%s%d", legacyInstanceSectionPrefix, instance)).Key("ADBInputIntervalMs").SetValue(fmt.Sprintf("%d", ms))
+		}
+	}
+
+	// Save per-instance ADB paths and proxies
+	saveInstanceSettings(cfg, "adb_path", config.InstanceADBPaths)
+	saveInstanceSettings(cfg, "proxy", config.InstanceProxies)
 
 	return cfg.SaveTo(path)
 }
@@ -0,0 +1,51 @@
+package coordinator
+
+import (
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/accounts"
+	"jordanella.com/pocket-tcg-go/internal/bot"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// AccountVerifier checks a bot's game loaded the injected account, comparing the device
+// account and the username and friend code stored for it in the shared accounts database
+type AccountVerifier struct {
+	db *database.DB
+}
+
+// NewAccountVerifier creates a verifier reading stored identities from the shared accounts
+// database (nil only checks the device account)
+func NewAccountVerifier(db *database.DB) *AccountVerifier {
+	return &AccountVerifier{db: db}
+}
+
+// Verify checks the account is loaded on the bot's instance (a bot.AccountVerifier)
+func (v *AccountVerifier) Verify(b *bot.Bot, account *accountpool.Account) (bool, error) {
+	adbPath, port, err := b.ADBTarget()
+	if err != nil {
+		return false, err
+	}
+
+	return accounts.VerifyLoadedAccount(adbPath, port, b.GamePackage(), v.expected(account))
+}
+
+// expected returns the identity the account is stored with. Accounts that haven't been
+// through onboarding yet have no username or friend code, so only their device account is checked.
+func (v *AccountVerifier) expected(account *accountpool.Account) accounts.AccountData {
+	expected := accounts.AccountData{DeviceAccount: account.DeviceAccount}
+	if v.db == nil {
+		return expected
+	}
+
+	stored, err := v.db.GetAccountByDeviceAccount(account.DeviceAccount)
+	if err != nil {
+		return expected
+	}
+	if stored.Username != nil {
+		expected.Username = *stored.Username
+	}
+	if stored.FriendCode != nil {
+		expected.FriendCode = *stored.FriendCode
+	}
+	return expected
+}
@@ -2,10 +2,12 @@ package coordinator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"jordanella.com/pocket-tcg-go/internal/actions"
 	"jordanella.com/pocket-tcg-go/internal/bot"
 )
 
@@ -34,6 +36,7 @@ type BotExecution struct {
 	Cancel    context.CancelFunc
 	StartTime time.Time
 	Status    string
+	Result    *actions.RoutineResult // Outcome of the routine (nil until it finishes, or without one)
 }
 
 // NewBotCoordinator creates a new bot coordinator
@@ -113,7 +116,12 @@ func (c *BotCoordinator) executeBot(request *BotRequest) {
 
 	// Execute routine if specified
 	if request.RoutineName != "" {
-		if err := c.executeRoutine(request); err != nil {
+		result, err := c.executeRoutine(request)
+		execution.Result = result
+		if errors.Is(err, actions.ErrAccountBanned) {
+			fmt.Printf("Bot %d: Routine '%s' stopped: %v\n", request.Instance, request.RoutineName, err)
+			execution.Status = "banned"
+		} else if err != nil {
 			fmt.Printf("Error: Bot %d routine '%s' failed: %v\n", request.Instance, request.RoutineName, err)
 			execution.Status = fmt.Sprintf("error: %v", err)
 		} else {
@@ -161,25 +169,34 @@ func (c *BotCoordinator) injectAccount(request *BotRequest) error {
 	return nil
 }
 
-// executeRoutine executes a specific routine on the bot
-func (c *BotCoordinator) executeRoutine(request *BotRequest) error {
+// executeRoutine executes a specific routine on the bot and returns how it went
+func (c *BotCoordinator) executeRoutine(request *BotRequest) (*actions.RoutineResult, error) {
 	// Track the routine name for restart capability
 	request.Bot.SetLastRoutine(request.RoutineName)
 
 	// Get routine from bot's registry
 	routineBuilder, err := request.Bot.Routines().Get(request.RoutineName)
 	if err != nil {
-		return fmt.Errorf("failed to get routine: %w", err)
+		return nil, fmt.Errorf("failed to get routine: %w", err)
 	}
 
 	// Execute routine
-	if err := routineBuilder.Execute(request.Bot); err != nil {
-		return fmt.Errorf("routine execution failed: %w", err)
+	executor := actions.NewRoutineExecutor(routineBuilder, nil).WithName(request.RoutineName)
+	result, err := executor.ExecuteWithResult(request.Bot)
+	request.Bot.SetLastRoutineResult(result)
+
+	// A banned account is released and never retried
+	if banErr := request.Bot.HandleBannedAccount(nil, 0); banErr != nil {
+		return result, banErr
 	}
 
-	fmt.Printf("Bot %d: Successfully completed routine '%s'\n", request.Instance, request.RoutineName)
+	if err != nil {
+		return result, fmt.Errorf("routine execution failed: %w", err)
+	}
 
-	return nil
+	fmt.Printf("Bot %d: Routine '%s' %s\n", request.Instance, request.RoutineName, result.Summary())
+
+	return result, nil
 }
 
 // StopBot stops a specific bot instance
@@ -253,3 +270,30 @@ func (c *BotCoordinator) GetActiveBots() []int {
 
 	return instances
 }
+
+// WaitForBotStopped waits until a bot instance has no active execution
+func (c *BotCoordinator) WaitForBotStopped(instance int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		c.mu.RLock()
+		_, running := c.activeBots[instance]
+		c.mu.RUnlock()
+
+		if !running {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("bot instance %d still running after %v", instance, timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// StartRoutine submits a request to run a routine on an existing bot (implements bot.RoutineStarter)
+func (c *BotCoordinator) StartRoutine(b *bot.Bot, routineName string) error {
+	return c.SubmitBotRequest(&BotRequest{
+		Instance:    b.Instance(),
+		RoutineName: routineName,
+		Bot:         b,
+	})
+}
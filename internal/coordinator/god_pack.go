@@ -0,0 +1,129 @@
+package coordinator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/accounts"
+	"jordanella.com/pocket-tcg-go/internal/bot"
+)
+
+// DefaultGodPackArchiveDir is where preserved god pack accounts are archived
+const DefaultGodPackArchiveDir = "god_packs"
+
+// GodPackPreserver keeps god pack accounts from being reused or overwritten:
+// the account is marked preserved in its pool, excluded from every pool definition,
+// its app data is extracted from the device, and its XML is archived in the background.
+type GodPackPreserver struct {
+	config      *bot.Config
+	poolManager *accountpool.PoolManager
+	archiveDir  string
+
+	wg sync.WaitGroup
+}
+
+// NewGodPackPreserver creates a preserver archiving to config.GodPackArchiveDir
+func NewGodPackPreserver(config *bot.Config, poolManager *accountpool.PoolManager) *GodPackPreserver {
+	archiveDir := config.GodPackArchiveDir
+	if archiveDir == "" {
+		archiveDir = DefaultGodPackArchiveDir
+	}
+
+	return &GodPackPreserver{
+		config:      config,
+		poolManager: poolManager,
+		archiveDir:  archiveDir,
+	}
+}
+
+// Preserve takes the account out of rotation and archives it (a bot.GodPackHook).
+// The app data is extracted before Preserve returns, so the routine can't move the device on
+// to another account first; only the XML export runs in the background.
+func (p *GodPackPreserver) Preserve(info bot.GodPackInfo) {
+	account := info.Account
+	if account == nil {
+		return
+	}
+
+	if info.Pool != nil {
+		if err := info.Pool.ReturnWithStatus(account, accountpool.AccountStatusPreserved, "god pack"); err != nil {
+			fmt.Printf("[GodPack] Warning - failed to mark account '%s' preserved: %v\n", account.ID, err)
+		}
+	}
+
+	if p.poolManager != nil && account.DeviceAccount != "" {
+		if _, err := p.poolManager.ExcludeAccount(account.DeviceAccount); err != nil {
+			fmt.Printf("[GodPack] Warning - failed to exclude account '%s' from pools: %v\n", account.ID, err)
+		}
+	}
+
+	dir := filepath.Join(p.archiveDir, archiveName(account, time.Now()))
+	p.extractAppData(info, dir)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.archiveXML(account, dir)
+	}()
+}
+
+// Wait blocks until all started archives have finished
+func (p *GodPackPreserver) Wait() {
+	p.wg.Wait()
+}
+
+// extractAppData extracts the app data of the account loaded on the bot's device into dir
+func (p *GodPackPreserver) extractAppData(info bot.GodPackInfo, dir string) {
+	account := info.Account
+	start := time.Now()
+
+	if info.Bot == nil {
+		fmt.Printf("[GodPack] No bot for account '%s' - app data not extracted\n", account.ID)
+		return
+	}
+
+	adbPath, port, err := info.Bot.ADBTarget()
+	if err != nil {
+		fmt.Printf("[GodPack] Failed to extract app data for account '%s': %v\n", account.ID, err)
+		return
+	}
+
+	if err := accounts.ExtractAppData(adbPath, port, info.Bot.GamePackage(), filepath.Join(dir, "app_data")); err != nil {
+		fmt.Printf("[GodPack] Failed to extract app data for account '%s': %v\n", account.ID, err)
+		return
+	}
+
+	fmt.Printf("[GodPack] Extracted app data for account '%s' to %s in %v\n", account.ID, dir, time.Since(start).Round(time.Second))
+}
+
+// archiveXML copies the account XML into dir
+func (p *GodPackPreserver) archiveXML(account *accountpool.Account, dir string) {
+	if p.poolManager == nil || account.DeviceAccount == "" {
+		return
+	}
+
+	if err := p.poolManager.ExportAccountXML(account.DeviceAccount, dir); err != nil {
+		fmt.Printf("[GodPack] Warning - failed to archive XML for account '%s': %v\n", account.ID, err)
+		return
+	}
+	fmt.Printf("[GodPack] Archived account '%s' to %s\n", account.ID, dir)
+}
+
+// archiveName names an account's archive folder, e.g. "1234abcd_20250101-120000"
+func archiveName(account *accountpool.Account, at time.Time) string {
+	name := account.DeviceAccount
+	if name == "" {
+		name = account.ID
+	}
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?* `, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	return name + "_" + at.Format("20060102-150405")
+}
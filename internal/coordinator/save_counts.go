@@ -0,0 +1,87 @@
+package coordinator
+
+import (
+	"fmt"
+	"os"
+
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/accounts"
+	"jordanella.com/pocket-tcg-go/internal/bot"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// SaveCountSyncer reads the pack and hourglass counts from the game's save once a bot has
+// loaded an account, and stores them on the account and its row in the shared accounts
+// database, correcting any drift in counts tallied from pack-open events
+type SaveCountSyncer struct {
+	config *bot.Config
+	db     *database.DB
+}
+
+// NewSaveCountSyncer creates a syncer writing to the shared accounts database
+func NewSaveCountSyncer(config *bot.Config, db *database.DB) *SaveCountSyncer {
+	return &SaveCountSyncer{
+		config: config,
+		db:     db,
+	}
+}
+
+// Sync syncs the counts of the account the bot loaded (a bot.AccountLoadedHook)
+func (s *SaveCountSyncer) Sync(info bot.AccountLoadedInfo) {
+	counts, err := s.SyncAccount(info.Bot, info.Account, accountpool.IsSandbox(info.Pool))
+	if err != nil {
+		fmt.Printf("Bot %d: Warning - failed to sync pack count from save: %v\n", info.Bot.Instance(), err)
+		return
+	}
+	fmt.Printf("Bot %d: Synced counts from save: %d packs opened\n", info.Bot.Instance(), counts.PacksOpened)
+}
+
+// SyncAccount reads the counts from the save on the bot's instance and stores them on the
+// account and, unless it comes from a sandbox pool, its database row. The account has to be
+// loaded on the device.
+func (s *SaveCountSyncer) SyncAccount(b *bot.Bot, account *accountpool.Account, sandbox bool) (*accounts.SaveCounts, error) {
+	if account == nil {
+		return nil, fmt.Errorf("no account injected")
+	}
+
+	adbPath, port, err := b.ADBTarget()
+	if err != nil {
+		return nil, err
+	}
+
+	appDataDir, err := os.MkdirTemp("", "pokemontcg_save")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(appDataDir)
+
+	if err := accounts.ExtractAppData(adbPath, port, b.GamePackage(), appDataDir); err != nil {
+		return nil, fmt.Errorf("failed to extract save: %w", err)
+	}
+	counts, err := accounts.ReadSaveCountsWithKeys(appDataDir, accounts.SaveCountKeys{
+		PackCount:   s.config.SavePackCountKey,
+		Hourglasses: s.config.SaveHourglassKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.PackCount = counts.PacksOpened
+
+	// Accounts without a device account can't be matched to a database row
+	if s.db == nil || sandbox || account.DeviceAccount == "" {
+		return counts, nil
+	}
+	dbAccount, err := s.db.GetAccountByDeviceAccount(account.DeviceAccount)
+	if err != nil {
+		return counts, fmt.Errorf("failed to find account %s: %w", account.DeviceAccount, err)
+	}
+
+	hourglasses := dbAccount.Hourglasses
+	if counts.HasHourglasses {
+		hourglasses = counts.Hourglasses
+	}
+	if err := s.db.SyncAccountSaveCounts(dbAccount.ID, counts.PacksOpened, hourglasses); err != nil {
+		return counts, fmt.Errorf("failed to update account %s: %w", account.DeviceAccount, err)
+	}
+	return counts, nil
+}
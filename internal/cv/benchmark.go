@@ -0,0 +1,183 @@
+package cv
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BenchmarkOptions configures a template matching benchmark
+type BenchmarkOptions struct {
+	Frame       *image.RGBA
+	Templates   map[string]*image.RGBA // Matched against the frame in turn
+	Config      *MatchConfig           // nil uses DefaultMatchConfig
+	Concurrency []int                  // Levels to measure (default: 1, 2, 4, ... up to one per CPU)
+	Duration    time.Duration          // Time spent at each level (default: 5s)
+	Iterations  int                    // If set, stop each level after this many matches instead
+}
+
+// BenchmarkLevel is the throughput and latency measured at one concurrency level
+type BenchmarkLevel struct {
+	Concurrency   int
+	Matches       int
+	Elapsed       time.Duration
+	MatchesPerSec float64
+	P50           time.Duration
+	P90           time.Duration
+	P99           time.Duration
+	Max           time.Duration
+}
+
+// BenchmarkResult holds the measurements of every concurrency level, in the order run
+type BenchmarkResult struct {
+	Levels []BenchmarkLevel
+}
+
+// Benchmark runs FindTemplate in a loop at each concurrency level and measures matches/sec
+// and match latency. Matches bypass the shared concurrency limit so the levels measure the
+// machine rather than the current setting.
+func Benchmark(opts BenchmarkOptions) (*BenchmarkResult, error) {
+	if opts.Frame == nil {
+		return nil, fmt.Errorf("no frame to match against")
+	}
+	if len(opts.Templates) == 0 {
+		return nil, fmt.Errorf("no templates to match")
+	}
+	config := opts.Config
+	if config == nil {
+		config = DefaultMatchConfig()
+	}
+	levels := opts.Concurrency
+	if len(levels) == 0 {
+		levels = defaultBenchmarkLevels()
+	}
+	duration := opts.Duration
+	if duration <= 0 {
+		duration = 5 * time.Second
+	}
+
+	// Sort the templates so every run matches them in the same order
+	names := make([]string, 0, len(opts.Templates))
+	for name := range opts.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	templates := make([]*image.RGBA, len(names))
+	for i, name := range names {
+		templates[i] = opts.Templates[name]
+	}
+
+	result := &BenchmarkResult{Levels: make([]BenchmarkLevel, 0, len(levels))}
+	for _, concurrency := range levels {
+		if concurrency < 1 {
+			return nil, fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+		}
+		result.Levels = append(result.Levels, benchmarkLevel(opts.Frame, templates, config, concurrency, duration, opts.Iterations))
+	}
+	return result, nil
+}
+
+// benchmarkLevel runs matches on concurrency workers until the duration passes (or the
+// iteration budget is used up) and summarizes their latencies
+func benchmarkLevel(frame *image.RGBA, templates []*image.RGBA, config *MatchConfig, concurrency int, duration time.Duration, iterations int) BenchmarkLevel {
+	var next atomic.Int64
+	latencies := make([][]time.Duration, concurrency)
+	deadline := time.Now().Add(duration)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				n := next.Add(1) - 1
+				if iterations > 0 {
+					if n >= int64(iterations) {
+						return
+					}
+				} else if time.Now().After(deadline) {
+					return
+				}
+
+				matchStart := time.Now()
+				FindTemplate(frame, templates[n%int64(len(templates))], config)
+				latencies[w] = append(latencies[w], time.Since(matchStart))
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	all := make([]time.Duration, 0)
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	level := BenchmarkLevel{
+		Concurrency: concurrency,
+		Matches:     len(all),
+		Elapsed:     elapsed,
+		P50:         percentile(all, 0.50),
+		P90:         percentile(all, 0.90),
+		P99:         percentile(all, 0.99),
+		Max:         percentile(all, 1),
+	}
+	if elapsed > 0 {
+		level.MatchesPerSec = float64(level.Matches) / elapsed.Seconds()
+	}
+	return level
+}
+
+// percentile returns the p-th (0-1) latency of sorted, nearest-rank
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// SuggestedConcurrency returns the lowest measured concurrency that reaches 95% of the best
+// throughput, a concurrency limit that gets nearly all of the machine's matching capacity
+// without adding queueing latency. It returns 0 when nothing was measured.
+func (r *BenchmarkResult) SuggestedConcurrency() int {
+	best := 0.0
+	for _, level := range r.Levels {
+		if level.MatchesPerSec > best {
+			best = level.MatchesPerSec
+		}
+	}
+	if best == 0 {
+		return 0
+	}
+
+	suggested := 0
+	for _, level := range r.Levels {
+		if level.MatchesPerSec >= best*0.95 && (suggested == 0 || level.Concurrency < suggested) {
+			suggested = level.Concurrency
+		}
+	}
+	return suggested
+}
+
+// defaultBenchmarkLevels returns 1, 2, 4, ... up to and including the CPU count
+func defaultBenchmarkLevels() []int {
+	cpus := runtime.NumCPU()
+	levels := make([]int, 0)
+	for n := 1; n < cpus; n *= 2 {
+		levels = append(levels, n)
+	}
+	return append(levels, cpus)
+}
@@ -0,0 +1,79 @@
+package cv
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestBenchmarkMeasuresEachLevel(t *testing.T) {
+	frame := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			frame.SetRGBA(x, y, color.RGBA{uint8(x * 4), uint8(y * 4), 0, 255})
+		}
+	}
+	templates := map[string]*image.RGBA{
+		"corner": CropRegion(frame, image.Rect(0, 0, 8, 8)),
+		"middle": CropRegion(frame, image.Rect(30, 30, 38, 38)),
+	}
+
+	result, err := Benchmark(BenchmarkOptions{
+		Frame:       frame,
+		Templates:   templates,
+		Concurrency: []int{1, 2},
+		Iterations:  20,
+	})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if len(result.Levels) != 2 {
+		t.Fatalf("got %d levels, want 2", len(result.Levels))
+	}
+	for _, level := range result.Levels {
+		if level.Matches != 20 {
+			t.Errorf("concurrency %d: got %d matches, want 20", level.Concurrency, level.Matches)
+		}
+		if level.MatchesPerSec <= 0 {
+			t.Errorf("concurrency %d: matches/sec = %v, want > 0", level.Concurrency, level.MatchesPerSec)
+		}
+		if level.P50 > level.P90 || level.P90 > level.P99 || level.P99 > level.Max {
+			t.Errorf("concurrency %d: percentiles out of order: p50=%v p90=%v p99=%v max=%v",
+				level.Concurrency, level.P50, level.P90, level.P99, level.Max)
+		}
+	}
+	if suggested := result.SuggestedConcurrency(); suggested != 1 && suggested != 2 {
+		t.Errorf("SuggestedConcurrency() = %d, want a measured level", suggested)
+	}
+}
+
+func TestBenchmarkRejectsMissingInput(t *testing.T) {
+	frame := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	if _, err := Benchmark(BenchmarkOptions{Frame: frame}); err == nil {
+		t.Error("expected an error without templates")
+	}
+	if _, err := Benchmark(BenchmarkOptions{
+		Frame:       frame,
+		Templates:   map[string]*image.RGBA{"t": frame},
+		Concurrency: []int{0},
+		Duration:    time.Millisecond,
+	}); err == nil {
+		t.Error("expected an error for concurrency 0")
+	}
+}
+
+func TestSuggestedConcurrency(t *testing.T) {
+	result := &BenchmarkResult{Levels: []BenchmarkLevel{
+		{Concurrency: 1, MatchesPerSec: 100},
+		{Concurrency: 2, MatchesPerSec: 190},
+		{Concurrency: 4, MatchesPerSec: 370},
+		{Concurrency: 8, MatchesPerSec: 380},
+	}}
+	if got := result.SuggestedConcurrency(); got != 4 {
+		t.Errorf("SuggestedConcurrency() = %d, want 4", got)
+	}
+	if got := (&BenchmarkResult{}).SuggestedConcurrency(); got != 0 {
+		t.Errorf("SuggestedConcurrency() of an empty result = %d, want 0", got)
+	}
+}
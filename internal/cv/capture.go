@@ -1,8 +1,6 @@
 package cv
 
-import (
-	"image"
-)
+import "image"
 
 // Capturer interface for different capture methods
 type Capturer interface {
@@ -36,3 +34,8 @@ func DefaultCaptureConfig() *CaptureConfig {
 		MaxCacheDuration: 100, // 100ms cache for rapid template checks
 	}
 }
+
+// SavePNG saves an image to a PNG file
+func SavePNG(img image.Image, path string) error {
+	return SaveImage(img, path, SaveOptions{Format: ImageFormatPNG})
+}
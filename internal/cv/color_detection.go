@@ -0,0 +1,202 @@
+package cv
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinBlobPixels is the smallest blob DetectColorRegion reports; smaller
+// blobs are treated as stray pixels (anti-aliasing, compression noise)
+const DefaultMinBlobPixels = 6
+
+// ColorBlob is a contiguous group of pixels matching a target color
+type ColorBlob struct {
+	Bounds     image.Rectangle // Bounding box of the blob
+	PixelCount int             // Number of matching pixels in the blob
+}
+
+// Center returns the center point of the blob's bounding box
+func (b ColorBlob) Center() image.Point {
+	return image.Point{
+		X: (b.Bounds.Min.X + b.Bounds.Max.X) / 2,
+		Y: (b.Bounds.Min.Y + b.Bounds.Max.Y) / 2,
+	}
+}
+
+// DetectColorRegion finds the largest contiguous region of pixels within tolerance of
+// target inside region (an empty region searches the whole frame). Blobs smaller than
+// DefaultMinBlobPixels are ignored. Tolerance is the mean per-channel difference (0-255).
+func DetectColorRegion(frame image.Image, target color.Color, tolerance int, region image.Rectangle) (found bool, bbox image.Rectangle, pixelCount int) {
+	return DetectColorRegionWithMinSize(frame, target, tolerance, region, DefaultMinBlobPixels)
+}
+
+// DetectColorRegionWithMinSize is DetectColorRegion with a custom minimum blob size
+func DetectColorRegionWithMinSize(frame image.Image, target color.Color, tolerance int, region image.Rectangle, minPixels int) (found bool, bbox image.Rectangle, pixelCount int) {
+	blobs := FindColorBlobs(frame, target, tolerance, region, minPixels)
+	if len(blobs) == 0 {
+		return false, image.Rectangle{}, 0
+	}
+	return true, blobs[0].Bounds, blobs[0].PixelCount
+}
+
+// FindColorBlobs returns all 4-connected blobs of at least minPixels matching pixels,
+// largest first
+func FindColorBlobs(frame image.Image, target color.Color, tolerance int, region image.Rectangle, minPixels int) []ColorBlob {
+	if frame == nil {
+		return nil
+	}
+	bounds := frame.Bounds()
+	if region.Empty() {
+		region = bounds
+	} else {
+		region = region.Intersect(bounds)
+	}
+	if region.Empty() {
+		return nil
+	}
+	if minPixels < 1 {
+		minPixels = 1
+	}
+
+	tr, tg, tb := toRGB8(target)
+	width, height := region.Dx(), region.Dy()
+
+	// Build the match mask once, then flood fill it
+	mask := make([]bool, width*height)
+	matchAt := pixelMatcher(frame, tr, tg, tb, tolerance)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mask[y*width+x] = matchAt(region.Min.X+x, region.Min.Y+y)
+		}
+	}
+
+	var blobs []ColorBlob
+	stack := make([]int, 0, 64)
+	for start := range mask {
+		if !mask[start] {
+			continue
+		}
+
+		mask[start] = false
+		stack = append(stack[:0], start)
+		minX, minY := width, height
+		maxX, maxY := -1, -1
+		count := 0
+
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			x, y := i%width, i/width
+			count++
+
+			if x < minX {
+				minX = x
+			}
+			if x > maxX {
+				maxX = x
+			}
+			if y < minY {
+				minY = y
+			}
+			if y > maxY {
+				maxY = y
+			}
+
+			if x > 0 && mask[i-1] {
+				mask[i-1] = false
+				stack = append(stack, i-1)
+			}
+			if x < width-1 && mask[i+1] {
+				mask[i+1] = false
+				stack = append(stack, i+1)
+			}
+			if y > 0 && mask[i-width] {
+				mask[i-width] = false
+				stack = append(stack, i-width)
+			}
+			if y < height-1 && mask[i+width] {
+				mask[i+width] = false
+				stack = append(stack, i+width)
+			}
+		}
+
+		if count < minPixels {
+			continue
+		}
+		blobs = append(blobs, ColorBlob{
+			Bounds:     image.Rect(region.Min.X+minX, region.Min.Y+minY, region.Min.X+maxX+1, region.Min.Y+maxY+1),
+			PixelCount: count,
+		})
+	}
+
+	sort.SliceStable(blobs, func(i, j int) bool {
+		return blobs[i].PixelCount > blobs[j].PixelCount
+	})
+	return blobs
+}
+
+// pixelMatcher returns a function reporting whether the pixel at (x, y) is within
+// tolerance of the target color, using direct Pix access for RGBA frames
+func pixelMatcher(frame image.Image, tr, tg, tb uint8, tolerance int) func(x, y int) bool {
+	if rgba, ok := frame.(*image.RGBA); ok {
+		return func(x, y int) bool {
+			i := rgba.PixOffset(x, y)
+			return int(colorDistance(rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2], tr, tg, tb)) <= tolerance
+		}
+	}
+	return func(x, y int) bool {
+		r, g, b := toRGB8(frame.At(x, y))
+		return int(colorDistance(r, g, b, tr, tg, tb)) <= tolerance
+	}
+}
+
+// toRGB8 converts a color to 8-bit RGB components
+func toRGB8(c color.Color) (uint8, uint8, uint8) {
+	r, g, b, _ := c.RGBA()
+	return uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)
+}
+
+// ParseHexColor parses "#RRGGBB" or "RRGGBB" into a color
+func ParseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color '%s': expected #RRGGBB", s)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color '%s': %w", s, err)
+	}
+	return color.RGBA{
+		R: uint8(value >> 16),
+		G: uint8(value >> 8),
+		B: uint8(value),
+		A: 255,
+	}, nil
+}
+
+// DetectColorRegion captures a fresh frame and finds the largest blob of the target color.
+// A nil region searches the whole frame below the title bar.
+func (s *Service) DetectColorRegion(target color.Color, tolerance int, region *image.Rectangle, minPixels int) (*ColorBlob, error) {
+	frame, err := s.CaptureFrame(false)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &MatchConfig{SearchRegion: region}
+	s.applyTitleBarExclusion(config, frame.Bounds())
+
+	searchArea := image.Rectangle{}
+	if config.SearchRegion != nil {
+		searchArea = *config.SearchRegion
+	}
+
+	blobs := FindColorBlobs(frame, target, tolerance, searchArea, minPixels)
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	return &blobs[0], nil
+}
@@ -0,0 +1,67 @@
+package cv
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+func TestDetectColorRegionFindsDominantBlob(t *testing.T) {
+	red := color.RGBA{R: 230, G: 30, B: 40, A: 255}
+	frame := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	fillRect(frame, frame.Bounds(), color.RGBA{R: 240, G: 240, B: 240, A: 255})
+	fillRect(frame, image.Rect(10, 10, 14, 14), red) // 16 px
+	fillRect(frame, image.Rect(60, 50, 70, 58), red) // 80 px
+	frame.SetRGBA(90, 90, red)                        // stray pixel
+
+	found, bbox, count := DetectColorRegion(frame, color.RGBA{R: 220, G: 40, B: 40, A: 255}, 20, image.Rectangle{})
+	if !found {
+		t.Fatal("expected the red blob to be found")
+	}
+	if bbox != image.Rect(60, 50, 70, 58) || count != 80 {
+		t.Errorf("expected the dominant blob (60,50)-(70,58) with 80 px, got %v with %d px", bbox, count)
+	}
+
+	// Restricting the region picks the smaller blob
+	found, bbox, _ = DetectColorRegion(frame, red, 20, image.Rect(0, 0, 40, 40))
+	if !found || bbox != image.Rect(10, 10, 14, 14) {
+		t.Errorf("expected the blob inside the region, got found=%v bbox=%v", found, bbox)
+	}
+}
+
+func TestDetectColorRegionIgnoresSmallBlobs(t *testing.T) {
+	red := color.RGBA{R: 230, G: 30, B: 40, A: 255}
+	frame := image.NewRGBA(image.Rect(0, 0, 50, 50))
+	fillRect(frame, image.Rect(5, 5, 7, 7), red) // 4 px
+
+	if found, _, _ := DetectColorRegion(frame, red, 10, image.Rectangle{}); found {
+		t.Error("a blob smaller than the minimum size should be ignored")
+	}
+	if found, _, count := DetectColorRegionWithMinSize(frame, red, 10, image.Rectangle{}, 4); !found || count != 4 {
+		t.Errorf("expected the blob with a lower minimum size, got found=%v count=%d", found, count)
+	}
+	if found, _, _ := DetectColorRegionWithMinSize(frame, color.RGBA{B: 255, A: 255}, 10, image.Rectangle{}, 1); found {
+		t.Error("no blue pixels should match")
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#E53935")
+	if err != nil {
+		t.Fatalf("ParseHexColor failed: %v", err)
+	}
+	if c != (color.RGBA{R: 0xE5, G: 0x39, B: 0x35, A: 255}) {
+		t.Errorf("unexpected color %v", c)
+	}
+	if _, err := ParseHexColor("red"); err == nil {
+		t.Error("expected an error for a non-hex color")
+	}
+}
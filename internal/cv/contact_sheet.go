@@ -0,0 +1,111 @@
+package cv
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultContactSheetCellWidth is the width frames are scaled to when none is given
+const DefaultContactSheetCellWidth = 240
+
+const (
+	contactSheetPadding     = 6
+	contactSheetLabelHeight = 18
+)
+
+var (
+	contactSheetBackground  = color.RGBA{32, 32, 32, 255}
+	contactSheetPlaceholder = color.RGBA{96, 40, 40, 255}
+	contactSheetText        = color.RGBA{235, 235, 235, 255}
+)
+
+// ContactSheetCell is one instance's frame on a contact sheet
+type ContactSheetCell struct {
+	Label string      // Shown above the frame, e.g. "Instance 3"
+	Frame image.Image // nil when the capture failed; a placeholder is drawn instead
+	Err   error       // Why the capture failed, shown on the placeholder
+}
+
+// BuildContactSheet scales each cell's frame down to cellWidth (0 = DefaultContactSheetCellWidth)
+// and tiles them into one image, columns per row (0 = a roughly square grid), with each cell's
+// label above it. Cells keep the aspect ratio of the first captured frame.
+func BuildContactSheet(cells []ContactSheetCell, cellWidth, columns int) *image.RGBA {
+	if cellWidth <= 0 {
+		cellWidth = DefaultContactSheetCellWidth
+	}
+	if columns <= 0 {
+		columns = int(math.Ceil(math.Sqrt(float64(len(cells)))))
+	}
+	if columns > len(cells) {
+		columns = len(cells)
+	}
+	if columns < 1 {
+		columns = 1
+	}
+	rows := (len(cells) + columns - 1) / columns
+
+	// Emulator frames are portrait (e.g. 540x960) unless a capture says otherwise
+	cellHeight := cellWidth * 16 / 9
+	for _, cell := range cells {
+		if cell.Frame != nil && cell.Frame.Bounds().Dx() > 0 {
+			bounds := cell.Frame.Bounds()
+			cellHeight = cellWidth * bounds.Dy() / bounds.Dx()
+			break
+		}
+	}
+
+	slotWidth := cellWidth + contactSheetPadding
+	slotHeight := contactSheetLabelHeight + cellHeight + contactSheetPadding
+	sheet := image.NewRGBA(image.Rect(0, 0,
+		columns*slotWidth+contactSheetPadding, rows*slotHeight+contactSheetPadding))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(contactSheetBackground), image.Point{}, draw.Src)
+
+	for i, cell := range cells {
+		x := contactSheetPadding + (i%columns)*slotWidth
+		y := contactSheetPadding + (i/columns)*slotHeight
+
+		drawContactSheetText(sheet, cell.Label, x, y+contactSheetLabelHeight-5, cellWidth)
+
+		frameRect := image.Rect(x, y+contactSheetLabelHeight, x+cellWidth, y+contactSheetLabelHeight+cellHeight)
+		if cell.Frame != nil {
+			xdraw.ApproxBiLinear.Scale(sheet, frameRect, cell.Frame, cell.Frame.Bounds(), xdraw.Src, nil)
+			continue
+		}
+
+		draw.Draw(sheet, frameRect, image.NewUniform(contactSheetPlaceholder), image.Point{}, draw.Src)
+		textY := frameRect.Min.Y + cellHeight/2
+		drawContactSheetText(sheet, "Capture failed", x+4, textY, cellWidth-8)
+		if cell.Err != nil {
+			drawContactSheetText(sheet, cell.Err.Error(), x+4, textY+16, cellWidth-8)
+		}
+	}
+
+	return sheet
+}
+
+// drawContactSheetText draws one line of text with its baseline at y, cut to fit maxWidth pixels
+func drawContactSheetText(dst draw.Image, text string, x, y, maxWidth int) {
+	face := basicfont.Face7x13
+	maxChars := maxWidth / face.Advance
+	if maxChars <= 0 {
+		return
+	}
+	if runes := []rune(text); len(runes) > maxChars {
+		text = string(runes[:maxChars-1]) + "…"
+	}
+
+	drawer := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(contactSheetText),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
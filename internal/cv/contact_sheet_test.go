@@ -0,0 +1,50 @@
+package cv
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestBuildContactSheet(t *testing.T) {
+	frame := image.NewRGBA(image.Rect(0, 0, 540, 960))
+	green := color.RGBA{0, 200, 0, 255}
+	draw.Draw(frame, frame.Bounds(), image.NewUniform(green), image.Point{}, draw.Src)
+
+	cells := []ContactSheetCell{
+		{Label: "Instance 1", Frame: frame},
+		{Label: "Instance 2", Err: errors.New("window not found")},
+		{Label: "Instance 3", Frame: frame},
+	}
+	sheet := BuildContactSheet(cells, 108, 0)
+
+	// 3 cells make a 2x2 grid of 108x192 frames
+	slotWidth := 108 + contactSheetPadding
+	slotHeight := contactSheetLabelHeight + 192 + contactSheetPadding
+	wantWidth := 2*slotWidth + contactSheetPadding
+	wantHeight := 2*slotHeight + contactSheetPadding
+	if sheet.Bounds().Dx() != wantWidth || sheet.Bounds().Dy() != wantHeight {
+		t.Fatalf("Expected a %dx%d sheet, got %v", wantWidth, wantHeight, sheet.Bounds())
+	}
+
+	// Centre of each cell's frame area (clear of the label and placeholder text)
+	cellCentre := func(i int) color.RGBA {
+		x := contactSheetPadding + (i%2)*slotWidth + 108/2
+		y := contactSheetPadding + (i/2)*slotHeight + contactSheetLabelHeight + 20
+		return sheet.RGBAAt(x, y)
+	}
+	if got := cellCentre(0); got != green {
+		t.Errorf("Expected the captured frame in cell 1, got %v", got)
+	}
+	if got := cellCentre(1); got != contactSheetPlaceholder {
+		t.Errorf("Expected a placeholder in cell 2, got %v", got)
+	}
+	if got := cellCentre(2); got != green {
+		t.Errorf("Expected the captured frame in cell 3, got %v", got)
+	}
+	if got := cellCentre(3); got != contactSheetBackground {
+		t.Errorf("Expected the unused 4th cell to be background, got %v", got)
+	}
+}
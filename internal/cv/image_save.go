@@ -0,0 +1,106 @@
+package cv
+
+import (
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageFormat is the file format SaveImage writes
+type ImageFormat string
+
+const (
+	ImageFormatPNG  ImageFormat = "png"  // Lossless; large but keeps exact pixels (e.g. for templates)
+	ImageFormatJPEG ImageFormat = "jpeg" // Lossy; much smaller, suited to high-volume diagnostic captures
+)
+
+// DefaultJPEGQuality is the JPEG quality used when none is configured
+const DefaultJPEGQuality = 85
+
+// SaveOptions controls how SaveImage encodes an image
+type SaveOptions struct {
+	Format         ImageFormat          // Empty = inferred from the path's extension (PNG if unknown)
+	JPEGQuality    int                  // 1-100 (0 = DefaultJPEGQuality)
+	PNGCompression png.CompressionLevel // Zero value is png.DefaultCompression
+}
+
+// ParseImageFormat parses a configured format name ("png", "jpeg" or "jpg"; empty = PNG)
+func ParseImageFormat(name string) (ImageFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "png":
+		return ImageFormatPNG, nil
+	case "jpeg", "jpg":
+		return ImageFormatJPEG, nil
+	}
+	return "", fmt.Errorf("unknown image format '%s' (use png or jpeg)", name)
+}
+
+// ParsePNGCompression parses a configured PNG compression level ("default", "fast", "best" or "none")
+func ParsePNGCompression(name string) (png.CompressionLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "default":
+		return png.DefaultCompression, nil
+	case "fast":
+		return png.BestSpeed, nil
+	case "best":
+		return png.BestCompression, nil
+	case "none":
+		return png.NoCompression, nil
+	}
+	return png.DefaultCompression, fmt.Errorf("unknown PNG compression '%s' (use default, fast, best or none)", name)
+}
+
+// Extension returns the file extension for the format, including the dot
+func (f ImageFormat) Extension() string {
+	if f == ImageFormatJPEG {
+		return ".jpg"
+	}
+	return ".png"
+}
+
+// formatForPath picks the format for a path: the explicit one, else the extension's
+func (o SaveOptions) formatForPath(path string) ImageFormat {
+	if o.Format != "" {
+		return o.Format
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		return ImageFormatJPEG
+	}
+	return ImageFormatPNG
+}
+
+// SaveImage saves an image to path as PNG or JPEG (see SaveOptions)
+func SaveImage(img image.Image, path string, opts SaveOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	switch opts.formatForPath(path) {
+	case ImageFormatJPEG:
+		quality := opts.JPEGQuality
+		if quality <= 0 {
+			quality = DefaultJPEGQuality
+		}
+		if quality > 100 {
+			quality = 100
+		}
+		if err := jpeg.Encode(file, img, &jpeg.Options{Quality: quality}); err != nil {
+			return fmt.Errorf("failed to encode JPEG: %w", err)
+		}
+
+	default:
+		encoder := png.Encoder{CompressionLevel: opts.PNGCompression}
+		if err := encoder.Encode(file, img); err != nil {
+			return fmt.Errorf("failed to encode PNG: %w", err)
+		}
+	}
+
+	return nil
+}
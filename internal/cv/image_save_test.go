@@ -0,0 +1,54 @@
+package cv
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveImageFormats(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 4), uint8(y * 4), 128, 255})
+		}
+	}
+	dir := t.TempDir()
+
+	cases := []struct {
+		name string
+		opts SaveOptions
+		want string // Format reported by image.DecodeConfig
+	}{
+		{"inferred.png", SaveOptions{}, "png"},
+		{"inferred.jpg", SaveOptions{JPEGQuality: 60}, "jpeg"},
+		{"explicit.img", SaveOptions{Format: ImageFormatJPEG}, "jpeg"},
+		{"best.png", SaveOptions{PNGCompression: png.BestCompression}, "png"},
+	}
+	for _, tc := range cases {
+		path := filepath.Join(dir, tc.name)
+		if err := SaveImage(img, path, tc.opts); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, format, err := image.DecodeConfig(file)
+		file.Close()
+		if err != nil || format != tc.want {
+			t.Errorf("%s: decoded as %q (%v), want %q", tc.name, format, err, tc.want)
+		}
+	}
+
+	if _, err := ParseImageFormat("gif"); err == nil {
+		t.Error("expected unknown format to be rejected")
+	}
+	if _, err := ParsePNGCompression("maximum"); err == nil {
+		t.Error("expected unknown PNG compression to be rejected")
+	}
+}
@@ -0,0 +1,102 @@
+package cv
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Frame captures and template matches are CPU heavy; with many bots running they are
+// limited across all services so bots queue briefly instead of thrashing the machine.
+
+// DefaultConcurrencyLimit is how many heavy operations may run at once when no limit is configured
+func DefaultConcurrencyLimit() int {
+	return runtime.NumCPU()
+}
+
+// ConcurrencyStats reports how the heavy operation limit is affecting bots
+type ConcurrencyStats struct {
+	Limit      int           // Operations allowed at once
+	InUse      int           // Operations running now
+	Operations int64         // Operations run since startup
+	Waits      int64         // Operations that had to wait for a slot
+	WaitTime   time.Duration // Total time spent waiting for slots
+}
+
+// opLimiter is a counting semaphore that records time spent waiting on it
+type opLimiter struct {
+	mu    sync.Mutex
+	limit int
+	slots chan struct{}
+
+	operations atomic.Int64
+	waits      atomic.Int64
+	waitNanos  atomic.Int64
+}
+
+var heavyOps = newOpLimiter(DefaultConcurrencyLimit())
+
+func newOpLimiter(limit int) *opLimiter {
+	l := &opLimiter{}
+	l.setLimit(limit)
+	return l
+}
+
+// SetConcurrencyLimit sets how many captures and template matches may run at once across
+// all bots (n <= 0 uses DefaultConcurrencyLimit). Operations already running keep their slots.
+func SetConcurrencyLimit(n int) {
+	heavyOps.setLimit(n)
+}
+
+// GetConcurrencyStats returns the heavy operation limit and how long bots have waited on it
+func GetConcurrencyStats() ConcurrencyStats {
+	return heavyOps.stats()
+}
+
+func (l *opLimiter) setLimit(n int) {
+	if n <= 0 {
+		n = DefaultConcurrencyLimit()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n == l.limit {
+		return
+	}
+	l.limit = n
+	l.slots = make(chan struct{}, n)
+}
+
+// acquire waits for a slot and returns the function that frees it
+func (l *opLimiter) acquire() (release func()) {
+	l.mu.Lock()
+	slots := l.slots
+	l.mu.Unlock()
+
+	l.operations.Add(1)
+	select {
+	case slots <- struct{}{}:
+	default:
+		start := time.Now()
+		slots <- struct{}{}
+		l.waits.Add(1)
+		l.waitNanos.Add(int64(time.Since(start)))
+	}
+
+	return func() { <-slots }
+}
+
+func (l *opLimiter) stats() ConcurrencyStats {
+	l.mu.Lock()
+	limit, inUse := l.limit, len(l.slots)
+	l.mu.Unlock()
+
+	return ConcurrencyStats{
+		Limit:      limit,
+		InUse:      inUse,
+		Operations: l.operations.Load(),
+		Waits:      l.waits.Load(),
+		WaitTime:   time.Duration(l.waitNanos.Load()),
+	}
+}
@@ -0,0 +1,53 @@
+package cv
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOpLimiterBoundsConcurrency(t *testing.T) {
+	limiter := newOpLimiter(2)
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := limiter.acquire()
+			defer release()
+
+			now := running.Add(1)
+			for {
+				old := peak.Load()
+				if now <= old || peak.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak.Load())
+	}
+
+	stats := limiter.stats()
+	if stats.Limit != 2 || stats.InUse != 0 || stats.Operations != 6 {
+		t.Errorf("stats = %+v, want limit 2, none in use, 6 operations", stats)
+	}
+	if stats.Waits == 0 || stats.WaitTime <= 0 {
+		t.Errorf("expected recorded waits, got %+v", stats)
+	}
+}
+
+func TestOpLimiterDefaultsToCPUCount(t *testing.T) {
+	limiter := newOpLimiter(0)
+	if got := limiter.stats().Limit; got != DefaultConcurrencyLimit() {
+		t.Errorf("limit = %d, want %d", got, DefaultConcurrencyLimit())
+	}
+}
@@ -0,0 +1,71 @@
+package cv
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ScreenSighting records the last screen template recognized on an instance
+type ScreenSighting struct {
+	Instance   int
+	ScreenName string
+	SeenAt     time.Time
+}
+
+// ScreenTracker remembers the last screen template that matched for one instance.
+// Updates happen on every successful match, so they are a single atomic store.
+type ScreenTracker struct {
+	instance int
+	last     atomic.Pointer[ScreenSighting]
+}
+
+// NewScreenTracker creates a tracker for an instance
+func NewScreenTracker(instance int) *ScreenTracker {
+	return &ScreenTracker{instance: instance}
+}
+
+// Record marks screenName as the most recently recognized screen
+func (t *ScreenTracker) Record(screenName string) {
+	if t == nil || screenName == "" {
+		return
+	}
+	t.last.Store(&ScreenSighting{
+		Instance:   t.instance,
+		ScreenName: screenName,
+		SeenAt:     time.Now(),
+	})
+}
+
+// Last returns the most recently recognized screen and when it was seen.
+// Returns "" and the zero time if nothing has matched yet.
+func (t *ScreenTracker) Last() (string, time.Time) {
+	if t == nil {
+		return "", time.Time{}
+	}
+	sighting := t.last.Load()
+	if sighting == nil {
+		return "", time.Time{}
+	}
+	return sighting.ScreenName, sighting.SeenAt
+}
+
+// Sighting returns a copy of the last sighting, or nil if nothing has matched yet
+func (t *ScreenTracker) Sighting() *ScreenSighting {
+	if t == nil {
+		return nil
+	}
+	sighting := t.last.Load()
+	if sighting == nil {
+		return nil
+	}
+	copied := *sighting
+	return &copied
+}
+
+// Reset forgets the last recognized screen
+func (t *ScreenTracker) Reset() {
+	if t == nil {
+		return
+	}
+	t.last.Store(nil)
+}
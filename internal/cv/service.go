@@ -36,6 +36,9 @@ type Service struct {
 	// Title bar exclusion
 	titleBarHeight int // Pixels to exclude from top of window
 
+	// Optional: records the last template that matched
+	screenTracker *ScreenTracker
+
 	mu sync.RWMutex
 }
 
@@ -77,6 +80,21 @@ func (s *Service) WithTemplateRegistry(registry TemplateRegistryInterface) *Serv
 	return s
 }
 
+// WithScreenTracker sets the tracker updated whenever a template matches
+func (s *Service) WithScreenTracker(tracker *ScreenTracker) *Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.screenTracker = tracker
+	return s
+}
+
+// ScreenTracker returns the screen tracker (nil if none was set)
+func (s *Service) ScreenTracker() *ScreenTracker {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.screenTracker
+}
+
 // SetTitleBarHeight updates the title bar exclusion height
 func (s *Service) SetTitleBarHeight(height int) {
 	s.mu.Lock()
@@ -105,7 +123,9 @@ func (s *Service) CaptureFrame(useCache bool) (*image.RGBA, error) {
 	}
 
 	// Capture new frame
+	release := heavyOps.acquire()
 	frame, err := s.capturer.CaptureFrame()
+	release()
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +173,12 @@ func (s *Service) FindTemplate(templateName string, config *MatchConfig) (*Match
 	// Apply title bar exclusion if not already set
 	s.applyTitleBarExclusion(config, frame.Bounds())
 
+	release := heavyOps.acquire()
 	result := FindTemplate(frame, template, config)
+	release()
+	if result.Found {
+		s.ScreenTracker().Record(templateName)
+	}
 	return result, nil
 }
 
@@ -171,7 +196,9 @@ func (s *Service) FindTemplateInFrame(frame *image.RGBA, templatePath string, co
 	// Apply title bar exclusion if not already set
 	s.applyTitleBarExclusion(config, frame.Bounds())
 
+	release := heavyOps.acquire()
 	result := FindTemplate(frame, template, config)
+	release()
 	return result, nil
 }
 
@@ -275,12 +302,9 @@ func (s *Service) loadTemplate(templateName string) (*image.RGBA, error) {
 		imageCache := registry.ImageCache()
 		if imageCache != nil {
 			// Try to get by template name (path might be the template name)
+			// Not cached locally - the shared cache owns the image and enforces its memory limit
 			img, _, err := imageCache.Get(templateName)
 			if err == nil {
-				// Cache it locally too for consistency
-				s.mu.Lock()
-				s.templateCache[templateName] = img
-				s.mu.Unlock()
 				return img, nil
 			}
 		}
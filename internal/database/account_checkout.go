@@ -84,6 +84,30 @@ func ReleaseAccount(db *sql.DB, deviceAccount string, orchestrationID string) er
 	return nil
 }
 
+// MarkDeviceAccountBanned flags an account as banned and inactive so pool queries skip it
+func MarkDeviceAccountBanned(db *sql.DB, deviceAccount string) error {
+	result, err := db.Exec(`
+		UPDATE accounts
+		SET is_banned = 1, is_active = 0
+		WHERE device_account = ?
+	`, deviceAccount)
+
+	if err != nil {
+		return fmt.Errorf("failed to mark account banned: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("account %s not found", deviceAccount)
+	}
+
+	return nil
+}
+
 // IsAccountCheckedOut checks if an account is currently checked out
 func IsAccountCheckedOut(db *sql.DB, deviceAccount string) (bool, string, int, error) {
 	var orchestrationID sql.NullString
@@ -0,0 +1,288 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RiskWeights controls how an account's risk score is computed. Weights are relative to each
+// other; a weight of 0 ignores that factor.
+type RiskWeights struct {
+	Failures float64 // Consecutive pool failures (failure_count), relative to FailureLimit
+	Errors   float64 // Logged errors within ErrorWindow, relative to ErrorLimit
+	Usage    float64 // Activities started within UsageWindow, relative to UsageLimit
+
+	FailureLimit int           // failure_count that earns the full failure risk
+	ErrorLimit   float64       // Weighted error count that earns the full error risk
+	ErrorWindow  time.Duration // How far back errors count
+	UsageLimit   int           // Activities per UsageWindow that earn the full usage risk
+	UsageWindow  time.Duration // How far back activities count
+
+	// ErrorTypeWeights is how much one error of each type counts towards ErrorLimit; types not
+	// listed count 1. Errors caused by the game or emulator rather than the account count less.
+	ErrorTypeWeights map[string]float64
+}
+
+// DefaultRiskWeights returns the weights used by AccountRiskScore
+func DefaultRiskWeights() RiskWeights {
+	return RiskWeights{
+		Failures:     0.4,
+		Errors:       0.35,
+		Usage:        0.25,
+		FailureLimit: 5,
+		ErrorLimit:   10,
+		ErrorWindow:  7 * 24 * time.Hour,
+		UsageLimit:   20,
+		UsageWindow:  24 * time.Hour,
+		ErrorTypeWeights: map[string]float64{
+			"communication": 0.5,
+			"timeout":       0.5,
+			"maintenance":   0,
+			"update":        0,
+		},
+	}
+}
+
+// AccountRiskScore estimates how likely an account is to get banned, from its failure history
+// and how heavily it's used, using DefaultRiskWeights. See ComputeAccountRisk.
+func (db *DB) AccountRiskScore(accountID string) (float64, []string, error) {
+	return ComputeAccountRisk(db.conn, accountID, DefaultRiskWeights())
+}
+
+// ComputeAccountRisk scores an account (by device account) from 0 (no risk) to 100, and lists
+// the factors that contributed. Each factor is a ratio between 0 and 1:
+//
+//	failures = min(failure_count / FailureLimit, 1)
+//	errors   = min(sum of ErrorTypeWeights of errors within ErrorWindow / ErrorLimit, 1)
+//	usage    = min(activities within UsageWindow / UsageLimit, 1)
+//
+// The score is the weighted average of the factors scaled to 0-100. Banned accounts score 100.
+func ComputeAccountRisk(conn *sql.DB, deviceAccount string, weights RiskWeights) (float64, []string, error) {
+	var id, failureCount int
+	var isBanned bool
+	err := conn.QueryRow(`
+		SELECT id, COALESCE(failure_count, 0), is_banned
+		FROM accounts
+		WHERE device_account = ?
+	`, deviceAccount).Scan(&id, &failureCount, &isBanned)
+	if err == sql.ErrNoRows {
+		return 0, nil, fmt.Errorf("account '%s' not found", deviceAccount)
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query account: %w", err)
+	}
+
+	if isBanned {
+		return 100, []string{"account is banned"}, nil
+	}
+
+	now := time.Now()
+	errorCounts, err := recentErrorCounts(conn, id, now.Add(-weights.ErrorWindow))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var recentActivities int
+	err = conn.QueryRow(`
+		SELECT COUNT(*) FROM activity_log
+		WHERE account_id = ? AND started_at >= ?
+	`, id, now.Add(-weights.UsageWindow)).Scan(&recentActivities)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to count recent activity: %w", err)
+	}
+
+	score, factors := scoreRisk(failureCount, errorCounts, recentActivities, weights)
+	return score, factors, nil
+}
+
+// AccountRisk is an account's risk score and the factors that contributed to it
+type AccountRisk struct {
+	Score   float64
+	Factors []string
+}
+
+// ComputeAccountRisks scores every account like ComputeAccountRisk, by device account, with
+// one grouped query per factor instead of three queries per account
+func ComputeAccountRisks(conn *sql.DB, weights RiskWeights) (map[string]AccountRisk, error) {
+	now := time.Now()
+
+	errorCounts := make(map[int]map[string]int)
+	rows, err := conn.Query(`
+		SELECT account_id, error_type, COUNT(*) FROM error_log
+		WHERE account_id IS NOT NULL AND occurred_at >= ?
+		GROUP BY account_id, error_type
+	`, now.Add(-weights.ErrorWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent errors: %w", err)
+	}
+	for rows.Next() {
+		var accountID, count int
+		var errorType string
+		if err := rows.Scan(&accountID, &errorType, &count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if errorCounts[accountID] == nil {
+			errorCounts[accountID] = make(map[string]int)
+		}
+		errorCounts[accountID][errorType] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	activityCounts := make(map[int]int)
+	rows, err = conn.Query(`
+		SELECT account_id, COUNT(*) FROM activity_log
+		WHERE account_id IS NOT NULL AND started_at >= ?
+		GROUP BY account_id
+	`, now.Add(-weights.UsageWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent activity: %w", err)
+	}
+	for rows.Next() {
+		var accountID, count int
+		if err := rows.Scan(&accountID, &count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		activityCounts[accountID] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = conn.Query(`SELECT id, device_account, COALESCE(failure_count, 0), is_banned FROM accounts`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	risks := make(map[string]AccountRisk)
+	for rows.Next() {
+		var id, failureCount int
+		var deviceAccount string
+		var isBanned bool
+		if err := rows.Scan(&id, &deviceAccount, &failureCount, &isBanned); err != nil {
+			return nil, err
+		}
+		if isBanned {
+			risks[deviceAccount] = AccountRisk{Score: 100, Factors: []string{"account is banned"}}
+			continue
+		}
+		score, factors := scoreRisk(failureCount, errorCounts[id], activityCounts[id], weights)
+		risks[deviceAccount] = AccountRisk{Score: score, Factors: factors}
+	}
+	return risks, rows.Err()
+}
+
+// scoreRisk combines an unbanned account's failures, recent errors by type and recent
+// activities into its risk score (see ComputeAccountRisk)
+func scoreRisk(failureCount int, errorCounts map[string]int, recentActivities int, weights RiskWeights) (float64, []string) {
+	factors := make([]string, 0, 3)
+	var weighted, totalWeight float64
+
+	totalWeight += weights.Failures
+	if ratio := riskRatio(float64(failureCount), float64(weights.FailureLimit)); ratio > 0 && weights.Failures > 0 {
+		weighted += weights.Failures * ratio
+		factors = append(factors, fmt.Sprintf("%d consecutive failures (limit %d)", failureCount, weights.FailureLimit))
+	}
+
+	totalWeight += weights.Errors
+	errorScore := 0.0
+	for errorType, count := range errorCounts {
+		errorWeight, listed := weights.ErrorTypeWeights[errorType]
+		if !listed {
+			errorWeight = 1
+		}
+		errorScore += errorWeight * float64(count)
+	}
+	if ratio := riskRatio(errorScore, weights.ErrorLimit); ratio > 0 && weights.Errors > 0 {
+		weighted += weights.Errors * ratio
+		factors = append(factors, fmt.Sprintf("errors in the last %s: %s", formatRiskWindow(weights.ErrorWindow), formatErrorCounts(errorCounts)))
+	}
+
+	totalWeight += weights.Usage
+	if ratio := riskRatio(float64(recentActivities), float64(weights.UsageLimit)); ratio > 0 && weights.Usage > 0 {
+		weighted += weights.Usage * ratio
+		factors = append(factors, fmt.Sprintf("%d runs in the last %s (limit %d)", recentActivities, formatRiskWindow(weights.UsageWindow), weights.UsageLimit))
+	}
+
+	if totalWeight <= 0 {
+		return 0, factors
+	}
+	return weighted / totalWeight * 100, factors
+}
+
+// recentErrorCounts counts an account's logged errors since a time, by error type
+func recentErrorCounts(conn *sql.DB, accountID int, since time.Time) (map[string]int, error) {
+	rows, err := conn.Query(`
+		SELECT error_type, COUNT(*) FROM error_log
+		WHERE account_id = ? AND occurred_at >= ?
+		GROUP BY error_type
+	`, accountID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent errors: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var errorType string
+		var count int
+		if err := rows.Scan(&errorType, &count); err != nil {
+			return nil, err
+		}
+		counts[errorType] = count
+	}
+	return counts, rows.Err()
+}
+
+// riskRatio returns value/limit capped at 1 (0 when there's no limit)
+func riskRatio(value, limit float64) float64 {
+	if limit <= 0 || value <= 0 {
+		return 0
+	}
+	if value >= limit {
+		return 1
+	}
+	return value / limit
+}
+
+// formatRiskWindow describes a window in whole days or hours where it can, e.g. "7 days"
+func formatRiskWindow(window time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case window > day && window%day == 0:
+		return fmt.Sprintf("%d days", window/day)
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%d hours", window/time.Hour)
+	default:
+		return window.String()
+	}
+}
+
+// formatErrorCounts lists error counts by type, most frequent first, e.g. "3 stuck, 1 popup"
+func formatErrorCounts(counts map[string]int) string {
+	types := make([]string, 0, len(counts))
+	for errorType := range counts {
+		types = append(types, errorType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if counts[types[i]] != counts[types[j]] {
+			return counts[types[i]] > counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+
+	parts := make([]string, len(types))
+	for i, errorType := range types {
+		parts[i] = fmt.Sprintf("%d %s", counts[errorType], errorType)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Account search for the accounts browser
+
+// Sort fields accepted by SearchAccounts
+const (
+	SortByID        = "id"
+	SortByUsername  = "username"
+	SortByPacks     = "packs"
+	SortByShinedust = "shinedust"
+	SortByStatus    = "status"
+	SortByLastUsed  = "last_used"
+)
+
+// accountSortColumns maps sort fields to SQL expressions (never interpolate user input directly)
+var accountSortColumns = map[string]string{
+	SortByID:        "id",
+	SortByUsername:  "COALESCE(username, device_account)",
+	SortByPacks:     "packs_opened",
+	SortByShinedust: "shinedust",
+	SortByStatus:    "(CASE WHEN is_banned = 1 THEN 2 WHEN is_active = 0 THEN 1 ELSE 0 END)",
+	SortByLastUsed:  "last_used_at",
+}
+
+// SortSpec describes how to order search results
+type SortSpec struct {
+	Field      string // One of the SortBy* constants (defaults to SortByID)
+	Descending bool
+}
+
+// AccountSearchResult is one page of search results
+type AccountSearchResult struct {
+	Accounts []*Account
+	Total    int // Total matching accounts across all pages
+	Page     int
+	PageSize int
+}
+
+// PageCount returns the number of pages needed for all matching accounts
+func (r *AccountSearchResult) PageCount() int {
+	if r.PageSize <= 0 || r.Total == 0 {
+		return 1
+	}
+	return (r.Total + r.PageSize - 1) / r.PageSize
+}
+
+// SearchAccounts returns a page (0-based) of accounts whose username, friend code or device account
+// contains query, or whose ID equals it. An empty query matches all accounts, including inactive and banned.
+func (db *DB) SearchAccounts(query string, sort SortSpec, page, size int) (*AccountSearchResult, error) {
+	if size <= 0 {
+		size = 50
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	orderBy, ok := accountSortColumns[sort.Field]
+	if !ok {
+		if sort.Field != "" {
+			return nil, fmt.Errorf("unknown sort field '%s'", sort.Field)
+		}
+		orderBy = accountSortColumns[SortByID]
+	}
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+
+	where := ""
+	var args []interface{}
+	if query = strings.TrimSpace(query); query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		where = `
+			WHERE username LIKE ? ESCAPE '\'
+				OR friend_code LIKE ? ESCAPE '\'
+				OR device_account LIKE ? ESCAPE '\'
+		`
+		args = append(args, pattern, pattern, pattern)
+
+		if id, err := strconv.Atoi(query); err == nil {
+			where += " OR id = ?"
+			args = append(args, id)
+		}
+	}
+
+	result := &AccountSearchResult{Page: page, PageSize: size}
+	if err := db.conn.QueryRow("SELECT COUNT(*) FROM accounts "+where, args...).Scan(&result.Total); err != nil {
+		return nil, fmt.Errorf("failed to count accounts: %w", err)
+	}
+
+	// Never-used accounts sort after used ones regardless of direction
+	rows, err := db.conn.Query(`
+		SELECT
+			id, device_account, device_password, username, friend_code,
+			shinedust, hourglasses, pokegold, pack_points,
+			packs_opened, wonder_picks_done, account_level,
+			created_at, last_used_at, stamina_recovery_time,
+			file_path, is_active, is_banned, notes,
+			COALESCE(pool_status, 'available'), COALESCE(failure_count, 0), last_error
+		FROM accounts
+	`+where+fmt.Sprintf(`
+		ORDER BY %s IS NULL, %s %s, id %s
+		LIMIT ? OFFSET ?
+	`, orderBy, orderBy, direction, direction), append(args, size, page*size)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search accounts: %w", err)
+	}
+	defer rows.Close()
+
+	result.Accounts = []*Account{}
+	for rows.Next() {
+		account := &Account{}
+		err := rows.Scan(
+			&account.ID, &account.DeviceAccount, &account.DevicePassword,
+			&account.Username, &account.FriendCode,
+			&account.Shinedust, &account.Hourglasses, &account.Pokegold, &account.PackPoints,
+			&account.PacksOpened, &account.WonderPicksDone, &account.AccountLevel,
+			&account.CreatedAt, &account.LastUsedAt, &account.StaminaRecoveryTime,
+			&account.FilePath, &account.IsActive, &account.IsBanned, &account.Notes,
+			&account.PoolStatus, &account.FailureCount, &account.LastError,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result.Accounts = append(result.Accounts, account)
+	}
+
+	return result, rows.Err()
+}
+
+// escapeLike escapes LIKE wildcards so the query matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Account state: key-value routine state kept per account across runs (e.g. the last day
+// a mission was claimed). Unlike persistent variables, which only live as long as the bot,
+// it is stored in the database.
+
+// GetAccountState returns an account's state value for a key (false if it has none)
+func (db *DB) GetAccountState(accountID int, key string) (string, bool, error) {
+	var value string
+	err := db.conn.QueryRow(`
+		SELECT value FROM account_state WHERE account_id = ? AND key = ?
+	`, accountID, key).Scan(&value)
+
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get account state '%s': %w", key, err)
+	}
+	return value, true, nil
+}
+
+// SetAccountState stores an account's state value for a key, replacing any previous value
+func (db *DB) SetAccountState(accountID int, key string, value string) error {
+	return db.SetAccountStates(accountID, map[string]string{key: value})
+}
+
+// SetAccountStates stores several state values of an account in one transaction
+func (db *DB) SetAccountStates(accountID int, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return db.ExecTx(func(tx *sql.Tx) error {
+		now := time.Now()
+		for key, value := range values {
+			_, err := tx.Exec(`
+				INSERT INTO account_state (account_id, key, value, updated_at)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT(account_id, key) DO UPDATE SET
+					value = excluded.value,
+					updated_at = excluded.updated_at
+			`, accountID, key, value, now)
+			if err != nil {
+				return fmt.Errorf("failed to set account state '%s': %w", key, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetAllAccountState returns all state values of an account by key
+func (db *DB) GetAllAccountState(accountID int) (map[string]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT key, value FROM account_state WHERE account_id = ?
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account state: %w", err)
+	}
+	defer rows.Close()
+
+	state := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		state[key] = value
+	}
+	return state, rows.Err()
+}
+
+// DeleteAccountState removes an account's state value for a key
+func (db *DB) DeleteAccountState(accountID int, key string) error {
+	_, err := db.conn.Exec(`DELETE FROM account_state WHERE account_id = ? AND key = ?`, accountID, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete account state '%s': %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// NormalizeTag trims and lower-cases a tag so "Farm A" and "farm a " are the same tag
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+	return tag, nil
+}
+
+// TagAccount adds a tag to an account (by device account). Tagging an account with a tag it
+// already has does nothing.
+func (db *DB) TagAccount(accountID string, tag string) error {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	return db.ExecTx(func(tx *sql.Tx) error {
+		tagged, err := tagAccountTx(tx, accountID, tag)
+		if err != nil {
+			return err
+		}
+		if !tagged {
+			return fmt.Errorf("account '%s' not found", accountID)
+		}
+		return nil
+	})
+}
+
+// UntagAccount removes a tag from an account (by device account). Removing a tag the account
+// doesn't have does nothing.
+func (db *DB) UntagAccount(accountID string, tag string) error {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
+		DELETE FROM account_tags
+		WHERE tag = ? AND account_id = (SELECT id FROM accounts WHERE device_account = ?)
+	`, tag, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to untag account: %w", err)
+	}
+	return nil
+}
+
+// BulkTagAccounts adds a tag to several accounts in one transaction, returning how many
+// accounts exist (and now carry the tag)
+func (db *DB) BulkTagAccounts(deviceAccounts []string, tag string) (int, error) {
+	return TagAccounts(db.conn, deviceAccounts, tag)
+}
+
+// TagAccounts is BulkTagAccounts for callers that only hold the connection
+func TagAccounts(conn *sql.DB, deviceAccounts []string, tag string) (int, error) {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	count := 0
+	for _, deviceAccount := range deviceAccounts {
+		tagged, err := tagAccountTx(tx, deviceAccount, tag)
+		if err != nil {
+			return 0, err
+		}
+		if tagged {
+			count++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// BulkUntagAccounts removes a tag from several accounts in one transaction, returning how many
+// accounts had it
+func (db *DB) BulkUntagAccounts(deviceAccounts []string, tag string) (int, error) {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = db.ExecTx(func(tx *sql.Tx) error {
+		for _, deviceAccount := range deviceAccounts {
+			result, err := tx.Exec(`
+				DELETE FROM account_tags
+				WHERE tag = ? AND account_id = (SELECT id FROM accounts WHERE device_account = ?)
+			`, tag, deviceAccount)
+			if err != nil {
+				return fmt.Errorf("failed to untag account '%s': %w", deviceAccount, err)
+			}
+			removed, _ := result.RowsAffected()
+			count += int(removed)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// tagAccountTx tags one account, returning false if the account doesn't exist
+func tagAccountTx(tx *sql.Tx, deviceAccount string, tag string) (bool, error) {
+	var id int
+	err := tx.QueryRow(`SELECT id FROM accounts WHERE device_account = ?`, deviceAccount).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to query account '%s': %w", deviceAccount, err)
+	}
+
+	// The UNIQUE(account_id, tag) constraint keeps a tag from being added twice
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO account_tags (account_id, tag) VALUES (?, ?)`, id, tag); err != nil {
+		return false, fmt.Errorf("failed to tag account '%s': %w", deviceAccount, err)
+	}
+	return true, nil
+}
+
+// AccountTags returns an account's tags (by device account), alphabetically
+func (db *DB) AccountTags(accountID string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT t.tag
+		FROM account_tags t
+		JOIN accounts a ON a.id = t.account_id
+		WHERE a.device_account = ?
+		ORDER BY t.tag
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account tags: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTags(rows)
+}
+
+// ListTags returns every tag in use on at least one account, alphabetically
+func (db *DB) ListTags() ([]string, error) {
+	rows, err := db.conn.Query(`SELECT DISTINCT tag FROM account_tags ORDER BY tag`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTags(rows)
+}
+
+func scanTags(rows *sql.Rows) ([]string, error) {
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
@@ -61,7 +61,8 @@ func (db *DB) GetAccountByID(id int) (*Account, error) {
 			shinedust, hourglasses, pokegold, pack_points,
 			packs_opened, wonder_picks_done, account_level,
 			created_at, last_used_at, stamina_recovery_time,
-			file_path, is_active, is_banned, notes
+			file_path, is_active, is_banned, notes,
+			COALESCE(pool_status, 'available'), COALESCE(failure_count, 0), last_error
 		FROM accounts
 		WHERE id = ?
 	`, id).Scan(
@@ -71,6 +72,7 @@ func (db *DB) GetAccountByID(id int) (*Account, error) {
 		&account.PacksOpened, &account.WonderPicksDone, &account.AccountLevel,
 		&account.CreatedAt, &account.LastUsedAt, &account.StaminaRecoveryTime,
 		&account.FilePath, &account.IsActive, &account.IsBanned, &account.Notes,
+		&account.PoolStatus, &account.FailureCount, &account.LastError,
 	)
 
 	if err != nil {
@@ -89,7 +91,8 @@ func (db *DB) GetAccountByDeviceAccount(deviceAccount string) (*Account, error)
 			shinedust, hourglasses, pokegold, pack_points,
 			packs_opened, wonder_picks_done, account_level,
 			created_at, last_used_at, stamina_recovery_time,
-			file_path, is_active, is_banned, notes
+			file_path, is_active, is_banned, notes,
+			COALESCE(pool_status, 'available'), COALESCE(failure_count, 0), last_error
 		FROM accounts
 		WHERE device_account = ?
 	`, deviceAccount).Scan(
@@ -99,6 +102,7 @@ func (db *DB) GetAccountByDeviceAccount(deviceAccount string) (*Account, error)
 		&account.PacksOpened, &account.WonderPicksDone, &account.AccountLevel,
 		&account.CreatedAt, &account.LastUsedAt, &account.StaminaRecoveryTime,
 		&account.FilePath, &account.IsActive, &account.IsBanned, &account.Notes,
+		&account.PoolStatus, &account.FailureCount, &account.LastError,
 	)
 
 	if err != nil {
@@ -116,7 +120,8 @@ func (db *DB) ListActiveAccounts() ([]*Account, error) {
 			shinedust, hourglasses, pokegold, pack_points,
 			packs_opened, wonder_picks_done, account_level,
 			created_at, last_used_at, stamina_recovery_time,
-			file_path, is_active, is_banned, notes
+			file_path, is_active, is_banned, notes,
+			COALESCE(pool_status, 'available'), COALESCE(failure_count, 0), last_error
 		FROM accounts
 		WHERE is_active = 1 AND is_banned = 0
 		ORDER BY last_used_at ASC
@@ -137,6 +142,7 @@ func (db *DB) ListActiveAccounts() ([]*Account, error) {
 			&account.PacksOpened, &account.WonderPicksDone, &account.AccountLevel,
 			&account.CreatedAt, &account.LastUsedAt, &account.StaminaRecoveryTime,
 			&account.FilePath, &account.IsActive, &account.IsBanned, &account.Notes,
+			&account.PoolStatus, &account.FailureCount, &account.LastError,
 		)
 		if err != nil {
 			return nil, err
@@ -171,6 +177,26 @@ func (db *DB) UpdateAccountStats(accountID int, packsOpened, wonderPicksDone, ac
 	})
 }
 
+// SyncAccountSaveCounts overwrites an account's pack and hourglass counts with the values
+// read from the game's save (see accounts.ReadSaveCounts), which are authoritative over
+// counts tallied from pack-open events
+func (db *DB) SyncAccountSaveCounts(accountID, packsOpened, hourglasses int) error {
+	return db.ExecTx(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE accounts
+			SET packs_opened = ?, hourglasses = ?
+			WHERE id = ?
+		`, packsOpened, hourglasses, accountID)
+		if err != nil {
+			return err
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return fmt.Errorf("account %d not found", accountID)
+		}
+		return nil
+	})
+}
+
 // UpdateAccountLastUsed updates the last_used_at timestamp for an account
 func (db *DB) UpdateAccountLastUsed(accountID int) error {
 	return db.ExecTx(func(tx *sql.Tx) error {
@@ -219,6 +245,99 @@ func (db *DB) SetAccountActive(accountID int, active bool) error {
 	})
 }
 
+// Pool statuses that can be set manually (see accountpool.AccountStatus)
+var manualPoolStatuses = map[string]bool{
+	"available": true,
+	"failed":    true,
+	"banned":    true,
+	"completed": true,
+}
+
+// SetAccountStatus manually sets an account's pool status and releases any checkout, e.g. to recover an
+// account stuck as failed or in_use. Banning also deactivates the account; any other status lifts a ban.
+// If resetFailures is true, failure_count and last_error are cleared.
+func (db *DB) SetAccountStatus(deviceAccount string, status string, resetFailures bool) error {
+	if !manualPoolStatuses[status] {
+		return fmt.Errorf("invalid account status '%s' (must be available, failed, banned or completed)", status)
+	}
+
+	return db.ExecTx(func(tx *sql.Tx) error {
+		updated, err := setAccountStatusTx(tx, deviceAccount, status, resetFailures)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return fmt.Errorf("account '%s' not found", deviceAccount)
+		}
+		return nil
+	})
+}
+
+// BulkUpdateStatus sets the pool status of several accounts in a single transaction (see SetAccountStatus).
+// Accounts that no longer exist are skipped. Returns the number of accounts updated.
+func (db *DB) BulkUpdateStatus(deviceAccounts []string, status string) (int, error) {
+	if !manualPoolStatuses[status] {
+		return 0, fmt.Errorf("invalid account status '%s' (must be available, failed, banned or completed)", status)
+	}
+
+	count := 0
+	err := db.ExecTx(func(tx *sql.Tx) error {
+		for _, deviceAccount := range deviceAccounts {
+			updated, err := setAccountStatusTx(tx, deviceAccount, status, false)
+			if err != nil {
+				return err
+			}
+			if updated {
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// setAccountStatusTx updates one account's pool status, returning false if the account doesn't exist
+func setAccountStatusTx(tx *sql.Tx, deviceAccount string, status string, resetFailures bool) (bool, error) {
+	query := `
+		UPDATE accounts
+		SET pool_status = ?,
+			checked_out_to_orchestration = NULL,
+			checked_out_to_instance = NULL,
+			checked_out_at = NULL
+	`
+	args := []interface{}{status}
+
+	if status == "banned" {
+		query += ", is_banned = 1, is_active = 0"
+	} else {
+		query += ", is_active = CASE WHEN is_banned = 1 THEN 1 ELSE is_active END, is_banned = 0"
+	}
+	if status == "completed" {
+		query += ", completed_at = ?"
+		args = append(args, time.Now())
+	}
+	if resetFailures {
+		query += ", failure_count = 0, last_error = NULL"
+	}
+
+	query += " WHERE device_account = ?"
+	args = append(args, deviceAccount)
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to update status of account '%s': %w", deviceAccount, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
 // UpdateAccountUsername updates the in-game username for an account
 func (db *DB) UpdateAccountUsername(accountID int, username string) error {
 	return db.ExecTx(func(tx *sql.Tx) error {
@@ -231,6 +350,28 @@ func (db *DB) UpdateAccountUsername(accountID int, username string) error {
 	})
 }
 
+// UpdateAccountFriendCode records the in-game friend code for an account.
+// Takes a plain *sql.DB so routine actions can call it.
+func UpdateAccountFriendCode(db *sql.DB, accountID int64, friendCode string) error {
+	result, err := db.Exec(`
+		UPDATE accounts
+		SET friend_code = ?
+		WHERE id = ?
+	`, friendCode, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to update friend code: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("account %d not found", accountID)
+	}
+	return nil
+}
+
 // UpdateAccountNotes updates the notes field for an account
 func (db *DB) UpdateAccountNotes(accountID int, notes string) error {
 	return db.ExecTx(func(tx *sql.Tx) error {
@@ -251,6 +392,31 @@ func (db *DB) DeleteAccount(accountID int) error {
 	})
 }
 
+// BulkDelete deletes several accounts in a single transaction (cascades to related records).
+// Accounts that no longer exist are skipped. Returns the number of accounts deleted.
+func (db *DB) BulkDelete(deviceAccounts []string) (int, error) {
+	count := 0
+	err := db.ExecTx(func(tx *sql.Tx) error {
+		for _, deviceAccount := range deviceAccounts {
+			result, err := tx.Exec(`DELETE FROM accounts WHERE device_account = ?`, deviceAccount)
+			if err != nil {
+				return fmt.Errorf("failed to delete account '%s': %w", deviceAccount, err)
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			count += int(rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // GetAccountsReadyForStamina returns accounts whose stamina has recovered
 func (db *DB) GetAccountsReadyForStamina() ([]*Account, error) {
 	now := time.Now()
@@ -260,7 +426,8 @@ func (db *DB) GetAccountsReadyForStamina() ([]*Account, error) {
 			shinedust, hourglasses, pokegold, pack_points,
 			packs_opened, wonder_picks_done, account_level,
 			created_at, last_used_at, stamina_recovery_time,
-			file_path, is_active, is_banned, notes
+			file_path, is_active, is_banned, notes,
+			COALESCE(pool_status, 'available'), COALESCE(failure_count, 0), last_error
 		FROM accounts
 		WHERE is_active = 1
 			AND is_banned = 0
@@ -284,6 +451,7 @@ func (db *DB) GetAccountsReadyForStamina() ([]*Account, error) {
 			&account.PacksOpened, &account.WonderPicksDone, &account.AccountLevel,
 			&account.CreatedAt, &account.LastUsedAt, &account.StaminaRecoveryTime,
 			&account.FilePath, &account.IsActive, &account.IsBanned, &account.Notes,
+			&account.PoolStatus, &account.FailureCount, &account.LastError,
 		)
 		if err != nil {
 			return nil, err
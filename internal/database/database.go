@@ -47,6 +47,12 @@ func Open(dbPath string) (*DB, error) {
 	return db, nil
 }
 
+// FromConn wraps a connection that is already open (e.g. the shared accounts database the
+// orchestrator was given), so its DB methods can be used. Closing it closes the connection.
+func FromConn(conn *sql.DB) *DB {
+	return &DB{conn: conn}
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	if db.conn != nil {
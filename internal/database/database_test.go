@@ -1,7 +1,11 @@
 package database
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -42,6 +46,26 @@ func TestDatabaseInitialization(t *testing.T) {
 	}
 }
 
+func TestSchemaVersion(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// A new database has no schema_version table yet
+	if version, err := db.SchemaVersion(); err != nil || version != 0 {
+		t.Fatalf("Expected version 0 before migrating, got %d (err: %v)", version, err)
+	}
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	if version, err := db.SchemaVersion(); err != nil || version != LatestSchemaVersion() {
+		t.Errorf("Expected version %d after migrating, got %d (err: %v)", LatestSchemaVersion(), version, err)
+	}
+}
+
 func TestAccountOperations(t *testing.T) {
 	// Setup
 	tempDir := t.TempDir()
@@ -102,6 +126,21 @@ func TestAccountOperations(t *testing.T) {
 		t.Errorf("Resources not updated correctly")
 	}
 
+	// Test SyncAccountSaveCounts
+	if err := db.SyncAccountSaveCounts(account.ID, 37, 12); err != nil {
+		t.Fatalf("Failed to sync save counts: %v", err)
+	}
+	synced, err := db.GetAccountByID(account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get synced account: %v", err)
+	}
+	if synced.PacksOpened != 37 || synced.Hourglasses != 12 || synced.Shinedust != 1000 {
+		t.Errorf("Save counts not synced correctly: packs=%d hourglasses=%d", synced.PacksOpened, synced.Hourglasses)
+	}
+	if err := db.SyncAccountSaveCounts(account.ID+1000, 1, 1); err == nil {
+		t.Error("Expected an error syncing a missing account")
+	}
+
 	// Test ListActiveAccounts
 	accounts, err := db.ListActiveAccounts()
 	if err != nil {
@@ -328,6 +367,15 @@ func TestPackTracking(t *testing.T) {
 		t.Fatalf("Failed to log pack opening: %v", err)
 	}
 
+	// The pack counts on the account
+	updated, err := db.GetAccountByID(account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get account: %v", err)
+	}
+	if updated.PacksOpened != 1 {
+		t.Errorf("Expected 1 pack opened on the account, got %d", updated.PacksOpened)
+	}
+
 	// Test LogCardPulled
 	cardName := "Pikachu"
 	cardNumber := "001/165"
@@ -445,3 +493,1086 @@ func TestTransactions(t *testing.T) {
 		t.Error("Transaction did not rollback correctly")
 	}
 }
+
+func TestDashboardStats(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("stats_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	other, err := db.CreateAccount("other_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	// Three packs for the account (one god pack), one for the other account
+	for i, godPack := range []bool{false, true, false} {
+		packID, err := db.LogPackOpening(account.ID, nil, "genetic_apex", nil, godPack, 5, nil, 5)
+		if err != nil {
+			t.Fatalf("Failed to log pack %d: %v", i, err)
+		}
+		if _, err := db.LogCardPulled(packID, account.ID, fmt.Sprintf("card_%d", i), nil, nil, "1_diamond", nil, false, false, nil); err != nil {
+			t.Fatalf("Failed to log card %d: %v", i, err)
+		}
+	}
+	if _, err := db.LogPackOpening(other.ID, nil, "genetic_apex", nil, false, 5, nil, 5); err != nil {
+		t.Fatalf("Failed to log pack: %v", err)
+	}
+
+	accountID := account.ID
+	if _, err := db.LogError(&accountID, nil, "stuck", "medium", "stuck on screen", nil, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to log error: %v", err)
+	}
+
+	executionID, err := StartRoutineExecution(db.Conn(), int64(account.ID), "open_packs", "", 1)
+	if err != nil {
+		t.Fatalf("Failed to start routine execution: %v", err)
+	}
+	if err := CompleteRoutineExecution(db.Conn(), executionID, 3, 0); err != nil {
+		t.Fatalf("Failed to complete routine execution: %v", err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	end := time.Now().Add(time.Hour)
+
+	// All accounts
+	stats, err := db.GetDashboardStats(nil, start, end)
+	if err != nil {
+		t.Fatalf("Failed to get dashboard stats: %v", err)
+	}
+	if stats.TotalPacks != 4 || stats.GodPacks != 1 {
+		t.Errorf("Expected 4 packs with 1 god pack, got %d/%d", stats.TotalPacks, stats.GodPacks)
+	}
+	if stats.GodPackRate != 25 {
+		t.Errorf("Expected god pack rate 25%%, got %.2f", stats.GodPackRate)
+	}
+	if len(stats.PacksPerDay) != 1 || stats.PacksPerDay[0].Count != 4 {
+		t.Errorf("Expected 4 packs on one day, got %+v", stats.PacksPerDay)
+	}
+	if stats.CardsByRarity["1_diamond"] != 3 {
+		t.Errorf("Expected 3 1_diamond cards, got %d", stats.CardsByRarity["1_diamond"])
+	}
+	if stats.ErrorsByType["stuck"] != 1 {
+		t.Errorf("Expected 1 stuck error, got %d", stats.ErrorsByType["stuck"])
+	}
+	if stats.RoutineRuns != 1 {
+		t.Errorf("Expected 1 routine run, got %d", stats.RoutineRuns)
+	}
+
+	// Single account
+	otherID := other.ID
+	stats, err = db.GetDashboardStats(&otherID, start, end)
+	if err != nil {
+		t.Fatalf("Failed to get dashboard stats: %v", err)
+	}
+	if stats.TotalPacks != 1 || stats.GodPacks != 0 || len(stats.CardsByRarity) != 0 || stats.RoutineRuns != 0 {
+		t.Errorf("Unexpected stats for other account: %+v", stats)
+	}
+
+	// Time range excludes everything
+	stats, err = db.GetDashboardStats(nil, start.Add(-48*time.Hour), start.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to get dashboard stats: %v", err)
+	}
+	if stats.TotalPacks != 0 || stats.GodPackRate != 0 || len(stats.PacksPerDay) != 0 {
+		t.Errorf("Expected no packs outside the time range, got %+v", stats)
+	}
+}
+
+func TestExportAccounts(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("export_account", "secret_password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	banned, err := db.CreateAccount("banned_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	if err := db.MarkAccountBanned(banned.ID); err != nil {
+		t.Fatalf("Failed to ban account: %v", err)
+	}
+
+	for i, rarity := range []string{"1_diamond", "1_diamond", "1_star"} {
+		packID, err := db.LogPackOpening(account.ID, nil, "genetic_apex", nil, i == 2, 5, nil, 5)
+		if err != nil {
+			t.Fatalf("Failed to log pack %d: %v", i, err)
+		}
+		if _, err := db.LogCardPulled(packID, account.ID, fmt.Sprintf("card_%d", i), nil, nil, rarity, nil, false, false, nil); err != nil {
+			t.Fatalf("Failed to log card %d: %v", i, err)
+		}
+	}
+
+	// Accounts CSV (banned accounts excluded by default)
+	var buf bytes.Buffer
+	if err := db.ExportAccountsCSV(&buf, AccountFilter{}); err != nil {
+		t.Fatalf("Failed to export accounts CSV: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("secret_password")) {
+		t.Error("Expected device password to be excluded from export")
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse accounts CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected header and 1 account, got %d rows", len(records))
+	}
+	row := make(map[string]string)
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if row["device_account"] != "export_account" || row["total_packs"] != "3" || row["god_packs"] != "1" || row["cards_pulled"] != "3" {
+		t.Errorf("Unexpected account row: %v", row)
+	}
+	if row["last_pull_at"] == "" {
+		t.Error("Expected last_pull_at to be set")
+	}
+
+	// Accounts JSON including banned accounts
+	buf.Reset()
+	if err := db.ExportAccountsJSON(&buf, AccountFilter{IncludeBanned: true}); err != nil {
+		t.Fatalf("Failed to export accounts JSON: %v", err)
+	}
+	var exported []AccountExport
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("Failed to parse accounts JSON: %v", err)
+	}
+	if len(exported) != 2 {
+		t.Fatalf("Expected 2 accounts, got %d", len(exported))
+	}
+	pulls := exported[0].Pulls
+	if pulls.TotalPacks != 3 || pulls.CardsByRarity["1_diamond"] != 2 || pulls.CardsByRarity["1_star"] != 1 {
+		t.Errorf("Unexpected pull summary: %+v", pulls)
+	}
+	if !exported[1].IsBanned || exported[1].Pulls.TotalPacks != 0 {
+		t.Errorf("Expected banned account without pulls, got %+v", exported[1])
+	}
+
+	// Pulls CSV for a single account
+	buf.Reset()
+	if err := db.ExportPullsCSV(&buf, AccountFilter{AccountIDs: []int{account.ID}}); err != nil {
+		t.Fatalf("Failed to export pulls CSV: %v", err)
+	}
+	records, err = csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse pulls CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Errorf("Expected header and 3 pulls, got %d rows", len(records))
+	}
+
+	// Time range excluding all pulls
+	buf.Reset()
+	if err := db.ExportAccountsJSON(&buf, AccountFilter{Since: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Failed to export accounts JSON: %v", err)
+	}
+	exported = nil
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("Failed to parse accounts JSON: %v", err)
+	}
+	if len(exported) != 1 || exported[0].Pulls.TotalPacks != 0 {
+		t.Errorf("Expected 1 account without pulls in range, got %+v", exported)
+	}
+}
+
+func TestSearchAccounts(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		account, err := db.CreateAccount(fmt.Sprintf("device_%d", i), "password", "")
+		if err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+		if err := db.UpdateAccountStats(account.ID, i*10, 0, 1); err != nil {
+			t.Fatalf("Failed to update stats: %v", err)
+		}
+	}
+	if err := db.UpdateAccountUsername(2, "Ash_100%"); err != nil {
+		t.Fatalf("Failed to update username: %v", err)
+	}
+	if err := db.MarkAccountBanned(3); err != nil {
+		t.Fatalf("Failed to ban account: %v", err)
+	}
+
+	// Sorted by packs, descending, paged
+	result, err := db.SearchAccounts("", SortSpec{Field: SortByPacks, Descending: true}, 0, 2)
+	if err != nil {
+		t.Fatalf("Failed to search accounts: %v", err)
+	}
+	if result.Total != 5 || result.PageCount() != 3 || len(result.Accounts) != 2 {
+		t.Fatalf("Expected 2 of 5 accounts on 3 pages, got %d of %d on %d", len(result.Accounts), result.Total, result.PageCount())
+	}
+	if result.Accounts[0].PacksOpened != 40 || result.Accounts[1].PacksOpened != 30 {
+		t.Errorf("Expected packs 40, 30, got %d, %d", result.Accounts[0].PacksOpened, result.Accounts[1].PacksOpened)
+	}
+
+	// Username match with literal wildcard
+	result, err = db.SearchAccounts("100%", SortSpec{}, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to search accounts: %v", err)
+	}
+	if result.Total != 1 || result.Accounts[0].ID != 2 {
+		t.Errorf("Expected account 2, got %d results", result.Total)
+	}
+
+	// ID match (no device account contains "5")
+	result, err = db.SearchAccounts("5", SortSpec{}, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to search accounts: %v", err)
+	}
+	if result.Total != 1 || result.Accounts[0].ID != 5 {
+		t.Errorf("Expected account 5 only, got %d results", result.Total)
+	}
+
+	// Banned accounts sort last by status
+	result, err = db.SearchAccounts("", SortSpec{Field: SortByStatus, Descending: true}, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to search accounts: %v", err)
+	}
+	if !result.Accounts[0].IsBanned {
+		t.Errorf("Expected banned account first, got %d", result.Accounts[0].ID)
+	}
+
+	if _, err := db.SearchAccounts("", SortSpec{Field: "password"}, 0, 10); err == nil {
+		t.Error("Expected error for unknown sort field")
+	}
+}
+
+func TestSetAccountStatus(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("stuck_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	// Simulate an account stuck in use after repeated failures
+	_, err = db.Conn().Exec(`
+		UPDATE accounts
+		SET pool_status = 'in_use', failure_count = 3, last_error = 'timeout',
+			checked_out_to_orchestration = 'orchestration', checked_out_to_instance = 2, checked_out_at = ?
+		WHERE id = ?
+	`, time.Now(), account.ID)
+	if err != nil {
+		t.Fatalf("Failed to set up account: %v", err)
+	}
+
+	if err := db.SetAccountStatus("stuck_account", "in_use", false); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+	if err := db.SetAccountStatus("missing_account", "available", false); err == nil {
+		t.Error("Expected error for missing account")
+	}
+
+	// Status only
+	if err := db.SetAccountStatus("stuck_account", "failed", false); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+	updated, _ := db.GetAccountByID(account.ID)
+	if updated.PoolStatus != "failed" || updated.FailureCount != 3 || updated.LastError == nil {
+		t.Errorf("Expected failed status with failures kept, got %s/%d", updated.PoolStatus, updated.FailureCount)
+	}
+	checkedOut, _, _, err := IsAccountCheckedOut(db.Conn(), "stuck_account")
+	if err != nil || checkedOut {
+		t.Errorf("Expected checkout to be released (err %v)", err)
+	}
+
+	// Ban, then reset to available with failures cleared
+	if err := db.SetAccountStatus("stuck_account", "banned", false); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+	updated, _ = db.GetAccountByID(account.ID)
+	if !updated.IsBanned || updated.IsActive {
+		t.Error("Expected banned account to be inactive")
+	}
+
+	if err := db.SetAccountStatus("stuck_account", "available", true); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+	updated, _ = db.GetAccountByID(account.ID)
+	if updated.PoolStatus != "available" || updated.FailureCount != 0 || updated.LastError != nil {
+		t.Errorf("Expected available status with failures cleared, got %s/%d", updated.PoolStatus, updated.FailureCount)
+	}
+	if updated.IsBanned || !updated.IsActive {
+		t.Error("Expected ban to be lifted")
+	}
+}
+
+func TestBulkAccountOperations(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	for _, name := range []string{"bulk_1", "bulk_2", "bulk_3"} {
+		if _, err := db.CreateAccount(name, "password", ""); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+
+	// Invalid status changes nothing
+	if _, err := db.BulkUpdateStatus([]string{"bulk_1"}, "in_use"); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+
+	// Missing accounts are skipped
+	count, err := db.BulkUpdateStatus([]string{"bulk_1", "bulk_2", "missing"}, "failed")
+	if err != nil {
+		t.Fatalf("Failed to bulk update status: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 accounts updated, got %d", count)
+	}
+	for _, name := range []string{"bulk_1", "bulk_2"} {
+		account, _ := db.GetAccountByDeviceAccount(name)
+		if account.PoolStatus != "failed" {
+			t.Errorf("Expected %s to be failed, got %s", name, account.PoolStatus)
+		}
+	}
+	untouched, _ := db.GetAccountByDeviceAccount("bulk_3")
+	if untouched.PoolStatus != "available" {
+		t.Errorf("Expected bulk_3 to be available, got %s", untouched.PoolStatus)
+	}
+
+	count, err = db.BulkDelete([]string{"bulk_1", "bulk_3", "missing"})
+	if err != nil {
+		t.Fatalf("Failed to bulk delete: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 accounts deleted, got %d", count)
+	}
+
+	result, err := db.SearchAccounts("", SortSpec{}, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to search accounts: %v", err)
+	}
+	if result.Total != 1 || result.Accounts[0].DeviceAccount != "bulk_2" {
+		t.Errorf("Expected only bulk_2 to remain, got %d accounts", result.Total)
+	}
+}
+
+func TestMetricsTotals(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("metrics_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	for i, godPack := range []bool{false, true} {
+		if _, err := db.LogPackOpening(account.ID, nil, "genetic_apex", nil, godPack, 5, nil, 5); err != nil {
+			t.Fatalf("Failed to log pack %d: %v", i, err)
+		}
+	}
+
+	accountID := account.ID
+	for _, errorType := range []string{"stuck", "stuck", "timeout"} {
+		if _, err := db.LogError(&accountID, nil, errorType, "medium", "error", nil, nil, nil, nil); err != nil {
+			t.Fatalf("Failed to log error: %v", err)
+		}
+	}
+
+	completed, err := StartRoutineExecution(db.Conn(), int64(account.ID), "open_packs", "", 1)
+	if err != nil {
+		t.Fatalf("Failed to start routine execution: %v", err)
+	}
+	if err := CompleteRoutineExecution(db.Conn(), completed, 2, 0); err != nil {
+		t.Fatalf("Failed to complete routine execution: %v", err)
+	}
+	failed, err := StartRoutineExecution(db.Conn(), int64(account.ID), "wonder_pick", "", 1)
+	if err != nil {
+		t.Fatalf("Failed to start routine execution: %v", err)
+	}
+	if err := FailRoutineExecution(db.Conn(), failed, "stuck"); err != nil {
+		t.Fatalf("Failed to fail routine execution: %v", err)
+	}
+
+	totals, err := db.GetMetricsTotals()
+	if err != nil {
+		t.Fatalf("Failed to get metrics totals: %v", err)
+	}
+	if totals.PacksOpened != 2 || totals.GodPacks != 1 {
+		t.Errorf("Expected 2 packs with 1 god pack, got %d/%d", totals.PacksOpened, totals.GodPacks)
+	}
+	if totals.AccountsProcessed != 1 {
+		t.Errorf("Expected 1 processed account, got %d", totals.AccountsProcessed)
+	}
+	if totals.ErrorsByType["stuck"] != 2 || totals.ErrorsByType["timeout"] != 1 {
+		t.Errorf("Unexpected errors by type: %v", totals.ErrorsByType)
+	}
+	if totals.RoutinesByStatus["completed"] != 1 || totals.RoutinesByStatus["failed"] != 1 {
+		t.Errorf("Unexpected routines by status: %v", totals.RoutinesByStatus)
+	}
+}
+
+func TestSessionTotals(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("session_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	// A run from before the session must not count
+	_, err = db.Conn().Exec(`
+		INSERT INTO routine_executions (account_id, routine_name, orchestration_id, execution_status, started_at, packs_opened)
+		VALUES (?, 'open_packs', 'group-a', 'completed', datetime('now', '-1 day'), 10)
+	`, account.ID)
+	if err != nil {
+		t.Fatalf("Failed to insert old routine execution: %v", err)
+	}
+
+	since := time.Now().Add(-time.Minute)
+
+	completed, err := StartRoutineExecution(db.Conn(), int64(account.ID), "open_packs", "group-a", 1)
+	if err != nil {
+		t.Fatalf("Failed to start routine execution: %v", err)
+	}
+	if err := CompleteRoutineExecution(db.Conn(), completed, 3, 1); err != nil {
+		t.Fatalf("Failed to complete routine execution: %v", err)
+	}
+	failed, err := StartRoutineExecution(db.Conn(), int64(account.ID), "wonder_pick", "group-b", 2)
+	if err != nil {
+		t.Fatalf("Failed to start routine execution: %v", err)
+	}
+	if err := FailRoutineExecution(db.Conn(), failed, "stuck"); err != nil {
+		t.Fatalf("Failed to fail routine execution: %v", err)
+	}
+
+	if _, err := db.LogPackOpening(account.ID, nil, "genetic_apex", nil, true, 5, nil, 5); err != nil {
+		t.Fatalf("Failed to log pack: %v", err)
+	}
+	accountID := account.ID
+	if _, err := db.LogError(&accountID, nil, "stuck", "medium", "error", nil, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to log error: %v", err)
+	}
+
+	totals, err := GetSessionTotals(db.Conn(), since)
+	if err != nil {
+		t.Fatalf("Failed to get session totals: %v", err)
+	}
+	if totals.AccountsProcessed != 1 || totals.PacksOpened != 3 {
+		t.Errorf("Expected 1 account and 3 packs, got %d/%d", totals.AccountsProcessed, totals.PacksOpened)
+	}
+	if totals.PackResults != 1 || totals.GodPacks != 1 {
+		t.Errorf("Expected 1 logged god pack, got %d/%d", totals.PackResults, totals.GodPacks)
+	}
+	if totals.Errors() != 1 || totals.ErrorsByType["stuck"] != 1 {
+		t.Errorf("Unexpected errors by type: %v", totals.ErrorsByType)
+	}
+
+	groupA, groupB := totals.Orchestrations["group-a"], totals.Orchestrations["group-b"]
+	if groupA == nil || groupA.RoutinesCompleted != 1 || groupA.PacksOpened != 3 || groupA.WonderPicks != 1 {
+		t.Errorf("Unexpected group-a totals: %+v", groupA)
+	}
+	if groupB == nil || groupB.RoutinesFailed != 1 || groupB.AccountsProcessed != 0 {
+		t.Errorf("Unexpected group-b totals: %+v", groupB)
+	}
+}
+
+func TestMergeAccounts(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	keep, _ := db.CreateAccount("keep_account", "password", "")
+	merge, _ := db.CreateAccount("merge_account", "password", "")
+	db.UpdateAccountResources(keep.ID, 100, 5, 0, 20)
+	db.UpdateAccountResources(merge.ID, 50, 9, 3, 10)
+	db.UpdateAccountUsername(merge.ID, "MergedName")
+
+	// History on both accounts, including a card owned by both
+	cardName := "Pikachu"
+	for _, id := range []int{keep.ID, merge.ID} {
+		packID, err := db.LogPackOpening(id, nil, "genetic_apex", nil, false, 5, nil, 5)
+		if err != nil {
+			t.Fatalf("Failed to log pack opening: %v", err)
+		}
+		if _, err := db.LogCardPulled(packID, id, "pikachu_001", &cardName, nil, "3_diamond", nil, false, false, nil); err != nil {
+			t.Fatalf("Failed to log card pulled: %v", err)
+		}
+	}
+	if _, err := db.StartActivity(merge.ID, "pack_opening", "test_routine", "test"); err != nil {
+		t.Fatalf("Failed to start activity: %v", err)
+	}
+	// Set after logging packs, which count on the account
+	db.UpdateAccountStats(merge.ID, 12, 4, 7)
+
+	// Accounts in use are refused
+	db.Conn().Exec(`UPDATE accounts SET checked_out_to_orchestration = 'orch' WHERE id = ?`, merge.ID)
+	if err := db.MergeAccounts("keep_account", "merge_account"); err == nil {
+		t.Error("Expected error merging a checked out account")
+	}
+	db.Conn().Exec(`UPDATE accounts SET checked_out_to_orchestration = NULL WHERE id = ?`, merge.ID)
+
+	if err := db.MergeAccounts("keep_account", "keep_account"); err == nil {
+		t.Error("Expected error merging an account into itself")
+	}
+	if err := db.MergeAccounts("keep_account", "missing"); err == nil {
+		t.Error("Expected error merging a missing account")
+	}
+
+	if err := db.MergeAccounts("keep_account", "merge_account"); err != nil {
+		t.Fatalf("Failed to merge accounts: %v", err)
+	}
+
+	if _, err := db.GetAccountByDeviceAccount("merge_account"); err == nil {
+		t.Error("Merged account should be deleted")
+	}
+
+	kept, err := db.GetAccountByID(keep.ID)
+	if err != nil {
+		t.Fatalf("Failed to get kept account: %v", err)
+	}
+	if kept.Shinedust != 100 || kept.Hourglasses != 9 || kept.Pokegold != 3 || kept.PackPoints != 20 {
+		t.Errorf("Expected higher resources to be kept, got %d/%d/%d/%d",
+			kept.Shinedust, kept.Hourglasses, kept.Pokegold, kept.PackPoints)
+	}
+	if kept.PacksOpened != 12 || kept.AccountLevel != 7 {
+		t.Errorf("Expected merged stats, got packs %d level %d", kept.PacksOpened, kept.AccountLevel)
+	}
+	if kept.Username == nil || *kept.Username != "MergedName" {
+		t.Errorf("Expected username to be filled from merged account, got %v", kept.Username)
+	}
+
+	packs, _ := db.GetRecentPacksForAccount(keep.ID, 10)
+	if len(packs) != 2 {
+		t.Errorf("Expected 2 packs after merge, got %d", len(packs))
+	}
+	activities, _ := db.GetRecentActivityForAccount(keep.ID, 10)
+	if len(activities) != 1 {
+		t.Errorf("Expected 1 activity after merge, got %d", len(activities))
+	}
+	collection, _ := db.GetAccountCollection(keep.ID)
+	if len(collection) != 1 || collection[0].Quantity != 2 {
+		t.Errorf("Expected combined collection with quantity 2, got %d entries", len(collection))
+	}
+}
+
+func TestAccountRiskScore(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	if _, _, err := db.AccountRiskScore("missing_account"); err == nil {
+		t.Error("Expected error for missing account")
+	}
+
+	account, err := db.CreateAccount("risky_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	score, factors, err := db.AccountRiskScore("risky_account")
+	if err != nil {
+		t.Fatalf("Failed to score account: %v", err)
+	}
+	if score != 0 || len(factors) != 0 {
+		t.Errorf("Expected a fresh account to have no risk, got %.1f %v", score, factors)
+	}
+
+	// Failures at the limit, 5 stuck errors (half the error limit) and some runs
+	if _, err := db.Conn().Exec(`UPDATE accounts SET failure_count = 5 WHERE id = ?`, account.ID); err != nil {
+		t.Fatalf("Failed to set failures: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := db.LogError(&account.ID, nil, "stuck", "medium", "stuck", nil, nil, nil, nil); err != nil {
+			t.Fatalf("Failed to log error: %v", err)
+		}
+	}
+	// Maintenance errors aren't the account's fault
+	if _, err := db.LogError(&account.ID, nil, "maintenance", "low", "maintenance", nil, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to log error: %v", err)
+	}
+
+	weights := DefaultRiskWeights()
+	score, factors, err = db.AccountRiskScore("risky_account")
+	if err != nil {
+		t.Fatalf("Failed to score account: %v", err)
+	}
+	want := (weights.Failures*1 + weights.Errors*0.5) / (weights.Failures + weights.Errors + weights.Usage) * 100
+	if score < want-0.01 || score > want+0.01 {
+		t.Errorf("Expected risk score %.2f, got %.2f", want, score)
+	}
+	if len(factors) != 2 {
+		t.Errorf("Expected failure and error factors, got %v", factors)
+	}
+
+	// Usage counts too
+	if _, err := db.StartActivity(account.ID, "routine", "farm", "test"); err != nil {
+		t.Fatalf("Failed to start activity: %v", err)
+	}
+	score, factors, err = db.AccountRiskScore("risky_account")
+	if err != nil {
+		t.Fatalf("Failed to score account: %v", err)
+	}
+	if score <= want || len(factors) != 3 {
+		t.Errorf("Expected recent usage to raise the score above %.2f, got %.2f %v", want, score, factors)
+	}
+
+	// Scoring every account at once agrees with scoring them one by one
+	if _, err := db.CreateAccount("calm_account", "password", ""); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	risks, err := ComputeAccountRisks(db.Conn(), weights)
+	if err != nil {
+		t.Fatalf("Failed to score accounts: %v", err)
+	}
+	if risky := risks["risky_account"]; risky.Score != score || len(risky.Factors) != len(factors) {
+		t.Errorf("Expected batch score %.2f %v, got %.2f %v", score, factors, risky.Score, risky.Factors)
+	}
+	if calm, scored := risks["calm_account"]; !scored || calm.Score != 0 {
+		t.Errorf("Expected calm_account to score 0, got %+v (scored %v)", calm, scored)
+	}
+
+	if err := db.MarkAccountBanned(account.ID); err != nil {
+		t.Fatalf("Failed to ban account: %v", err)
+	}
+	score, _, err = db.AccountRiskScore("risky_account")
+	if err != nil || score != 100 {
+		t.Errorf("Expected banned account to score 100, got %.1f (err %v)", score, err)
+	}
+}
+
+func TestAccountTags(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	for _, deviceAccount := range []string{"tag_a", "tag_b", "tag_c"} {
+		if _, err := db.CreateAccount(deviceAccount, "password", ""); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+
+	if err := db.TagAccount("tag_a", " Farm-A "); err != nil {
+		t.Fatalf("Failed to tag account: %v", err)
+	}
+	// Tagging again is a no-op thanks to the uniqueness constraint
+	if err := db.TagAccount("tag_a", "farm-a"); err != nil {
+		t.Fatalf("Failed to re-tag account: %v", err)
+	}
+	if err := db.TagAccount("missing_account", "farm-a"); err == nil {
+		t.Error("Expected error tagging a missing account")
+	}
+	if err := db.TagAccount("tag_a", "  "); err == nil {
+		t.Error("Expected error for an empty tag")
+	}
+
+	tags, err := db.AccountTags("tag_a")
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != "farm-a" {
+		t.Errorf("Expected [farm-a], got %v", tags)
+	}
+
+	count, err := db.BulkTagAccounts([]string{"tag_a", "tag_b", "missing_account"}, "vip")
+	if err != nil {
+		t.Fatalf("Failed to bulk tag: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 accounts tagged, got %d", count)
+	}
+
+	all, err := db.ListTags()
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+	if len(all) != 2 || all[0] != "farm-a" || all[1] != "vip" {
+		t.Errorf("Expected [farm-a vip], got %v", all)
+	}
+
+	// Merging keeps the union of both accounts' tags
+	if err := db.MergeAccounts("tag_a", "tag_b"); err != nil {
+		t.Fatalf("Failed to merge accounts: %v", err)
+	}
+	if err := db.TagAccount("tag_c", "farm-b"); err != nil {
+		t.Fatalf("Failed to tag account: %v", err)
+	}
+	if err := db.MergeAccounts("tag_a", "tag_c"); err != nil {
+		t.Fatalf("Failed to merge accounts: %v", err)
+	}
+	tags, err = db.AccountTags("tag_a")
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+	if len(tags) != 3 {
+		t.Errorf("Expected 3 tags after merge, got %v", tags)
+	}
+
+	if err := db.UntagAccount("tag_a", "VIP"); err != nil {
+		t.Fatalf("Failed to untag account: %v", err)
+	}
+	count, err = db.BulkUntagAccounts([]string{"tag_a"}, "vip")
+	if err != nil {
+		t.Fatalf("Failed to bulk untag: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no accounts untagged, got %d", count)
+	}
+	tags, err = db.AccountTags("tag_a")
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "farm-a" || tags[1] != "farm-b" {
+		t.Errorf("Expected [farm-a farm-b], got %v", tags)
+	}
+}
+
+func TestRecordWonderPick(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("wonder_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	card := "Pikachu ex"
+	if _, err := RecordWonderPick(db.Conn(), int64(account.ID), &card, nil, true, 1, false); err != nil {
+		t.Fatalf("Failed to record wonder pick: %v", err)
+	}
+	// Failed picks are recorded but not counted
+	if _, err := RecordWonderPick(db.Conn(), int64(account.ID), nil, nil, false, 1, false); err != nil {
+		t.Fatalf("Failed to record failed wonder pick: %v", err)
+	}
+
+	picks, err := db.GetRecentWonderPicks(account.ID, 10)
+	if err != nil {
+		t.Fatalf("Failed to get wonder picks: %v", err)
+	}
+	if len(picks) != 2 {
+		t.Fatalf("Expected 2 wonder picks, got %d", len(picks))
+	}
+
+	updated, err := db.GetAccountByID(account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get account: %v", err)
+	}
+	if updated.WonderPicksDone != 1 {
+		t.Errorf("Expected 1 wonder pick done, got %d", updated.WonderPicksDone)
+	}
+}
+
+func TestMissionCompletion(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("mission_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	name := "Open 3 packs"
+	if _, err := db.LogMissionCompletion(account.ID, "daily", &name, 50, 0, 0, 5); err != nil {
+		t.Fatalf("Failed to log mission: %v", err)
+	}
+	if _, err := db.LogMissionCompletion(account.ID, "weekly", nil, 0, 0, 0, 0); err != nil {
+		t.Fatalf("Failed to log mission: %v", err)
+	}
+
+	missions, err := db.GetRecentMissions(account.ID, 10)
+	if err != nil {
+		t.Fatalf("Failed to get missions: %v", err)
+	}
+	if len(missions) != 2 {
+		t.Fatalf("Expected 2 missions, got %d", len(missions))
+	}
+	for _, mission := range missions {
+		if mission.MissionType == "daily" && (mission.MissionName == nil || *mission.MissionName != name || mission.ShinedustReward != 50) {
+			t.Errorf("Unexpected daily mission: %+v", mission)
+		}
+	}
+}
+
+func TestAccountState(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	account, err := db.CreateAccount("state_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	if _, found, err := db.GetAccountState(account.ID, "last_mission_day"); err != nil || found {
+		t.Fatalf("Expected no state before it is set, got found=%v err=%v", found, err)
+	}
+
+	if err := db.SetAccountState(account.ID, "last_mission_day", "2024-01-01"); err != nil {
+		t.Fatalf("Failed to set state: %v", err)
+	}
+	if err := db.SetAccountState(account.ID, "last_mission_day", "2024-01-02"); err != nil {
+		t.Fatalf("Failed to overwrite state: %v", err)
+	}
+	if err := db.SetAccountStates(account.ID, map[string]string{"streak": "3"}); err != nil {
+		t.Fatalf("Failed to set states: %v", err)
+	}
+
+	value, found, err := db.GetAccountState(account.ID, "last_mission_day")
+	if err != nil || !found || value != "2024-01-02" {
+		t.Errorf("Expected last_mission_day=2024-01-02, got %q (found=%v, err=%v)", value, found, err)
+	}
+
+	state, err := db.GetAllAccountState(account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get all state: %v", err)
+	}
+	if len(state) != 2 || state["streak"] != "3" {
+		t.Errorf("Unexpected state: %v", state)
+	}
+
+	if err := db.DeleteAccountState(account.ID, "streak"); err != nil {
+		t.Fatalf("Failed to delete state: %v", err)
+	}
+	if _, found, _ := db.GetAccountState(account.ID, "streak"); found {
+		t.Error("Expected streak to be deleted")
+	}
+
+	// Merging keeps the merged account's state, without overwriting the kept account's
+	merged, err := db.CreateAccount("merged_state_account", "password", "")
+	if err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	if err := db.SetAccountStates(merged.ID, map[string]string{"last_mission_day": "2023-12-31", "streak": "7"}); err != nil {
+		t.Fatalf("Failed to set states: %v", err)
+	}
+	if err := db.MergeAccounts("state_account", "merged_state_account"); err != nil {
+		t.Fatalf("Failed to merge accounts: %v", err)
+	}
+
+	state, err = db.GetAllAccountState(account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get all state: %v", err)
+	}
+	if state["last_mission_day"] != "2024-01-02" || state["streak"] != "7" {
+		t.Errorf("Unexpected state after merge: %v", state)
+	}
+}
+
+func TestPoolLeases(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.RunMigrations()
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	if !HasPoolLeases(db.Conn()) {
+		t.Fatal("Expected the pool_leases table after migrating")
+	}
+
+	// A second connection stands in for another process
+	other, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open second connection: %v", err)
+	}
+	defer other.Close()
+
+	gui, cli := NewLeaseHolder("gui"), NewLeaseHolder("cli")
+	if gui == cli {
+		t.Fatal("Expected lease holders to be unique")
+	}
+
+	if claimed, err := ClaimPoolLease(db.Conn(), "lease_account", "pool", gui, time.Hour); err != nil || !claimed {
+		t.Fatalf("Expected the first claim to succeed, got %v, %v", claimed, err)
+	}
+	if claimed, err := ClaimPoolLease(other.Conn(), "lease_account", "pool", cli, time.Hour); err != nil || claimed {
+		t.Fatalf("Expected a claim on a held account to fail, got %v, %v", claimed, err)
+	}
+	if holder, err := PoolLeaseHolder(other.Conn(), "lease_account"); err != nil || holder != gui {
+		t.Errorf("Expected holder %s, got %q (err: %v)", gui, holder, err)
+	}
+
+	// Releasing someone else's lease does nothing; releasing your own frees the account
+	if err := ReleasePoolLease(other.Conn(), "lease_account", cli); err != nil {
+		t.Fatalf("Failed to release: %v", err)
+	}
+	if claimed, _ := ClaimPoolLease(other.Conn(), "lease_account", "pool", cli, time.Hour); claimed {
+		t.Fatal("Expected the lease to still be held after another holder's release")
+	}
+	if err := ReleasePoolLease(db.Conn(), "lease_account", gui); err != nil {
+		t.Fatalf("Failed to release: %v", err)
+	}
+	if claimed, err := ClaimPoolLease(other.Conn(), "lease_account", "pool", cli, time.Hour); err != nil || !claimed {
+		t.Fatalf("Expected a released account to be claimable, got %v, %v", claimed, err)
+	}
+
+	// An expired lease is claimable, as after a crash
+	if claimed, _ := ClaimPoolLease(db.Conn(), "expired_account", "pool", gui, -time.Minute); !claimed {
+		t.Fatal("Expected the claim to succeed")
+	}
+	if claimed, err := ClaimPoolLease(other.Conn(), "expired_account", "pool", cli, time.Hour); err != nil || !claimed {
+		t.Errorf("Expected an expired lease to be claimable, got %v, %v", claimed, err)
+	}
+
+	if released, err := ReleasePoolLeases(other.Conn(), cli); err != nil || released != 2 {
+		t.Errorf("Expected 2 leases released, got %d (err: %v)", released, err)
+	}
+}
+
+func TestIsBusy(t *testing.T) {
+	tests := []struct {
+		err  error
+		busy bool
+	}{
+		{nil, false},
+		{fmt.Errorf("database is locked"), true},
+		{fmt.Errorf("failed to claim lease: %w", fmt.Errorf("database table is locked: pool_leases")), true},
+		{fmt.Errorf("no such table: pool_leases"), false},
+	}
+	for _, tt := range tests {
+		if got := isBusy(tt.err); got != tt.busy {
+			t.Errorf("isBusy(%v) = %v, want %v", tt.err, got, tt.busy)
+		}
+	}
+}
@@ -0,0 +1,422 @@
+package database
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export operations. Rows are written as they are read so large exports
+// never hold the whole result set in memory. Device passwords are never exported.
+
+// AccountFilter selects the accounts and pulls to export
+type AccountFilter struct {
+	AccountIDs      []int     // Only these accounts (empty = all)
+	IncludeInactive bool      // Include deactivated (but not banned) accounts
+	IncludeBanned   bool      // Include banned accounts
+	Since           time.Time // Only count/export pulls at or after this time (zero = no limit)
+	Until           time.Time // Only count/export pulls before this time (zero = no limit)
+}
+
+// exportTimeFormat is used for all timestamps in exports
+const exportTimeFormat = time.RFC3339
+
+// accountExportColumns are the CSV columns written by ExportAccountsCSV
+var accountExportColumns = []string{
+	"id", "device_account", "username", "friend_code",
+	"shinedust", "hourglasses", "pokegold", "pack_points",
+	"packs_opened", "total_packs", "god_packs", "cards_pulled",
+	"wonder_picks_done", "account_level",
+	"created_at", "last_used_at", "last_pull_at", "is_active", "is_banned",
+}
+
+// pullExportColumns are the CSV columns written by ExportPullsCSV
+var pullExportColumns = []string{
+	"pull_id", "account_id", "device_account", "username",
+	"pack_id", "pack_type", "pack_name", "is_god_pack",
+	"card_id", "card_name", "card_number", "rarity", "card_type",
+	"is_full_art", "is_ex", "detection_confidence", "detected_at",
+}
+
+// PullSummary summarizes an account's pulls in a JSON export
+type PullSummary struct {
+	TotalPacks    int            `json:"total_packs"`
+	GodPacks      int            `json:"god_packs"`
+	CardsPulled   int            `json:"cards_pulled"`
+	CardsByRarity map[string]int `json:"cards_by_rarity"`
+	LastPullAt    *time.Time     `json:"last_pull_at,omitempty"`
+}
+
+// AccountExport is one account in a JSON export
+type AccountExport struct {
+	ID              int         `json:"id"`
+	DeviceAccount   string      `json:"device_account"`
+	Username        *string     `json:"username,omitempty"`
+	FriendCode      *string     `json:"friend_code,omitempty"`
+	Shinedust       int         `json:"shinedust"`
+	Hourglasses     int         `json:"hourglasses"`
+	Pokegold        int         `json:"pokegold"`
+	PackPoints      int         `json:"pack_points"`
+	PacksOpened     int         `json:"packs_opened"`
+	WonderPicksDone int         `json:"wonder_picks_done"`
+	AccountLevel    int         `json:"account_level"`
+	CreatedAt       time.Time   `json:"created_at"`
+	LastUsedAt      *time.Time  `json:"last_used_at,omitempty"`
+	IsActive        bool        `json:"is_active"`
+	IsBanned        bool        `json:"is_banned"`
+	Pulls           PullSummary `json:"pulls"`
+}
+
+// ExportAccountsCSV writes one row per account, including computed pack and pull totals
+func (db *DB) ExportAccountsCSV(w io.Writer, filter AccountFilter) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(accountExportColumns); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	err := db.queryAccountExports(filter, func(account *AccountExport) error {
+		return cw.Write([]string{
+			strconv.Itoa(account.ID),
+			account.DeviceAccount,
+			stringOrEmpty(account.Username),
+			stringOrEmpty(account.FriendCode),
+			strconv.Itoa(account.Shinedust),
+			strconv.Itoa(account.Hourglasses),
+			strconv.Itoa(account.Pokegold),
+			strconv.Itoa(account.PackPoints),
+			strconv.Itoa(account.PacksOpened),
+			strconv.Itoa(account.Pulls.TotalPacks),
+			strconv.Itoa(account.Pulls.GodPacks),
+			strconv.Itoa(account.Pulls.CardsPulled),
+			strconv.Itoa(account.WonderPicksDone),
+			strconv.Itoa(account.AccountLevel),
+			account.CreatedAt.Format(exportTimeFormat),
+			formatExportTime(account.LastUsedAt),
+			formatExportTime(account.Pulls.LastPullAt),
+			strconv.FormatBool(account.IsActive),
+			strconv.FormatBool(account.IsBanned),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportAccountsJSON writes a JSON array with one object per account, each with a nested pull summary
+func (db *DB) ExportAccountsJSON(w io.Writer, filter AccountFilter) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("["); err != nil {
+		return err
+	}
+
+	first := true
+	err := db.queryAccountExports(filter, func(account *AccountExport) error {
+		data, err := json.Marshal(account)
+		if err != nil {
+			return fmt.Errorf("failed to encode account %d: %w", account.ID, err)
+		}
+		separator := ",\n  "
+		if first {
+			separator = "\n  "
+			first = false
+		}
+		if _, err := bw.WriteString(separator); err != nil {
+			return err
+		}
+		_, err = bw.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := bw.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ExportPullsCSV writes one row per card pulled by the filtered accounts, oldest first
+func (db *DB) ExportPullsCSV(w io.Writer, filter AccountFilter) error {
+	where, args := filter.accountConditions("a")
+	pullWhere, pullArgs := filter.timeConditions("c.detected_at")
+	where = append(where, pullWhere...)
+	args = append(args, pullArgs...)
+
+	query := `
+		SELECT
+			c.id, c.account_id, a.device_account, a.username,
+			p.id, p.pack_type, p.pack_name, p.is_god_pack,
+			c.card_id, c.card_name, c.card_number, c.rarity, c.card_type,
+			c.is_full_art, c.is_ex, c.detection_confidence, c.detected_at
+		FROM cards_pulled c
+		JOIN pack_results p ON p.id = c.pack_result_id
+		JOIN accounts a ON a.id = c.account_id
+	` + whereClause(where) + `
+		ORDER BY c.detected_at, c.id
+	`
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query pulls: %w", err)
+	}
+	defer rows.Close()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(pullExportColumns); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for rows.Next() {
+		var (
+			pullID, accountID, packID    int64
+			deviceAccount, packType      string
+			cardID, rarity               string
+			username, packName, cardName *string
+			cardNumber, cardType         *string
+			isGodPack, isFullArt, isEx   bool
+			confidence                   *float64
+			detectedAt                   time.Time
+		)
+		err := rows.Scan(
+			&pullID, &accountID, &deviceAccount, &username,
+			&packID, &packType, &packName, &isGodPack,
+			&cardID, &cardName, &cardNumber, &rarity, &cardType,
+			&isFullArt, &isEx, &confidence, &detectedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to read pull: %w", err)
+		}
+
+		confidenceText := ""
+		if confidence != nil {
+			confidenceText = strconv.FormatFloat(*confidence, 'f', 3, 64)
+		}
+
+		err = cw.Write([]string{
+			strconv.FormatInt(pullID, 10),
+			strconv.FormatInt(accountID, 10),
+			deviceAccount,
+			stringOrEmpty(username),
+			strconv.FormatInt(packID, 10),
+			packType,
+			stringOrEmpty(packName),
+			strconv.FormatBool(isGodPack),
+			cardID,
+			stringOrEmpty(cardName),
+			stringOrEmpty(cardNumber),
+			rarity,
+			stringOrEmpty(cardType),
+			strconv.FormatBool(isFullArt),
+			strconv.FormatBool(isEx),
+			confidenceText,
+			detectedAt.Format(exportTimeFormat),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write pull: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read pulls: %w", err)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// queryAccountExports streams the filtered accounts with their pull summaries to fn, ordered by ID.
+// Pull summaries are aggregated in the same query because the connection pool allows a single
+// open query at a time.
+func (db *DB) queryAccountExports(filter AccountFilter, fn func(*AccountExport) error) error {
+	where, args := filter.accountConditions("a")
+	packWhere, packArgs := filter.timeConditions("opened_at")
+	cardWhere, cardArgs := filter.timeConditions("detected_at")
+
+	query := `
+		SELECT
+			a.id, a.device_account, a.username, a.friend_code,
+			a.shinedust, a.hourglasses, a.pokegold, a.pack_points,
+			a.packs_opened, a.wonder_picks_done, a.account_level,
+			a.created_at, a.last_used_at, a.is_active, a.is_banned,
+			COALESCE(p.total_packs, 0), COALESCE(p.god_packs, 0), p.last_pull_at,
+			r.rarities
+		FROM accounts a
+		LEFT JOIN (
+			SELECT
+				account_id,
+				COUNT(*) AS total_packs,
+				SUM(CASE WHEN is_god_pack = 1 THEN 1 ELSE 0 END) AS god_packs,
+				MAX(opened_at) AS last_pull_at
+			FROM pack_results
+			` + whereClause(packWhere) + `
+			GROUP BY account_id
+		) p ON p.account_id = a.id
+		LEFT JOIN (
+			SELECT account_id, group_concat(rarity || '=' || count, ',') AS rarities
+			FROM (
+				SELECT account_id, rarity, COUNT(*) AS count
+				FROM cards_pulled
+				` + whereClause(cardWhere) + `
+				GROUP BY account_id, rarity
+			)
+			GROUP BY account_id
+		) r ON r.account_id = a.id
+	` + whereClause(where) + `
+		ORDER BY a.id
+	`
+
+	// Placeholders appear in subquery order: packs, cards, then accounts
+	queryArgs := append(append(packArgs, cardArgs...), args...)
+
+	rows, err := db.conn.Query(query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		account := &AccountExport{}
+		var lastPullAt, rarities sql.NullString
+		err := rows.Scan(
+			&account.ID, &account.DeviceAccount, &account.Username, &account.FriendCode,
+			&account.Shinedust, &account.Hourglasses, &account.Pokegold, &account.PackPoints,
+			&account.PacksOpened, &account.WonderPicksDone, &account.AccountLevel,
+			&account.CreatedAt, &account.LastUsedAt, &account.IsActive, &account.IsBanned,
+			&account.Pulls.TotalPacks, &account.Pulls.GodPacks, &lastPullAt,
+			&rarities,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to read account: %w", err)
+		}
+
+		account.Pulls.LastPullAt = parseExportTime(lastPullAt)
+		account.Pulls.CardsByRarity, account.Pulls.CardsPulled = parseRarityCounts(rarities.String)
+
+		if err := fn(account); err != nil {
+			return fmt.Errorf("failed to write account %d: %w", account.ID, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read accounts: %w", err)
+	}
+	return nil
+}
+
+// accountConditions returns WHERE conditions selecting the filtered accounts (alias is the accounts table alias)
+func (f AccountFilter) accountConditions(alias string) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if len(f.AccountIDs) > 0 {
+		placeholders := make([]string, len(f.AccountIDs))
+		for i, id := range f.AccountIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s.id IN (%s)", alias, strings.Join(placeholders, ", ")))
+	}
+	if !f.IncludeInactive {
+		// Banning also deactivates an account, so banned accounts are governed by IncludeBanned alone
+		conditions = append(conditions, fmt.Sprintf("(%s.is_active = 1 OR %s.is_banned = 1)", alias, alias))
+	}
+	if !f.IncludeBanned {
+		conditions = append(conditions, alias+".is_banned = 0")
+	}
+
+	return conditions, args
+}
+
+// timeConditions returns WHERE conditions limiting column to the filter's time range
+func (f AccountFilter) timeConditions(column string) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if !f.Since.IsZero() {
+		conditions = append(conditions, column+" >= ?")
+		args = append(args, f.Since)
+	}
+	if !f.Until.IsZero() {
+		conditions = append(conditions, column+" < ?")
+		args = append(args, f.Until)
+	}
+
+	return conditions, args
+}
+
+// whereClause joins conditions into a WHERE clause ("" if there are none)
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// parseRarityCounts parses "rarity=count,..." into a map and the total count
+func parseRarityCounts(value string) (map[string]int, int) {
+	counts := make(map[string]int)
+	total := 0
+	if value == "" {
+		return counts, 0
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		rarity, countText, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		count, err := strconv.Atoi(countText)
+		if err != nil {
+			continue
+		}
+		counts[rarity] += count
+		total += count
+	}
+	return counts, total
+}
+
+// parseExportTime parses a timestamp returned by an aggregate (which SQLite returns as text)
+func parseExportTime(value sql.NullString) *time.Time {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+
+	layouts := []string{
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02T15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value.String); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// formatExportTime formats an optional timestamp ("" if unset)
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(exportTimeFormat)
+}
+
+// stringOrEmpty dereferences an optional string
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
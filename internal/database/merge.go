@@ -0,0 +1,128 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// mergeReassignTables are the history tables whose rows are moved to the kept account on merge
+var mergeReassignTables = []string{
+	"activity_log",
+	"error_log",
+	"pack_results",
+	"cards_pulled",
+	"wonder_pick_results",
+	"mission_completion",
+}
+
+// MergeAccounts merges the history of a duplicate account into a canonical one and deletes the duplicate.
+// keepID and mergeID are device accounts. Activity, errors, pack openings, cards, wonder picks, missions
+// and routine executions are reassigned to the kept account, collections are combined, and the kept
+// account takes the higher resource values and latest stats of the two. Everything happens in one
+// transaction. Merging is refused if either account is in use (pool status in_use or checked out).
+func (db *DB) MergeAccounts(keepID, mergeID string) error {
+	if keepID == mergeID {
+		return fmt.Errorf("cannot merge account '%s' into itself", keepID)
+	}
+
+	return db.ExecTx(func(tx *sql.Tx) error {
+		keep, err := mergeCandidateTx(tx, keepID)
+		if err != nil {
+			return err
+		}
+		merge, err := mergeCandidateTx(tx, mergeID)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range mergeReassignTables {
+			if _, err := tx.Exec(`UPDATE `+table+` SET account_id = ? WHERE account_id = ?`, keep, merge); err != nil {
+				return fmt.Errorf("failed to reassign %s: %w", table, err)
+			}
+		}
+
+		// Executions that collide with one already recorded for the kept account are dropped with the merged row
+		if _, err := tx.Exec(`UPDATE OR IGNORE routine_executions SET account_id = ? WHERE account_id = ?`, keep, merge); err != nil {
+			return fmt.Errorf("failed to reassign routine_executions: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO account_collection (account_id, card_id, card_name, card_number, rarity, quantity, first_obtained_at, last_obtained_at)
+			SELECT ?, card_id, card_name, card_number, rarity, quantity, first_obtained_at, last_obtained_at
+			FROM account_collection
+			WHERE account_id = ?
+			ON CONFLICT(account_id, card_id) DO UPDATE SET
+				quantity = quantity + excluded.quantity,
+				first_obtained_at = MIN(first_obtained_at, excluded.first_obtained_at),
+				last_obtained_at = MAX(last_obtained_at, excluded.last_obtained_at)
+		`, keep, merge); err != nil {
+			return fmt.Errorf("failed to merge collection: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE accounts
+			SET shinedust = MAX(accounts.shinedust, m.shinedust),
+				hourglasses = MAX(accounts.hourglasses, m.hourglasses),
+				pokegold = MAX(accounts.pokegold, m.pokegold),
+				pack_points = MAX(accounts.pack_points, m.pack_points),
+				packs_opened = MAX(accounts.packs_opened, m.packs_opened),
+				wonder_picks_done = MAX(accounts.wonder_picks_done, m.wonder_picks_done),
+				account_level = MAX(accounts.account_level, m.account_level),
+				created_at = MIN(accounts.created_at, COALESCE(m.created_at, accounts.created_at)),
+				last_used_at = COALESCE(MAX(accounts.last_used_at, m.last_used_at), accounts.last_used_at, m.last_used_at),
+				stamina_recovery_time = COALESCE(MAX(accounts.stamina_recovery_time, m.stamina_recovery_time), accounts.stamina_recovery_time, m.stamina_recovery_time),
+				username = COALESCE(accounts.username, m.username),
+				friend_code = COALESCE(accounts.friend_code, m.friend_code),
+				notes = COALESCE(accounts.notes, m.notes)
+			FROM (SELECT * FROM accounts WHERE id = ?) AS m
+			WHERE accounts.id = ?
+		`, merge, keep); err != nil {
+			return fmt.Errorf("failed to merge account stats: %w", err)
+		}
+
+		// Tags the kept account already has are skipped; the rest cascade away with the merged row
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO account_tags (account_id, tag, created_at)
+			SELECT ?, tag, created_at FROM account_tags WHERE account_id = ?
+		`, keep, merge); err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		// Routine state works the same way: the kept account's own values win
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO account_state (account_id, key, value, updated_at)
+			SELECT ?, key, value, updated_at FROM account_state WHERE account_id = ?
+		`, keep, merge); err != nil {
+			return fmt.Errorf("failed to merge account state: %w", err)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM accounts WHERE id = ?`, merge); err != nil {
+			return fmt.Errorf("failed to delete merged account '%s': %w", mergeID, err)
+		}
+		return nil
+	})
+}
+
+// mergeCandidateTx returns the id of an account that may take part in a merge
+func mergeCandidateTx(tx *sql.Tx, deviceAccount string) (int, error) {
+	var id int
+	var poolStatus sql.NullString
+	var checkedOutTo sql.NullString
+
+	err := tx.QueryRow(`
+		SELECT id, pool_status, checked_out_to_orchestration
+		FROM accounts
+		WHERE device_account = ?
+	`, deviceAccount).Scan(&id, &poolStatus, &checkedOutTo)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("account '%s' not found", deviceAccount)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load account '%s': %w", deviceAccount, err)
+	}
+
+	if poolStatus.String == "in_use" || checkedOutTo.Valid {
+		return 0, fmt.Errorf("account '%s' is in use and cannot be merged", deviceAccount)
+	}
+	return id, nil
+}
@@ -0,0 +1,66 @@
+package database
+
+// MetricsTotals holds all-time counters exposed by the metrics endpoint
+type MetricsTotals struct {
+	PacksOpened       int
+	GodPacks          int
+	AccountsProcessed int            // Accounts with at least one completed routine execution
+	ErrorsByType      map[string]int // error_log rows grouped by error_type
+	RoutinesByStatus  map[string]int // routine_executions grouped by execution_status
+}
+
+// GetMetricsTotals returns all-time pack, account, error and routine counters
+func (db *DB) GetMetricsTotals() (*MetricsTotals, error) {
+	totals := &MetricsTotals{
+		ErrorsByType:     make(map[string]int),
+		RoutinesByStatus: make(map[string]int),
+	}
+
+	err := db.conn.QueryRow(`
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN is_god_pack = 1 THEN 1 ELSE 0 END), 0)
+		FROM pack_results
+	`).Scan(&totals.PacksOpened, &totals.GodPacks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.conn.QueryRow(`
+		SELECT COUNT(DISTINCT account_id)
+		FROM routine_executions
+		WHERE execution_status = 'completed'
+	`).Scan(&totals.AccountsProcessed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.countGroupedBy(`SELECT error_type, COUNT(*) FROM error_log GROUP BY error_type`, totals.ErrorsByType); err != nil {
+		return nil, err
+	}
+	if err := db.countGroupedBy(`SELECT execution_status, COUNT(*) FROM routine_executions GROUP BY execution_status`, totals.RoutinesByStatus); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// countGroupedBy runs a "SELECT key, COUNT(*) ... GROUP BY key" query into counts
+func (db *DB) countGroupedBy(query string, counts map[string]int) error {
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		counts[key] = count
+	}
+
+	return rows.Err()
+}
@@ -82,6 +82,36 @@ var migrations = []Migration{
 		Up:          migration011Up,
 		Down:        migration011Down,
 	},
+	{
+		Version:     12,
+		Description: "Create pool_stats_history table for pool statistics over time",
+		Up:          migration012Up,
+		Down:        migration012Down,
+	},
+	{
+		Version:     13,
+		Description: "Add run outcome (steps executed, sentries fired, summary) to routine_executions",
+		Up:          migration013Up,
+		Down:        migration013Down,
+	},
+	{
+		Version:     14,
+		Description: "Create account_tags table for grouping accounts by tag",
+		Up:          migration014Up,
+		Down:        migration014Down,
+	},
+	{
+		Version:     15,
+		Description: "Create account_state table for routine state kept per account",
+		Up:          migration015Up,
+		Down:        migration015Down,
+	},
+	{
+		Version:     16,
+		Description: "Create pool_leases table for claiming pool accounts across processes",
+		Up:          migration016Up,
+		Down:        migration016Down,
+	},
 }
 
 // RunMigrations runs all pending database migrations
@@ -128,6 +158,17 @@ func (db *DB) RunMigrations() error {
 	return nil
 }
 
+// SchemaVersion returns the highest migration applied to the database. Unlike GetVersion,
+// it returns 0 for a database that has never been migrated.
+func (db *DB) SchemaVersion() (int, error) {
+	return db.getCurrentVersion()
+}
+
+// LatestSchemaVersion returns the version RunMigrations brings a database to
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
 // getCurrentVersion returns the current schema version
 func (db *DB) getCurrentVersion() (int, error) {
 	// Check if schema_version table exists
@@ -704,3 +745,116 @@ func migration011Down(tx *sql.Tx) error {
 	`)
 	return err
 }
+
+// Migration 012: Create pool_stats_history table
+func migration012Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		-- Periodic snapshots of open pool statistics, for charting a run
+		CREATE TABLE pool_stats_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pool_name TEXT NOT NULL,
+			sampled_at DATETIME NOT NULL,
+			total INTEGER NOT NULL DEFAULT 0,
+			available INTEGER NOT NULL DEFAULT 0,
+			in_use INTEGER NOT NULL DEFAULT 0,
+			completed INTEGER NOT NULL DEFAULT 0,
+			failed INTEGER NOT NULL DEFAULT 0
+		);
+
+		CREATE INDEX idx_pool_stats_history_lookup ON pool_stats_history(pool_name, sampled_at);
+	`)
+	return err
+}
+
+func migration012Down(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP INDEX IF EXISTS idx_pool_stats_history_lookup;
+		DROP TABLE IF EXISTS pool_stats_history;
+	`)
+	return err
+}
+
+// Migration 013: Record how each routine run went, not just whether it failed
+func migration013Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE routine_executions ADD COLUMN steps_executed INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE routine_executions ADD COLUMN sentries_fired INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE routine_executions ADD COLUMN outcome TEXT;
+	`)
+	return err
+}
+
+func migration013Down(tx *sql.Tx) error {
+	// SQLite doesn't support DROP COLUMN; the columns are ignored by older versions
+	return nil
+}
+
+// Migration 014: Create account_tags table
+func migration014Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		-- Free-form labels on accounts, which pools can select by
+		CREATE TABLE account_tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			account_id INTEGER NOT NULL,
+			tag TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+			UNIQUE(account_id, tag)
+		);
+
+		CREATE INDEX idx_account_tags_tag ON account_tags(tag);
+	`)
+	return err
+}
+
+func migration014Down(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP INDEX IF EXISTS idx_account_tags_tag;
+		DROP TABLE IF EXISTS account_tags;
+	`)
+	return err
+}
+
+// Migration 015: Create account_state table
+func migration015Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		-- Key-value routine state that survives across runs of the same account
+		CREATE TABLE account_state (
+			account_id INTEGER NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (account_id, key),
+			FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+		);
+	`)
+	return err
+}
+
+func migration015Down(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS account_state;`)
+	return err
+}
+
+// Migration 016: Create pool_leases table
+func migration016Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		-- Which process holds each pool account (see ClaimPoolLease); times are Unix seconds.
+		-- Keyed by device_account, as pools also serve accounts without an accounts row.
+		CREATE TABLE pool_leases (
+			device_account TEXT PRIMARY KEY,
+			pool_name TEXT,
+			holder TEXT,
+			leased_at INTEGER,
+			expires_at INTEGER
+		);
+
+		CREATE INDEX idx_pool_leases_holder ON pool_leases(holder);
+	`)
+	return err
+}
+
+func migration016Down(tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS pool_leases;`)
+	return err
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Mission tracking operations
+
+// LogMissionCompletion records a claimed mission and returns its entry's ID
+func (db *DB) LogMissionCompletion(
+	accountID int,
+	missionType string,
+	missionName *string,
+	shinedustReward int,
+	hourglassesReward int,
+	pokegoldReward int,
+	packPointsReward int,
+) (int64, error) {
+	var missionID int64
+	err := db.ExecTx(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			INSERT INTO mission_completion (
+				account_id, mission_type, mission_name,
+				shinedust_reward, hourglasses_reward, pokegold_reward,
+				pack_points_reward, completed_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, accountID, missionType, missionName,
+			shinedustReward, hourglassesReward, pokegoldReward,
+			packPointsReward, time.Now())
+
+		if err != nil {
+			return fmt.Errorf("failed to insert mission completion: %w", err)
+		}
+
+		missionID, err = result.LastInsertId()
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return missionID, nil
+}
+
+// GetRecentMissions returns recently claimed missions for an account
+func (db *DB) GetRecentMissions(accountID int, limit int) ([]*MissionCompletion, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT
+			id, account_id, mission_type, mission_name,
+			shinedust_reward, hourglasses_reward, pokegold_reward,
+			pack_points_reward, completed_at
+		FROM mission_completion
+		WHERE account_id = ?
+		ORDER BY completed_at DESC
+		LIMIT ?
+	`, accountID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	missions := []*MissionCompletion{}
+	for rows.Next() {
+		mission := &MissionCompletion{}
+		err := rows.Scan(
+			&mission.ID, &mission.AccountID, &mission.MissionType, &mission.MissionName,
+			&mission.ShinedustReward, &mission.HourglassesReward, &mission.PokegoldReward,
+			&mission.PackPointsReward, &mission.CompletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		missions = append(missions, mission)
+	}
+
+	return missions, rows.Err()
+}
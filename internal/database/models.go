@@ -33,6 +33,11 @@ type Account struct {
 	IsActive bool    `db:"is_active"`
 	IsBanned bool    `db:"is_banned"`
 	Notes    *string `db:"notes"`
+
+	// Pool lifecycle
+	PoolStatus   string  `db:"pool_status"`
+	FailureCount int     `db:"failure_count"`
+	LastError    *string `db:"last_error"`
 }
 
 // ActivityLog represents a single activity session
@@ -9,7 +9,8 @@ import (
 
 // Pack and card tracking operations
 
-// LogPackOpening creates a new pack result entry and returns its ID
+// LogPackOpening creates a new pack result entry, counts the pack in the account's packs_opened
+// and returns the entry's ID
 func (db *DB) LogPackOpening(
 	accountID int,
 	activityLogID *int,
@@ -48,7 +49,15 @@ func (db *DB) LogPackOpening(
 		}
 
 		packID, err = result.LastInsertId()
-		return err
+		if err != nil {
+			return err
+		}
+
+		// Keep the account's pack count current, e.g. for max_packs_per_account
+		if _, err := tx.Exec(`UPDATE accounts SET packs_opened = packs_opened + 1 WHERE id = ?`, accountID); err != nil {
+			return fmt.Errorf("failed to update account pack count: %w", err)
+		}
+		return nil
 	})
 
 	if err != nil {
@@ -403,6 +412,53 @@ func (db *DB) LogWonderPick(
 	return wonderPickID, nil
 }
 
+// RecordWonderPick logs a wonder pick result for callers that only hold the connection and,
+// when the pick succeeded, counts it in the account's wonder_picks_done, in one transaction
+func RecordWonderPick(
+	conn *sql.DB,
+	accountID int64,
+	cardSelected *string,
+	cardRarity *string,
+	success bool,
+	energyCost int,
+	wasFree bool,
+) (int64, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		INSERT INTO wonder_pick_results (
+			account_id, card_selected, card_rarity,
+			success, energy_cost, was_free, picked_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, accountID, cardSelected, cardRarity, success, energyCost, wasFree, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert wonder pick result: %w", err)
+	}
+
+	wonderPickID, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if success {
+		_, err = tx.Exec(`
+			UPDATE accounts SET wonder_picks_done = wonder_picks_done + 1 WHERE id = ?
+		`, accountID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count wonder pick: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return wonderPickID, nil
+}
+
 // GetRecentWonderPicks returns recent wonder picks for an account
 func (db *DB) GetRecentWonderPicks(accountID int, limit int) ([]*WonderPickResult, error) {
 	if limit <= 0 {
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Pool leases make account claims visible across processes: a pool hands an account out only
+// after claiming its lease, and the claim is a single conditional UPDATE, so two processes
+// (e.g. the GUI and a CLI tool) sharing a database can't both get the same account. Leases
+// expire so accounts held by a process that crashed become claimable again.
+
+const (
+	// PoolLeaseDuration is how long a lease lasts unless renewed
+	PoolLeaseDuration = 2 * time.Hour
+
+	// leaseBusyRetries is how often a claim is retried while another process holds the write lock
+	leaseBusyRetries = 5
+	leaseBusyBackoff = 20 * time.Millisecond
+)
+
+// NewLeaseHolder returns an ID for a lease holder that is unique across processes and
+// machines, labelled for diagnostics (e.g. with the pool name)
+func NewLeaseHolder(label string) string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("%s:%d:%s:%s", host, os.Getpid(), label, hex.EncodeToString(suffix))
+}
+
+// HasPoolLeases reports whether the database has the pool_leases table (migration 16)
+func HasPoolLeases(db *sql.DB) bool {
+	var name string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'pool_leases'`).Scan(&name)
+	return err == nil
+}
+
+// ClaimPoolLease claims an account's lease for holder if nobody else holds an unexpired one.
+// Returns false if another holder has it. Claiming a lease the holder already has renews it.
+func ClaimPoolLease(db *sql.DB, deviceAccount, poolName, holder string, duration time.Duration) (bool, error) {
+	var claimed bool
+	err := retryOnBusy(func() error {
+		if _, err := db.Exec(`INSERT OR IGNORE INTO pool_leases (device_account) VALUES (?)`, deviceAccount); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		result, err := db.Exec(`
+			UPDATE pool_leases
+			SET holder = ?, pool_name = ?, leased_at = ?, expires_at = ?
+			WHERE device_account = ?
+			AND (holder IS NULL OR holder = ? OR expires_at <= ?)
+		`, holder, poolName, now.Unix(), now.Add(duration).Unix(), deviceAccount, holder, now.Unix())
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		claimed = rows == 1
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim lease on %s: %w", deviceAccount, err)
+	}
+	return claimed, nil
+}
+
+// ReleasePoolLease gives up holder's lease on an account. Leases held by others are untouched.
+func ReleasePoolLease(db *sql.DB, deviceAccount, holder string) error {
+	err := retryOnBusy(func() error {
+		_, err := db.Exec(`
+			UPDATE pool_leases
+			SET holder = NULL, pool_name = NULL, leased_at = NULL, expires_at = NULL
+			WHERE device_account = ? AND holder = ?
+		`, deviceAccount, holder)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release lease on %s: %w", deviceAccount, err)
+	}
+	return nil
+}
+
+// ReleasePoolLeases gives up every lease holder has (cleanup when a pool closes)
+func ReleasePoolLeases(db *sql.DB, holder string) (int64, error) {
+	var released int64
+	err := retryOnBusy(func() error {
+		result, err := db.Exec(`
+			UPDATE pool_leases
+			SET holder = NULL, pool_name = NULL, leased_at = NULL, expires_at = NULL
+			WHERE holder = ?
+		`, holder)
+		if err != nil {
+			return err
+		}
+		released, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to release leases: %w", err)
+	}
+	return released, nil
+}
+
+// RenewPoolLeases extends every lease holder has by duration from now, so accounts held for
+// longer than a lease lasts aren't claimed by another process
+func RenewPoolLeases(db *sql.DB, holder string, duration time.Duration) error {
+	err := retryOnBusy(func() error {
+		_, err := db.Exec(`UPDATE pool_leases SET expires_at = ? WHERE holder = ?`, time.Now().Add(duration).Unix(), holder)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to renew leases: %w", err)
+	}
+	return nil
+}
+
+// PoolLeaseHolder returns who holds an account's unexpired lease ("" if nobody does)
+func PoolLeaseHolder(db *sql.DB, deviceAccount string) (string, error) {
+	var holder sql.NullString
+	err := db.QueryRow(`
+		SELECT holder FROM pool_leases
+		WHERE device_account = ? AND expires_at > ?
+	`, deviceAccount, time.Now().Unix()).Scan(&holder)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query lease on %s: %w", deviceAccount, err)
+	}
+	return holder.String, nil
+}
+
+// retryOnBusy runs fn again while SQLite reports the database is locked by another process
+func retryOnBusy(fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= leaseBusyRetries; attempt++ {
+		if err = fn(); !isBusy(err) {
+			return err
+		}
+		time.Sleep(leaseBusyBackoff * time.Duration(attempt+1))
+	}
+	return err
+}
+
+// isBusy matches SQLITE_BUSY and SQLITE_LOCKED by message, as the driver's typed errors only
+// exist in cgo builds
+func isBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "database is locked") || strings.Contains(message, "database table is locked")
+}
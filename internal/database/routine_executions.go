@@ -39,6 +39,9 @@ type RoutineExecution struct {
 	PacksOpened      int
 	WonderPicksDone  int
 	BotInstance      int
+	StepsExecuted    int     // Steps run in the latest iteration (see RecordRoutineOutcome)
+	SentriesFired    int     // Times a sentry halted the routine or failed in the latest iteration
+	Outcome          *string // One-line summary of the latest iteration, e.g. "completed after 47 steps"
 }
 
 // StartRoutineExecution records the start of a routine execution
@@ -99,12 +102,31 @@ func FailRoutineExecution(db *sql.DB, executionID int64, errorMessage string) er
 	return nil
 }
 
+// RecordRoutineOutcome stores how a routine iteration went (see actions.RoutineResult).
+// Later iterations of the same execution overwrite it.
+func RecordRoutineOutcome(db *sql.DB, executionID int64, stepsExecuted, sentriesFired int, outcome string) error {
+	_, err := db.Exec(`
+		UPDATE routine_executions
+		SET steps_executed = ?,
+		    sentries_fired = ?,
+		    outcome = ?
+		WHERE id = ?
+	`, stepsExecuted, sentriesFired, outcome, executionID)
+
+	if err != nil {
+		return fmt.Errorf("failed to record routine outcome: %w", err)
+	}
+
+	return nil
+}
+
 // GetRoutineExecution retrieves a routine execution by ID
 func GetRoutineExecution(db *sql.DB, executionID int64) (*RoutineExecution, error) {
 	var exec RoutineExecution
 	var completedAt sql.NullTime
 	var durationSeconds sql.NullInt64
 	var errorMessage sql.NullString
+	var outcome sql.NullString
 
 	var orchestrationID sql.NullString
 
@@ -121,7 +143,10 @@ func GetRoutineExecution(db *sql.DB, executionID int64) (*RoutineExecution, erro
 			error_message,
 			packs_opened,
 			wonder_picks_done,
-			bot_instance
+			bot_instance,
+			steps_executed,
+			sentries_fired,
+			outcome
 		FROM routine_executions
 		WHERE id = ?
 	`, executionID).Scan(
@@ -137,6 +162,9 @@ func GetRoutineExecution(db *sql.DB, executionID int64) (*RoutineExecution, erro
 		&exec.PacksOpened,
 		&exec.WonderPicksDone,
 		&exec.BotInstance,
+		&exec.StepsExecuted,
+		&exec.SentriesFired,
+		&outcome,
 	)
 
 	if err != nil {
@@ -157,6 +185,9 @@ func GetRoutineExecution(db *sql.DB, executionID int64) (*RoutineExecution, erro
 	if errorMessage.Valid {
 		exec.ErrorMessage = &errorMessage.String
 	}
+	if outcome.Valid {
+		exec.Outcome = &outcome.String
+	}
 
 	return &exec, nil
 }
@@ -168,6 +199,7 @@ func GetLastRoutineExecution(db *sql.DB, accountID int64, routineName string) (*
 	var completedAt sql.NullTime
 	var durationSeconds sql.NullInt64
 	var errorMessage sql.NullString
+	var outcome sql.NullString
 
 	err := db.QueryRow(`
 		SELECT
@@ -182,7 +214,10 @@ func GetLastRoutineExecution(db *sql.DB, accountID int64, routineName string) (*
 			error_message,
 			packs_opened,
 			wonder_picks_done,
-			bot_instance
+			bot_instance,
+			steps_executed,
+			sentries_fired,
+			outcome
 		FROM routine_executions
 		WHERE account_id = ? AND routine_name = ?
 		ORDER BY started_at DESC
@@ -200,6 +235,9 @@ func GetLastRoutineExecution(db *sql.DB, accountID int64, routineName string) (*
 		&exec.PacksOpened,
 		&exec.WonderPicksDone,
 		&exec.BotInstance,
+		&exec.StepsExecuted,
+		&exec.SentriesFired,
+		&outcome,
 	)
 
 	if err == sql.ErrNoRows {
@@ -223,6 +261,9 @@ func GetLastRoutineExecution(db *sql.DB, accountID int64, routineName string) (*
 	if errorMessage.Valid {
 		exec.ErrorMessage = &errorMessage.String
 	}
+	if outcome.Valid {
+		exec.Outcome = &outcome.String
+	}
 
 	return &exec, nil
 }
@@ -258,7 +299,10 @@ func GetAccountRoutineHistory(db *sql.DB, accountID int64, routineName string, l
 			error_message,
 			packs_opened,
 			wonder_picks_done,
-			bot_instance
+			bot_instance,
+			steps_executed,
+			sentries_fired,
+			outcome
 		FROM routine_executions
 		WHERE account_id = ? AND routine_name = ?
 		ORDER BY started_at DESC
@@ -281,6 +325,7 @@ func GetAccountRoutineHistory(db *sql.DB, accountID int64, routineName string, l
 		var completedAt sql.NullTime
 		var durationSeconds sql.NullInt64
 		var errorMessage sql.NullString
+		var outcome sql.NullString
 
 		err := rows.Scan(
 			&exec.ID,
@@ -295,6 +340,9 @@ func GetAccountRoutineHistory(db *sql.DB, accountID int64, routineName string, l
 			&exec.PacksOpened,
 			&exec.WonderPicksDone,
 			&exec.BotInstance,
+			&exec.StepsExecuted,
+			&exec.SentriesFired,
+			&outcome,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan routine execution: %w", err)
@@ -314,6 +362,9 @@ func GetAccountRoutineHistory(db *sql.DB, accountID int64, routineName string, l
 		if errorMessage.Valid {
 			exec.ErrorMessage = &errorMessage.String
 		}
+		if outcome.Valid {
+			exec.Outcome = &outcome.String
+		}
 
 		executions = append(executions, &exec)
 	}
@@ -324,3 +375,18 @@ func GetAccountRoutineHistory(db *sql.DB, accountID int64, routineName string, l
 
 	return executions, nil
 }
+
+// IncrementRoutineExecutionWonderPicks counts one more wonder pick for an ongoing routine execution
+func IncrementRoutineExecutionWonderPicks(db *sql.DB, executionID int64) error {
+	_, err := db.Exec(`
+		UPDATE routine_executions
+		SET wonder_picks_done = COALESCE(wonder_picks_done, 0) + 1
+		WHERE id = ?
+	`, executionID)
+
+	if err != nil {
+		return fmt.Errorf("failed to update routine wonder picks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SessionTotals aggregates what was recorded across the logs since a session started
+type SessionTotals struct {
+	AccountsProcessed int            // Accounts with at least one completed routine execution
+	PacksOpened       int            // Packs recorded on routine executions
+	PackResults       int            // Packs logged to pack_results (contents read)
+	GodPacks          int            // God packs logged to pack_results
+	ActivitiesByState map[string]int // activity_log grouped by status
+	ErrorsByType      map[string]int // error_log grouped by error_type
+	Orchestrations    map[string]*OrchestrationTotals
+}
+
+// OrchestrationTotals are one orchestration's routine executions in a session
+type OrchestrationTotals struct {
+	AccountsProcessed int
+	RoutinesCompleted int
+	RoutinesFailed    int
+	PacksOpened       int
+	WonderPicks       int
+}
+
+// Errors returns the total number of errors logged
+func (t *SessionTotals) Errors() int {
+	total := 0
+	for _, count := range t.ErrorsByType {
+		total += count
+	}
+	return total
+}
+
+// GetSessionTotals returns the totals recorded since a session started. Routine executions
+// are also grouped by orchestration ID so each bot group's outcome can be reported.
+func GetSessionTotals(db *sql.DB, since time.Time) (*SessionTotals, error) {
+	totals := &SessionTotals{
+		ActivitiesByState: make(map[string]int),
+		ErrorsByType:      make(map[string]int),
+		Orchestrations:    make(map[string]*OrchestrationTotals),
+	}
+
+	// routine_executions timestamps come from datetime('now'), which SQLite records in UTC;
+	// pack_results, error_log and activity_log store the time they were logged
+	sinceUTC := since.UTC().Format("2006-01-02 15:04:05")
+
+	err := db.QueryRow(`
+		SELECT
+			COUNT(DISTINCT CASE WHEN execution_status = 'completed' THEN account_id END),
+			COALESCE(SUM(packs_opened), 0)
+		FROM routine_executions
+		WHERE started_at >= ?
+	`, sinceUTC).Scan(&totals.AccountsProcessed, &totals.PacksOpened)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.QueryRow(`
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN is_god_pack = 1 THEN 1 ELSE 0 END), 0)
+		FROM pack_results
+		WHERE opened_at >= ?
+	`, since).Scan(&totals.PackResults, &totals.GodPacks)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := countSessionGroupedBy(db, `
+		SELECT status, COUNT(*) FROM activity_log
+		WHERE started_at >= ? GROUP BY status
+	`, since, totals.ActivitiesByState); err != nil {
+		return nil, err
+	}
+	if err := countSessionGroupedBy(db, `
+		SELECT error_type, COUNT(*) FROM error_log
+		WHERE occurred_at >= ? GROUP BY error_type
+	`, since, totals.ErrorsByType); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT
+			orchestration_id,
+			COUNT(DISTINCT CASE WHEN execution_status = 'completed' THEN account_id END),
+			COALESCE(SUM(CASE WHEN execution_status = 'completed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN execution_status = 'failed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(packs_opened), 0),
+			COALESCE(SUM(wonder_picks_done), 0)
+		FROM routine_executions
+		WHERE started_at >= ? AND orchestration_id IS NOT NULL
+		GROUP BY orchestration_id
+	`, sinceUTC)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orchestrationID string
+		orchestration := &OrchestrationTotals{}
+		if err := rows.Scan(&orchestrationID, &orchestration.AccountsProcessed, &orchestration.RoutinesCompleted,
+			&orchestration.RoutinesFailed, &orchestration.PacksOpened, &orchestration.WonderPicks); err != nil {
+			return nil, err
+		}
+		totals.Orchestrations[orchestrationID] = orchestration
+	}
+
+	return totals, rows.Err()
+}
+
+// countSessionGroupedBy runs a "SELECT key, COUNT(*) ... WHERE time >= ? GROUP BY key" query into counts
+func countSessionGroupedBy(db *sql.DB, query string, since time.Time, counts map[string]int) error {
+	rows, err := db.Query(query, since)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key sql.NullString
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		counts[key.String] += count
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"time"
+)
+
+// Aggregate statistics for the statistics dashboard.
+// All queries take an optional account ID (nil = all accounts) and a time range.
+
+// DailyCount is a count for a single day (YYYY-MM-DD, as recorded)
+type DailyCount struct {
+	Day   string
+	Count int
+}
+
+// DashboardStats aggregates pack, card, error and routine metrics for a time range
+type DashboardStats struct {
+	TotalPacks         int
+	GodPacks           int
+	GodPackRate        float64 // Percentage of packs that were god packs
+	PacksPerDay        []DailyCount
+	CardsByRarity      map[string]int
+	ErrorsByType       map[string]int
+	RoutineRuns        int           // Completed routine executions
+	AvgRoutineDuration time.Duration // Average duration of completed routine executions
+}
+
+// GetPacksPerDay returns the number of packs opened per day, oldest first
+func (db *DB) GetPacksPerDay(accountID *int, startDate, endDate time.Time) ([]DailyCount, error) {
+	query := `
+		SELECT substr(opened_at, 1, 10) as day, COUNT(*) as count
+		FROM pack_results
+		WHERE opened_at BETWEEN ? AND ?
+	`
+	args := []interface{}{startDate, endDate}
+
+	if accountID != nil {
+		query += " AND account_id = ?"
+		args = append(args, *accountID)
+	}
+
+	query += " GROUP BY day ORDER BY day"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []DailyCount{}
+	for rows.Next() {
+		var day DailyCount
+		if err := rows.Scan(&day.Day, &day.Count); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+
+	return days, rows.Err()
+}
+
+// GetGodPackCounts returns the total number of packs opened and how many were god packs
+func (db *DB) GetGodPackCounts(accountID *int, startDate, endDate time.Time) (total, godPacks int, err error) {
+	query := `
+		SELECT
+			COUNT(*) as total,
+			COALESCE(SUM(CASE WHEN is_god_pack = 1 THEN 1 ELSE 0 END), 0) as god_packs
+		FROM pack_results
+		WHERE opened_at BETWEEN ? AND ?
+	`
+	args := []interface{}{startDate, endDate}
+
+	if accountID != nil {
+		query += " AND account_id = ?"
+		args = append(args, *accountID)
+	}
+
+	err = db.conn.QueryRow(query, args...).Scan(&total, &godPacks)
+	return total, godPacks, err
+}
+
+// GetCardsByRarity returns the number of cards pulled grouped by rarity
+func (db *DB) GetCardsByRarity(accountID *int, startDate, endDate time.Time) (map[string]int, error) {
+	query := `
+		SELECT rarity, COUNT(*) as count
+		FROM cards_pulled
+		WHERE detected_at BETWEEN ? AND ?
+	`
+	args := []interface{}{startDate, endDate}
+
+	if accountID != nil {
+		query += " AND account_id = ?"
+		args = append(args, *accountID)
+	}
+
+	query += " GROUP BY rarity"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	distribution := make(map[string]int)
+	for rows.Next() {
+		var rarity string
+		var count int
+		if err := rows.Scan(&rarity, &count); err != nil {
+			return nil, err
+		}
+		distribution[rarity] = count
+	}
+
+	return distribution, rows.Err()
+}
+
+// GetAverageRoutineDuration returns the number of completed routine executions and their average duration
+func (db *DB) GetAverageRoutineDuration(accountID *int, startDate, endDate time.Time) (int, time.Duration, error) {
+	// started_at defaults to CURRENT_TIMESTAMP, which SQLite records in UTC
+	query := `
+		SELECT COUNT(*), COALESCE(AVG(duration_seconds), 0)
+		FROM routine_executions
+		WHERE execution_status = 'completed'
+			AND duration_seconds IS NOT NULL
+			AND started_at BETWEEN ? AND ?
+	`
+	args := []interface{}{
+		startDate.UTC().Format("2006-01-02 15:04:05"),
+		endDate.UTC().Format("2006-01-02 15:04:05"),
+	}
+
+	if accountID != nil {
+		query += " AND account_id = ?"
+		args = append(args, *accountID)
+	}
+
+	var runs int
+	var avgSeconds float64
+	if err := db.conn.QueryRow(query, args...).Scan(&runs, &avgSeconds); err != nil {
+		return 0, 0, err
+	}
+
+	return runs, time.Duration(avgSeconds * float64(time.Second)), nil
+}
+
+// GetDashboardStats collects all dashboard statistics for a time range
+func (db *DB) GetDashboardStats(accountID *int, startDate, endDate time.Time) (*DashboardStats, error) {
+	stats := &DashboardStats{}
+	var err error
+
+	stats.TotalPacks, stats.GodPacks, err = db.GetGodPackCounts(accountID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if stats.TotalPacks > 0 {
+		stats.GodPackRate = float64(stats.GodPacks) / float64(stats.TotalPacks) * 100
+	}
+
+	if stats.PacksPerDay, err = db.GetPacksPerDay(accountID, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	if stats.CardsByRarity, err = db.GetCardsByRarity(accountID, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	if stats.ErrorsByType, err = db.GetErrorStatsByType(accountID, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	stats.RoutineRuns, stats.AvgRoutineDuration, err = db.GetAverageRoutineDuration(accountID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,224 @@
+package diagnostics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/internal/adb"
+	"jordanella.com/pocket-tcg-go/internal/database"
+	"jordanella.com/pocket-tcg-go/internal/emulator"
+	"jordanella.com/pocket-tcg-go/pkg/templates"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn" // Usable, but something needs attention
+	StatusFail Status = "fail"
+)
+
+// CheckResult is the outcome of one pre-flight check
+type CheckResult struct {
+	Name    string
+	Status  Status
+	Message string // What was found
+	Fix     string // How to fix a warning or failure
+}
+
+// Passed reports whether the check found nothing blocking
+func (r CheckResult) Passed() bool {
+	return r.Status != StatusFail
+}
+
+// Environment locates what the checks inspect
+type Environment struct {
+	ADBPath      string // ADB executable (empty: search MuMuFolder)
+	MuMuFolder   string // MuMu installation folder
+	DatabasePath string
+	RoutinesDir  string
+	TemplatesDir string // Holds the images and the registry/ YAML files
+}
+
+// DefaultEnvironment returns the working-directory layout the GUI and headless mode use
+// (bot.db, routines/, templates/)
+func DefaultEnvironment(adbPath, mumuFolder string) Environment {
+	return Environment{
+		ADBPath:      adbPath,
+		MuMuFolder:   mumuFolder,
+		DatabasePath: "bot.db",
+		RoutinesDir:  filepath.Join(".", "routines"),
+		TemplatesDir: filepath.Join(".", "templates"),
+	}
+}
+
+// RunAll runs every check in order: ADB, database, emulator instances, templates, routines
+func RunAll(env Environment) []CheckResult {
+	return []CheckResult{
+		CheckADB(env),
+		CheckDatabase(env),
+		CheckEmulators(env),
+		CheckTemplates(env),
+		CheckRoutines(env),
+	}
+}
+
+// AllPassed reports whether none of the results is a failure
+func AllPassed(results []CheckResult) bool {
+	for _, result := range results {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckADB checks that the ADB executable can be found and responds
+func CheckADB(env Environment) CheckResult {
+	result := CheckResult{Name: "ADB"}
+
+	adbPath := env.ADBPath
+	if adbPath == "" {
+		found, err := adb.FindADB(env.MuMuFolder)
+		if err != nil {
+			return result.fail(fmt.Sprintf("ADB not found: %v", err),
+				"Set folderPath to your MuMu installation or adbPath to the ADB executable in Settings.ini")
+		}
+		adbPath = found
+	} else if _, err := os.Stat(adbPath); err != nil {
+		return result.fail(fmt.Sprintf("ADB path %s does not exist", adbPath),
+			"Fix adbPath in Settings.ini, or clear it to auto-detect from folderPath")
+	}
+
+	version, err := adb.Version(adbPath)
+	if err != nil {
+		return result.fail(fmt.Sprintf("%s does not respond: %v", adbPath, err),
+			"Check that adbPath points to a working ADB executable and is not blocked by antivirus")
+	}
+	return result.pass(fmt.Sprintf("%s (%s)", adbPath, version))
+}
+
+// CheckDatabase checks that the database exists, opens, and is migrated to the latest schema
+func CheckDatabase(env Environment) CheckResult {
+	result := CheckResult{Name: "Database"}
+
+	// Opening would create a missing database, so look first
+	if _, err := os.Stat(env.DatabasePath); err != nil {
+		return result.fail(fmt.Sprintf("Database %s not found", env.DatabasePath),
+			"Start the GUI or headless mode once to create and migrate the database")
+	}
+
+	db, err := database.Open(env.DatabasePath)
+	if err != nil {
+		return result.fail(fmt.Sprintf("Failed to open %s: %v", env.DatabasePath, err),
+			"Close other programs using the database, or restore it from a backup")
+	}
+	defer db.Close()
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return result.fail(fmt.Sprintf("Failed to read schema version: %v", err),
+			"The database may be corrupt; restore it from a backup")
+	}
+
+	latest := database.LatestSchemaVersion()
+	switch {
+	case version < latest:
+		return result.fail(fmt.Sprintf("Schema version %d, latest is %d", version, latest),
+			"Restart the bot to run the pending migrations")
+	case version > latest:
+		return result.warn(fmt.Sprintf("Schema version %d is newer than this build (%d)", version, latest),
+			"Update the bot; this database was migrated by a newer version")
+	}
+	return result.pass(fmt.Sprintf("%s (schema version %d)", env.DatabasePath, version))
+}
+
+// CheckEmulators checks that at least one MuMu instance is running
+func CheckEmulators(env Environment) CheckResult {
+	result := CheckResult{Name: "Emulator instances"}
+
+	instances, err := emulator.NewMuMuManager(env.MuMuFolder).FindInstances()
+	if err != nil {
+		return result.fail(fmt.Sprintf("Failed to discover instances: %v", err),
+			"Check that folderPath in Settings.ini points to your MuMu installation")
+	}
+	if len(instances) == 0 {
+		return result.fail("No running MuMu instances found",
+			"Start at least one MuMu instance from the MuMu Multi-Instance manager")
+	}
+	return result.pass(fmt.Sprintf("%d instance(s) running", len(instances)))
+}
+
+// CheckTemplates checks that the template registry loads and isn't empty
+func CheckTemplates(env Environment) CheckResult {
+	result := CheckResult{Name: "Templates"}
+
+	registryDir := filepath.Join(env.TemplatesDir, "registry")
+	if _, err := os.Stat(registryDir); err != nil {
+		return result.fail(fmt.Sprintf("Template registry %s not found", registryDir),
+			"Run the bot from its install folder, next to the templates/ directory")
+	}
+
+	registry := templates.NewTemplateRegistry(env.TemplatesDir).WithoutImageCache()
+	err := registry.LoadFromDirectory(registryDir)
+	if registry.Count() == 0 {
+		return result.fail("No templates loaded", "Restore the templates/registry YAML files from the release")
+	}
+	if err != nil {
+		return result.warn(fmt.Sprintf("%d templates loaded, with errors: %v", registry.Count(), err),
+			"Fix the template YAML files named in the error")
+	}
+	return result.pass(fmt.Sprintf("%d templates loaded", registry.Count()))
+}
+
+// CheckRoutines checks that the routines directory exists and its routines are valid
+func CheckRoutines(env Environment) CheckResult {
+	result := CheckResult{Name: "Routines"}
+
+	if _, err := os.Stat(env.RoutinesDir); err != nil {
+		return result.fail(fmt.Sprintf("Routines directory %s not found", env.RoutinesDir),
+			"Run the bot from its install folder, next to the routines/ directory")
+	}
+
+	// Routines are validated against the templates they reference (CheckTemplates reports load errors)
+	templateRegistry := templates.NewTemplateRegistry(env.TemplatesDir).WithoutImageCache()
+	templateRegistry.LoadFromDirectory(filepath.Join(env.TemplatesDir, "registry"))
+	registry := actions.NewRoutineRegistry(env.RoutinesDir).WithTemplateRegistry(templateRegistry)
+
+	valid := registry.ListValid()
+	invalid := registry.ListInvalid()
+	switch {
+	case len(valid) == 0 && len(invalid) == 0:
+		return result.fail("No routines found", "Restore the routines/ YAML files from the release")
+	case len(valid) == 0:
+		return result.fail(fmt.Sprintf("All %d routines are invalid", len(invalid)),
+			"Open the Routines tab to see each routine's validation error")
+	case len(invalid) > 0:
+		return result.warn(fmt.Sprintf("%d valid, %d invalid (first: %s: %v)", len(valid), len(invalid), invalid[0], registry.GetValidationError(invalid[0])),
+			"Open the Routines tab to see each routine's validation error")
+	}
+	return result.pass(fmt.Sprintf("%d routines valid", len(valid)))
+}
+
+func (r CheckResult) pass(message string) CheckResult {
+	r.Status = StatusPass
+	r.Message = message
+	return r
+}
+
+func (r CheckResult) warn(message, fix string) CheckResult {
+	r.Status = StatusWarn
+	r.Message = message
+	r.Fix = fix
+	return r
+}
+
+func (r CheckResult) fail(message, fix string) CheckResult {
+	r.Status = StatusFail
+	r.Message = message
+	r.Fix = fix
+	return r
+}
@@ -0,0 +1,130 @@
+package emulator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultAliasFile is where instance aliases are persisted. Aliases are keyed by
+// instance index and kept outside the MuMu config folder so they survive
+// MuMu reconfiguring or recreating its instance configs.
+const DefaultAliasFile = "instance_aliases.yaml"
+
+// AliasStore holds user-defined display names for emulator instances
+type AliasStore struct {
+	mu      sync.RWMutex
+	path    string
+	aliases map[int]string
+}
+
+var (
+	defaultAliasStore     *AliasStore
+	defaultAliasStoreOnce sync.Once
+)
+
+// DefaultAliasStore returns the process-wide alias store backed by DefaultAliasFile.
+// Every Manager shares it so aliases set in one tab show up everywhere.
+func DefaultAliasStore() *AliasStore {
+	defaultAliasStoreOnce.Do(func() {
+		defaultAliasStore = NewAliasStore(DefaultAliasFile)
+		if err := defaultAliasStore.Load(); err != nil {
+			fmt.Printf("Warning: Failed to load instance aliases: %v\n", err)
+		}
+	})
+	return defaultAliasStore
+}
+
+// NewAliasStore creates an empty alias store persisted at path
+func NewAliasStore(path string) *AliasStore {
+	return &AliasStore{
+		path:    path,
+		aliases: make(map[int]string),
+	}
+}
+
+// Load reads aliases from disk. A missing file is not an error.
+func (s *AliasStore) Load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read alias file: %w", err)
+	}
+
+	aliases := make(map[int]string)
+	if err := yaml.Unmarshal(data, &aliases); err != nil {
+		return fmt.Errorf("failed to parse alias file: %w", err)
+	}
+
+	s.mu.Lock()
+	s.aliases = aliases
+	s.mu.Unlock()
+	return nil
+}
+
+// Get returns the alias for an instance, or "" if none is set
+func (s *AliasStore) Get(index int) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.aliases[index]
+}
+
+// Set assigns an alias to an instance and saves the store.
+// An empty alias removes the entry.
+func (s *AliasStore) Set(index int, alias string) error {
+	alias = strings.TrimSpace(alias)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if alias == "" {
+		delete(s.aliases, index)
+	} else {
+		s.aliases[index] = alias
+	}
+
+	data, err := yaml.Marshal(s.aliases)
+	if err != nil {
+		return fmt.Errorf("failed to marshal aliases: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write alias file: %w", err)
+	}
+	return nil
+}
+
+// All returns a copy of all aliases keyed by instance index
+func (s *AliasStore) All() map[int]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	aliases := make(map[int]string, len(s.aliases))
+	for index, alias := range s.aliases {
+		aliases[index] = alias
+	}
+	return aliases
+}
+
+// Label formats an instance for display, e.g. "Instance 3 (main-farmer)".
+// The alias wins over the MuMu player name; with neither it is just "Instance 3".
+func (s *AliasStore) Label(index int, playerName string) string {
+	name := s.Get(index)
+	if name == "" {
+		name = playerName
+	}
+	if name == "" {
+		return fmt.Sprintf("Instance %d", index)
+	}
+	return fmt.Sprintf("Instance %d (%s)", index, name)
+}
+
+// InstanceLabel formats an instance for display using the shared alias store
+func InstanceLabel(index int, playerName string) string {
+	return DefaultAliasStore().Label(index, playerName)
+}
@@ -1,6 +1,7 @@
 package emulator
 
 import (
+	"errors"
 	"fmt"
 
 	"jordanella.com/pocket-tcg-go/internal/adb"
@@ -11,6 +12,9 @@ type Manager struct {
 	mumuMgr   *MuMuManager
 	instances map[int]*Instance // Map of instance index to Instance
 	adbPath   string
+	aliases   *AliasStore
+
+	instanceADBPaths map[int]string // Per-instance ADB overrides (see SetInstanceADBPaths)
 }
 
 // Instance represents a managed emulator instance with ADB
@@ -27,9 +31,25 @@ func NewManager(folderPath, adbPath string) *Manager {
 		mumuMgr:   NewMuMuManager(folderPath),
 		instances: make(map[int]*Instance),
 		adbPath:   adbPath,
+		aliases:   DefaultAliasStore(),
 	}
 }
 
+// SetInstanceADBPaths sets per-instance ADB executables, for instances that belong to
+// another MuMu installation than the global ADB path. Instances without an entry use the
+// manager's ADB path.
+func (m *Manager) SetInstanceADBPaths(paths map[int]string) {
+	m.instanceADBPaths = paths
+}
+
+// ADBPathFor returns the ADB executable used to connect to an instance
+func (m *Manager) ADBPathFor(index int) string {
+	if path := m.instanceADBPaths[index]; path != "" {
+		return path
+	}
+	return m.adbPath
+}
+
 // DiscoverInstances finds all running MuMu instances
 func (m *Manager) DiscoverInstances() error {
 	mumuInstances, err := m.mumuMgr.FindInstances()
@@ -62,11 +82,10 @@ func (m *Manager) ConnectInstance(index int) error {
 		return nil // Already connected
 	}
 
-	// Create ADB controller
+	// Connect through the shared ADB server (started and kept alive as needed)
 	port := fmt.Sprintf("%d", inst.MuMu.ADBPort)
-	ctrl := adb.NewController(m.adbPath, port)
-
-	if err := ctrl.Connect(); err != nil {
+	ctrl, err := adb.ServerFor(m.ADBPathFor(index)).Connect(port)
+	if err != nil {
 		return fmt.Errorf("failed to connect ADB to instance %d: %w", index, err)
 	}
 
@@ -84,7 +103,7 @@ func (m *Manager) DisconnectInstance(index int) error {
 	}
 
 	if inst.ADB != nil {
-		inst.ADB.Disconnect()
+		adb.ServerFor(m.ADBPathFor(index)).Release(inst.ADB)
 		inst.IsConnected = false
 	}
 
@@ -166,6 +185,39 @@ func (m *Manager) LaunchInstance(index int) error {
 	return m.mumuMgr.LaunchInstance(index)
 }
 
+// StopInstance disconnects ADB from an instance and shuts it down
+func (m *Manager) StopInstance(index int) error {
+	if _, exists := m.instances[index]; exists {
+		m.DisconnectInstance(index)
+	}
+	return m.mumuMgr.StopInstance(index)
+}
+
+// StopAllInstances shuts down every running MuMu instance, returning the indexes that were
+// stopped. Instances that fail to stop are reported in the error; the others still stop.
+func (m *Manager) StopAllInstances() ([]int, error) {
+	running, err := m.mumuMgr.FindInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find running instances: %w", err)
+	}
+
+	indexes := make([]int, 0, len(running))
+	for _, inst := range running {
+		indexes = append(indexes, inst.Index)
+	}
+
+	stopped := make([]int, 0, len(indexes))
+	var errs []error
+	for _, index := range indexes {
+		if err := m.StopInstance(index); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		stopped = append(stopped, index)
+	}
+	return stopped, errors.Join(errs...)
+}
+
 // IsInstanceRunning checks if an instance is currently running
 func (m *Manager) IsInstanceRunning(index int) bool {
 	return m.mumuMgr.IsInstanceRunning(index)
@@ -180,3 +232,23 @@ func (m *Manager) GetAllInstanceConfigs() (map[int]*MuMuExtraConfig, error) {
 func (m *Manager) GetInstanceConfig(index int) (*MuMuExtraConfig, error) {
 	return m.mumuMgr.ReadInstanceConfig(index)
 }
+
+// SetInstanceAlias assigns a display alias to an instance. An empty alias clears it.
+func (m *Manager) SetInstanceAlias(index int, alias string) error {
+	return m.aliases.Set(index, alias)
+}
+
+// GetInstanceAlias returns the alias for an instance, or "" if none is set
+func (m *Manager) GetInstanceAlias(index int) string {
+	return m.aliases.Get(index)
+}
+
+// InstanceLabel returns the display label for an instance, preferring its alias
+// over the MuMu player name
+func (m *Manager) InstanceLabel(index int) string {
+	playerName := ""
+	if config, err := m.mumuMgr.ReadInstanceConfig(index); err == nil && config != nil {
+		playerName = config.PlayerName
+	}
+	return m.aliases.Label(index, playerName)
+}
@@ -148,7 +148,7 @@ func (m *MuMuManager) FindInstances() ([]*MuMuInstance, error) {
 				Index:        instanceIndex,
 				WindowTitle:  titleStr,
 				WindowHandle: uintptr(hwnd),
-				ADBPort:      MuMuBasePort + (instanceIndex * MuMuPortIncrement),
+				ADBPort:      ComputedADBPort(instanceIndex),
 				Version:      m.version,
 				PlayerName:   titleStr,
 			}
@@ -272,6 +272,7 @@ func (c *WindowConfig) CalculatePosition(instanceIndex, titleHeight int) (x, y i
 const (
 	WM_GETTEXT       = 0x000D
 	WM_GETTEXTLENGTH = 0x000E
+	WM_CLOSE         = 0x0010
 	GWL_STYLE        = -16
 	WS_CAPTION       = 0x00C00000
 	SWP_NOZORDER     = 0x0004
@@ -296,6 +297,7 @@ var (
 	procSetWindowLong       = user32.NewProc("SetWindowLongW")
 	procInvalidateRect      = user32.NewProc("InvalidateRect")
 	procSendMessage         = user32.NewProc("SendMessageW")
+	procPostMessage         = user32.NewProc("PostMessageW")
 	procGetSystemMetrics    = user32.NewProc("GetSystemMetrics")
 )
 
@@ -406,6 +408,25 @@ func (m *MuMuManager) IsInstanceRunning(index int) bool {
 	return false
 }
 
+// StopInstance closes a running MuMu instance's window, which shuts the instance down.
+// The close is posted, so this returns before the instance has exited.
+func (m *MuMuManager) StopInstance(index int) error {
+	for i, inst := range m.instances {
+		if inst.Index != index {
+			continue
+		}
+
+		ret, _, err := procPostMessage.Call(inst.WindowHandle, WM_CLOSE, 0, 0)
+		if ret == 0 {
+			return fmt.Errorf("failed to close MuMu instance %d: %w", index, err)
+		}
+
+		m.instances = append(m.instances[:i], m.instances[i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("instance %d is not running", index)
+}
+
 // shellExecuteNonElevated launches a program without elevated privileges using ShellExecute
 // This is necessary because MuMu Player has issues when run as administrator
 func shellExecuteNonElevated(file, args string) error {
@@ -0,0 +1,132 @@
+package emulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// ErrADBPortUnknown is returned by VerifyInstancePort when the instance's MuMu config
+// doesn't say which ADB port it uses, so the computed port can't be checked
+var ErrADBPortUnknown = errors.New("configured ADB port unknown")
+
+// PortMismatchError reports an instance whose computed ADB port differs from the port
+// its MuMu config forwards ADB to, so connecting by the computed port would reach
+// another emulator (or nothing)
+type PortMismatchError struct {
+	Instance       int
+	ComputedPort   int    // Port derived from the instance index
+	ConfiguredPort int    // Port in the instance's vm_config.json
+	ConfigPath     string // Config file the configured port was read from
+	PortOwner      int    // Instance whose config uses the computed port (-1 if none)
+}
+
+func (e *PortMismatchError) Error() string {
+	msg := fmt.Sprintf("instance %d ADB port mismatch: computed port %d (%d + %d*%d) but %s configures port %d",
+		e.Instance, e.ComputedPort, MuMuBasePort, e.Instance, MuMuPortIncrement, e.ConfigPath, e.ConfiguredPort)
+	if e.PortOwner >= 0 {
+		msg += fmt.Sprintf("; port %d belongs to instance %d", e.ComputedPort, e.PortOwner)
+	}
+	return msg
+}
+
+// ComputedADBPort returns the ADB port MuMu assigns an instance by default
+func ComputedADBPort(instanceIndex int) int {
+	return MuMuBasePort + (instanceIndex * MuMuPortIncrement)
+}
+
+// vmConfigPath returns the path of an instance's vm_config.json
+func (m *MuMuManager) vmConfigPath(instanceIndex int) string {
+	return filepath.Join(m.folderPath, "vms", fmt.Sprintf("MuMuPlayerGlobal-12.0-%d", instanceIndex), "configs", "vm_config.json")
+}
+
+// ReadConfiguredADBPort reads the host port an instance's vm_config.json forwards ADB to
+// (vm.nat.port_forward.adb.host_port)
+func (m *MuMuManager) ReadConfiguredADBPort(instanceIndex int) (int, error) {
+	configPath := m.vmConfigPath(instanceIndex)
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read vm config for instance %d: %w", instanceIndex, err)
+	}
+
+	var config struct {
+		VM struct {
+			NAT struct {
+				PortForward struct {
+					ADB struct {
+						HostPort json.RawMessage `json:"host_port"`
+					} `json:"adb"`
+				} `json:"port_forward"`
+			} `json:"nat"`
+		} `json:"vm"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return 0, fmt.Errorf("failed to parse vm config for instance %d: %w", instanceIndex, err)
+	}
+
+	// MuMu writes the port as a string, but accept a number too
+	raw := config.VM.NAT.PortForward.ADB.HostPort
+	var portStr string
+	if err := json.Unmarshal(raw, &portStr); err != nil {
+		portStr = string(raw)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 {
+		return 0, fmt.Errorf("%w: no adb host_port in %s", ErrADBPortUnknown, configPath)
+	}
+	return port, nil
+}
+
+// VerifyInstancePort checks that the ADB port computed for an instance is the port its MuMu
+// config forwards ADB to. A mismatch returns a *PortMismatchError; an instance whose port
+// can't be read returns an error wrapping ErrADBPortUnknown.
+func (m *Manager) VerifyInstancePort(instanceID int) error {
+	computed := ComputedADBPort(instanceID)
+	if inst, exists := m.instances[instanceID]; exists && inst.MuMu != nil {
+		computed = inst.MuMu.ADBPort
+	}
+
+	configured, err := m.mumuMgr.ReadConfiguredADBPort(instanceID)
+	if err != nil {
+		if errors.Is(err, ErrADBPortUnknown) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrADBPortUnknown, err)
+	}
+	if configured == computed {
+		return nil
+	}
+
+	return &PortMismatchError{
+		Instance:       instanceID,
+		ComputedPort:   computed,
+		ConfiguredPort: configured,
+		ConfigPath:     m.mumuMgr.vmConfigPath(instanceID),
+		PortOwner:      m.mumuMgr.findPortOwner(computed, instanceID),
+	}
+}
+
+// findPortOwner returns the instance, other than exclude, whose config forwards ADB to port
+func (m *MuMuManager) findPortOwner(port, exclude int) int {
+	configs, _ := m.GetAllInstanceConfigs()
+
+	indices := make([]int, 0, len(configs))
+	for index := range configs {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	for _, index := range indices {
+		if index == exclude {
+			continue
+		}
+		if configured, err := m.ReadConfiguredADBPort(index); err == nil && configured == port {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,81 @@
+package emulator
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProxyNone is the proxy setting that clears an instance's proxy
+const ProxyNone = "none"
+
+// Proxy is an HTTP proxy an instance's traffic is sent through, so instances don't all
+// share one IP. Android's global proxy setting takes no credentials, so only proxies
+// without authentication (or with IP allow-listing) work.
+type Proxy struct {
+	Host string
+	Port int
+}
+
+// ParseProxy parses "host:port". "none" parses to nil, which clears the instance's proxy.
+func ParseProxy(s string) (*Proxy, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, ProxyNone) {
+		return nil, nil
+	}
+	s = strings.TrimPrefix(s, "http://")
+
+	host, portStr, err := net.SplitHostPort(s)
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("invalid proxy '%s': expected host:port", s)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid proxy '%s': port must be between 1 and 65535", s)
+	}
+	return &Proxy{Host: host, Port: port}, nil
+}
+
+// String returns the proxy as "host:port"
+func (p *Proxy) String() string {
+	return net.JoinHostPort(p.Host, strconv.Itoa(p.Port))
+}
+
+// CheckReachable opens a TCP connection to the proxy to confirm it accepts connections
+func (p *Proxy) CheckReachable(timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", p.String(), timeout)
+	if err != nil {
+		return fmt.Errorf("proxy %s is unreachable: %w", p, err)
+	}
+	conn.Close()
+	return nil
+}
+
+// ApplyProxy sets the global HTTP proxy of a connected instance through ADB, or clears it
+// when proxy is nil. Apps started afterwards use it, so apply it before launching the game.
+func (m *Manager) ApplyProxy(index int, proxy *Proxy) error {
+	inst, exists := m.instances[index]
+	if !exists || inst.ADB == nil {
+		return fmt.Errorf("instance %d is not connected", index)
+	}
+
+	value := ":0" // Android's value for "no proxy"
+	if proxy != nil {
+		value = proxy.String()
+	}
+	if _, err := inst.ADB.Shell("settings put global http_proxy " + value); err != nil {
+		return fmt.Errorf("failed to set proxy on instance %d: %w", index, err)
+	}
+
+	// Read the setting back so a rejected value doesn't pass silently
+	current, err := inst.ADB.Shell("settings get global http_proxy")
+	if err != nil {
+		return fmt.Errorf("failed to read proxy of instance %d: %w", index, err)
+	}
+	if current != value {
+		return fmt.Errorf("proxy of instance %d is '%s' after setting it to '%s'", index, current, value)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package emulator
+
+import "testing"
+
+func TestParseProxy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    *Proxy
+		wantErr bool
+	}{
+		{input: "10.0.0.5:8080", want: &Proxy{Host: "10.0.0.5", Port: 8080}},
+		{input: " proxy.example.com:3128 ", want: &Proxy{Host: "proxy.example.com", Port: 3128}},
+		{input: "http://10.0.0.5:8080", want: &Proxy{Host: "10.0.0.5", Port: 8080}},
+		{input: "none"},
+		{input: "NONE"},
+		{input: "10.0.0.5", wantErr: true},
+		{input: ":8080", wantErr: true},
+		{input: "10.0.0.5:http", wantErr: true},
+		{input: "10.0.0.5:0", wantErr: true},
+		{input: "10.0.0.5:65536", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseProxy(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package emulator
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// ErrProcessNotFound is returned by InstanceResourceUsage when the instance has no
+// emulator window, or its process has exited
+var ErrProcessNotFound = errors.New("emulator process not found")
+
+var (
+	kernel32                     = syscall.NewLazyDLL("kernel32.dll")
+	procCreateToolhelp32Snapshot = kernel32.NewProc("CreateToolhelp32Snapshot")
+	procProcess32FirstW          = kernel32.NewProc("Process32FirstW")
+	procProcess32NextW           = kernel32.NewProc("Process32NextW")
+	procGetProcessTimes          = kernel32.NewProc("GetProcessTimes")
+	procK32GetProcessMemoryInfo  = kernel32.NewProc("K32GetProcessMemoryInfo")
+	procGlobalMemoryStatusEx     = kernel32.NewProc("GlobalMemoryStatusEx")
+	procGetWindowThreadProcessId = user32.NewProc("GetWindowThreadProcessId")
+)
+
+const (
+	th32csSnapProcess              = 0x00000002
+	processQueryLimitedInformation = 0x1000
+	processVMRead                  = 0x0010
+)
+
+// processEntry32 mirrors PROCESSENTRY32W
+type processEntry32 struct {
+	Size            uint32
+	Usage           uint32
+	ProcessID       uint32
+	DefaultHeapID   uintptr
+	ModuleID        uint32
+	Threads         uint32
+	ParentProcessID uint32
+	PriClassBase    int32
+	Flags           uint32
+	ExeFile         [260]uint16
+}
+
+// processMemoryCounters mirrors PROCESS_MEMORY_COUNTERS
+type processMemoryCounters struct {
+	Size                       uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+}
+
+// memoryStatusEx mirrors MEMORYSTATUSEX
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// cpuSample is a process's total CPU time at a point in wall time
+type cpuSample struct {
+	cpu time.Duration
+	at  time.Time
+}
+
+// cpuSamples keeps the last CPU sample per process, so usage is measured between calls
+var (
+	cpuSamples   = make(map[uint32]cpuSample)
+	cpuSamplesMu sync.Mutex
+)
+
+// InstanceResourceUsage returns the CPU (percent of the whole machine) and memory (working
+// set, MB) used by an instance's emulator: its window's process and the processes it started
+// (MuMu runs each instance's VM in a child process). CPU is averaged since the previous call
+// for the instance, or since the processes started on the first call.
+// Returns ErrProcessNotFound when the instance isn't running.
+func (m *Manager) InstanceResourceUsage(instanceID int) (cpuPercent float64, memMB int, err error) {
+	mumuInstance, err := m.mumuMgr.GetInstance(instanceID)
+	if err != nil || mumuInstance.WindowHandle == 0 {
+		return 0, 0, fmt.Errorf("instance %d: %w", instanceID, ErrProcessNotFound)
+	}
+
+	var rootPID uint32
+	procGetWindowThreadProcessId.Call(mumuInstance.WindowHandle, uintptr(unsafe.Pointer(&rootPID)))
+	if rootPID == 0 {
+		return 0, 0, fmt.Errorf("instance %d: %w", instanceID, ErrProcessNotFound)
+	}
+
+	pids, err := processTree(rootPID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("instance %d: %w", instanceID, err)
+	}
+
+	var cpuTotal float64
+	var memBytes uint64
+	measured := 0
+	for _, pid := range pids {
+		cpu, mem, err := processUsage(pid)
+		if err != nil {
+			continue // Exited or inaccessible child
+		}
+		cpuTotal += cpu
+		memBytes += mem
+		measured++
+	}
+	if measured == 0 {
+		return 0, 0, fmt.Errorf("instance %d: %w", instanceID, ErrProcessNotFound)
+	}
+
+	return cpuTotal, int(memBytes >> 20), nil
+}
+
+// SystemMemory returns the machine's available and total physical memory in MB
+func SystemMemory() (availableMB int, totalMB int, err error) {
+	status := memoryStatusEx{Length: uint32(unsafe.Sizeof(memoryStatusEx{}))}
+	ret, _, callErr := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if ret == 0 {
+		return 0, 0, fmt.Errorf("GlobalMemoryStatusEx failed: %v", callErr)
+	}
+	return int(status.AvailPhys >> 20), int(status.TotalPhys >> 20), nil
+}
+
+// processTree returns rootPID and the IDs of all its descendant processes
+func processTree(rootPID uint32) ([]uint32, error) {
+	snapshot, _, callErr := procCreateToolhelp32Snapshot.Call(th32csSnapProcess, 0)
+	if syscall.Handle(snapshot) == syscall.InvalidHandle {
+		return nil, fmt.Errorf("failed to list processes: %v", callErr)
+	}
+	defer syscall.CloseHandle(syscall.Handle(snapshot))
+
+	children := make(map[uint32][]uint32)
+	found := false
+	entry := processEntry32{Size: uint32(unsafe.Sizeof(processEntry32{}))}
+	ret, _, _ := procProcess32FirstW.Call(snapshot, uintptr(unsafe.Pointer(&entry)))
+	for ret != 0 {
+		if entry.ProcessID == rootPID {
+			found = true
+		} else if entry.ProcessID != 0 {
+			children[entry.ParentProcessID] = append(children[entry.ParentProcessID], entry.ProcessID)
+		}
+		ret, _, _ = procProcess32NextW.Call(snapshot, uintptr(unsafe.Pointer(&entry)))
+	}
+	if !found {
+		return nil, ErrProcessNotFound
+	}
+
+	tree := []uint32{rootPID}
+	for i := 0; i < len(tree); i++ {
+		tree = append(tree, children[tree[i]]...)
+	}
+	return tree, nil
+}
+
+// processUsage returns a process's CPU percent (of all cores) since its last sample and its
+// working set in bytes
+func processUsage(pid uint32) (float64, uint64, error) {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation|processVMRead, false, pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	var creation, exit, kernel, user syscall.Filetime
+	ret, _, callErr := procGetProcessTimes.Call(uintptr(handle),
+		uintptr(unsafe.Pointer(&creation)), uintptr(unsafe.Pointer(&exit)),
+		uintptr(unsafe.Pointer(&kernel)), uintptr(unsafe.Pointer(&user)))
+	if ret == 0 {
+		return 0, 0, fmt.Errorf("GetProcessTimes failed: %v", callErr)
+	}
+
+	counters := processMemoryCounters{Size: uint32(unsafe.Sizeof(processMemoryCounters{}))}
+	ret, _, callErr = procK32GetProcessMemoryInfo.Call(uintptr(handle),
+		uintptr(unsafe.Pointer(&counters)), uintptr(counters.Size))
+	if ret == 0 {
+		return 0, 0, fmt.Errorf("GetProcessMemoryInfo failed: %v", callErr)
+	}
+
+	// Filetime durations count 100ns intervals
+	now := time.Now()
+	cpu := time.Duration((filetimeTicks(kernel) + filetimeTicks(user)) * 100)
+	previous := cpuSample{at: time.Unix(0, creation.Nanoseconds())}
+
+	cpuSamplesMu.Lock()
+	if sample, exists := cpuSamples[pid]; exists && sample.at.After(previous.at) {
+		previous = sample
+	}
+	cpuSamples[pid] = cpuSample{cpu: cpu, at: now}
+	cpuSamplesMu.Unlock()
+
+	var percent float64
+	if elapsed := now.Sub(previous.at); elapsed > 0 && cpu >= previous.cpu {
+		percent = float64(cpu-previous.cpu) / float64(elapsed) / float64(runtime.NumCPU()) * 100
+	}
+	return percent, uint64(counters.WorkingSetSize), nil
+}
+
+// filetimeTicks returns a FILETIME duration as a count of 100ns intervals
+func filetimeTicks(ft syscall.Filetime) int64 {
+	return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)
+}
@@ -2,20 +2,43 @@ package events
 
 import (
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// subscription represents a single event subscription
+// Subscriber backpressure settings
+const (
+	DefaultSubscriberBufferSize = 256              // Events buffered per subscriber before the oldest is dropped
+	slowConsumerThreshold       = 5 * time.Second  // How long a subscriber must stay backed up before it is reported
+	slowConsumerWarnInterval    = 30 * time.Second // Minimum time between warnings for the same subscriber
+)
+
+// subscription represents a single event subscription.
+// Each subscription has its own bounded queue drained by a dedicated goroutine;
+// when the queue is full the oldest queued event is dropped (drop-oldest).
 type subscription struct {
-	id      SubscriptionID
-	handler EventHandler
+	id        SubscriptionID
+	name      string
+	eventType EventType
+	handler   EventHandler
+
+	queue chan Event
+	done  chan struct{} // Closed by Unsubscribe
+
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+
+	// Slow-consumer tracking (only touched by the dispatch goroutine)
+	backedUpSince time.Time
+	lastWarning   time.Time
 }
 
 // DefaultEventBus is the default implementation of EventBus
 type DefaultEventBus struct {
 	// Subscriber management
-	subscribers map[EventType][]subscription
+	subscribers map[EventType][]*subscription
 	mu          sync.RWMutex
 
 	// Event queue
@@ -26,15 +49,37 @@ type DefaultEventBus struct {
 	// Subscription ID generator
 	nextSubID SubscriptionID
 	subMu     sync.Mutex
+
+	// Per-subscriber queue capacity for new subscriptions
+	subscriberBufferSize int
+}
+
+// SubscriberStats reports delivery metrics for a single subscription
+type SubscriberStats struct {
+	ID            SubscriptionID
+	Name          string
+	EventType     EventType
+	Delivered     uint64 // Events handled
+	Dropped       uint64 // Events discarded because the subscriber queue was full
+	QueueDepth    int    // Events waiting to be handled
+	QueueCapacity int
+}
+
+// BusStats reports event bus queue and subscriber metrics
+type BusStats struct {
+	QueueDepth  int // Events published but not yet dispatched
+	Subscribers []SubscriberStats
 }
 
 // NewEventBus creates a new event bus with specified buffer size
 func NewEventBus(bufferSize int) *DefaultEventBus {
 	bus := &DefaultEventBus{
-		subscribers: make(map[EventType][]subscription),
+		subscribers: make(map[EventType][]*subscription),
 		eventQueue:  make(chan Event, bufferSize),
 		stopCh:      make(chan struct{}),
 		nextSubID:   1,
+
+		subscriberBufferSize: DefaultSubscriberBufferSize,
 	}
 
 	// Start event processor
@@ -44,8 +89,23 @@ func NewEventBus(bufferSize int) *DefaultEventBus {
 	return bus
 }
 
+// SetSubscriberBufferSize sets the queue capacity for subscriptions created afterwards
+func (eb *DefaultEventBus) SetSubscriberBufferSize(size int) {
+	if size < 1 {
+		size = 1
+	}
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	eb.subscriberBufferSize = size
+}
+
 // Subscribe registers a handler for a specific event type
 func (eb *DefaultEventBus) Subscribe(eventType EventType, handler EventHandler) SubscriptionID {
+	return eb.SubscribeNamed("", eventType, handler)
+}
+
+// SubscribeNamed registers a handler with a name used in stats and slow-consumer warnings
+func (eb *DefaultEventBus) SubscribeNamed(name string, eventType EventType, handler EventHandler) SubscriptionID {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
 
@@ -55,11 +115,22 @@ func (eb *DefaultEventBus) Subscribe(eventType EventType, handler EventHandler)
 	eb.nextSubID++
 	eb.subMu.Unlock()
 
+	if name == "" {
+		name = fmt.Sprintf("subscriber-%d", subID)
+	}
+
+	sub := &subscription{
+		id:        subID,
+		name:      name,
+		eventType: eventType,
+		handler:   handler,
+		queue:     make(chan Event, eb.subscriberBufferSize),
+		done:      make(chan struct{}),
+	}
+	go eb.deliver(sub)
+
 	// Add subscription
-	eb.subscribers[eventType] = append(eb.subscribers[eventType], subscription{
-		id:      subID,
-		handler: handler,
-	})
+	eb.subscribers[eventType] = append(eb.subscribers[eventType], sub)
 
 	return subID
 }
@@ -73,8 +144,9 @@ func (eb *DefaultEventBus) Unsubscribe(id SubscriptionID) {
 	for eventType, subs := range eb.subscribers {
 		for i, sub := range subs {
 			if sub.id == id {
-				// Remove from slice
+				// Remove from slice and stop its delivery goroutine
 				eb.subscribers[eventType] = append(subs[:i], subs[i+1:]...)
+				close(sub.done)
 				return
 			}
 		}
@@ -101,10 +173,21 @@ func (eb *DefaultEventBus) PublishAsync(event Event) {
 	go eb.Publish(event)
 }
 
-// Stop stops the event bus and drains remaining events
+// Stop stops the event bus and drains remaining events.
+// Subscribers finish handling their queued events in the background.
 func (eb *DefaultEventBus) Stop() {
 	close(eb.stopCh)
 	eb.wg.Wait()
+
+	// No more dispatches - let each delivery goroutine drain its queue and exit
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	for _, subs := range eb.subscribers {
+		for _, sub := range subs {
+			close(sub.queue)
+		}
+	}
+	eb.subscribers = make(map[EventType][]*subscription)
 }
 
 // processEvents runs in a goroutine and dispatches events to handlers
@@ -130,9 +213,9 @@ func (eb *DefaultEventBus) processEvents() {
 	}
 }
 
-// dispatch sends an event to all registered handlers
+// dispatch queues an event for every subscriber of its type
 func (eb *DefaultEventBus) dispatch(event Event) {
-	// Get handlers with read lock
+	// Get subscribers with read lock
 	eb.mu.RLock()
 	subs, exists := eb.subscribers[event.Type]
 	if !exists || len(subs) == 0 {
@@ -140,19 +223,72 @@ func (eb *DefaultEventBus) dispatch(event Event) {
 		return
 	}
 
-	// Make a copy of handlers to avoid holding lock during dispatch
-	handlers := make([]EventHandler, len(subs))
-	for i, sub := range subs {
-		handlers[i] = sub.handler
-	}
+	// Make a copy to avoid holding lock during dispatch
+	targets := make([]*subscription, len(subs))
+	copy(targets, subs)
 	eb.mu.RUnlock()
 
-	// Call handlers in goroutines to avoid blocking
-	for _, handler := range handlers {
-		go eb.safeHandlerCall(handler, event)
+	for _, sub := range targets {
+		sub.enqueue(event)
+		eb.checkSlowConsumer(sub)
+	}
+}
+
+// enqueue adds an event to the subscriber queue, dropping the oldest queued event if it is full.
+// Only the dispatch goroutine enqueues, so the loop always makes progress.
+func (s *subscription) enqueue(event Event) {
+	for {
+		select {
+		case s.queue <- event:
+			return
+		default:
+		}
+
+		select {
+		case <-s.queue:
+			s.dropped.Add(1)
+		default:
+		}
 	}
 }
 
+// deliver runs in a goroutine per subscription and calls its handler for each queued event
+func (eb *DefaultEventBus) deliver(sub *subscription) {
+	for {
+		select {
+		case event, ok := <-sub.queue:
+			if !ok {
+				return
+			}
+			eb.safeHandlerCall(sub.handler, event)
+			sub.delivered.Add(1)
+		case <-sub.done:
+			return
+		}
+	}
+}
+
+// checkSlowConsumer warns when a subscriber's queue has stayed at least 3/4 full for slowConsumerThreshold
+func (eb *DefaultEventBus) checkSlowConsumer(sub *subscription) {
+	now := time.Now()
+	if len(sub.queue)*4 < cap(sub.queue)*3 {
+		sub.backedUpSince = time.Time{}
+		return
+	}
+
+	if sub.backedUpSince.IsZero() {
+		sub.backedUpSince = now
+		return
+	}
+	if now.Sub(sub.backedUpSince) < slowConsumerThreshold || now.Sub(sub.lastWarning) < slowConsumerWarnInterval {
+		return
+	}
+
+	sub.lastWarning = now
+	fmt.Printf("[EventBus] WARNING: Slow subscriber '%s' (%s): queue %d/%d, %d delivered, %d dropped\n",
+		sub.name, sub.eventType, len(sub.queue), cap(sub.queue), sub.delivered.Load(), sub.dropped.Load())
+}
+
 // safeHandlerCall calls a handler with panic recovery
 func (eb *DefaultEventBus) safeHandlerCall(handler EventHandler, event Event) {
 	defer func() {
@@ -172,6 +308,35 @@ func (eb *DefaultEventBus) GetSubscriberCount(eventType EventType) int {
 	return len(eb.subscribers[eventType])
 }
 
+// Stats returns delivery metrics for the bus and every subscriber
+func (eb *DefaultEventBus) Stats() BusStats {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	stats := BusStats{
+		QueueDepth:  len(eb.eventQueue),
+		Subscribers: make([]SubscriberStats, 0),
+	}
+	for _, subs := range eb.subscribers {
+		for _, sub := range subs {
+			stats.Subscribers = append(stats.Subscribers, SubscriberStats{
+				ID:            sub.id,
+				Name:          sub.name,
+				EventType:     sub.eventType,
+				Delivered:     sub.delivered.Load(),
+				Dropped:       sub.dropped.Load(),
+				QueueDepth:    len(sub.queue),
+				QueueCapacity: cap(sub.queue),
+			})
+		}
+	}
+
+	sort.Slice(stats.Subscribers, func(i, j int) bool {
+		return stats.Subscribers[i].ID < stats.Subscribers[j].ID
+	})
+	return stats
+}
+
 // GetQueueSize returns the current number of events in the queue
 func (eb *DefaultEventBus) GetQueueSize() int {
 	return len(eb.eventQueue)
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it is true or the timeout expires
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func subscriberStats(t *testing.T, bus *DefaultEventBus, id SubscriptionID) SubscriberStats {
+	t.Helper()
+	for _, s := range bus.Stats().Subscribers {
+		if s.ID == id {
+			return s
+		}
+	}
+	t.Fatalf("subscriber %d not found in stats", id)
+	return SubscriberStats{}
+}
+
+func TestSlowSubscriberDropsOldest(t *testing.T) {
+	bus := NewEventBus(1000)
+	defer bus.Stop()
+	bus.SetSubscriberBufferSize(10)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	received := make(chan int, 100)
+
+	id := bus.SubscribeNamed("slow", EventTypeBotProgress, func(e Event) {
+		seq := e.Data["seq"].(int)
+		if seq == 0 {
+			close(started)
+			<-release
+		}
+		received <- seq
+	})
+
+	publish := func(seq int) {
+		bus.Publish(Event{Type: EventTypeBotProgress, Data: map[string]interface{}{"seq": seq}})
+	}
+
+	// Block the handler on the first event, then flood the subscriber
+	publish(0)
+	<-started
+	for seq := 1; seq < 100; seq++ {
+		publish(seq)
+	}
+
+	// Events 1-89 are dropped; the queue keeps the newest 10
+	waitFor(t, 2*time.Second, func() bool {
+		stats := subscriberStats(t, bus, id)
+		return stats.Dropped == 89 && stats.QueueDepth == 10
+	})
+
+	stats := subscriberStats(t, bus, id)
+	if stats.Name != "slow" || stats.QueueCapacity != 10 {
+		t.Errorf("stats = %+v, want name 'slow' and capacity 10", stats)
+	}
+
+	close(release)
+
+	want := []int{0, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99}
+	for i, w := range want {
+		select {
+		case got := <-received:
+			if got != w {
+				t.Fatalf("event %d: got seq %d, want %d", i, got, w)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("event %d: timed out waiting for seq %d", i, w)
+		}
+	}
+
+	waitFor(t, 2*time.Second, func() bool {
+		return subscriberStats(t, bus, id).Delivered == uint64(len(want))
+	})
+}
+
+func TestSlowSubscriberDoesNotBlockOthers(t *testing.T) {
+	bus := NewEventBus(1000)
+	defer bus.Stop()
+	bus.SetSubscriberBufferSize(5)
+
+	release := make(chan struct{})
+	defer close(release)
+	bus.Subscribe(EventTypeBotProgress, func(e Event) {
+		<-release
+	})
+
+	bus.SetSubscriberBufferSize(100)
+	fastID := bus.Subscribe(EventTypeBotProgress, func(e Event) {})
+
+	for i := 0; i < 50; i++ {
+		bus.Publish(Event{Type: EventTypeBotProgress})
+	}
+
+	waitFor(t, 2*time.Second, func() bool {
+		return subscriberStats(t, bus, fastID).Delivered == 50
+	})
+	if dropped := subscriberStats(t, bus, fastID).Dropped; dropped != 0 {
+		t.Errorf("fast subscriber dropped %d events, want 0", dropped)
+	}
+}
+
+func TestUnsubscribeRemovesStats(t *testing.T) {
+	bus := NewEventBus(10)
+	defer bus.Stop()
+
+	id := bus.Subscribe(EventTypeBotStarted, func(e Event) {})
+	bus.Unsubscribe(id)
+
+	for _, s := range bus.Stats().Subscribers {
+		if s.ID == id {
+			t.Fatalf("unsubscribed subscriber %d still reported in stats", id)
+		}
+	}
+}
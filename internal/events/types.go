@@ -13,6 +13,10 @@ const (
 	EventTypeGroupLaunched      EventType = "group.launched"
 	EventTypeGroupStopped       EventType = "group.stopped"
 	EventTypeGroupStatusChanged EventType = "group.status_changed"
+	EventTypeGroupCompleted     EventType = "group.completed" // All bots finished on their own (not stopped)
+
+	// Emitted when a group's LaunchOptions.MaxRunDuration elapses and its bots are asked to stop
+	EventTypeGroupTimeLimitReached EventType = "group.time_limit_reached"
 
 	// Bot events
 	EventTypeBotStarted   EventType = "bot.started"
@@ -21,6 +25,12 @@ const (
 	EventTypeBotCompleted EventType = "bot.completed"
 	EventTypeBotProgress  EventType = "bot.progress"
 
+	// Emitted when a bot's restart policy gives up after max_retries consecutive failures
+	EventTypeCircuitBreakerTripped EventType = "bot.circuit_breaker_tripped"
+
+	// Emitted by the reportgodpack action
+	EventTypeGodPackFound EventType = "pack.god_pack"
+
 	// Instance events
 	EventTypeInstanceHealthChanged EventType = "instance.health_changed"
 	EventTypeInstanceAssigned      EventType = "instance.assigned"
@@ -31,8 +41,16 @@ const (
 	EventTypeAccountReturned   EventType = "account.returned"
 	EventTypeAccountCompleted  EventType = "account.completed"
 	EventTypeAccountFailed     EventType = "account.failed"
+	EventTypeAccountBanned     EventType = "account.banned"
 	EventTypePoolRefreshed     EventType = "pool.refreshed"
 
+	// Emitted when a bot finds its group's pool empty and goes idle waiting for accounts
+	EventTypePoolExhausted EventType = "pool.exhausted"
+
+	// Maintenance events
+	EventTypeMaintenanceDetected EventType = "maintenance.detected"
+	EventTypeMaintenanceEnded    EventType = "maintenance.ended"
+
 	// Error events
 	EventTypeError EventType = "error"
 )
@@ -56,6 +74,9 @@ type EventBus interface {
 	// Subscribe registers a handler for a specific event type
 	Subscribe(eventType EventType, handler EventHandler) SubscriptionID
 
+	// SubscribeNamed registers a handler with a name shown in stats and slow-consumer warnings
+	SubscribeNamed(name string, eventType EventType, handler EventHandler) SubscriptionID
+
 	// Unsubscribe removes a subscription by ID
 	Unsubscribe(id SubscriptionID)
 
@@ -67,6 +88,9 @@ type EventBus interface {
 
 	// Stop stops the event bus and drains remaining events
 	Stop()
+
+	// Stats returns delivered/dropped counts and queue depth per subscriber
+	Stats() BusStats
 }
 
 // Helper functions to create common events
@@ -98,6 +122,32 @@ func NewGroupStoppedEvent(groupName string) Event {
 	}
 }
 
+// NewGroupCompletedEvent creates a group completed event
+func NewGroupCompletedEvent(groupName string, accountsProcessed int) Event {
+	return Event{
+		Type:      EventTypeGroupCompleted,
+		Source:    "orchestrator",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":         groupName,
+			"accounts_processed": accountsProcessed,
+		},
+	}
+}
+
+// NewGroupTimeLimitReachedEvent creates a group time limit reached event
+func NewGroupTimeLimitReachedEvent(groupName string, maxRunDuration time.Duration) Event {
+	return Event{
+		Type:      EventTypeGroupTimeLimitReached,
+		Source:    "orchestrator",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":       groupName,
+			"max_run_duration": maxRunDuration.String(),
+		},
+	}
+}
+
 // NewBotStartedEvent creates a bot started event
 func NewBotStartedEvent(groupName string, instanceID int) Event {
 	return Event{
@@ -151,6 +201,38 @@ func NewBotCompletedEvent(groupName string, instanceID int) Event {
 	}
 }
 
+// NewCircuitBreakerTrippedEvent creates an event for a bot that stopped after repeated failures
+func NewCircuitBreakerTrippedEvent(groupName string, instanceID int, routineName string, failures int, err error) Event {
+	return Event{
+		Type:      EventTypeCircuitBreakerTripped,
+		Source:    "orchestrator",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":   groupName,
+			"instance_id":  instanceID,
+			"routine_name": routineName,
+			"failures":     failures,
+			"error":        err.Error(),
+		},
+	}
+}
+
+// NewGodPackFoundEvent creates a god pack found event
+func NewGodPackFoundEvent(groupName string, instanceID int, accountID, deviceAccount, packName string) Event {
+	return Event{
+		Type:      EventTypeGodPackFound,
+		Source:    "orchestrator",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":     groupName,
+			"instance_id":    instanceID,
+			"account_id":     accountID,
+			"device_account": deviceAccount,
+			"pack_name":      packName,
+		},
+	}
+}
+
 // NewInstanceHealthChangedEvent creates an instance health changed event
 func NewInstanceHealthChangedEvent(instanceID int, isReady, wasReady, windowDetected, adbConnected bool) Event {
 	return Event{
@@ -181,6 +263,21 @@ func NewAccountCheckedOutEvent(poolName, accountID, deviceAccount string) Event
 	}
 }
 
+// NewAccountBannedEvent creates an account banned event
+func NewAccountBannedEvent(groupName string, instanceID int, accountID, deviceAccount string) Event {
+	return Event{
+		Type:      EventTypeAccountBanned,
+		Source:    "orchestrator",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":     groupName,
+			"instance_id":    instanceID,
+			"account_id":     accountID,
+			"device_account": deviceAccount,
+		},
+	}
+}
+
 // NewPoolRefreshedEvent creates a pool refreshed event
 func NewPoolRefreshedEvent(poolName string, totalAccounts, availableAccounts int) Event {
 	return Event{
@@ -195,6 +292,51 @@ func NewPoolRefreshedEvent(poolName string, totalAccounts, availableAccounts int
 	}
 }
 
+// NewPoolExhaustedEvent creates a pool exhausted event. maxWait is how long the bot
+// idles for accounts before stopping (0 = it stops right away).
+func NewPoolExhaustedEvent(groupName string, instanceID int, poolName string, maxWait time.Duration) Event {
+	return Event{
+		Type:      EventTypePoolExhausted,
+		Source:    "orchestrator",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":  groupName,
+			"instance_id": instanceID,
+			"pool_name":   poolName,
+			"waiting":     maxWait > 0,
+			"max_wait":    maxWait.String(),
+		},
+	}
+}
+
+// NewMaintenanceDetectedEvent creates a maintenance detected event
+func NewMaintenanceDetectedEvent(groupName string, instanceID int, pausedBots int, nextCheck time.Time) Event {
+	return Event{
+		Type:      EventTypeMaintenanceDetected,
+		Source:    "maintenance_monitor",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"group_name":  groupName,
+			"instance_id": instanceID,
+			"paused_bots": pausedBots,
+			"next_check":  nextCheck,
+		},
+	}
+}
+
+// NewMaintenanceEndedEvent creates a maintenance ended event
+func NewMaintenanceEndedEvent(resumedBots int, duration time.Duration) Event {
+	return Event{
+		Type:      EventTypeMaintenanceEnded,
+		Source:    "maintenance_monitor",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"resumed_bots": resumedBots,
+			"duration":     duration,
+		},
+	}
+}
+
 // NewErrorEvent creates an error event
 func NewErrorEvent(source, component string, err error, metadata map[string]interface{}) Event {
 	data := map[string]interface{}{
@@ -420,7 +420,7 @@ func (a *AccountTab) showInjectAccountDialog(accountFile *accounts.AccountFile)
 			a.controller.logTab.AddLog(LogLevelInfo, instanceIndex, fmt.Sprintf("Injecting account: %s", accountFile.Filename))
 
 			// Inject the account
-			err := accounts.InjectAccount(adbPath, inst.MuMu.ADBPort, accountFile.FilePath)
+			err := accounts.InjectAccount(adbPath, inst.MuMu.ADBPort, cfg.GamePackageName(), accountFile.FilePath)
 
 			// Update UI on main thread
 			fyne.Do(func() {
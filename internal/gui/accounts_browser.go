@@ -0,0 +1,771 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// accountsBrowserPageSize is the number of accounts shown per page
+const accountsBrowserPageSize = 100
+
+// accountsBrowserColumns are the table columns (sortField is empty for unsortable columns)
+var accountsBrowserColumns = []struct {
+	title     string
+	sortField string
+	width     float32
+}{
+	{"", "", 36}, // Selection
+	{"ID", database.SortByID, 60},
+	{"Username", database.SortByUsername, 160},
+	{"Friend Code", "", 150},
+	{"Packs", database.SortByPacks, 80},
+	{"Shinedust", database.SortByShinedust, 100},
+	{"Status", database.SortByStatus, 90},
+	{"Pool Status", "", 100},
+	{"Last Used", database.SortByLastUsed, 130},
+}
+
+// AccountsBrowserTab lists every account in the database with search, sorting and paging
+type AccountsBrowserTab struct {
+	controller *Controller
+	db         *database.DB
+
+	// Query state
+	sort   database.SortSpec
+	page   int
+	result *database.AccountSearchResult
+
+	// Bulk selection by device account (kept across pages and searches)
+	selected map[string]bool
+
+	// Widgets
+	searchEntry *widget.Entry
+	table       *widget.Table
+	pageLabel   *widget.Label
+	prevBtn     *widget.Button
+	nextBtn     *widget.Button
+	detailsArea *fyne.Container
+
+	selectionLabel *widget.Label
+	bulkStatusBtn  *widget.Button
+	bulkTagBtn     *widget.Button
+	bulkUntagBtn   *widget.Button
+	bulkDeleteBtn  *widget.Button
+	mergeBtn       *widget.Button
+}
+
+// NewAccountsBrowserTab creates a new accounts browser tab
+func NewAccountsBrowserTab(ctrl *Controller, db *database.DB) *AccountsBrowserTab {
+	return &AccountsBrowserTab{
+		controller: ctrl,
+		db:         db,
+		sort:       database.SortSpec{Field: database.SortByLastUsed, Descending: true},
+		result:     &database.AccountSearchResult{PageSize: accountsBrowserPageSize},
+		selected:   make(map[string]bool),
+	}
+}
+
+// Build constructs the UI
+func (t *AccountsBrowserTab) Build() fyne.CanvasObject {
+	// Header
+	header := widget.NewLabelWithStyle("Database - Account Browser", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+
+	// Search box (queries as the user types)
+	t.searchEntry = widget.NewEntry()
+	t.searchEntry.SetPlaceHolder("Search username, friend code or ID...")
+	t.searchEntry.OnChanged = func(string) {
+		t.page = 0
+		t.refresh()
+	}
+
+	// Paging
+	t.pageLabel = widget.NewLabel("")
+	t.prevBtn = widget.NewButton("◀", func() {
+		if t.page > 0 {
+			t.page--
+			t.refresh()
+		}
+	})
+	t.nextBtn = widget.NewButton("▶", func() {
+		if t.page < t.result.PageCount()-1 {
+			t.page++
+			t.refresh()
+		}
+	})
+
+	refreshBtn := widget.NewButton("Refresh", func() {
+		t.refresh()
+	})
+
+	toolbar := container.NewBorder(nil, nil, nil,
+		container.NewHBox(t.prevBtn, t.pageLabel, t.nextBtn, refreshBtn),
+		t.searchEntry,
+	)
+
+	// Bulk actions
+	t.selectionLabel = widget.NewLabel("")
+	selectPageBtn := widget.NewButton("Select Page", func() {
+		for _, acc := range t.result.Accounts {
+			t.selected[acc.DeviceAccount] = true
+		}
+		t.selectionChanged()
+	})
+	clearSelectionBtn := widget.NewButton("Clear Selection", func() {
+		t.selected = make(map[string]bool)
+		t.selectionChanged()
+	})
+	t.bulkStatusBtn = widget.NewButton("Set Status...", func() {
+		t.showBulkStatusDialog()
+	})
+	t.bulkTagBtn = widget.NewButton("Add Tag...", func() {
+		t.showBulkTagDialog(false)
+	})
+	t.bulkUntagBtn = widget.NewButton("Remove Tag...", func() {
+		t.showBulkTagDialog(true)
+	})
+	t.bulkDeleteBtn = widget.NewButtonWithIcon("Delete...", theme.DeleteIcon(), func() {
+		t.showBulkDeleteDialog()
+	})
+	t.bulkDeleteBtn.Importance = widget.DangerImportance
+	t.mergeBtn = widget.NewButton("Merge...", func() {
+		t.showMergeDialog()
+	})
+
+	bulkBar := container.NewHBox(
+		t.selectionLabel,
+		selectPageBtn,
+		clearSelectionBtn,
+		widget.NewSeparator(),
+		t.bulkStatusBtn,
+		t.bulkTagBtn,
+		t.bulkUntagBtn,
+		t.bulkDeleteBtn,
+		t.mergeBtn,
+	)
+	t.selectionChanged()
+
+	// Accounts table
+	t.table = t.buildTable()
+
+	// Details panel
+	t.detailsArea = container.NewVBox(widget.NewLabel("Select an account to see its details"))
+
+	split := container.NewHSplit(t.table, container.NewVScroll(t.detailsArea))
+	split.SetOffset(0.6)
+
+	t.refresh()
+
+	return container.NewBorder(
+		container.NewVBox(header, toolbar, bulkBar),
+		nil,
+		nil,
+		nil,
+		split,
+	)
+}
+
+// buildTable creates the accounts table with clickable sort headers
+func (t *AccountsBrowserTab) buildTable() *widget.Table {
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(t.result.Accounts), len(accountsBrowserColumns)
+		},
+		func() fyne.CanvasObject {
+			return newContextLabel()
+		},
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			if id.Row >= len(t.result.Accounts) {
+				return
+			}
+			acc := t.result.Accounts[id.Row]
+			label := cell.(*contextLabel)
+			if id.Col == 0 {
+				label.SetText(selectionMark(t.selected[acc.DeviceAccount]))
+			} else {
+				label.SetText(accountsBrowserCell(acc, id.Col))
+			}
+			label.onSecondaryTap = func(e *fyne.PointEvent) {
+				t.showAccountMenu(acc, e.AbsolutePosition)
+			}
+		},
+	)
+
+	table.ShowHeaderRow = true
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	table.UpdateHeader = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		btn := cell.(*widget.Button)
+		column := accountsBrowserColumns[id.Col]
+
+		title := column.title
+		if column.sortField != "" && column.sortField == t.sort.Field {
+			if t.sort.Descending {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+		btn.SetText(title)
+
+		if column.sortField == "" {
+			btn.Disable()
+			btn.OnTapped = nil
+			return
+		}
+		btn.Enable()
+		btn.OnTapped = func() {
+			t.sortBy(column.sortField)
+		}
+	}
+
+	for i, column := range accountsBrowserColumns {
+		table.SetColumnWidth(i, column.width)
+	}
+
+	table.OnSelected = func(id widget.TableCellID) {
+		if id.Row < 0 || id.Row >= len(t.result.Accounts) {
+			return
+		}
+		acc := t.result.Accounts[id.Row]
+
+		// Clicking the selection column toggles the account
+		if id.Col == 0 {
+			if t.selected[acc.DeviceAccount] {
+				delete(t.selected, acc.DeviceAccount)
+			} else {
+				t.selected[acc.DeviceAccount] = true
+			}
+			t.table.Unselect(id)
+			t.selectionChanged()
+			return
+		}
+
+		t.showDetails(acc)
+	}
+
+	return table
+}
+
+// sortBy sorts by field, toggling the direction if already sorted by it
+func (t *AccountsBrowserTab) sortBy(field string) {
+	if t.sort.Field == field {
+		t.sort.Descending = !t.sort.Descending
+	} else {
+		t.sort = database.SortSpec{Field: field}
+	}
+	t.page = 0
+	t.refresh()
+}
+
+// refresh re-runs the search for the current query, sort and page
+func (t *AccountsBrowserTab) refresh() {
+	// Don't refresh if the table isn't built yet
+	if t.table == nil {
+		return
+	}
+
+	if t.db == nil {
+		t.pageLabel.SetText("Database not initialized")
+		return
+	}
+
+	result, err := t.db.SearchAccounts(t.searchEntry.Text, t.sort, t.page, accountsBrowserPageSize)
+	if err != nil {
+		if t.controller.window != nil {
+			dialog.ShowError(fmt.Errorf("failed to search accounts: %w", err), t.controller.window)
+		}
+		return
+	}
+
+	t.result = result
+	t.pageLabel.SetText(fmt.Sprintf("Page %d/%d (%d accounts)", result.Page+1, result.PageCount(), result.Total))
+	if result.Page > 0 {
+		t.prevBtn.Enable()
+	} else {
+		t.prevBtn.Disable()
+	}
+	if result.Page < result.PageCount()-1 {
+		t.nextBtn.Enable()
+	} else {
+		t.nextBtn.Disable()
+	}
+
+	t.table.UnselectAll()
+	t.table.Refresh()
+}
+
+// showDetails shows an account's details and recent activity in the side panel
+func (t *AccountsBrowserTab) showDetails(acc *database.Account) {
+	details := widget.NewForm(
+		widget.NewFormItem("Account ID", widget.NewLabel(fmt.Sprintf("%d", acc.ID))),
+		widget.NewFormItem("Device Account", widget.NewLabel(acc.DeviceAccount)),
+		widget.NewFormItem("Username", widget.NewLabel(stringOrEmpty(acc.Username))),
+		widget.NewFormItem("Friend Code", widget.NewLabel(stringOrEmpty(acc.FriendCode))),
+		widget.NewFormItem("Status", widget.NewLabel(accountStatusText(acc))),
+		widget.NewFormItem("Pool Status", widget.NewLabel(acc.PoolStatus)),
+		widget.NewFormItem("Failures", widget.NewLabel(fmt.Sprintf("%d", acc.FailureCount))),
+		widget.NewFormItem("Last Error", widget.NewLabel(stringOrEmpty(acc.LastError))),
+		widget.NewFormItem("Ban Risk", t.buildRiskLabel(acc.DeviceAccount)),
+		widget.NewFormItem("Tags", t.buildTagsLabel(acc.DeviceAccount)),
+		widget.NewFormItem("Level", widget.NewLabel(fmt.Sprintf("%d", acc.AccountLevel))),
+		widget.NewFormItem("Packs Opened", widget.NewLabel(fmt.Sprintf("%d", acc.PacksOpened))),
+		widget.NewFormItem("Wonder Picks", widget.NewLabel(fmt.Sprintf("%d", acc.WonderPicksDone))),
+		widget.NewFormItem("Resources", widget.NewLabel(fmt.Sprintf("💎 %d  ⏳ %d  🪙 %d  Pack Points %d",
+			acc.Shinedust, acc.Hourglasses, acc.Pokegold, acc.PackPoints))),
+		widget.NewFormItem("Created", widget.NewLabel(acc.CreatedAt.Format("2006-01-02 15:04:05"))),
+		widget.NewFormItem("Last Used", widget.NewLabel(timeOrEmpty(acc.LastUsedAt))),
+		widget.NewFormItem("Notes", widget.NewLabel(stringOrEmpty(acc.Notes))),
+	)
+
+	t.detailsArea.Objects = []fyne.CanvasObject{
+		widget.NewLabelWithStyle(accountDisplayName(acc), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		details,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Recent Activity", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		t.buildRecentActivity(acc.ID),
+	}
+	t.detailsArea.Refresh()
+}
+
+// buildRiskLabel shows an account's ban risk score and the factors behind it
+func (t *AccountsBrowserTab) buildRiskLabel(deviceAccount string) fyne.CanvasObject {
+	score, factors, err := t.db.AccountRiskScore(deviceAccount)
+	if err != nil {
+		return widget.NewLabel(fmt.Sprintf("Unavailable: %v", err))
+	}
+
+	text := fmt.Sprintf("%.0f / 100", score)
+	if len(factors) > 0 {
+		text += "\n" + strings.Join(factors, "\n")
+	}
+	label := widget.NewLabel(text)
+	label.Wrapping = fyne.TextWrapWord
+	return label
+}
+
+// buildTagsLabel lists an account's tags
+func (t *AccountsBrowserTab) buildTagsLabel(deviceAccount string) fyne.CanvasObject {
+	tags, err := t.db.AccountTags(deviceAccount)
+	if err != nil {
+		return widget.NewLabel(fmt.Sprintf("Unavailable: %v", err))
+	}
+	if len(tags) == 0 {
+		return widget.NewLabel("-")
+	}
+	label := widget.NewLabel(strings.Join(tags, ", "))
+	label.Wrapping = fyne.TextWrapWord
+	return label
+}
+
+// buildRecentActivity lists the latest activities for an account
+func (t *AccountsBrowserTab) buildRecentActivity(accountID int) fyne.CanvasObject {
+	activities, err := t.db.GetRecentActivityForAccount(accountID, 20)
+	if err != nil {
+		return widget.NewLabel(fmt.Sprintf("Failed to load activity: %v", err))
+	}
+	if len(activities) == 0 {
+		return widget.NewLabel("No activity recorded")
+	}
+
+	list := container.NewVBox()
+	for _, activity := range activities {
+		line := fmt.Sprintf("%s  %s  [%s]",
+			activity.StartedAt.Format("2006-01-02 15:04"), activity.ActivityType, activity.Status)
+		if activity.ErrorMessage != nil && *activity.ErrorMessage != "" {
+			line += " - " + *activity.ErrorMessage
+		}
+		list.Add(widget.NewLabel(line))
+	}
+	return list
+}
+
+// showAccountMenu shows the right-click menu for an account
+func (t *AccountsBrowserTab) showAccountMenu(acc *database.Account, position fyne.Position) {
+	var items []*fyne.MenuItem
+	for _, status := range []accountpool.AccountStatus{
+		accountpool.AccountStatusAvailable,
+		accountpool.AccountStatusFailed,
+		accountpool.AccountStatusBanned,
+		accountpool.AccountStatusCompleted,
+	} {
+		status := status
+		item := fyne.NewMenuItem(fmt.Sprintf("Set Status: %s", status), func() {
+			t.showSetStatusDialog(acc, status)
+		})
+		item.Checked = acc.PoolStatus == string(status)
+		items = append(items, item)
+	}
+	items = append(items,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Details", func() { t.showDetails(acc) }),
+	)
+
+	widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), t.controller.window.Canvas(), position)
+}
+
+// showSetStatusDialog confirms a manual pool status change
+func (t *AccountsBrowserTab) showSetStatusDialog(acc *database.Account, status accountpool.AccountStatus) {
+	resetCheck := widget.NewCheck("Clear failure count and last error", nil)
+	resetCheck.SetChecked(status == accountpool.AccountStatusAvailable)
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Set %s from '%s' to '%s'?", accountDisplayName(acc), acc.PoolStatus, status)),
+		resetCheck,
+	)
+
+	dialog.ShowCustomConfirm("Set Account Status", "Set Status", "Cancel", content, func(confirmed bool) {
+		if confirmed {
+			t.setAccountStatus(acc, status, resetCheck.Checked)
+		}
+	}, t.controller.window)
+}
+
+// setAccountStatus updates the account in the database and in any open pools
+func (t *AccountsBrowserTab) setAccountStatus(acc *database.Account, status accountpool.AccountStatus, resetFailures bool) {
+	if err := t.db.SetAccountStatus(acc.DeviceAccount, string(status), resetFailures); err != nil {
+		dialog.ShowError(err, t.controller.window)
+		return
+	}
+
+	pools := 0
+	if t.controller.poolManager != nil {
+		pools = t.controller.poolManager.SetAccountStatus(acc.DeviceAccount, status, resetFailures)
+	}
+	if t.controller.logTab != nil {
+		t.controller.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Set account %s status to %s (%d open pools updated)",
+			acc.DeviceAccount, status, pools))
+	}
+
+	t.refresh()
+}
+
+// selectionChanged updates the bulk action bar and selection marks
+func (t *AccountsBrowserTab) selectionChanged() {
+	if t.selectionLabel == nil {
+		return
+	}
+
+	t.selectionLabel.SetText(fmt.Sprintf("%d selected", len(t.selected)))
+	if len(t.selected) > 0 {
+		t.bulkStatusBtn.Enable()
+		t.bulkTagBtn.Enable()
+		t.bulkUntagBtn.Enable()
+		t.bulkDeleteBtn.Enable()
+	} else {
+		t.bulkStatusBtn.Disable()
+		t.bulkTagBtn.Disable()
+		t.bulkUntagBtn.Disable()
+		t.bulkDeleteBtn.Disable()
+	}
+	if len(t.selected) == 2 {
+		t.mergeBtn.Enable()
+	} else {
+		t.mergeBtn.Disable()
+	}
+
+	if t.table != nil {
+		t.table.Refresh()
+	}
+}
+
+// selectedAccounts returns the selected device accounts in a stable order
+func (t *AccountsBrowserTab) selectedAccounts() []string {
+	deviceAccounts := make([]string, 0, len(t.selected))
+	for deviceAccount := range t.selected {
+		deviceAccounts = append(deviceAccounts, deviceAccount)
+	}
+	sort.Strings(deviceAccounts)
+	return deviceAccounts
+}
+
+// showBulkStatusDialog asks for a pool status to apply to all selected accounts
+func (t *AccountsBrowserTab) showBulkStatusDialog() {
+	deviceAccounts := t.selectedAccounts()
+	if len(deviceAccounts) == 0 {
+		return
+	}
+
+	statusSelect := widget.NewSelect([]string{
+		string(accountpool.AccountStatusAvailable),
+		string(accountpool.AccountStatusFailed),
+		string(accountpool.AccountStatusBanned),
+		string(accountpool.AccountStatusCompleted),
+	}, nil)
+	statusSelect.SetSelectedIndex(0)
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Set the pool status of %d selected accounts to:", len(deviceAccounts))),
+		statusSelect,
+	)
+
+	dialog.ShowCustomConfirm("Bulk Set Status", "Set Status", "Cancel", content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		status := accountpool.AccountStatus(statusSelect.Selected)
+
+		count, err := t.db.BulkUpdateStatus(deviceAccounts, string(status))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("no accounts were changed: %w", err), t.controller.window)
+			return
+		}
+
+		if t.controller.poolManager != nil {
+			for _, deviceAccount := range deviceAccounts {
+				t.controller.poolManager.SetAccountStatus(deviceAccount, status, false)
+			}
+		}
+
+		t.bulkActionDone(fmt.Sprintf("Set status of %d accounts to %s", count, status), count, len(deviceAccounts))
+	}, t.controller.window)
+}
+
+// showBulkTagDialog asks for a tag to add to (or remove from) all selected accounts
+func (t *AccountsBrowserTab) showBulkTagDialog(remove bool) {
+	deviceAccounts := t.selectedAccounts()
+	if len(deviceAccounts) == 0 {
+		return
+	}
+
+	// Offer existing tags, but allow typing a new one
+	existing, err := t.db.ListTags()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load tags: %w", err), t.controller.window)
+		return
+	}
+	tagEntry := widget.NewSelectEntry(existing)
+	tagEntry.SetPlaceHolder("e.g. farm-a")
+
+	title, confirmText, prompt := "Bulk Add Tag", "Add Tag", "Tag %d selected accounts with:"
+	if remove {
+		title, confirmText, prompt = "Bulk Remove Tag", "Remove Tag", "Remove this tag from %d selected accounts:"
+	}
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf(prompt, len(deviceAccounts))),
+		tagEntry,
+		widget.NewLabel("Pools that select by this tag pick up the change on their next refresh."),
+	)
+
+	dialog.ShowCustomConfirm(title, confirmText, "Cancel", content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		tag, err := database.NormalizeTag(tagEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, t.controller.window)
+			return
+		}
+
+		var summary string
+		count, requested := 0, len(deviceAccounts)
+		if remove {
+			count, err = t.db.BulkUntagAccounts(deviceAccounts, tag)
+			summary = fmt.Sprintf("Removed tag '%s' from %d accounts", tag, count)
+			requested = count // Accounts without the tag aren't skipped
+		} else {
+			count, err = t.db.BulkTagAccounts(deviceAccounts, tag)
+			summary = fmt.Sprintf("Tagged %d accounts with '%s'", count, tag)
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("no tags were changed: %w", err), t.controller.window)
+			return
+		}
+
+		// Pools that select by tag resolve their members from the database
+		if t.controller.poolManager != nil {
+			if err := t.controller.poolManager.RefreshOpenPools(); err != nil && t.controller.logTab != nil {
+				t.controller.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to refresh pools after tagging: %v", err))
+			}
+		}
+
+		t.bulkActionDone(summary, count, requested)
+	}, t.controller.window)
+}
+
+// showBulkDeleteDialog confirms deleting all selected accounts
+func (t *AccountsBrowserTab) showBulkDeleteDialog() {
+	deviceAccounts := t.selectedAccounts()
+	if len(deviceAccounts) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Permanently delete %d selected accounts?\n\nTheir activity, pack and card history will also be deleted.", len(deviceAccounts))
+	dialog.ShowConfirm("Delete Accounts", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		count, err := t.db.BulkDelete(deviceAccounts)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("no accounts were deleted: %w", err), t.controller.window)
+			return
+		}
+
+		// Drop deleted accounts from open pools
+		if t.controller.poolManager != nil {
+			if err := t.controller.poolManager.RefreshOpenPools(); err != nil && t.controller.logTab != nil {
+				t.controller.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to refresh pools after delete: %v", err))
+			}
+		}
+
+		t.detailsArea.Objects = []fyne.CanvasObject{widget.NewLabel("Select an account to see its details")}
+		t.detailsArea.Refresh()
+
+		t.bulkActionDone(fmt.Sprintf("Deleted %d accounts", count), count, len(deviceAccounts))
+	}, t.controller.window)
+}
+
+// showMergeDialog asks which of the two selected accounts to keep and merges the other into it
+func (t *AccountsBrowserTab) showMergeDialog() {
+	deviceAccounts := t.selectedAccounts()
+	if len(deviceAccounts) != 2 {
+		return
+	}
+
+	// Accounts assigned in open pools may not be checked out in the database yet
+	if t.controller.poolManager != nil {
+		for _, deviceAccount := range deviceAccounts {
+			if pools := t.controller.poolManager.PoolsUsingAccount(deviceAccount); len(pools) > 0 {
+				dialog.ShowError(fmt.Errorf("account '%s' is in use in pool %s and cannot be merged",
+					deviceAccount, strings.Join(pools, ", ")), t.controller.window)
+				return
+			}
+		}
+	}
+
+	keepSelect := widget.NewSelect(deviceAccounts, nil)
+	keepSelect.SetSelectedIndex(0)
+
+	content := container.NewVBox(
+		widget.NewLabel("Keep this account and merge the other one into it:"),
+		keepSelect,
+		widget.NewLabel("Activity, packs, cards and errors move to the kept account, which takes\nthe higher resources and latest stats. The other account is deleted."),
+	)
+
+	dialog.ShowCustomConfirm("Merge Accounts", "Merge", "Cancel", content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		keep := keepSelect.Selected
+		merge := deviceAccounts[0]
+		if merge == keep {
+			merge = deviceAccounts[1]
+		}
+
+		if err := t.db.MergeAccounts(keep, merge); err != nil {
+			dialog.ShowError(fmt.Errorf("accounts were not merged: %w", err), t.controller.window)
+			return
+		}
+
+		// Drop the merged account from open pools
+		if t.controller.poolManager != nil {
+			if err := t.controller.poolManager.RefreshOpenPools(); err != nil && t.controller.logTab != nil {
+				t.controller.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to refresh pools after merge: %v", err))
+			}
+		}
+
+		t.detailsArea.Objects = []fyne.CanvasObject{widget.NewLabel("Select an account to see its details")}
+		t.detailsArea.Refresh()
+
+		t.bulkActionDone(fmt.Sprintf("Merged account %s into %s", merge, keep), 1, 1)
+	}, t.controller.window)
+}
+
+// bulkActionDone logs a completed bulk action, reports skipped accounts and clears the selection
+func (t *AccountsBrowserTab) bulkActionDone(summary string, count, requested int) {
+	if t.controller.logTab != nil {
+		t.controller.logTab.AddLog(LogLevelInfo, 0, summary)
+	}
+	if skipped := requested - count; skipped > 0 {
+		dialog.ShowInformation("Bulk Action",
+			fmt.Sprintf("%s.\n%d selected accounts no longer exist and were skipped.", summary, skipped),
+			t.controller.window)
+	}
+
+	t.selected = make(map[string]bool)
+	t.selectionChanged()
+	t.refresh()
+}
+
+// accountsBrowserCell returns the text for a table cell
+func accountsBrowserCell(acc *database.Account, col int) string {
+	switch accountsBrowserColumns[col].title {
+	case "ID":
+		return fmt.Sprintf("%d", acc.ID)
+	case "Username":
+		return accountDisplayName(acc)
+	case "Friend Code":
+		return stringOrEmpty(acc.FriendCode)
+	case "Packs":
+		return fmt.Sprintf("%d", acc.PacksOpened)
+	case "Shinedust":
+		return fmt.Sprintf("%d", acc.Shinedust)
+	case "Status":
+		return accountStatusText(acc)
+	case "Pool Status":
+		return acc.PoolStatus
+	case "Last Used":
+		if acc.LastUsedAt == nil {
+			return "Never"
+		}
+		return acc.LastUsedAt.Format("2006-01-02 15:04")
+	}
+	return ""
+}
+
+// accountDisplayName returns the username, falling back to the (truncated) device account
+func accountDisplayName(acc *database.Account) string {
+	if acc.Username != nil && strings.TrimSpace(*acc.Username) != "" {
+		return *acc.Username
+	}
+	return acc.DeviceAccount[:min(20, len(acc.DeviceAccount))]
+}
+
+// selectionMark returns the selection column text
+func selectionMark(selected bool) string {
+	if selected {
+		return "☑"
+	}
+	return "☐"
+}
+
+// accountStatusText describes whether an account is active, inactive or banned
+func accountStatusText(acc *database.Account) string {
+	if acc.IsBanned {
+		return "Banned"
+	}
+	if !acc.IsActive {
+		return "Inactive"
+	}
+	return "Active"
+}
+
+// contextLabel is a table cell label that reports right-clicks
+type contextLabel struct {
+	widget.Label
+	onSecondaryTap func(*fyne.PointEvent)
+}
+
+// newContextLabel creates an empty context label
+func newContextLabel() *contextLabel {
+	label := &contextLabel{}
+	label.ExtendBaseWidget(label)
+	return label
+}
+
+// TappedSecondary implements fyne.SecondaryTappable
+func (l *contextLabel) TappedSecondary(e *fyne.PointEvent) {
+	if l.onSecondaryTap != nil {
+		l.onSecondaryTap(e)
+	}
+}
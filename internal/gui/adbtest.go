@@ -54,6 +54,9 @@ func (a *ADBTestTab) Build() fyne.CanvasObject {
 	a.devicesLabel = widget.NewLabel("Devices: None")
 	a.testResultsLabel = widget.NewLabel("")
 
+	// Show results published before the tab was built
+	a.restoreRecentResults()
+
 	// Progress bar (hidden by default)
 	a.progressBar = widget.NewProgressBarInfinite()
 	a.progressBar.Hide()
@@ -94,8 +97,8 @@ func (a *ADBTestTab) Build() fyne.CanvasObject {
 		a.testConnect(a.selectedInstance)
 	})
 
-	killServerBtn := widget.NewButton("Kill ADB Server", func() {
-		a.killADBServer()
+	restartServerBtn := widget.NewButton("Restart ADB Server", func() {
+		a.restartADBServer()
 	})
 
 	launchAppBtn := widget.NewButton("Launch PocketTCG", func() {
@@ -128,7 +131,7 @@ func (a *ADBTestTab) Build() fyne.CanvasObject {
 		a.testButton,
 		testDevicesBtn,
 		testConnectBtn,
-		killServerBtn,
+		restartServerBtn,
 		launchAppBtn,
 		killAppBtn,
 		positionWindowBtn,
@@ -391,25 +394,35 @@ func (a *ADBTestTab) testConnect(instance int) {
 	}()
 }
 
-// killADBServer kills the ADB server
-func (a *ADBTestTab) killADBServer() {
+// restartADBServer restarts the shared ADB server and reconnects the bots' devices
+func (a *ADBTestTab) restartADBServer() {
 	bus := a.controller.GetEventBus()
 
+	adbPath := a.controller.GetConfig().ADB().Path
+	if adbPath == "" {
+		bus.Publish(UpdateLabel("adbtest.results", "❌ ADB path not configured"))
+		return
+	}
+
 	bus.Publish(ShowProgressBar("adbtest"))
 
 	go func() {
-		output, err := a.runADBCommandWithTimeout("kill-server", 5*time.Second)
+		server := adb.ServerFor(adbPath)
+		err := server.Restart()
+		status := server.Status()
 
 		bus.Publish(HideProgressBar("adbtest"))
 
 		if err != nil {
-			bus.Publish(UpdateLabel("adbtest.results", fmt.Sprintf("❌ Failed to kill ADB server: %v", err)))
+			bus.Publish(UpdateLabel("adbtest.results", fmt.Sprintf("❌ Failed to restart ADB server: %v", err)))
+			bus.Publish(AddLog(LogLevelError, 0, fmt.Sprintf("ADB server restart failed: %v", err)))
 			return
 		}
 
-		bus.Publish(UpdateLabel("adbtest.results", fmt.Sprintf("✓ ADB server killed\n\n%s", output)))
-		bus.Publish(UpdateLabel("adbtest.devices", "Devices: Server killed"))
-		bus.Publish(AddLog(LogLevelInfo, 0, "ADB server killed"))
+		bus.Publish(UpdateLabel("adbtest.results", fmt.Sprintf(
+			"✓ ADB server restarted\n\nReconnected devices: %d\nRestarts this session: %d",
+			status.Connections, status.Restarts)))
+		bus.Publish(AddLog(LogLevelInfo, 0, "ADB server restarted"))
 	}()
 }
 
@@ -475,9 +488,10 @@ func (a *ADBTestTab) launchPocketTCG() {
 		}
 
 		// Launch the app
-		// am start -W -n jp.pokemon.pokemontcgp/com.unity3d.player.UnityPlayerActivity -f 0x10018000
+		// am start -W -n <package>/com.unity3d.player.UnityPlayerActivity -f 0x10018000
+		gamePackage := a.controller.GetConfig().GamePackageName()
 		output, err := a.runADBCommandWithTimeout(
-			fmt.Sprintf("-s %s shell am start -W -n jp.pokemon.pokemontcgp/com.unity3d.player.UnityPlayerActivity -f 0x10018000", target),
+			fmt.Sprintf("-s %s shell am start -W -n %s/%s -f 0x10018000", target, gamePackage, accounts.AppActivity),
 			15*time.Second,
 		)
 
@@ -516,7 +530,7 @@ func (a *ADBTestTab) killPocketTCG() {
 
 		// Force stop the app
 		output, err := a.runADBCommandWithTimeout(
-			fmt.Sprintf("-s %s shell am force-stop jp.pokemon.pokemontcgp", target),
+			fmt.Sprintf("-s %s shell am force-stop %s", target, a.controller.GetConfig().GamePackageName()),
 			10*time.Second,
 		)
 
@@ -570,12 +584,7 @@ func (a *ADBTestTab) buildInstanceOptions() []string {
 		config := configs[instanceNum]
 		port := 16384 + (instanceNum * 32)
 
-		var optionText string
-		if config.PlayerName != "" {
-			optionText = fmt.Sprintf("Instance %d: %s (port %d)", instanceNum, config.PlayerName, port)
-		} else {
-			optionText = fmt.Sprintf("Instance %d (port %d)", instanceNum, port)
-		}
+		optionText := fmt.Sprintf("%s (port %d)", emulator.InstanceLabel(instanceNum, config.PlayerName), port)
 
 		options = append(options, optionText)
 	}
@@ -669,14 +678,13 @@ func (a *ADBTestTab) extractOBBData() {
 		// Calculate port for this instance
 		port := 16384 + (a.selectedInstance * 32)
 
-		// Create extraction directory
-		extractDir := fmt.Sprintf("./extracted_obb/instance_%d", a.selectedInstance)
-
-		// Get ADB path from config
-		adbPath := a.controller.GetConfig().ADB().Path
+		// Get ADB path and extraction folder layout from config
+		cfg := a.controller.GetConfig()
+		adbPath := cfg.ADB().Path
+		layout := accounts.ExtractLayout{BaseDir: cfg.ExtractDir, Naming: cfg.ExtractNaming, GamePackage: cfg.GamePackageName()}
 
 		// Use the accounts package extraction function
-		err := accounts.ExtractOBBData(adbPath, port, extractDir)
+		extractDir, err := layout.ExtractOBBData(adbPath, port, a.selectedInstance)
 
 		bus.Publish(HideProgressBar("adbtest"))
 
@@ -705,14 +713,13 @@ func (a *ADBTestTab) extractAppData() {
 		// Calculate port for this instance
 		port := 16384 + (a.selectedInstance * 32)
 
-		// Create extraction directory
-		extractDir := fmt.Sprintf("./extracted_app_data/instance_%d", a.selectedInstance)
-
-		// Get ADB path from config
-		adbPath := a.controller.GetConfig().ADB().Path
+		// Get ADB path and extraction folder layout from config
+		cfg := a.controller.GetConfig()
+		adbPath := cfg.ADB().Path
+		layout := accounts.ExtractLayout{BaseDir: cfg.ExtractDir, Naming: cfg.ExtractNaming, GamePackage: cfg.GamePackageName()}
 
-		// Use the accounts package extraction function
-		err := accounts.ExtractAppData(adbPath, port, extractDir)
+		// Use the accounts package extraction function (names the folder after the account)
+		extractDir, err := layout.ExtractAppData(adbPath, port, a.selectedInstance)
 
 		bus.Publish(HideProgressBar("adbtest"))
 
@@ -744,11 +751,12 @@ func (a *ADBTestTab) crawlStorage() {
 		// Create output file
 		outputFile := fmt.Sprintf("./storage_crawl_instance_%d.txt", a.selectedInstance)
 
-		// Get ADB path from config
-		adbPath := a.controller.GetConfig().ADB().Path
+		// Get ADB path and game package from config
+		cfg := a.controller.GetConfig()
+		adbPath := cfg.ADB().Path
 
 		// Use the accounts package crawl function
-		err := accounts.CrawlStorage(adbPath, port, outputFile)
+		err := accounts.CrawlStorage(adbPath, port, cfg.GamePackageName(), outputFile)
 
 		bus.Publish(HideProgressBar("adbtest"))
 
@@ -765,3 +773,28 @@ func (a *ADBTestTab) crawlStorage() {
 		bus.Publish(AddLog(LogLevelInfo, a.selectedInstance, fmt.Sprintf("Storage crawl saved to %s", outputFile)))
 	}()
 }
+
+// restoreRecentResults fills the labels with the latest label events published before Build
+func (a *ADBTestTab) restoreRecentResults() {
+	bus := a.controller.GetEventBus()
+	if bus == nil {
+		return
+	}
+
+	labels := map[string]*widget.Label{
+		"adbtest.results": a.testResultsLabel,
+		"adbtest.path":    a.adbPathLabel,
+		"adbtest.version": a.adbVersionLabel,
+		"adbtest.status":  a.adbStatusLabel,
+		"adbtest.devices": a.devicesLabel,
+	}
+	for target, label := range labels {
+		recent := bus.Recent(target, 1)
+		if len(recent) == 0 {
+			continue
+		}
+		if text, ok := recent[0].Data["text"].(string); ok {
+			label.SetText(text)
+		}
+	}
+}
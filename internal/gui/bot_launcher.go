@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"image/color"
 	"path/filepath"
@@ -14,10 +15,13 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 	"jordanella.com/pocket-tcg-go/internal/actions"
 	"jordanella.com/pocket-tcg-go/internal/bot"
 	"jordanella.com/pocket-tcg-go/internal/coordinator"
+	"jordanella.com/pocket-tcg-go/internal/gui/components"
 )
 
 // BotLauncherTab allows launching multiple bots with routine selection
@@ -44,6 +48,9 @@ type BotLauncherTab struct {
 	pollingActive bool
 	pollingStop   chan struct{}
 	pollingWg     sync.WaitGroup
+
+	// Routine hot-reload watcher
+	watchCancel context.CancelFunc
 }
 
 // BotLaunchConfig represents configuration for a single bot instance
@@ -52,15 +59,19 @@ type BotLaunchConfig struct {
 	routineSelect   *widget.Select
 	statusLabel     *widget.Label
 	statusIndicator *canvas.Circle // Visual state indicator
+	lastScreenLabel *widget.Label  // Last recognized screen, for spotting stuck bots
 	selectedRoutine string
 	// Individual control buttons
 	pauseBtn   *widget.Button
 	resumeBtn  *widget.Button
-	stopBtn    *widget.Button
-	restartBtn *widget.Button
+	stopBtn        *widget.Button
+	restartBtn     *widget.Button
+	restartWithBtn *widget.Button
 	// Variable inspector
 	variablesAccordion *widget.Accordion
 	variablesLabel     *widget.Label
+	variablesWatchStop func() // Stops the WatchBotVariables stream (nil when not watching)
+	variablesWatchMu   sync.Mutex
 	// Config editor
 	configBtn       *widget.Button
 	configOverrides map[string]string // User-configured parameter overrides
@@ -122,7 +133,7 @@ func (t *BotLauncherTab) Build() fyne.CanvasObject {
 
 	// Reload buttons for development
 	reloadRoutinesBtn := widget.NewButton("Reload Routines", func() {
-		t.reloadRoutines()
+		t.confirmReloadRoutines()
 	})
 
 	reloadTemplatesBtn := widget.NewButton("Reload Templates", func() {
@@ -161,6 +172,9 @@ func (t *BotLauncherTab) Build() fyne.CanvasObject {
 	// Auto-generate default configs
 	t.generateBotConfigs()
 
+	// Pick up routine file edits without a full reload
+	t.startRoutineWatch()
+
 	return container.NewBorder(
 		container.NewVBox(header, description),
 		nil,
@@ -330,6 +344,7 @@ func (t *BotLauncherTab) createBotConfig(instance int) *BotLaunchConfig {
 		routineSelect:   routineSelect,
 		statusLabel:     widget.NewLabel("Ready"),
 		statusIndicator: statusIndicator,
+		lastScreenLabel: widget.NewLabel(""),
 		selectedRoutine: "<none>",
 		configOverrides: make(map[string]string),
 	}
@@ -355,6 +370,11 @@ func (t *BotLauncherTab) createBotConfig(instance int) *BotLaunchConfig {
 	})
 	config.restartBtn.Disable()
 
+	config.restartWithBtn = widget.NewButton("Restart with...", func() {
+		t.showRestartWithDialog(config)
+	})
+	config.restartWithBtn.Disable()
+
 	// Config button (enabled when routine selected)
 	config.configBtn = widget.NewButton("⚙ Config", func() {
 		t.showConfigEditor(config)
@@ -387,6 +407,7 @@ func (t *BotLauncherTab) createBotConfigCard(config *BotLaunchConfig) fyne.Canva
 		config.resumeBtn,
 		config.stopBtn,
 		config.restartBtn,
+		config.restartWithBtn,
 	)
 
 	// Routine selection row with config button
@@ -396,6 +417,8 @@ func (t *BotLauncherTab) createBotConfigCard(config *BotLaunchConfig) fyne.Canva
 	statusRow := container.NewHBox(
 		config.statusIndicator,
 		config.statusLabel,
+		layout.NewSpacer(),
+		config.lastScreenLabel,
 	)
 
 	// Variable inspector accordion
@@ -469,8 +492,14 @@ func (t *BotLauncherTab) launchAllBots() {
 		)
 	}
 
+	if t.controller.db != nil {
+		// Account state, activities and checkouts go to the shared accounts database
+		t.manager.SetDatabase(t.controller.db.Conn())
+	}
+
 	// Create coordinator for account injection
 	t.coordinator = coordinator.NewBotCoordinator(config)
+	t.manager.SetRoutineStarter(t.coordinator)
 
 	// Launch each configured bot
 	successCount := 0
@@ -667,6 +696,69 @@ func (t *BotLauncherTab) restartBot(instance int) {
 	t.updateBotButtons(instance)
 }
 
+// showRestartWithDialog lets the user restart a bot with a different routine.
+// The bot's config overrides are applied only if the chosen routine is the one they were set for.
+func (t *BotLauncherTab) showRestartWithDialog(config *BotLaunchConfig) {
+	routines := make([]string, 0, len(t.availableRoutines))
+	for _, routine := range t.availableRoutines {
+		if routine != "<none>" {
+			routines = append(routines, routine)
+		}
+	}
+
+	routineSelect := widget.NewSelect(routines, nil)
+	if config.selectedRoutine != "<none>" {
+		routineSelect.SetSelected(config.selectedRoutine)
+	}
+
+	dialog.ShowCustomConfirm(
+		fmt.Sprintf("Restart Bot %d With...", config.instance),
+		"Restart",
+		"Cancel",
+		container.NewVBox(
+			widget.NewLabel("The current routine will be stopped."),
+			routineSelect,
+		),
+		func(confirmed bool) {
+			if !confirmed || routineSelect.Selected == "" {
+				return
+			}
+			t.restartBotWithRoutine(config, routineSelect.Selected)
+		},
+		t.controller.window,
+	)
+}
+
+// restartBotWithRoutine restarts a bot with the routine chosen in the "Restart with..." dialog
+func (t *BotLauncherTab) restartBotWithRoutine(config *BotLaunchConfig, displayName string) {
+	routineName := displayName
+	if filename, ok := t.displayToFilename[displayName]; ok {
+		routineName = filename
+	}
+
+	var overrides map[string]string
+	if displayName == config.selectedRoutine {
+		overrides = config.configOverrides
+	}
+
+	t.safeLog(LogLevelInfo, config.instance, fmt.Sprintf("Restarting with routine: %s", displayName))
+
+	// Stopping the current routine can take a while - keep the UI responsive
+	go func() {
+		if err := t.manager.RestartBotWithRoutine(config.instance, routineName, overrides); err != nil {
+			t.safeLog(LogLevelError, config.instance, fmt.Sprintf("Failed to restart: %v", err))
+			return
+		}
+
+		fyne.Do(func() {
+			if displayName != config.selectedRoutine {
+				config.routineSelect.SetSelected(displayName)
+			}
+			t.updateBotButtons(config.instance)
+		})
+	}()
+}
+
 // updateBotButtons updates button states based on bot's routine controller state
 func (t *BotLauncherTab) updateBotButtons(instance int) {
 	// Find the config for this instance
@@ -688,15 +780,22 @@ func (t *BotLauncherTab) updateBotButtons(instance int) {
 		config.resumeBtn.Disable()
 		config.stopBtn.Disable()
 		config.restartBtn.Disable()
+		config.restartWithBtn.Disable()
 		config.statusLabel.SetText("Not Running")
 		config.statusIndicator.FillColor = color.RGBA{R: 128, G: 128, B: 128, A: 255} // Gray
 		config.statusIndicator.Refresh()
+		config.lastScreenLabel.SetText("")
 		return
 	}
 
+	config.lastScreenLabel.SetText("Last screen: " + components.FormatLastScreen(b.LastScreen()))
+
 	state := b.RoutineController().GetState()
 	hasLastRoutine := b.GetLastRoutine() != ""
 
+	// Switching routines stops the current one, so it is allowed in every state
+	config.restartWithBtn.Enable()
+
 	switch state {
 	case bot.StateIdle:
 		config.pauseBtn.Disable()
@@ -779,10 +878,10 @@ func (t *BotLauncherTab) startStatusPolling() {
 			case <-t.pollingStop:
 				return
 			case <-ticker.C:
-				// Poll status and variables for all bot configs
+				// Poll status for all bot configs; variables are pushed by a watch once the bot exists
 				for _, config := range t.botConfigs {
 					t.updateBotButtons(config.instance)
-					t.updateBotVariables(config)
+					t.watchBotVariables(config)
 				}
 			}
 		}
@@ -799,16 +898,67 @@ func (t *BotLauncherTab) stopStatusPolling() {
 	close(t.pollingStop)
 	t.pollingWg.Wait()
 
+	for _, config := range t.botConfigs {
+		config.variablesWatchMu.Lock()
+		if config.variablesWatchStop != nil {
+			config.variablesWatchStop()
+		}
+		config.variablesWatchMu.Unlock()
+	}
+
 	// Recreate the channel for next time
 	t.pollingStop = make(chan struct{})
 }
 
 // Cleanup performs cleanup when the tab is closed
 func (t *BotLauncherTab) Cleanup() {
+	if t.watchCancel != nil {
+		t.watchCancel()
+	}
 	t.stopStatusPolling()
 	t.stopAllBots()
 }
 
+// confirmReloadRoutines warns when saved groups depend on the routines being reloaded
+func (t *BotLauncherTab) confirmReloadRoutines() {
+	usage := t.routineGroupUsage()
+	if len(usage) == 0 {
+		t.reloadRoutines()
+		return
+	}
+
+	dialog.ShowConfirm(
+		"Routines In Use",
+		fmt.Sprintf("Reloading affects routines used by orchestration groups:\n\n%s\nReload anyway?",
+			strings.Join(usage, "\n")),
+		func(confirmed bool) {
+			if confirmed {
+				t.reloadRoutines()
+			}
+		},
+		t.controller.window,
+	)
+}
+
+// routineGroupUsage lists "routine: used by N group(s)" for each routine that groups depend on
+func (t *BotLauncherTab) routineGroupUsage() []string {
+	orchestrator := t.controller.orchestrator
+	if orchestrator == nil || orchestrator.GetRoutineRegistry() == nil {
+		return nil
+	}
+
+	usage := make([]string, 0)
+	for _, filename := range orchestrator.GetRoutineRegistry().ListAvailable() {
+		groups := orchestrator.GroupsUsing(filename)
+		if len(groups) == 0 {
+			continue
+		}
+		usage = append(usage, fmt.Sprintf("• %s: used by %d group(s) (%s)",
+			filename, len(groups), strings.Join(groups, ", ")))
+	}
+	return usage
+}
+
 // reloadRoutines reloads all routine files from disk
 func (t *BotLauncherTab) reloadRoutines() {
 	if t.manager == nil {
@@ -825,24 +975,87 @@ func (t *BotLauncherTab) reloadRoutines() {
 		return
 	}
 
-	// Reload the available routines list
+	// Reload the available routines list and dropdowns
+	t.refreshRoutineDropdowns()
+
+	t.statusLabel.SetText("✓ Routines reloaded successfully")
+
+	// Show success dialog with count
+	validCount := len(t.availableRoutines)
+	dialog.ShowInformation("Reload Complete",
+		fmt.Sprintf("Successfully reloaded %d routine(s)", validCount),
+		t.controller.window)
+}
+
+// browseForConfigFile opens a file picker for a filepath config param
+func (t *BotLauncherTab) browseForConfigFile(param actions.ConfigParam, entry *widget.Entry) {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("error selecting file: %w", err), t.controller.window)
+			return
+		}
+		if reader == nil {
+			return // User cancelled
+		}
+		defer reader.Close()
+
+		entry.SetText(filepath.FromSlash(reader.URI().Path()))
+	}, t.controller.window)
+
+	if len(param.Extensions) > 0 {
+		fileDialog.SetFilter(storage.NewExtensionFileFilter(param.Extensions))
+	}
+
+	fileDialog.Show()
+}
+
+// refreshRoutineDropdowns rebuilds the routine list and updates every bot's dropdown
+func (t *BotLauncherTab) refreshRoutineDropdowns() {
 	t.loadAvailableRoutines()
 
-	// Update all dropdown menus
 	for _, config := range t.botConfigs {
 		if config.routineSelect != nil {
 			config.routineSelect.Options = t.availableRoutines
 			config.routineSelect.Refresh()
 		}
 	}
+}
+
+// startRoutineWatch reloads individual routine files as they change on disk
+func (t *BotLauncherTab) startRoutineWatch() {
+	if t.watchCancel != nil {
+		return
+	}
 
-	t.statusLabel.SetText("✓ Routines reloaded successfully")
+	rr := t.controller.GetRoutineRegistry()
+	if rr == nil {
+		return
+	}
 
-	// Show success dialog with count
-	validCount := len(t.availableRoutines)
-	dialog.ShowInformation("Reload Complete",
-		fmt.Sprintf("Successfully reloaded %d routine(s)", validCount),
-		t.controller.window)
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := rr.WatchDirectory(ctx)
+	if err != nil {
+		cancel()
+		fmt.Printf("Warning: routine hot-reload disabled: %v\n", err)
+		return
+	}
+	t.watchCancel = cancel
+
+	go func() {
+		for event := range events {
+			status := fmt.Sprintf("✓ Reloaded routine %s", event.Filename)
+			if event.Removed {
+				status = fmt.Sprintf("Routine %s removed", event.Filename)
+			} else if !event.Valid {
+				status = fmt.Sprintf("⚠️ Routine %s is invalid: %v", event.Filename, event.Error)
+			}
+
+			fyne.Do(func() {
+				t.refreshRoutineDropdowns()
+				t.statusLabel.SetText(status)
+			})
+		}
+	}()
 }
 
 // reloadTemplates reloads all template files from disk
@@ -869,24 +1082,49 @@ func (t *BotLauncherTab) reloadTemplates() {
 		t.controller.window)
 }
 
-// updateBotVariables updates the variable display for a bot
-func (t *BotLauncherTab) updateBotVariables(config *BotLaunchConfig) {
+// watchBotVariables starts streaming a bot's variables into its Variables accordion.
+// It does nothing if the bot is not running or is already being watched.
+func (t *BotLauncherTab) watchBotVariables(config *BotLaunchConfig) {
 	if t.manager == nil || config.variablesLabel == nil {
 		return
 	}
 
-	// Get variables from manager
-	variables, err := t.manager.GetBotVariables(config.instance)
-	if err != nil {
-		// Bot not running or not found
-		config.variablesLabel.SetText("No variables (bot not running)")
+	config.variablesWatchMu.Lock()
+	defer config.variablesWatchMu.Unlock()
+
+	if config.variablesWatchStop != nil {
+		return
+	}
+	if _, exists := t.manager.GetBot(config.instance); !exists {
 		return
 	}
 
-	// Format variables for display
+	updates, stop := t.manager.WatchBotVariables(config.instance)
+	config.variablesWatchStop = stop
+
+	go func() {
+		for variables := range updates {
+			text := formatBotVariables(variables)
+			fyne.Do(func() {
+				config.variablesLabel.SetText(text)
+			})
+		}
+
+		// Stream ends when the bot shuts down or the watch is stopped
+		config.variablesWatchMu.Lock()
+		config.variablesWatchStop = nil
+		config.variablesWatchMu.Unlock()
+
+		fyne.Do(func() {
+			config.variablesLabel.SetText("No variables (bot not running)")
+		})
+	}()
+}
+
+// formatBotVariables formats variables for display, sorted by name
+func formatBotVariables(variables map[string]string) string {
 	if len(variables) == 0 {
-		config.variablesLabel.SetText("No variables set")
-		return
+		return "No variables set"
 	}
 
 	// Build formatted text with sorted keys
@@ -909,7 +1147,7 @@ func (t *BotLauncherTab) updateBotVariables(config *BotLaunchConfig) {
 		}
 	}
 
-	config.variablesLabel.SetText(displayText.String())
+	return displayText.String()
 }
 
 // updateConfigButtonState enables/disables the config button based on whether routine has config
@@ -1022,6 +1260,31 @@ func (t *BotLauncherTab) showConfigEditor(config *BotLaunchConfig) {
 			}
 			inputWidget = sel
 			formEntries[param.Name] = sel
+
+		case "duration":
+			entry := widget.NewEntry()
+			entry.SetText(currentValue)
+			entry.SetPlaceHolder("e.g. 30s, 5m, 1h30m")
+			entry.Validator = func(value string) error {
+				_, err := param.NormalizeValue(value)
+				return err
+			}
+			inputWidget = entry
+			formEntries[param.Name] = entry
+
+		case "filepath":
+			entry := widget.NewEntry()
+			entry.SetText(currentValue)
+			entry.SetPlaceHolder(param.Default)
+			entry.Validator = func(value string) error {
+				_, err := param.NormalizeValue(value)
+				return err
+			}
+			browseBtn := widget.NewButton("Browse...", func() {
+				t.browseForConfigFile(param, entry)
+			})
+			inputWidget = container.NewBorder(nil, nil, nil, browseBtn, entry)
+			formEntries[param.Name] = entry
 		}
 
 		// Create label with description
@@ -1113,15 +1376,23 @@ func (t *BotLauncherTab) showConfigEditor(config *BotLaunchConfig) {
 					}
 				}
 
+				// Validate duration/filepath types and store them in canonical form
+				normalized, err := param.NormalizeValue(value)
+				if err != nil {
+					validationErrors = append(validationErrors, err.Error())
+					continue
+				}
+				value = normalized
+
 				// Store override if different from default
 				if value != "" && value != param.Default {
 					newOverrides[param.Name] = value
 				}
 			}
 
-			// Show validation errors
+			// Show validation errors (nothing is applied)
 			if len(validationErrors) > 0 {
-				errMsg := "Validation errors:\n" + strings.Join(validationErrors, "\n")
+				errMsg := "Config not applied. Validation errors:\n" + strings.Join(validationErrors, "\n")
 				dialog.ShowError(fmt.Errorf("%s", errMsg), t.controller.window)
 				return
 			}
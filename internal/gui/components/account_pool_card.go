@@ -32,6 +32,7 @@ type AccountPoolCard struct {
 	container     *fyne.Container
 	nameText      *canvas.Text
 	typeLabel     *canvas.Text
+	healthText    *canvas.Text
 	countText     *canvas.Text
 	updatedText   *canvas.Text
 	descText      *canvas.Text
@@ -72,9 +73,15 @@ func (c *AccountPoolCard) build() *fyne.Container {
 	c.typeLabel = canvas.NewText(fmt.Sprintf("<%s>", c.poolType), theme.Color(theme.ColorNameForeground))
 	c.typeLabel.TextSize = 12
 
+	// Health badge, filled in by SetHealth
+	c.healthText = canvas.NewText("", theme.Color(theme.ColorNameForeground))
+	c.healthText.TextSize = 12
+	c.healthText.TextStyle = fyne.TextStyle{Bold: true}
+
 	headerRow := container.NewHBox(
 		c.nameText,
 		c.typeLabel,
+		c.healthText,
 	)
 
 	// === INFO ROW ===
@@ -138,6 +145,28 @@ func (c *AccountPoolCard) SetSelected(selected bool) {
 	})
 }
 
+// SetHealth shows the pool's health grade and score as a colored badge
+func (c *AccountPoolCard) SetHealth(grade string, score float64) {
+	c.healthText.Text = fmt.Sprintf("%s %.0f", grade, score)
+
+	switch grade {
+	case "Healthy":
+		c.healthText.Color = theme.Color(theme.ColorNameSuccess)
+	case "Fair":
+		c.healthText.Color = theme.Color(theme.ColorNameWarning)
+	case "Error":
+		c.healthText.Color = theme.Color(theme.ColorNameError)
+		c.healthText.Text = grade
+	default:
+		c.healthText.Color = theme.Color(theme.ColorNameError)
+	}
+
+	// Refresh UI (wrapped in fyne.Do for thread safety)
+	fyne.Do(func() {
+		c.healthText.Refresh()
+	})
+}
+
 // UpdateData updates the card data
 func (c *AccountPoolCard) UpdateData(accountCount int, lastUpdated string, description string) {
 	c.accountCount = accountCount
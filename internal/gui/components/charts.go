@@ -0,0 +1,152 @@
+package components
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ChartPoint is a labelled value shown in a chart
+type ChartPoint struct {
+	Label string
+	Value float64
+}
+
+// barMaxWidth is the width of the largest bar in a bar chart
+const barMaxWidth float32 = 300
+
+// NewBarChart creates a horizontal bar chart with one row per point:
+// "label  [bar]  value". Bars are scaled to the largest value.
+func NewBarChart(points []ChartPoint) fyne.CanvasObject {
+	if len(points) == 0 {
+		return widget.NewLabel("No data")
+	}
+
+	maxValue := 0.0
+	for _, p := range points {
+		if p.Value > maxValue {
+			maxValue = p.Value
+		}
+	}
+
+	labels := container.NewVBox()
+	bars := container.NewVBox()
+	values := container.NewVBox()
+
+	for _, p := range points {
+		width := float32(1)
+		if maxValue > 0 {
+			width = float32(p.Value/maxValue) * barMaxWidth
+		}
+
+		bar := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+		bar.SetMinSize(fyne.NewSize(width, theme.TextSize()))
+
+		labels.Add(widget.NewLabel(p.Label))
+		bars.Add(container.NewHBox(container.NewCenter(bar)))
+		values.Add(widget.NewLabel(formatChartValue(p.Value)))
+	}
+
+	return container.NewHBox(labels, bars, values)
+}
+
+// NewLineChart creates a line chart of the points in order, with the first and last labels underneath
+func NewLineChart(points []ChartPoint, size fyne.Size) fyne.CanvasObject {
+	if len(points) == 0 {
+		return widget.NewLabel("No data")
+	}
+
+	plot := container.New(&lineChartLayout{points: points, size: size})
+	lineColor := theme.Color(theme.ColorNamePrimary)
+
+	// One line per segment, followed by one dot per point (positioned by the layout)
+	for i := 1; i < len(points); i++ {
+		line := canvas.NewLine(lineColor)
+		line.StrokeWidth = 2
+		plot.Add(line)
+	}
+	for range points {
+		plot.Add(canvas.NewCircle(lineColor))
+	}
+
+	maxValue := 0.0
+	for _, p := range points {
+		if p.Value > maxValue {
+			maxValue = p.Value
+		}
+	}
+
+	axis := container.NewBorder(nil, nil,
+		widget.NewLabel(points[0].Label),
+		widget.NewLabel(points[len(points)-1].Label),
+	)
+
+	return container.NewBorder(
+		widget.NewLabel(fmt.Sprintf("max %s", formatChartValue(maxValue))),
+		axis,
+		nil,
+		nil,
+		plot,
+	)
+}
+
+// lineChartLayout positions the lines and dots of a line chart within the available space
+type lineChartLayout struct {
+	points []ChartPoint
+	size   fyne.Size
+}
+
+// dotSize is the diameter of a point marker
+const dotSize float32 = 6
+
+func (l *lineChartLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	maxValue := 0.0
+	for _, p := range l.points {
+		if p.Value > maxValue {
+			maxValue = p.Value
+		}
+	}
+
+	position := func(i int) fyne.Position {
+		x := size.Width / 2
+		if len(l.points) > 1 {
+			x = float32(i) / float32(len(l.points)-1) * size.Width
+		}
+		y := size.Height
+		if maxValue > 0 {
+			y = size.Height - float32(l.points[i].Value/maxValue)*size.Height
+		}
+		return fyne.NewPos(x, y)
+	}
+
+	segments := len(l.points) - 1
+	for i := 0; i < segments; i++ {
+		line := objects[i].(*canvas.Line)
+		line.Position1 = position(i)
+		line.Position2 = position(i + 1)
+		line.Refresh()
+	}
+
+	for i := range l.points {
+		dot := objects[segments+i]
+		p := position(i)
+		dot.Move(fyne.NewPos(p.X-dotSize/2, p.Y-dotSize/2))
+		dot.Resize(fyne.NewSize(dotSize, dotSize))
+	}
+}
+
+func (l *lineChartLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return l.size
+}
+
+// formatChartValue shows whole numbers without decimals
+func formatChartValue(value float64) string {
+	if value == float64(int64(value)) {
+		return fmt.Sprintf("%d", int64(value))
+	}
+	return fmt.Sprintf("%.2f", value)
+}
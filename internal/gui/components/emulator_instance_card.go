@@ -9,6 +9,8 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+
+	"jordanella.com/pocket-tcg-go/pkg/templates"
 )
 
 // EmulatorInstanceCardCallbacks defines callback functions for instance card actions
@@ -281,3 +283,15 @@ func formatDurationCompact(d time.Duration) string {
 	}
 	return fmt.Sprintf("%dh%dm", hours, minutes)
 }
+
+// FormatLastScreen describes a bot's last recognized screen (e.g., "LoadingScreen 4m ago",
+// or "Home [ja] 4m ago" when a language variant matched)
+func FormatLastScreen(screenName string, seenAt time.Time) string {
+	if screenName == "" || seenAt.IsZero() {
+		return "—"
+	}
+	if language, name := templates.TemplateLanguage(screenName); language != "" {
+		screenName = fmt.Sprintf("%s [%s]", name, language)
+	}
+	return fmt.Sprintf("%s %s ago", screenName, formatDurationCompact(time.Since(seenAt)))
+}
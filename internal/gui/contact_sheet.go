@@ -0,0 +1,98 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/bot"
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// runningBots returns every running bot by instance: bots started from this tab and bots in
+// running orchestration groups
+func (c *Controller) runningBots() map[int]*bot.Bot {
+	bots := c.GetAllBots()
+	if c.orchestrator == nil {
+		return bots
+	}
+
+	for _, group := range c.orchestrator.ListActiveGroups() {
+		for instanceID, info := range group.GetAllBotInfo() {
+			if _, exists := bots[instanceID]; !exists && info.Bot != nil {
+				bots[instanceID] = info.Bot
+			}
+		}
+	}
+	return bots
+}
+
+// captureContactSheet captures every running bot's screen as contact sheet cells, in
+// instance order. Cells of instances that fail to capture hold the error instead of a frame.
+func (c *Controller) captureContactSheet() ([]cv.ContactSheetCell, error) {
+	bots := c.runningBots()
+	if len(bots) == 0 {
+		return nil, fmt.Errorf("no bots are running")
+	}
+
+	instances := make([]int, 0, len(bots))
+	for instanceID := range bots {
+		instances = append(instances, instanceID)
+	}
+	sort.Ints(instances)
+
+	cells := make([]cv.ContactSheetCell, len(instances))
+	var wg sync.WaitGroup
+	for i, instanceID := range instances {
+		wg.Add(1)
+		go func(i, instanceID int) {
+			defer wg.Done()
+			cells[i].Label = fmt.Sprintf("Instance %d", instanceID)
+			service := bots[instanceID].CV()
+			if service == nil {
+				cells[i].Err = fmt.Errorf("bot is not initialized")
+				return
+			}
+			frame, err := service.CaptureFrame(false)
+			if err != nil {
+				cells[i].Err = err
+				return
+			}
+			cells[i].Frame = frame
+		}(i, instanceID)
+	}
+	wg.Wait()
+
+	return cells, nil
+}
+
+// snapshotAllInstances saves a contact sheet of every running bot's screen as PNG
+func (c *ControlTab) snapshotAllInstances() {
+	go func() {
+		cells, err := c.controller.captureContactSheet()
+		if err != nil {
+			c.showError(fmt.Sprintf("Failed to capture contact sheet: %v", err))
+			return
+		}
+
+		failed := 0
+		for _, cell := range cells {
+			if cell.Frame == nil {
+				failed++
+				c.controller.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("%s: capture failed: %v", cell.Label, cell.Err))
+			}
+		}
+
+		sheet := cv.BuildContactSheet(cells, cv.DefaultContactSheetCellWidth, 0)
+		fileName := fmt.Sprintf("contact_sheet_%s.png", time.Now().Format("20060102_150405"))
+		if err := cv.SaveImage(sheet, fileName, cv.SaveOptions{Format: cv.ImageFormatPNG}); err != nil {
+			c.showError(fmt.Sprintf("Failed to save contact sheet: %v", err))
+			return
+		}
+
+		c.controller.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Contact sheet of %d instances saved to: %s", len(cells), fileName))
+		c.showSuccess(fmt.Sprintf("Contact sheet saved!\n\nFile: %s\n\nInstances: %d (%d failed to capture)",
+			fileName, len(cells), failed))
+	}()
+}
@@ -1,9 +1,11 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -11,10 +13,14 @@ import (
 	"fyne.io/fyne/v2/widget"
 	"jordanella.com/pocket-tcg-go/internal/accountpool"
 	"jordanella.com/pocket-tcg-go/internal/actions"
+	"jordanella.com/pocket-tcg-go/internal/api"
 	"jordanella.com/pocket-tcg-go/internal/bot"
+	"jordanella.com/pocket-tcg-go/internal/coordinator"
 	"jordanella.com/pocket-tcg-go/internal/database"
 	"jordanella.com/pocket-tcg-go/internal/emulator"
 	"jordanella.com/pocket-tcg-go/internal/gui/tabs"
+	"jordanella.com/pocket-tcg-go/internal/metrics"
+	"jordanella.com/pocket-tcg-go/internal/notify"
 	"jordanella.com/pocket-tcg-go/pkg/templates"
 )
 
@@ -40,6 +46,8 @@ type Controller struct {
 	accountTab           *AccountTab
 	controlTab           *ControlTab
 	adbTestTab           *ADBTestTab
+	templateTesterTab    *TemplateTesterTab
+	systemCheckTab       *SystemCheckTab
 	routinesTab          *RoutinesEnhancedTab
 	managerGroupsTab     *ManagerGroupsTab
 	orchestrationTab     *tabs.OrchestrationTabV3
@@ -56,10 +64,12 @@ type Controller struct {
 	db              *database.DB
 	poolManager     *accountpool.PoolManager
 	dbAccountsTab   *DatabaseAccountsTab
+	accountsBrowser *AccountsBrowserTab
 	dbActivityTab   *DatabaseActivityTab
 	dbErrorsTab     *DatabaseErrorsTab
 	dbPacksTab      *DatabasePacksTab
 	dbCollectionTab *DatabaseCollectionTab
+	statisticsTab   *StatisticsTab
 	dbTabContainer  *fyne.Container
 
 	// Content area reference for tab switching
@@ -71,6 +81,15 @@ type Controller struct {
 
 	// Event bus for thread-safe UI updates
 	eventBus *EventBus
+
+	// Optional Prometheus-style metrics endpoint
+	metricsServer *metrics.Server
+
+	// Optional HTTP control API
+	apiServer *api.Server
+
+	// Optional webhook notifications for orchestrator events
+	notifier *notify.Dispatcher
 }
 
 // NewController creates a new GUI controller
@@ -99,6 +118,8 @@ func NewController(cfg *bot.Config, app fyne.App, window fyne.Window) *Controlle
 	ctrl.accountTab = NewAccountTab(ctrl)
 	ctrl.controlTab = NewControlTab(ctrl)
 	ctrl.adbTestTab = NewADBTestTab(ctrl)
+	ctrl.templateTesterTab = NewTemplateTesterTab(ctrl)
+	ctrl.systemCheckTab = NewSystemCheckTab(ctrl)
 
 	// Create manager with shared registries (MVC: injecting Model into Manager)
 	// This manager is used by routinesTab for routine execution
@@ -114,6 +135,11 @@ func NewController(cfg *bot.Config, app fyne.App, window fyne.Window) *Controlle
 	// Initialize database after log tab is ready
 	ctrl.initializeDatabase()
 
+	// Expose /metrics for scraping (needs the orchestrator and database)
+	ctrl.startMetricsServer()
+	ctrl.startAPIServer()
+	ctrl.startNotifications()
+
 	// Subscribe event handlers
 	ctrl.setupEventHandlers()
 
@@ -195,10 +221,12 @@ func (c *Controller) initializeDatabase() {
 
 	// Initialize database tabs (they handle nil database gracefully)
 	c.dbAccountsTab = NewDatabaseAccountsTab(c, c.db)
+	c.accountsBrowser = NewAccountsBrowserTab(c, c.db)
 	c.dbActivityTab = NewDatabaseActivityTab(c, c.db)
 	c.dbErrorsTab = NewDatabaseErrorsTab(c, c.db)
 	c.dbPacksTab = NewDatabasePacksTab(c, c.db)
 	c.dbCollectionTab = NewDatabaseCollectionTab(c, c.db)
+	c.statisticsTab = NewStatisticsTab(c, c.db)
 
 	// Initialize Account Pools tab and PoolManager
 	if c.db != nil {
@@ -206,12 +234,26 @@ func (c *Controller) initializeDatabase() {
 		poolsDir := "pools"
 		xmlStorageDir := "account_xmls" // Global XML storage directory
 		c.poolManager = accountpool.NewPoolManager(poolsDir, c.db.Conn(), xmlStorageDir)
+		c.poolManager.SetHealthWeights(accountpool.HealthWeights{
+			Availability: c.config.PoolHealthWeightAvailability,
+			Packs:        c.config.PoolHealthWeightPacks,
+			Reliability:  c.config.PoolHealthWeightReliability,
+			Rested:       c.config.PoolHealthWeightRested,
+			PackTarget:   c.config.PoolHealthPackTarget,
+			RestWindow:   time.Duration(c.config.PoolHealthRestHours) * time.Hour,
+		})
 
 		// Discover existing pools from disk
 		if err := c.poolManager.DiscoverPools(); err != nil {
 			c.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to discover pools: %v", err))
 		}
 
+		// Keep open pools fed according to their refresh_interval
+		c.poolManager.StartRefreshScheduler()
+
+		// Record pool statistics over time for charting
+		c.poolManager.StartStatsSampler(time.Duration(c.config.PoolStatsSampleSeconds) * time.Second)
+
 		// Initialize orchestrator with database connection (need emulator manager for pools tab)
 		emulatorManager := c.CreateEmulatorManager()
 		c.accountPoolsTab = tabs.NewAccountPoolsTabV2(c.poolManager, c.db.Conn(), emulatorManager, c.window)
@@ -229,6 +271,26 @@ func (c *Controller) initializeDatabase() {
 			c.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to load group definitions: %v", err))
 		}
 
+		// Pool for groups without their own
+		if c.config.DefaultPool != "" {
+			if err := c.orchestrator.SetDefaultPool(c.config.DefaultPool); err != nil {
+				c.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to set default pool: %v", err))
+			}
+		}
+
+		// Check injected accounts were loaded against their stored identity
+		c.orchestrator.SetAccountVerifier(coordinator.NewAccountVerifier(c.db).Verify)
+
+		// Keep god pack accounts out of rotation and archive them
+		if c.config.GodPackPreserve {
+			c.orchestrator.SetGodPackHook(coordinator.NewGodPackPreserver(c.config, c.poolManager).Preserve)
+		}
+
+		// Correct accounts' pack and hourglass counts from the game's save once they are loaded
+		if c.config.SyncSaveCounts {
+			c.orchestrator.SetAccountLoadedHook(coordinator.NewSaveCountSyncer(c.config, c.db).Sync)
+		}
+
 		// Initialize orchestration tab
 		emulatorManager = c.CreateEmulatorManager()
 		c.orchestrationTab = tabs.NewOrchestrationTabV3(c.orchestrator, emulatorManager, c.window)
@@ -263,6 +325,8 @@ func (c *Controller) BuildUI() fyne.CanvasObject {
 		widget.NewButton("ADB Test", func() { c.switchTab(7) }),
 		widget.NewButton("Routines", func() { c.switchTab(8) }),
 		widget.NewButton("Database", func() { c.switchTab(9) }),
+		widget.NewButton("Template Tester", func() { c.switchTab(10) }),
+		widget.NewButton("System Check", func() { c.switchTab(11) }),
 	)
 
 	// Create database tab with nested tabs (after database tabs are initialized)
@@ -310,6 +374,8 @@ func (c *Controller) BuildUI() fyne.CanvasObject {
 		c.adbTestTab.Build(),
 		c.routinesTab.Build(),
 		c.dbTabContainer,
+		c.templateTesterTab.Build(),
+		c.systemCheckTab.Build(),
 	)
 
 	// Initial state: show emulator instances
@@ -328,8 +394,8 @@ func (c *Controller) BuildUI() fyne.CanvasObject {
 // buildDatabaseTab creates a tabbed container for database views
 func (c *Controller) buildDatabaseTab() *fyne.Container {
 	// Check if database tabs are initialized
-	if c.dbAccountsTab == nil || c.dbActivityTab == nil || c.dbErrorsTab == nil ||
-		c.dbPacksTab == nil || c.dbCollectionTab == nil {
+	if c.dbAccountsTab == nil || c.accountsBrowser == nil || c.dbActivityTab == nil || c.dbErrorsTab == nil ||
+		c.dbPacksTab == nil || c.dbCollectionTab == nil || c.statisticsTab == nil {
 		// Return empty container with error message
 		return container.NewCenter(
 			widget.NewLabel("Database tabs not initialized"),
@@ -339,10 +405,12 @@ func (c *Controller) buildDatabaseTab() *fyne.Container {
 	// Create nested tabs for different database views
 	tabs := container.NewAppTabs(
 		container.NewTabItem("Accounts", c.dbAccountsTab.Build()),
+		container.NewTabItem("Account Browser", c.accountsBrowser.Build()),
 		container.NewTabItem("Activity", c.dbActivityTab.Build()),
 		container.NewTabItem("Errors", c.dbErrorsTab.Build()),
 		container.NewTabItem("Pack Results", c.dbPacksTab.Build()),
 		container.NewTabItem("Collection", c.dbCollectionTab.Build()),
+		container.NewTabItem("Statistics", c.statisticsTab.Build()),
 	)
 
 	tabs.SetTabLocation(container.TabLocationTop)
@@ -443,11 +511,50 @@ func (c *Controller) Shutdown() {
 	c.botsMu.Lock()
 	defer c.botsMu.Unlock()
 
+	if c.notifier != nil {
+		c.notifier.Stop()
+		c.notifier = nil
+	}
+
+	// Stop serving the API and metrics before the sources go away
+	if c.apiServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		c.apiServer.Shutdown(ctx)
+		cancel()
+		c.apiServer = nil
+	}
+	if c.metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		c.metricsServer.Shutdown(ctx)
+		cancel()
+		c.metricsServer = nil
+	}
+
 	for _, b := range c.bots {
 		b.Shutdown()
 	}
 	c.bots = make(map[int]*bot.Bot)
 
+	// Keep a summary of the session's runs (needs the database)
+	if c.orchestrator != nil && c.orchestrator.HasSessionActivity() {
+		if path, err := c.orchestrator.WriteSessionReport(bot.DefaultReportsDir); err != nil {
+			fmt.Printf("Warning: Failed to write session report: %v\n", err)
+		} else {
+			fmt.Printf("Session report written to %s\n", path)
+		}
+	}
+
+	// Stop the orchestrator's monitors before the bots' instances and database go away
+	if c.orchestrator != nil {
+		c.orchestrator.Shutdown()
+	}
+
+	// Stop scheduled pool refreshes and statistics sampling before the database goes away
+	if c.poolManager != nil {
+		c.poolManager.StopRefreshScheduler()
+		c.poolManager.StopStatsSampler()
+	}
+
 	// Close database
 	if c.db != nil {
 		c.db.Close()
@@ -460,35 +567,80 @@ func (c *Controller) Shutdown() {
 	}
 }
 
+// startMetricsServer starts the metrics endpoint if enabled in config
+func (c *Controller) startMetricsServer() {
+	if c.config == nil || !c.config.MetricsEnabled {
+		return
+	}
+
+	var collectors []metrics.Collector
+	if c.orchestrator != nil {
+		collectors = append(collectors, metrics.NewOrchestratorCollector(c.orchestrator))
+	}
+	if c.poolManager != nil {
+		collectors = append(collectors, metrics.NewPoolCollector(c.poolManager))
+	}
+	if c.db != nil {
+		collectors = append(collectors, metrics.NewDatabaseCollector(c.db))
+	}
+	collectors = append(collectors, metrics.NewCVCollector())
+
+	server := metrics.NewServer(c.config.MetricsPort, collectors...)
+	if err := server.Start(); err != nil {
+		c.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to start metrics server: %v", err))
+		return
+	}
+	c.metricsServer = server
+	c.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Metrics available at http://%s/metrics", server.Addr()))
+}
+
+// startAPIServer starts the control API if enabled in config
+func (c *Controller) startAPIServer() {
+	if c.config == nil || !c.config.APIEnabled {
+		return
+	}
+
+	server := api.NewServer(c.config.APIPort, c.config.APIKey, c.orchestrator, c.poolManager)
+	if err := server.Start(); err != nil {
+		c.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Failed to start API server: %v", err))
+		return
+	}
+	c.apiServer = server
+	c.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Control API available at http://%s/api", server.Addr()))
+}
+
+// startNotifications posts orchestrator events to the configured webhook
+func (c *Controller) startNotifications() {
+	if c.config == nil || c.config.NotifyWebhookURL == "" || c.orchestrator == nil {
+		return
+	}
+
+	eventTypes, err := notify.ParseEventTypes(c.config.NotifyEvents)
+	if err != nil {
+		c.logTab.AddLog(LogLevelWarn, 0, fmt.Sprintf("Notifications disabled: %v", err))
+		return
+	}
+
+	dispatcher := notify.NewDispatcher(notify.NewDiscordWebhook(c.config.NotifyWebhookURL, c.config.NotifyUserID), c.config.NotifyMaxPerMinute)
+	dispatcher.Subscribe(c.orchestrator.GetEventBus(), eventTypes)
+	c.notifier = dispatcher
+	c.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Webhook notifications enabled for %d event types", len(eventTypes)))
+}
+
 // setupEventHandlers registers all event handlers
 func (c *Controller) setupEventHandlers() {
 	// Progress bar events
-	c.eventBus.Subscribe(EventTypeProgressBarShow, func(e Event) {
-		c.handleProgressBarEvent(e, true)
-	})
-
-	c.eventBus.Subscribe(EventTypeProgressBarHide, func(e Event) {
-		c.handleProgressBarEvent(e, false)
-	})
+	c.eventBus.SubscribeProgressBar(c.handleProgressBarEvent)
 
 	// Label update events
-	c.eventBus.Subscribe(EventTypeLabelUpdate, func(e Event) {
-		c.handleLabelUpdate(e)
-	})
+	c.eventBus.SubscribeLabel(c.handleLabelUpdate)
 
 	// Log events
-	c.eventBus.Subscribe(EventTypeLogAdd, func(e Event) {
-		c.handleLogEvent(e)
-	})
+	c.eventBus.SubscribeLog(c.handleLogEvent)
 
 	// Dialog events
-	c.eventBus.Subscribe(EventTypeDialogError, func(e Event) {
-		c.handleDialogError(e)
-	})
-
-	c.eventBus.Subscribe(EventTypeDialogInfo, func(e Event) {
-		c.handleDialogInfo(e)
-	})
+	c.eventBus.SubscribeErrorDialog(c.handleDialogError)
+	c.eventBus.SubscribeInfoDialog(c.handleDialogInfo)
 }
 
 // GetEventBus returns the event bus for publishing events
@@ -497,9 +649,9 @@ func (c *Controller) GetEventBus() *EventBus {
 }
 
 // handleProgressBarEvent handles progress bar show/hide events
-func (c *Controller) handleProgressBarEvent(e Event, show bool) {
+func (c *Controller) handleProgressBarEvent(target string, show bool) {
 	// Route to appropriate tab based on target
-	switch e.Target {
+	switch target {
 	case "adbtest":
 		if c.adbTestTab != nil && c.adbTestTab.progressBar != nil {
 			if show {
@@ -521,14 +673,9 @@ func (c *Controller) handleProgressBarEvent(e Event, show bool) {
 }
 
 // handleLabelUpdate handles label update events
-func (c *Controller) handleLabelUpdate(e Event) {
-	text, ok := e.Data["text"].(string)
-	if !ok {
-		return
-	}
-
+func (c *Controller) handleLabelUpdate(target string, text string) {
 	// Route to appropriate widget based on target
-	switch e.Target {
+	switch target {
 	case "adbtest.results":
 		if c.adbTestTab != nil && c.adbTestTab.testResultsLabel != nil {
 			fyne.Do(func() {
@@ -568,51 +715,21 @@ func (c *Controller) handleLabelUpdate(e Event) {
 }
 
 // handleLogEvent handles log add events
-func (c *Controller) handleLogEvent(e Event) {
-	level, ok := e.Data["level"].(LogLevel)
-	if !ok {
-		return
-	}
-
-	instance, ok := e.Data["instance"].(int)
-	if !ok {
-		return
-	}
-
-	message, ok := e.Data["message"].(string)
-	if !ok {
-		return
-	}
-
+func (c *Controller) handleLogEvent(level LogLevel, instance int, message string) {
 	if c.logTab != nil {
 		c.logTab.AddLog(level, instance, message)
 	}
 }
 
 // handleDialogError handles error dialog events
-func (c *Controller) handleDialogError(e Event) {
-	message, ok := e.Data["message"].(string)
-	if !ok {
-		return
-	}
-
+func (c *Controller) handleDialogError(message string) {
 	// Fyne dialogs are safe to call from any goroutine
 	// because they queue themselves on the main thread
 	dialog.ShowError(fmt.Errorf("%s", message), c.window)
 }
 
 // handleDialogInfo handles info dialog events
-func (c *Controller) handleDialogInfo(e Event) {
-	title, ok := e.Data["title"].(string)
-	if !ok {
-		return
-	}
-
-	message, ok := e.Data["message"].(string)
-	if !ok {
-		return
-	}
-
+func (c *Controller) handleDialogInfo(title string, message string) {
 	dialog.ShowInformation(title, message, c.window)
 }
 
@@ -669,7 +786,9 @@ func (c *Controller) CreateEmulatorManager() *emulator.Manager {
 		adbPath = "dummy"
 	}
 
-	return emulator.NewManager(cfg.FolderPath, adbPath)
+	mgr := emulator.NewManager(cfg.FolderPath, adbPath)
+	mgr.SetInstanceADBPaths(cfg.ResolvedInstanceADBPaths())
+	return mgr
 }
 
 // GetMuMuManager returns the shared MuMu manager
@@ -3,9 +3,9 @@ package gui
 import (
 	"fmt"
 	"image"
-	"image/png"
-	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -15,7 +15,7 @@ import (
 	"jordanella.com/pocket-tcg-go/internal/bot"
 	"jordanella.com/pocket-tcg-go/internal/cv"
 	"jordanella.com/pocket-tcg-go/internal/emulator"
-	_ "jordanella.com/pocket-tcg-go/pkg/templates"
+	"jordanella.com/pocket-tcg-go/pkg/templates"
 )
 
 // ControlTab provides bot control and management
@@ -62,10 +62,15 @@ func (c *ControlTab) Build() fyne.CanvasObject {
 		c.populateInstanceDropdown()
 	})
 
+	aliasBtn := widget.NewButton("Set Alias...", func() {
+		c.showAliasDialog()
+	})
+
 	instanceSelector := container.NewHBox(
 		widget.NewLabel("Instance:"),
 		c.instanceSelect,
 		refreshBtn,
+		aliasBtn,
 	)
 
 	// Single instance controls
@@ -140,10 +145,15 @@ func (c *ControlTab) Build() fyne.CanvasObject {
 		c.stopAllInstances()
 	})
 
+	contactSheetBtn := widget.NewButton("Snapshot All (Contact Sheet)", func() {
+		c.snapshotAllInstances()
+	})
+
 	multiControls := container.NewGridWithColumns(2,
 		launchAllBtn,
 		c.startAllBtn,
 		c.stopAllBtn,
+		contactSheetBtn,
 	)
 
 	multiInstanceSection := container.NewVBox(
@@ -698,13 +708,13 @@ func (c *ControlTab) populateInstanceDropdown() {
 
 	// Sort by instance number for consistent ordering
 	for i := 0; i <= 10; i++ {
-		if config, exists := configs[i]; exists && config.PlayerName != "" {
-			displayName := fmt.Sprintf("%d: %s", i, config.PlayerName)
+		if config, exists := configs[i]; exists && (config.PlayerName != "" || mgr.GetInstanceAlias(i) != "") {
+			displayName := emulator.InstanceLabel(i, config.PlayerName)
 			options = append(options, displayName)
 			newInstanceMap[displayName] = i
 		} else if i > 0 && i <= 5 {
 			// For instances 1-5 without configs, still show them
-			displayName := fmt.Sprintf("Instance %d", i)
+			displayName := emulator.InstanceLabel(i, "")
 			options = append(options, displayName)
 			newInstanceMap[displayName] = i
 		}
@@ -722,6 +732,40 @@ func (c *ControlTab) populateInstanceDropdown() {
 	c.controller.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Loaded %d instance configurations", len(options)))
 }
 
+// showAliasDialog prompts for a display alias for the selected instance
+func (c *ControlTab) showAliasDialog() {
+	instanceNum, err := c.getSelectedInstance()
+	if err != nil {
+		c.showError(err.Error())
+		return
+	}
+
+	aliases := emulator.DefaultAliasStore()
+
+	aliasEntry := widget.NewEntry()
+	aliasEntry.SetText(aliases.Get(instanceNum))
+	aliasEntry.SetPlaceHolder("Leave empty to use the MuMu player name")
+
+	dialog.ShowForm(fmt.Sprintf("Alias for Instance %d", instanceNum), "Save", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Alias", aliasEntry)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := aliases.Set(instanceNum, aliasEntry.Text); err != nil {
+				c.showError(fmt.Sprintf("Failed to save alias: %v", err))
+				return
+			}
+			c.populateInstanceDropdown()
+			for label, index := range c.instanceMap {
+				if index == instanceNum {
+					c.instanceSelect.SetSelected(label)
+					break
+				}
+			}
+		}, c.controller.window)
+}
+
 // getSelectedInstance returns the instance number from the selected dropdown item
 func (c *ControlTab) getSelectedInstance() (int, error) {
 	selected := c.instanceSelect.Selected
@@ -758,7 +802,35 @@ func getScaleParam(language string) int {
 	}
 }
 
-// snapshotScreen captures the full window and saves it as PNG
+// snapshotFormats maps the snapshot dialogs' format choices to image formats
+var snapshotFormats = map[string]cv.ImageFormat{
+	"PNG (lossless)": cv.ImageFormatPNG,
+	"JPEG (smaller)": cv.ImageFormatJPEG,
+}
+
+// newSnapshotFormatSelect creates the snapshot format choice, defaulting to the configured
+// screenshot format. Changing it swaps the extension in fileNameEntry.
+func (c *ControlTab) newSnapshotFormatSelect(fileNameEntry *widget.Entry) *widget.Select {
+	formatSelect := widget.NewSelect([]string{"PNG (lossless)", "JPEG (smaller)"}, func(choice string) {
+		name := fileNameEntry.Text
+		fileNameEntry.SetText(strings.TrimSuffix(name, filepath.Ext(name)) + snapshotFormats[choice].Extension())
+	})
+
+	formatSelect.SetSelected("PNG (lossless)")
+	if c.controller.GetConfig().GetScreenshotOptions().Format == cv.ImageFormatJPEG {
+		formatSelect.SetSelected("JPEG (smaller)")
+	}
+	return formatSelect
+}
+
+// snapshotSaveOptions returns the configured screenshot options with the chosen format
+func (c *ControlTab) snapshotSaveOptions(formatSelect *widget.Select) cv.SaveOptions {
+	opts := c.controller.GetConfig().GetScreenshotOptions()
+	opts.Format = snapshotFormats[formatSelect.Selected]
+	return opts
+}
+
+// snapshotScreen captures the full window and saves it as PNG or JPEG
 func (c *ControlTab) snapshotScreen() {
 	instanceNum, err := c.getSelectedInstance()
 	if err != nil {
@@ -777,11 +849,13 @@ func (c *ControlTab) snapshotScreen() {
 	fileNameEntry := widget.NewEntry()
 	fileNameEntry.SetText(fmt.Sprintf("snapshot_instance_%d.png", instanceNum))
 	fileNameEntry.SetPlaceHolder("File name")
+	formatSelect := c.newSnapshotFormatSelect(fileNameEntry)
 
 	// Create form
 	form := container.NewVBox(
 		widget.NewLabel("Enter filename for snapshot:"),
 		fileNameEntry,
+		container.NewGridWithColumns(2, widget.NewLabel("Format:"), formatSelect),
 		widget.NewLabel("(File will be saved in current directory)"),
 	)
 
@@ -809,9 +883,9 @@ func (c *ControlTab) snapshotScreen() {
 				return
 			}
 
-			// Save to PNG
-			if err := savePNG(frame, fileName); err != nil {
-				c.showError(fmt.Sprintf("Failed to save PNG: %v", err))
+			// Save in the chosen format
+			if err := cv.SaveImage(frame, fileName, c.snapshotSaveOptions(formatSelect)); err != nil {
+				c.showError(fmt.Sprintf("Failed to save snapshot: %v", err))
 				c.controller.logTab.AddLog(LogLevelError, instanceNum, fmt.Sprintf("Save failed: %v", err))
 				return
 			}
@@ -826,7 +900,7 @@ func (c *ControlTab) snapshotScreen() {
 	dlg.Show()
 }
 
-// snapshotRegion captures a specific region and saves it as PNG
+// snapshotRegion captures a specific region and saves it as PNG or JPEG (or as a PNG template)
 func (c *ControlTab) snapshotRegion() {
 	instanceNum, err := c.getSelectedInstance()
 	if err != nil {
@@ -864,6 +938,31 @@ func (c *ControlTab) snapshotRegion() {
 	fileNameEntry := widget.NewEntry()
 	fileNameEntry.SetText(fmt.Sprintf("region_instance_%d.png", instanceNum))
 	fileNameEntry.SetPlaceHolder("File name")
+	formatSelect := c.newSnapshotFormatSelect(fileNameEntry)
+
+	// Template authoring: save the region straight into the template registry
+	templateNameEntry := widget.NewEntry()
+	templateNameEntry.SetPlaceHolder("Template name (letters, digits, _ and -)")
+	templateNameEntry.Disable()
+
+	thresholdEntry := widget.NewEntry()
+	thresholdEntry.SetText("0.8")
+	thresholdEntry.Disable()
+
+	saveAsTemplateCheck := widget.NewCheck("Save as template", func(checked bool) {
+		if checked {
+			// Templates are always saved losslessly
+			fileNameEntry.Disable()
+			formatSelect.Disable()
+			templateNameEntry.Enable()
+			thresholdEntry.Enable()
+		} else {
+			fileNameEntry.Enable()
+			formatSelect.Enable()
+			templateNameEntry.Disable()
+			thresholdEntry.Disable()
+		}
+	})
 
 	// Create form
 	form := container.NewVBox(
@@ -884,6 +983,13 @@ func (c *ControlTab) snapshotRegion() {
 		widget.NewSeparator(),
 		widget.NewLabel("Output filename:"),
 		fileNameEntry,
+		container.NewGridWithColumns(2, widget.NewLabel("Format:"), formatSelect),
+		widget.NewSeparator(),
+		saveAsTemplateCheck,
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Template name:"), templateNameEntry,
+			widget.NewLabel("Match threshold:"), thresholdEntry,
+		),
 	)
 
 	// Create dialog
@@ -917,8 +1023,26 @@ func (c *ControlTab) snapshotRegion() {
 			return
 		}
 
+		saveAsTemplate := saveAsTemplateCheck.Checked
+		templateName := strings.TrimSpace(templateNameEntry.Text)
+		threshold := 0.8
+
 		fileName := fileNameEntry.Text
-		if fileName == "" {
+		if saveAsTemplate {
+			if err := templates.ValidateTemplateName(templateName); err != nil {
+				c.showError(err.Error())
+				return
+			}
+			if b.Templates().Has(templateName) {
+				c.showError(fmt.Sprintf("Template '%s' already exists", templateName))
+				return
+			}
+			threshold, err = strconv.ParseFloat(strings.TrimSpace(thresholdEntry.Text), 64)
+			if err != nil || threshold <= 0 || threshold > 1 {
+				c.showError("Match threshold must be a number between 0 and 1")
+				return
+			}
+		} else if fileName == "" {
 			c.showError("Please enter a filename")
 			return
 		}
@@ -950,9 +1074,23 @@ func (c *ControlTab) snapshotRegion() {
 			// Crop the region
 			region := cv.CropRegion(frame, image.Rect(x1, y1, x2, y2))
 
-			// Save to PNG
-			if err := savePNG(region, fileName); err != nil {
-				c.showError(fmt.Sprintf("Failed to save PNG: %v", err))
+			if saveAsTemplate {
+				template, err := b.SaveCapturedTemplate(region, templateName, threshold)
+				if err != nil {
+					c.showError(fmt.Sprintf("Failed to save template: %v", err))
+					c.controller.logTab.AddLog(LogLevelError, instanceNum, fmt.Sprintf("Template save failed: %v", err))
+					return
+				}
+
+				c.controller.logTab.AddLog(LogLevelInfo, instanceNum, fmt.Sprintf("Template '%s' saved to: %s", template.Name, template.Path))
+				c.showSuccess(fmt.Sprintf("Template saved and registered!\n\nName: %s\nFile: %s\nThreshold: %.2f\nSize: %dx%d",
+					template.Name, template.Path, template.Threshold, x2-x1, y2-y1))
+				return
+			}
+
+			// Save in the chosen format
+			if err := cv.SaveImage(region, fileName, c.snapshotSaveOptions(formatSelect)); err != nil {
+				c.showError(fmt.Sprintf("Failed to save snapshot: %v", err))
 				c.controller.logTab.AddLog(LogLevelError, instanceNum, fmt.Sprintf("Save failed: %v", err))
 				return
 			}
@@ -963,21 +1101,6 @@ func (c *ControlTab) snapshotRegion() {
 		}()
 	}, c.controller.window)
 
-	dlg.Resize(fyne.NewSize(400, 300))
+	dlg.Resize(fyne.NewSize(450, 460))
 	dlg.Show()
 }
-
-// savePNG saves an image to a PNG file
-func savePNG(img image.Image, path string) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	if err := png.Encode(file, img); err != nil {
-		return fmt.Errorf("failed to encode PNG: %w", err)
-	}
-
-	return nil
-}
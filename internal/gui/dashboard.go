@@ -126,7 +126,7 @@ func (d *DashboardTab) createMuMuInstanceCard(inst *emulator.MuMuInstance) fyne.
 	// Extract Account button
 	extractAccountBtn := widget.NewButton("Extract Account", func() {
 		destFile := fmt.Sprintf("account_%s.xml", inst.WindowTitle)
-		err := accounts.ExtractAccount(d.controller.config.ADBPath, inst.ADBPort, destFile)
+		err := accounts.ExtractAccount(d.controller.config.ADBPath, inst.ADBPort, d.controller.config.GamePackageName(), destFile)
 		if err != nil {
 			d.controller.logTab.AddLog(LogLevelError, inst.Index, fmt.Sprintf("Failed to extract account: %v", err))
 		} else {
@@ -247,6 +247,7 @@ func (d *DashboardTab) testADBConnection(inst *emulator.MuMuInstance) {
 
 	// Create emulator manager
 	mgr := emulator.NewManager(cfg.FolderPath, adbCfg.Path)
+	mgr.SetInstanceADBPaths(cfg.ResolvedInstanceADBPaths())
 
 	// Discover instances (to populate manager state)
 	if err := mgr.DiscoverInstances(); err != nil {
@@ -7,6 +7,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 	"jordanella.com/pocket-tcg-go/internal/database"
 )
@@ -48,10 +49,16 @@ func (t *DatabaseAccountsTab) Build() fyne.CanvasObject {
 		t.refresh()
 	})
 
+	// Export button
+	exportBtn := widget.NewButton("Export...", func() {
+		t.showExportDialog()
+	})
+
 	// Toolbar
 	toolbar := container.NewHBox(
 		t.viewModeBtn,
 		refreshBtn,
+		exportBtn,
 	)
 
 	// Content area - use Stack instead of VBox to allow content to expand
@@ -128,6 +135,83 @@ func (t *DatabaseAccountsTab) refresh() {
 	t.contentArea.Refresh()
 }
 
+// Export formats offered by the accounts tab
+const (
+	exportAccountsCSV  = "Accounts (CSV)"
+	exportAccountsJSON = "Accounts with pull summaries (JSON)"
+	exportPullsCSV     = "Card pulls (CSV)"
+)
+
+// showExportDialog asks for the export format and filters, then for the file to save to
+func (t *DatabaseAccountsTab) showExportDialog() {
+	window := t.controller.window
+	if t.db == nil {
+		dialog.ShowError(fmt.Errorf("database not initialized"), window)
+		return
+	}
+
+	formatSelect := widget.NewSelect([]string{exportAccountsCSV, exportAccountsJSON, exportPullsCSV}, nil)
+	formatSelect.SetSelected(exportAccountsCSV)
+	inactiveCheck := widget.NewCheck("Include inactive accounts", nil)
+	bannedCheck := widget.NewCheck("Include banned accounts", nil)
+
+	form := container.NewVBox(
+		widget.NewLabel("Format:"),
+		formatSelect,
+		inactiveCheck,
+		bannedCheck,
+	)
+
+	dialog.ShowCustomConfirm("Export Accounts", "Choose File...", "Cancel", form, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		filter := database.AccountFilter{
+			IncludeInactive: inactiveCheck.Checked,
+			IncludeBanned:   bannedCheck.Checked,
+		}
+		t.showExportFileDialog(formatSelect.Selected, filter)
+	}, window)
+}
+
+// showExportFileDialog streams the chosen export to the file picked by the user
+func (t *DatabaseAccountsTab) showExportFileDialog(format string, filter database.AccountFilter) {
+	window := t.controller.window
+
+	export := t.db.ExportAccountsCSV
+	name, ext := "accounts", ".csv"
+	switch format {
+	case exportAccountsJSON:
+		export = t.db.ExportAccountsJSON
+		ext = ".json"
+	case exportPullsCSV:
+		export = t.db.ExportPullsCSV
+		name = "pulls"
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if err := export(writer, filter); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export %s: %w", name, err), window)
+			return
+		}
+
+		dialog.ShowInformation("Export Complete", fmt.Sprintf("Exported %s to %s", name, writer.URI().Path()), window)
+	}, window)
+
+	saveDialog.SetFileName(fmt.Sprintf("%s_%s%s", name, time.Now().Format("20060102_150405"), ext))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{ext}))
+	saveDialog.Show()
+}
+
 // buildCardsView creates a grid of account cards
 func (t *DatabaseAccountsTab) buildCardsView(accounts []*database.Account) fyne.CanvasObject {
 	cards := container.NewGridWithColumns(2)
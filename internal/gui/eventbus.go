@@ -28,6 +28,12 @@ type Event struct {
 	Data      map[string]interface{}
 }
 
+// Per-topic replay settings
+const (
+	replayHistorySize = 100 // Recent events kept per topic for SubscribeReplay
+	streamBufferSize  = 100 // Live events buffered per SubscribeReplay channel
+)
+
 // EventBus manages event distribution
 type EventBus struct {
 	events   chan Event
@@ -35,6 +41,10 @@ type EventBus struct {
 	mu       sync.RWMutex
 	stopCh   chan struct{}
 	app      fyne.App
+
+	// Replay support (topic = Event.Target)
+	history map[string][]Event      // Recent events per topic, oldest first
+	streams map[string][]chan Event // SubscribeReplay channels per topic
 }
 
 // EventHandler processes events
@@ -46,6 +56,8 @@ func NewEventBus() *EventBus {
 		events:   make(chan Event, 100), // Buffered channel
 		handlers: make(map[EventType][]EventHandler),
 		stopCh:   make(chan struct{}),
+		history:  make(map[string][]Event),
+		streams:  make(map[string][]chan Event),
 	}
 }
 
@@ -57,6 +69,134 @@ func (eb *EventBus) Subscribe(eventType EventType, handler EventHandler) {
 	eb.handlers[eventType] = append(eb.handlers[eventType], handler)
 }
 
+// SubscribeReplay returns a channel that first receives the last n events published to topic
+// (the event Target, e.g. "adbtest.results"), then every new event for it.
+// Live events are dropped if the channel buffer is full. Call Unsubscribe to close it.
+func (eb *EventBus) SubscribeReplay(topic string, n int) <-chan Event {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	replay := eb.recentLocked(topic, n)
+	ch := make(chan Event, len(replay)+streamBufferSize)
+	for _, event := range replay {
+		ch <- event
+	}
+
+	eb.streams[topic] = append(eb.streams[topic], ch)
+	return ch
+}
+
+// Unsubscribe closes a channel returned by SubscribeReplay
+func (eb *EventBus) Unsubscribe(ch <-chan Event) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	for topic, streams := range eb.streams {
+		for i, stream := range streams {
+			if stream == ch {
+				eb.streams[topic] = append(streams[:i], streams[i+1:]...)
+				close(stream)
+				return
+			}
+		}
+	}
+}
+
+// Recent returns up to the last n events published to topic, oldest first
+func (eb *EventBus) Recent(topic string, n int) []Event {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+	return eb.recentLocked(topic, n)
+}
+
+// recentLocked copies the last n events for topic (caller must hold mu)
+func (eb *EventBus) recentLocked(topic string, n int) []Event {
+	history := eb.history[topic]
+	if n <= 0 || len(history) == 0 {
+		return nil
+	}
+	if n > len(history) {
+		n = len(history)
+	}
+
+	recent := make([]Event, n)
+	copy(recent, history[len(history)-n:])
+	return recent
+}
+
+// Typed subscription helpers - handlers receive the event fields instead of the raw Data map
+
+// SubscribeProgressBar registers a handler for progress bar show/hide events
+func (eb *EventBus) SubscribeProgressBar(handler func(target string, visible bool)) {
+	eb.Subscribe(EventTypeProgressBarShow, func(e Event) {
+		handler(e.Target, true)
+	})
+	eb.Subscribe(EventTypeProgressBarHide, func(e Event) {
+		handler(e.Target, false)
+	})
+}
+
+// SubscribeLabel registers a handler for label update events
+func (eb *EventBus) SubscribeLabel(handler func(target string, text string)) {
+	eb.Subscribe(EventTypeLabelUpdate, func(e Event) {
+		if text, ok := e.Data["text"].(string); ok {
+			handler(e.Target, text)
+		}
+	})
+}
+
+// SubscribeLog registers a handler for log events
+func (eb *EventBus) SubscribeLog(handler func(level LogLevel, instance int, message string)) {
+	eb.Subscribe(EventTypeLogAdd, func(e Event) {
+		level, ok := e.Data["level"].(LogLevel)
+		if !ok {
+			return
+		}
+		instance, ok := e.Data["instance"].(int)
+		if !ok {
+			return
+		}
+		message, ok := e.Data["message"].(string)
+		if !ok {
+			return
+		}
+		handler(level, instance, message)
+	})
+}
+
+// SubscribeStatus registers a handler for status update events
+func (eb *EventBus) SubscribeStatus(handler func(target string, status string)) {
+	eb.Subscribe(EventTypeStatusUpdate, func(e Event) {
+		if status, ok := e.Data["status"].(string); ok {
+			handler(e.Target, status)
+		}
+	})
+}
+
+// SubscribeErrorDialog registers a handler for error dialog events
+func (eb *EventBus) SubscribeErrorDialog(handler func(message string)) {
+	eb.Subscribe(EventTypeDialogError, func(e Event) {
+		if message, ok := e.Data["message"].(string); ok {
+			handler(message)
+		}
+	})
+}
+
+// SubscribeInfoDialog registers a handler for info dialog events
+func (eb *EventBus) SubscribeInfoDialog(handler func(title string, message string)) {
+	eb.Subscribe(EventTypeDialogInfo, func(e Event) {
+		title, ok := e.Data["title"].(string)
+		if !ok {
+			return
+		}
+		message, ok := e.Data["message"].(string)
+		if !ok {
+			return
+		}
+		handler(title, message)
+	})
+}
+
 // Publish sends an event to the bus
 func (eb *EventBus) Publish(event Event) {
 	select {
@@ -118,6 +258,8 @@ func (eb *EventBus) processEvents() {
 
 // dispatch sends events to registered handlers
 func (eb *EventBus) dispatch(event Event) {
+	eb.recordAndStream(event)
+
 	eb.mu.RLock()
 	handlers, ok := eb.handlers[event.Type]
 	eb.mu.RUnlock()
@@ -136,6 +278,31 @@ func (eb *EventBus) dispatch(event Event) {
 	}
 }
 
+// recordAndStream adds the event to its topic history and forwards it to SubscribeReplay channels
+func (eb *EventBus) recordAndStream(event Event) {
+	if event.Target == "" {
+		return
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	history := append(eb.history[event.Target], event)
+	if len(history) > replayHistorySize {
+		history = history[len(history)-replayHistorySize:]
+	}
+	eb.history[event.Target] = history
+
+	// Sends happen under the lock so Unsubscribe cannot close a channel mid-send
+	for _, stream := range eb.streams[event.Target] {
+		select {
+		case stream <- event:
+		default:
+			log.Printf("[EventBus] WARNING: Replay subscriber full, dropping event: type=%d, target=%s\n", event.Type, event.Target)
+		}
+	}
+}
+
 // Helper functions for common events
 
 // ShowProgressBar creates an event to show a progress bar
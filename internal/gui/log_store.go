@@ -0,0 +1,143 @@
+package gui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// AllInstances matches entries from every instance in LogStore.Filter
+const AllInstances = -1
+
+// LogStore keeps the most recent log entries in a fixed-size ring buffer.
+// It is safe for concurrent writers.
+type LogStore struct {
+	entries []LogEntry
+	start   int // Index of the oldest entry
+	count   int
+	mu      sync.RWMutex
+}
+
+// NewLogStore creates a log store holding up to capacity entries
+func NewLogStore(capacity int) *LogStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LogStore{
+		entries: make([]LogEntry, capacity),
+	}
+}
+
+// Add appends an entry, overwriting the oldest one when the store is full
+func (s *LogStore) Add(entry LogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	if s.count < len(s.entries) {
+		s.entries[(s.start+s.count)%len(s.entries)] = entry
+		s.count++
+		return
+	}
+
+	s.entries[s.start] = entry
+	s.start = (s.start + 1) % len(s.entries)
+}
+
+// Clear removes all entries
+func (s *LogStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = make([]LogEntry, len(s.entries))
+	s.start = 0
+	s.count = 0
+}
+
+// Len returns the number of stored entries
+func (s *LogStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.count
+}
+
+// All returns every stored entry, oldest first
+func (s *LogStore) All() []LogEntry {
+	return s.Filter(LogLevelDebug, AllInstances)
+}
+
+// Filter returns entries at or above minLevel for an instance (AllInstances for every instance,
+// 0 for system messages), oldest first
+func (s *LogStore) Filter(minLevel LogLevel, instance int) []LogEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]LogEntry, 0, s.count)
+	for i := 0; i < s.count; i++ {
+		entry := s.entries[(s.start+i)%len(s.entries)]
+		if entry.Level < minLevel {
+			continue
+		}
+		if instance != AllInstances && entry.Instance != instance {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// Instances returns the distinct instance numbers present in the store, in first-seen order
+func (s *LogStore) Instances() []int {
+	seen := make(map[int]bool)
+	instances := make([]int, 0)
+	for _, entry := range s.All() {
+		if !seen[entry.Instance] {
+			seen[entry.Instance] = true
+			instances = append(instances, entry.Instance)
+		}
+	}
+	return instances
+}
+
+// ExportJSONL writes entries as one JSON object per line
+func ExportJSONL(w io.Writer, entries []LogEntry) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write log entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// ExportText writes entries in the same layout as the log tab
+func ExportText(w io.Writer, entries []LogEntry) error {
+	bw := bufio.NewWriter(w)
+	for _, entry := range entries {
+		source := "SYS"
+		if entry.Instance > 0 {
+			source = fmt.Sprintf("I%d", entry.Instance)
+		}
+		if _, err := fmt.Fprintf(bw, "%s [%s] [%s] %s\n",
+			entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Level, source, entry.Message); err != nil {
+			return fmt.Errorf("failed to write log entry: %w", err)
+		}
+	}
+	return bw.Flush()
+}
+
+// ExportJSONL writes all stored entries as JSON lines
+func (s *LogStore) ExportJSONL(w io.Writer) error {
+	return ExportJSONL(w, s.All())
+}
+
+// ExportText writes all stored entries as plain text
+func (s *LogStore) ExportText(w io.Writer) error {
+	return ExportText(w, s.All())
+}
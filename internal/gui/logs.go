@@ -2,11 +2,17 @@ package gui
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -35,36 +41,70 @@ func (l LogLevel) String() string {
 	}
 }
 
+// MarshalText encodes the level by name (used for JSON export)
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// ParseLogLevel converts a level name (DEBUG, INFO, WARN, ERROR) to a LogLevel
+func ParseLogLevel(name string) (LogLevel, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "DEBUG":
+		return LogLevelDebug, nil
+	case "INFO":
+		return LogLevelInfo, nil
+	case "WARN", "WARNING":
+		return LogLevelWarn, nil
+	case "ERROR":
+		return LogLevelError, nil
+	default:
+		return LogLevelDebug, fmt.Errorf("unknown log level '%s'", name)
+	}
+}
+
 // LogEntry represents a single log entry
 type LogEntry struct {
-	Timestamp time.Time
-	Level     LogLevel
-	Instance  int
-	Message   string
+	Timestamp time.Time `json:"timestamp"`
+	Level     LogLevel  `json:"level"`
+	Instance  int       `json:"instance"` // 0 = system
+	Message   string    `json:"message"`
 }
 
+// Instance filter options
+const (
+	instanceFilterAll    = "All"
+	instanceFilterSystem = "System"
+)
+
 // LogTab displays bot event logs
 type LogTab struct {
 	controller *Controller
 
 	// Log storage
-	logs   []LogEntry
-	logsMu sync.RWMutex
+	store *LogStore
+
+	// Current filtered view (only touched on the UI thread)
+	view []LogEntry
+
+	// Instances offered in the instance filter
+	knownInstances   map[int]bool
+	knownInstancesMu sync.Mutex
 
 	// Widgets
-	logList       *widget.List
-	clearBtn      *widget.Button
-	filterSelect  *widget.Select
+	logList         *widget.List
+	clearBtn        *widget.Button
+	exportBtn       *widget.Button
+	filterSelect    *widget.Select
+	instanceSelect  *widget.Select
 	autoScrollCheck *widget.Check
-	maxLogs       int
 }
 
 // NewLogTab creates a new log tab
 func NewLogTab(ctrl *Controller) *LogTab {
 	tab := &LogTab{
-		controller: ctrl,
-		logs:       make([]LogEntry, 0, 1000),
-		maxLogs:    1000,
+		controller:     ctrl,
+		store:          NewLogStore(1000),
+		knownInstances: make(map[int]bool),
 	}
 
 	// Add some sample logs for demonstration
@@ -80,16 +120,20 @@ func (l *LogTab) Build() fyne.CanvasObject {
 	// Header
 	header := widget.NewLabelWithStyle("Event Log", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 
-	// Filter dropdown
+	// Minimum level dropdown
 	l.filterSelect = widget.NewSelect(
-		[]string{"All", "DEBUG", "INFO", "WARN", "ERROR"},
+		[]string{"DEBUG", "INFO", "WARN", "ERROR"},
 		func(selected string) {
-			if l.logList != nil {
-				l.logList.Refresh()
-			}
+			l.refreshView()
 		},
 	)
-	l.filterSelect.PlaceHolder = "All"
+	l.filterSelect.PlaceHolder = "DEBUG"
+
+	// Instance dropdown
+	l.instanceSelect = widget.NewSelect(l.instanceOptions(), func(selected string) {
+		l.refreshView()
+	})
+	l.instanceSelect.PlaceHolder = instanceFilterAll
 
 	// Auto-scroll checkbox
 	l.autoScrollCheck = widget.NewCheck("Auto-scroll", nil)
@@ -100,18 +144,26 @@ func (l *LogTab) Build() fyne.CanvasObject {
 		l.ClearLogs()
 	})
 
+	// Export button
+	l.exportBtn = widget.NewButton("Export...", func() {
+		l.showExportDialog()
+	})
+
 	// Controls
 	controls := container.NewHBox(
-		widget.NewLabel("Filter:"),
+		widget.NewLabel("Min level:"),
 		l.filterSelect,
+		widget.NewLabel("Instance:"),
+		l.instanceSelect,
 		l.autoScrollCheck,
 		l.clearBtn,
+		l.exportBtn,
 	)
 
 	// Log list
 	l.logList = widget.NewList(
 		func() int {
-			return l.getFilteredLogCount()
+			return len(l.view)
 		},
 		func() fyne.CanvasObject {
 			return container.NewHBox(
@@ -122,10 +174,10 @@ func (l *LogTab) Build() fyne.CanvasObject {
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
-			entry := l.getFilteredLog(id)
-			if entry == nil {
+			if id < 0 || id >= len(l.view) {
 				return
 			}
+			entry := l.view[id]
 
 			box := item.(*fyne.Container)
 
@@ -163,6 +215,8 @@ func (l *LogTab) Build() fyne.CanvasObject {
 		},
 	)
 
+	l.view = l.filteredLogs()
+
 	// Layout
 	content := container.NewBorder(
 		container.NewVBox(header, controls),
@@ -175,113 +229,144 @@ func (l *LogTab) Build() fyne.CanvasObject {
 	return content
 }
 
-// AddLog adds a new log entry
+// AddLog adds a new log entry (safe to call from any goroutine)
 func (l *LogTab) AddLog(level LogLevel, instance int, message string) {
-	l.logsMu.Lock()
-
-	entry := LogEntry{
+	l.store.Add(LogEntry{
 		Timestamp: time.Now(),
 		Level:     level,
 		Instance:  instance,
 		Message:   message,
-	}
-
-	l.logs = append(l.logs, entry)
-
-	// Trim if exceeds max
-	if len(l.logs) > l.maxLogs {
-		l.logs = l.logs[len(l.logs)-l.maxLogs:]
-	}
+	})
 
-	l.logsMu.Unlock()
+	// Offer new instances in the instance filter
+	l.knownInstancesMu.Lock()
+	newInstance := !l.knownInstances[instance]
+	l.knownInstances[instance] = true
+	l.knownInstancesMu.Unlock()
 
 	// Refresh list if created - use fyne.Do for thread safety
 	if l.logList != nil {
 		fyne.Do(func() {
-			l.logList.Refresh()
-
-			// Auto-scroll to bottom
-			if l.autoScrollCheck != nil && l.autoScrollCheck.Checked {
-				l.logList.ScrollToBottom()
+			if newInstance && l.instanceSelect != nil {
+				l.instanceSelect.SetOptions(l.instanceOptions())
 			}
+			l.refreshView()
 		})
 	}
 }
 
 // ClearLogs removes all log entries
 func (l *LogTab) ClearLogs() {
-	l.logsMu.Lock()
-	defer l.logsMu.Unlock()
+	l.store.Clear()
+	l.refreshView()
+}
 
-	l.logs = make([]LogEntry, 0, 1000)
+// GetLogs returns all logs (for export, etc.)
+func (l *LogTab) GetLogs() []LogEntry {
+	return l.store.All()
+}
 
-	if l.logList != nil {
-		l.logList.Refresh()
-	}
+// Store returns the underlying log store
+func (l *LogTab) Store() *LogStore {
+	return l.store
 }
 
-// getFilteredLogCount returns count of logs matching filter
-func (l *LogTab) getFilteredLogCount() int {
-	l.logsMu.RLock()
-	defer l.logsMu.RUnlock()
+// refreshView re-applies the filters and refreshes the list (UI thread only)
+func (l *LogTab) refreshView() {
+	if l.logList == nil {
+		return
+	}
 
-	// Default to "All" if nothing selected
-	selected := "All"
+	l.view = l.filteredLogs()
+	l.logList.Refresh()
+
+	// Auto-scroll to bottom
+	if l.autoScrollCheck != nil && l.autoScrollCheck.Checked {
+		l.logList.ScrollToBottom()
+	}
+}
+
+// filteredLogs returns the entries matching the selected level and instance
+func (l *LogTab) filteredLogs() []LogEntry {
+	minLevel := LogLevelDebug
 	if l.filterSelect != nil && l.filterSelect.Selected != "" {
-		selected = l.filterSelect.Selected
+		if level, err := ParseLogLevel(l.filterSelect.Selected); err == nil {
+			minLevel = level
+		}
 	}
 
-	if selected == "All" {
-		return len(l.logs)
+	instance := AllInstances
+	if l.instanceSelect != nil {
+		instance = parseInstanceFilter(l.instanceSelect.Selected)
 	}
 
-	count := 0
-	for _, entry := range l.logs {
-		if entry.Level.String() == selected {
-			count++
+	return l.store.Filter(minLevel, instance)
+}
+
+// instanceOptions builds the instance dropdown options from instances seen so far
+func (l *LogTab) instanceOptions() []string {
+	l.knownInstancesMu.Lock()
+	instances := make([]int, 0, len(l.knownInstances))
+	for instance := range l.knownInstances {
+		if instance > 0 {
+			instances = append(instances, instance)
 		}
 	}
-	return count
-}
+	l.knownInstancesMu.Unlock()
+	sort.Ints(instances)
 
-// getFilteredLog returns the Nth filtered log entry
-func (l *LogTab) getFilteredLog(index int) *LogEntry {
-	l.logsMu.RLock()
-	defer l.logsMu.RUnlock()
+	options := []string{instanceFilterAll, instanceFilterSystem}
+	for _, instance := range instances {
+		options = append(options, fmt.Sprintf("Instance %d", instance))
+	}
+	return options
+}
 
-	// Default to "All" if nothing selected
-	selected := "All"
-	if l.filterSelect != nil && l.filterSelect.Selected != "" {
-		selected = l.filterSelect.Selected
+// parseInstanceFilter converts an instance dropdown option to a LogStore.Filter instance
+func parseInstanceFilter(option string) int {
+	switch option {
+	case "", instanceFilterAll:
+		return AllInstances
+	case instanceFilterSystem:
+		return 0
 	}
 
-	if selected == "All" {
-		if index >= 0 && index < len(l.logs) {
-			return &l.logs[index]
-		}
-		return nil
+	instance, err := strconv.Atoi(strings.TrimPrefix(option, "Instance "))
+	if err != nil {
+		return AllInstances
 	}
+	return instance
+}
 
-	// Filter logs
-	currentIndex := 0
-	for i := range l.logs {
-		if l.logs[i].Level.String() == selected {
-			if currentIndex == index {
-				return &l.logs[i]
-			}
-			currentIndex++
+// showExportDialog saves the currently filtered logs as .jsonl or plain text
+func (l *LogTab) showExportDialog() {
+	window := l.controller.window
+	entries := l.filteredLogs()
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
 		}
-	}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
 
-	return nil
-}
+		if strings.EqualFold(filepath.Ext(writer.URI().Path()), ".jsonl") {
+			err = ExportJSONL(writer, entries)
+		} else {
+			err = ExportText(writer, entries)
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export logs: %w", err), window)
+			return
+		}
 
-// GetLogs returns all logs (for export, etc.)
-func (l *LogTab) GetLogs() []LogEntry {
-	l.logsMu.RLock()
-	defer l.logsMu.RUnlock()
+		l.AddLog(LogLevelInfo, 0, fmt.Sprintf("Exported %d log entries to %s", len(entries), writer.URI().Path()))
+	}, window)
 
-	logs := make([]LogEntry, len(l.logs))
-	copy(logs, l.logs)
-	return logs
+	saveDialog.SetFileName(fmt.Sprintf("logs_%s.jsonl", time.Now().Format("20060102_150405")))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".jsonl", ".txt", ".log"}))
+	saveDialog.Show()
 }
@@ -49,6 +49,9 @@ type ManagerGroup struct {
 	PoolConfig   accountpool.PoolConfig
 	AccountPool  accountpool.AccountPool
 
+	// Successful routine runs before a bot completes instead of restarting (0 = unlimited)
+	MaxIterations int
+
 	// UI components
 	card           *fyne.Container
 	statusLabel    *widget.Label
@@ -168,6 +171,10 @@ func (t *ManagerGroupsTab) showCreateGroupDialog() {
 	instancesEntry.SetPlaceHolder("e.g., 1-4 or 1,2,3,4")
 	instancesEntry.SetText("1-4")
 
+	maxIterationsEntry := widget.NewEntry()
+	maxIterationsEntry.SetPlaceHolder("0 = unlimited")
+	maxIterationsEntry.SetText("0")
+
 	// Pool selection
 	var poolOptions []string
 	poolOptions = append(poolOptions, "(None - No Account Pool)")
@@ -225,6 +232,17 @@ func (t *ManagerGroupsTab) showCreateGroupDialog() {
 	maxFailuresEntry.SetPlaceHolder("3")
 	maxFailuresEntry.SetText("3")
 
+	// Legacy pools can't run anymore; convert the folder to a unified pool instead
+	convertBtn := widget.NewButton("Convert to Unified Pool...", func() {
+		config, err := t.parseLegacyPoolConfig(minPacksEntry.Text, maxPacksEntry.Text,
+			sortMethodSelect.Selected, retryCheck.Checked, maxFailuresEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, t.controller.window)
+			return
+		}
+		t.convertLegacyPool(accountsPath, config, poolSelect)
+	})
+
 	legacyConfigContainer := container.NewVBox(
 		widget.NewLabel("Minimum Packs:"),
 		minPacksEntry,
@@ -235,6 +253,7 @@ func (t *ManagerGroupsTab) showCreateGroupDialog() {
 		retryCheck,
 		widget.NewLabel("Max Retry Attempts:"),
 		maxFailuresEntry,
+		convertBtn,
 	)
 	legacyConfigContainer.Hide()
 
@@ -259,6 +278,8 @@ func (t *ManagerGroupsTab) showCreateGroupDialog() {
 		routineHelpLabel,
 		widget.NewLabel("Bot Instances:"),
 		instancesEntry,
+		widget.NewLabel("Max Iterations per Bot:"),
+		maxIterationsEntry,
 		widget.NewSeparator(),
 		widget.NewLabelWithStyle("Account Pool", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		widget.NewLabel("Select Pool:"),
@@ -280,6 +301,7 @@ func (t *ManagerGroupsTab) showCreateGroupDialog() {
 				nameEntry.Text,
 				routineEntry.Text,
 				instancesEntry.Text,
+				maxIterationsEntry.Text,
 				poolSelect.Selected,
 				accountsPath,
 				minPacksEntry.Text,
@@ -300,7 +322,7 @@ func (t *ManagerGroupsTab) showCreateGroupDialog() {
 
 // createGroup creates a new manager group
 func (t *ManagerGroupsTab) createGroup(
-	name, routineDisplay, instancesStr, poolSelection, accountsPath,
+	name, routineDisplay, instancesStr, maxIterationsStr, poolSelection, accountsPath,
 	minPacksStr, maxPacksStr, sortMethodStr string,
 	retryFailed bool, maxFailuresStr string,
 ) error {
@@ -335,12 +357,21 @@ func (t *ManagerGroupsTab) createGroup(
 		return fmt.Errorf("invalid instance IDs: %w", err)
 	}
 
+	maxIterations, err := t.parseMaxIterations(maxIterationsStr)
+	if err != nil {
+		return err
+	}
+
 	// Create manager with Controller's registries (MVC: injecting Model into Manager)
 	manager := bot.NewManagerWithRegistries(
 		t.controller.config,
 		t.controller.GetTemplateRegistry(),
 		t.controller.GetRoutineRegistry(),
 	)
+	if t.controller.db != nil {
+		// Account state, activities and checkouts go to the shared accounts database
+		manager.SetDatabase(t.controller.db.Conn())
+	}
 
 	// Create account pool based on selection
 	var pool accountpool.AccountPool
@@ -349,7 +380,7 @@ func (t *ManagerGroupsTab) createGroup(
 
 	if poolSelection != "(None - No Account Pool)" && poolSelection != "" {
 		if poolSelection == "(Legacy - File Browser)" {
-			return fmt.Errorf("legacy file browser pools are no longer supported - please use the Account Pools tab to create a unified pool")
+			return fmt.Errorf("legacy file browser pools are no longer supported - use \"Convert to Unified Pool...\" or the Account Pools tab to create a unified pool")
 		} else {
 			// Pool from PoolManager (get from Controller)
 			poolManager := t.controller.poolManager
@@ -378,6 +409,8 @@ func (t *ManagerGroupsTab) createGroup(
 		PoolConfig:   poolConfig,
 		AccountPool:  pool,
 		running:      false,
+
+		MaxIterations: maxIterations,
 	}
 
 	// Create UI card for group
@@ -509,6 +542,7 @@ func (t *ManagerGroupsTab) startGroup(group *ManagerGroup) {
 				MaxDelay:       5 * time.Minute,
 				BackoffFactor:  2.0,
 				ResetOnSuccess: true,
+				MaxIterations:  group.MaxIterations,
 			}
 
 			if err := group.Manager.ExecuteWithRestart(id, group.RoutineName, policy); err != nil {
@@ -613,6 +647,10 @@ func (t *ManagerGroupsTab) editGroup(group *ManagerGroup) {
 	instancesEntry.SetPlaceHolder("e.g., 1-4 or 1,2,3,4")
 	instancesEntry.SetText(t.formatInstanceIDs(group.InstanceIDs))
 
+	maxIterationsEntry := widget.NewEntry()
+	maxIterationsEntry.SetPlaceHolder("0 = unlimited")
+	maxIterationsEntry.SetText(strconv.Itoa(group.MaxIterations))
+
 	// Pool selection
 	var poolOptions []string
 	poolOptions = append(poolOptions, "(None - No Account Pool)")
@@ -678,6 +716,17 @@ func (t *ManagerGroupsTab) editGroup(group *ManagerGroup) {
 	maxFailuresEntry := widget.NewEntry()
 	maxFailuresEntry.SetText(strconv.Itoa(group.PoolConfig.MaxFailures))
 
+	// Legacy pools can't run anymore; convert the folder to a unified pool instead
+	convertBtn := widget.NewButton("Convert to Unified Pool...", func() {
+		config, err := t.parseLegacyPoolConfig(minPacksEntry.Text, maxPacksEntry.Text,
+			sortMethodSelect.Selected, retryCheck.Checked, maxFailuresEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, t.controller.window)
+			return
+		}
+		t.convertLegacyPool(accountsPath, config, poolSelect)
+	})
+
 	legacyConfigContainer := container.NewVBox(
 		widget.NewLabel("Minimum Packs:"),
 		minPacksEntry,
@@ -688,6 +737,7 @@ func (t *ManagerGroupsTab) editGroup(group *ManagerGroup) {
 		retryCheck,
 		widget.NewLabel("Max Retry Attempts:"),
 		maxFailuresEntry,
+		convertBtn,
 	)
 
 	// Show/hide legacy fields based on pool selection
@@ -719,6 +769,8 @@ func (t *ManagerGroupsTab) editGroup(group *ManagerGroup) {
 		routineHelpLabel,
 		widget.NewLabel("Bot Instances:"),
 		instancesEntry,
+		widget.NewLabel("Max Iterations per Bot:"),
+		maxIterationsEntry,
 		widget.NewSeparator(),
 		widget.NewLabelWithStyle("Account Pool", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		widget.NewLabel("Select Pool:"),
@@ -740,6 +792,7 @@ func (t *ManagerGroupsTab) editGroup(group *ManagerGroup) {
 				group,
 				routineEntry.Text,
 				instancesEntry.Text,
+				maxIterationsEntry.Text,
 				poolSelect.Selected,
 				accountsPath,
 				minPacksEntry.Text,
@@ -761,7 +814,7 @@ func (t *ManagerGroupsTab) editGroup(group *ManagerGroup) {
 // updateGroup updates an existing manager group
 func (t *ManagerGroupsTab) updateGroup(
 	group *ManagerGroup,
-	routineDisplay, instancesStr, poolSelection, accountsPath,
+	routineDisplay, instancesStr, maxIterationsStr, poolSelection, accountsPath,
 	minPacksStr, maxPacksStr, sortMethodStr string,
 	retryFailed bool, maxFailuresStr string,
 ) error {
@@ -785,12 +838,21 @@ func (t *ManagerGroupsTab) updateGroup(
 		return fmt.Errorf("invalid instance IDs: %w", err)
 	}
 
+	maxIterations, err := t.parseMaxIterations(maxIterationsStr)
+	if err != nil {
+		return err
+	}
+
 	// Create new manager with Controller's registries (MVC: injecting Model into Manager)
 	newManager := bot.NewManagerWithRegistries(
 		t.controller.config,
 		t.controller.GetTemplateRegistry(),
 		t.controller.GetRoutineRegistry(),
 	)
+	if t.controller.db != nil {
+		// Account state, activities and checkouts go to the shared accounts database
+		newManager.SetDatabase(t.controller.db.Conn())
+	}
 
 	// Create account pool based on selection
 	var pool accountpool.AccountPool
@@ -799,7 +861,7 @@ func (t *ManagerGroupsTab) updateGroup(
 
 	if poolSelection != "(None - No Account Pool)" && poolSelection != "" {
 		if poolSelection == "(Legacy - File Browser)" {
-			return fmt.Errorf("legacy file browser pools are no longer supported - please use the Account Pools tab to create a unified pool")
+			return fmt.Errorf("legacy file browser pools are no longer supported - use \"Convert to Unified Pool...\" or the Account Pools tab to create a unified pool")
 		} else {
 			// Pool from PoolManager (get from Controller)
 			poolManager := t.controller.poolManager
@@ -830,6 +892,7 @@ func (t *ManagerGroupsTab) updateGroup(
 	group.PoolConfig = poolConfig
 	group.AccountPool = pool
 	group.Manager = newManager
+	group.MaxIterations = maxIterations
 
 	// Recreate the card to reflect changes
 	t.groupsContainer.Remove(group.card)
@@ -971,6 +1034,84 @@ func (t *ManagerGroupsTab) parseInstanceIDs(str string) ([]int, error) {
 	return ids, nil
 }
 
+// parseMaxIterations parses the max iterations entry (empty = unlimited)
+func (t *ManagerGroupsTab) parseMaxIterations(str string) (int, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return 0, nil
+	}
+	maxIterations, err := strconv.Atoi(str)
+	if err != nil || maxIterations < 0 {
+		return 0, fmt.Errorf("max iterations must be a number >= 0 (0 for unlimited)")
+	}
+	return maxIterations, nil
+}
+
+// parseLegacyPoolConfig reads the legacy file pool settings entered in a group dialog
+func (t *ManagerGroupsTab) parseLegacyPoolConfig(
+	minPacksStr, maxPacksStr, sortMethodStr string,
+	retryFailed bool, maxFailuresStr string,
+) (accountpool.PoolConfig, error) {
+	config := accountpool.DefaultPoolConfig()
+	config.SortMethod = t.parseSortMethod(sortMethodStr)
+	config.RetryFailed = retryFailed
+
+	var err error
+	if config.MinPacks, err = strconv.Atoi(strings.TrimSpace(minPacksStr)); err != nil {
+		return config, fmt.Errorf("invalid minimum packs: %s", minPacksStr)
+	}
+	if config.MaxPacks, err = strconv.Atoi(strings.TrimSpace(maxPacksStr)); err != nil {
+		return config, fmt.Errorf("invalid maximum packs: %s", maxPacksStr)
+	}
+	if config.MaxFailures, err = strconv.Atoi(strings.TrimSpace(maxFailuresStr)); err != nil {
+		return config, fmt.Errorf("invalid max retry attempts: %s", maxFailuresStr)
+	}
+	return config, nil
+}
+
+// convertLegacyPool asks for a pool name, converts a legacy file pool folder into a unified
+// pool and selects the new pool in poolSelect
+func (t *ManagerGroupsTab) convertLegacyPool(accountsPath string, config accountpool.PoolConfig, poolSelect *widget.Select) {
+	poolManager := t.controller.poolManager
+	if poolManager == nil {
+		dialog.ShowError(fmt.Errorf("pool manager not available (database not initialized)"), t.controller.window)
+		return
+	}
+	if accountsPath == "" {
+		dialog.ShowError(fmt.Errorf("select the legacy accounts directory first"), t.controller.window)
+		return
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(filepath.Base(accountsPath))
+
+	content := container.NewVBox(
+		widget.NewLabel("The directory's accounts are imported into the database and a unified\npool selecting them is created. The directory itself is left untouched."),
+		widget.NewLabel("Pool Name:"),
+		nameEntry,
+	)
+
+	dialog.ShowCustomConfirm("Convert Legacy Pool", "Convert", "Cancel", content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		poolDef, err := poolManager.ConvertLegacyFilePool(accountsPath, config, nameEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to convert legacy pool: %w", err), t.controller.window)
+			return
+		}
+
+		poolSelect.Options = append(poolSelect.Options, poolDef.Name)
+		poolSelect.SetSelected(poolDef.Name)
+
+		t.controller.logTab.AddLog(LogLevelInfo, 0, fmt.Sprintf("Created pool '%s': %s", poolDef.Name, poolDef.Config.Description))
+		dialog.ShowInformation("Legacy Pool Converted",
+			fmt.Sprintf("Created pool '%s'.\n\n%s", poolDef.Name, poolDef.Config.Description),
+			t.controller.window)
+	}, t.controller.window)
+}
+
 func (t *ManagerGroupsTab) parseSortMethod(str string) accountpool.SortMethod {
 	switch {
 	case strings.Contains(str, "oldest"):
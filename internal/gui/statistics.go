@@ -0,0 +1,212 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"jordanella.com/pocket-tcg-go/internal/database"
+	"jordanella.com/pocket-tcg-go/internal/gui/components"
+)
+
+// Time range options for the statistics tab
+var statisticsRanges = []struct {
+	name     string
+	duration time.Duration // 0 = all time
+}{
+	{"Last 24 hours", 24 * time.Hour},
+	{"Last 7 days", 7 * 24 * time.Hour},
+	{"Last 30 days", 30 * 24 * time.Hour},
+	{"All time", 0},
+}
+
+// StatisticsTab displays aggregate pack, card, error and routine statistics
+type StatisticsTab struct {
+	controller *Controller
+	db         *database.DB
+
+	// Filters
+	rangeSelect   *widget.Select
+	filterAccount *widget.Entry
+
+	// Content containers
+	contentArea *fyne.Container
+}
+
+// NewStatisticsTab creates a new statistics tab
+func NewStatisticsTab(ctrl *Controller, db *database.DB) *StatisticsTab {
+	return &StatisticsTab{
+		controller: ctrl,
+		db:         db,
+	}
+}
+
+// Build constructs the UI
+func (t *StatisticsTab) Build() fyne.CanvasObject {
+	// Header
+	header := widget.NewLabelWithStyle("Database - Statistics", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+
+	// Filters
+	rangeNames := make([]string, len(statisticsRanges))
+	for i, r := range statisticsRanges {
+		rangeNames[i] = r.name
+	}
+	t.rangeSelect = widget.NewSelect(rangeNames, func(string) {
+		t.refresh()
+	})
+
+	t.filterAccount = widget.NewEntry()
+	t.filterAccount.SetPlaceHolder("Account ID (all)")
+	t.filterAccount.OnSubmitted = func(string) {
+		t.refresh()
+	}
+
+	// Refresh button
+	refreshBtn := widget.NewButton("Refresh", func() {
+		t.refresh()
+	})
+
+	// Toolbar
+	toolbar := container.NewHBox(
+		widget.NewLabel("Range:"),
+		t.rangeSelect,
+		widget.NewLabel("Account ID:"),
+		t.filterAccount,
+		refreshBtn,
+	)
+
+	// Content area
+	t.contentArea = container.NewVBox()
+	t.rangeSelect.SetSelected(statisticsRanges[1].name) // Triggers the first refresh
+
+	return container.NewBorder(
+		container.NewVBox(header, toolbar),
+		nil,
+		nil,
+		nil,
+		container.NewVScroll(t.contentArea),
+	)
+}
+
+// refresh reloads the statistics
+func (t *StatisticsTab) refresh() {
+	// Don't refresh if content area not initialized yet
+	if t.contentArea == nil {
+		return
+	}
+
+	if t.db == nil {
+		t.showContent(widget.NewLabel("Database not initialized"))
+		return
+	}
+
+	accountID, err := t.selectedAccount()
+	if err != nil {
+		dialog.ShowError(err, t.controller.window)
+		return
+	}
+
+	start, end := t.selectedRange()
+	stats, err := t.db.GetDashboardStats(accountID, start, end)
+	if err != nil {
+		if t.controller.window != nil {
+			dialog.ShowError(fmt.Errorf("failed to load statistics: %w", err), t.controller.window)
+		}
+		return
+	}
+
+	t.showContent(
+		t.buildSummary(stats),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Packs Opened per Day", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		components.NewLineChart(dailyPoints(stats.PacksPerDay), fyne.NewSize(500, 150)),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Cards Pulled by Rarity", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		components.NewBarChart(sortedPoints(stats.CardsByRarity, false)),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Errors by Type", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		components.NewBarChart(sortedPoints(stats.ErrorsByType, true)),
+	)
+}
+
+// showContent replaces the content area
+func (t *StatisticsTab) showContent(objects ...fyne.CanvasObject) {
+	t.contentArea.Objects = objects
+	t.contentArea.Refresh()
+}
+
+// buildSummary creates the totals row
+func (t *StatisticsTab) buildSummary(stats *database.DashboardStats) fyne.CanvasObject {
+	avgDuration := "N/A"
+	if stats.RoutineRuns > 0 {
+		avgDuration = stats.AvgRoutineDuration.Round(time.Second).String()
+	}
+
+	return container.NewGridWithColumns(4,
+		statisticsCard("Packs Opened", fmt.Sprintf("%d", stats.TotalPacks)),
+		statisticsCard("God Packs", fmt.Sprintf("%d (%.2f%%)", stats.GodPacks, stats.GodPackRate)),
+		statisticsCard("Routine Runs", fmt.Sprintf("%d", stats.RoutineRuns)),
+		statisticsCard("Avg Routine Duration", avgDuration),
+	)
+}
+
+// statisticsCard creates a titled value
+func statisticsCard(title, value string) fyne.CanvasObject {
+	return widget.NewCard(title, "", widget.NewLabelWithStyle(value, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+}
+
+// selectedAccount parses the account filter (nil = all accounts)
+func (t *StatisticsTab) selectedAccount() (*int, error) {
+	text := strings.TrimSpace(t.filterAccount.Text)
+	if text == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.Atoi(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID '%s'", text)
+	}
+	return &id, nil
+}
+
+// selectedRange returns the start and end of the selected time range
+func (t *StatisticsTab) selectedRange() (time.Time, time.Time) {
+	end := time.Now()
+	for _, r := range statisticsRanges {
+		if r.name == t.rangeSelect.Selected && r.duration > 0 {
+			return end.Add(-r.duration), end
+		}
+	}
+	return time.Time{}, end
+}
+
+// dailyPoints converts daily counts to chart points
+func dailyPoints(days []database.DailyCount) []components.ChartPoint {
+	points := make([]components.ChartPoint, len(days))
+	for i, day := range days {
+		points[i] = components.ChartPoint{Label: day.Day, Value: float64(day.Count)}
+	}
+	return points
+}
+
+// sortedPoints converts counts to chart points, sorted by name or by count (largest first)
+func sortedPoints(counts map[string]int, byCount bool) []components.ChartPoint {
+	points := make([]components.ChartPoint, 0, len(counts))
+	for name, count := range counts {
+		points = append(points, components.ChartPoint{Label: name, Value: float64(count)})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		if byCount && points[i].Value != points[j].Value {
+			return points[i].Value > points[j].Value
+		}
+		return points[i].Label < points[j].Label
+	})
+	return points
+}
@@ -0,0 +1,145 @@
+package gui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"jordanella.com/pocket-tcg-go/internal/diagnostics"
+)
+
+// SystemCheckTab runs the pre-flight checks (ADB, database, emulators, templates, routines)
+// and lists their results with fix suggestions
+type SystemCheckTab struct {
+	controller *Controller
+
+	// Widgets
+	runButton    *widget.Button
+	summaryLabel *widget.Label
+	progressBar  *widget.ProgressBarInfinite
+	resultsBox   *fyne.Container
+}
+
+// NewSystemCheckTab creates a new system check tab
+func NewSystemCheckTab(ctrl *Controller) *SystemCheckTab {
+	return &SystemCheckTab{
+		controller: ctrl,
+	}
+}
+
+// Build constructs the system check UI
+func (s *SystemCheckTab) Build() fyne.CanvasObject {
+	header := widget.NewLabelWithStyle("System Check", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	description := widget.NewLabel("Checks that ADB, the database, emulator instances, templates and routines are ready before running bots.")
+	description.Wrapping = fyne.TextWrapWord
+
+	s.summaryLabel = widget.NewLabel("Not run yet")
+	s.progressBar = widget.NewProgressBarInfinite()
+	s.progressBar.Hide()
+	s.resultsBox = container.NewVBox()
+
+	s.runButton = widget.NewButton("Run Checks", func() {
+		s.runChecks()
+	})
+
+	return container.NewBorder(
+		container.NewVBox(
+			header,
+			description,
+			container.NewHBox(s.runButton, s.summaryLabel),
+			s.progressBar,
+			widget.NewSeparator(),
+		),
+		nil,
+		nil,
+		nil,
+		container.NewVScroll(s.resultsBox),
+	)
+}
+
+// runChecks runs the checks in the background and shows their results
+func (s *SystemCheckTab) runChecks() {
+	cfg := s.controller.GetConfig()
+	env := diagnostics.DefaultEnvironment(cfg.ADBPath, cfg.FolderPath)
+
+	s.runButton.Disable()
+	s.summaryLabel.SetText("Running checks...")
+	s.progressBar.Show()
+	s.progressBar.Start()
+
+	go func() {
+		results := diagnostics.RunAll(env)
+
+		fyne.Do(func() {
+			s.progressBar.Stop()
+			s.progressBar.Hide()
+			s.runButton.Enable()
+			s.showResults(results)
+		})
+
+		level := LogLevelInfo
+		if !diagnostics.AllPassed(results) {
+			level = LogLevelWarn
+		}
+		s.controller.GetEventBus().Publish(AddLog(level, 0, fmt.Sprintf("System check: %s", summarizeChecks(results))))
+	}()
+}
+
+// showResults replaces the result list (must run on the UI thread)
+func (s *SystemCheckTab) showResults(results []diagnostics.CheckResult) {
+	s.resultsBox.RemoveAll()
+	for _, result := range results {
+		s.resultsBox.Add(buildCheckRow(result))
+		s.resultsBox.Add(widget.NewSeparator())
+	}
+	s.resultsBox.Refresh()
+	s.summaryLabel.SetText(summarizeChecks(results))
+}
+
+// buildCheckRow shows one result: a colored status, the check's findings and how to fix them
+func buildCheckRow(result diagnostics.CheckResult) fyne.CanvasObject {
+	icon, colorName, statusText := theme.ConfirmIcon(), theme.ColorNameSuccess, "PASS"
+	switch result.Status {
+	case diagnostics.StatusWarn:
+		icon, colorName, statusText = theme.WarningIcon(), theme.ColorNameWarning, "WARN"
+	case diagnostics.StatusFail:
+		icon, colorName, statusText = theme.ErrorIcon(), theme.ColorNameError, "FAIL"
+	}
+
+	status := canvas.NewText(statusText, theme.Color(colorName))
+	status.TextStyle = fyne.TextStyle{Bold: true}
+
+	message := widget.NewLabel(result.Message)
+	message.Wrapping = fyne.TextWrapWord
+	details := container.NewVBox(
+		widget.NewLabelWithStyle(result.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		message,
+	)
+	if result.Fix != "" {
+		fix := widget.NewLabel("Fix: " + result.Fix)
+		fix.Wrapping = fyne.TextWrapWord
+		fix.TextStyle = fyne.TextStyle{Italic: true}
+		details.Add(fix)
+	}
+
+	return container.NewBorder(nil, nil, container.NewHBox(widget.NewIcon(icon), status), nil, details)
+}
+
+// summarizeChecks counts the results by status
+func summarizeChecks(results []diagnostics.CheckResult) string {
+	var passed, warned, failed int
+	for _, result := range results {
+		switch result.Status {
+		case diagnostics.StatusPass:
+			passed++
+		case diagnostics.StatusWarn:
+			warned++
+		default:
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d passed, %d warnings, %d failed", passed, warned, failed)
+}
@@ -3,6 +3,7 @@ package tabs
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -42,8 +43,12 @@ type AccountPoolsTabV2 struct {
 	poolNameLabel    *widget.Label
 	descEntry        *widget.Entry
 	sortMethodSelect *widget.Select
+	selectionSelect  *widget.Select
+	selectionWeight  *widget.Entry
 	retryFailedCheck *widget.Check
 	maxFailuresEntry *widget.Entry
+	maxPacksEntry    *widget.Entry
+	maxRiskEntry     *widget.Entry
 
 	// Details tab - read-only
 	totalAccountsValue *widget.Label
@@ -224,6 +229,31 @@ func (t *AccountPoolsTabV2) buildDetailsTab() fyne.CanvasObject {
 
 	sortRow := container.NewHBox(sortLabel, t.sortMethodSelect)
 
+	// Selection Strategy (which account is handed out next)
+	selectionLabel := components.BoldText("Selection:")
+	t.selectionSelect = widget.NewSelect([]string{
+		string(accountpool.SelectionSortBased),
+		string(accountpool.SelectionRoundRobin),
+		string(accountpool.SelectionLeastRecentlyUsed),
+		string(accountpool.SelectionRandom),
+		string(accountpool.SelectionWeightedRandom),
+	}, func(s string) {
+		// The weight only applies to weighted random selection
+		if s == string(accountpool.SelectionWeightedRandom) {
+			t.selectionWeight.Enable()
+		} else {
+			t.selectionWeight.Disable()
+		}
+		t.markDirty()
+	})
+
+	t.selectionWeight = widget.NewEntry()
+	t.selectionWeight.SetPlaceHolder(accountpool.DefaultSelectionWeight)
+	t.selectionWeight.OnChanged = func(string) { t.markDirty() }
+	t.selectionSelect.SetSelected(string(accountpool.SelectionSortBased))
+
+	selectionRow := container.NewHBox(selectionLabel, t.selectionSelect, components.BoldText("Weight:"), t.selectionWeight)
+
 	// Retry Failed
 	t.retryFailedCheck = widget.NewCheck("Retry Failed Accounts", func(bool) { t.markDirty() })
 
@@ -235,6 +265,22 @@ func (t *AccountPoolsTabV2) buildDetailsTab() fyne.CanvasObject {
 
 	maxFailuresRow := container.NewHBox(maxFailuresLabel, t.maxFailuresEntry)
 
+	// Max Packs Per Account (move on to the next account at the ceiling)
+	maxPacksLabel := components.BoldText("Max Packs Per Account:")
+	t.maxPacksEntry = widget.NewEntry()
+	t.maxPacksEntry.SetPlaceHolder("0 (no limit)")
+	t.maxPacksEntry.OnChanged = func(string) { t.markDirty() }
+
+	maxPacksRow := container.NewHBox(maxPacksLabel, t.maxPacksEntry)
+
+	// Max Risk Score (exclude accounts likely to get banned)
+	maxRiskLabel := components.BoldText("Max Risk Score:")
+	t.maxRiskEntry = widget.NewEntry()
+	t.maxRiskEntry.SetPlaceHolder("0 (disabled)")
+	t.maxRiskEntry.OnChanged = func(string) { t.markDirty() }
+
+	maxRiskRow := container.NewHBox(maxRiskLabel, t.maxRiskEntry)
+
 	// Actions
 	t.saveBtn = components.PrimaryButton("Save Changes", func() {
 		t.handleSave()
@@ -260,8 +306,11 @@ func (t *AccountPoolsTabV2) buildDetailsTab() fyne.CanvasObject {
 		accountsRow,
 		widget.NewSeparator(),
 		sortRow,
+		selectionRow,
 		t.retryFailedCheck,
 		maxFailuresRow,
+		maxPacksRow,
+		maxRiskRow,
 		widget.NewSeparator(),
 		actions,
 	)
@@ -331,7 +380,11 @@ func (t *AccountPoolsTabV2) buildQueriesTab() fyne.CanvasObject {
 			if id < len(t.queriesData) {
 				query := t.queriesData[id]
 				box := obj.(*fyne.Container)
-				box.Objects[0].(*widget.Label).SetText(query.Name)
+				name := query.Name
+				if query.IsAdvanced() {
+					name += " (SQL)"
+				}
+				box.Objects[0].(*widget.Label).SetText(name)
 				box.Objects[1].(*widget.Button).OnTapped = func() {
 					t.handleEditQuery(id)
 				}
@@ -649,8 +702,24 @@ func (t *AccountPoolsTabV2) loadPoolData(poolName string) {
 	// Update Details tab
 	t.descEntry.SetText(poolDef.Config.Description)
 	t.sortMethodSelect.SetSelected(poolDef.Config.Config.SortMethod)
+	if poolDef.Config.Config.SelectionStrategy == "" {
+		t.selectionSelect.SetSelected(string(accountpool.SelectionSortBased))
+	} else {
+		t.selectionSelect.SetSelected(poolDef.Config.Config.SelectionStrategy)
+	}
+	t.selectionWeight.SetText(poolDef.Config.Config.SelectionWeight)
 	t.retryFailedCheck.SetChecked(poolDef.Config.Config.RetryFailed)
 	t.maxFailuresEntry.SetText(fmt.Sprintf("%d", poolDef.Config.Config.MaxFailures))
+	if poolDef.Config.Config.MaxPacksPerAccount > 0 {
+		t.maxPacksEntry.SetText(fmt.Sprintf("%d", poolDef.Config.Config.MaxPacksPerAccount))
+	} else {
+		t.maxPacksEntry.SetText("")
+	}
+	if poolDef.Config.Config.MaxRiskScore > 0 {
+		t.maxRiskEntry.SetText(strconv.FormatFloat(poolDef.Config.Config.MaxRiskScore, 'f', -1, 64))
+	} else {
+		t.maxRiskEntry.SetText("")
+	}
 
 	// Update Queries tab
 	t.queriesDataMu.Lock()
@@ -691,11 +760,34 @@ func (t *AccountPoolsTabV2) handleSave() {
 	if maxFailures == 0 {
 		maxFailures = 3
 	}
+	maxPacks := 0
+	if text := strings.TrimSpace(t.maxPacksEntry.Text); text != "" {
+		var err error
+		if maxPacks, err = strconv.Atoi(text); err != nil || maxPacks < 0 {
+			dialog.ShowError(fmt.Errorf("max packs per account must be a non-negative number"), t.window)
+			return
+		}
+	}
+	maxRisk := 0.0
+	if text := strings.TrimSpace(t.maxRiskEntry.Text); text != "" {
+		var err error
+		if maxRisk, err = strconv.ParseFloat(text, 64); err != nil || maxRisk < 0 || maxRisk > 100 {
+			dialog.ShowError(fmt.Errorf("max risk score must be a number between 0 and 100"), t.window)
+			return
+		}
+	}
 
 	t.currentPool.Description = t.descEntry.Text
 	t.currentPool.Config.SortMethod = t.sortMethodSelect.Selected
+	t.currentPool.Config.SelectionStrategy = t.selectionSelect.Selected
+	if t.currentPool.Config.SelectionStrategy == string(accountpool.SelectionSortBased) {
+		t.currentPool.Config.SelectionStrategy = ""
+	}
+	t.currentPool.Config.SelectionWeight = strings.TrimSpace(t.selectionWeight.Text)
 	t.currentPool.Config.RetryFailed = t.retryFailedCheck.Checked
 	t.currentPool.Config.MaxFailures = maxFailures
+	t.currentPool.Config.MaxPacksPerAccount = maxPacks
+	t.currentPool.Config.MaxRiskScore = maxRisk
 
 	// Get queries, includes, excludes from UI
 	t.queriesDataMu.RLock()
@@ -1009,31 +1101,26 @@ func (t *AccountPoolsTabV2) loadExistingPools() {
 		return
 	}
 
-	poolNames := t.poolManager.ListPools()
-	for _, poolName := range poolNames {
-		t.addPoolCard(poolName)
+	// Best pools first, each with its health badge
+	for _, health := range t.poolManager.RankPools() {
+		t.addPoolCard(health)
 	}
 
 	t.updateStatusLabel()
 }
 
-func (t *AccountPoolsTabV2) addPoolCard(poolName string) {
+func (t *AccountPoolsTabV2) addPoolCard(health accountpool.PoolHealth) {
+	poolName := health.Name
 	poolDef, err := t.poolManager.GetPoolDefinition(poolName)
 	if err != nil {
 		fmt.Printf("Warning: Failed to get pool definition for '%s': %v\n", poolName, err)
 		return
 	}
 
-	testResult, err := t.poolManager.TestPool(poolName)
-	accountCount := 0
-	if err == nil && testResult != nil {
-		accountCount = testResult.AccountsFound
-	}
-
 	card := components.NewAccountPoolCard(
 		poolName,
 		"unified",
-		accountCount,
+		health.Total,
 		"recently",
 		poolDef.Config.Description,
 		components.AccountPoolCardCallbacks{
@@ -1043,6 +1130,8 @@ func (t *AccountPoolsTabV2) addPoolCard(poolName string) {
 		},
 	)
 
+	card.SetHealth(health.Grade(), health.Score)
+
 	t.poolCardsMu.Lock()
 	t.poolCards[poolName] = card
 	t.poolCardsMu.Unlock()
@@ -1087,45 +1176,126 @@ func (t *AccountPoolsTabV2) Stop() {
 
 // === QUERY BUILDER DIALOG ===
 
-// showQueryBuilder shows a visual query builder dialog
-func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySource, onSave func(accountpool.QuerySource)) {
-	// Column definitions with types
-	type columnDef struct {
-		name        string
-		comparators []string
+// queryPreviewSize is the number of sample accounts shown in the query builder preview
+const queryPreviewSize = 10
+
+// Comparator sets offered by the query builder for each kind of column
+var (
+	numericComparators   = []string{"=", "!=", ">", ">=", "<", "<="}
+	timestampComparators = []string{"=", "!=", ">", ">=", "<", "<=", "IS NULL", "IS NOT NULL"}
+	statusComparators    = []string{"IN", "NOT IN", "=", "!="}
+	booleanComparators   = []string{"= 1 (TRUE)", "= 0 (FALSE)"}
+)
+
+// queryBuilderColumn is a column the query builder can filter and sort on
+type queryBuilderColumn struct {
+	name        string
+	comparators []string
+	placeholder string
+}
+
+// queryBuilderColumns lists the filterable account columns
+var queryBuilderColumns = []queryBuilderColumn{
+	{"packs_opened", numericComparators, "0"},
+	{"shinedust", numericComparators, "0"},
+	{"hourglasses", numericComparators, "0"},
+	{"pokegold", numericComparators, "0"},
+	{"pack_points", numericComparators, "0"},
+	{"wonder_picks_done", numericComparators, "0"},
+	{"account_level", numericComparators, "0"},
+	{"failure_count", numericComparators, "0"},
+	{"pool_status", statusComparators, "available, failed"},
+	{"last_used_at", timestampComparators, "2006-01-02 15:04:05"},
+	{"completed_at", timestampComparators, "2006-01-02 15:04:05"},
+	{"created_at", timestampComparators, "2006-01-02 15:04:05"},
+	{"is_active", booleanComparators, ""},
+	{"is_banned", booleanComparators, ""},
+}
+
+// findQueryBuilderColumn returns the builder column definition for name (numeric if unknown)
+func findQueryBuilderColumn(name string) queryBuilderColumn {
+	for _, column := range queryBuilderColumns {
+		if column.name == name {
+			return column
+		}
+	}
+	return queryBuilderColumn{name, numericComparators, ""}
+}
+
+// isBooleanColumn returns true if the column is filtered with TRUE/FALSE choices
+func isBooleanColumn(name string) bool {
+	return name == "is_active" || name == "is_banned"
+}
+
+// filterComparatorOption returns the comparator dropdown option for a filter
+func filterComparatorOption(filter *accountpool.QueryFilter) string {
+	if isBooleanColumn(filter.Column) {
+		if filter.Value == "0" {
+			return booleanComparators[1]
+		}
+		return booleanComparators[0]
+	}
+	return filter.Comparator
+}
+
+// applyComparatorOption sets a filter's comparator (and value for TRUE/FALSE choices) from a dropdown option
+func applyComparatorOption(filter *accountpool.QueryFilter, option string) {
+	switch option {
+	case booleanComparators[0]:
+		filter.Comparator, filter.Value = "=", "1"
+	case booleanComparators[1]:
+		filter.Comparator, filter.Value = "=", "0"
+	default:
+		filter.Comparator = option
+		if filter.IsNullCheck() {
+			filter.Value = ""
+		}
 	}
+}
 
-	numericComparators := []string{"=", "!=", ">", ">=", "<", "<="}
-	booleanComparators := []string{"= 1 (TRUE)", "= 0 (FALSE)"}
-	timestampComparators := []string{"=", "!=", ">", ">=", "<", "<="}
-
-	columnDefs := []columnDef{
-		{"packs_opened", numericComparators},
-		{"shinedust", numericComparators},
-		{"hourglasses", numericComparators},
-		{"pokegold", numericComparators},
-		{"pack_points", numericComparators},
-		{"wonder_picks_done", numericComparators},
-		{"account_level", numericComparators},
-		{"last_used_at", timestampComparators},
-		{"is_active", booleanComparators},
-		{"is_banned", booleanComparators},
+// formatQueryParameters formats named parameters as sorted name=value lines
+func formatQueryParameters(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	// Extract column names for dropdown
-	columns := make([]string, len(columnDefs))
-	for i, def := range columnDefs {
-		columns[i] = def.name
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = name + "=" + params[name]
 	}
+	return strings.Join(lines, "\n")
+}
 
-	// Helper to get comparators for a column
-	getComparatorsForColumn := func(column string) []string {
-		for _, def := range columnDefs {
-			if def.name == column {
-				return def.comparators
-			}
+// parseQueryParameters parses name=value lines (blank lines are ignored)
+func parseQueryParameters(text string) (map[string]string, error) {
+	params := make(map[string]string)
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
 		}
-		return numericComparators // Default
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("parameter line %d must be name=value", i+1)
+		}
+		name = strings.TrimLeft(strings.TrimSpace(name), ":@$")
+		if name == "" {
+			return nil, fmt.Errorf("parameter line %d has no name", i+1)
+		}
+		params[name] = strings.TrimSpace(value)
+	}
+	return params, nil
+}
+
+// showQueryBuilder shows a visual query builder dialog.
+// The query is previewed live and only saved once it runs successfully.
+func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySource, onSave func(accountpool.QuerySource)) {
+	// Extract column names for dropdowns
+	columns := make([]string, len(queryBuilderColumns))
+	for i, column := range queryBuilderColumns {
+		columns[i] = column.name
 	}
 
 	sortDirections := []string{"asc", "desc"}
@@ -1135,6 +1305,9 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 	var filters []accountpool.QueryFilter
 	var sorts []accountpool.SortOrder
 	var limit int
+	var tags []string
+	var rawSQL string
+	var params map[string]string
 
 	// Pre-populate if editing
 	if existingQuery != nil {
@@ -1144,8 +1317,14 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 		sorts = make([]accountpool.SortOrder, len(existingQuery.Sort))
 		copy(sorts, existingQuery.Sort)
 		limit = existingQuery.Limit
+		tags = existingQuery.Tags
+		rawSQL = existingQuery.RawSQL
+		params = existingQuery.Parameters
 	}
 
+	// Re-runs the live preview (defined once all inputs exist)
+	var updatePreview func()
+
 	// === NAME ===
 	nameEntry := widget.NewEntry()
 	nameEntry.SetText(queryName)
@@ -1162,67 +1341,62 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 			idx := i // Capture for closure
 			filter := &filters[idx]
 
-			comparatorSelect := widget.NewSelect([]string{}, func(selected string) {
-				// Extract actual comparator from display string (e.g., "= 1 (TRUE)" -> "=")
-				parts := strings.Split(selected, " ")
-				if len(parts) > 0 {
-					filter.Comparator = parts[0]
-					// For boolean fields, set value from selection
-					if strings.Contains(selected, "TRUE") {
-						filter.Value = "1"
-					} else if strings.Contains(selected, "FALSE") {
-						filter.Value = "0"
-					}
-				} else {
-					filter.Comparator = selected
-				}
-			})
-
 			valueEntry := widget.NewEntry()
 			valueEntry.SetText(filter.Value)
 			valueEntry.OnChanged = func(value string) {
 				filter.Value = value
+				updatePreview()
 			}
 
-			columnSelect := widget.NewSelect(columns, func(selected string) {
-				filter.Column = selected
-				// Update comparators based on column type
-				comparators := getComparatorsForColumn(selected)
-				comparatorSelect.Options = comparators
-				if len(comparators) > 0 {
-					comparatorSelect.SetSelected(comparators[0])
-				}
-				comparatorSelect.Refresh()
-
-				// Hide value entry for boolean fields (value set by comparator)
-				if selected == "is_active" || selected == "is_banned" {
+			// Show the value entry only for comparators that take a value
+			updateValueEntry := func() {
+				column := findQueryBuilderColumn(filter.Column)
+				valueEntry.SetPlaceHolder(column.placeholder)
+				if isBooleanColumn(filter.Column) || filter.IsNullCheck() {
 					valueEntry.Hide()
 				} else {
 					valueEntry.Show()
 				}
+			}
+
+			comparatorSelect := widget.NewSelect(findQueryBuilderColumn(filter.Column).comparators, func(selected string) {
+				applyComparatorOption(filter, selected)
+				updateValueEntry()
+				updatePreview()
 			})
-			columnSelect.SetSelected(filter.Column)
+			comparatorSelect.SetSelected(filterComparatorOption(filter))
 
-			// Set initial comparators
-			comparators := getComparatorsForColumn(filter.Column)
-			comparatorSelect.Options = comparators
-			comparatorSelect.SetSelected(filter.Comparator)
+			columnSelect := widget.NewSelect(columns, func(selected string) {
+				if selected == filter.Column {
+					return
+				}
+				filter.Column = selected
 
-			// Hide value entry for boolean fields
-			if filter.Column == "is_active" || filter.Column == "is_banned" {
-				valueEntry.Hide()
-			}
+				// Reset the comparator for the new column type
+				comparators := findQueryBuilderColumn(selected).comparators
+				comparatorSelect.Options = comparators
+				comparatorSelect.SetSelected(comparators[0])
+				comparatorSelect.Refresh()
+				if !isBooleanColumn(selected) {
+					filter.Value = ""
+					valueEntry.SetText("")
+				}
+				updateValueEntry()
+				updatePreview()
+			})
+			columnSelect.SetSelected(filter.Column)
+			updateValueEntry()
 
 			removeBtn := widget.NewButton("Remove", func() {
 				filters = append(filters[:idx], filters[idx+1:]...)
 				updateFiltersUI()
+				updatePreview()
 			})
 
-			filterRow := container.NewHBox(
-				columnSelect,
-				comparatorSelect,
-				valueEntry,
+			filterRow := container.NewBorder(nil, nil,
+				container.NewHBox(columnSelect, comparatorSelect),
 				removeBtn,
+				valueEntry,
 			)
 			filtersContainer.Add(filterRow)
 		}
@@ -1236,6 +1410,7 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 			Value:      "0",
 		})
 		updateFiltersUI()
+		updatePreview()
 	})
 
 	updateFiltersUI()
@@ -1256,19 +1431,22 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 
 			columnSelect := widget.NewSelect(columns, func(selected string) {
 				sort.Column = selected
+				updatePreview()
 			})
 			columnSelect.SetSelected(sort.Column)
 
 			directionSelect := widget.NewSelect(sortDirections, func(selected string) {
 				sort.Direction = selected
+				updatePreview()
 			})
-			directionSelect.SetSelected(sort.Direction)
+			directionSelect.SetSelected(strings.ToLower(sort.Direction))
 
 			// Reorder buttons
 			moveUpBtn := widget.NewButton("↑", func() {
 				if idx > 0 {
 					sorts[idx], sorts[idx-1] = sorts[idx-1], sorts[idx]
 					updateSortsUI()
+					updatePreview()
 				}
 			})
 			moveUpBtn.Importance = widget.LowImportance
@@ -1280,6 +1458,7 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 				if idx < len(sorts)-1 {
 					sorts[idx], sorts[idx+1] = sorts[idx+1], sorts[idx]
 					updateSortsUI()
+					updatePreview()
 				}
 			})
 			moveDownBtn.Importance = widget.LowImportance
@@ -1290,6 +1469,7 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 			removeBtn := widget.NewButton("Remove", func() {
 				sorts = append(sorts[:idx], sorts[idx+1:]...)
 				updateSortsUI()
+				updatePreview()
 			})
 
 			sortRow := container.NewHBox(
@@ -1311,6 +1491,7 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 			Direction: "desc",
 		})
 		updateSortsUI()
+		updatePreview()
 	})
 
 	updateSortsUI()
@@ -1319,11 +1500,21 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 	limitEntry := widget.NewEntry()
 	limitEntry.SetText(fmt.Sprintf("%d", limit))
 	limitEntry.SetPlaceHolder("0 = no limit")
+	limitEntry.OnChanged = func(string) {
+		updatePreview()
+	}
 
-	// === DIALOG CONTENT ===
-	content := container.NewVBox(
-		components.Subheading("Query Name"),
-		nameEntry,
+	// === TAGS ===
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetText(strings.Join(tags, ", "))
+	tagsEntry.SetPlaceHolder("farm-a, farm-b (accounts with any of these tags)")
+	tagsEntry.OnChanged = func(string) {
+		updatePreview()
+	}
+
+	structuredContent := container.NewVBox(
+		components.Subheading("Tags"),
+		tagsEntry,
 		widget.NewSeparator(),
 		components.Subheading("Filters (AND combined)"),
 		filtersContainer,
@@ -1337,48 +1528,195 @@ func (t *AccountPoolsTabV2) showQueryBuilder(existingQuery *accountpool.QuerySou
 		limitEntry,
 	)
 
-	scroll := container.NewVScroll(content)
-	scroll.SetMinSize(fyne.NewSize(600, 400))
+	// === ADVANCED (RAW SQL) ===
+	sqlEntry := widget.NewMultiLineEntry()
+	sqlEntry.SetText(rawSQL)
+	sqlEntry.SetPlaceHolder(accountpool.QueryBaseSQL + "WHERE packs_opened >= :min_packs")
+	sqlEntry.SetMinRowsVisible(6)
+	sqlEntry.OnChanged = func(string) {
+		updatePreview()
+	}
 
-	// === DIALOG ===
-	dlg := dialog.NewCustomConfirm("Query Builder", "Save", "Cancel",
-		scroll,
-		func(save bool) {
-			if !save {
-				return
+	paramsEntry := widget.NewMultiLineEntry()
+	paramsEntry.SetText(formatQueryParameters(params))
+	paramsEntry.SetPlaceHolder("min_packs=5")
+	paramsEntry.SetMinRowsVisible(3)
+	paramsEntry.OnChanged = func(string) {
+		updatePreview()
+	}
+
+	advancedContent := container.NewVBox(
+		components.Subheading("SQL"),
+		widget.NewLabel("Must select device_account, device_password, shinedust, packs_opened, last_used_at"),
+		sqlEntry,
+		components.Subheading("Named Parameters (name=value per line)"),
+		paramsEntry,
+	)
+
+	advancedCheck := widget.NewCheck("Advanced mode (raw SQL)", func(advanced bool) {
+		if advanced {
+			// Start from the columns every query must return
+			if strings.TrimSpace(sqlEntry.Text) == "" {
+				sqlEntry.SetText(accountpool.QueryBaseSQL)
 			}
+			structuredContent.Hide()
+			advancedContent.Show()
+		} else {
+			advancedContent.Hide()
+			structuredContent.Show()
+		}
+		updatePreview()
+	})
 
-			// Validate
-			name := strings.TrimSpace(nameEntry.Text)
-			if name == "" {
-				dialog.ShowError(fmt.Errorf("query name cannot be empty"), t.window)
-				return
+	// buildQuery assembles the query from the current inputs, saving only the active mode's fields
+	buildQuery := func() (accountpool.QuerySource, error) {
+		query := accountpool.QuerySource{
+			Name: strings.TrimSpace(nameEntry.Text),
+		}
+
+		if advancedCheck.Checked {
+			parsedParams, err := parseQueryParameters(paramsEntry.Text)
+			if err != nil {
+				return query, err
+			}
+			query.RawSQL = strings.TrimSpace(sqlEntry.Text)
+			if len(parsedParams) > 0 {
+				query.Parameters = parsedParams
+			}
+			return query, nil
+		}
+
+		parsedLimit := 0
+		if text := strings.TrimSpace(limitEntry.Text); text != "" {
+			var err error
+			parsedLimit, err = strconv.Atoi(text)
+			if err != nil {
+				return query, fmt.Errorf("invalid limit value: %w", err)
 			}
+		}
+		query.Filters = append([]accountpool.QueryFilter(nil), filters...)
+		query.Sort = append([]accountpool.SortOrder(nil), sorts...)
+		query.Limit = parsedLimit
+		for _, tag := range strings.Split(tagsEntry.Text, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				query.Tags = append(query.Tags, tag)
+			}
+		}
+		return query, nil
+	}
+
+	// === PREVIEW ===
+	previewLabel := widget.NewLabel("")
+	previewLabel.Wrapping = fyne.TextWrapWord
+	previewAccounts := widget.NewLabel("")
+	previewAccounts.Wrapping = fyne.TextWrapWord
+
+	// Ignore callbacks fired while the inputs are being populated
+	previewReady := false
+	previewGeneration := 0
+	updatePreview = func() {
+		if !previewReady {
+			return
+		}
+
+		query, err := buildQuery()
+		if err != nil {
+			previewLabel.SetText(fmt.Sprintf("❌ %v", err))
+			previewAccounts.SetText("")
+			return
+		}
+
+		// Only the latest preview is shown if several are in flight
+		previewGeneration++
+		generation := previewGeneration
+		previewLabel.SetText("Running preview...")
 
-			// Parse limit
-			parsedLimit := 0
-			if limitEntry.Text != "" {
-				var err error
-				parsedLimit, err = strconv.Atoi(limitEntry.Text)
+		go func() {
+			sample, total, err := t.poolManager.PreviewQuery(query, queryPreviewSize)
+			fyne.Do(func() {
+				if generation != previewGeneration {
+					return
+				}
 				if err != nil {
-					dialog.ShowError(fmt.Errorf("invalid limit value: %w", err), t.window)
+					previewLabel.SetText(fmt.Sprintf("❌ %v", err))
+					previewAccounts.SetText("")
 					return
 				}
-			}
 
-			// Build query
-			query := accountpool.QuerySource{
-				Name:    name,
-				Filters: filters,
-				Sort:    sorts,
-				Limit:   parsedLimit,
-			}
+				previewLabel.SetText(fmt.Sprintf("✓ %d accounts match", total))
+				lines := make([]string, len(sample))
+				for i, account := range sample {
+					lines[i] = fmt.Sprintf("%s (%d packs)", account.DeviceAccount, account.PackCount)
+				}
+				if total > len(sample) {
+					lines = append(lines, fmt.Sprintf("... and %d more", total-len(sample)))
+				}
+				previewAccounts.SetText(strings.Join(lines, "\n"))
+			})
+		}()
+	}
 
-			onSave(query)
-		},
-		t.window,
+	// === DIALOG CONTENT ===
+	content := container.NewVBox(
+		components.Subheading("Query Name"),
+		nameEntry,
+		advancedCheck,
+		widget.NewSeparator(),
+		structuredContent,
+		advancedContent,
+		widget.NewSeparator(),
+		components.Subheading("Preview"),
+		previewLabel,
+		previewAccounts,
 	)
 
+	if existingQuery != nil && existingQuery.IsAdvanced() {
+		advancedCheck.SetChecked(true)
+	} else {
+		advancedContent.Hide()
+	}
+
+	previewReady = true
+	updatePreview()
+
+	scroll := container.NewVScroll(content)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+
+	// === DIALOG ===
+	dlg := dialog.NewCustomWithoutButtons("Query Builder", scroll, t.window)
+
+	var saveBtn *widget.Button
+	saveBtn = components.PrimaryButton("Save", func() {
+		query, err := buildQuery()
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+		if query.Name == "" {
+			dialog.ShowError(fmt.Errorf("query name cannot be empty"), t.window)
+			return
+		}
+
+		// Only accept queries that run; the builder stays open on error
+		saveBtn.Disable()
+		go func() {
+			_, _, err := t.poolManager.PreviewQuery(query, 0)
+			fyne.Do(func() {
+				saveBtn.Enable()
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("query is not valid: %w", err), t.window)
+					return
+				}
+				dlg.Hide()
+				onSave(query)
+			})
+		}()
+	})
+	cancelBtn := widget.NewButton("Cancel", func() {
+		dlg.Hide()
+	})
+	dlg.SetButtons([]fyne.CanvasObject{cancelBtn, saveBtn})
+
 	dlg.Resize(fyne.NewSize(700, 500))
 	dlg.Show()
 }
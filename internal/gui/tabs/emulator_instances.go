@@ -202,7 +202,9 @@ func (t *EmulatorInstancesTab) loadExistingData() {
 
 		// Not detected = not running
 		name := fmt.Sprintf("Instance %d", instanceID)
-		if playerName != "" {
+		if alias := emulator.DefaultAliasStore().Get(instanceID); alias != "" {
+			name = alias
+		} else if playerName != "" {
 			name = playerName
 		}
 
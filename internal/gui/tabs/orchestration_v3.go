@@ -1,6 +1,7 @@
 package tabs
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -13,8 +14,10 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"jordanella.com/pocket-tcg-go/internal/actions"
 	"jordanella.com/pocket-tcg-go/internal/bot"
 	"jordanella.com/pocket-tcg-go/internal/emulator"
+	"jordanella.com/pocket-tcg-go/internal/events"
 	"jordanella.com/pocket-tcg-go/internal/gui/components"
 )
 
@@ -42,6 +45,9 @@ type OrchestrationTabV3 struct {
 	refreshBtn    *widget.Button
 	statusLabel   *widget.Label
 
+	// Maintenance banner (hidden unless bots are paused for maintenance)
+	maintenanceLabel *widget.Label
+
 	// Right panel: Tabs
 	tabs *container.AppTabs
 
@@ -55,6 +61,7 @@ type OrchestrationTabV3 struct {
 	// Instances tab widgets
 	instancesList       *widget.List
 	instancesData       []int
+	instanceRoutines    map[int]string // Per-instance routine overrides (guarded by instancesDataMu)
 	instancesDataMu     sync.RWMutex
 	addInstanceDropdown *widget.Select
 	addInstanceBtn      *widget.Button
@@ -65,6 +72,7 @@ type OrchestrationTabV3 struct {
 	poolsData       []string
 	poolsDataMu     sync.RWMutex
 	addPoolDropdown *widget.Select
+	defaultPoolSelect *widget.Select
 	addPoolBtn      *widget.Button
 	refreshPoolsBtn *widget.Button
 
@@ -72,27 +80,51 @@ type OrchestrationTabV3 struct {
 	validateRoutineCheck     *widget.Check
 	validateTemplatesCheck   *widget.Check
 	validateEmulatorsCheck   *widget.Check
+	validateProxiesCheck     *widget.Check
 	staggerDelayEntry        *widget.Entry
 	emulatorTimeoutEntry     *widget.Entry
 	conflictResolutionSelect *widget.Select
+	launchGameCheck          *widget.Check
+	startupRoutineEntry      *widget.Entry
+	idleTimeoutEntry         *widget.Entry
+	maxRunDurationEntry      *widget.Entry
+	idleActionSelect         *widget.Select
+	pauseOnErrorCheck        *widget.Check
+	logToFilesCheck          *widget.Check
 
 	// Restart Policy widgets
-	restartEnabledCheck *widget.Check
-	maxRetriesEntry     *widget.Entry
-	initialDelayEntry   *widget.Entry
-	maxDelayEntry       *widget.Entry
-	backoffFactorEntry  *widget.Entry
-	resetOnSuccessCheck *widget.Check
+	restartEnabledCheck   *widget.Check
+	maxRetriesEntry       *widget.Entry
+	maxIterationsEntry    *widget.Entry
+	initialDelayEntry     *widget.Entry
+	maxDelayEntry         *widget.Entry
+	backoffStrategySelect *widget.Select
+	backoffFactorEntry    *widget.Entry
+	resetOnSuccessCheck   *widget.Check
+	resumeCheckpointCheck *widget.Check
 
 	// Status tab widgets
 	statusList   *widget.List
 	statusData   [][]string
 	statusDataMu sync.RWMutex
+	statusInstances []int // Instance ID of each status row
+	selectedStatusInstance int // Instance of the selected status row (-1 = none)
+	stepErrorLabel *widget.Label
+	stepErrorBtns []*widget.Button // Resume, skip and abort for the selected bot's step error
+	budgetLabel   *widget.Label
+	progressLabel *widget.Label
+	maxBotsEntry  *widget.Entry
+	minFreeMemEntry *widget.Entry
+	botCountLabel *widget.Label
+	scaleDownBtn  *widget.Button
+	scaleUpBtn    *widget.Button
+	runStaggerEntry *widget.Entry
 
 	// Action buttons
 	saveBtn    *widget.Button
 	discardBtn *widget.Button
 	deleteBtn  *widget.Button
+	previewBtn *widget.Button
 	startBtn   *widget.Button
 	stopBtn    *widget.Button
 
@@ -111,6 +143,7 @@ func NewOrchestrationTabV3(orchestrator *bot.Orchestrator, emulatorMgr *emulator
 		instancesData: make([]int, 0),
 		poolsData:     make([]string, 0),
 		statusData:    make([][]string, 0),
+		selectedStatusInstance: -1,
 		stopRefresh:   make(chan bool),
 	}
 }
@@ -133,6 +166,9 @@ func (t *OrchestrationTabV3) Build() fyne.CanvasObject {
 	// Start periodic refresh
 	go t.startPeriodicRefresh()
 
+	// Show maintenance pauses as they happen
+	t.subscribeMaintenanceEvents()
+
 	return split
 }
 
@@ -148,11 +184,27 @@ func (t *OrchestrationTabV3) buildLeftPanel() fyne.CanvasObject {
 		t.loadGroupDefinitions()
 	})
 
+	summaryBtn := components.SecondaryButton("Session Summary", func() {
+		t.showSessionSummary()
+	})
+
+	emergencyBtn := components.DangerButton("Emergency Stop All", func() {
+		t.handleEmergencyStop()
+	})
+	emergencyBtn.SetIcon(theme.WarningIcon())
+
 	t.statusLabel = widget.NewLabel("No groups")
 
+	t.maintenanceLabel = widget.NewLabel("")
+	t.maintenanceLabel.Importance = widget.WarningImportance
+	t.maintenanceLabel.Wrapping = fyne.TextWrapWord
+	t.maintenanceLabel.Hide()
+
 	controls := container.NewVBox(
-		container.NewHBox(t.newGroupBtn, t.refreshBtn),
+		container.NewHBox(t.newGroupBtn, t.refreshBtn, summaryBtn),
+		emergencyBtn,
 		t.statusLabel,
+		t.maintenanceLabel,
 		widget.NewSeparator(),
 	)
 
@@ -246,6 +298,10 @@ func (t *OrchestrationTabV3) buildRightPanel() fyne.CanvasObject {
 		t.handleDeleteGroup()
 	})
 
+	t.previewBtn = components.SecondaryButton("Preview Launch", func() {
+		t.handlePreviewLaunch()
+	})
+
 	t.startBtn = components.PrimaryButton("Start Group", func() {
 		t.handleStartGroup()
 	})
@@ -259,6 +315,7 @@ func (t *OrchestrationTabV3) buildRightPanel() fyne.CanvasObject {
 		t.discardBtn,
 		layout.NewSpacer(),
 		t.deleteBtn,
+		t.previewBtn,
 		t.startBtn,
 		t.stopBtn,
 	)
@@ -323,23 +380,38 @@ func (t *OrchestrationTabV3) buildInstancesTab() fyne.CanvasObject {
 			return container.NewHBox(
 				widget.NewLabel(""),
 				layout.NewSpacer(),
+				widget.NewSelect([]string{}, nil),
 				widget.NewButtonWithIcon("", theme.DeleteIcon(), nil),
 			)
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			t.instancesDataMu.RLock()
-			defer t.instancesDataMu.RUnlock()
-
 			if id >= len(t.instancesData) {
+				t.instancesDataMu.RUnlock()
 				return
 			}
-
 			instance := t.instancesData[id]
+			override := t.instanceRoutines[instance]
+			t.instancesDataMu.RUnlock()
+
 			hbox := obj.(*fyne.Container)
 			label := hbox.Objects[0].(*widget.Label)
-			btn := hbox.Objects[2].(*widget.Button)
+			routineSelect := hbox.Objects[2].(*widget.Select)
+			btn := hbox.Objects[3].(*widget.Button)
 
 			label.SetText(fmt.Sprintf("Instance %d", instance))
+
+			// Rows are reused: detach the handler while showing this instance's routine
+			routineSelect.OnChanged = nil
+			routineSelect.Options = append([]string{groupRoutineOption}, t.routineSelect.Options...)
+			if override == "" {
+				override = groupRoutineOption
+			}
+			routineSelect.SetSelected(override)
+			routineSelect.OnChanged = func(selected string) {
+				t.handleInstanceRoutineChanged(instance, selected)
+			}
+
 			btn.OnTapped = func() {
 				t.handleRemoveInstance(id)
 			}
@@ -370,6 +442,7 @@ func (t *OrchestrationTabV3) buildInstancesTab() fyne.CanvasObject {
 	content := container.NewBorder(
 		container.NewVBox(
 			widget.NewLabel("Configured Instances:"),
+			widget.NewLabel("Each instance runs the group routine unless another routine is picked for it."),
 			widget.NewSeparator(),
 		),
 		container.NewVBox(
@@ -432,13 +505,20 @@ func (t *OrchestrationTabV3) buildAccountPoolsTab() fyne.CanvasObject {
 		t.updatePoolDropdownList()
 	})
 
+	// Default pool (shared by all groups that don't configure a pool)
+	t.defaultPoolSelect = widget.NewSelect([]string{}, nil)
+	t.defaultPoolSelect.PlaceHolder = noDefaultPool
+
 	// Update dropdown
 	t.updatePoolDropdownList()
+	t.defaultPoolSelect.OnChanged = t.handleDefaultPoolChanged
 
 	addSection := container.NewVBox(
 		widget.NewLabel("Add Account Pool:"),
 		t.addPoolDropdown,
 		container.NewHBox(t.addPoolBtn, t.refreshPoolsBtn),
+		widget.NewSeparator(),
+		components.FieldRow("Default Pool (all groups)", t.defaultPoolSelect),
 	)
 
 	content := container.NewBorder(
@@ -464,6 +544,7 @@ func (t *OrchestrationTabV3) buildLaunchOptionsTab() fyne.CanvasObject {
 	t.validateRoutineCheck = widget.NewCheck("Validate Routine", func(b bool) { t.markDirty() })
 	t.validateTemplatesCheck = widget.NewCheck("Validate Templates", func(b bool) { t.markDirty() })
 	t.validateEmulatorsCheck = widget.NewCheck("Validate Emulators", func(b bool) { t.markDirty() })
+	t.validateProxiesCheck = widget.NewCheck("Validate Proxies", func(b bool) { t.markDirty() })
 
 	// Timing options
 	t.staggerDelayEntry = widget.NewEntry()
@@ -481,6 +562,34 @@ func (t *OrchestrationTabV3) buildLaunchOptionsTab() fyne.CanvasObject {
 	)
 	t.conflictResolutionSelect.PlaceHolder = "Select conflict resolution strategy"
 
+	// Game
+	t.launchGameCheck = widget.NewCheck("Launch Game Before Routine", func(b bool) { t.markDirty() })
+
+	t.startupRoutineEntry = widget.NewEntry()
+	t.startupRoutineEntry.SetPlaceHolder("e.g., startup (blank = none)")
+	t.startupRoutineEntry.OnChanged = func(s string) { t.markDirty() }
+
+	// Time limit
+	t.maxRunDurationEntry = widget.NewEntry()
+	t.maxRunDurationEntry.SetPlaceHolder("e.g., 2h (blank = no limit)")
+	t.maxRunDurationEntry.OnChanged = func(s string) { t.markDirty() }
+
+	// Idle watchdog
+	t.idleTimeoutEntry = widget.NewEntry()
+	t.idleTimeoutEntry.SetPlaceHolder("e.g., 30m (blank = disabled)")
+	t.idleTimeoutEntry.OnChanged = func(s string) { t.markDirty() }
+
+	t.idleActionSelect = widget.NewSelect(
+		[]string{string(bot.IdleActionRestart), string(bot.IdleActionStop)},
+		func(s string) { t.markDirty() },
+	)
+
+	// Debugging
+	t.pauseOnErrorCheck = widget.NewCheck("Pause on Step Error (resume, skip or abort from the Status tab)", func(b bool) { t.markDirty() })
+
+	// Logging
+	t.logToFilesCheck = widget.NewCheck("Log Each Bot to logs/<group>/instance-<n>.log", func(b bool) { t.markDirty() })
+
 	// Restart policy
 	t.restartEnabledCheck = widget.NewCheck("Enable Auto-Restart", func(b bool) { t.markDirty() })
 
@@ -488,6 +597,10 @@ func (t *OrchestrationTabV3) buildLaunchOptionsTab() fyne.CanvasObject {
 	t.maxRetriesEntry.SetPlaceHolder("e.g., 5")
 	t.maxRetriesEntry.OnChanged = func(s string) { t.markDirty() }
 
+	t.maxIterationsEntry = widget.NewEntry()
+	t.maxIterationsEntry.SetPlaceHolder("0 = unlimited")
+	t.maxIterationsEntry.OnChanged = func(s string) { t.markDirty() }
+
 	t.initialDelayEntry = widget.NewEntry()
 	t.initialDelayEntry.SetPlaceHolder("e.g., 10s")
 	t.initialDelayEntry.OnChanged = func(s string) { t.markDirty() }
@@ -500,17 +613,42 @@ func (t *OrchestrationTabV3) buildLaunchOptionsTab() fyne.CanvasObject {
 	t.backoffFactorEntry.SetPlaceHolder("e.g., 2.0")
 	t.backoffFactorEntry.OnChanged = func(s string) { t.markDirty() }
 
+	strategies := make([]string, len(bot.BackoffStrategies))
+	for i, strategy := range bot.BackoffStrategies {
+		strategies[i] = string(strategy)
+	}
+	t.backoffStrategySelect = widget.NewSelect(strategies, func(s string) {
+		// The backoff factor only applies to exponential backoff
+		if s == string(bot.BackoffExponential) {
+			t.backoffFactorEntry.Enable()
+		} else {
+			t.backoffFactorEntry.Disable()
+		}
+		t.markDirty()
+	})
+
 	t.resetOnSuccessCheck = widget.NewCheck("Reset on Success", func(b bool) { t.markDirty() })
+	t.resumeCheckpointCheck = widget.NewCheck("Resume Failed Runs from Last Checkpoint", func(b bool) { t.markDirty() })
 
 	form := container.NewVBox(
 		widget.NewLabelWithStyle("Validation", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		t.validateRoutineCheck,
 		t.validateTemplatesCheck,
 		t.validateEmulatorsCheck,
+		t.validateProxiesCheck,
 		widget.NewSeparator(),
 		widget.NewLabelWithStyle("Timing", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		components.FieldRow("Stagger Delay", t.staggerDelayEntry),
 		components.FieldRow("Emulator Timeout", t.emulatorTimeoutEntry),
+		t.launchGameCheck,
+		components.FieldRow("Startup Routine", t.startupRoutineEntry),
+		components.FieldRow("Max Run Duration", t.maxRunDurationEntry),
+		components.FieldRow("Idle Timeout", t.idleTimeoutEntry),
+		components.FieldRow("When Idle", t.idleActionSelect),
+		t.pauseOnErrorCheck,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Logging", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		t.logToFilesCheck,
 		widget.NewSeparator(),
 		widget.NewLabelWithStyle("Conflict Resolution", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		t.conflictResolutionSelect,
@@ -518,15 +656,21 @@ func (t *OrchestrationTabV3) buildLaunchOptionsTab() fyne.CanvasObject {
 		widget.NewLabelWithStyle("Restart Policy", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		t.restartEnabledCheck,
 		components.FieldRow("Max Retries", t.maxRetriesEntry),
+		components.FieldRow("Max Iterations", t.maxIterationsEntry),
 		components.FieldRow("Initial Delay", t.initialDelayEntry),
 		components.FieldRow("Max Delay", t.maxDelayEntry),
+		components.FieldRow("Backoff Strategy", t.backoffStrategySelect),
 		components.FieldRow("Backoff Factor", t.backoffFactorEntry),
 		t.resetOnSuccessCheck,
+		t.resumeCheckpointCheck,
 	)
 
 	return container.NewVScroll(form)
 }
 
+// waitingForAccountsText is shown for bots idling because their pool ran out of accounts
+const waitingForAccountsText = "idle - waiting for accounts"
+
 // buildStatusTab creates the Status tab showing running bots
 func (t *OrchestrationTabV3) buildStatusTab() fyne.CanvasObject {
 	t.statusList = widget.NewList(
@@ -540,6 +684,9 @@ func (t *OrchestrationTabV3) buildStatusTab() fyne.CanvasObject {
 				widget.NewLabel(""),
 				widget.NewLabel(""),
 				widget.NewLabel(""),
+				widget.NewLabel(""),
+				widget.NewLabel(""),
+				widget.NewLabel(""),
 			)
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
@@ -554,18 +701,83 @@ func (t *OrchestrationTabV3) buildStatusTab() fyne.CanvasObject {
 			hbox := obj.(*fyne.Container)
 			hbox.Objects[0].(*widget.Label).SetText(row[0]) // Bot ID
 			hbox.Objects[1].(*widget.Label).SetText(row[1]) // Instance
-			hbox.Objects[2].(*widget.Label).SetText(row[2]) // Status
+			statusLabel := hbox.Objects[2].(*widget.Label) // Status
+			statusLabel.Importance = widget.MediumImportance
+			if row[2] == waitingForAccountsText {
+				statusLabel.Importance = widget.WarningImportance
+			}
+			statusLabel.SetText(row[2])
+			hbox.Objects[3].(*widget.Label).SetText(row[3]) // CPU / RAM
+			hbox.Objects[4].(*widget.Label).SetText(row[4]) // Last Screen
+			hbox.Objects[5].(*widget.Label).SetText(row[5]) // Last Run
 		},
 	)
 
+	t.statusList.OnSelected = func(id widget.ListItemID) {
+		t.statusDataMu.Lock()
+		if id < len(t.statusInstances) {
+			t.selectedStatusInstance = t.statusInstances[id]
+		}
+		t.statusDataMu.Unlock()
+		t.updateStatusData()
+	}
+
+	// Step error controls for a bot paused by pause-on-error
+	t.stepErrorLabel = widget.NewLabel("")
+	t.stepErrorLabel.Wrapping = fyne.TextWrapWord
+	t.stepErrorBtns = []*widget.Button{
+		widget.NewButton("Resume (Retry Step)", func() { t.handleResolveStepError(actions.StepErrorResume) }),
+		widget.NewButton("Skip Step", func() { t.handleResolveStepError(actions.StepErrorSkip) }),
+		widget.NewButton("Abort", func() { t.handleResolveStepError(actions.StepErrorAbort) }),
+	}
+	stepErrorRow := container.NewBorder(nil, nil, nil,
+		container.NewHBox(t.stepErrorBtns[0], t.stepErrorBtns[1], t.stepErrorBtns[2]), t.stepErrorLabel)
+
 	header := container.NewHBox(
 		widget.NewLabelWithStyle("Bot ID", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		widget.NewLabelWithStyle("Instance", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("CPU / RAM", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Last Screen", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Last Run", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 	)
 
+	// Global bot budget (shared by all groups)
+	t.budgetLabel = widget.NewLabel("")
+	t.progressLabel = widget.NewLabel("")
+	t.maxBotsEntry = widget.NewEntry()
+	t.maxBotsEntry.SetPlaceHolder("0 = unlimited")
+	if t.orchestrator != nil {
+		t.maxBotsEntry.SetText(fmt.Sprintf("%d", t.orchestrator.GetMaxConcurrentBots()))
+	}
+	t.minFreeMemEntry = widget.NewEntry()
+	t.minFreeMemEntry.SetPlaceHolder("0 = no limit")
+	if t.orchestrator != nil {
+		t.minFreeMemEntry.SetText(fmt.Sprintf("%d", t.orchestrator.GetMinFreeMemoryMB()))
+	}
+	applyBudgetBtn := widget.NewButton("Apply", t.handleApplyBotBudget)
+
+	budgetRow := container.NewBorder(nil, nil, nil, applyBudgetBtn, container.NewVBox(
+		components.FieldRow("Max Concurrent Bots (all groups)", t.maxBotsEntry),
+		components.FieldRow("Min Free RAM (MB) to launch", t.minFreeMemEntry),
+	))
+
+	// Runtime scaling of the running group
+	t.botCountLabel = widget.NewLabel("")
+	t.scaleDownBtn = widget.NewButton("-", func() { t.handleScaleGroup(-1) })
+	t.scaleUpBtn = widget.NewButton("+", func() { t.handleScaleGroup(1) })
+	scaleRow := container.NewHBox(
+		widget.NewLabel("Bots"), t.scaleDownBtn, t.botCountLabel, t.scaleUpBtn,
+	)
+
+	t.runStaggerEntry = widget.NewEntry()
+	t.runStaggerEntry.SetPlaceHolder("e.g., 5s")
+	applyStaggerBtn := widget.NewButton("Apply", t.handleApplyRunStagger)
+	staggerRow := container.NewBorder(nil, nil, nil, applyStaggerBtn,
+		components.FieldRow("Stagger Delay (running group)", t.runStaggerEntry))
+
 	content := container.NewBorder(
-		header,
+		container.NewVBox(budgetRow, scaleRow, staggerRow, t.budgetLabel, t.progressLabel, stepErrorRow, widget.NewSeparator(), header),
 		nil,
 		nil,
 		nil,
@@ -653,6 +865,7 @@ func (t *OrchestrationTabV3) selectGroup(id widget.ListItemID) {
 
 	// Populate fields
 	t.populateFields()
+	t.showRunStagger()
 
 	// Update button states
 	t.updateButtonStates()
@@ -680,6 +893,10 @@ func (t *OrchestrationTabV3) populateFields() {
 	t.instancesDataMu.Lock()
 	t.instancesData = make([]int, len(t.currentGroup.AvailableInstances))
 	copy(t.instancesData, t.currentGroup.AvailableInstances)
+	t.instanceRoutines = make(map[int]string, len(t.currentGroup.InstanceRoutines))
+	for instanceID, routineName := range t.currentGroup.InstanceRoutines {
+		t.instanceRoutines[instanceID] = routineName
+	}
 	t.instancesDataMu.Unlock()
 	fyne.Do(func() { t.instancesList.Refresh() })
 
@@ -702,8 +919,26 @@ func (t *OrchestrationTabV3) populateFields() {
 	t.validateRoutineCheck.SetChecked(t.currentGroup.LaunchOptions.ValidateRoutine)
 	t.validateTemplatesCheck.SetChecked(t.currentGroup.LaunchOptions.ValidateTemplates)
 	t.validateEmulatorsCheck.SetChecked(t.currentGroup.LaunchOptions.ValidateEmulators)
+	t.validateProxiesCheck.SetChecked(t.currentGroup.LaunchOptions.ValidateProxies)
 	t.staggerDelayEntry.SetText(t.currentGroup.LaunchOptions.StaggerDelay.String())
 	t.emulatorTimeoutEntry.SetText(t.currentGroup.LaunchOptions.EmulatorTimeout.String())
+	t.launchGameCheck.SetChecked(t.currentGroup.LaunchOptions.LaunchGame)
+	t.startupRoutineEntry.SetText(t.currentGroup.LaunchOptions.StartupRoutine)
+	t.maxRunDurationEntry.SetText("")
+	if t.currentGroup.LaunchOptions.MaxRunDuration > 0 {
+		t.maxRunDurationEntry.SetText(t.currentGroup.LaunchOptions.MaxRunDuration.String())
+	}
+	t.idleTimeoutEntry.SetText("")
+	if t.currentGroup.LaunchOptions.IdleTimeout > 0 {
+		t.idleTimeoutEntry.SetText(t.currentGroup.LaunchOptions.IdleTimeout.String())
+	}
+	if t.currentGroup.LaunchOptions.IdleAction == bot.IdleActionStop {
+		t.idleActionSelect.SetSelected(string(bot.IdleActionStop))
+	} else {
+		t.idleActionSelect.SetSelected(string(bot.IdleActionRestart))
+	}
+	t.pauseOnErrorCheck.SetChecked(t.currentGroup.LaunchOptions.PauseOnError)
+	t.logToFilesCheck.SetChecked(t.currentGroup.LaunchOptions.LogToFiles)
 
 	// Map conflict resolution enum to string
 	conflictStr := "skip"
@@ -720,10 +955,13 @@ func (t *OrchestrationTabV3) populateFields() {
 	// Restart Policy
 	t.restartEnabledCheck.SetChecked(t.currentGroup.LaunchOptions.RestartPolicy.Enabled)
 	t.maxRetriesEntry.SetText(fmt.Sprintf("%d", t.currentGroup.LaunchOptions.RestartPolicy.MaxRetries))
+	t.maxIterationsEntry.SetText(fmt.Sprintf("%d", t.currentGroup.LaunchOptions.RestartPolicy.MaxIterations))
 	t.initialDelayEntry.SetText(t.currentGroup.LaunchOptions.RestartPolicy.InitialDelay.String())
 	t.maxDelayEntry.SetText(t.currentGroup.LaunchOptions.RestartPolicy.MaxDelay.String())
 	t.backoffFactorEntry.SetText(fmt.Sprintf("%.1f", t.currentGroup.LaunchOptions.RestartPolicy.BackoffFactor))
+	t.backoffStrategySelect.SetSelected(string(t.currentGroup.LaunchOptions.RestartPolicy.EffectiveStrategy()))
 	t.resetOnSuccessCheck.SetChecked(t.currentGroup.LaunchOptions.RestartPolicy.ResetOnSuccess)
+	t.resumeCheckpointCheck.SetChecked(t.currentGroup.LaunchOptions.RestartPolicy.ResumeFromCheckpoint)
 
 	// Status tab
 	t.updateStatusData()
@@ -735,24 +973,290 @@ func (t *OrchestrationTabV3) updateStatusData() {
 	defer t.statusDataMu.Unlock()
 
 	t.statusData = make([][]string, 0)
+	t.statusInstances = make([]int, 0)
+	var selectedStepError *bot.PendingStepError
 
 	if t.currentRunGroup != nil {
 		// Get bot states from runtime group
 		botInfos := t.currentRunGroup.GetAllBotInfo()
 		for instanceID, info := range botInfos {
 			status := string(info.Status)
+			if info.Status == bot.BotStatusWaitingForAccounts {
+				status = waitingForAccountsText
+			}
+
+			lastScreen := components.FormatLastScreen("", time.Time{})
+			lastRun := "-"
+			if info.Bot != nil {
+				lastScreen = components.FormatLastScreen(info.Bot.LastScreen())
+				if result := info.Bot.LastRoutineResult(); result != nil {
+					lastRun = result.Summary()
+				}
+				if pending := info.Bot.PendingStepError(); pending != nil {
+					status = fmt.Sprintf("paused on error in '%s'", pending.Step)
+					if instanceID == t.selectedStatusInstance {
+						selectedStepError = pending
+					}
+				}
+			}
 
 			t.statusData = append(t.statusData, []string{
 				fmt.Sprintf("Instance %d", instanceID),
 				fmt.Sprintf("Instance %d", instanceID),
 				status,
+				t.formatResourceUsage(instanceID),
+				lastScreen,
+				lastRun,
+			})
+			t.statusInstances = append(t.statusInstances, instanceID)
+		}
+
+		// Bots waiting for the global bot budget
+		for _, instanceID := range t.orchestrator.GetQueuedInstances(t.currentRunGroup.Name) {
+			t.statusData = append(t.statusData, []string{
+				fmt.Sprintf("Instance %d", instanceID),
+				fmt.Sprintf("Instance %d", instanceID),
+				string(bot.BotStatusQueued),
+				"-",
+				components.FormatLastScreen("", time.Time{}),
+				"-",
 			})
+			t.statusInstances = append(t.statusInstances, instanceID)
 		}
 	}
 
+	budgetText := t.formatBudgetStatus()
+	progressText := t.formatProgressStatus()
+	botCountText, canScaleDown, canScaleUp := t.formatBotCount()
+
 	fyne.Do(func() {
 		t.statusList.Refresh()
+		if t.botCountLabel != nil {
+			t.botCountLabel.SetText(botCountText)
+			if canScaleDown {
+				t.scaleDownBtn.Enable()
+			} else {
+				t.scaleDownBtn.Disable()
+			}
+			if canScaleUp {
+				t.scaleUpBtn.Enable()
+			} else {
+				t.scaleUpBtn.Disable()
+			}
+		}
+		if t.budgetLabel != nil {
+			t.budgetLabel.SetText(budgetText)
+		}
+		if t.progressLabel != nil {
+			t.progressLabel.SetText(progressText)
+		}
+		if t.stepErrorLabel != nil {
+			t.showStepError(selectedStepError)
+		}
+	})
+}
+
+// showStepError shows the selected bot's pending step error and enables its controls
+func (t *OrchestrationTabV3) showStepError(pending *bot.PendingStepError) {
+	if pending == nil {
+		t.stepErrorLabel.SetText("")
+		for _, btn := range t.stepErrorBtns {
+			btn.Disable()
+		}
+		return
+	}
+
+	t.stepErrorLabel.SetText(fmt.Sprintf("Instance %d paused at %s on step '%s': %v",
+		t.selectedStatusInstance, pending.PausedAt.Format("15:04:05"), pending.Step, pending.Err))
+	for _, btn := range t.stepErrorBtns {
+		btn.Enable()
+	}
+}
+
+// handleResolveStepError resumes the selected bot from its step error with the chosen decision
+func (t *OrchestrationTabV3) handleResolveStepError(decision actions.StepErrorDecision) {
+	if t.currentRunGroup == nil || t.selectedStatusInstance < 0 {
+		return
+	}
+
+	err := t.orchestrator.ResolveStepError(t.currentRunGroup.Name, t.selectedStatusInstance, decision)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to %s step: %w", decision, err), t.window)
+	}
+	t.updateStatusData()
+}
+
+// formatProgressStatus describes the current group's account progress and estimated time remaining
+func (t *OrchestrationTabV3) formatProgressStatus() string {
+	if t.orchestrator == nil || t.currentRunGroup == nil || t.currentRunGroup.AccountPool == nil {
+		return ""
+	}
+
+	processed, total, _, eta, err := t.orchestrator.GroupETA(t.currentRunGroup.Name)
+	text := bot.FormatGroupETA(processed, total, eta, err)
+	if text == "" {
+		return ""
+	}
+	return "Accounts: " + text
+}
+
+// formatBudgetStatus describes the current group's launch progress and global bot budget usage
+func (t *OrchestrationTabV3) formatBudgetStatus() string {
+	if t.orchestrator == nil {
+		return ""
+	}
+
+	active := t.orchestrator.ActiveBotCount()
+	queued := t.orchestrator.QueuedBotCount()
+
+	limit := "unlimited"
+	if maxBots := t.orchestrator.GetMaxConcurrentBots(); maxBots > 0 {
+		limit = fmt.Sprintf("%d", maxBots)
+	}
+
+	text := fmt.Sprintf("All groups: %d running / %s max, %d queued", active, limit, queued)
+	if availableMB, totalMB, err := emulator.SystemMemory(); err == nil {
+		text = fmt.Sprintf("%s  |  Free RAM: %d / %d MB", text, availableMB, totalMB)
+		if t.orchestrator.IsMemoryLow() {
+			text += " (launches held - low memory)"
+		}
+	}
+	if t.currentRunGroup != nil {
+		launched, groupQueued := t.orchestrator.GetGroupBudgetStatus(t.currentRunGroup.Name)
+		groupText := fmt.Sprintf("This group: launching %d, queued %d", launched, groupQueued)
+		if elapsed, remaining, ok := t.currentRunGroup.RunTimeLimit(); ok {
+			groupText = fmt.Sprintf("%s, running %v, %v left", groupText,
+				elapsed.Round(time.Second), remaining.Round(time.Second))
+		}
+		text = fmt.Sprintf("%s  |  %s", groupText, text)
+	}
+	return text
+}
+
+// formatResourceUsage describes the CPU and memory used by an instance's emulator
+func (t *OrchestrationTabV3) formatResourceUsage(instanceID int) string {
+	if t.orchestrator == nil || t.orchestrator.GetEmulatorManager() == nil {
+		return "-"
+	}
+
+	cpuPercent, memMB, err := t.orchestrator.GetEmulatorManager().InstanceResourceUsage(instanceID)
+	if errors.Is(err, emulator.ErrProcessNotFound) {
+		return "not running"
+	}
+	if err != nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%% / %d MB", cpuPercent, memMB)
+}
+
+// subscribeMaintenanceEvents updates the maintenance banner from orchestrator events
+func (t *OrchestrationTabV3) subscribeMaintenanceEvents() {
+	if t.orchestrator == nil || t.orchestrator.GetEventBus() == nil {
+		return
+	}
+	bus := t.orchestrator.GetEventBus()
+
+	bus.SubscribeNamed("orchestration_tab.maintenance", events.EventTypeMaintenanceDetected, func(e events.Event) {
+		text := "Maintenance detected — bots paused."
+		if groups := t.orchestrator.GetMaintenanceMonitor().Status().Groups; len(groups) > 0 {
+			text = fmt.Sprintf("Maintenance detected — bots in %s paused.", strings.Join(groups, ", "))
+		}
+		if nextCheck, ok := e.Data["next_check"].(time.Time); ok {
+			text = fmt.Sprintf("%s Re-checking at %s.", text, nextCheck.Format("15:04:05"))
+		}
+		fyne.Do(func() {
+			t.maintenanceLabel.SetText(text)
+			t.maintenanceLabel.Show()
+		})
 	})
+
+	bus.SubscribeNamed("orchestration_tab.maintenance", events.EventTypeMaintenanceEnded, func(e events.Event) {
+		fyne.Do(func() {
+			t.maintenanceLabel.SetText("")
+			t.maintenanceLabel.Hide()
+		})
+	})
+}
+
+// formatBotCount describes the running group's bot count and whether it can be scaled down or up
+func (t *OrchestrationTabV3) formatBotCount() (string, bool, bool) {
+	group := t.currentRunGroup
+	if group == nil || !group.IsRunning() {
+		return "not running", false, false
+	}
+
+	count := group.RequestedBotCount
+	text := fmt.Sprintf("%d / %d instances", count, len(group.AvailableInstances))
+	return text, count > 1, count < len(group.AvailableInstances)
+}
+
+// handleScaleGroup adds or removes bots from the running group by delta
+func (t *OrchestrationTabV3) handleScaleGroup(delta int) {
+	group := t.currentRunGroup
+	if group == nil {
+		return
+	}
+	target := group.RequestedBotCount + delta
+
+	t.scaleDownBtn.Disable()
+	t.scaleUpBtn.Disable()
+
+	// Scaling up launches bots with the stagger delay, so run it off the GUI thread
+	go func() {
+		err := t.orchestrator.ScaleGroup(group.Name, target)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to scale group: %w", err), t.window)
+			}
+			t.updateStatusData()
+		})
+	}()
+}
+
+// showRunStagger fills the stagger control with the running group's delay
+func (t *OrchestrationTabV3) showRunStagger() {
+	if t.runStaggerEntry == nil {
+		return
+	}
+	if t.currentRunGroup == nil {
+		t.runStaggerEntry.SetText("")
+		return
+	}
+	t.runStaggerEntry.SetText(t.currentRunGroup.StaggerDelay().String())
+}
+
+// handleApplyRunStagger changes the running group's stagger delay
+func (t *OrchestrationTabV3) handleApplyRunStagger() {
+	if t.currentRunGroup == nil {
+		dialog.ShowError(fmt.Errorf("group is not running"), t.window)
+		return
+	}
+
+	delay, err := time.ParseDuration(strings.TrimSpace(t.runStaggerEntry.Text))
+	if err != nil || delay < 0 {
+		dialog.ShowError(fmt.Errorf("stagger delay must be a duration like 5s"), t.window)
+		return
+	}
+
+	t.currentRunGroup.SetStaggerDelay(delay)
+}
+
+// handleApplyBotBudget applies the global max concurrent bots setting
+func (t *OrchestrationTabV3) handleApplyBotBudget() {
+	maxBots, err := strconv.Atoi(strings.TrimSpace(t.maxBotsEntry.Text))
+	if err != nil || maxBots < 0 {
+		dialog.ShowError(fmt.Errorf("max concurrent bots must be a non-negative number"), t.window)
+		return
+	}
+	minFreeMB, err := strconv.Atoi(strings.TrimSpace(t.minFreeMemEntry.Text))
+	if err != nil || minFreeMB < 0 {
+		dialog.ShowError(fmt.Errorf("min free RAM must be a non-negative number of MB"), t.window)
+		return
+	}
+
+	t.orchestrator.SetMaxConcurrentBots(maxBots)
+	t.orchestrator.SetMinFreeMemoryMB(minFreeMB)
+	t.updateStatusData()
 }
 
 // markDirty marks the group as having unsaved changes
@@ -794,8 +1298,10 @@ func (t *OrchestrationTabV3) updateButtonStates() {
 		if t.startBtn != nil {
 			if hasGroup && !isRunning {
 				t.startBtn.Enable()
+				t.previewBtn.Enable()
 			} else {
 				t.startBtn.Disable()
+				t.previewBtn.Disable()
 			}
 		}
 
@@ -898,108 +1404,126 @@ func (t *OrchestrationTabV3) handleSaveChanges() {
 		return
 	}
 
-	// Collect values from fields
+	// Collect values from fields into a working copy so a failed validation leaves the group untouched
+	updated := t.currentGroup.Clone()
 	name := strings.TrimSpace(t.nameEntry.Text)
-	if name == "" {
-		dialog.ShowError(fmt.Errorf("group name is required"), t.window)
-		return
-	}
-
-	routine := t.routineSelect.Selected
-	if routine == "" {
-		dialog.ShowError(fmt.Errorf("routine must be selected"), t.window)
-		return
-	}
 
+	// An unparseable bot count is reported by validation along with any other problems
 	botCount, err := strconv.Atoi(strings.TrimSpace(t.botCountEntry.Text))
-	if err != nil || botCount <= 0 {
-		dialog.ShowError(fmt.Errorf("invalid bot count"), t.window)
-		return
+	if err != nil {
+		botCount = 0
 	}
 
-	// Check instances
-	t.instancesDataMu.RLock()
-	if len(t.instancesData) == 0 {
-		t.instancesDataMu.RUnlock()
-		dialog.ShowError(fmt.Errorf("at least one instance is required"), t.window)
-		return
-	}
-	if botCount > len(t.instancesData) {
-		t.instancesDataMu.RUnlock()
-		dialog.ShowError(fmt.Errorf("bot count (%d) exceeds available instances (%d)", botCount, len(t.instancesData)), t.window)
-		return
-	}
-	t.instancesDataMu.RUnlock()
-
-	// Update current group
+	// Update working copy
 	oldName := t.currentGroup.Name
-	t.currentGroup.Name = name
-	t.currentGroup.Description = strings.TrimSpace(t.descEntry.Text)
-	t.currentGroup.RoutineName = routine
-	t.currentGroup.RequestedBotCount = botCount
+	updated.Name = name
+	updated.Description = strings.TrimSpace(t.descEntry.Text)
+	updated.RoutineName = t.routineSelect.Selected
+	updated.RequestedBotCount = botCount
 
 	// Save account pools (both legacy single and new multiple)
 	t.poolsDataMu.RLock()
-	t.currentGroup.AccountPoolNames = make([]string, len(t.poolsData))
-	copy(t.currentGroup.AccountPoolNames, t.poolsData)
+	updated.AccountPoolNames = make([]string, len(t.poolsData))
+	copy(updated.AccountPoolNames, t.poolsData)
 	// Also update legacy field for backwards compatibility
 	if len(t.poolsData) > 0 {
-		t.currentGroup.AccountPoolName = t.poolsData[0]
+		updated.AccountPoolName = t.poolsData[0]
 	} else {
-		t.currentGroup.AccountPoolName = ""
+		updated.AccountPoolName = ""
 	}
 	t.poolsDataMu.RUnlock()
 
 	t.instancesDataMu.RLock()
-	t.currentGroup.AvailableInstances = make([]int, len(t.instancesData))
-	copy(t.currentGroup.AvailableInstances, t.instancesData)
+	updated.AvailableInstances = make([]int, len(t.instancesData))
+	copy(updated.AvailableInstances, t.instancesData)
+	updated.InstanceRoutines = nil
+	for instanceID, routineName := range t.instanceRoutines {
+		if updated.InstanceRoutines == nil {
+			updated.InstanceRoutines = make(map[int]string)
+		}
+		updated.InstanceRoutines[instanceID] = routineName
+	}
 	t.instancesDataMu.RUnlock()
 
 	// Parse launch options
-	t.currentGroup.LaunchOptions.ValidateRoutine = t.validateRoutineCheck.Checked
-	t.currentGroup.LaunchOptions.ValidateTemplates = t.validateTemplatesCheck.Checked
-	t.currentGroup.LaunchOptions.ValidateEmulators = t.validateEmulatorsCheck.Checked
+	updated.LaunchOptions.ValidateRoutine = t.validateRoutineCheck.Checked
+	updated.LaunchOptions.ValidateTemplates = t.validateTemplatesCheck.Checked
+	updated.LaunchOptions.ValidateEmulators = t.validateEmulatorsCheck.Checked
+	updated.LaunchOptions.ValidateProxies = t.validateProxiesCheck.Checked
+	updated.LaunchOptions.LaunchGame = t.launchGameCheck.Checked
+	updated.LaunchOptions.StartupRoutine = strings.TrimSpace(t.startupRoutineEntry.Text)
+	updated.LaunchOptions.LogToFiles = t.logToFilesCheck.Checked
 
 	if staggerDelay, err := time.ParseDuration(t.staggerDelayEntry.Text); err == nil {
-		t.currentGroup.LaunchOptions.StaggerDelay = staggerDelay
+		updated.LaunchOptions.StaggerDelay = staggerDelay
 	}
 
 	if emulatorTimeout, err := time.ParseDuration(t.emulatorTimeoutEntry.Text); err == nil {
-		t.currentGroup.LaunchOptions.EmulatorTimeout = emulatorTimeout
+		updated.LaunchOptions.EmulatorTimeout = emulatorTimeout
+	}
+
+	// A blank max run duration runs until stopped
+	updated.LaunchOptions.MaxRunDuration = 0
+	if maxRunDuration, err := time.ParseDuration(strings.TrimSpace(t.maxRunDurationEntry.Text)); err == nil {
+		updated.LaunchOptions.MaxRunDuration = maxRunDuration
 	}
 
+	// A blank idle timeout disables the watchdog
+	updated.LaunchOptions.IdleTimeout = 0
+	if idleTimeout, err := time.ParseDuration(strings.TrimSpace(t.idleTimeoutEntry.Text)); err == nil {
+		updated.LaunchOptions.IdleTimeout = idleTimeout
+	}
+	updated.LaunchOptions.IdleAction = bot.IdleAction(t.idleActionSelect.Selected)
+	updated.LaunchOptions.PauseOnError = t.pauseOnErrorCheck.Checked
+
 	// Map conflict resolution string to enum
 	switch t.conflictResolutionSelect.Selected {
 	case "skip":
-		t.currentGroup.LaunchOptions.OnConflict = bot.ConflictResolutionSkip
+		updated.LaunchOptions.OnConflict = bot.ConflictResolutionSkip
 	case "error":
-		t.currentGroup.LaunchOptions.OnConflict = bot.ConflictResolutionAbort
+		updated.LaunchOptions.OnConflict = bot.ConflictResolutionAbort
 	case "force":
-		t.currentGroup.LaunchOptions.OnConflict = bot.ConflictResolutionCancel
+		updated.LaunchOptions.OnConflict = bot.ConflictResolutionCancel
 	default:
-		t.currentGroup.LaunchOptions.OnConflict = bot.ConflictResolutionSkip
+		updated.LaunchOptions.OnConflict = bot.ConflictResolutionSkip
 	}
 
 	// Restart policy
-	t.currentGroup.LaunchOptions.RestartPolicy.Enabled = t.restartEnabledCheck.Checked
+	updated.LaunchOptions.RestartPolicy.Enabled = t.restartEnabledCheck.Checked
 
 	if maxRetries, err := strconv.Atoi(t.maxRetriesEntry.Text); err == nil {
-		t.currentGroup.LaunchOptions.RestartPolicy.MaxRetries = maxRetries
+		updated.LaunchOptions.RestartPolicy.MaxRetries = maxRetries
+	}
+
+	if maxIterations, err := strconv.Atoi(strings.TrimSpace(t.maxIterationsEntry.Text)); err == nil {
+		updated.LaunchOptions.RestartPolicy.MaxIterations = maxIterations
+	} else if strings.TrimSpace(t.maxIterationsEntry.Text) == "" {
+		updated.LaunchOptions.RestartPolicy.MaxIterations = 0
 	}
 
 	if initialDelay, err := time.ParseDuration(t.initialDelayEntry.Text); err == nil {
-		t.currentGroup.LaunchOptions.RestartPolicy.InitialDelay = initialDelay
+		updated.LaunchOptions.RestartPolicy.InitialDelay = initialDelay
 	}
 
 	if maxDelay, err := time.ParseDuration(t.maxDelayEntry.Text); err == nil {
-		t.currentGroup.LaunchOptions.RestartPolicy.MaxDelay = maxDelay
+		updated.LaunchOptions.RestartPolicy.MaxDelay = maxDelay
 	}
 
+	updated.LaunchOptions.RestartPolicy.Strategy = bot.BackoffStrategy(t.backoffStrategySelect.Selected)
+
 	if backoffFactor, err := strconv.ParseFloat(t.backoffFactorEntry.Text, 64); err == nil {
-		t.currentGroup.LaunchOptions.RestartPolicy.BackoffFactor = backoffFactor
+		updated.LaunchOptions.RestartPolicy.BackoffFactor = backoffFactor
 	}
 
-	t.currentGroup.LaunchOptions.RestartPolicy.ResetOnSuccess = t.resetOnSuccessCheck.Checked
+	updated.LaunchOptions.RestartPolicy.ResetOnSuccess = t.resetOnSuccessCheck.Checked
+	updated.LaunchOptions.RestartPolicy.ResumeFromCheckpoint = t.resumeCheckpointCheck.Checked
+
+	// Validate everything up front so all problems are shown in one dialog
+	if err := t.orchestrator.ValidateDefinition(updated).Err(); err != nil {
+		t.showValidationErrors(err)
+		return
+	}
+	t.currentGroup = updated
 
 	// Handle rename
 	if oldName != name {
@@ -1049,6 +1573,70 @@ func (t *OrchestrationTabV3) handleSaveChanges() {
 	dialog.ShowInformation("Saved", fmt.Sprintf("Group '%s' saved successfully", name), t.window)
 }
 
+// showValidationErrors shows every validation problem in a single dialog
+func (t *OrchestrationTabV3) showValidationErrors(err error) {
+	var failure *bot.ValidationFailure
+	if !errors.As(err, &failure) {
+		dialog.ShowError(err, t.window)
+		return
+	}
+
+	var sb strings.Builder
+	for _, message := range failure.Messages() {
+		sb.WriteString("• " + message + "\n")
+	}
+
+	issues := widget.NewLabel(sb.String())
+	issues.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVScroll(issues)
+	content.SetMinSize(fyne.NewSize(450, 200))
+
+	dialog.ShowCustom(
+		fmt.Sprintf("Cannot Save Group (%d issue(s))", len(failure.Errors)),
+		"OK",
+		content,
+		t.window,
+	)
+}
+
+// showSessionSummary shows the current session's report, or the last saved one if no group
+// has been launched yet
+func (t *OrchestrationTabV3) showSessionSummary() {
+	if t.orchestrator == nil {
+		return
+	}
+
+	title := "Session Summary"
+	var report *bot.SessionReport
+	var err error
+	if t.orchestrator.HasSessionActivity() {
+		report, err = t.orchestrator.SessionSummary()
+	} else if last, loadErr := bot.LoadLastSessionReport(bot.DefaultReportsDir); loadErr == nil {
+		title = "Last Session Summary"
+		report = last
+	} else {
+		report, err = t.orchestrator.SessionSummary()
+	}
+	if report == nil {
+		dialog.ShowError(err, t.window)
+		return
+	}
+
+	text := report.Format()
+	if err != nil {
+		text += fmt.Sprintf("\nWarning: %v\n", err)
+	}
+
+	summary := widget.NewLabel(text)
+	summary.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVScroll(summary)
+	content.SetMinSize(fyne.NewSize(450, 300))
+
+	dialog.ShowCustom(title, "Close", content, t.window)
+}
+
 // handleDiscardChanges discards changes and reloads from saved definition
 func (t *OrchestrationTabV3) handleDiscardChanges() {
 	if t.selectedIndex >= 0 {
@@ -1133,47 +1721,150 @@ func (t *OrchestrationTabV3) handleStartGroup() {
 		"Start Group",
 		fmt.Sprintf("Start group '%s'?", name),
 		func(confirmed bool) {
-			if !confirmed {
+			if confirmed {
+				t.launchGroup(name)
+			}
+		},
+		t.window,
+	)
+}
+
+// launchGroup launches a saved group in the background and reports the result
+func (t *OrchestrationTabV3) launchGroup(name string) {
+	// Launch asynchronously to prevent GUI freeze
+	go func() {
+		// Refresh instance state before launching to ensure accuracy
+		if err := t.orchestrator.GetEmulatorManager().DiscoverInstances(); err != nil {
+			fmt.Printf("Warning: Failed to discover instances before launch: %v\n", err)
+			// Continue anyway - instances might still be launchable
+		}
+
+		result, err := t.orchestrator.LaunchGroup(name, t.currentGroup.LaunchOptions)
+		if err != nil {
+			fyne.Do(func() {
+				dialog.ShowError(fmt.Errorf("failed to start group: %w", err), t.window)
+			})
+			return
+		}
+
+		// Update runtime group reference
+		t.currentRunGroup, _ = t.orchestrator.GetGroup(name)
+
+		// Update status on GUI thread
+		fyne.Do(func() {
+			t.updateStatusData()
+			t.updateButtonStates()
+			t.showRunStagger()
+
+			message := fmt.Sprintf(
+				"Group started!\n\nLaunched: %d/%d bots\nQueued: %d (waiting for bot budget)\nConflicts: %d\nErrors: %d",
+				result.LaunchedBots,
+				result.RequestedBots,
+				result.QueuedBots,
+				len(result.Conflicts),
+				len(result.Errors),
+			)
+			dialog.ShowInformation("Group Started", message, t.window)
+		})
+	}()
+}
+
+// handlePreviewLaunch shows what starting the current group would do, without starting it
+func (t *OrchestrationTabV3) handlePreviewLaunch() {
+	if t.currentGroup == nil {
+		return
+	}
+
+	if t.isDirty {
+		dialog.ShowError(fmt.Errorf("please save changes before previewing the launch"), t.window)
+		return
+	}
+
+	name := t.currentGroup.Name
+	options := t.currentGroup.LaunchOptions
+
+	// Instance discovery and pool resolution can take a moment
+	go func() {
+		plan, err := t.orchestrator.SimulateLaunch(name, options)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to preview launch: %w", err), t.window)
 				return
 			}
+			t.showLaunchPlan(plan)
+		})
+	}()
+}
 
-			// Launch asynchronously to prevent GUI freeze
-			go func() {
-				// Refresh instance state before launching to ensure accuracy
-				if err := t.orchestrator.GetEmulatorManager().DiscoverInstances(); err != nil {
-					fmt.Printf("Warning: Failed to discover instances before launch: %v\n", err)
-					// Continue anyway - instances might still be launchable
-				}
+// showLaunchPlan shows a simulated launch in a dialog, offering to start the group if it would launch
+func (t *OrchestrationTabV3) showLaunchPlan(plan *bot.LaunchPlan) {
+	var b strings.Builder
 
-				result, err := t.orchestrator.LaunchGroup(name, t.currentGroup.LaunchOptions)
-				if err != nil {
-					fyne.Do(func() {
-						dialog.ShowError(fmt.Errorf("failed to start group: %w", err), t.window)
-					})
-					return
-				}
+	fmt.Fprintf(&b, "Bots: %d of %d requested\n", len(plan.Bots), plan.RequestedBots)
+	if plan.PoolName != "" {
+		fmt.Fprintf(&b, "Pool '%s': %d available accounts\n", plan.PoolName, plan.AvailableAccounts)
+	}
 
-				// Update runtime group reference
-				t.currentRunGroup, _ = t.orchestrator.GetGroup(name)
+	if len(plan.Bots) > 0 {
+		b.WriteString("\nAssignments:\n")
+		for _, planned := range plan.Bots {
+			account := planned.Account
+			if account == "" {
+				account = "(no account)"
+			}
+			fmt.Fprintf(&b, "  Instance %d -> %s", planned.InstanceID, account)
+			if !planned.EmulatorRunning {
+				b.WriteString(" [emulator will be started]")
+			}
+			if planned.Preempts != "" {
+				fmt.Fprintf(&b, " [stops bot of '%s']", planned.Preempts)
+			}
+			if planned.Queued {
+				b.WriteString(" [queued for bot budget]")
+			}
+			b.WriteString("\n")
+		}
+	}
 
-				// Update status on GUI thread
-				fyne.Do(func() {
-					t.updateStatusData()
-					t.updateButtonStates()
+	for _, conflict := range plan.Conflicts {
+		fmt.Fprintf(&b, "\nConflict: instance %d is used by group '%s'", conflict.InstanceID, conflict.CurrentGroupName)
+	}
+	if len(plan.SkippedInstances) > 0 {
+		fmt.Fprintf(&b, "\nSkipped instances: %v", plan.SkippedInstances)
+	}
+	if len(plan.Conflicts) > 0 || len(plan.SkippedInstances) > 0 {
+		b.WriteString("\n")
+	}
 
-					message := fmt.Sprintf(
-						"Group started!\n\nLaunched: %d/%d bots\nConflicts: %d\nErrors: %d",
-						result.LaunchedBots,
-						result.RequestedBots,
-						len(result.Conflicts),
-						len(result.Errors),
-					)
-					dialog.ShowInformation("Group Started", message, t.window)
-				})
-			}()
-		},
-		t.window,
-	)
+	if len(plan.Warnings) > 0 {
+		b.WriteString("\nWarnings:\n")
+		for _, warning := range plan.Warnings {
+			fmt.Fprintf(&b, "  - %s\n", warning)
+		}
+	}
+	if len(plan.Errors) > 0 {
+		b.WriteString("\nThe launch would fail:\n")
+		for _, planErr := range plan.Errors {
+			fmt.Fprintf(&b, "  - %s\n", strings.TrimSpace(planErr))
+		}
+	}
+
+	text := widget.NewLabel(b.String())
+	text.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(text)
+	scroll.SetMinSize(fyne.NewSize(500, 350))
+
+	title := fmt.Sprintf("Launch Preview: %s", plan.GroupName)
+	if !plan.WouldLaunch() {
+		dialog.ShowCustom(title, "Close", scroll, t.window)
+		return
+	}
+
+	dialog.ShowCustomConfirm(title, "Start Group", "Close", scroll, func(start bool) {
+		if start {
+			t.launchGroup(plan.GroupName)
+		}
+	}, t.window)
 }
 
 // handleStopGroup stops the current group
@@ -1210,6 +1901,47 @@ func (t *OrchestrationTabV3) handleStopGroup() {
 	)
 }
 
+// handleEmergencyStop confirms, then stops every group, returns in-use accounts, closes all
+// pools and optionally shuts down all emulators
+func (t *OrchestrationTabV3) handleEmergencyStop() {
+	killEmulatorsCheck := widget.NewCheck("Also shut down all emulators", nil)
+	message := widget.NewLabel("Stop ALL groups and bots now?\n\nThis will:\n- Stop every running or launching group\n- Return in-use accounts to their pools\n- Close all account pools")
+	message.Wrapping = fyne.TextWrapWord
+
+	dialog.ShowCustomConfirm(
+		"Emergency Stop",
+		"Stop Everything",
+		"Cancel",
+		container.NewVBox(message, killEmulatorsCheck),
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			killEmulators := killEmulatorsCheck.Checked
+			go func() {
+				report, err := t.orchestrator.EmergencyStop(killEmulators)
+
+				fyne.Do(func() {
+					t.currentRunGroup = nil
+					t.updateStatusData()
+					t.updateButtonStates()
+					t.updateStatusLabel()
+
+					text := fmt.Sprintf("Stopped %d group(s) and %d bot(s)\nReturned %d account(s)\nClosed %d pool(s)\nStopped %d emulator(s)",
+						len(report.GroupsStopped), report.BotsStopped, report.AccountsReturned,
+						report.PoolsClosed, len(report.EmulatorsStopped))
+					if err != nil {
+						text += fmt.Sprintf("\n\nErrors:\n%v", err)
+					}
+					dialog.ShowInformation("Emergency Stop", text, t.window)
+				})
+			}()
+		},
+		t.window,
+	)
+}
+
 // handleAddInstanceFromDropdown adds instance from dropdown selection
 func (t *OrchestrationTabV3) handleAddInstanceFromDropdown() {
 	selected := t.addInstanceDropdown.Selected
@@ -1252,6 +1984,7 @@ func (t *OrchestrationTabV3) handleRemoveInstance(id widget.ListItemID) {
 	defer t.instancesDataMu.Unlock()
 
 	if id >= 0 && id < len(t.instancesData) {
+		delete(t.instanceRoutines, t.instancesData[id])
 		t.instancesData = append(t.instancesData[:id], t.instancesData[id+1:]...)
 		fyne.Do(func() {
 			t.instancesList.Refresh()
@@ -1260,6 +1993,25 @@ func (t *OrchestrationTabV3) handleRemoveInstance(id widget.ListItemID) {
 	}
 }
 
+// groupRoutineOption is the per-instance routine option that runs the group routine
+const groupRoutineOption = "(group routine)"
+
+// handleInstanceRoutineChanged sets or clears an instance's routine override
+func (t *OrchestrationTabV3) handleInstanceRoutineChanged(instanceID int, selected string) {
+	t.instancesDataMu.Lock()
+	if t.instanceRoutines == nil {
+		t.instanceRoutines = make(map[int]string)
+	}
+	if selected == groupRoutineOption || selected == "" {
+		delete(t.instanceRoutines, instanceID)
+	} else {
+		t.instanceRoutines[instanceID] = selected
+	}
+	t.instancesDataMu.Unlock()
+
+	t.markDirty()
+}
+
 // handleAddPoolFromDropdown adds a pool from the dropdown
 func (t *OrchestrationTabV3) handleAddPoolFromDropdown() {
 	selected := t.addPoolDropdown.Selected
@@ -1353,11 +2105,47 @@ func (t *OrchestrationTabV3) updatePoolDropdownList() {
 		t.addPoolDropdown.Options = poolNames
 	}
 
+	if t.defaultPoolSelect != nil {
+		t.defaultPoolSelect.Options = append([]string{noDefaultPool}, poolNames...)
+		if defaultPool := t.orchestrator.GetDefaultPool(); defaultPool != "" {
+			t.defaultPoolSelect.Selected = defaultPool
+		} else {
+			t.defaultPoolSelect.Selected = noDefaultPool
+		}
+	}
+
 	fyne.Do(func() {
 		t.addPoolDropdown.Refresh()
+		if t.defaultPoolSelect != nil {
+			t.defaultPoolSelect.Refresh()
+		}
 	})
 }
 
+// noDefaultPool is the default pool selector option that clears the default
+const noDefaultPool = "(none)"
+
+// handleDefaultPoolChanged sets the orchestrator's default pool, reverting the selector if
+// the pool no longer exists
+func (t *OrchestrationTabV3) handleDefaultPoolChanged(selected string) {
+	if t.orchestrator == nil {
+		return
+	}
+
+	poolName := selected
+	if poolName == noDefaultPool {
+		poolName = ""
+	}
+	if poolName == t.orchestrator.GetDefaultPool() {
+		return
+	}
+
+	if err := t.orchestrator.SetDefaultPool(poolName); err != nil {
+		dialog.ShowError(err, t.window)
+		t.updatePoolDropdownList()
+	}
+}
+
 // updateInstanceDropdown updates the instance dropdown from emulator manager
 func (t *OrchestrationTabV3) updateInstanceDropdown() {
 	if t.emulatorMgr == nil {
@@ -1383,11 +2171,7 @@ func (t *OrchestrationTabV3) updateInstanceDropdown() {
 	// Build options list from all configured instances
 	options := make([]string, 0, len(configs))
 	for index, config := range configs {
-		label := fmt.Sprintf("Instance %d", index)
-		if config.PlayerName != "" {
-			label = fmt.Sprintf("Instance %d (%s)", index, config.PlayerName)
-		}
-		options = append(options, label)
+		options = append(options, emulator.InstanceLabel(index, config.PlayerName))
 	}
 
 	// Sort by instance number
@@ -1396,11 +2180,7 @@ func (t *OrchestrationTabV3) updateInstanceDropdown() {
 	for i := 0; i < 100; i++ { // Reasonable upper limit
 		for index := range configs {
 			if index == i {
-				label := fmt.Sprintf("Instance %d", index)
-				if configs[index].PlayerName != "" {
-					label = fmt.Sprintf("Instance %d (%s)", index, configs[index].PlayerName)
-				}
-				sortedOptions = append(sortedOptions, label)
+				sortedOptions = append(sortedOptions, emulator.InstanceLabel(index, configs[index].PlayerName))
 				break
 			}
 		}
@@ -0,0 +1,304 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"jordanella.com/pocket-tcg-go/internal/cv"
+	"jordanella.com/pocket-tcg-go/internal/emulator"
+)
+
+// TemplateTesterTab captures a frame from an instance and shows where a template matches,
+// so thresholds can be tuned before they are baked into a routine
+type TemplateTesterTab struct {
+	controller *Controller
+
+	// Widgets
+	instanceSelect  *widget.Select
+	templateSelect  *widget.Select
+	thresholdSlider *widget.Slider
+	thresholdLabel  *widget.Label
+	resultLabel     *widget.Label
+	preview         *canvas.Image
+	captureBtn      *widget.Button
+
+	// Last capture and match (the slider re-evaluates these without recapturing)
+	mu         sync.Mutex
+	frame      *image.RGBA
+	match      *cv.MatchResult
+	matchSize  image.Point
+	searchArea *image.Rectangle
+}
+
+// NewTemplateTesterTab creates a new template tester tab
+func NewTemplateTesterTab(ctrl *Controller) *TemplateTesterTab {
+	return &TemplateTesterTab{
+		controller: ctrl,
+	}
+}
+
+// Build constructs the template tester UI
+func (t *TemplateTesterTab) Build() fyne.CanvasObject {
+	header := widget.NewLabelWithStyle("Template Match Tester", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+
+	t.instanceSelect = widget.NewSelect(t.buildInstanceOptions(), nil)
+	if len(t.instanceSelect.Options) > 0 {
+		t.instanceSelect.SetSelected(t.instanceSelect.Options[0])
+	}
+
+	t.templateSelect = widget.NewSelect(t.buildTemplateOptions(), func(name string) {
+		t.onTemplateSelected(name)
+	})
+
+	refreshBtn := widget.NewButton("Refresh", func() {
+		t.instanceSelect.Options = t.buildInstanceOptions()
+		t.instanceSelect.Refresh()
+		t.templateSelect.Options = t.buildTemplateOptions()
+		t.templateSelect.Refresh()
+	})
+
+	t.thresholdLabel = widget.NewLabel("")
+	t.thresholdSlider = widget.NewSlider(0.5, 1.0)
+	t.thresholdSlider.Step = 0.01
+	t.thresholdSlider.OnChanged = func(value float64) {
+		t.thresholdLabel.SetText(fmt.Sprintf("Threshold: %.2f", value))
+		t.renderResult()
+	}
+	t.thresholdSlider.SetValue(0.8)
+
+	t.captureBtn = widget.NewButton("Capture & Match", func() {
+		t.captureAndMatch()
+	})
+	t.captureBtn.Importance = widget.HighImportance
+
+	t.resultLabel = widget.NewLabel("Pick an instance and a template, then capture a frame")
+	t.resultLabel.Wrapping = fyne.TextWrapWord
+
+	t.preview = canvas.NewImageFromImage(nil)
+	t.preview.FillMode = canvas.ImageFillContain
+	t.preview.SetMinSize(fyne.NewSize(360, 640))
+
+	controls := container.NewVBox(
+		header,
+		widget.NewForm(
+			widget.NewFormItem("Instance", t.instanceSelect),
+			widget.NewFormItem("Template", t.templateSelect),
+		),
+		refreshBtn,
+		widget.NewSeparator(),
+		t.thresholdLabel,
+		t.thresholdSlider,
+		t.captureBtn,
+		widget.NewSeparator(),
+		t.resultLabel,
+	)
+
+	return container.NewBorder(nil, nil, container.NewPadded(controls), nil, container.NewScroll(t.preview))
+}
+
+// buildInstanceOptions lists configured MuMu instances
+func (t *TemplateTesterTab) buildInstanceOptions() []string {
+	mumuMgr := t.controller.GetMuMuManager()
+	if mumuMgr == nil {
+		return []string{}
+	}
+
+	configs, err := mumuMgr.GetAllInstanceConfigs()
+	if err != nil {
+		return []string{}
+	}
+
+	indexes := make([]int, 0, len(configs))
+	for index := range configs {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	options := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		options = append(options, emulator.InstanceLabel(index, configs[index].PlayerName))
+	}
+	return options
+}
+
+// buildTemplateOptions lists registered templates by name
+func (t *TemplateTesterTab) buildTemplateOptions() []string {
+	registry := t.controller.GetTemplateRegistry()
+	if registry == nil {
+		return []string{}
+	}
+	names := registry.List()
+	sort.Strings(names)
+	return names
+}
+
+// onTemplateSelected starts the slider at the template's own threshold
+func (t *TemplateTesterTab) onTemplateSelected(name string) {
+	registry := t.controller.GetTemplateRegistry()
+	if registry == nil {
+		return
+	}
+	if template, ok := registry.Get(name); ok && template.Threshold > 0 {
+		t.thresholdSlider.SetValue(template.Threshold)
+	}
+}
+
+// captureAndMatch grabs a fresh frame from the selected instance and matches the selected template
+func (t *TemplateTesterTab) captureAndMatch() {
+	var instance int
+	if _, err := fmt.Sscanf(t.instanceSelect.Selected, "Instance %d", &instance); err != nil {
+		t.resultLabel.SetText("Select an instance first")
+		return
+	}
+	templateName := t.templateSelect.Selected
+	if templateName == "" {
+		t.resultLabel.SetText("Select a template first")
+		return
+	}
+
+	t.captureBtn.Disable()
+	t.resultLabel.SetText(fmt.Sprintf("Capturing instance %d...", instance))
+
+	go func() {
+		defer fyne.Do(func() { t.captureBtn.Enable() })
+
+		frame, err := t.captureFrame(instance)
+		if err != nil {
+			fyne.Do(func() { t.resultLabel.SetText(fmt.Sprintf("Capture failed: %v", err)) })
+			return
+		}
+
+		match, size, searchArea, err := t.matchTemplate(frame, templateName)
+		if err != nil {
+			fyne.Do(func() { t.resultLabel.SetText(fmt.Sprintf("Match failed: %v", err)) })
+			return
+		}
+
+		t.mu.Lock()
+		t.frame = frame
+		t.match = match
+		t.matchSize = size
+		t.searchArea = searchArea
+		t.mu.Unlock()
+
+		fyne.Do(t.renderResult)
+	}()
+}
+
+// captureFrame captures from the instance's running bot, or directly from its window
+func (t *TemplateTesterTab) captureFrame(instance int) (*image.RGBA, error) {
+	if b, ok := t.controller.GetBot(instance); ok && b.CV() != nil {
+		return b.CV().CaptureFrame(false)
+	}
+
+	mgr := t.controller.CreateEmulatorManager()
+	if err := mgr.DiscoverInstances(); err != nil {
+		return nil, err
+	}
+	inst, err := mgr.GetInstance(instance)
+	if err != nil {
+		return nil, fmt.Errorf("instance %d is not running", instance)
+	}
+
+	capture, err := cv.NewWindowCapture(inst.MuMu.WindowHandle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create window capture: %w", err)
+	}
+	return capture.CaptureFrame()
+}
+
+// matchTemplate finds the best match for a template in a frame, the same way routines do.
+// The threshold is left at zero so the best location and confidence are always reported;
+// the slider decides whether that counts as found.
+func (t *TemplateTesterTab) matchTemplate(frame *image.RGBA, templateName string) (*cv.MatchResult, image.Point, *image.Rectangle, error) {
+	registry := t.controller.GetTemplateRegistry()
+	if registry == nil || registry.ImageCache() == nil {
+		return nil, image.Point{}, nil, fmt.Errorf("template registry not available")
+	}
+
+	needle, template, err := registry.ImageCache().Get(templateName)
+	if err != nil {
+		return nil, image.Point{}, nil, err
+	}
+	defer registry.ImageCache().Release(templateName)
+
+	config := &cv.MatchConfig{}
+	if template.Region != nil {
+		config.SearchRegion = template.Region.ToImageRectangle()
+	} else if titleBar := t.controller.GetConfig().TitleBarHeight; titleBar > 0 {
+		bounds := frame.Bounds()
+		config.SearchRegion = &image.Rectangle{
+			Min: image.Point{X: bounds.Min.X, Y: bounds.Min.Y + titleBar},
+			Max: bounds.Max,
+		}
+	}
+
+	result := cv.FindTemplate(frame, needle, config)
+	return result, needle.Bounds().Size(), config.SearchRegion, nil
+}
+
+// renderResult redraws the preview and summary for the current threshold
+func (t *TemplateTesterTab) renderResult() {
+	if t.preview == nil || t.resultLabel == nil {
+		return
+	}
+
+	t.mu.Lock()
+	frame, match, size, searchArea := t.frame, t.match, t.matchSize, t.searchArea
+	t.mu.Unlock()
+
+	if frame == nil || match == nil {
+		return
+	}
+
+	threshold := t.thresholdSlider.Value
+	found := match.Confidence >= threshold && size.X > 0
+
+	overlay := image.NewRGBA(frame.Bounds())
+	draw.Draw(overlay, overlay.Bounds(), frame, frame.Bounds().Min, draw.Src)
+
+	if searchArea != nil {
+		drawRectOutline(overlay, *searchArea, color.RGBA{R: 80, G: 140, B: 255, A: 255}, 1)
+	}
+
+	boxColor := color.RGBA{R: 220, G: 40, B: 40, A: 255}
+	status := "NOT FOUND"
+	if found {
+		boxColor = color.RGBA{R: 40, G: 200, B: 60, A: 255}
+		status = "FOUND"
+	}
+	if size.X > 0 {
+		drawRectOutline(overlay, image.Rectangle{Min: match.Location, Max: match.Location.Add(size)}, boxColor, 3)
+	}
+
+	t.preview.Image = overlay
+	t.preview.Refresh()
+	t.resultLabel.SetText(fmt.Sprintf("%s — confidence %.3f (threshold %.2f)\nBest match at (%d, %d), size %dx%d",
+		status, match.Confidence, threshold, match.Location.X, match.Location.Y, size.X, size.Y))
+}
+
+// drawRectOutline draws a rectangle border of the given thickness onto img
+func drawRectOutline(img *image.RGBA, rect image.Rectangle, c color.Color, thickness int) {
+	rect = rect.Intersect(img.Bounds())
+	if rect.Empty() {
+		return
+	}
+	src := image.NewUniform(c)
+	edges := []image.Rectangle{
+		image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+thickness),
+		image.Rect(rect.Min.X, rect.Max.Y-thickness, rect.Max.X, rect.Max.Y),
+		image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+thickness, rect.Max.Y),
+		image.Rect(rect.Max.X-thickness, rect.Min.Y, rect.Max.X, rect.Max.Y),
+	}
+	for _, edge := range edges {
+		draw.Draw(img, edge.Intersect(rect), src, image.Point{}, draw.Over)
+	}
+}
@@ -66,7 +66,7 @@ func (el *EventLogger) subscribeToEvents() {
 	}
 
 	for _, eventType := range eventTypes {
-		el.eventBus.Subscribe(eventType, el.handleEvent)
+		el.eventBus.SubscribeNamed("event_logger", eventType, el.handleEvent)
 	}
 }
 
@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultLogsDir        = "logs"
+	DefaultLogMaxSizeMB   = 10 // Size a sink's log file rotates at when none is configured
+	DefaultLogMaxFiles    = 5  // Rotated files kept per log when none is configured
+	instanceLogNameFormat = "instance-%d.log"
+)
+
+// FileSink writes logs to one rotating file per group and instance:
+// <dir>/<group>/instance-<n>.log. Files are opened on first use.
+type FileSink struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	maxFiles int
+	files    map[string]*RotatingFile // Keyed by file path
+}
+
+// NewFileSink creates a sink rooted at dir whose files rotate at maxSizeMB and keep
+// maxFiles rotated files (zero values use the defaults)
+func NewFileSink(dir string, maxSizeMB, maxFiles int) *FileSink {
+	if maxSizeMB <= 0 {
+		maxSizeMB = DefaultLogMaxSizeMB
+	}
+	if maxFiles <= 0 {
+		maxFiles = DefaultLogMaxFiles
+	}
+
+	return &FileSink{
+		dir:      dir,
+		maxBytes: int64(maxSizeMB) * 1024 * 1024,
+		maxFiles: maxFiles,
+		files:    make(map[string]*RotatingFile),
+	}
+}
+
+// Path returns the log file path for a group's instance
+func (s *FileSink) Path(group string, instance int) string {
+	return filepath.Join(s.dir, sanitizeLogDirName(group), fmt.Sprintf(instanceLogNameFormat, instance))
+}
+
+// Writer returns the log file for a group's instance, opening it if needed
+func (s *FileSink) Writer(group string, instance int) (io.Writer, error) {
+	path := s.Path(group, instance)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if file, exists := s.files[path]; exists {
+		return file, nil
+	}
+
+	file, err := NewRotatingFile(path, s.maxBytes, s.maxFiles)
+	if err != nil {
+		return nil, err
+	}
+	s.files[path] = file
+	return file, nil
+}
+
+// Close closes every open log file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for path, file := range s.files {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(s.files, path)
+	}
+	return firstErr
+}
+
+// WriteLine writes a timestamped line to w, adding the trailing newline if missing
+func WriteLine(w io.Writer, message string) error {
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+	_, err := io.WriteString(w, time.Now().Format("2006-01-02 15:04:05.000")+" "+message)
+	return err
+}
+
+// sanitizeLogDirName makes a group name safe to use as a directory name
+func sanitizeLogDirName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RotatingFile is a log file that rotates by size: when a write would grow it past
+// maxBytes, path is renamed to path.1 (path.1 to path.2, and so on) and a new file is
+// started. At most maxFiles rotated files are kept; older ones are deleted.
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewRotatingFile opens path for appending, creating it and its directory if needed.
+// maxBytes <= 0 disables rotation; maxFiles < 0 is treated as 0 (no rotated files kept).
+func NewRotatingFile(path string, maxBytes int64, maxFiles int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	rf := &RotatingFile{
+		path:     path,
+		maxBytes: maxBytes,
+		maxFiles: max(maxFiles, 0),
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Path returns the path of the current log file
+func (rf *RotatingFile) Path() string {
+	return rf.path
+}
+
+// Write appends p to the log file, rotating first if p would exceed the size limit
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	// A write larger than the limit still goes to a fresh file rather than being split
+	if rf.maxBytes > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxBytes {
+		if err := rf.rotate(); err != nil && rf.file == nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file; later writes fail
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.file == nil {
+		return nil
+	}
+	err := rf.file.Close()
+	rf.file = nil
+	return err
+}
+
+// open opens (or creates) the current log file and records its size
+func (rf *RotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files up by one, dropping the oldest, and starts a new file.
+// The current file is reopened even if shifting fails, so logging continues.
+func (rf *RotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	rf.file = nil
+
+	shiftErr := rf.shift()
+	if err := rf.open(); err != nil {
+		return err
+	}
+	return shiftErr
+}
+
+// shift moves path to path.1, path.1 to path.2, and so on, deleting what falls past maxFiles
+func (rf *RotatingFile) shift() error {
+	if rf.maxFiles == 0 {
+		if err := os.Remove(rf.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove log file: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Remove(rotatedPath(rf.path, rf.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove oldest log file: %w", err)
+	}
+	for i := rf.maxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedPath(rf.path, i), rotatedPath(rf.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+	if err := os.Rename(rf.path, rotatedPath(rf.path, 1)); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
+
+// rotatedPath returns the path of the nth most recent rotated file
+func rotatedPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
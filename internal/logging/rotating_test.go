@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFileKeepsMaxFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "group", "instance-1.log")
+
+	rf, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	// Each 6-byte write overflows the 10-byte limit, so every write after the first rotates
+	for _, line := range []string{"first\n", "secnd\n", "third\n", "forth\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]string{
+		path:        "forth\n",
+		path + ".1": "third\n",
+		path + ".2": "secnd\n",
+	}
+	for file, content := range want {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("reading %s: %v", file, err)
+		}
+		if string(data) != content {
+			t.Errorf("%s = %q, want %q", filepath.Base(file), data, content)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected no third rotated file, got err=%v", err)
+	}
+}
+
+func TestFileSinkWritesPerGroupInstance(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewFileSink(dir, 0, 0)
+
+	w, err := sink.Writer("Farm/A", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteLine(w, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := sink.Writer("Farm/A", 3); again != w {
+		t.Error("expected the same writer for the same group and instance")
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "Farm_A", "instance-3.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), " hello\n") {
+		t.Errorf("log line = %q, want timestamped hello", data)
+	}
+}
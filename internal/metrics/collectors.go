@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"jordanella.com/pocket-tcg-go/internal/accountpool"
+	"jordanella.com/pocket-tcg-go/internal/cv"
+	"jordanella.com/pocket-tcg-go/internal/database"
+)
+
+// GroupStats is a snapshot of one running bot group
+type GroupStats struct {
+	Name              string
+	BotsByStatus      map[string]int // Bot count per status (running, queued, failed, ...)
+	AccountsProcessed int            // Accounts taken from the group's pool so far
+	AccountsTotal     int            // Accounts in the pool when the group launched
+}
+
+// GroupStatsProvider reports running bot groups (implemented by bot.Orchestrator)
+type GroupStatsProvider interface {
+	GroupStats() []GroupStats
+}
+
+// NewOrchestratorCollector exposes bot and per-group account progress gauges
+func NewOrchestratorCollector(provider GroupStatsProvider) Collector {
+	return CollectorFunc(func() ([]Metric, error) {
+		groups := provider.GroupStats()
+
+		activeBots := Metric{Name: "pocket_active_bots", Help: "Bots currently running", Type: TypeGauge}
+		bots := Metric{Name: "pocket_bots", Help: "Bots by group and status", Type: TypeGauge}
+		processed := Metric{Name: "pocket_group_accounts_processed", Help: "Accounts processed by each running group", Type: TypeGauge}
+		total := Metric{Name: "pocket_group_accounts_total", Help: "Accounts in each running group's pool at launch", Type: TypeGauge}
+
+		running := 0
+		for _, group := range groups {
+			for status, count := range group.BotsByStatus {
+				bots.Samples = append(bots.Samples, Sample{
+					Labels: map[string]string{"group": group.Name, "status": status},
+					Value:  float64(count),
+				})
+				if status == "running" {
+					running += count
+				}
+			}
+			groupLabel := map[string]string{"group": group.Name}
+			processed.Samples = append(processed.Samples, Sample{Labels: groupLabel, Value: float64(group.AccountsProcessed)})
+			total.Samples = append(total.Samples, Sample{Labels: groupLabel, Value: float64(group.AccountsTotal)})
+		}
+		activeBots.Samples = []Sample{{Value: float64(running)}}
+
+		return []Metric{activeBots, bots, processed, total}, nil
+	})
+}
+
+// NewPoolCollector exposes account counts for every open account pool
+func NewPoolCollector(pm *accountpool.PoolManager) Collector {
+	return CollectorFunc(func() ([]Metric, error) {
+		accounts := Metric{Name: "pocket_pool_accounts", Help: "Accounts in each open pool by state", Type: TypeGauge}
+
+		for name, stats := range pm.OpenPoolStats() {
+			for state, count := range map[string]int{
+				"available": stats.Available,
+				"in_use":    stats.InUse,
+				"completed": stats.Completed,
+				"failed":    stats.Failed,
+				"skipped":   stats.Skipped,
+				"banned":    stats.Banned,
+				"preserved": stats.Preserved,
+			} {
+				accounts.Samples = append(accounts.Samples, Sample{
+					Labels: map[string]string{"pool": name, "state": state},
+					Value:  float64(count),
+				})
+			}
+		}
+
+		return []Metric{accounts}, nil
+	})
+}
+
+// NewCVCollector exposes the heavy CV operation limit and time bots spent waiting on it
+func NewCVCollector() Collector {
+	return CollectorFunc(func() ([]Metric, error) {
+		stats := cv.GetConcurrencyStats()
+
+		return []Metric{
+			Gauge("pocket_cv_concurrency_limit", "Frame captures and template matches allowed at once", float64(stats.Limit)),
+			Gauge("pocket_cv_operations_in_use", "Frame captures and template matches running now", float64(stats.InUse)),
+			Counter("pocket_cv_operations_total", "Frame captures and template matches run", float64(stats.Operations)),
+			Counter("pocket_cv_waits_total", "Operations that waited for a free slot", float64(stats.Waits)),
+			Counter("pocket_cv_wait_seconds_total", "Time spent waiting for a free slot", stats.WaitTime.Seconds()),
+		}, nil
+	})
+}
+
+// NewDatabaseCollector exposes all-time pack, account, error and routine counters
+func NewDatabaseCollector(db *database.DB) Collector {
+	return CollectorFunc(func() ([]Metric, error) {
+		totals, err := db.GetMetricsTotals()
+		if err != nil {
+			return nil, err
+		}
+
+		return []Metric{
+			Counter("pocket_packs_opened_total", "Packs opened", float64(totals.PacksOpened)),
+			Counter("pocket_god_packs_total", "God packs opened", float64(totals.GodPacks)),
+			Counter("pocket_accounts_processed_total", "Accounts with at least one completed routine", float64(totals.AccountsProcessed)),
+			Labeled("pocket_errors_total", "Logged errors by type", TypeCounter, "type", totals.ErrorsByType),
+			Labeled("pocket_routine_executions_total", "Routine executions by status (completed, failed, started)", TypeCounter, "status", totals.RoutinesByStatus),
+		}, nil
+	})
+}
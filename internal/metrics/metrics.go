@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metric types in the Prometheus text exposition format
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+)
+
+// Sample is one value of a metric with its labels
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Metric is a named family of samples
+type Metric struct {
+	Name    string
+	Help    string
+	Type    string // TypeCounter or TypeGauge
+	Samples []Sample
+}
+
+// Gauge creates a single-sample gauge metric
+func Gauge(name, help string, value float64) Metric {
+	return Metric{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Value: value}}}
+}
+
+// Counter creates a single-sample counter metric
+func Counter(name, help string, value float64) Metric {
+	return Metric{Name: name, Help: help, Type: TypeCounter, Samples: []Sample{{Value: value}}}
+}
+
+// Labeled creates a metric with one sample per value of a single label
+func Labeled(name, help, metricType, label string, values map[string]int) Metric {
+	metric := Metric{Name: name, Help: help, Type: metricType}
+	for key, value := range values {
+		metric.Samples = append(metric.Samples, Sample{
+			Labels: map[string]string{label: key},
+			Value:  float64(value),
+		})
+	}
+	return metric
+}
+
+// Collector produces metrics at scrape time
+type Collector interface {
+	Collect() ([]Metric, error)
+}
+
+// CollectorFunc adapts a function to the Collector interface
+type CollectorFunc func() ([]Metric, error)
+
+// Collect calls f
+func (f CollectorFunc) Collect() ([]Metric, error) {
+	return f()
+}
+
+// WriteText writes metrics in the Prometheus text exposition format.
+// Samples are sorted by labels so output is stable between scrapes.
+func WriteText(w io.Writer, metrics []Metric) error {
+	bw := bufio.NewWriter(w)
+
+	for _, metric := range metrics {
+		if metric.Help != "" {
+			fmt.Fprintf(bw, "# HELP %s %s\n", metric.Name, escapeHelp(metric.Help))
+		}
+		if metric.Type != "" {
+			fmt.Fprintf(bw, "# TYPE %s %s\n", metric.Name, metric.Type)
+		}
+
+		lines := make([]string, 0, len(metric.Samples))
+		for _, sample := range metric.Samples {
+			lines = append(lines, metric.Name+formatLabels(sample.Labels)+" "+formatValue(sample.Value))
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			bw.WriteString(line)
+			bw.WriteByte('\n')
+		}
+	}
+
+	return bw.Flush()
+}
+
+// formatLabels renders {key="value",...} with keys sorted, or "" for no labels
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(key)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(labels[key]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// formatValue renders a sample value, using integer notation when possible
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case value == math.Trunc(value) && math.Abs(value) < 1e15:
+		return strconv.FormatInt(int64(value), 10)
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
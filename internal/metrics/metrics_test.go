@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	var b strings.Builder
+	err := WriteText(&b, []Metric{
+		Gauge("pocket_active_bots", "Bots currently running", 3),
+		Labeled("pocket_errors_total", "Logged errors by type", TypeCounter, "type", map[string]int{
+			"timeout": 1,
+			"stuck":   2,
+		}),
+		{Name: "pocket_rate", Type: TypeGauge, Samples: []Sample{
+			{Labels: map[string]string{"group": `say "hi"`}, Value: 1.5},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	expected := `# HELP pocket_active_bots Bots currently running
+# TYPE pocket_active_bots gauge
+pocket_active_bots 3
+# HELP pocket_errors_total Logged errors by type
+# TYPE pocket_errors_total counter
+pocket_errors_total{type="stuck"} 2
+pocket_errors_total{type="timeout"} 1
+# TYPE pocket_rate gauge
+pocket_rate{group="say \"hi\""} 1.5
+`
+	if b.String() != expected {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", b.String(), expected)
+	}
+}
+
+type fakeGroups []GroupStats
+
+func (f fakeGroups) GroupStats() []GroupStats { return f }
+
+func TestServerServesMetrics(t *testing.T) {
+	groups := fakeGroups{{
+		Name:              "farm",
+		BotsByStatus:      map[string]int{"running": 2, "queued": 1},
+		AccountsProcessed: 42,
+		AccountsTotal:     100,
+	}}
+	failing := CollectorFunc(func() ([]Metric, error) { return nil, errors.New("database closed") })
+
+	server := NewServer(1, NewOrchestratorCollector(groups), failing)
+	server.addr = "127.0.0.1:0" // Any free port
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	url := "http://" + server.Addr() + "/metrics"
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	for _, line := range []string{
+		"pocket_active_bots 2",
+		`pocket_bots{group="farm",status="queued"} 1`,
+		`pocket_group_accounts_processed{group="farm"} 42`,
+		`pocket_group_accounts_total{group="farm"} 100`,
+		"pocket_metrics_collector_errors 1",
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("missing %q in:\n%s", line, body)
+		}
+	}
+
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if server.Addr() != "" {
+		t.Error("Addr should be empty after shutdown")
+	}
+	if _, err := http.Get(url); err == nil {
+		t.Error("server still answering after shutdown")
+	}
+}
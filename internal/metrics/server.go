@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultPort is the port the metrics server listens on when none is configured
+const DefaultPort = 9464
+
+// Server serves collected metrics over HTTP at /metrics
+type Server struct {
+	addr       string
+	collectors []Collector
+
+	mu         sync.Mutex
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// NewServer creates a metrics server listening on port (all interfaces)
+func NewServer(port int, collectors ...Collector) *Server {
+	if port <= 0 {
+		port = DefaultPort
+	}
+	return &Server{
+		addr:       fmt.Sprintf(":%d", port),
+		collectors: collectors,
+	}
+}
+
+// Start begins listening; the port is bound before returning so conflicts are reported
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer != nil {
+		return fmt.Errorf("metrics server already running")
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.Handler())
+
+	s.listener = listener
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Metrics server stopped: %v\n", err)
+		}
+	}(s.httpServer)
+
+	fmt.Printf("Metrics server listening on %s/metrics\n", listener.Addr())
+	return nil
+}
+
+// Addr returns the address the server is listening on (empty when stopped)
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Shutdown stops the server, waiting for in-flight scrapes until ctx expires
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.httpServer
+	s.httpServer = nil
+	s.listener = nil
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Handler returns an http.Handler that writes all collectors' metrics.
+// A failing collector is skipped and counted in pocket_metrics_collector_errors.
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var all []Metric
+		failures := 0
+		for _, collector := range s.collectors {
+			metrics, err := collector.Collect()
+			if err != nil {
+				failures++
+				continue
+			}
+			all = append(all, metrics...)
+		}
+		all = append(all, Gauge("pocket_metrics_collector_errors",
+			"Collectors that failed during this scrape", float64(failures)))
+
+		var buf bytes.Buffer
+		if err := WriteText(&buf, all); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buf.Bytes())
+	})
+}
@@ -0,0 +1,240 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+)
+
+const (
+	// stallGridWidth and stallGridHeight are the dimensions frames are downscaled to before comparing
+	stallGridWidth  = 32
+	stallGridHeight = 24
+
+	// stallSampleStep skips pixels inside each grid cell to keep sampling cheap
+	stallSampleStep = 4
+)
+
+// FrameSource provides frames for stall detection (implemented by cv.Service)
+type FrameSource interface {
+	CaptureFrame(useCache bool) (*image.RGBA, error)
+}
+
+// StallConfig controls when a screen is considered stalled
+type StallConfig struct {
+	CheckInterval time.Duration // How often a frame is sampled
+	Window        time.Duration // How long the screen must stay unchanged before a stall is raised
+	Threshold     float64       // Mean luminance change in percent (0-100) that counts as the screen changing
+}
+
+// DefaultStallConfig returns conservative defaults. The window is long enough that
+// screens that sit idle with small animations while a routine waits don't trigger.
+func DefaultStallConfig() StallConfig {
+	return StallConfig{
+		CheckInterval: 5 * time.Second,
+		Window:        3 * time.Minute,
+		Threshold:     1.0,
+	}
+}
+
+// StallCallback is called when the screen hasn't changed for the configured window
+type StallCallback func(reason string, err error)
+
+// StallDetector periodically compares downscaled frames and raises a stall when
+// the screen stops changing while the bot is supposed to be working
+type StallDetector struct {
+	source  FrameSource
+	config  StallConfig
+	onStall StallCallback
+	active  func() bool // Only check while this returns true (nil = always)
+	now     func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+
+	reference   []float64 // Downscaled luminance of the last frame that counted as a change
+	lastChange  time.Time
+	lastDiff    float64
+	stallsFound int
+}
+
+// NewStallDetector creates a stall detector for a frame source
+func NewStallDetector(source FrameSource, config StallConfig) *StallDetector {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StallDetector{
+		source: source,
+		config: config,
+		now:    time.Now,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// WithStallCallback sets the callback raised when a stall is detected
+func (sd *StallDetector) WithStallCallback(callback StallCallback) *StallDetector {
+	sd.onStall = callback
+	return sd
+}
+
+// WithActiveCheck limits detection to when active returns true (e.g. a routine is running).
+// The unchanged timer restarts whenever the detector becomes active again.
+func (sd *StallDetector) WithActiveCheck(active func() bool) *StallDetector {
+	sd.active = active
+	return sd
+}
+
+// Start begins stall monitoring
+func (sd *StallDetector) Start() {
+	if sd.source == nil || sd.config.CheckInterval <= 0 || sd.config.Window <= 0 {
+		return
+	}
+	sd.wg.Add(1)
+	go sd.monitor()
+}
+
+// Stop stops stall monitoring
+func (sd *StallDetector) Stop() {
+	sd.cancel()
+	sd.wg.Wait()
+}
+
+// Reset forgets the reference frame so the unchanged timer starts over
+func (sd *StallDetector) Reset() {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.reference = nil
+}
+
+func (sd *StallDetector) monitor() {
+	defer sd.wg.Done()
+
+	ticker := time.NewTicker(sd.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sd.ctx.Done():
+			return
+		case <-ticker.C:
+			if sd.active != nil && !sd.active() {
+				sd.Reset()
+				continue
+			}
+			if err := sd.Check(); err != nil && sd.onStall != nil {
+				sd.onStall("screen_stalled", err)
+			}
+		}
+	}
+}
+
+// Check samples one frame and returns an error if the screen has been unchanged
+// for the configured window. Capture failures are not treated as stalls.
+func (sd *StallDetector) Check() error {
+	frame, err := sd.source.CaptureFrame(false)
+	if err != nil || frame == nil {
+		return nil
+	}
+	return sd.observe(downscaleLuminance(frame), sd.now())
+}
+
+// observe compares a downscaled frame against the reference and updates stall state
+func (sd *StallDetector) observe(sample []float64, now time.Time) error {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	if sd.reference == nil {
+		sd.reference = sample
+		sd.lastChange = now
+		return nil
+	}
+
+	sd.lastDiff = frameDifference(sd.reference, sample)
+	if sd.lastDiff > sd.config.Threshold {
+		sd.reference = sample
+		sd.lastChange = now
+		return nil
+	}
+
+	unchanged := now.Sub(sd.lastChange)
+	if unchanged < sd.config.Window {
+		return nil
+	}
+
+	// Restart the timer so a persistent stall is raised once per window
+	sd.lastChange = now
+	sd.stallsFound++
+	return fmt.Errorf("screen unchanged for %v (difference %.2f%% <= %.2f%%)",
+		unchanged.Round(time.Second), sd.lastDiff, sd.config.Threshold)
+}
+
+// GetStallStatus returns the detector's current state
+func (sd *StallDetector) GetStallStatus() map[string]interface{} {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	unchanged := time.Duration(0)
+	if sd.reference != nil {
+		unchanged = sd.now().Sub(sd.lastChange)
+	}
+
+	return map[string]interface{}{
+		"unchanged_for":   unchanged,
+		"last_difference": sd.lastDiff,
+		"stalls_detected": sd.stallsFound,
+	}
+}
+
+// downscaleLuminance averages a frame's luminance into a small fixed grid
+func downscaleLuminance(frame *image.RGBA) []float64 {
+	bounds := frame.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	grid := make([]float64, stallGridWidth*stallGridHeight)
+	if width <= 0 || height <= 0 {
+		return grid
+	}
+
+	for gy := 0; gy < stallGridHeight; gy++ {
+		y0 := bounds.Min.Y + gy*height/stallGridHeight
+		y1 := bounds.Min.Y + (gy+1)*height/stallGridHeight
+		for gx := 0; gx < stallGridWidth; gx++ {
+			x0 := bounds.Min.X + gx*width/stallGridWidth
+			x1 := bounds.Min.X + (gx+1)*width/stallGridWidth
+
+			var sum float64
+			var count int
+			for y := y0; y < y1; y += stallSampleStep {
+				for x := x0; x < x1; x += stallSampleStep {
+					i := frame.PixOffset(x, y)
+					r, g, b := frame.Pix[i], frame.Pix[i+1], frame.Pix[i+2]
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+					count++
+				}
+			}
+			if count > 0 {
+				grid[gy*stallGridWidth+gx] = sum / float64(count)
+			}
+		}
+	}
+	return grid
+}
+
+// frameDifference returns the mean absolute luminance difference in percent (0-100)
+func frameDifference(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 100
+	}
+
+	var total float64
+	for i := range a {
+		d := a[i] - b[i]
+		if d < 0 {
+			d = -d
+		}
+		total += d
+	}
+	return total / float64(len(a)) / 255 * 100
+}
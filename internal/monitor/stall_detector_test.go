@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+func solidFrame(gray uint8) *image.RGBA {
+	frame := image.NewRGBA(image.Rect(0, 0, 128, 96))
+	for y := 0; y < 96; y++ {
+		for x := 0; x < 128; x++ {
+			frame.Set(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+	return frame
+}
+
+func TestStallDetectorRaisesAfterWindow(t *testing.T) {
+	sd := NewStallDetector(nil, StallConfig{CheckInterval: time.Second, Window: time.Minute, Threshold: 1.0})
+	start := time.Now()
+	sample := downscaleLuminance(solidFrame(100))
+
+	if err := sd.observe(sample, start); err != nil {
+		t.Fatalf("first frame should only set the reference: %v", err)
+	}
+	if err := sd.observe(sample, start.Add(30*time.Second)); err != nil {
+		t.Fatalf("stall raised before the window elapsed: %v", err)
+	}
+	if err := sd.observe(sample, start.Add(61*time.Second)); err == nil {
+		t.Fatal("expected a stall after the window elapsed")
+	}
+
+	// The timer restarts after a stall is raised
+	if err := sd.observe(sample, start.Add(62*time.Second)); err != nil {
+		t.Fatalf("stall raised twice in one window: %v", err)
+	}
+}
+
+func TestStallDetectorIgnoresSmallChanges(t *testing.T) {
+	sd := NewStallDetector(nil, StallConfig{CheckInterval: time.Second, Window: time.Minute, Threshold: 1.0})
+	start := time.Now()
+
+	sd.observe(downscaleLuminance(solidFrame(100)), start)
+
+	// A 1-level change is well under the 1% threshold, so the timer keeps running
+	if err := sd.observe(downscaleLuminance(solidFrame(101)), start.Add(61*time.Second)); err == nil {
+		t.Fatal("expected a stall despite a tiny change")
+	}
+
+	// A large change resets the timer
+	sd.observe(downscaleLuminance(solidFrame(200)), start.Add(70*time.Second))
+	if err := sd.observe(downscaleLuminance(solidFrame(200)), start.Add(100*time.Second)); err != nil {
+		t.Fatalf("stall raised after the screen changed: %v", err)
+	}
+}
+
+func TestFrameDifference(t *testing.T) {
+	black := downscaleLuminance(solidFrame(0))
+	white := downscaleLuminance(solidFrame(255))
+
+	if diff := frameDifference(black, black); diff != 0 {
+		t.Errorf("identical frames differ by %.2f%%", diff)
+	}
+	if diff := frameDifference(black, white); diff < 99.9 {
+		t.Errorf("black vs white differ by only %.2f%%", diff)
+	}
+}
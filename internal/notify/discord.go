@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// discordEmbedColors are embed colors per event severity
+const (
+	discordColorInfo    = 0x3498db
+	discordColorSuccess = 0x2ecc71
+	discordColorWarning = 0xe74c3c
+)
+
+// DiscordWebhook posts notifications to a Discord channel webhook
+type DiscordWebhook struct {
+	url    string
+	userID string // Optional Discord user ID to mention
+	client *http.Client
+}
+
+// NewDiscordWebhook creates a notifier for a Discord webhook URL.
+// If userID is set, messages mention that user.
+func NewDiscordWebhook(url, userID string) *DiscordWebhook {
+	return &DiscordWebhook{
+		url:    url,
+		userID: userID,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+	Timestamp   string `json:"timestamp,omitempty"`
+}
+
+type discordPayload struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds"`
+}
+
+// Notify implements Notifier
+func (d *DiscordWebhook) Notify(ctx context.Context, msg Message) error {
+	payload := discordPayload{
+		Embeds: []discordEmbed{{
+			Title:       msg.Title,
+			Description: msg.Text,
+			Color:       discordColor(msg),
+			Timestamp:   msg.Timestamp.UTC().Format(time.RFC3339),
+		}},
+	}
+	if d.userID != "" {
+		payload.Content = fmt.Sprintf("<@%s>", d.userID)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// discordColor picks an embed color for the event
+func discordColor(msg Message) int {
+	switch msg.EventType {
+	case events.EventTypeGodPackFound, events.EventTypeGroupCompleted:
+		return discordColorSuccess
+	case events.EventTypeAccountBanned, events.EventTypeCircuitBreakerTripped:
+		return discordColorWarning
+	default:
+		return discordColorInfo
+	}
+}
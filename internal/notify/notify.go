@@ -0,0 +1,241 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// DefaultMaxPerMinute is how many notifications are sent per minute before the rest are suppressed
+const DefaultMaxPerMinute = 10
+
+// sendTimeout bounds how long a single notification may take to post
+const sendTimeout = 15 * time.Second
+
+// Message is a rendered notification
+type Message struct {
+	EventType events.EventType
+	Title     string
+	Text      string
+	Timestamp time.Time
+}
+
+// Notifier delivers messages to an external service
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// DefaultTemplates are the message templates for each supported event.
+// Templates are text/template strings executed against the event's Data map.
+var DefaultTemplates = map[events.EventType]string{
+	events.EventTypeGodPackFound: "God pack found by **{{.group_name}}** on instance {{.instance_id}}\n" +
+		"Account: `{{.account_id}}`{{if .pack_name}}\nPack: {{.pack_name}}{{end}}",
+	events.EventTypeGroupCompleted: "Group **{{.group_name}}** finished ({{.accounts_processed}} accounts processed)",
+	events.EventTypeGroupTimeLimitReached: "Group **{{.group_name}}** reached its {{.max_run_duration}} time limit; " +
+		"bots stop after their current run",
+	events.EventTypeCircuitBreakerTripped: "Bot {{.instance_id}} in **{{.group_name}}** stopped after {{.failures}} failed attempts " +
+		"of `{{.routine_name}}`\nLast error: {{.error}}",
+	events.EventTypeAccountBanned: "Account `{{.account_id}}` was banned (instance {{.instance_id}}, group **{{.group_name}}**)",
+	events.EventTypePoolExhausted: "Bot {{.instance_id}} in **{{.group_name}}** ran out of accounts in pool **{{.pool_name}}**" +
+		"{{if .waiting}}; waiting up to {{.max_wait}} for more{{else}}; stopping{{end}}",
+}
+
+// titles are the notification titles for each supported event
+var titles = map[events.EventType]string{
+	events.EventTypeGodPackFound:          "God pack",
+	events.EventTypeGroupCompleted:        "Group completed",
+	events.EventTypeGroupTimeLimitReached: "Group time limit reached",
+	events.EventTypeCircuitBreakerTripped: "Bot stopped after repeated failures",
+	events.EventTypeAccountBanned:         "Account banned",
+	events.EventTypePoolExhausted:         "Pool exhausted",
+}
+
+// SupportedEvents returns the event types that can be notified on
+func SupportedEvents() []events.EventType {
+	supported := make([]events.EventType, 0, len(DefaultTemplates))
+	for eventType := range DefaultTemplates {
+		supported = append(supported, eventType)
+	}
+	sort.Slice(supported, func(i, j int) bool { return supported[i] < supported[j] })
+	return supported
+}
+
+// ParseEventTypes parses a comma-separated list of event types (e.g. "pack.god_pack,group.completed")
+func ParseEventTypes(list string) ([]events.EventType, error) {
+	var eventTypes []events.EventType
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		eventType := events.EventType(name)
+		if _, ok := DefaultTemplates[eventType]; !ok {
+			return nil, fmt.Errorf("unsupported notification event '%s' (supported: %v)", name, SupportedEvents())
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes, nil
+}
+
+// Dispatcher turns bus events into notifications, rate limiting how many are sent
+type Dispatcher struct {
+	notifier Notifier
+
+	mu            sync.Mutex
+	templates     map[events.EventType]*template.Template
+	maxPerMinute  int
+	sent          []time.Time // Send times within the last minute
+	suppressed    int         // Events dropped since the last notification was sent
+	subscriptions []events.SubscriptionID
+	bus           events.EventBus
+
+	now func() time.Time
+}
+
+// NewDispatcher creates a dispatcher sending at most maxPerMinute notifications per minute
+func NewDispatcher(notifier Notifier, maxPerMinute int) *Dispatcher {
+	if maxPerMinute <= 0 {
+		maxPerMinute = DefaultMaxPerMinute
+	}
+
+	d := &Dispatcher{
+		notifier:     notifier,
+		templates:    make(map[events.EventType]*template.Template),
+		maxPerMinute: maxPerMinute,
+		now:          time.Now,
+	}
+	for eventType, text := range DefaultTemplates {
+		d.templates[eventType] = template.Must(template.New(string(eventType)).Parse(text))
+	}
+	return d
+}
+
+// SetTemplate replaces the message template for an event type
+func (d *Dispatcher) SetTemplate(eventType events.EventType, text string) error {
+	tmpl, err := template.New(string(eventType)).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template for %s: %w", eventType, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.templates[eventType] = tmpl
+	return nil
+}
+
+// Subscribe starts notifying on the given event types
+func (d *Dispatcher) Subscribe(bus events.EventBus, eventTypes []events.EventType) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.bus = bus
+	for _, eventType := range eventTypes {
+		id := bus.SubscribeNamed("notify:"+string(eventType), eventType, d.handle)
+		d.subscriptions = append(d.subscriptions, id)
+	}
+}
+
+// Stop unsubscribes from the event bus
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.bus == nil {
+		return
+	}
+	for _, id := range d.subscriptions {
+		d.bus.Unsubscribe(id)
+	}
+	d.subscriptions = nil
+	d.bus = nil
+}
+
+// Format renders the message for an event
+func (d *Dispatcher) Format(event events.Event) (Message, error) {
+	d.mu.Lock()
+	tmpl, ok := d.templates[event.Type]
+	d.mu.Unlock()
+
+	if !ok {
+		return Message{}, fmt.Errorf("no template for event %s", event.Type)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event.Data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s: %w", event.Type, err)
+	}
+
+	title := titles[event.Type]
+	if title == "" {
+		title = string(event.Type)
+	}
+
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = d.now()
+	}
+
+	return Message{
+		EventType: event.Type,
+		Title:     title,
+		Text:      buf.String(),
+		Timestamp: timestamp,
+	}, nil
+}
+
+// allow reports whether a notification may be sent now, recording it if so.
+// When allowed, it also returns how many events were suppressed since the last send.
+func (d *Dispatcher) allow() (bool, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	cutoff := now.Add(-time.Minute)
+	kept := d.sent[:0]
+	for _, at := range d.sent {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	d.sent = kept
+
+	if len(d.sent) >= d.maxPerMinute {
+		d.suppressed++
+		return false, 0
+	}
+
+	d.sent = append(d.sent, now)
+	suppressed := d.suppressed
+	d.suppressed = 0
+	return true, suppressed
+}
+
+// handle formats and sends one event; failures are logged, never fatal
+func (d *Dispatcher) handle(event events.Event) {
+	msg, err := d.Format(event)
+	if err != nil {
+		fmt.Printf("[Notify] %v\n", err)
+		return
+	}
+
+	allowed, suppressed := d.allow()
+	if !allowed {
+		return
+	}
+	if suppressed > 0 {
+		msg.Text += fmt.Sprintf("\n_(%d earlier notifications suppressed by rate limit)_", suppressed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	if err := d.notifier.Notify(ctx, msg); err != nil {
+		fmt.Printf("[Notify] Failed to send %s notification: %v\n", event.Type, err)
+	}
+}
@@ -0,0 +1,188 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"jordanella.com/pocket-tcg-go/internal/events"
+)
+
+// recordingNotifier captures sent messages
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, msg Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return r.err
+}
+
+func (r *recordingNotifier) sent() []Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Message(nil), r.messages...)
+}
+
+func TestFormatGodPackIncludesAccount(t *testing.T) {
+	d := NewDispatcher(&recordingNotifier{}, 0)
+
+	msg, err := d.Format(events.NewGodPackFoundEvent("Farm", 3, "acc-42", "device-42", "Mewtwo"))
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if msg.Title != "God pack" {
+		t.Errorf("title = %q", msg.Title)
+	}
+	for _, want := range []string{"Farm", "instance 3", "acc-42", "Mewtwo"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("message %q missing %q", msg.Text, want)
+		}
+	}
+}
+
+func TestSetTemplate(t *testing.T) {
+	d := NewDispatcher(&recordingNotifier{}, 0)
+
+	if err := d.SetTemplate(events.EventTypeGroupCompleted, "{{.group_name}} done"); err != nil {
+		t.Fatalf("SetTemplate: %v", err)
+	}
+	msg, err := d.Format(events.NewGroupCompletedEvent("Farm", 12))
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if msg.Text != "Farm done" {
+		t.Errorf("text = %q", msg.Text)
+	}
+
+	if err := d.SetTemplate(events.EventTypeGroupCompleted, "{{.group_name"); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+func TestParseEventTypes(t *testing.T) {
+	eventTypes, err := ParseEventTypes(" pack.god_pack, group.completed ,")
+	if err != nil {
+		t.Fatalf("ParseEventTypes: %v", err)
+	}
+	if len(eventTypes) != 2 || eventTypes[0] != events.EventTypeGodPackFound || eventTypes[1] != events.EventTypeGroupCompleted {
+		t.Errorf("eventTypes = %v", eventTypes)
+	}
+
+	if _, err := ParseEventTypes("bot.progress"); err == nil {
+		t.Error("expected error for unsupported event")
+	}
+}
+
+func TestRateLimitSuppressesAndReports(t *testing.T) {
+	notifier := &recordingNotifier{}
+	d := NewDispatcher(notifier, 2)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	event := events.NewAccountBannedEvent("Farm", 1, "acc-1", "dev-1")
+	for i := 0; i < 5; i++ {
+		d.handle(event)
+	}
+	if got := len(notifier.sent()); got != 2 {
+		t.Fatalf("sent %d notifications within a minute, want 2", got)
+	}
+
+	// Once the window passes the next message reports what was dropped
+	now = now.Add(61 * time.Second)
+	d.handle(event)
+
+	sent := notifier.sent()
+	if len(sent) != 3 {
+		t.Fatalf("sent %d notifications, want 3", len(sent))
+	}
+	if !strings.Contains(sent[2].Text, "3 earlier notifications suppressed") {
+		t.Errorf("expected suppressed count in %q", sent[2].Text)
+	}
+}
+
+func TestNotifierErrorIsNotFatal(t *testing.T) {
+	notifier := &recordingNotifier{err: errors.New("boom")}
+	d := NewDispatcher(notifier, 0)
+
+	d.handle(events.NewGroupCompletedEvent("Farm", 1))
+	d.handle(events.NewGroupCompletedEvent("Farm", 2))
+
+	if got := len(notifier.sent()); got != 2 {
+		t.Errorf("sent %d notifications, want 2", got)
+	}
+}
+
+func TestDispatcherSubscribesToBus(t *testing.T) {
+	bus := events.NewEventBus(100)
+	defer bus.Stop()
+
+	notifier := &recordingNotifier{}
+	d := NewDispatcher(notifier, 0)
+	d.Subscribe(bus, []events.EventType{events.EventTypeGodPackFound})
+
+	bus.Publish(events.NewGroupCompletedEvent("Farm", 1)) // Not subscribed
+	bus.Publish(events.NewGodPackFoundEvent("Farm", 1, "acc-1", "dev-1", ""))
+
+	deadline := time.Now().Add(time.Second)
+	for len(notifier.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	d.Stop()
+
+	sent := notifier.sent()
+	if len(sent) != 1 || sent[0].EventType != events.EventTypeGodPackFound {
+		t.Errorf("sent = %+v, want one god pack notification", sent)
+	}
+}
+
+func TestDiscordWebhookPostsEmbed(t *testing.T) {
+	var payload discordPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhook := NewDiscordWebhook(server.URL, "1234")
+	err := webhook.Notify(context.Background(), Message{
+		EventType: events.EventTypeGodPackFound,
+		Title:     "God pack",
+		Text:      "details",
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	if payload.Content != "<@1234>" {
+		t.Errorf("content = %q", payload.Content)
+	}
+	if len(payload.Embeds) != 1 || payload.Embeds[0].Title != "God pack" || payload.Embeds[0].Description != "details" {
+		t.Errorf("embeds = %+v", payload.Embeds)
+	}
+}
+
+func TestDiscordWebhookReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := NewDiscordWebhook(server.URL, "").Notify(context.Background(), Message{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("err = %v, want 429 error", err)
+	}
+}
@@ -0,0 +1,129 @@
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrorType is a known kind of bot error. The values are the error_type names stored in error_log.
+type ErrorType string
+
+const (
+	ErrorCommunication ErrorType = "communication" // ADB disconnected, emulator crashed
+	ErrorStuck         ErrorType = "stuck"         // Bot stuck on the same screen
+	ErrorNoResponse    ErrorType = "no_response"   // Game not responding
+	ErrorPopup         ErrorType = "popup"         // Unexpected popup (level up, rewards, etc.)
+	ErrorMaintenance   ErrorType = "maintenance"   // Maintenance mode
+	ErrorUpdate        ErrorType = "update"        // Update required
+	ErrorTitleScreen   ErrorType = "title_screen"  // Returned to the title screen unexpectedly
+	ErrorTimeout       ErrorType = "timeout"       // Action exceeded its maximum runtime
+)
+
+// Types lists the known error types
+func Types() []ErrorType {
+	return []ErrorType{
+		ErrorCommunication, ErrorStuck, ErrorNoResponse, ErrorPopup,
+		ErrorMaintenance, ErrorUpdate, ErrorTitleScreen, ErrorTimeout,
+	}
+}
+
+// Valid reports whether t is a known error type
+func (t ErrorType) Valid() bool {
+	for _, known := range Types() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the error type named name
+func Parse(name string) (ErrorType, error) {
+	if t := ErrorType(name); t.Valid() {
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown error type '%s' (must be one of %v)", name, Types())
+}
+
+// Bot is the bot a recovery runs on; recoveries assert the richer interfaces they need
+// (e.g. actions.BotInterface)
+type Bot interface {
+	Instance() int
+}
+
+// Func recovers a bot from an error of the type it's registered for. Returning nil means the
+// bot is back in a state where the failed step can run again.
+type Func func(bot Bot) error
+
+var (
+	mu         sync.RWMutex
+	recoveries = make(map[ErrorType]Func)
+)
+
+// Register sets the recovery for an error type, replacing any registered before. A nil fn
+// removes it.
+func Register(errorType ErrorType, fn Func) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if fn == nil {
+		delete(recoveries, errorType)
+		return
+	}
+	recoveries[errorType] = fn
+}
+
+// Lookup returns the recovery registered for an error type
+func Lookup(errorType ErrorType) (Func, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	fn, exists := recoveries[errorType]
+	return fn, exists
+}
+
+// Registered lists the error types that have a recovery
+func Registered() []ErrorType {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	types := make([]ErrorType, 0, len(recoveries))
+	for errorType := range recoveries {
+		types = append(types, errorType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Error is an error of a known type, reported by a routine or sentry that detected it
+type Error struct {
+	Type ErrorType
+	Err  error
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("%s: %v", e.Type, e.Err) }
+func (e *Error) Unwrap() error { return e.Err }
+
+// Wrap marks err as an error of a known type (nil stays nil)
+func Wrap(errorType ErrorType, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Type: errorType, Err: err}
+}
+
+// New creates an error of a known type
+func New(errorType ErrorType, message string) error {
+	return &Error{Type: errorType, Err: errors.New(message)}
+}
+
+// TypeOf returns the type of err, if it (or an error it wraps) is an Error
+func TypeOf(err error) (ErrorType, bool) {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed.Type, true
+	}
+	return "", false
+}
@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// CapturedTemplatesFile is the YAML file (inside the template config directory)
+// that templates captured from the GUI are appended to
+const CapturedTemplatesFile = "captured.yaml"
+
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateTemplateName checks that a name can be used both as a template name and a filename
+func ValidateTemplateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if !templateNamePattern.MatchString(name) {
+		return fmt.Errorf("template name '%s' may only contain letters, digits, '_' and '-'", name)
+	}
+	return nil
+}
+
+// SaveCapturedTemplate saves img as <basePath>/<name>.png, appends its definition to
+// CapturedTemplatesFile in configDir so it survives a template reload, and registers it
+// so routines can use it immediately. The name must not already exist.
+func (tr *TemplateRegistry) SaveCapturedTemplate(img image.Image, name string, threshold float64, configDir string) (cv.Template, error) {
+	if err := ValidateTemplateName(name); err != nil {
+		return cv.Template{}, err
+	}
+	if tr.Has(name) {
+		return cv.Template{}, fmt.Errorf("template '%s' already exists", name)
+	}
+	if threshold <= 0 {
+		threshold = 0.8
+	}
+
+	relPath := name + ".png"
+	imagePath := filepath.Join(tr.basePath, relPath)
+	if _, err := os.Stat(imagePath); err == nil {
+		return cv.Template{}, fmt.Errorf("template image %s already exists", imagePath)
+	}
+
+	if err := os.MkdirAll(tr.basePath, 0755); err != nil {
+		return cv.Template{}, fmt.Errorf("failed to create template directory: %w", err)
+	}
+	if err := cv.SavePNG(img, imagePath); err != nil {
+		return cv.Template{}, err
+	}
+
+	def := TemplateDefinition{
+		Name:      name,
+		Path:      relPath,
+		Threshold: threshold,
+	}
+	if err := appendTemplateDefinition(filepath.Join(configDir, CapturedTemplatesFile), def); err != nil {
+		os.Remove(imagePath)
+		return cv.Template{}, err
+	}
+
+	template := cv.Template{
+		Name:      name,
+		Path:      imagePath,
+		Threshold: threshold,
+	}
+	if err := tr.Register(template); err != nil {
+		return cv.Template{}, err
+	}
+	return template, nil
+}
+
+// appendTemplateDefinition adds a definition to a template YAML file, creating it if needed
+func appendTemplateDefinition(path string, def TemplateDefinition) error {
+	var file TemplateFile
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read template file %s: %w", path, err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse template file %s: %w", path, err)
+		}
+	}
+
+	for _, existing := range file.Templates {
+		if existing.Name == def.Name {
+			return fmt.Errorf("template '%s' already defined in %s", def.Name, path)
+		}
+	}
+	file.Templates = append(file.Templates, def)
+
+	data, err = yaml.Marshal(&file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create template config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write template file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package templates
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveCapturedTemplate(t *testing.T) {
+	imageDir := t.TempDir()
+	configDir := t.TempDir()
+	registry := NewTemplateRegistry(imageDir)
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+
+	template, err := registry.SaveCapturedTemplate(img, "shop_button", 0, configDir)
+	if err != nil {
+		t.Fatalf("SaveCapturedTemplate failed: %v", err)
+	}
+	if template.Threshold != 0.8 {
+		t.Errorf("Expected default threshold 0.8, got %v", template.Threshold)
+	}
+	if !registry.Has("shop_button") {
+		t.Error("Captured template should be registered immediately")
+	}
+	if _, _, err := registry.ImageCache().Get("shop_button"); err != nil {
+		t.Errorf("Captured template image should be loadable: %v", err)
+	}
+
+	// Duplicate names are rejected
+	if _, err := registry.SaveCapturedTemplate(img, "shop_button", 0, configDir); err == nil {
+		t.Error("Expected an error for a duplicate template name")
+	}
+
+	// Illegal filenames are rejected
+	if _, err := registry.SaveCapturedTemplate(img, "../escape", 0, configDir); err == nil {
+		t.Error("Expected an error for an illegal template name")
+	}
+
+	// A reload from the config directory brings the template back
+	reloaded := NewTemplateRegistry(imageDir)
+	if err := reloaded.LoadFromDirectory(configDir); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	got, ok := reloaded.Get("shop_button")
+	if !ok {
+		t.Fatal("Captured template missing after reload")
+	}
+	if got.Path != filepath.Join(imageDir, "shop_button.png") {
+		t.Errorf("Unexpected template path after reload: %s", got.Path)
+	}
+}
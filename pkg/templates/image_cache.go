@@ -1,8 +1,10 @@
 package templates
 
 import (
+	"container/list"
 	"fmt"
 	"image"
+	"image/draw"
 	"image/png"
 	"os"
 	"sync"
@@ -10,20 +12,29 @@ import (
 	"jordanella.com/pocket-tcg-go/internal/cv"
 )
 
+// DefaultMaxCacheBytes is the default memory limit for decoded template images
+const DefaultMaxCacheBytes int64 = 256 << 20
+
 // CachedTemplate extends cv.Template with image caching capabilities
 type CachedTemplate struct {
 	cv.Template
-	image       *image.RGBA  // Cached image data
-	mu          sync.RWMutex // Protects image field
-	preload     bool         // Whether to preload image at startup
-	unloadAfter bool         // Whether to unload after use
-	useCount    int          // Number of times loaded (for stats)
+	image       *image.RGBA   // Cached image data (nil when not loaded)
+	element     *list.Element // Position in the LRU list while loaded
+	loading     chan struct{} // Closed when an in-flight load finishes
+	preload     bool          // Whether to preload image at startup
+	unloadAfter bool          // Whether to unload after use
+	useCount    int           // Number of times loaded (for stats)
 }
 
-// ImageCache manages template image loading and caching
+// ImageCache manages template image loading and caching.
+// Decoded images are shared by every bot using the registry and evicted
+// least-recently-used first once the cache exceeds its memory limit.
 type ImageCache struct {
 	templates map[string]*CachedTemplate
-	mu        sync.RWMutex
+	lru       *list.List // Loaded templates, most recently used first
+	bytes     int64      // Memory held by loaded images
+	maxBytes  int64      // Memory limit (0 = unlimited)
+	mu        sync.Mutex
 	stats     CacheStats
 }
 
@@ -33,85 +44,127 @@ type CacheStats struct {
 	Misses      int64 // Cache misses (had to load)
 	Loads       int64 // Total load operations
 	Unloads     int64 // Total unload operations
+	Evictions   int64 // Images dropped to stay under the memory limit
 	PreloadFail int64 // Failed preloads
+	Bytes       int64 // Memory held by decoded images
+	MaxBytes    int64 // Memory limit (0 = unlimited)
+	Loaded      int   // Number of decoded images in memory
 }
 
-// NewImageCache creates a new image cache
+// NewImageCache creates a new image cache limited to DefaultMaxCacheBytes
 func NewImageCache() *ImageCache {
+	return NewImageCacheWithLimit(DefaultMaxCacheBytes)
+}
+
+// NewImageCacheWithLimit creates a new image cache limited to maxBytes of decoded images (0 = unlimited)
+func NewImageCacheWithLimit(maxBytes int64) *ImageCache {
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
 	return &ImageCache{
 		templates: make(map[string]*CachedTemplate),
+		lru:       list.New(),
+		maxBytes:  maxBytes,
 	}
 }
 
-// Register adds a template to the cache
-func (ic *ImageCache) Register(template cv.Template, preload, unloadAfter bool) error {
+// SetMaxBytes changes the memory limit (0 = unlimited), evicting images if needed
+func (ic *ImageCache) SetMaxBytes(maxBytes int64) {
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+
 	ic.mu.Lock()
 	defer ic.mu.Unlock()
 
+	ic.maxBytes = maxBytes
+	ic.evictLocked(nil)
+}
+
+// Register adds a template to the cache, replacing (and unloading) any template with the same name
+func (ic *ImageCache) Register(template cv.Template, preload, unloadAfter bool) error {
+	ic.mu.Lock()
+	if old, ok := ic.templates[template.Name]; ok {
+		ic.unloadLocked(old)
+	}
+
 	cached := &CachedTemplate{
 		Template:    template,
 		preload:     preload,
 		unloadAfter: unloadAfter,
 	}
+	ic.templates[template.Name] = cached
+	ic.mu.Unlock()
 
 	// Preload if requested
 	if preload {
-		if err := cached.load(); err != nil {
-			ic.stats.PreloadFail++
+		if err := ic.preload(cached); err != nil {
 			return fmt.Errorf("failed to preload template %s: %w", template.Name, err)
 		}
-		ic.stats.Loads++
 	}
 
-	ic.templates[template.Name] = cached
 	return nil
 }
 
+// Remove drops a template and its image from the cache
+func (ic *ImageCache) Remove(name string) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if cached, ok := ic.templates[name]; ok {
+		ic.unloadLocked(cached)
+		delete(ic.templates, name)
+	}
+}
+
+// Clear drops every template and image from the cache
+func (ic *ImageCache) Clear() {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	ic.templates = make(map[string]*CachedTemplate)
+	ic.lru.Init()
+	ic.bytes = 0
+}
+
 // Get retrieves a template and its image, loading if necessary
 func (ic *ImageCache) Get(name string) (*image.RGBA, cv.Template, error) {
-	ic.mu.RLock()
+	ic.mu.Lock()
 	cached, ok := ic.templates[name]
-	ic.mu.RUnlock()
-
 	if !ok {
+		ic.mu.Unlock()
 		return nil, cv.Template{}, fmt.Errorf("template '%s' not found in cache", name)
 	}
 
-	// Get or load image
-	img, err := cached.getOrLoad()
-	if err != nil {
-		return nil, cv.Template{}, err
-	}
-
-	// Update stats
-	ic.mu.Lock()
-	if cached.image != nil && cached.useCount > 0 {
+	if img := cached.image; img != nil {
 		ic.stats.Hits++
-	} else {
-		ic.stats.Misses++
+		ic.lru.MoveToFront(cached.element)
+		ic.mu.Unlock()
+		return img, cached.Template, nil
 	}
+
+	ic.stats.Misses++
 	ic.mu.Unlock()
 
+	img, err := ic.load(cached)
+	if err != nil {
+		return nil, cv.Template{}, err
+	}
 	return img, cached.Template, nil
 }
 
 // Release unloads a template image if unloadAfter is set
 func (ic *ImageCache) Release(name string) error {
-	ic.mu.RLock()
-	cached, ok := ic.templates[name]
-	ic.mu.RUnlock()
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
 
+	cached, ok := ic.templates[name]
 	if !ok {
 		return fmt.Errorf("template '%s' not found in cache", name)
 	}
 
-	if cached.unloadAfter {
-		if err := cached.unload(); err != nil {
-			return err
-		}
-		ic.mu.Lock()
+	if cached.unloadAfter && ic.unloadLocked(cached) {
 		ic.stats.Unloads++
-		ic.mu.Unlock()
 	}
 
 	return nil
@@ -119,26 +172,19 @@ func (ic *ImageCache) Release(name string) error {
 
 // PreloadAll loads all templates marked for preloading
 func (ic *ImageCache) PreloadAll() error {
-	ic.mu.RLock()
+	ic.mu.Lock()
 	templates := make([]*CachedTemplate, 0, len(ic.templates))
 	for _, t := range ic.templates {
 		if t.preload {
 			templates = append(templates, t)
 		}
 	}
-	ic.mu.RUnlock()
+	ic.mu.Unlock()
 
 	var errors []error
 	for _, cached := range templates {
-		if err := cached.load(); err != nil {
+		if err := ic.preload(cached); err != nil {
 			errors = append(errors, fmt.Errorf("template %s: %w", cached.Name, err))
-			ic.mu.Lock()
-			ic.stats.PreloadFail++
-			ic.mu.Unlock()
-		} else {
-			ic.mu.Lock()
-			ic.stats.Loads++
-			ic.mu.Unlock()
 		}
 	}
 
@@ -151,74 +197,132 @@ func (ic *ImageCache) PreloadAll() error {
 
 // UnloadAll unloads all cached images
 func (ic *ImageCache) UnloadAll() {
-	ic.mu.RLock()
-	templates := make([]*CachedTemplate, 0, len(ic.templates))
-	for _, t := range templates {
-		templates = append(templates, t)
-	}
-	ic.mu.RUnlock()
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
 
-	for _, cached := range templates {
-		cached.unload()
-		ic.mu.Lock()
-		ic.stats.Unloads++
-		ic.mu.Unlock()
+	for _, cached := range ic.templates {
+		if ic.unloadLocked(cached) {
+			ic.stats.Unloads++
+		}
 	}
 }
 
 // Stats returns cache statistics
 func (ic *ImageCache) Stats() CacheStats {
-	ic.mu.RLock()
-	defer ic.mu.RUnlock()
-	return ic.stats
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	stats := ic.stats
+	stats.Bytes = ic.bytes
+	stats.MaxBytes = ic.maxBytes
+	stats.Loaded = ic.lru.Len()
+	return stats
 }
 
-// CachedTemplate methods
+// preload loads a template image, counting failures in PreloadFail
+func (ic *ImageCache) preload(cached *CachedTemplate) error {
+	if _, err := ic.load(cached); err != nil {
+		ic.mu.Lock()
+		ic.stats.PreloadFail++
+		ic.mu.Unlock()
+		return err
+	}
+	return nil
+}
 
-// getOrLoad returns the cached image or loads it if not cached
-func (ct *CachedTemplate) getOrLoad() (*image.RGBA, error) {
-	// Fast path: image already loaded
-	ct.mu.RLock()
-	if ct.image != nil {
-		defer ct.mu.RUnlock()
-		return ct.image, nil
+// load returns the template image, decoding it if needed.
+// Concurrent loads of the same template wait for a single decode.
+func (ic *ImageCache) load(cached *CachedTemplate) (*image.RGBA, error) {
+	ic.mu.Lock()
+	for {
+		if img := cached.image; img != nil {
+			ic.lru.MoveToFront(cached.element)
+			ic.mu.Unlock()
+			return img, nil
+		}
+		if cached.loading == nil {
+			break
+		}
+
+		// Another goroutine is decoding this template - wait for it
+		loading := cached.loading
+		ic.mu.Unlock()
+		<-loading
+		ic.mu.Lock()
 	}
-	ct.mu.RUnlock()
 
-	// Slow path: need to load
-	ct.mu.Lock()
-	defer ct.mu.Unlock()
+	loading := make(chan struct{})
+	cached.loading = loading
+	ic.mu.Unlock()
+
+	img, err := decodeTemplate(cached.Path)
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
 
-	// Double-check after acquiring write lock
-	if ct.image != nil {
-		return ct.image, nil
+	cached.loading = nil
+	close(loading)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return ct.loadUnsafe()
-}
+	// The template may have been removed or replaced while decoding
+	if ic.templates[cached.Name] == cached {
+		cached.image = img
+		cached.element = ic.lru.PushFront(cached)
+		cached.useCount++
+		ic.bytes += imageBytes(img)
+		ic.stats.Loads++
+		ic.evictLocked(cached)
+	}
 
-// load loads the template image (thread-safe)
-func (ct *CachedTemplate) load() error {
-	ct.mu.Lock()
-	defer ct.mu.Unlock()
+	return img, nil
+}
 
-	if ct.image != nil {
-		return nil // Already loaded
+// evictLocked unloads least-recently-used images until the cache is under its limit.
+// keep is never evicted so a single oversized image can still be used. Caller must hold ic.mu.
+func (ic *ImageCache) evictLocked(keep *CachedTemplate) {
+	if ic.maxBytes == 0 {
+		return
 	}
 
-	_, err := ct.loadUnsafe()
-	return err
+	for element := ic.lru.Back(); element != nil && ic.bytes > ic.maxBytes; {
+		cached := element.Value.(*CachedTemplate)
+		element = element.Prev()
+		if cached == keep {
+			continue
+		}
+		ic.unloadLocked(cached)
+		ic.stats.Evictions++
+	}
 }
 
-// loadUnsafe loads the image without locking (caller must hold lock)
-func (ct *CachedTemplate) loadUnsafe() (*image.RGBA, error) {
-	// Check if file exists
-	if _, err := os.Stat(string(ct.Path)); os.IsNotExist(err) {
-		return nil, fmt.Errorf("template image not found: %s", ct.Path)
+// unloadLocked drops a template's image and reports whether one was loaded. Caller must hold ic.mu.
+func (ic *ImageCache) unloadLocked(cached *CachedTemplate) bool {
+	if cached.image == nil {
+		return false
 	}
 
-	// Open and decode PNG
-	file, err := os.Open(string(ct.Path))
+	// No need to close image.RGBA - Go GC will handle it once bots stop using it
+	ic.bytes -= imageBytes(cached.image)
+	ic.lru.Remove(cached.element)
+	cached.image = nil
+	cached.element = nil
+	return true
+}
+
+// imageBytes returns the memory held by an image's pixel data
+func imageBytes(img *image.RGBA) int64 {
+	return int64(len(img.Pix))
+}
+
+// decodeTemplate loads a template PNG as RGBA
+func decodeTemplate(path string) (*image.RGBA, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("template image not found: %s", path)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to open template: %w", err)
 	}
@@ -230,41 +334,20 @@ func (ct *CachedTemplate) loadUnsafe() (*image.RGBA, error) {
 	}
 
 	// Convert to RGBA
-	var rgba *image.RGBA
-	if r, ok := img.(*image.RGBA); ok {
-		rgba = r
-	} else {
-		bounds := img.Bounds()
-		rgba = image.NewRGBA(bounds)
-		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			for x := bounds.Min.X; x < bounds.Max.X; x++ {
-				rgba.Set(x, y, img.At(x, y))
-			}
-		}
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba, nil
 	}
-
-	ct.image = rgba
-	ct.useCount++
-
-	return ct.image, nil
-}
-
-// unload releases the template image (thread-safe)
-func (ct *CachedTemplate) unload() error {
-	ct.mu.Lock()
-	defer ct.mu.Unlock()
-
-	if ct.image != nil {
-		// No need to close image.RGBA - Go GC will handle it
-		ct.image = nil
-	}
-
-	return nil
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+	return rgba, nil
 }
 
 // IsLoaded returns true if the image is currently in memory
-func (ct *CachedTemplate) IsLoaded() bool {
-	ct.mu.RLock()
-	defer ct.mu.RUnlock()
-	return ct.image != nil
+func (ic *ImageCache) IsLoaded(name string) bool {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	cached, ok := ic.templates[name]
+	return ok && cached.image != nil
 }
@@ -0,0 +1,180 @@
+package templates
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// writeTemplatePNG writes a size x size NRGBA PNG and returns its template
+func writeTemplatePNG(t testing.TB, dir, name string, size int) cv.Template {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	path := filepath.Join(dir, name+".png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, img); err != nil {
+		t.Fatalf("Failed to encode %s: %v", path, err)
+	}
+
+	return cv.Template{Name: name, Path: path, Threshold: 0.8}
+}
+
+func TestImageCacheHitsAndMisses(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewTemplateRegistry(dir)
+	if err := registry.Register(writeTemplatePNG(t, dir, "button", 10)); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		img, _, err := registry.ImageCache().Get("button")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if img.Bounds().Dx() != 10 {
+			t.Errorf("Expected 10px wide image, got %d", img.Bounds().Dx())
+		}
+	}
+
+	stats := registry.CacheStats()
+	if stats.Misses != 1 || stats.Hits != 2 || stats.Loads != 1 {
+		t.Errorf("Expected 1 miss, 2 hits, 1 load, got %+v", stats)
+	}
+	if stats.Bytes != 10*10*4 {
+		t.Errorf("Expected %d bytes, got %d", 10*10*4, stats.Bytes)
+	}
+}
+
+func TestImageCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	imageSize := int64(10 * 10 * 4)
+	cache := NewImageCacheWithLimit(2 * imageSize)
+
+	for _, name := range []string{"a", "b", "c"} {
+		if err := cache.Register(writeTemplatePNG(t, dir, name, 10), false, false); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	get := func(name string) {
+		if _, _, err := cache.Get(name); err != nil {
+			t.Fatalf("Get(%s) failed: %v", name, err)
+		}
+	}
+
+	get("a")
+	get("b")
+	get("a") // b is now least recently used
+	get("c")
+
+	if cache.IsLoaded("b") {
+		t.Error("Expected 'b' to be evicted")
+	}
+	if !cache.IsLoaded("a") || !cache.IsLoaded("c") {
+		t.Error("Expected 'a' and 'c' to stay loaded")
+	}
+
+	stats := cache.Stats()
+	if stats.Evictions != 1 || stats.Bytes != 2*imageSize || stats.Loaded != 2 {
+		t.Errorf("Expected 1 eviction and 2 images in memory, got %+v", stats)
+	}
+
+	// Lowering the limit evicts immediately
+	cache.SetMaxBytes(imageSize)
+	if stats := cache.Stats(); stats.Loaded != 1 || stats.Bytes != imageSize {
+		t.Errorf("Expected 1 image after lowering the limit, got %+v", stats)
+	}
+}
+
+func TestImageCacheConcurrentGetDecodesOnce(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewImageCache()
+	if err := cache.Register(writeTemplatePNG(t, dir, "shared", 64), false, false); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := cache.Get("shared"); err != nil {
+				t.Errorf("Get failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads := cache.Stats().Loads; loads != 1 {
+		t.Errorf("Expected 1 decode, got %d", loads)
+	}
+}
+
+func TestTemplateRegistryRemoveDropsImage(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewTemplateRegistry(dir)
+	registry.Register(writeTemplatePNG(t, dir, "gone", 10))
+
+	if _, _, err := registry.ImageCache().Get("gone"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	registry.Remove("gone")
+
+	if _, _, err := registry.ImageCache().Get("gone"); err == nil {
+		t.Error("Expected error for removed template")
+	}
+	if bytes := registry.CacheStats().Bytes; bytes != 0 {
+		t.Errorf("Expected 0 bytes after remove, got %d", bytes)
+	}
+}
+
+// BenchmarkTemplateDecodePerCall decodes the template on every lookup (no shared cache)
+func BenchmarkTemplateDecodePerCall(b *testing.B) {
+	template := writeTemplatePNG(b, b.TempDir(), "bench", 64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeTemplate(template.Path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkTemplateCacheGet looks the template up through the shared cache from 10 "bots"
+func BenchmarkTemplateCacheGet(b *testing.B) {
+	template := writeTemplatePNG(b, b.TempDir(), "bench", 64)
+	cache := NewImageCache()
+	if err := cache.Register(template, true, false); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetParallelism(10)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, _, err := cache.Get("bench"); err != nil {
+				panic(fmt.Sprintf("Get failed: %v", err))
+			}
+		}
+	})
+}
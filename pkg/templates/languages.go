@@ -0,0 +1,218 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"jordanella.com/pocket-tcg-go/internal/cv"
+)
+
+// LanguagesDir is the subdirectory of a template directory holding language sets, one
+// directory per language (e.g. registry/languages/ja/*.yaml). A set only overrides the
+// default templates whose text differs in that language; image paths are relative to the
+// registry's base path like the default set's.
+const LanguagesDir = "languages"
+
+// LanguageTemplateName returns the registry name of a template's variant for a language
+func LanguageTemplateName(language, templateName string) string {
+	return "@" + language + "/" + templateName
+}
+
+// TemplateLanguage splits a registry name into the language set it belongs to ("" for the
+// default set) and the template name, e.g. to report which variant a match used
+func TemplateLanguage(name string) (language, templateName string) {
+	if strings.HasPrefix(name, "@") {
+		if i := strings.Index(name, "/"); i > 1 {
+			return name[1:i], name[i+1:]
+		}
+	}
+	return "", name
+}
+
+// loadLanguageDirectories loads each subdirectory of dirPath as the language set it is named after
+func (tr *TemplateRegistry) loadLanguageDirectories(dirPath string) error {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to read language directory %s: %w", dirPath, err)
+	}
+
+	var loadErrors []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if err := tr.LoadLanguageTemplates(entry.Name(), filepath.Join(dirPath, entry.Name())); err != nil {
+			loadErrors = append(loadErrors, fmt.Errorf("language %s: %w", entry.Name(), err))
+		}
+	}
+
+	if len(loadErrors) > 0 {
+		return fmt.Errorf("failed to load %d language sets (first error): %w", len(loadErrors), loadErrors[0])
+	}
+	return nil
+}
+
+// LoadLanguageTemplates loads the template YAML files in dirPath as language's template set,
+// replacing any set loaded for the language before. The templates are registered under
+// LanguageTemplateName; use ResolveForLanguage or ForLanguage to look them up. The set must
+// be consistent with the default templates (see validateLanguageSet) or it is not loaded.
+func (tr *TemplateRegistry) LoadLanguageTemplates(language, dirPath string) error {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to read template directory %s: %w", dirPath, err)
+	}
+
+	tr.removeLanguageTemplates(language)
+
+	loaded := make(map[string]bool)
+	var problems []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// Only process .yaml and .yml files
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		names, err := tr.loadFile(filepath.Join(dirPath, entry.Name()), tr.basePath, func(name string) string {
+			return LanguageTemplateName(language, name)
+		})
+		for _, name := range names {
+			if loaded[name] {
+				problems = append(problems, fmt.Sprintf("template '%s' is declared more than once", name))
+			}
+			loaded[name] = true
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("file %s: %v", entry.Name(), err))
+		}
+	}
+
+	tr.mu.Lock()
+	tr.languageTemplates[language] = loaded
+	tr.mu.Unlock()
+
+	problems = append(problems, tr.validateLanguageSet(language)...)
+	if len(problems) > 0 {
+		tr.removeLanguageTemplates(language)
+		return fmt.Errorf("inconsistent language set: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateLanguageSet checks that every template in a language set overrides a default
+// template and has an image. Returns the problems found.
+func (tr *TemplateRegistry) validateLanguageSet(language string) []string {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	names := make([]string, 0, len(tr.languageTemplates[language]))
+	for name := range tr.languageTemplates[language] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		if _, ok := tr.templates[name]; !ok {
+			problems = append(problems, fmt.Sprintf("template '%s' has no default template to override", name))
+		}
+
+		template, ok := tr.templates[LanguageTemplateName(language, name)]
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(template.Path); err != nil {
+			problems = append(problems, fmt.Sprintf("template '%s' image %s is missing", name, template.Path))
+		}
+	}
+	return problems
+}
+
+// removeLanguageTemplates drops the templates loaded for a language
+func (tr *TemplateRegistry) removeLanguageTemplates(language string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	for name := range tr.languageTemplates[language] {
+		scoped := LanguageTemplateName(language, name)
+		delete(tr.templates, scoped)
+		if tr.imageCache != nil {
+			tr.imageCache.Remove(scoped)
+		}
+	}
+	delete(tr.languageTemplates, language)
+}
+
+// Languages returns the languages with a loaded template set, sorted
+func (tr *TemplateRegistry) Languages() []string {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	languages := make([]string, 0, len(tr.languageTemplates))
+	for language := range tr.languageTemplates {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// ResolveForLanguage looks a template up for a language: the language's variant (see
+// LoadLanguageTemplates) if its set has one, otherwise the default template. The returned
+// template's Name is the registry name to pass to the CV service.
+func (tr *TemplateRegistry) ResolveForLanguage(language, templateName string) (cv.Template, bool) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	if tr.languageTemplates[language][templateName] {
+		if template, ok := tr.templates[LanguageTemplateName(language, templateName)]; ok {
+			return template, true
+		}
+	}
+
+	template, ok := tr.templates[templateName]
+	return template, ok
+}
+
+// LanguageView looks templates up in a registry for one language, falling back to the
+// default template where the language has no variant
+type LanguageView struct {
+	registry *TemplateRegistry
+	language string
+}
+
+// ForLanguage returns a view that resolves templates for language
+func (tr *TemplateRegistry) ForLanguage(language string) LanguageView {
+	return LanguageView{registry: tr, language: language}
+}
+
+// Language returns the language the view resolves templates for
+func (v LanguageView) Language() string {
+	return v.language
+}
+
+// Get retrieves a template by name, preferring the language's variant
+func (v LanguageView) Get(name string) (cv.Template, bool) {
+	return v.registry.ResolveForLanguage(v.language, name)
+}
+
+// MustGet retrieves a template by name and panics if not found
+func (v LanguageView) MustGet(name string) cv.Template {
+	template, ok := v.Get(name)
+	if !ok {
+		panic(fmt.Sprintf("template '%s' not found in registry", name))
+	}
+	return template
+}
+
+// Has checks if a template exists in the registry
+func (v LanguageView) Has(name string) bool {
+	_, ok := v.Get(name)
+	return ok
+}
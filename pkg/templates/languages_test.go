@@ -0,0 +1,107 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveForLanguage(t *testing.T) {
+	imageDir := t.TempDir()
+	registryDir := t.TempDir()
+	jaDir := filepath.Join(registryDir, LanguagesDir, "ja")
+	if err := os.MkdirAll(jaDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	writeFile(registryDir, "ui.yaml", `
+templates:
+  - name: Confirm
+    path: confirm.png
+  - name: Back
+    path: back.png
+`)
+	writeFile(jaDir, "ui.yaml", `
+templates:
+  - name: Confirm
+    path: ja/confirm.png
+    threshold: 0.9
+`)
+	if err := os.MkdirAll(filepath.Join(imageDir, "ja"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(imageDir, "ja/confirm.png", "")
+
+	tr := NewTemplateRegistry(imageDir).WithoutImageCache()
+	if err := tr.LoadFromDirectory(registryDir); err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+	if languages := tr.Languages(); len(languages) != 1 || languages[0] != "ja" {
+		t.Fatalf("Expected the ja language set, got %v", languages)
+	}
+
+	// The language's variant wins, and its registry name tells which set matched
+	ja := tr.ForLanguage("ja")
+	template, ok := ja.Get("Confirm")
+	if !ok || template.Name != LanguageTemplateName("ja", "Confirm") || template.Threshold != 0.9 {
+		t.Fatalf("Expected ja variant of Confirm, got %+v (found %v)", template, ok)
+	}
+	if language, name := TemplateLanguage(template.Name); language != "ja" || name != "Confirm" {
+		t.Errorf("TemplateLanguage(%q) = %q, %q", template.Name, language, name)
+	}
+
+	// Templates the language does not override fall back to the default set
+	if template, ok := ja.Get("Back"); !ok || template.Name != "Back" {
+		t.Errorf("Expected default Back, got %+v (found %v)", template, ok)
+	}
+	if language, _ := TemplateLanguage("Back"); language != "" {
+		t.Errorf("Expected default set for Back, got %q", language)
+	}
+
+	// Other languages and plain lookups see the default template
+	if template, _ := tr.ForLanguage("fr").Get("Confirm"); template.Name != "Confirm" {
+		t.Errorf("Expected default Confirm for fr, got %+v", template)
+	}
+	if template, _ := tr.Get("Confirm"); template.Path != filepath.Join(imageDir, "confirm.png") {
+		t.Errorf("Language templates must not replace default ones, got %+v", template)
+	}
+}
+
+func TestLoadLanguageTemplatesRejectsInconsistentSet(t *testing.T) {
+	imageDir := t.TempDir()
+	jaDir := t.TempDir()
+
+	tr := NewTemplateRegistry(imageDir).WithoutImageCache()
+	tr.Register(tr.GetOrDefault("Confirm", 0.8))
+
+	// Overrides a template with no default, and its image is missing
+	if err := os.WriteFile(filepath.Join(jaDir, "ui.yaml"), []byte(`
+templates:
+  - name: Unknown
+    path: ja/unknown.png
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := tr.LoadLanguageTemplates("ja", jaDir)
+	if err == nil {
+		t.Fatal("Expected an inconsistent language set to be rejected")
+	}
+	for _, want := range []string{"no default template", "is missing"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error, got: %v", want, err)
+		}
+	}
+
+	// A rejected set is not loaded
+	if len(tr.Languages()) != 0 || tr.Has(LanguageTemplateName("ja", "Unknown")) {
+		t.Error("Expected the rejected language set to be removed")
+	}
+}
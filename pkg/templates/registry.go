@@ -16,6 +16,12 @@ type TemplateRegistry struct {
 	templates  map[string]cv.Template
 	basePath   string      // Base path for template image files
 	imageCache *ImageCache // Optional: for caching loaded images
+
+	// Templates each routine ships itself (routine name -> template names), see LoadRoutineTemplates
+	routineTemplates map[string]map[string]bool
+
+	// Language-specific template variants (language -> template names), see LoadLanguageTemplates
+	languageTemplates map[string]map[string]bool
 }
 
 // TemplateDefinition represents a template in the YAML file
@@ -46,9 +52,11 @@ type TemplateFile struct {
 // basePath is the root directory where template image files are stored
 func NewTemplateRegistry(basePath string) *TemplateRegistry {
 	return &TemplateRegistry{
-		templates:  make(map[string]cv.Template),
-		basePath:   basePath,
-		imageCache: NewImageCache(),
+		templates:        make(map[string]cv.Template),
+		basePath:         basePath,
+		imageCache:       NewImageCache(),
+		routineTemplates:  make(map[string]map[string]bool),
+		languageTemplates: make(map[string]map[string]bool),
 	}
 }
 
@@ -58,33 +66,60 @@ func (tr *TemplateRegistry) WithoutImageCache() *TemplateRegistry {
 	return tr
 }
 
+// WithMaxCacheBytes limits the memory used by decoded template images (0 = unlimited)
+func (tr *TemplateRegistry) WithMaxCacheBytes(maxBytes int64) *TemplateRegistry {
+	tr.SetMaxCacheBytes(maxBytes)
+	return tr
+}
+
+// SetMaxCacheBytes changes the image cache memory limit (0 = unlimited), evicting images if needed
+func (tr *TemplateRegistry) SetMaxCacheBytes(maxBytes int64) {
+	if tr.imageCache != nil {
+		tr.imageCache.SetMaxBytes(maxBytes)
+	}
+}
+
 // LoadFromFile loads templates from a YAML file
 func (tr *TemplateRegistry) LoadFromFile(filePath string) error {
+	_, err := tr.loadFile(filePath, tr.basePath, nil)
+	return err
+}
+
+// loadFile loads templates from a YAML file with image paths relative to imageDir.
+// Templates are registered under scopedName(name) if scopedName is set.
+// Returns the template names as written in the file.
+func (tr *TemplateRegistry) loadFile(filePath, imageDir string, scopedName func(string) string) ([]string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read template file %s: %w", filePath, err)
+		return nil, fmt.Errorf("failed to read template file %s: %w", filePath, err)
 	}
 
 	var templateFile TemplateFile
 	if err := yaml.Unmarshal(data, &templateFile); err != nil {
-		return fmt.Errorf("failed to unmarshal template YAML: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal template YAML: %w", err)
 	}
 
 	tr.mu.Lock()
 	defer tr.mu.Unlock()
 
+	names := make([]string, 0, len(templateFile.Templates))
 	for i, def := range templateFile.Templates {
 		if def.Name == "" {
-			return fmt.Errorf("template %d: name cannot be empty", i+1)
+			return names, fmt.Errorf("template %d: name cannot be empty", i+1)
 		}
 		if def.Path == "" {
-			return fmt.Errorf("template %d (%s): path cannot be empty", i+1, def.Name)
+			return names, fmt.Errorf("template %d (%s): path cannot be empty", i+1, def.Name)
+		}
+
+		name := def.Name
+		if scopedName != nil {
+			name = scopedName(def.Name)
 		}
 
 		// Convert the definition to a cv.Template
 		template := cv.Template{
-			Name:      def.Name,
-			Path:      filepath.Join(tr.basePath, def.Path),
+			Name:      name,
+			Path:      filepath.Join(imageDir, def.Path),
 			Threshold: def.Threshold,
 			Scale:     def.Scale,
 		}
@@ -104,7 +139,8 @@ func (tr *TemplateRegistry) LoadFromFile(filePath string) error {
 			template.Threshold = 0.8
 		}
 
-		tr.templates[def.Name] = template
+		tr.templates[name] = template
+		names = append(names, def.Name)
 
 		// Register with image cache if enabled
 		if tr.imageCache != nil {
@@ -116,7 +152,7 @@ func (tr *TemplateRegistry) LoadFromFile(filePath string) error {
 		}
 	}
 
-	return nil
+	return names, nil
 }
 
 // LoadFromDirectory loads all YAML files from a directory
@@ -148,6 +184,14 @@ func (tr *TemplateRegistry) LoadFromDirectory(dirPath string) error {
 		}
 	}
 
+	// Language sets are checked against the default templates, so load them last
+	languagesDir := filepath.Join(dirPath, LanguagesDir)
+	if info, err := os.Stat(languagesDir); err == nil && info.IsDir() {
+		if err := tr.loadLanguageDirectories(languagesDir); err != nil {
+			loadErrors = append(loadErrors, err)
+		}
+	}
+
 	if len(loadErrors) > 0 {
 		// Return first error but log that there were multiple
 		return fmt.Errorf("failed to load %d template files (first error): %w", len(loadErrors), loadErrors[0])
@@ -156,6 +200,89 @@ func (tr *TemplateRegistry) LoadFromDirectory(dirPath string) error {
 	return nil
 }
 
+// RoutineTemplateName returns the registry name of a template shipped by a routine
+func RoutineTemplateName(routineName, templateName string) string {
+	return routineName + "/" + templateName
+}
+
+// LoadRoutineTemplates loads the template YAML files in dirPath as routineName's own template set,
+// replacing any set loaded for the routine before. Image paths are relative to dirPath. The
+// templates are registered under RoutineTemplateName so they never shadow global templates;
+// use ResolveForRoutine to look a template up for the routine.
+func (tr *TemplateRegistry) LoadRoutineTemplates(routineName, dirPath string) error {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to read template directory %s: %w", dirPath, err)
+	}
+
+	tr.removeRoutineTemplates(routineName)
+
+	loaded := make(map[string]bool)
+	var loadErrors []error
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// Only process .yaml and .yml files
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		names, err := tr.loadFile(filepath.Join(dirPath, entry.Name()), dirPath, func(name string) string {
+			return RoutineTemplateName(routineName, name)
+		})
+		for _, name := range names {
+			loaded[name] = true
+		}
+		if err != nil {
+			loadErrors = append(loadErrors, fmt.Errorf("file %s: %w", entry.Name(), err))
+		}
+	}
+
+	tr.mu.Lock()
+	tr.routineTemplates[routineName] = loaded
+	tr.mu.Unlock()
+
+	if len(loadErrors) > 0 {
+		return fmt.Errorf("failed to load %d template files (first error): %w", len(loadErrors), loadErrors[0])
+	}
+	return nil
+}
+
+// removeRoutineTemplates drops the templates loaded for a routine
+func (tr *TemplateRegistry) removeRoutineTemplates(routineName string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	for name := range tr.routineTemplates[routineName] {
+		scoped := RoutineTemplateName(routineName, name)
+		delete(tr.templates, scoped)
+		if tr.imageCache != nil {
+			tr.imageCache.Remove(scoped)
+		}
+	}
+	delete(tr.routineTemplates, routineName)
+}
+
+// ResolveForRoutine looks a template up for a routine: the routine's own variant (see
+// LoadRoutineTemplates) if it ships one, otherwise the global template. The returned
+// template's Name is the registry name to pass to the CV service.
+func (tr *TemplateRegistry) ResolveForRoutine(routineName, templateName string) (cv.Template, bool) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	if tr.routineTemplates[routineName][templateName] {
+		if template, ok := tr.templates[RoutineTemplateName(routineName, templateName)]; ok {
+			return template, true
+		}
+	}
+
+	template, ok := tr.templates[templateName]
+	return template, ok
+}
+
 // Get retrieves a template by name
 // Returns the template and true if found, or an empty template and false if not found
 func (tr *TemplateRegistry) Get(name string) (cv.Template, bool) {
@@ -200,6 +327,7 @@ func (tr *TemplateRegistry) Register(template cv.Template) error {
 	defer tr.mu.Unlock()
 
 	tr.templates[template.Name] = template
+	tr.registerImage(template)
 	return nil
 }
 
@@ -213,11 +341,20 @@ func (tr *TemplateRegistry) RegisterBatch(templates []cv.Template) error {
 			return fmt.Errorf("template %d: name cannot be empty", i)
 		}
 		tr.templates[template.Name] = template
+		tr.registerImage(template)
 	}
 
 	return nil
 }
 
+// registerImage makes a programmatically registered template loadable through the image cache
+func (tr *TemplateRegistry) registerImage(template cv.Template) {
+	if tr.imageCache != nil {
+		// Without preloading, Register cannot fail
+		tr.imageCache.Register(template, false, false)
+	}
+}
+
 // Has checks if a template exists in the registry
 func (tr *TemplateRegistry) Has(name string) bool {
 	tr.mu.RLock()
@@ -253,6 +390,11 @@ func (tr *TemplateRegistry) Clear() {
 	defer tr.mu.Unlock()
 
 	tr.templates = make(map[string]cv.Template)
+	tr.routineTemplates = make(map[string]map[string]bool)
+	tr.languageTemplates = make(map[string]map[string]bool)
+	if tr.imageCache != nil {
+		tr.imageCache.Clear()
+	}
 }
 
 // Remove removes a template from the registry
@@ -262,9 +404,9 @@ func (tr *TemplateRegistry) Remove(name string) bool {
 
 	if _, ok := tr.templates[name]; ok {
 		delete(tr.templates, name)
-		// Also unload from cache if present
+		// Also drop from cache if present
 		if tr.imageCache != nil {
-			tr.imageCache.Release(name)
+			tr.imageCache.Remove(name)
 		}
 		return true
 	}
@@ -291,7 +433,7 @@ func (tr *TemplateRegistry) UnloadAll() {
 	}
 }
 
-// CacheStats returns image cache statistics
+// CacheStats returns image cache statistics (hits, misses, bytes in memory, ...)
 func (tr *TemplateRegistry) CacheStats() CacheStats {
 	if tr.imageCache == nil {
 		return CacheStats{}
@@ -299,6 +441,25 @@ func (tr *TemplateRegistry) CacheStats() CacheStats {
 	return tr.imageCache.Stats()
 }
 
+// CVRegistry adapts the registry for cv.Service.WithTemplateRegistry so that
+// every bot sharing the registry also shares its decoded template images
+func (tr *TemplateRegistry) CVRegistry() cv.TemplateRegistryInterface {
+	return cvRegistry{tr}
+}
+
+// cvRegistry implements cv.TemplateRegistryInterface
+type cvRegistry struct {
+	*TemplateRegistry
+}
+
+// ImageCache returns the shared image cache, or nil if caching is disabled
+func (r cvRegistry) ImageCache() cv.ImageCacheInterface {
+	if r.imageCache == nil {
+		return nil
+	}
+	return r.imageCache
+}
+
 // Global registry instance (for backward compatibility)
 var globalRegistry *TemplateRegistry
 var once sync.Once
@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveForRoutine(t *testing.T) {
+	globalDir := t.TempDir()
+	routineDir := t.TempDir()
+
+	writeFile := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	writeFile(globalDir, "global.yaml", `
+templates:
+  - name: Confirm
+    path: confirm.png
+  - name: Back
+    path: back.png
+`)
+	writeFile(routineDir, "overlay.yaml", `
+templates:
+  - name: Confirm
+    path: confirm_alt.png
+    threshold: 0.9
+`)
+
+	tr := NewTemplateRegistry(globalDir).WithoutImageCache()
+	if err := tr.LoadFromDirectory(globalDir); err != nil {
+		t.Fatalf("Failed to load global templates: %v", err)
+	}
+	if err := tr.LoadRoutineTemplates("battle", routineDir); err != nil {
+		t.Fatalf("Failed to load routine templates: %v", err)
+	}
+
+	// The routine's own variant wins
+	template, ok := tr.ResolveForRoutine("battle", "Confirm")
+	if !ok || template.Name != RoutineTemplateName("battle", "Confirm") {
+		t.Fatalf("Expected routine variant of Confirm, got %+v (found %v)", template, ok)
+	}
+	if template.Path != filepath.Join(routineDir, "confirm_alt.png") || template.Threshold != 0.9 {
+		t.Errorf("Unexpected routine variant %+v", template)
+	}
+
+	// Templates the routine does not ship fall back to global
+	if template, ok := tr.ResolveForRoutine("battle", "Back"); !ok || template.Name != "Back" {
+		t.Errorf("Expected global Back, got %+v (found %v)", template, ok)
+	}
+
+	// Other routines and plain lookups see the global template
+	if template, ok := tr.ResolveForRoutine("other", "Confirm"); !ok || template.Name != "Confirm" {
+		t.Errorf("Expected global Confirm for other routine, got %+v (found %v)", template, ok)
+	}
+	if template, _ := tr.Get("Confirm"); template.Path != filepath.Join(globalDir, "confirm.png") {
+		t.Errorf("Routine templates must not replace global ones, got %+v", template)
+	}
+
+	// Reloading replaces the routine's previous set
+	os.Remove(filepath.Join(routineDir, "overlay.yaml"))
+	if err := tr.LoadRoutineTemplates("battle", routineDir); err != nil {
+		t.Fatalf("Failed to reload routine templates: %v", err)
+	}
+	if template, _ := tr.ResolveForRoutine("battle", "Confirm"); template.Name != "Confirm" {
+		t.Errorf("Expected global Confirm after reload, got %+v", template)
+	}
+	if tr.Has(RoutineTemplateName("battle", "Confirm")) {
+		t.Error("Expected stale routine template to be removed")
+	}
+}